@@ -46,6 +46,8 @@ func RootCmd() *cobra.Command {
 	cmd.AddCommand(util.VersionCmd())
 
 	cmd.Flags().String("analyzers", "", "filename or url of the analyzers to use")
+	cmd.Flags().String("output", "", "output format: csv, results are printed as text by default")
+	cmd.Flags().String("ignore-list", "", "file listing known failures and warnings, by check title with an optional reason and expiry, to report as informational instead")
 	cmd.Flags().Bool("debug", false, "enable debug logging")
 
 	viper.BindPFlags(cmd.Flags())
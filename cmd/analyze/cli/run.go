@@ -9,10 +9,16 @@ import (
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/troubleshoot/internal/util"
 	analyzer "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	"github.com/replicatedhq/troubleshoot/pkg/convert"
 	"github.com/spf13/viper"
 )
 
 func runAnalyzers(v *viper.Viper, bundlePath string) error {
+	output := v.GetString("output")
+	if output != "" && output != "csv" {
+		return fmt.Errorf("unsupported output format: %q", output)
+	}
+
 	specPath := v.GetString("analyzers")
 
 	specContent := ""
@@ -49,13 +55,33 @@ func runAnalyzers(v *viper.Viper, bundlePath string) error {
 		specContent = string(body)
 	}
 
+	var suppressions []analyzer.Suppression
+	if v.GetString("ignore-list") != "" {
+		suppressions, err = analyzer.LoadSuppressions(v.GetString("ignore-list"))
+		if err != nil {
+			return err
+		}
+	}
+
 	analyzeResults, err := analyzer.DownloadAndAnalyze(bundlePath, specContent)
 	if err != nil {
 		return errors.Wrap(err, "failed to download and analyze bundle")
 	}
+	analyzer.SuppressResults(analyzeResults, suppressions)
+
+	if output == "csv" {
+		formatted, err := convert.ToCSV(convert.FromAnalyzerResult(analyzeResults))
+		if err != nil {
+			return errors.Wrap(err, "failed to format results")
+		}
+		fmt.Printf("%s", formatted)
+		return nil
+	}
 
 	for _, analyzeResult := range analyzeResults {
-		if analyzeResult.IsPass {
+		if analyzeResult.Suppression != nil {
+			fmt.Printf("Info: %s\n %s\n Suppressed: %s\n", analyzeResult.Title, analyzeResult.Message, analyzeResult.Suppression.Summary())
+		} else if analyzeResult.IsPass {
 			fmt.Printf("Pass: %s\n %s\n", analyzeResult.Title, analyzeResult.Message)
 		} else if analyzeResult.IsWarn {
 			fmt.Printf("Warn: %s\n %s\n", analyzeResult.Title, analyzeResult.Message)
@@ -58,6 +58,7 @@ func RootCmd() *cobra.Command {
 	cmd.Flags().Bool("collect-without-permissions", false, "always generate a support bundle, even if it some require additional permissions")
 	cmd.Flags().Bool("debug", false, "enable debug logging")
 	cmd.Flags().String("chroot", "", "Chroot to path")
+	cmd.Flags().String("progress-socket", "", "path of a Unix domain socket to create and publish collection progress events to, as JSON lines")
 
 	// hidden in favor of the `insecure-skip-tls-verify` flag
 	cmd.Flags().Bool("allow-insecure-connections", false, "when set, do not verify TLS certs when retrieving spec and reporting results")
@@ -127,11 +127,29 @@ func runCollect(v *viper.Viper, arg string) error {
 		additionalRedactors.Spec.Redactors = append(additionalRedactors.Spec.Redactors, multidocRedactors.Spec.Redactors...)
 	}
 
+	var progressSocket *collect.ProgressSocket
+	if socketPath := v.GetString("progress-socket"); socketPath != "" {
+		progressSocket, err = collect.ListenProgressSocket(socketPath)
+		if err != nil {
+			return err
+		}
+		defer progressSocket.Close()
+	}
+
 	// make sure we don't block any senders
 	progressCh := make(chan interface{})
-	defer close(progressCh)
+	progressDone := make(chan struct{})
+	defer func() {
+		close(progressCh)
+		// wait for the last events to be published before the socket is closed
+		<-progressDone
+	}()
 	go func() {
-		for range progressCh {
+		defer close(progressDone)
+		for msg := range progressCh {
+			if progressSocket != nil {
+				progressSocket.Publish(msg)
+			}
 		}
 	}()
 
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/mattn/go-isatty"
 	"github.com/replicatedhq/troubleshoot/cmd/internal/util"
 	"github.com/replicatedhq/troubleshoot/internal/traces"
 	"github.com/replicatedhq/troubleshoot/pkg/constants"
@@ -20,8 +21,14 @@ import (
 
 func RootCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "preflight [url]",
-		Args:  cobra.MinimumNArgs(1),
+		Use: "preflight [url]",
+		Args: func(cmd *cobra.Command, args []string) error {
+			// the spec may be loaded from --collector-spec-from-url instead of an argument
+			if specURL, _ := cmd.Flags().GetString("collector-spec-from-url"); specURL != "" {
+				return nil
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
 		Short: "Run and retrieve preflight checks in a cluster",
 		Long: `A preflight check is a set of validations that can and should be run to ensure
 that a cluster meets the requirements to run an application.`,
@@ -48,7 +55,9 @@ that a cluster meets the requirements to run an application.`,
 				defer closer()
 			}
 
-			err = preflight.RunPreflights(v.GetBool("interactive"), v.GetString("output"), v.GetString("format"), args)
+			// fall back to the text results when the output is not a terminal, e.g. when piped
+			interactive := v.GetBool("interactive") && isatty.IsTerminal(os.Stdout.Fd())
+			err = preflight.RunPreflights(interactive, v.GetString("output"), v.GetString("format"), args)
 			if !v.GetBool("dry-run") && (v.GetBool("debug") || v.IsSet("v")) {
 				fmt.Fprintf(os.Stderr, "\n%s", traces.GetExporterInstance().GetSummary())
 			}
@@ -72,6 +81,8 @@ that a cluster meets the requirements to run an application.`,
 	// Adding here to avoid that
 	cmd.Flags().Bool("dry-run", false, "print the preflight spec without running preflight checks")
 	cmd.Flags().Bool("no-uri", false, "When this flag is used, Preflight does not attempt to retrieve the spec referenced by the uri: field`")
+	cmd.Flags().String("collector-spec-from-url", "", "URL of a preflight spec to load in addition to any specs provided as arguments")
+	cmd.Flags().String("spec-checksum", "", "expected SHA-256 checksum of the spec loaded with --collector-spec-from-url. The spec is not run if the checksum does not match")
 
 	k8sutil.AddFlags(cmd.Flags())
 
@@ -106,6 +106,18 @@ func generateSchemas(v *viper.Viper) error {
 			"troubleshoot.sh_supportbundles.yaml",
 			"supportbundle-troubleshoot-v1beta2.json",
 		},
+		{
+			"troubleshoot.sh_hostcollectors.yaml",
+			"hostcollector-troubleshoot-v1beta2.json",
+		},
+		{
+			"troubleshoot.sh_hostpreflights.yaml",
+			"hostpreflight-troubleshoot-v1beta2.json",
+		},
+		{
+			"troubleshoot.sh_remotecollectors.yaml",
+			"remotecollector-troubleshoot-v1beta2.json",
+		},
 	}
 
 	for _, file := range files {
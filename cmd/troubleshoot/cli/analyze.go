@@ -33,10 +33,19 @@ func Analyze() *cobra.Command {
 				return err
 			}
 
+			var suppressions []analyzer.Suppression
+			if v.GetString("ignore-list") != "" {
+				suppressions, err = analyzer.LoadSuppressions(v.GetString("ignore-list"))
+				if err != nil {
+					return err
+				}
+			}
+
 			result, err := analyzer.DownloadAndAnalyze(v.GetString("bundle"), analyzerSpec)
 			if err != nil {
 				return err
 			}
+			analyzer.SuppressResults(result, suppressions)
 
 			var data interface{}
 			switch v.GetString("compatibility") {
@@ -52,6 +61,8 @@ func Analyze() *cobra.Command {
 				formatted, err = json.MarshalIndent(data, "", "    ")
 			case "", "yaml":
 				formatted, err = yaml.Marshal(data)
+			case "csv":
+				formatted, err = convert.ToCSV(convert.FromAnalyzerResult(result))
 			default:
 				return fmt.Errorf("unsupported output format: %q", v.GetString("output"))
 			}
@@ -67,7 +78,8 @@ func Analyze() *cobra.Command {
 
 	cmd.Flags().String("bundle", "", "filename of the support bundle to analyze")
 	cmd.MarkFlagRequired("bundle")
-	cmd.Flags().String("output", "", "output format: json, yaml")
+	cmd.Flags().String("output", "", "output format: json, yaml, csv")
+	cmd.Flags().String("ignore-list", "", "file listing known failures and warnings, by check title with an optional reason and expiry, to report as informational instead")
 	cmd.Flags().String("compatibility", "", "output compatibility mode: support-bundle")
 	cmd.Flags().MarkHidden("compatibility")
 	cmd.Flags().Bool("quiet", false, "enable/disable error messaging and only show parseable output")
@@ -121,7 +121,9 @@ func drawAnalyzersTable(analyzeResults []*analyzerunner.AnalyzeResult) {
 
 	for i, analyzeResult := range analyzeResults {
 		title := analyzeResult.Title
-		if analyzeResult.IsPass {
+		if analyzeResult.Suppression != nil {
+			title = fmt.Sprintf("ℹ  %s (Suppressed)", title)
+		} else if analyzeResult.IsPass {
 			title = fmt.Sprintf("✔  %s", title)
 		} else if analyzeResult.IsWarn {
 			title = fmt.Sprintf("⚠️  %s", title)
@@ -132,7 +134,13 @@ func drawAnalyzersTable(analyzeResults []*analyzerunner.AnalyzeResult) {
 			title,
 		})
 
-		if analyzeResult.IsPass {
+		if analyzeResult.Suppression != nil {
+			if i == selectedResult {
+				table.RowStyles[i] = ui.NewStyle(ui.ColorWhite, ui.ColorClear, ui.ModifierReverse)
+			} else {
+				table.RowStyles[i] = ui.NewStyle(ui.ColorWhite, ui.ColorClear)
+			}
+		} else if analyzeResult.IsPass {
 			if i == selectedResult {
 				table.RowStyles[i] = ui.NewStyle(ui.ColorGreen, ui.ColorClear, ui.ModifierReverse)
 			} else {
@@ -166,7 +174,9 @@ func drawDetails(analysisResult *analyzerunner.AnalyzeResult) {
 	// For long title that lead to wrapping text, the terminal width is divided by 2 and deducted by MESSAGE_TEXT_PADDING to account for the padding
 	title.Text = wordwrap.WrapString(analysisResult.Title, uint(termWidth/2-constants.MESSAGE_TEXT_PADDING))
 	title.Border = false
-	if analysisResult.IsPass {
+	if analysisResult.Suppression != nil {
+		title.TextStyle = ui.NewStyle(ui.ColorWhite, ui.ColorClear, ui.ModifierBold)
+	} else if analysisResult.IsPass {
 		title.TextStyle = ui.NewStyle(ui.ColorGreen, ui.ColorClear, ui.ModifierBold)
 	} else if analysisResult.IsWarn {
 		title.TextStyle = ui.NewStyle(ui.ColorYellow, ui.ColorClear, ui.ModifierBold)
@@ -189,6 +199,10 @@ func drawDetails(analysisResult *analyzerunner.AnalyzeResult) {
 		urlText := wordwrap.WrapString(fmt.Sprintf("For more information: %s", analysisResult.URI), uint(termWidth/2-constants.MESSAGE_TEXT_PADDING))
 		message.Text = message.Text + "\n\n" + urlText
 	}
+	if analysisResult.Suppression != nil {
+		suppressedText := wordwrap.WrapString(fmt.Sprintf("Suppressed: %s", analysisResult.Suppression.Summary()), uint(termWidth/2-constants.MESSAGE_TEXT_PADDING))
+		message.Text = message.Text + "\n\n" + suppressedText
+	}
 	height = util.EstimateNumberOfLines(message.Text) + constants.MESSAGE_TEXT_LINES_MARGIN_TO_BOTTOM
 	message.Border = false
 	message.SetRect(termWidth/2, currentTop, termWidth, currentTop+height)
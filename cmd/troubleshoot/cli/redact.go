@@ -1,13 +1,16 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/pkg/errors"
 	analyzer "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
 	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/redact"
 	"github.com/replicatedhq/troubleshoot/pkg/supportbundle"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -53,13 +56,23 @@ For more information on redactors visit https://troubleshoot.sh/docs/redact/
 				return err
 			}
 
+			if v.GetBool("dry-run") {
+				return redactDryRun(bundleDir, collectorResult, redactors, v.GetString("dry-run-output"))
+			}
+
 			// 4. Perform redaction on the bundle
 			err = collect.RedactResult(bundleDir, collectorResult, redactors)
 			if err != nil {
 				return errors.Wrap(err, "failed to redact support bundle")
 			}
 
-			// 5. Compress the bundle once more after redacting
+			// 5. Record the redactions, adding to those made when the bundle was generated
+			err = supportbundle.SaveRedactionsFile(bundleDir, collectorResult)
+			if err != nil {
+				return err
+			}
+
+			// 6. Compress the bundle once more after redacting
 			output := v.GetString("output")
 			if output == "" {
 				output = fmt.Sprintf("redacted-support-bundle-%s.tar.gz", time.Now().Format("2006-01-02T15_04_05"))
@@ -69,6 +82,21 @@ For more information on redactors visit https://troubleshoot.sh/docs/redact/
 				return errors.Wrap(err, "failed to create support bundle archive")
 			}
 			fmt.Println("Redacted support bundle:", output)
+
+			if auditPath := v.GetString("redaction-audit"); auditPath != "" {
+				if err := writeRedactionAudit(auditPath); err != nil {
+					return err
+				}
+				fmt.Println("Redaction audit:", auditPath)
+			}
+
+			tokenMappingPath, err := writeTokenMapping(v.GetString("token-mapping"))
+			if err != nil {
+				return err
+			}
+			if tokenMappingPath != "" {
+				fmt.Println("Redaction token mapping, do not share:", tokenMappingPath)
+			}
 			return nil
 		},
 	}
@@ -77,6 +105,72 @@ For more information on redactors visit https://troubleshoot.sh/docs/redact/
 	cmd.MarkFlagRequired("bundle")
 	cmd.Flags().BoolP("quiet", "q", false, "enable/disable error messaging and only show parseable output")
 	cmd.Flags().StringP("output", "o", "", "file path of where to save the redacted support bundle archive (default \"redacted-support-bundle-YYYY-MM-DDTHH_MM_SS.tar.gz\")")
+	cmd.Flags().String("redaction-audit", "", "file path of where to save a report of the redactions performed, with counts by file and by redactor but none of the redacted values")
+	cmd.Flags().String("token-mapping", "", "file path of where to save the mapping of redaction tokens to the values they replace, when redactors tokenize values. It is never included in the support bundle and is only readable by the current user (default \"redaction-tokens-YYYY-MM-DDTHH_MM_SS.json\")")
+	cmd.Flags().Bool("dry-run", false, "report what would be redacted, by file and redactor, without changing the bundle or creating a redacted archive")
+	cmd.Flags().String("dry-run-output", "", "file path of where to save the dry run report, only readable by the current user as it includes the text around each redacted value (default \"redaction-preview-YYYY-MM-DDTHH_MM_SS.json\")")
 
 	return cmd
 }
+
+// redactDryRun saves the redactions that would be performed on the bundle to path. The report has
+// the redacted values masked but shows the text around them, so only the current user can read it.
+func redactDryRun(bundleDir string, collectorResult collect.CollectorResult, redactors []*troubleshootv1beta2.Redact, path string) error {
+	matches, err := collect.DryRunRedactResult(bundleDir, collectorResult, redactors)
+	if err != nil {
+		return errors.Wrap(err, "failed to dry run redaction of support bundle")
+	}
+
+	b, err := json.MarshalIndent(matches, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal redaction preview")
+	}
+
+	if path == "" {
+		path = fmt.Sprintf("redaction-preview-%s.json", time.Now().Format("2006-01-02T15_04_05"))
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return errors.Wrap(err, "failed to write redaction preview")
+	}
+
+	fmt.Printf("Redaction preview: %s (%d redactions, the bundle was not changed)\n", path, len(matches))
+	return nil
+}
+
+// writeRedactionAudit saves a report of the redactions performed in this process to path
+func writeRedactionAudit(path string) error {
+	b, err := json.MarshalIndent(redact.GetRedactionAudit(), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal redaction audit")
+	}
+
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return errors.Wrap(err, "failed to write redaction audit")
+	}
+
+	return nil
+}
+
+// writeTokenMapping saves the values replaced by redaction tokens to path, only readable by the
+// current user as it reveals them. Nothing is saved when no value was tokenized, and the path of
+// the file is returned otherwise.
+func writeTokenMapping(path string) (string, error) {
+	mapping := redact.GetTokenMapping()
+	if len(mapping) == 0 {
+		return "", nil
+	}
+
+	b, err := json.MarshalIndent(mapping, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal redaction token mapping")
+	}
+
+	if path == "" {
+		path = fmt.Sprintf("redaction-tokens-%s.json", time.Now().Format("2006-01-02T15_04_05"))
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return "", errors.Wrap(err, "failed to write redaction token mapping")
+	}
+
+	return path, nil
+}
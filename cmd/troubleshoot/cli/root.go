@@ -82,19 +82,34 @@ If no arguments are provided, specs are automatically loaded from the cluster by
 
 	cmd.AddCommand(Analyze())
 	cmd.AddCommand(Redact())
+	cmd.AddCommand(Serve())
 	cmd.AddCommand(util.VersionCmd())
 
 	cmd.Flags().StringSlice("redactors", []string{}, "names of the additional redactors to use")
+	cmd.Flags().String("redactors-from-configmap", "", "namespace/name of a configmap holding redactor specs to use in addition to the redactors of the support bundle spec")
 	cmd.Flags().Bool("redact", true, "enable/disable default redactions")
+	cmd.Flags().Bool("collection-timing", false, "add collection-timing.json to the support bundle with how long each collector took to run")
+	cmd.Flags().String("redaction-audit", "", "file path of where to save a report of the redactions performed, with counts by file and by redactor but none of the redacted values")
+	cmd.Flags().String("token-mapping", "", "file path of where to save the mapping of redaction tokens to the values they replace, when redactors tokenize values. It is never included in the support bundle and is only readable by the current user (default \"redaction-tokens-YYYY-MM-DDTHH_MM_SS.json\")")
+	cmd.Flags().Bool("metadata-only", false, "collect only resource metadata, statuses, counts and versions, leaving out spec data, env vars, configmap and secret contents and logs. The bundle's metadata-only.json lists exactly what is included")
+	cmd.Flags().String("resume", "", "directory to collect the support bundle in, in a directory named after the bundle, kept after collection. If a previous collection in the directory was interrupted, it is continued and the collectors that completed are not run again")
+	cmd.Flags().Bool("deterministic", false, "make the support bundle archive byte-stable for the same cluster state, to diff bundles. JSON files get sorted keys and lists, and archive entries are sorted without timestamps, in a support-bundle directory")
+	cmd.Flags().String("max-bundle-size", "", "maximum size of the compressed support bundle, e.g. 100Mi. Over it, the oldest lines of logs are removed and large files are left out, keeping events, pods and other critical files, and bundle-size-budget.json lists what was trimmed")
+	cmd.Flags().String("gzip-file-threshold", "", "gzip the .log and .txt files larger than this size, e.g. 1Mi, saving them as <name>.gz. Pod logs are gzipped as they are written, other files once their collector completes. It lowers the disk usage of log heavy collections, analyzers read the files decompressed")
+	cmd.Flags().Bool("stream-archive", false, "write the support bundle into a zip archive as each collector completes, instead of a tar.gz archive written once collection and analysis are done. Files are redacted before they are written. It can't be used with --max-bundle-size or --deterministic")
+	cmd.Flags().String("ignore-list", "", "file listing known failures and warnings, by check title with an optional reason and expiry, to report as informational instead")
 	cmd.Flags().Bool("interactive", true, "enable/disable interactive mode")
 	cmd.Flags().Bool("collect-without-permissions", true, "always generate a support bundle, even if it some require additional permissions")
 	cmd.Flags().StringSliceP("selector", "l", []string{"troubleshoot.sh/kind=support-bundle"}, "selector to filter on for loading additional support bundle specs found in secrets within the cluster")
 	cmd.Flags().Bool("load-cluster-specs", false, "enable/disable loading additional troubleshoot specs found within the cluster. Do not load by default unless no specs are provided in the cli args")
-	cmd.Flags().String("since-time", "", "force pod logs collectors to return logs after a specific date (RFC3339)")
-	cmd.Flags().String("since", "", "force pod logs collectors to return logs newer than a relative duration like 5s, 2m, or 3h.")
+	cmd.Flags().String("since-time", "", "force pod logs collectors and the logs of unhealthy pods to return logs after a specific date (RFC3339)")
+	cmd.Flags().String("since", "", "force pod logs collectors and the logs of unhealthy pods to return logs newer than a relative duration like 5s, 2m, or 3h.")
 	cmd.Flags().StringP("output", "o", "", "specify the output file path for the support bundle")
 	cmd.Flags().Bool("debug", false, "enable debug logging. This is equivalent to --v=0")
 	cmd.Flags().Bool("dry-run", false, "print support bundle spec without collecting anything")
+	cmd.Flags().String("dry-run-format", "yaml", "output of --dry-run: yaml prints the support bundle spec, text or json list each collector with the namespaces it would read and whether RBAC allows it")
+	cmd.Flags().String("collector-spec-from-url", "", "URL of a support bundle spec to load in addition to any specs provided as arguments")
+	cmd.Flags().String("spec-checksum", "", "expected SHA-256 checksum of the spec loaded with --collector-spec-from-url. The spec is not run if the checksum does not match")
 
 	// hidden in favor of the `insecure-skip-tls-verify` flag
 	cmd.Flags().Bool("allow-insecure-connections", false, "when set, do not verify TLS certs when retrieving spec and reporting results")
@@ -10,7 +10,9 @@ import (
 	"os/signal"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"sync"
+	"text/tabwriter"
 	"time"
 
 	cursor "github.com/ahmetalpbalkan/go-cursor"
@@ -31,6 +33,7 @@ import (
 	"github.com/replicatedhq/troubleshoot/pkg/types"
 	"github.com/spf13/viper"
 	spin "github.com/tj/go-spin"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -55,7 +58,23 @@ func runTroubleshoot(v *viper.Viper, args []string) error {
 		return err
 	}
 
-	// For --dry-run, we want to print the yaml and exit
+	// For --dry-run, we want to print the yaml, or what would be collected, and exit
+	if v.GetBool("dry-run") && v.GetString("dry-run-format") != "yaml" {
+		plans, err := supportbundle.PlanCollection(ctx, &mainBundle.Spec, supportbundle.SupportBundleCreateOpts{
+			KubernetesRestConfig: restConfig,
+			Namespace:            v.GetString("namespace"),
+			MetadataOnly:         v.GetBool("metadata-only"),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to plan collection")
+		}
+		out, err := formatCollectionPlan(plans, v.GetString("dry-run-format"), mainBundle.Spec.RunHostCollectorsInPod)
+		if err != nil {
+			return err
+		}
+		fmt.Print(out)
+		return nil
+	}
 	if v.GetBool("dry-run") {
 		k := loader.TroubleshootKinds{
 			SupportBundlesV1Beta2: []troubleshootv1beta2.SupportBundle{*mainBundle},
@@ -98,6 +117,30 @@ func runTroubleshoot(v *viper.Viper, args []string) error {
 		}
 	}
 
+	var maxBundleSize int64
+	if v.GetString("max-bundle-size") != "" {
+		maxBundleSize, err = parseMaxBundleSize(v.GetString("max-bundle-size"))
+		if err != nil {
+			return err
+		}
+	}
+
+	var gzipFileThreshold int64
+	if v.GetString("gzip-file-threshold") != "" {
+		gzipFileThreshold, err = parseSizeFlag("gzip-file-threshold", v.GetString("gzip-file-threshold"))
+		if err != nil {
+			return err
+		}
+	}
+
+	var suppressions []analyzer.Suppression
+	if v.GetString("ignore-list") != "" {
+		suppressions, err = analyzer.LoadSuppressions(v.GetString("ignore-list"))
+		if err != nil {
+			return err
+		}
+	}
+
 	if v.GetBool("allow-insecure-connections") || v.GetBool("insecure-skip-tls-verify") {
 		httputil.AddTransport(&http.Transport{
 			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
@@ -185,6 +228,14 @@ func runTroubleshoot(v *viper.Viper, args []string) error {
 		Redact:                    v.GetBool("redact"),
 		FromCLI:                   true,
 		RunHostCollectorsInPod:    mainBundle.Spec.RunHostCollectorsInPod,
+		CollectionTiming:          v.GetBool("collection-timing"),
+		MetadataOnly:              v.GetBool("metadata-only"),
+		ResumeDir:                 v.GetString("resume"),
+		Deterministic:             v.GetBool("deterministic"),
+		MaxBundleSize:             maxBundleSize,
+		GzipFileThreshold:         gzipFileThreshold,
+		StreamArchive:             v.GetBool("stream-archive"),
+		Suppressions:              suppressions,
 	}
 
 	nonInteractiveOutput := analysisOutput{}
@@ -197,6 +248,20 @@ func runTroubleshoot(v *viper.Viper, args []string) error {
 	close(progressChan) // this removes the spinner in interactive mode
 	isProgressChanClosed = true
 
+	if auditPath := v.GetString("redaction-audit"); auditPath != "" {
+		if err := writeRedactionAudit(auditPath); err != nil {
+			return err
+		}
+	}
+
+	tokenMappingPath, err := writeTokenMapping(v.GetString("token-mapping"))
+	if err != nil {
+		return err
+	}
+	if tokenMappingPath != "" {
+		fmt.Fprintf(os.Stderr, "The values replaced by redaction tokens were saved to %s, do not share this file\n", tokenMappingPath)
+	}
+
 	if len(response.AnalyzerResults) > 0 {
 		if interactive {
 			if err := showInteractiveResults(mainBundle.Name, response.AnalyzerResults, response.ArchivePath); err != nil {
@@ -204,6 +269,7 @@ func runTroubleshoot(v *viper.Viper, args []string) error {
 			}
 		} else {
 			nonInteractiveOutput.Analysis = response.AnalyzerResults
+			nonInteractiveOutput.RootCauses = response.RootCauses
 		}
 	}
 
@@ -402,7 +468,7 @@ func parseTimeFlags(v *viper.Viper) (*time.Time, error) {
 	)
 	if v.GetString("since-time") != "" {
 		if v.GetString("since") != "" {
-			return nil, errors.Errorf("at most one of `sinceTime` or `since` may be specified")
+			return nil, errors.Errorf("at most one of `--since-time` or `--since` may be specified")
 		}
 		sinceTime, err = time.Parse(time.RFC3339, v.GetString("since-time"))
 		if err != nil {
@@ -420,21 +486,108 @@ func parseTimeFlags(v *viper.Viper) (*time.Time, error) {
 	return &sinceTime, nil
 }
 
+// parseMaxBundleSize parses the --max-bundle-size flag, a quantity such as 100Mi or 1G
+func parseMaxBundleSize(value string) (int64, error) {
+	return parseSizeFlag("max-bundle-size", value)
+}
+
+// parseSizeFlag parses a flag that is a positive quantity of bytes, such as 100Mi or 1G
+func parseSizeFlag(name string, value string) (int64, error) {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to parse --%s flag", name)
+	}
+	if quantity.Value() <= 0 {
+		return 0, errors.Errorf("--%s must be positive, got %s", name, value)
+	}
+	return quantity.Value(), nil
+}
+
+// formatCollectionPlan formats the collectors a dry run would run as json, or as text with a row
+// per collector followed by the permissions they lack
+func formatCollectionPlan(plans []supportbundle.CollectorPlan, format string, hostCollectorsInPod bool) (string, error) {
+	switch format {
+	case "json":
+		b, err := json.MarshalIndent(plans, "", "  ")
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal collection plan")
+		}
+		return string(b) + "\n", nil
+	case "text":
+	default:
+		return "", errors.Errorf("invalid --dry-run-format %q, must be one of yaml, text or json", format)
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COLLECTOR\tTARGET\tSTATUS")
+
+	forbidden := []string{}
+	for _, plan := range plans {
+		targets := []string{}
+		switch {
+		case plan.Host && hostCollectorsInPod:
+			targets = append(targets, "each node")
+		case plan.Host:
+			targets = append(targets, "local host")
+		case plan.AllNamespaces && len(plan.ExcludedNamespaces) > 0:
+			targets = append(targets, fmt.Sprintf("all namespaces except %v", plan.ExcludedNamespaces))
+		case plan.AllNamespaces:
+			targets = append(targets, "all namespaces")
+		default:
+			targets = append(targets, plan.Namespaces...)
+		}
+		if plan.ClusterScoped {
+			targets = append(targets, "cluster")
+		}
+		target := strings.Join(targets, ", ")
+		if target == "" {
+			target = "-"
+		}
+
+		status := "ok"
+		switch {
+		case plan.Excluded:
+			status = "excluded"
+		case len(plan.Forbidden) > 0:
+			status = "forbidden"
+			for _, f := range plan.Forbidden {
+				forbidden = append(forbidden, fmt.Sprintf("  %s", f))
+			}
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", plan.Name, target, status)
+	}
+	if err := w.Flush(); err != nil {
+		return "", errors.Wrap(err, "failed to format collection plan")
+	}
+
+	if len(forbidden) > 0 {
+		b.WriteString("\nThe current user lacks these permissions:\n")
+		b.WriteString(strings.Join(forbidden, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
 type analysisOutput struct {
 	Analysis    []*analyzer.AnalyzeResult
+	RootCauses  []analyzer.RootCause
 	ArchivePath string
 }
 
 func (a *analysisOutput) FormattedAnalysisOutput() (outputJson string, err error) {
 	type convertedOutput struct {
-		ConvertedAnalysis []*convert.Result `json:"analyzerResults"`
-		ArchivePath       string            `json:"archivePath"`
+		ConvertedAnalysis []*convert.Result    `json:"analyzerResults"`
+		RootCauses        []analyzer.RootCause `json:"rootCauses,omitempty"`
+		ArchivePath       string               `json:"archivePath"`
 	}
 
 	converted := convert.FromAnalyzerResult(a.Analysis)
 
 	o := convertedOutput{
 		ConvertedAnalysis: converted,
+		RootCauses:        a.RootCauses,
 		ArchivePath:       a.ArchivePath,
 	}
 
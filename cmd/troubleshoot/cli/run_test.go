@@ -13,6 +13,7 @@ import (
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
 	"github.com/replicatedhq/troubleshoot/pkg/httputil"
 	"github.com/replicatedhq/troubleshoot/pkg/loader"
+	"github.com/replicatedhq/troubleshoot/pkg/supportbundle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	testclient "k8s.io/client-go/kubernetes/fake"
@@ -435,3 +436,57 @@ func Test_loadInvalidURISpec(t *testing.T) {
 	assert.Len(t, sb.Spec.Collectors, 3)              // default + clusterInfo + clusterResources
 	assert.NotNil(t, sb.Spec.Collectors[0].ConfigMap) // come from the original spec
 }
+
+func Test_parseMaxBundleSize(t *testing.T) {
+	size, err := parseMaxBundleSize("100Mi")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100*1024*1024), size)
+
+	size, err = parseMaxBundleSize("1G")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000*1000*1000), size)
+
+	_, err = parseMaxBundleSize("0")
+	assert.EqualError(t, err, "--max-bundle-size must be positive, got 0")
+
+	_, err = parseMaxBundleSize("lots")
+	assert.ErrorContains(t, err, "unable to parse --max-bundle-size flag")
+}
+
+func Test_formatCollectionPlan(t *testing.T) {
+	plans := []supportbundle.CollectorPlan{
+		{Name: "cpu", Host: true},
+		{Name: "cluster-resources", AllNamespaces: true, ClusterScoped: true},
+		{Name: "cluster-info"},
+		{Name: "logs/app", Namespaces: []string{"app", "default"}},
+		{Name: "secret/db", Namespaces: []string{"db"}, Forbidden: []string{`cannot collect secret/db: action "get" is not allowed on resource "secrets" in the "db" namespace`}},
+		{Name: "configmap/ca", Namespaces: []string{"default"}, Excluded: true},
+	}
+
+	out, err := formatCollectionPlan(plans, "text", false)
+	require.NoError(t, err)
+	assert.Equal(t, `COLLECTOR          TARGET                   STATUS
+cpu                local host               ok
+cluster-resources  all namespaces, cluster  ok
+cluster-info       -                        ok
+logs/app           app, default             ok
+secret/db          db                       forbidden
+configmap/ca       default                  excluded
+
+The current user lacks these permissions:
+  cannot collect secret/db: action "get" is not allowed on resource "secrets" in the "db" namespace
+`, out)
+
+	out, err = formatCollectionPlan(plans[:1], "text", true)
+	require.NoError(t, err)
+	assert.Contains(t, out, "cpu        each node  ok")
+
+	out, err = formatCollectionPlan(plans[:2], "json", false)
+	require.NoError(t, err)
+	got := []supportbundle.CollectorPlan{}
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	assert.Equal(t, plans[:2], got)
+
+	_, err = formatCollectionPlan(plans, "table", false)
+	assert.EqualError(t, err, `invalid --dry-run-format "table", must be one of yaml, text or json`)
+}
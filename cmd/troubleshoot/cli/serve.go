@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/bundleserver"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func Serve() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Args:  cobra.NoArgs,
+		Short: "Run an HTTP server that receives and stores uploaded support bundles",
+		Long: `Run an HTTP server that receives support bundle archives and stores them in a local directory.
+
+Bundles are uploaded with POST /v1/bundles, either as the request body or as the "bundle" field
+of a multipart form, and are returned by id with GET /v1/bundles/{id}. Every request must carry
+the auth token as a bearer token. When the X-Bundle-Sha256 header is sent the upload is rejected
+unless the received archive matches the checksum, and archives that are not complete gzipped tar
+files are always rejected.
+
+The auth token can also be set with the TROUBLESHOOT_AUTH_TOKEN environment variable.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			// received bundles are only readable by the user running the server
+			dir := v.GetString("dir")
+			if err := os.MkdirAll(dir, 0700); err != nil {
+				return errors.Wrapf(err, "failed to create bundle directory %s", dir)
+			}
+			store, err := collect.NewLocalBundleStore(dir)
+			if err != nil {
+				return err
+			}
+
+			var maxBundleSize int64
+			if v.GetString("max-bundle-size") != "" {
+				maxBundleSize, err = parseMaxBundleSize(v.GetString("max-bundle-size"))
+				if err != nil {
+					return err
+				}
+			}
+
+			server, err := bundleserver.NewServer(bundleserver.Options{
+				Store:         store,
+				Token:         v.GetString("auth-token"),
+				MaxBundleSize: maxBundleSize,
+			})
+			if err != nil {
+				return err
+			}
+
+			httpServer := &http.Server{
+				Addr:              v.GetString("address"),
+				Handler:           server.Handler(),
+				ReadHeaderTimeout: 30 * time.Second,
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			errCh := make(chan error, 1)
+			go func() {
+				certFile, keyFile := v.GetString("tls-cert"), v.GetString("tls-key")
+				if certFile != "" || keyFile != "" {
+					fmt.Printf("Receiving support bundles on https://%s\n", httpServer.Addr)
+					errCh <- httpServer.ListenAndServeTLS(certFile, keyFile)
+					return
+				}
+				fmt.Printf("Receiving support bundles on http://%s\n", httpServer.Addr)
+				errCh <- httpServer.ListenAndServe()
+			}()
+
+			select {
+			case err := <-errCh:
+				return errors.Wrap(err, "failed to serve")
+			case <-ctx.Done():
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			return httpServer.Shutdown(shutdownCtx)
+		},
+	}
+
+	cmd.Flags().String("address", ":8080", "address to listen on")
+	cmd.Flags().String("dir", "support-bundles", "directory to store received support bundles in")
+	cmd.Flags().String("auth-token", "", "bearer token clients must send to upload or download support bundles")
+	cmd.Flags().String("tls-cert", "", "file path of the TLS certificate, the server uses plain HTTP when no certificate is set")
+	cmd.Flags().String("tls-key", "", "file path of the TLS private key")
+	cmd.Flags().String("max-bundle-size", "", "largest support bundle accepted, e.g. 100Mi. Bundles of any size are accepted when it is not set")
+
+	return cmd
+}
@@ -5,6 +5,7 @@ import "C"
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	analyzer "github.com/replicatedhq/troubleshoot/pkg/analyze"
 	"github.com/replicatedhq/troubleshoot/pkg/convert"
@@ -14,9 +15,37 @@ import (
 
 //export Analyze
 func Analyze(bundleURL string, analyzers string, outputFormat string, compatibility string) *C.char {
+	return analyze(bundleURL, analyzers, outputFormat, compatibility, analyzer.DownloadOptions{})
+}
+
+// AnalyzeWithRetries is Analyze with retries of failed bundle downloads. maxAttempts is the number
+// of times the download is tried, baseDelay and maxDelay are durations such as 1s, empty for their
+// defaults.
+//
+//export AnalyzeWithRetries
+func AnalyzeWithRetries(bundleURL string, analyzers string, outputFormat string, compatibility string, maxAttempts int, baseDelay string, maxDelay string) *C.char {
+	opts := analyzer.DownloadOptions{MaxAttempts: maxAttempts}
+	var err error
+	if baseDelay != "" {
+		if opts.BaseDelay, err = time.ParseDuration(baseDelay); err != nil {
+			fmt.Printf("invalid base delay: %s\n", err.Error())
+			return C.CString("")
+		}
+	}
+	if maxDelay != "" {
+		if opts.MaxDelay, err = time.ParseDuration(maxDelay); err != nil {
+			fmt.Printf("invalid max delay: %s\n", err.Error())
+			return C.CString("")
+		}
+	}
+
+	return analyze(bundleURL, analyzers, outputFormat, compatibility, opts)
+}
+
+func analyze(bundleURL string, analyzers string, outputFormat string, compatibility string, opts analyzer.DownloadOptions) *C.char {
 	logger.SetQuiet(true)
 
-	result, err := analyzer.DownloadAndAnalyze(bundleURL, analyzers)
+	result, err := analyzer.DownloadAndAnalyzeWithOptions(bundleURL, analyzers, opts)
 	if err != nil {
 		fmt.Printf("error downloading and analyzing: %s\n", err.Error())
 		return C.CString("")
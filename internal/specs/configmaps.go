@@ -2,8 +2,11 @@ package specs
 
 import (
 	"context"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/loader"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
@@ -44,3 +47,45 @@ func LoadFromConfigMapMatchingLabel(ctx context.Context, client kubernetes.Inter
 
 	return configMapMatchingKey, nil
 }
+
+// LoadRedactorsFromConfigMap loads the redactor specs in the data of a configmap, referenced as
+// namespace/name. Every key of the configmap can hold a redactor spec, the other specs are ignored.
+// It is an error for the configmap to not exist or to have no redactor specs, as redactors that
+// are silently not applied could leak sensitive data.
+func LoadRedactorsFromConfigMap(ctx context.Context, client kubernetes.Interface, ref string) (*loader.TroubleshootKinds, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.Errorf("redactors configmap %q must be in the format namespace/name", ref)
+	}
+	ns, name := parts[0], parts[1]
+
+	configMap, err := client.CoreV1().ConfigMaps(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, errors.Errorf("redactors configmap %s not found in namespace %s", name, ns)
+		}
+		return nil, errors.Wrapf(err, "failed to get redactors configmap %s", ref)
+	}
+
+	rawSpecs := make([]string, 0, len(configMap.Data))
+	for _, spec := range configMap.Data {
+		rawSpecs = append(rawSpecs, spec)
+	}
+
+	kinds, err := loader.LoadSpecs(ctx, loader.LoadOptions{
+		RawSpecs: rawSpecs,
+		Strict:   true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load specs from redactors configmap %s", ref)
+	}
+	if len(kinds.RedactorsV1Beta2) == 0 {
+		return nil, errors.Errorf("no redactor specs found in configmap %s", ref)
+	}
+
+	klog.V(1).InfoS("Loaded redactors from config map", "name", name, "namespace", ns, "count", len(kinds.RedactorsV1Beta2))
+
+	redactors := loader.NewTroubleshootKinds()
+	redactors.RedactorsV1Beta2 = kinds.RedactorsV1Beta2
+	return redactors, nil
+}
@@ -559,3 +559,82 @@ spec:
 		})
 	}
 }
+
+func TestLoadRedactorsFromConfigMap(t *testing.T) {
+	redactorSpec := `apiVersion: troubleshoot.sh/v1beta2
+kind: Redactor
+metadata:
+  name: central-redactors
+spec:
+  redactors:
+  - name: replace some-content
+    removals:
+      values:
+      - some-content`
+	collectorSpec := `apiVersion: troubleshoot.sh/v1beta2
+kind: Collector
+metadata:
+  name: collectors
+spec:
+  collectors:
+  - clusterInfo: {}`
+
+	tests := []struct {
+		name          string
+		ref           string
+		data          map[string]string
+		wantRedactors []string
+		wantErr       string
+	}{
+		{
+			name: "redactor specs",
+			ref:  "security/redactors",
+			data: map[string]string{
+				"redactor.yaml":  redactorSpec,
+				"collector.yaml": collectorSpec,
+			},
+			wantRedactors: []string{"replace some-content"},
+		},
+		{
+			name:    "missing configmap",
+			ref:     "security/other",
+			wantErr: "redactors configmap other not found in namespace security",
+		},
+		{
+			name:    "no redactor specs",
+			ref:     "security/redactors",
+			data:    map[string]string{"collector.yaml": collectorSpec},
+			wantErr: "no redactor specs found in configmap security/redactors",
+		},
+		{
+			name:    "invalid reference",
+			ref:     "redactors",
+			wantErr: "must be in the format namespace/name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := testclient.NewSimpleClientset()
+			_, err := client.CoreV1().ConfigMaps("security").Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "redactors", Namespace: "security"},
+				Data:       tt.data,
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			got, err := LoadRedactorsFromConfigMap(ctx, client, tt.ref)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, got.CollectorsV1Beta2)
+			require.Len(t, got.RedactorsV1Beta2, 1)
+			names := []string{}
+			for _, redactor := range got.RedactorsV1Beta2[0].Spec.Redactors {
+				names = append(names, redactor.Name)
+			}
+			assert.Equal(t, tt.wantRedactors, names)
+		})
+	}
+}
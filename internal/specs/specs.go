@@ -2,6 +2,8 @@ package specs
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -82,6 +84,28 @@ func LoadFromCLIArgs(ctx context.Context, client kubernetes.Interface, args []st
 	allURLSpecs := loader.NewTroubleshootKinds()
 	rawSpecs := []string{}
 
+	specURL, checksum := vp.GetString("collector-spec-from-url"), vp.GetString("spec-checksum")
+	if checksum != "" && specURL == "" {
+		return nil, types.NewExitCodeError(constants.EXIT_CODE_SPEC_ISSUES, errors.New("--spec-checksum requires --collector-spec-from-url"))
+	}
+	if specURL != "" {
+		rawSpec, err := LoadFromURLWithChecksum(ctx, specURL, checksum)
+		if err != nil {
+			return nil, types.NewExitCodeError(constants.EXIT_CODE_SPEC_ISSUES, err)
+		}
+		if checksum != "" {
+			// a spec uri would replace the verified spec with one that is not verified
+			verifiedKinds, err := loader.LoadSpecs(ctx, loader.LoadOptions{RawSpec: rawSpec})
+			if err != nil {
+				return nil, types.NewExitCodeError(constants.EXIT_CODE_SPEC_ISSUES, err)
+			}
+			if uris := specURIs(verifiedKinds); len(uris) > 0 {
+				return nil, types.NewExitCodeError(constants.EXIT_CODE_SPEC_ISSUES, errors.Errorf("spec downloaded from %s with a checksum must not set uri, found %s", specURL, strings.Join(uris, ", ")))
+			}
+		}
+		rawSpecs = append(rawSpecs, rawSpec)
+	}
+
 	for _, v := range args {
 		if strings.HasPrefix(v, "secret/") {
 			// format secret/namespace-name/secret-name[/data-key]
@@ -218,6 +242,14 @@ func LoadFromCLIArgs(ctx context.Context, client kubernetes.Interface, args []st
 		kinds.Add(allURLSpecs)
 	}
 
+	if ref := vp.GetString("redactors-from-configmap"); ref != "" {
+		redactors, err := LoadRedactorsFromConfigMap(ctx, client, ref)
+		if err != nil {
+			return nil, types.NewExitCodeError(constants.EXIT_CODE_SPEC_ISSUES, err)
+		}
+		kinds.Add(redactors)
+	}
+
 	if vp.GetBool("load-cluster-specs") {
 		clusterKinds, err := LoadFromCluster(ctx, client, vp.GetStringSlice("selector"), vp.GetString("namespace"))
 		if err != nil {
@@ -233,6 +265,33 @@ func LoadFromCLIArgs(ctx context.Context, client kubernetes.Interface, args []st
 	return kinds, nil
 }
 
+// LoadFromURLWithChecksum downloads a spec from url. When checksum is set, the spec is only
+// returned if its hex encoded SHA-256 digest matches, so a tampered or unexpected spec is never run.
+func LoadFromURLWithChecksum(ctx context.Context, url string, checksum string) (string, error) {
+	if !util.IsURL(url) {
+		return "", errors.Errorf("%s is not a URL", url)
+	}
+
+	rawSpec, err := downloadFromHttpURL(ctx, url, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to download spec from %s", url)
+	}
+
+	if checksum == "" {
+		return rawSpec, nil
+	}
+
+	sum := sha256.Sum256([]byte(rawSpec))
+	actual := hex.EncodeToString(sum[:])
+	expected := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(checksum), "sha256:"))
+	if actual != expected {
+		return "", errors.Errorf("checksum mismatch for spec downloaded from %s: expected sha256 %s, got %s", url, expected, actual)
+	}
+
+	klog.V(1).Infof("Verified sha256 checksum of spec downloaded from %s", url)
+	return rawSpec, nil
+}
+
 func downloadFromHttpURL(ctx context.Context, url string, headers map[string]string) (string, error) {
 	hs := []string{}
 	for k, v := range headers {
@@ -444,3 +503,26 @@ func getFirstSpecOf(kinds *loader.TroubleshootKinds, name string) reflect.Value
 	}
 	return reflect.Value{}
 }
+
+// specURIs returns the .spec.uri values set in the kinds
+func specURIs(kinds *loader.TroubleshootKinds) []string {
+	uris := []string{}
+	obj := reflect.ValueOf(*kinds)
+	for i := 0; i < obj.NumField(); i++ {
+		field := obj.Field(i)
+		if field.Kind() != reflect.Slice {
+			continue
+		}
+		for count := 0; count < field.Len(); count++ {
+			specField := field.Index(count).FieldByName("Spec")
+			if !specField.IsValid() {
+				continue
+			}
+			uriField := specField.FieldByName("Uri")
+			if uriField.Kind() == reflect.String && uriField.String() != "" {
+				uris = append(uris, uriField.String())
+			}
+		}
+	}
+	return uris
+}
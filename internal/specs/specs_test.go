@@ -3,12 +3,15 @@ package specs
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -268,6 +271,100 @@ spec:
 	assert.Contains(t, output, "failed to download spec from URI")
 }
 
+func TestLoadFromCLIArgs_SpecFromURLWithChecksum(t *testing.T) {
+	spec := `apiVersion: troubleshoot.sh/v1beta2
+kind: HostCollector
+metadata:
+  name: cpu
+spec:
+  collectors:
+    - cpu: {}
+`
+	sum := sha256.Sum256([]byte(spec))
+	checksum := hex.EncodeToString(sum[:])
+
+	m := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(spec))
+	}))
+	defer m.Close()
+
+	tests := []struct {
+		name     string
+		checksum string
+		wantErr  bool
+	}{
+		{
+			name:     "no checksum",
+			checksum: "",
+		},
+		{
+			name:     "matching checksum",
+			checksum: checksum,
+		},
+		{
+			name:     "matching checksum with algorithm prefix",
+			checksum: "sha256:" + strings.ToUpper(checksum),
+		},
+		{
+			name:     "mismatched checksum",
+			checksum: strings.Repeat("0", 64),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := viper.New()
+			vp.Set("collector-spec-from-url", m.URL)
+			vp.Set("spec-checksum", tt.checksum)
+
+			client := testclient.NewSimpleClientset()
+			specs, err := LoadFromCLIArgs(context.Background(), client, []string{}, vp)
+			if tt.wantErr {
+				require.ErrorContains(t, err, "checksum mismatch")
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, specs.HostCollectorsV1Beta2, 1)
+		})
+	}
+}
+
+func TestLoadFromCLIArgs_SpecChecksumErrors(t *testing.T) {
+	spec := `apiVersion: troubleshoot.sh/v1beta2
+kind: SupportBundle
+metadata:
+  name: sb
+spec:
+  uri: https://example.com/unverified.yaml
+  collectors:
+    - clusterInfo: {}
+`
+	sum := sha256.Sum256([]byte(spec))
+
+	m := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(spec))
+	}))
+	defer m.Close()
+
+	t.Run("checksum without spec url", func(t *testing.T) {
+		vp := viper.New()
+		vp.Set("spec-checksum", hex.EncodeToString(sum[:]))
+
+		_, err := LoadFromCLIArgs(context.Background(), testclient.NewSimpleClientset(), []string{}, vp)
+		require.ErrorContains(t, err, "--spec-checksum requires --collector-spec-from-url")
+	})
+
+	t.Run("verified spec with uri", func(t *testing.T) {
+		vp := viper.New()
+		vp.Set("collector-spec-from-url", m.URL)
+		vp.Set("spec-checksum", hex.EncodeToString(sum[:]))
+
+		_, err := LoadFromCLIArgs(context.Background(), testclient.NewSimpleClientset(), []string{}, vp)
+		require.ErrorContains(t, err, "must not set uri, found https://example.com/unverified.yaml")
+	})
+}
+
 func TestLoadAdditionalSpecFromURIs(t *testing.T) {
 	m := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`apiVersion: troubleshoot.sh/v1beta2
@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type AnalyzeAdmissionWebhooks struct {
+	analyzer *troubleshootv1beta2.AdmissionWebhooksAnalyze
+}
+
+// admissionWebhookIssue is the template data available to outcome messages, the fields of the
+// webhook status and why the webhook is broken
+type admissionWebhookIssue struct {
+	collect.AdmissionWebhookStatus
+	Problem string
+}
+
+func (a *AnalyzeAdmissionWebhooks) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Admission Webhooks"
+}
+
+func (a *AnalyzeAdmissionWebhooks) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+// Analyze reports a result for each webhook that can not be called. Without outcomes a webhook
+// that fails closed fails the check, as the API requests it intercepts are rejected, and one that
+// is ignored warns.
+func (a *AnalyzeAdmissionWebhooks) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	collected, err := getFile(path.Join(constants.ADMISSION_WEBHOOKS_DIR, "reachability.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get contents of reachability.json")
+	}
+
+	var statuses []collect.AdmissionWebhookStatus
+	if err := json.Unmarshal(collected, &statuses); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal admission webhook reachability")
+	}
+
+	results := []*AnalyzeResult{}
+	for _, status := range statuses {
+		problem := admissionWebhookProblem(status)
+		if problem == "" {
+			continue
+		}
+		issue := admissionWebhookIssue{AdmissionWebhookStatus: status, Problem: problem}
+
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				Message: fmt.Sprintf("Admission webhook %s of %s %s can not be called: %s", status.Webhook, status.Kind, status.Configuration, problem),
+			}
+			// webhooks fail closed unless their failure policy is Ignore
+			if status.FailurePolicy == "Ignore" {
+				result.IsWarn = true
+			} else {
+				result.IsFail = true
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "admissionregistration.k8s.io/v1",
+			Kind:       status.Kind,
+			Name:       status.Configuration,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No broken admission webhook was found",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// admissionWebhookProblem returns why the API server can not call the webhook, or an empty string
+// when nothing shows it is broken. A service that could not be dialed because the collector ran
+// outside of the cluster is judged by its ready endpoints only.
+func admissionWebhookProblem(status collect.AdmissionWebhookStatus) string {
+	switch {
+	case status.ServiceFound != nil && !*status.ServiceFound:
+		return fmt.Sprintf("service %s does not exist", status.Service)
+	case status.ReadyEndpoints != nil && *status.ReadyEndpoints == 0:
+		return fmt.Sprintf("service %s has no ready endpoints", status.Service)
+	case status.Reachable != nil && !*status.Reachable:
+		return fmt.Sprintf("%s is not reachable: %s", status.Address, status.Error)
+	case status.CertificateError != "":
+		return fmt.Sprintf("the serving certificate is not trusted: %s", status.CertificateError)
+	}
+	return ""
+}
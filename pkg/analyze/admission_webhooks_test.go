@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeAdmissionWebhooks(t *testing.T) {
+	reachability := []byte(`[
+		{"kind":"ValidatingWebhookConfiguration","configuration":"policy","webhook":"validate.policy.io","failurePolicy":"Fail","service":"policy/policy","serviceFound":true,"readyEndpoints":2,"address":"policy.policy.svc:443","reachable":true},
+		{"kind":"ValidatingWebhookConfiguration","configuration":"policy","webhook":"removed.policy.io","failurePolicy":"Fail","service":"policy/removed","serviceFound":false,"address":"removed.policy.svc:443","error":"service policy/removed was not found"},
+		{"kind":"ValidatingWebhookConfiguration","configuration":"policy","webhook":"scaled-down.policy.io","failurePolicy":"Ignore","service":"policy/scaled-down","serviceFound":true,"readyEndpoints":0,"address":"scaled-down.policy.svc:443","error":"dial tcp: lookup scaled-down.policy.svc: no such host"},
+		{"kind":"MutatingWebhookConfiguration","configuration":"external","webhook":"external.example.com","url":"https://hooks.example.com/mutate","address":"hooks.example.com:443","reachable":false,"error":"dial tcp 10.1.2.3:443: connect: connection refused"},
+		{"kind":"MutatingWebhookConfiguration","configuration":"sidecar","webhook":"sidecar.mesh.io","service":"mesh/sidecar","serviceFound":true,"readyEndpoints":1,"address":"sidecar.mesh.svc:443","reachable":true,"certificateNotAfter":"2025-01-01T00:00:00Z","certificateError":"x509: certificate has expired or is not yet valid"}
+	]`)
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.AdmissionWebhooksAnalyze
+		reachability []byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:         "broken webhooks fail or warn by failure policy",
+			reachability: reachability,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Admission Webhooks",
+					Message:        "Admission webhook removed.policy.io of ValidatingWebhookConfiguration policy can not be called: service policy/removed does not exist",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "admissionregistration.k8s.io/v1", Kind: "ValidatingWebhookConfiguration", Name: "policy"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Admission Webhooks",
+					Message:        "Admission webhook scaled-down.policy.io of ValidatingWebhookConfiguration policy can not be called: service policy/scaled-down has no ready endpoints",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "admissionregistration.k8s.io/v1", Kind: "ValidatingWebhookConfiguration", Name: "policy"},
+				},
+				{
+					IsFail:         true,
+					Title:          "Admission Webhooks",
+					Message:        "Admission webhook external.example.com of MutatingWebhookConfiguration external can not be called: hooks.example.com:443 is not reachable: dial tcp 10.1.2.3:443: connect: connection refused",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "admissionregistration.k8s.io/v1", Kind: "MutatingWebhookConfiguration", Name: "external"},
+				},
+				{
+					IsFail:         true,
+					Title:          "Admission Webhooks",
+					Message:        "Admission webhook sidecar.mesh.io of MutatingWebhookConfiguration sidecar can not be called: the serving certificate is not trusted: x509: certificate has expired or is not yet valid",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "admissionregistration.k8s.io/v1", Kind: "MutatingWebhookConfiguration", Name: "sidecar"},
+				},
+			},
+		},
+		{
+			name: "outcome templates",
+			analyzer: troubleshootv1beta2.AdmissionWebhooksAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{CheckName: "Webhooks"},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{Warn: &troubleshootv1beta2.SingleOutcome{When: "true", Message: "{{ .Webhook }} ({{ .FailurePolicy }}): {{ .Problem }}"}},
+					{Pass: &troubleshootv1beta2.SingleOutcome{When: "false", Message: "All webhooks can be called"}},
+				},
+			},
+			reachability: []byte(`[
+				{"kind":"ValidatingWebhookConfiguration","configuration":"policy","webhook":"removed.policy.io","failurePolicy":"Fail","service":"policy/removed","serviceFound":false,"address":"removed.policy.svc:443"}
+			]`),
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Webhooks",
+					Message:        "removed.policy.io (Fail): service policy/removed does not exist",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "admissionregistration.k8s.io/v1", Kind: "ValidatingWebhookConfiguration", Name: "policy"},
+				},
+			},
+		},
+		{
+			name: "unresolved services with ready endpoints pass",
+			reachability: []byte(`[
+				{"kind":"ValidatingWebhookConfiguration","configuration":"policy","webhook":"validate.policy.io","service":"policy/policy","serviceFound":true,"readyEndpoints":2,"address":"policy.policy.svc:443","error":"dial tcp: lookup policy.policy.svc: no such host"}
+			]`),
+			expectResult: []AnalyzeResult{
+				{IsPass: true, Title: "Admission Webhooks", Message: "No broken admission webhook was found"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				req.Equal("cluster-resources/webhooks/reachability.json", n)
+				return test.reachability, nil
+			}
+
+			a := &AnalyzeAdmissionWebhooks{
+				analyzer: &test.analyzer,
+			}
+
+			results, err := a.Analyze(getFile, nil)
+			req.NoError(err)
+
+			actual := []AnalyzeResult{}
+			for _, result := range results {
+				actual = append(actual, *result)
+			}
+			req.Equal(test.expectResult, actual)
+		})
+	}
+}
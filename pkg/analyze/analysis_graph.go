@@ -0,0 +1,284 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// AnalysisGraphOwns links an owner to the object it owns, e.g. a deployment to its replica set
+	AnalysisGraphOwns = "owns"
+	// AnalysisGraphSelects links a service to a pod its selector matches
+	AnalysisGraphSelects = "selects"
+	// AnalysisGraphRecorded links an object to an event recorded about it
+	AnalysisGraphRecorded = "recorded"
+
+	// analysisGraphEventsPerObject is how many of the most recent events of an object are linked
+	analysisGraphEventsPerObject = 5
+)
+
+// AnalysisGraph links the objects involved in failures and warnings to the objects around them,
+// so that a deployment can be shown with its failing pods and the events of those pods. Nodes are
+// sorted by ID and edges by their endpoints.
+type AnalysisGraph struct {
+	Nodes []AnalysisGraphNode `json:"nodes" yaml:"nodes"`
+	Edges []AnalysisGraphEdge `json:"edges" yaml:"edges"`
+}
+
+// AnalysisGraphNode is an object of the graph, its ID is its kind, namespace and name, e.g.
+// Pod default/api-0
+type AnalysisGraphNode struct {
+	ID     string                 `json:"id" yaml:"id"`
+	Object corev1.ObjectReference `json:"object" yaml:"object"`
+	// Severity is fail or warn, the worst of the findings on the object
+	Severity string                 `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Findings []AnalysisGraphFinding `json:"findings,omitempty" yaml:"findings,omitempty"`
+	// Status is the status of a pod as shown by kubectl, e.g. CrashLoopBackOff
+	Status string `json:"status,omitempty" yaml:"status,omitempty"`
+	// Event is set on the nodes of events
+	Event *AnalysisGraphEvent `json:"event,omitempty" yaml:"event,omitempty"`
+}
+
+type AnalysisGraphFinding struct {
+	Title    string `json:"title" yaml:"title"`
+	Message  string `json:"message" yaml:"message"`
+	Severity string `json:"severity" yaml:"severity"`
+}
+
+type AnalysisGraphEvent struct {
+	Type     string      `json:"type" yaml:"type"`
+	Reason   string      `json:"reason" yaml:"reason"`
+	Message  string      `json:"message" yaml:"message"`
+	Count    int32       `json:"count,omitempty" yaml:"count,omitempty"`
+	LastSeen metav1.Time `json:"lastSeen,omitempty" yaml:"lastSeen,omitempty"`
+}
+
+// AnalysisGraphEdge links two nodes by their IDs, Relation is one of owns, selects or recorded
+type AnalysisGraphEdge struct {
+	From     string `json:"from" yaml:"from"`
+	To       string `json:"to" yaml:"to"`
+	Relation string `json:"relation" yaml:"relation"`
+}
+
+// BuildAnalysisGraphLocal builds the graph of the analysis of a local bundle, see BuildAnalysisGraph
+func BuildAnalysisGraphLocal(localBundlePath string, results []*AnalyzeResult) (*AnalysisGraph, error) {
+	rootDir, err := FindBundleRootDir(localBundlePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find root dir")
+	}
+
+	fcp := fileContentProvider{rootDir: rootDir}
+	return BuildAnalysisGraph(results, fcp.getFileContents, fcp.getChildFileContents)
+}
+
+// BuildAnalysisGraph returns the objects involved in the failures and warnings of the analysis and
+// the collected pods that are unhealthy, linked to the owners of the pods, the services selecting
+// them and the most recent events of every object. Only resources the cluster resources collector
+// saved are read. Suppressed results and results without an involved object are left out.
+func BuildAnalysisGraph(results []*AnalyzeResult, getFile getCollectedFileContents, findFiles getChildCollectedFileContents) (*AnalysisGraph, error) {
+	graph := newAnalysisGraphBuilder()
+
+	for _, result := range results {
+		if result == nil || result.InvolvedObject == nil || result.Suppression != nil || !(result.IsFail || result.IsWarn) {
+			continue
+		}
+		graph.addFinding(result)
+	}
+
+	pods, err := readCollectedPods(findFiles, nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	failingPods := []corev1.Pod{}
+	for _, pod := range pods {
+		podRef := corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name}
+		if !graph.hasNode(podRef) && !k8sutil.IsPodUnhealthy(&pod) {
+			continue
+		}
+		failingPods = append(failingPods, pod)
+
+		podID := graph.addObject(podRef)
+		graph.nodes[podID].Status, _ = k8sutil.GetPodStatusReason(&pod)
+
+		// owners are listed with each replica set before its deployment
+		ownedID := podID
+		for _, owner := range podOwners(pod) {
+			ownerID := graph.addObject(owner)
+			if owner.Kind == "Deployment" {
+				graph.addEdge(ownerID, ownedID, AnalysisGraphOwns)
+				continue
+			}
+			graph.addEdge(ownerID, podID, AnalysisGraphOwns)
+			ownedID = ownerID
+		}
+	}
+
+	if len(failingPods) > 0 {
+		services, err := readCollectedServices(findFiles, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range services {
+			if len(service.Spec.Selector) == 0 {
+				continue
+			}
+			selector := labels.SelectorFromSet(service.Spec.Selector)
+			for _, pod := range failingPods {
+				if pod.Namespace != service.Namespace || !selector.Matches(labels.Set(pod.Labels)) {
+					continue
+				}
+				serviceID := graph.addObject(corev1.ObjectReference{APIVersion: "v1", Kind: "Service", Namespace: service.Namespace, Name: service.Name})
+				graph.addEdge(serviceID, rootCauseObjectKey(corev1.ObjectReference{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name}), AnalysisGraphSelects)
+			}
+		}
+	}
+
+	if len(graph.nodes) > 0 {
+		events, err := readCollectedEvents(findFiles, nil)
+		if err != nil {
+			return nil, err
+		}
+		graph.addEvents(events)
+	}
+
+	return graph.build(), nil
+}
+
+// analysisGraphBuilder collects the nodes and edges of a graph, each added once
+type analysisGraphBuilder struct {
+	nodes map[string]*AnalysisGraphNode
+	edges map[AnalysisGraphEdge]bool
+}
+
+func newAnalysisGraphBuilder() *analysisGraphBuilder {
+	return &analysisGraphBuilder{
+		nodes: map[string]*AnalysisGraphNode{},
+		edges: map[AnalysisGraphEdge]bool{},
+	}
+}
+
+func (g *analysisGraphBuilder) hasNode(object corev1.ObjectReference) bool {
+	_, ok := g.nodes[rootCauseObjectKey(object)]
+	return ok
+}
+
+// addObject adds a node for the object unless there is one, and returns its ID
+func (g *analysisGraphBuilder) addObject(object corev1.ObjectReference) string {
+	id := rootCauseObjectKey(object)
+	if _, ok := g.nodes[id]; !ok {
+		g.nodes[id] = &AnalysisGraphNode{
+			ID: id,
+			Object: corev1.ObjectReference{
+				APIVersion: object.APIVersion,
+				Kind:       object.Kind,
+				Namespace:  object.Namespace,
+				Name:       object.Name,
+			},
+		}
+	}
+	return id
+}
+
+func (g *analysisGraphBuilder) addFinding(result *AnalyzeResult) {
+	node := g.nodes[g.addObject(*result.InvolvedObject)]
+
+	severity := "warn"
+	if result.IsFail {
+		severity = "fail"
+	}
+	if node.Severity != "fail" {
+		node.Severity = severity
+	}
+	node.Findings = append(node.Findings, AnalysisGraphFinding{
+		Title:    result.Title,
+		Message:  result.Message,
+		Severity: severity,
+	})
+}
+
+func (g *analysisGraphBuilder) addEdge(from, to, relation string) {
+	g.edges[AnalysisGraphEdge{From: from, To: to, Relation: relation}] = true
+}
+
+// addEvents links each object of the graph to its most recent events
+func (g *analysisGraphBuilder) addEvents(events []corev1.Event) {
+	byObject := map[string][]corev1.Event{}
+	for _, event := range events {
+		id := rootCauseObjectKey(event.InvolvedObject)
+		if node, ok := g.nodes[id]; ok && node.Event == nil {
+			byObject[id] = append(byObject[id], event)
+		}
+	}
+
+	for id, objectEvents := range byObject {
+		sort.SliceStable(objectEvents, func(i, j int) bool {
+			return eventLastSeen(objectEvents[i]).After(eventLastSeen(objectEvents[j]).Time)
+		})
+		if len(objectEvents) > analysisGraphEventsPerObject {
+			objectEvents = objectEvents[:analysisGraphEventsPerObject]
+		}
+
+		for _, event := range objectEvents {
+			eventID := g.addObject(corev1.ObjectReference{APIVersion: "v1", Kind: "Event", Namespace: event.Namespace, Name: event.Name})
+			g.nodes[eventID].Event = &AnalysisGraphEvent{
+				Type:     event.Type,
+				Reason:   event.Reason,
+				Message:  event.Message,
+				Count:    event.Count,
+				LastSeen: eventLastSeen(event),
+			}
+			g.addEdge(id, eventID, AnalysisGraphRecorded)
+		}
+	}
+}
+
+func (g *analysisGraphBuilder) build() *AnalysisGraph {
+	graph := &AnalysisGraph{
+		Nodes: []AnalysisGraphNode{},
+		Edges: []AnalysisGraphEdge{},
+	}
+
+	for _, node := range g.nodes {
+		graph.Nodes = append(graph.Nodes, *node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].ID < graph.Nodes[j].ID
+	})
+
+	for edge := range g.edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+
+	return graph
+}
+
+// eventLastSeen returns when an event last occurred. Events created through events.k8s.io/v1 only
+// set the event time, and events that were never repeated may only have their creation time.
+func eventLastSeen(event corev1.Event) metav1.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp
+	case !event.EventTime.IsZero():
+		return metav1.NewTime(event.EventTime.Time)
+	}
+	return event.CreationTimestamp
+}
@@ -0,0 +1,139 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildAnalysisGraph(t *testing.T) {
+	evictedPod := &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "api-7d9f8c6b5-k2x4p"}
+	deployment := &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "api"}
+
+	results := []*AnalyzeResult{
+		{
+			IsWarn:         true,
+			Title:          "Deployment Status",
+			Message:        "The api deployment has 1 of 3 replicas available",
+			InvolvedObject: deployment,
+		},
+		{
+			IsFail:         true,
+			Title:          "Pod default/api-7d9f8c6b5-k2x4p status",
+			Message:        "Pod default/api-7d9f8c6b5-k2x4p status is Evicted",
+			InvolvedObject: evictedPod,
+		},
+		{
+			IsPass:         true,
+			Title:          "Deployment Status",
+			Message:        "The worker deployment is ready",
+			InvolvedObject: &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "worker"},
+		},
+		{
+			IsWarn:         true,
+			Title:          "Deployment Replicas",
+			Message:        "The api deployment runs a single replica per zone",
+			InvolvedObject: deployment,
+			Suppression:    &Suppression{Title: "Deployment Replicas"},
+		},
+	}
+
+	services := `{"kind": "ServiceList", "apiVersion": "v1", "items": [
+		{"metadata": {"name": "api", "namespace": "default"}, "spec": {"selector": {"app": "api"}}},
+		{"metadata": {"name": "worker", "namespace": "default"}, "spec": {"selector": {"app": "worker"}}},
+		{"metadata": {"name": "kubernetes", "namespace": "default"}, "spec": {}}
+	]}`
+
+	// the six events of the worker pod are listed oldest first, the last one only has an event time
+	events := `{"kind": "EventList", "apiVersion": "v1", "items": [
+		{"metadata": {"name": "api-7d9f8c6b5-k2x4p.1", "namespace": "default"}, "involvedObject": {"kind": "Pod", "namespace": "default", "name": "api-7d9f8c6b5-k2x4p"}, "type": "Warning", "reason": "Evicted", "message": "The node was low on resource: ephemeral-storage.", "lastTimestamp": "2026-10-14T09:10:00Z"},
+		{"metadata": {"name": "api-7d9f8c6b5-z5m1r.1", "namespace": "default"}, "involvedObject": {"kind": "Pod", "namespace": "default", "name": "api-7d9f8c6b5-z5m1r"}, "type": "Normal", "reason": "Started", "message": "Started container api", "lastTimestamp": "2026-10-14T09:11:15Z"},
+		{"metadata": {"name": "worker-5c8d7f9b4-h7t2j.1", "namespace": "default"}, "involvedObject": {"kind": "Pod", "namespace": "default", "name": "worker-5c8d7f9b4-h7t2j"}, "type": "Normal", "reason": "Scheduled", "message": "Successfully assigned default/worker-5c8d7f9b4-h7t2j", "lastTimestamp": "2026-10-14T08:00:00Z"},
+		{"metadata": {"name": "worker-5c8d7f9b4-h7t2j.2", "namespace": "default"}, "involvedObject": {"kind": "Pod", "namespace": "default", "name": "worker-5c8d7f9b4-h7t2j"}, "type": "Normal", "reason": "Pulled", "message": "Container image already present on machine", "lastTimestamp": "2026-10-14T08:00:05Z"},
+		{"metadata": {"name": "worker-5c8d7f9b4-h7t2j.3", "namespace": "default"}, "involvedObject": {"kind": "Pod", "namespace": "default", "name": "worker-5c8d7f9b4-h7t2j"}, "type": "Normal", "reason": "Created", "message": "Created container worker", "lastTimestamp": "2026-10-14T08:00:06Z"},
+		{"metadata": {"name": "worker-5c8d7f9b4-h7t2j.4", "namespace": "default"}, "involvedObject": {"kind": "Pod", "namespace": "default", "name": "worker-5c8d7f9b4-h7t2j"}, "type": "Normal", "reason": "Started", "message": "Started container worker", "lastTimestamp": "2026-10-14T08:00:07Z"},
+		{"metadata": {"name": "worker-5c8d7f9b4-h7t2j.5", "namespace": "default"}, "involvedObject": {"kind": "Pod", "namespace": "default", "name": "worker-5c8d7f9b4-h7t2j"}, "type": "Warning", "reason": "BackOff", "message": "Back-off restarting failed container", "count": 63, "lastTimestamp": "2026-10-14T09:30:00Z"},
+		{"metadata": {"name": "worker-5c8d7f9b4-h7t2j.6", "namespace": "default"}, "involvedObject": {"kind": "Pod", "namespace": "default", "name": "worker-5c8d7f9b4-h7t2j"}, "type": "Warning", "reason": "Unhealthy", "message": "Liveness probe failed", "eventTime": "2026-10-14T09:31:00.000000Z"}
+	]}`
+
+	findFiles := func(n string, _ []string) (map[string][]byte, error) {
+		switch n {
+		case "cluster-resources/pods/*.json":
+			return map[string][]byte{"cluster-resources/pods/default.json": []byte(rootCausesPodsDefault)}, nil
+		case "cluster-resources/services/*.json":
+			return map[string][]byte{"cluster-resources/services/default.json": []byte(services)}, nil
+		case "cluster-resources/events/*.json":
+			return map[string][]byte{"cluster-resources/events/default.json": []byte(events)}, nil
+		}
+		t.Fatalf("unexpected files %s", n)
+		return nil, nil
+	}
+
+	graph, err := BuildAnalysisGraph(results, nil, findFiles)
+	require.NoError(t, err)
+
+	ids := []string{}
+	for _, node := range graph.Nodes {
+		ids = append(ids, node.ID)
+	}
+	require.Equal(t, []string{
+		"Deployment default/api",
+		"Deployment default/worker",
+		"Event default/api-7d9f8c6b5-k2x4p.1",
+		"Event default/worker-5c8d7f9b4-h7t2j.2",
+		"Event default/worker-5c8d7f9b4-h7t2j.3",
+		"Event default/worker-5c8d7f9b4-h7t2j.4",
+		"Event default/worker-5c8d7f9b4-h7t2j.5",
+		"Event default/worker-5c8d7f9b4-h7t2j.6",
+		"Pod default/api-7d9f8c6b5-k2x4p",
+		"Pod default/api-7d9f8c6b5-q8w3n",
+		"Pod default/worker-5c8d7f9b4-h7t2j",
+		"ReplicaSet default/api-7d9f8c6b5",
+		"ReplicaSet default/worker-5c8d7f9b4",
+		"Service default/api",
+		"Service default/worker",
+	}, ids)
+
+	require.Equal(t, []AnalysisGraphEdge{
+		{From: "Deployment default/api", To: "ReplicaSet default/api-7d9f8c6b5", Relation: "owns"},
+		{From: "Deployment default/worker", To: "ReplicaSet default/worker-5c8d7f9b4", Relation: "owns"},
+		{From: "Pod default/api-7d9f8c6b5-k2x4p", To: "Event default/api-7d9f8c6b5-k2x4p.1", Relation: "recorded"},
+		{From: "Pod default/worker-5c8d7f9b4-h7t2j", To: "Event default/worker-5c8d7f9b4-h7t2j.2", Relation: "recorded"},
+		{From: "Pod default/worker-5c8d7f9b4-h7t2j", To: "Event default/worker-5c8d7f9b4-h7t2j.3", Relation: "recorded"},
+		{From: "Pod default/worker-5c8d7f9b4-h7t2j", To: "Event default/worker-5c8d7f9b4-h7t2j.4", Relation: "recorded"},
+		{From: "Pod default/worker-5c8d7f9b4-h7t2j", To: "Event default/worker-5c8d7f9b4-h7t2j.5", Relation: "recorded"},
+		{From: "Pod default/worker-5c8d7f9b4-h7t2j", To: "Event default/worker-5c8d7f9b4-h7t2j.6", Relation: "recorded"},
+		{From: "ReplicaSet default/api-7d9f8c6b5", To: "Pod default/api-7d9f8c6b5-k2x4p", Relation: "owns"},
+		{From: "ReplicaSet default/api-7d9f8c6b5", To: "Pod default/api-7d9f8c6b5-q8w3n", Relation: "owns"},
+		{From: "ReplicaSet default/worker-5c8d7f9b4", To: "Pod default/worker-5c8d7f9b4-h7t2j", Relation: "owns"},
+		{From: "Service default/api", To: "Pod default/api-7d9f8c6b5-k2x4p", Relation: "selects"},
+		{From: "Service default/api", To: "Pod default/api-7d9f8c6b5-q8w3n", Relation: "selects"},
+		{From: "Service default/worker", To: "Pod default/worker-5c8d7f9b4-h7t2j", Relation: "selects"},
+	}, graph.Edges)
+
+	require.Equal(t, AnalysisGraphNode{
+		ID:       "Deployment default/api",
+		Object:   *deployment,
+		Severity: "warn",
+		Findings: []AnalysisGraphFinding{
+			{Title: "Deployment Status", Message: "The api deployment has 1 of 3 replicas available", Severity: "warn"},
+		},
+	}, graph.Nodes[0])
+	require.Equal(t, AnalysisGraphNode{
+		ID:       "Pod default/api-7d9f8c6b5-k2x4p",
+		Object:   *evictedPod,
+		Severity: "fail",
+		Findings: []AnalysisGraphFinding{
+			{Title: "Pod default/api-7d9f8c6b5-k2x4p status", Message: "Pod default/api-7d9f8c6b5-k2x4p status is Evicted", Severity: "fail"},
+		},
+		Status: "Evicted",
+	}, graph.Nodes[8])
+	require.Equal(t, "CrashLoopBackOff", graph.Nodes[10].Status)
+	event := graph.Nodes[7].Event
+	require.NotNil(t, event)
+	require.Equal(t, "Unhealthy", event.Reason)
+	require.True(t, event.LastSeen.Equal(&metav1.Time{Time: time.Date(2026, 10, 14, 9, 31, 0, 0, time.UTC)}))
+}
@@ -29,6 +29,13 @@ type AnalyzeResult struct {
 	IsFail bool
 	IsWarn bool
 	Strict bool
+	// Blocking is set on warnings from checks marked as blocking, preflights treat them as failures
+	Blocking bool
+	// Suppression is the ignore-list entry matching a failure or warning, suppressed results are
+	// informational and are not counted as failures or warnings
+	Suppression *Suppression
+	// Remediation are the steps of the outcome the result was built from
+	Remediation []troubleshootv1beta2.RemediationStep
 
 	Title   string
 	Message string
@@ -94,6 +101,9 @@ func HostAnalyze(
 		klog.Errorf("no outcome matched for %q host analyzer", analyzer.Title())
 	}
 
+	markBlockingWarnings(result, getBlockingFlag(hostAnalyzer))
+	attachRemediation(result, getOutcomes(hostAnalyzer))
+
 	return result
 }
 
@@ -143,6 +153,12 @@ func Analyze(
 		return nil, nil
 	}
 
+	if !analyzesMetadataOnly(analyzerInst) && isMetadataOnlyBundle(getFile) {
+		klog.Infof("skipping %q analyzer, the support bundle only has metadata", analyzerInst.Title())
+		span.SetAttributes(attribute.Bool(constants.EXCLUDED, true))
+		return nil, nil
+	}
+
 	results, err := analyzerInst.Analyze(getFile, findFiles)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
@@ -157,6 +173,9 @@ func Analyze(
 		klog.Errorf("no outcome matched for %q analyzer", analyzerInst.Title())
 	}
 
+	markBlockingWarnings(results, getBlockingFlag(analyzer))
+	attachRemediation(results, getOutcomes(analyzer))
+
 	return results, nil
 }
 
@@ -182,6 +201,40 @@ func GetExcludeFlag(analyzer *troubleshootv1beta2.Analyze) *multitype.BoolOrStri
 	return nil
 }
 
+// getBlockingFlag returns the blocking flag of the analyzer set in an Analyze or HostAnalyze spec
+func getBlockingFlag(spec interface{}) *multitype.BoolOrString {
+	reflected := reflect.ValueOf(spec).Elem()
+	for i := 0; i < reflected.NumField(); i++ {
+		if reflected.Field(i).Kind() != reflect.Ptr || reflected.Field(i).IsNil() {
+			continue
+		}
+
+		field := reflect.Indirect(reflected.Field(i)).FieldByName("Blocking")
+		if !field.IsValid() {
+			continue
+		}
+		blocking, ok := field.Interface().(*multitype.BoolOrString)
+		if !ok {
+			continue
+		}
+		return blocking
+	}
+
+	return nil
+}
+
+// markBlockingWarnings flags the warnings of a check marked as blocking
+func markBlockingWarnings(results []*AnalyzeResult, blocking *multitype.BoolOrString) {
+	if !blocking.BoolOrDefaultFalse() {
+		return
+	}
+	for _, result := range results {
+		if result.IsWarn {
+			result.Blocking = true
+		}
+	}
+}
+
 type Analyzer interface {
 	Title() string
 	IsExcluded() (bool, error)
@@ -236,6 +289,8 @@ func GetAnalyzer(analyzer *troubleshootv1beta2.Analyze) Analyzer {
 		return &AnalyzeMssql{analyzer: analyzer.Mssql}
 	case analyzer.Redis != nil:
 		return &AnalyzeRedis{analyzer: analyzer.Redis}
+	case analyzer.Mongodb != nil:
+		return &AnalyzeMongodb{analyzer: analyzer.Mongodb}
 	case analyzer.CephStatus != nil:
 		return &AnalyzeCephStatus{analyzer: analyzer.CephStatus}
 	case analyzer.Velero != nil:
@@ -260,6 +315,74 @@ func GetAnalyzer(analyzer *troubleshootv1beta2.Analyze) Analyzer {
 		return &AnalyzeNodeMetrics{analyzer: analyzer.NodeMetrics}
 	case analyzer.HTTP != nil:
 		return &AnalyzeHTTPAnalyze{analyzer: analyzer.HTTP}
+	case analyzer.WaitForFirstConsumer != nil:
+		return &AnalyzeWaitForFirstConsumer{analyzer: analyzer.WaitForFirstConsumer}
+	case analyzer.GitOps != nil:
+		return &AnalyzeGitOps{analyzer: analyzer.GitOps}
+	case analyzer.ImageArchitecture != nil:
+		return &AnalyzeImageArchitecture{analyzer: analyzer.ImageArchitecture}
+	case analyzer.TopologySpread != nil:
+		return &AnalyzeTopologySpread{analyzer: analyzer.TopologySpread}
+	case analyzer.CSR != nil:
+		return &AnalyzeCSR{analyzer: analyzer.CSR}
+	case analyzer.GoldenSnapshot != nil:
+		return &AnalyzeGoldenSnapshot{analyzer: analyzer.GoldenSnapshot}
+	case analyzer.OOMKilled != nil:
+		return &AnalyzeOOMKilled{analyzer: analyzer.OOMKilled}
+	case analyzer.APIWarnings != nil:
+		return &AnalyzeAPIWarnings{analyzer: analyzer.APIWarnings}
+	case analyzer.ConfigMapDrift != nil:
+		return &AnalyzeConfigMapDrift{analyzer: analyzer.ConfigMapDrift}
+	case analyzer.VPA != nil:
+		return &AnalyzeVPA{analyzer: analyzer.VPA}
+	case analyzer.ConfigMounts != nil:
+		return &AnalyzeConfigMounts{analyzer: analyzer.ConfigMounts}
+	case analyzer.HighAvailability != nil:
+		return &AnalyzeHighAvailability{analyzer: analyzer.HighAvailability}
+	case analyzer.OrphanedResources != nil:
+		return &AnalyzeOrphanedResources{analyzer: analyzer.OrphanedResources}
+	case analyzer.LastAppliedDrift != nil:
+		return &AnalyzeLastAppliedDrift{analyzer: analyzer.LastAppliedDrift}
+	case analyzer.APIServerFeatures != nil:
+		return &AnalyzeAPIServerFeatures{analyzer: analyzer.APIServerFeatures}
+	case analyzer.ExposedServices != nil:
+		return &AnalyzeExposedServices{analyzer: analyzer.ExposedServices}
+	case analyzer.ImagePolicy != nil:
+		return &AnalyzeImagePolicy{analyzer: analyzer.ImagePolicy}
+	case analyzer.OverPermissiveBindings != nil:
+		return &AnalyzeOverPermissiveBindings{analyzer: analyzer.OverPermissiveBindings}
+	case analyzer.DuplicateAPIVersions != nil:
+		return &AnalyzeDuplicateAPIVersions{analyzer: analyzer.DuplicateAPIVersions}
+	case analyzer.MissingPDB != nil:
+		return &AnalyzeMissingPDB{analyzer: analyzer.MissingPDB}
+	case analyzer.ServiceMesh != nil:
+		return &AnalyzeServiceMesh{analyzer: analyzer.ServiceMesh}
+	case analyzer.ClusterAutoscaler != nil:
+		return &AnalyzeClusterAutoscaler{analyzer: analyzer.ClusterAutoscaler}
+	case analyzer.CordonedNodes != nil:
+		return &AnalyzeCordonedNodes{analyzer: analyzer.CordonedNodes}
+	case analyzer.RequiredNamespaces != nil:
+		return &AnalyzeRequiredNamespaces{analyzer: analyzer.RequiredNamespaces}
+	case analyzer.WritableHostPath != nil:
+		return &AnalyzeWritableHostPath{analyzer: analyzer.WritableHostPath}
+	case analyzer.KubeStateMetrics != nil:
+		return &AnalyzeKubeStateMetrics{analyzer: analyzer.KubeStateMetrics}
+	case analyzer.LogTimestamps != nil:
+		return &AnalyzeLogTimestamps{analyzer: analyzer.LogTimestamps}
+	case analyzer.NodePodCapacity != nil:
+		return &AnalyzeNodePodCapacity{analyzer: analyzer.NodePodCapacity}
+	case analyzer.ReadinessGates != nil:
+		return &AnalyzeReadinessGates{analyzer: analyzer.ReadinessGates}
+	case analyzer.FieldManagers != nil:
+		return &AnalyzeFieldManagers{analyzer: analyzer.FieldManagers}
+	case analyzer.RBACGaps != nil:
+		return &AnalyzeRBACGaps{analyzer: analyzer.RBACGaps}
+	case analyzer.ClusterVersionCompatibility != nil:
+		return &AnalyzeClusterVersionCompatibility{analyzer: analyzer.ClusterVersionCompatibility}
+	case analyzer.Prometheus != nil:
+		return &AnalyzePrometheus{analyzer: analyzer.Prometheus}
+	case analyzer.AdmissionWebhooks != nil:
+		return &AnalyzeAdmissionWebhooks{analyzer: analyzer.AdmissionWebhooks}
 	default:
 		return nil
 	}
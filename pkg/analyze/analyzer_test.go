@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/pkg/errors"
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
 	"github.com/replicatedhq/troubleshoot/pkg/multitype"
 	"github.com/stretchr/testify/assert"
@@ -67,6 +68,121 @@ func Test_GetExcludeFlag(t *testing.T) {
 	}
 }
 
+func TestAnalyzeBlockingWarnings(t *testing.T) {
+	textAnalyze := func(blocking *multitype.BoolOrString, outcome *troubleshootv1beta2.Outcome) *troubleshootv1beta2.Analyze {
+		return &troubleshootv1beta2.Analyze{
+			TextAnalyze: &troubleshootv1beta2.TextAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					Blocking: blocking,
+				},
+				CollectorName: "text-collector",
+				FileName:      "cfile.txt",
+				RegexPattern:  "success",
+				Outcomes:      []*troubleshootv1beta2.Outcome{outcome},
+			},
+		}
+	}
+	warn := &troubleshootv1beta2.Outcome{
+		Warn: &troubleshootv1beta2.SingleOutcome{Message: "advisory"},
+	}
+	fail := &troubleshootv1beta2.Outcome{
+		Fail: &troubleshootv1beta2.SingleOutcome{Message: "failed"},
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     *troubleshootv1beta2.Analyze
+		wantWarn     bool
+		wantBlocking bool
+	}{
+		{
+			name:         "warning from a blocking check",
+			analyzer:     textAnalyze(multitype.FromBool(true), warn),
+			wantWarn:     true,
+			wantBlocking: true,
+		},
+		{
+			name:         "warning from an advisory check",
+			analyzer:     textAnalyze(multitype.FromBool(false), warn),
+			wantWarn:     true,
+			wantBlocking: false,
+		},
+		{
+			name:         "warning without the flag",
+			analyzer:     textAnalyze(nil, warn),
+			wantWarn:     true,
+			wantBlocking: false,
+		},
+		{
+			name:         "failures are not marked",
+			analyzer:     textAnalyze(multitype.FromString("true"), fail),
+			wantWarn:     false,
+			wantBlocking: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(string) ([]byte, error) {
+				return []byte("no match"), nil
+			}
+			findFiles := func(string, []string) (map[string][]byte, error) {
+				return map[string][]byte{"text-collector/cfile.txt": []byte("no match")}, nil
+			}
+
+			results, err := Analyze(context.Background(), test.analyzer, getFile, findFiles)
+			req.NoError(err)
+			req.Len(results, 1)
+			assert.Equal(t, test.wantWarn, results[0].IsWarn)
+			assert.Equal(t, test.wantBlocking, results[0].Blocking)
+		})
+	}
+}
+
+func TestAnalyzeMetadataOnlyBundle(t *testing.T) {
+	files := map[string][]byte{
+		"metadata-only.json":                []byte(`{"included": [], "excluded": []}`),
+		"cluster-info/cluster_version.json": []byte(`{"info": {"major": "1", "minor": "31", "gitVersion": "v1.31.2"}, "string": "v1.31.2"}`),
+	}
+	getFile := func(n string) ([]byte, error) {
+		if b, ok := files[n]; ok {
+			return b, nil
+		}
+		return nil, errors.Errorf("file %s was not collected", n)
+	}
+	findFiles := func(string, []string) (map[string][]byte, error) {
+		return map[string][]byte{}, nil
+	}
+
+	textAnalyze := &troubleshootv1beta2.Analyze{
+		TextAnalyze: &troubleshootv1beta2.TextAnalyze{
+			CollectorName: "logs",
+			FileName:      "app.log",
+			RegexPattern:  "error",
+			Outcomes: []*troubleshootv1beta2.Outcome{
+				{Fail: &troubleshootv1beta2.SingleOutcome{When: "true", Message: "errors logged"}},
+			},
+		},
+	}
+	results, err := Analyze(context.Background(), textAnalyze, getFile, findFiles)
+	require.NoError(t, err)
+	assert.Empty(t, results, "analyzers that need data are skipped")
+
+	clusterVersion := &troubleshootv1beta2.Analyze{
+		ClusterVersion: &troubleshootv1beta2.ClusterVersion{
+			Outcomes: []*troubleshootv1beta2.Outcome{
+				{Pass: &troubleshootv1beta2.SingleOutcome{When: ">= 1.20.0", Message: "supported"}},
+			},
+		},
+	}
+	results, err = Analyze(context.Background(), clusterVersion, getFile, findFiles)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].IsPass)
+}
+
 func TestAnalyzeWithNilAnalyzer(t *testing.T) {
 	got, err := Analyze(context.Background(), nil, nil, nil)
 	assert.Error(t, err)
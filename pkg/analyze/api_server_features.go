@@ -0,0 +1,296 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	apiServerFeatureGate      = "FeatureGate"
+	apiServerAdmissionPlugin  = "AdmissionPlugin"
+	apiServerFeatureDisabled  = "Disabled"
+	apiServerFeatureNotSet    = "NotSet"
+	apiServerFeatureNotActive = "NotEnabled"
+	apiServerFlagsNotVisible  = "NotVisible"
+)
+
+// defaultAdmissionPlugins are enabled by kube-apiserver unless they are listed in
+// --disable-admission-plugins
+var defaultAdmissionPlugins = []string{
+	"CertificateApproval",
+	"CertificateSigning",
+	"CertificateSubjectRestriction",
+	"ClusterTrustBundleAttest",
+	"DefaultIngressClass",
+	"DefaultStorageClass",
+	"DefaultTolerationSeconds",
+	"LimitRanger",
+	"MutatingAdmissionWebhook",
+	"NamespaceLifecycle",
+	"PersistentVolumeClaimResize",
+	"PodSecurity",
+	"Priority",
+	"ResourceQuota",
+	"RuntimeClass",
+	"ServiceAccount",
+	"StorageObjectInUseProtection",
+	"TaintNodesByCondition",
+	"ValidatingAdmissionPolicy",
+	"ValidatingAdmissionWebhook",
+}
+
+type AnalyzeAPIServerFeatures struct {
+	analyzer *troubleshootv1beta2.APIServerFeaturesAnalyze
+}
+
+// apiServerFeatureIssue is the template data available to outcome messages
+type apiServerFeatureIssue struct {
+	// Type is FeatureGate or AdmissionPlugin, it is empty when the apiserver flags are not visible
+	Type string
+	Name string
+	// State is one of Disabled, NotEnabled, NotSet or NotVisible. NotSet feature gates are left at
+	// the default for the Kubernetes version, which may or may not enable them.
+	State string
+	// APIServers is the comma separated list of the kube-apiserver pods the issue was found on
+	APIServers string
+	pods       []corev1.Pod
+}
+
+// apiServerFlags holds the parsed feature gate and admission plugin flags of one kube-apiserver
+type apiServerFlags struct {
+	featureGates             map[string]bool
+	enableAdmissionPlugins   []string
+	disabledAdmissionPlugins []string
+}
+
+func (a *AnalyzeAPIServerFeatures) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "API Server Feature Gates and Admission Plugins"
+}
+
+func (a *AnalyzeAPIServerFeatures) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeAPIServerFeatures) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	pods, err := readCollectedPods(findFiles, []string{metav1.NamespaceSystem})
+	if err != nil {
+		return nil, err
+	}
+
+	apiServers := []corev1.Pod{}
+	for _, pod := range pods {
+		if isKubeAPIServerPod(pod) {
+			apiServers = append(apiServers, pod)
+		}
+	}
+	sort.Slice(apiServers, func(i, j int) bool {
+		return apiServers[i].Name < apiServers[j].Name
+	})
+
+	var issues []apiServerFeatureIssue
+	if len(apiServers) == 0 {
+		issues = []apiServerFeatureIssue{{State: apiServerFlagsNotVisible}}
+	} else {
+		issues = findAPIServerFeatureIssues(apiServers, a.analyzer.FeatureGates, a.analyzer.AdmissionPlugins)
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				Message: defaultAPIServerFeatureMessage(issue),
+			}
+			switch issue.State {
+			case apiServerFeatureNotSet, apiServerFlagsNotVisible:
+				result.IsWarn = true
+			default:
+				result.IsFail = true
+			}
+		}
+		if len(issue.pods) > 0 {
+			result.InvolvedObject = &corev1.ObjectReference{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Namespace:  issue.pods[0].Namespace,
+				Name:       issue.pods[0].Name,
+			}
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "All required feature gates and admission plugins are enabled",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultAPIServerFeatureMessage(issue apiServerFeatureIssue) string {
+	switch issue.State {
+	case apiServerFlagsNotVisible:
+		return "No kube-apiserver pods were found in kube-system, feature gates and admission plugins could not be checked. This is expected on managed clusters, confirm the required configuration with the provider."
+	case apiServerFeatureNotSet:
+		return fmt.Sprintf("Feature gate %s is not set on %s, it is only enabled if it is on by default in this Kubernetes version", issue.Name, issue.APIServers)
+	case apiServerFeatureDisabled:
+		if issue.Type == apiServerFeatureGate {
+			return fmt.Sprintf("Feature gate %s is disabled on %s", issue.Name, issue.APIServers)
+		}
+		return fmt.Sprintf("Admission plugin %s is disabled on %s", issue.Name, issue.APIServers)
+	default:
+		return fmt.Sprintf("Admission plugin %s is not enabled on %s", issue.Name, issue.APIServers)
+	}
+}
+
+// findAPIServerFeatureIssues checks every kube-apiserver for the required feature gates and
+// admission plugins. An issue is returned for each name and state, listing the apiservers it
+// was found on, so a gate missing on a single control plane node is not hidden by the others.
+func findAPIServerFeatureIssues(apiServers []corev1.Pod, featureGates, admissionPlugins []string) []apiServerFeatureIssue {
+	flags := []apiServerFlags{}
+	for _, pod := range apiServers {
+		flags = append(flags, parseAPIServerFlags(pod))
+	}
+
+	issues := []apiServerFeatureIssue{}
+	addIssues := func(issueType, name string, stateOf func(apiServerFlags) string) {
+		byState := map[string][]corev1.Pod{}
+		for i, pod := range apiServers {
+			if state := stateOf(flags[i]); state != "" {
+				byState[state] = append(byState[state], pod)
+			}
+		}
+		for _, state := range []string{apiServerFeatureDisabled, apiServerFeatureNotActive, apiServerFeatureNotSet} {
+			pods := byState[state]
+			if len(pods) == 0 {
+				continue
+			}
+			names := []string{}
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			issues = append(issues, apiServerFeatureIssue{
+				Type:       issueType,
+				Name:       name,
+				State:      state,
+				APIServers: strings.Join(names, ", "),
+				pods:       pods,
+			})
+		}
+	}
+
+	for _, gate := range featureGates {
+		addIssues(apiServerFeatureGate, gate, func(f apiServerFlags) string {
+			enabled, ok := f.featureGates[gate]
+			switch {
+			case !ok:
+				return apiServerFeatureNotSet
+			case !enabled:
+				return apiServerFeatureDisabled
+			}
+			return ""
+		})
+	}
+
+	for _, plugin := range admissionPlugins {
+		addIssues(apiServerAdmissionPlugin, plugin, func(f apiServerFlags) string {
+			switch {
+			case slices.Contains(f.disabledAdmissionPlugins, plugin):
+				return apiServerFeatureDisabled
+			case slices.Contains(f.enableAdmissionPlugins, plugin), slices.Contains(defaultAdmissionPlugins, plugin):
+				return ""
+			}
+			return apiServerFeatureNotActive
+		})
+	}
+
+	return issues
+}
+
+func isKubeAPIServerPod(pod corev1.Pod) bool {
+	if pod.Labels["component"] == "kube-apiserver" {
+		return true
+	}
+	return strings.HasPrefix(pod.Name, "kube-apiserver-")
+}
+
+// parseAPIServerFlags reads the feature gate and admission plugin flags from the command and
+// args of the kube-apiserver container, in both the --flag=value and --flag value forms
+func parseAPIServerFlags(pod corev1.Pod) apiServerFlags {
+	flags := apiServerFlags{featureGates: map[string]bool{}}
+
+	var container *corev1.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == "kube-apiserver" || container == nil {
+			container = &pod.Spec.Containers[i]
+		}
+	}
+	if container == nil {
+		return flags
+	}
+
+	args := append(append([]string{}, container.Command...), container.Args...)
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		if !hasValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			value = args[i+1]
+			i++
+		}
+
+		switch name {
+		case "--feature-gates":
+			for _, gate := range splitFlagList(value) {
+				gateName, gateValue, _ := strings.Cut(gate, "=")
+				enabled, err := strconv.ParseBool(gateValue)
+				if err != nil {
+					continue
+				}
+				flags.featureGates[gateName] = enabled
+			}
+		case "--enable-admission-plugins", "--admission-control":
+			flags.enableAdmissionPlugins = append(flags.enableAdmissionPlugins, splitFlagList(value)...)
+		case "--disable-admission-plugins":
+			flags.disabledAdmissionPlugins = append(flags.disabledAdmissionPlugins, splitFlagList(value)...)
+		}
+	}
+
+	return flags
+}
+
+func splitFlagList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,174 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeAPIServerFeatures(t *testing.T) {
+	apiServerReference := func(name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "kube-system", Name: name}
+	}
+
+	selfManaged := map[string][]byte{
+		"cluster-resources/pods/kube-system.json": []byte(apiServerFeaturesKubeSystem),
+	}
+	managed := map[string][]byte{
+		"cluster-resources/pods/kube-system.json": []byte(`{"kind": "PodList", "items": []}`),
+	}
+
+	tests := []struct {
+		name         string
+		files        map[string][]byte
+		analyzer     troubleshootv1beta2.APIServerFeaturesAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:  "required gates and plugins are enabled",
+			files: selfManaged,
+			analyzer: troubleshootv1beta2.APIServerFeaturesAnalyze{
+				FeatureGates:     []string{"InPlacePodVerticalScaling"},
+				AdmissionPlugins: []string{"NodeRestriction", "PodSecurity"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "API Server Feature Gates and Admission Plugins",
+					Message: "All required feature gates and admission plugins are enabled",
+				},
+			},
+		},
+		{
+			name:  "missing gates and plugins are reported per apiserver",
+			files: selfManaged,
+			analyzer: troubleshootv1beta2.APIServerFeaturesAnalyze{
+				FeatureGates:     []string{"SidecarContainers", "UserNamespacesSupport"},
+				AdmissionPlugins: []string{"AlwaysPullImages", "DefaultStorageClass", "EventRateLimit"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "API Server Feature Gates and Admission Plugins",
+					Message:        "Feature gate SidecarContainers is disabled on kube-apiserver-cp-1",
+					InvolvedObject: apiServerReference("kube-apiserver-cp-1"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "API Server Feature Gates and Admission Plugins",
+					Message:        "Feature gate SidecarContainers is not set on kube-apiserver-cp-2, it is only enabled if it is on by default in this Kubernetes version",
+					InvolvedObject: apiServerReference("kube-apiserver-cp-2"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "API Server Feature Gates and Admission Plugins",
+					Message:        "Feature gate UserNamespacesSupport is not set on kube-apiserver-cp-1, kube-apiserver-cp-2, it is only enabled if it is on by default in this Kubernetes version",
+					InvolvedObject: apiServerReference("kube-apiserver-cp-1"),
+				},
+				{
+					IsFail:         true,
+					Title:          "API Server Feature Gates and Admission Plugins",
+					Message:        "Admission plugin AlwaysPullImages is not enabled on kube-apiserver-cp-2",
+					InvolvedObject: apiServerReference("kube-apiserver-cp-2"),
+				},
+				{
+					IsFail:         true,
+					Title:          "API Server Feature Gates and Admission Plugins",
+					Message:        "Admission plugin DefaultStorageClass is disabled on kube-apiserver-cp-2",
+					InvolvedObject: apiServerReference("kube-apiserver-cp-2"),
+				},
+				{
+					IsFail:         true,
+					Title:          "API Server Feature Gates and Admission Plugins",
+					Message:        "Admission plugin EventRateLimit is not enabled on kube-apiserver-cp-1, kube-apiserver-cp-2",
+					InvolvedObject: apiServerReference("kube-apiserver-cp-1"),
+				},
+			},
+		},
+		{
+			name:  "custom outcomes are templated with the missing gate",
+			files: selfManaged,
+			analyzer: troubleshootv1beta2.APIServerFeaturesAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Operator Prerequisites",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Type }} {{ .Name }} is {{ .State }} on {{ .APIServers }}",
+						},
+					},
+				},
+				FeatureGates: []string{"SidecarContainers"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Operator Prerequisites",
+					Message:        "FeatureGate SidecarContainers is Disabled on kube-apiserver-cp-1",
+					InvolvedObject: apiServerReference("kube-apiserver-cp-1"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Operator Prerequisites",
+					Message:        "FeatureGate SidecarContainers is NotSet on kube-apiserver-cp-2",
+					InvolvedObject: apiServerReference("kube-apiserver-cp-2"),
+				},
+			},
+		},
+		{
+			name:  "managed cluster without visible apiserver flags warns",
+			files: managed,
+			analyzer: troubleshootv1beta2.APIServerFeaturesAnalyze{
+				FeatureGates: []string{"InPlacePodVerticalScaling"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "API Server Feature Gates and Admission Plugins",
+					Message: "No kube-apiserver pods were found in kube-system, feature gates and admission plugins could not be checked. This is expected on managed clusters, confirm the required configuration with the provider.",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeAPIServerFeatures{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
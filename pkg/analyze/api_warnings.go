@@ -0,0 +1,158 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+)
+
+// apiDeprecationRegex matches the warning the apiserver returns for deprecated built-in APIs,
+// e.g. "batch/v1beta1 CronJob is deprecated in v1.21+, unavailable in v1.25+; use batch/v1 CronJob".
+// Custom resource deprecations omit the versions, and the replacement is optional.
+var apiDeprecationRegex = regexp.MustCompile(`^(\S+) (\S+) is deprecated(?: in (v[0-9.]+)\+)?(?:, unavailable in (v[0-9.]+)\+)?(?:; use (.+))?$`)
+
+type AnalyzeAPIWarnings struct {
+	analyzer *troubleshootv1beta2.APIWarningsAnalyze
+}
+
+// apiDeprecation is the template data available to outcome messages
+type apiDeprecation struct {
+	APIVersion string
+	Kind       string
+	// DeprecatedIn, RemovedIn and Replacement are empty when the warning does not include them
+	DeprecatedIn string
+	RemovedIn    string
+	Replacement  string
+	// Message is the warning as returned by the apiserver
+	Message string
+	// Count is the number of responses that included the warning during collection
+	Count int
+}
+
+func (a *AnalyzeAPIWarnings) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Deprecated APIs"
+}
+
+func (a *AnalyzeAPIWarnings) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeAPIWarnings) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	var targetVersion *semver.Version
+	if a.analyzer.TargetVersion != "" {
+		v, err := semver.ParseTolerant(a.analyzer.TargetVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse target version %q", a.analyzer.TargetVersion)
+		}
+		targetVersion = &v
+	}
+
+	collected, err := getFile(fmt.Sprintf("%s/%s.json", constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_API_WARNINGS))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get contents of api-warnings.json")
+	}
+
+	var warnings []collect.APIWarning
+	if err := json.Unmarshal(collected, &warnings); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal api warnings")
+	}
+
+	deprecations := findAPIDeprecations(warnings)
+
+	results := []*AnalyzeResult{}
+	for _, deprecation := range deprecations {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), deprecation)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = defaultAPIDeprecationResult(a.Title(), deprecation, targetVersion)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "The apiserver did not report any deprecated APIs during collection",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultAPIDeprecationResult(title string, deprecation apiDeprecation, targetVersion *semver.Version) *AnalyzeResult {
+	message := fmt.Sprintf("%s %s is deprecated", deprecation.APIVersion, deprecation.Kind)
+	if deprecation.DeprecatedIn != "" {
+		message += " since " + deprecation.DeprecatedIn
+	}
+	if deprecation.RemovedIn != "" {
+		message += " and is unavailable in " + deprecation.RemovedIn
+	}
+	message += "."
+	if deprecation.Replacement != "" {
+		message += fmt.Sprintf(" Migrate manifests and clients to %s.", deprecation.Replacement)
+	}
+
+	result := &AnalyzeResult{
+		Title:   title,
+		IsWarn:  true,
+		Message: message,
+	}
+
+	if targetVersion != nil && deprecation.RemovedIn != "" {
+		removedIn, err := semver.ParseTolerant(deprecation.RemovedIn)
+		if err == nil && targetVersion.GTE(removedIn) {
+			result.IsWarn = false
+			result.IsFail = true
+			result.Message += fmt.Sprintf(" It will not be served after upgrading to v%d.%d.", targetVersion.Major, targetVersion.Minor)
+		}
+	}
+
+	return result
+}
+
+// findAPIDeprecations returns the warnings that report a deprecated API. Other warnings, such as
+// those about unknown fields, are ignored.
+func findAPIDeprecations(warnings []collect.APIWarning) []apiDeprecation {
+	deprecations := []apiDeprecation{}
+	for _, warning := range warnings {
+		message := strings.TrimSpace(warning.Message)
+		match := apiDeprecationRegex.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		deprecations = append(deprecations, apiDeprecation{
+			APIVersion:   match[1],
+			Kind:         match[2],
+			DeprecatedIn: match[3],
+			RemovedIn:    match[4],
+			Replacement:  match[5],
+			Message:      message,
+			Count:        warning.Count,
+		})
+	}
+	return deprecations
+}
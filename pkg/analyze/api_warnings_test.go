@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeAPIWarnings(t *testing.T) {
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.APIWarningsAnalyze
+		warnings     []byte
+		expectResult []AnalyzeResult
+		expectErr    string
+	}{
+		{
+			name:     "deprecations are reported and other warnings ignored",
+			analyzer: troubleshootv1beta2.APIWarningsAnalyze{},
+			warnings: []byte(apiWarningsAPIWarnings),
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "Deprecated APIs",
+					Message: "autoscaling/v2beta2 HorizontalPodAutoscaler is deprecated since v1.23 and is unavailable in v1.26. Migrate manifests and clients to autoscaling/v2 HorizontalPodAutoscaler.",
+				},
+				{
+					IsWarn:  true,
+					Title:   "Deprecated APIs",
+					Message: "flowcontrol.apiserver.k8s.io/v1beta3 FlowSchema is deprecated since v1.29 and is unavailable in v1.32. Migrate manifests and clients to flowcontrol.apiserver.k8s.io/v1 FlowSchema.",
+				},
+				{
+					IsWarn:  true,
+					Title:   "Deprecated APIs",
+					Message: "widgets.example.com/v1alpha1 Widget is deprecated. Migrate manifests and clients to widgets.example.com/v1 Widget.",
+				},
+			},
+		},
+		{
+			name: "apis removed by the target version fail",
+			analyzer: troubleshootv1beta2.APIWarningsAnalyze{
+				TargetVersion: "1.30.2",
+			},
+			warnings: []byte(apiWarningsAPIWarnings),
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "Deprecated APIs",
+					Message: "autoscaling/v2beta2 HorizontalPodAutoscaler is deprecated since v1.23 and is unavailable in v1.26. Migrate manifests and clients to autoscaling/v2 HorizontalPodAutoscaler. It will not be served after upgrading to v1.30.",
+				},
+				{
+					IsWarn:  true,
+					Title:   "Deprecated APIs",
+					Message: "flowcontrol.apiserver.k8s.io/v1beta3 FlowSchema is deprecated since v1.29 and is unavailable in v1.32. Migrate manifests and clients to flowcontrol.apiserver.k8s.io/v1 FlowSchema.",
+				},
+				{
+					IsWarn:  true,
+					Title:   "Deprecated APIs",
+					Message: "widgets.example.com/v1alpha1 Widget is deprecated. Migrate manifests and clients to widgets.example.com/v1 Widget.",
+				},
+			},
+		},
+		{
+			name: "custom outcomes are templated with the deprecation",
+			analyzer: troubleshootv1beta2.APIWarningsAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "API Usage",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .APIVersion }} {{ .Kind }} read {{ .Count }} time(s), removed in {{ .RemovedIn }}",
+						},
+					},
+				},
+			},
+			warnings: []byte(apiWarningsAPIWarnings),
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "API Usage",
+					Message: "autoscaling/v2beta2 HorizontalPodAutoscaler read 3 time(s), removed in v1.26",
+				},
+				{
+					IsWarn:  true,
+					Title:   "API Usage",
+					Message: "flowcontrol.apiserver.k8s.io/v1beta3 FlowSchema read 1 time(s), removed in v1.32",
+				},
+				{
+					IsWarn:  true,
+					Title:   "API Usage",
+					Message: "widgets.example.com/v1alpha1 Widget read 1 time(s), removed in ",
+				},
+			},
+		},
+		{
+			name:     "no warnings passes",
+			analyzer: troubleshootv1beta2.APIWarningsAnalyze{},
+			warnings: []byte(`[]`),
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Deprecated APIs",
+					Message: "The apiserver did not report any deprecated APIs during collection",
+				},
+			},
+		},
+		{
+			name: "invalid target version",
+			analyzer: troubleshootv1beta2.APIWarningsAnalyze{
+				TargetVersion: "latest",
+			},
+			warnings:  []byte(`[]`),
+			expectErr: `failed to parse target version "latest"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if n == "cluster-resources/api-warnings.json" {
+					return test.warnings, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			a := &AnalyzeAPIWarnings{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, nil)
+			if test.expectErr != "" {
+				req.ErrorContains(err, test.expectErr)
+				return
+			}
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	clusterAutoscalerComponent = "cluster-autoscaler"
+
+	clusterAutoscalerQuotaExceeded       = "QuotaExceeded"
+	clusterAutoscalerOutOfCapacity       = "OutOfCapacity"
+	clusterAutoscalerMaxSizeReached      = "MaxSizeReached"
+	clusterAutoscalerNoMatchingNodeGroup = "NoMatchingNodeGroup"
+	clusterAutoscalerScaleUpFailed       = "ScaleUpFailed"
+)
+
+// clusterAutoscalerReasonPatterns classify the errors reported for a failed scale-up, in order of
+// precedence. Errors that match none of them are NoMatchingNodeGroup for pods that did not trigger
+// a scale-up and ScaleUpFailed otherwise.
+var clusterAutoscalerReasonPatterns = []struct {
+	reason  string
+	pattern *regexp.Regexp
+}{
+	{clusterAutoscalerQuotaExceeded, regexp.MustCompile(`(?i)quota|limit ?exceeded`)},
+	{clusterAutoscalerOutOfCapacity, regexp.MustCompile(`(?i)insufficient ?instance ?capacity|resource_pool_exhausted|out ?of ?resources?|out of capacity|stockout|sku ?not ?available`)},
+	{clusterAutoscalerMaxSizeReached, regexp.MustCompile(`(?i)max (node group|cluster) size reached`)},
+}
+
+var clusterAutoscalerReasons = map[string]struct {
+	description string
+	remediation string
+}{
+	clusterAutoscalerQuotaExceeded: {
+		description: "the cloud provider quota is exceeded",
+		remediation: "Raise the cloud provider quota for the node group's instance type and region, or use a smaller instance type.",
+	},
+	clusterAutoscalerOutOfCapacity: {
+		description: "the cloud provider is out of capacity for the instance type",
+		remediation: "Add instance types or zones to the node group, or retry once the cloud provider has capacity.",
+	},
+	clusterAutoscalerMaxSizeReached: {
+		description: "the node group is at its maximum size",
+		remediation: "Raise the maximum size of the node group, or reduce the resource requests of the pending pods.",
+	},
+	clusterAutoscalerNoMatchingNodeGroup: {
+		description: "no node group can run the pod",
+		remediation: "Check the pod's node selector, affinity, tolerations and resource requests against the labels, taints and instance sizes of the node groups.",
+	},
+	clusterAutoscalerScaleUpFailed: {
+		description: "new nodes could not be launched",
+		remediation: "Check the cluster-autoscaler logs and the cloud provider for why the nodes failed to launch.",
+	},
+}
+
+var failedToScaleUpGroupMessage = regexp.MustCompile(`^Scale-up failed for group ([^:]+): (.*)$`)
+
+type AnalyzeClusterAutoscaler struct {
+	analyzer *troubleshootv1beta2.ClusterAutoscalerAnalyze
+}
+
+// clusterAutoscalerIssue is the template data available to outcome messages
+type clusterAutoscalerIssue struct {
+	// Reason is one of QuotaExceeded, OutOfCapacity, MaxSizeReached, NoMatchingNodeGroup or
+	// ScaleUpFailed
+	Reason string
+	// NodeGroup is the node group that failed to scale up, it is empty for pods that did not
+	// trigger a scale-up
+	NodeGroup string
+	Kind      string
+	Namespace string
+	Name      string
+	// Message is the error reported by the autoscaler
+	Message string
+	// Remediation suggests how to resolve the failure
+	Remediation string
+}
+
+// clusterAutoscalerStatus holds the node group fields of the YAML status written by
+// cluster-autoscaler 1.30 and later
+type clusterAutoscalerStatus struct {
+	NodeGroups []struct {
+		Name    string `yaml:"name"`
+		ScaleUp struct {
+			Status      string `yaml:"status"`
+			BackoffInfo struct {
+				ErrorCode    string `yaml:"errorCode"`
+				ErrorMessage string `yaml:"errorMessage"`
+			} `yaml:"backoffInfo"`
+		} `yaml:"scaleUp"`
+	} `yaml:"nodeGroups"`
+}
+
+// nodeGroupBackoff is a node group whose scale-up is backed off after a failure
+type nodeGroupBackoff struct {
+	nodeGroup string
+	// message is empty when the status does not report the error
+	message string
+}
+
+func (a *AnalyzeClusterAutoscaler) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Cluster Autoscaler"
+}
+
+func (a *AnalyzeClusterAutoscaler) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeClusterAutoscaler) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	var statusConfigMap *corev1.ConfigMap
+	statusFile, err := getFile(filepath.Join(constants.CLUSTER_AUTOSCALER_DIR, "status.json"))
+	if err == nil {
+		var status collect.ClusterAutoscalerStatus
+		if err := json.Unmarshal(statusFile, &status); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal cluster-autoscaler status")
+		}
+		if status.ConfigMapExists {
+			statusConfigMap = status.ConfigMap
+		}
+	}
+
+	events, err := readClusterAutoscalerEvents(getFile, findFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := findClusterAutoscalerIssues(statusConfigMap, events)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = defaultClusterAutoscalerIssueResult(a.Title(), issue)
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			Kind:      issue.Kind,
+			Namespace: issue.Namespace,
+			Name:      issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			message := "No cluster-autoscaler was detected"
+			if statusConfigMap != nil || len(events) > 0 {
+				message = "No cluster-autoscaler scale-up failures were found"
+			}
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: message,
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultClusterAutoscalerIssueResult(title string, issue clusterAutoscalerIssue) *AnalyzeResult {
+	description := clusterAutoscalerReasons[issue.Reason].description
+
+	if issue.Kind == "Pod" {
+		return &AnalyzeResult{
+			Title:   title,
+			IsWarn:  true,
+			Message: fmt.Sprintf("Pod %s/%s did not trigger a scale-up because %s: %s. %s", issue.Namespace, issue.Name, description, issue.Message, issue.Remediation),
+		}
+	}
+
+	subject := "A scale-up"
+	if issue.NodeGroup != "" {
+		subject = fmt.Sprintf("Scale-up of node group %s", issue.NodeGroup)
+	}
+	message := fmt.Sprintf("%s failed because %s. %s", subject, description, issue.Remediation)
+	if issue.Message != "" {
+		message = fmt.Sprintf("%s failed because %s: %s. %s", subject, description, issue.Message, issue.Remediation)
+	}
+	return &AnalyzeResult{
+		Title:   title,
+		IsFail:  true,
+		Message: message,
+	}
+}
+
+// findClusterAutoscalerIssues returns the node groups that failed to scale up, from the
+// FailedToScaleUpGroup events and the backoffs in the status, followed by the pods that did not
+// trigger a scale-up
+func findClusterAutoscalerIssues(statusConfigMap *corev1.ConfigMap, events []corev1.Event) []clusterAutoscalerIssue {
+	nodeGroupIssues := map[string]clusterAutoscalerIssue{}
+	podIssues := map[string]clusterAutoscalerIssue{}
+
+	for _, event := range events {
+		message := strings.TrimSpace(event.Message)
+
+		switch event.Reason {
+		case "FailedToScaleUpGroup":
+			nodeGroup := ""
+			if matches := failedToScaleUpGroupMessage.FindStringSubmatch(message); matches != nil {
+				nodeGroup, message = matches[1], matches[2]
+			}
+			nodeGroupIssues[nodeGroup] = newClusterAutoscalerIssue(classifyScaleUpFailure(message, clusterAutoscalerScaleUpFailed), nodeGroup, event.InvolvedObject, message)
+		case "NotTriggerScaleUp":
+			key := event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+			podIssues[key] = newClusterAutoscalerIssue(classifyScaleUpFailure(message, clusterAutoscalerNoMatchingNodeGroup), "", event.InvolvedObject, message)
+		}
+	}
+
+	if statusConfigMap != nil {
+		statusObject := corev1.ObjectReference{
+			Kind:      "ConfigMap",
+			Namespace: statusConfigMap.Namespace,
+			Name:      statusConfigMap.Name,
+		}
+		for _, backoff := range clusterAutoscalerBackoffs(statusConfigMap.Data["status"]) {
+			if _, ok := nodeGroupIssues[backoff.nodeGroup]; ok {
+				continue
+			}
+			nodeGroupIssues[backoff.nodeGroup] = newClusterAutoscalerIssue(classifyScaleUpFailure(backoff.message, clusterAutoscalerScaleUpFailed), backoff.nodeGroup, statusObject, backoff.message)
+		}
+	}
+
+	issues := []clusterAutoscalerIssue{}
+	for _, byKey := range []map[string]clusterAutoscalerIssue{nodeGroupIssues, podIssues} {
+		keys := []string{}
+		for key := range byKey {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			issues = append(issues, byKey[key])
+		}
+	}
+
+	return issues
+}
+
+func newClusterAutoscalerIssue(reason, nodeGroup string, object corev1.ObjectReference, message string) clusterAutoscalerIssue {
+	return clusterAutoscalerIssue{
+		Reason:      reason,
+		NodeGroup:   nodeGroup,
+		Kind:        object.Kind,
+		Namespace:   object.Namespace,
+		Name:        object.Name,
+		Message:     strings.TrimSuffix(message, "."),
+		Remediation: clusterAutoscalerReasons[reason].remediation,
+	}
+}
+
+func classifyScaleUpFailure(message, fallback string) string {
+	for _, reason := range clusterAutoscalerReasonPatterns {
+		if reason.pattern.MatchString(message) {
+			return reason.reason
+		}
+	}
+	return fallback
+}
+
+// clusterAutoscalerBackoffs returns the node groups whose scale-up is backed off in the status
+// saved in the autoscaler status ConfigMap. The YAML status of cluster-autoscaler 1.30 and later
+// and the human readable status of earlier versions are both supported, only the former reports
+// the error that caused the backoff.
+func clusterAutoscalerBackoffs(status string) []nodeGroupBackoff {
+	backoffs := []nodeGroupBackoff{}
+
+	var parsed clusterAutoscalerStatus
+	if err := yaml.Unmarshal([]byte(status), &parsed); err == nil && len(parsed.NodeGroups) > 0 {
+		for _, nodeGroup := range parsed.NodeGroups {
+			if nodeGroup.ScaleUp.Status != "Backoff" {
+				continue
+			}
+			info := nodeGroup.ScaleUp.BackoffInfo
+			message := info.ErrorCode
+			if info.ErrorMessage != "" {
+				message = strings.TrimPrefix(info.ErrorCode+": "+info.ErrorMessage, ": ")
+			}
+			backoffs = append(backoffs, nodeGroupBackoff{nodeGroup: nodeGroup.Name, message: message})
+		}
+		return backoffs
+	}
+
+	inNodeGroups := false
+	nodeGroup := ""
+	for _, line := range strings.Split(status, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 1 && fields[0] == "NodeGroups:" {
+			inNodeGroups = true
+			continue
+		}
+		if !inNodeGroups || len(fields) < 2 {
+			continue
+		}
+		if fields[0] == "Name:" {
+			nodeGroup = fields[1]
+		} else if fields[0] == "ScaleUp:" && fields[1] == "Backoff" {
+			backoffs = append(backoffs, nodeGroupBackoff{nodeGroup: nodeGroup})
+		}
+	}
+	return backoffs
+}
+
+// readClusterAutoscalerEvents returns the events recorded by the autoscaler, from the cluster
+// autoscaler collector and from the events saved by the cluster resources collector
+func readClusterAutoscalerEvents(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]corev1.Event, error) {
+	namespaceEvents, err := findFiles(filepath.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_EVENTS, "*.json"), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read collected events")
+	}
+
+	collected := map[string][]byte{}
+	for fileName, fileContent := range namespaceEvents {
+		collected[fileName] = fileContent
+	}
+	autoscalerEventsFile := filepath.Join(constants.CLUSTER_AUTOSCALER_DIR, "events.json")
+	if fileContent, err := getFile(autoscalerEventsFile); err == nil {
+		collected[autoscalerEventsFile] = fileContent
+	}
+
+	fileNames := []string{}
+	for fileName := range collected {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	seen := map[string]bool{}
+	events := []corev1.Event{}
+	for _, fileName := range fileNames {
+		var eventList corev1.EventList
+		if err := json.Unmarshal(collected[fileName], &eventList); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", fileName)
+		}
+		for _, event := range eventList.Items {
+			key := event.Namespace + "/" + event.Name
+			if event.Source.Component != clusterAutoscalerComponent || seen[key] {
+				continue
+			}
+			seen[key] = true
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,196 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeClusterAutoscaler(t *testing.T) {
+	statusObject := &corev1.ObjectReference{Kind: "ConfigMap", Namespace: "kube-system", Name: "cluster-autoscaler-status"}
+
+	tests := []struct {
+		name         string
+		files        map[string][]byte
+		analyzer     troubleshootv1beta2.ClusterAutoscalerAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name: "scale-up failures from the status and events",
+			files: map[string][]byte{
+				"cluster-resources/autoscaler/status.json": []byte(clusterAutoscalerStatusConfigMap),
+				"cluster-resources/autoscaler/events.json": []byte(clusterAutoscalerEvents),
+				"cluster-resources/events/default.json":    []byte(clusterAutoscalerEventsDefault),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Cluster Autoscaler",
+					Message:        "Scale-up of node group ng-cpu failed because the cloud provider quota is exceeded: Could not launch On-Demand Instances. VcpuLimitExceeded - You have requested more vCPU capacity than your current vCPU limit of 32 allows for the instance bucket that the specified instance type belongs to. Raise the cloud provider quota for the node group's instance type and region, or use a smaller instance type.",
+					InvolvedObject: statusObject,
+				},
+				{
+					IsFail:         true,
+					Title:          "Cluster Autoscaler",
+					Message:        "Scale-up of node group ng-gpu failed because the cloud provider is out of capacity for the instance type: OutOfResource: ZONE_RESOURCE_POOL_EXHAUSTED: The zone us-central1-a does not have enough resources available to fulfill the request. Add instance types or zones to the node group, or retry once the cloud provider has capacity.",
+					InvolvedObject: statusObject,
+				},
+				{
+					IsWarn:         true,
+					Title:          "Cluster Autoscaler",
+					Message:        "Pod default/arm-build-0 did not trigger a scale-up because no node group can run the pod: pod didn't trigger scale-up: 3 node(s) didn't match Pod's node affinity/selector. Check the pod's node selector, affinity, tolerations and resource requests against the labels, taints and instance sizes of the node groups.",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "arm-build-0"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Cluster Autoscaler",
+					Message:        "Pod default/gpu-job-7x2kq did not trigger a scale-up because the node group is at its maximum size: pod didn't trigger scale-up: 1 max node group size reached, 2 node(s) didn't match Pod's node affinity/selector. Raise the maximum size of the node group, or reduce the resource requests of the pending pods.",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "gpu-job-7x2kq"},
+				},
+			},
+		},
+		{
+			name: "backoff in the status of older autoscalers",
+			files: map[string][]byte{
+				"cluster-resources/autoscaler/status.json": []byte(`{
+  "namespace": "kube-system",
+  "name": "cluster-autoscaler-status",
+  "configMapExists": true,
+  "configMap": {
+    "metadata": {"name": "cluster-autoscaler-status", "namespace": "kube-system"},
+    "data": {
+      "status": "Cluster-autoscaler status at 2026-10-14 09:12:31 +0000 UTC:\nCluster-wide:\n  Health:      Healthy (ready=3 unready=0 notStarted=0 longNotStarted=0 registered=3)\n  ScaleUp:     InProgress (ready=3 registered=3)\n\nNodeGroups:\n  Name:        ng-1\n  Health:      Healthy (ready=3 unready=0 notStarted=0 longNotStarted=0 registered=3 cloudProviderTarget=3 (minSize=1, maxSize=5))\n  ScaleUp:     NoActivity (ready=3 cloudProviderTarget=3)\n\n  Name:        ng-2\n  Health:      Healthy (ready=0 unready=0 notStarted=0 longNotStarted=0 registered=0 cloudProviderTarget=1 (minSize=0, maxSize=3))\n  ScaleUp:     Backoff (ready=0 cloudProviderTarget=1)\n"
+    }
+  }
+}`),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Cluster Autoscaler",
+					Message:        "Scale-up of node group ng-2 failed because new nodes could not be launched. Check the cluster-autoscaler logs and the cloud provider for why the nodes failed to launch.",
+					InvolvedObject: statusObject,
+				},
+			},
+		},
+		{
+			name: "custom outcomes",
+			files: map[string][]byte{
+				"cluster-resources/autoscaler/events.json": []byte(clusterAutoscalerEvents),
+			},
+			analyzer: troubleshootv1beta2.ClusterAutoscalerAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Autoscaling",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Reason }} {{ .Kind }} {{ .Namespace }}/{{ .Name }} {{ .NodeGroup }}",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "Scale-ups are succeeding",
+						},
+					},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Autoscaling",
+					Message:        "QuotaExceeded ConfigMap kube-system/cluster-autoscaler-status ng-cpu",
+					InvolvedObject: statusObject,
+				},
+				{
+					IsFail:         true,
+					Title:          "Autoscaling",
+					Message:        "MaxSizeReached Pod default/gpu-job-7x2kq ",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "gpu-job-7x2kq"},
+				},
+			},
+		},
+		{
+			name: "no scale-up failures",
+			files: map[string][]byte{
+				"cluster-resources/autoscaler/status.json": []byte(`{"namespace": "kube-system", "name": "cluster-autoscaler-status", "configMapExists": true, "configMap": {"metadata": {"name": "cluster-autoscaler-status", "namespace": "kube-system"}, "data": {"status": "autoscalerStatus: Running\nnodeGroups:\n- name: ng-1\n  scaleUp:\n    status: NoActivity\n"}}}`),
+				"cluster-resources/autoscaler/events.json": []byte(`{"kind": "EventList", "items": []}`),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Cluster Autoscaler",
+					Message: "No cluster-autoscaler scale-up failures were found",
+				},
+			},
+		},
+		{
+			name: "no autoscaler",
+			files: map[string][]byte{
+				"cluster-resources/events/default.json": []byte(`{"kind": "EventList", "items": []}`),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Cluster Autoscaler",
+					Message: "No cluster-autoscaler was detected",
+				},
+			},
+		},
+		{
+			name: "status configmap not found",
+			files: map[string][]byte{
+				"cluster-resources/autoscaler/status.json": []byte(`{"namespace": "kube-system", "name": "cluster-autoscaler-status", "configMapExists": false}`),
+				"cluster-resources/autoscaler/events.json": []byte(`{"kind": "EventList", "items": []}`),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Cluster Autoscaler",
+					Message: "No cluster-autoscaler was detected",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeClusterAutoscaler{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -16,6 +17,12 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// troubleshootPodLabels are the labels of the pods troubleshoot creates to run collectors
+var troubleshootPodLabels = []string{"troubleshoot-role", "troubleshoot.sh/collector"}
+
+// troubleshootPodNames are the names of the pods troubleshoot creates, and of their owners
+var troubleshootPodNames = []string{"troubleshoot-*"}
+
 type AnalyzeClusterPodStatuses struct {
 	analyzer *troubleshootv1beta2.ClusterPodStatuses
 }
@@ -45,6 +52,12 @@ func (a *AnalyzeClusterPodStatuses) Analyze(getFile getCollectedFileContents, fi
 }
 
 func clusterPodStatuses(analyzer *troubleshootv1beta2.ClusterPodStatuses, getChildCollectedFileContents getChildCollectedFileContents, getChildCollectedFileContentsEvents getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	for _, pattern := range analyzer.EphemeralPods {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid ephemeral pod pattern %q", pattern)
+		}
+	}
+
 	excludeFiles := []string{}
 	collected, err := getChildCollectedFileContents(filepath.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_PODS, "*.json"), excludeFiles)
 	if err != nil {
@@ -78,6 +91,13 @@ func clusterPodStatuses(analyzer *troubleshootv1beta2.ClusterPodStatuses, getChi
 	allResults := []*AnalyzeResult{}
 
 	for _, pod := range pods {
+		// the pods troubleshoot deletes once it collected from them are often still terminating
+		// when cluster resources are collected, they are not an issue of the cluster
+		if pod.DeletionTimestamp != nil && isEphemeralPod(&pod, analyzer.EphemeralPods) {
+			klog.V(2).Infof("Skipping terminating ephemeral pod %s/%s", pod.Namespace, pod.Name)
+			continue
+		}
+
 		if pod.Status.Reason == "" {
 			// get pod status reason and message from the pod
 			pod.Status.Reason, pod.Status.Message = k8sutil.GetPodStatusReason(&pod)
@@ -224,3 +244,26 @@ func clusterPodStatuses(analyzer *troubleshootv1beta2.ClusterPodStatuses, getChi
 
 	return allResults, nil
 }
+
+// isEphemeralPod returns true for the pods created by troubleshoot, and for the pods whose name or
+// owner name matches one of the patterns
+func isEphemeralPod(pod *corev1.Pod, patterns []string) bool {
+	for _, label := range troubleshootPodLabels {
+		if _, ok := pod.Labels[label]; ok {
+			return true
+		}
+	}
+
+	names := []string{pod.Name}
+	for _, owner := range pod.OwnerReferences {
+		names = append(names, owner.Name)
+	}
+	for _, pattern := range append(append([]string{}, troubleshootPodNames...), patterns...) {
+		for _, name := range names {
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -7,8 +7,69 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const terminatingEphemeralPods = `[
+  {
+    "metadata": {"name": "troubleshoot-copyfromhost-5sq8v-xm2kd", "namespace": "default", "deletionTimestamp": "2024-05-01T12:00:00Z", "labels": {"troubleshoot.sh/collector": "copyfromhost"}},
+    "status": {"phase": "Running"}
+  },
+  {
+    "metadata": {"name": "my-tool-check-4hz7k", "namespace": "default", "deletionTimestamp": "2024-05-01T12:00:00Z"},
+    "status": {"phase": "Running"}
+  },
+  {
+    "metadata": {"name": "api-7d4b9c8f6-x2x9z", "namespace": "default", "deletionTimestamp": "2024-05-01T12:00:00Z"},
+    "status": {"phase": "Running"}
+  }
+]`
+
+func Test_isEphemeralPod(t *testing.T) {
+	tests := []struct {
+		name     string
+		pod      corev1.Pod
+		patterns []string
+		want     bool
+	}{
+		{
+			name: "troubleshoot label",
+			pod:  corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "node-commands-abcde", Labels: map[string]string{"troubleshoot-role": "node-commands"}}},
+			want: true,
+		},
+		{
+			name: "troubleshoot name",
+			pod:  corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "troubleshoot-dns-abcde"}},
+			want: true,
+		},
+		{
+			name: "owned by a troubleshoot daemonset",
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:            "collector-xyz",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "troubleshoot-copyfromhost-5sq8v"}},
+			}},
+			want: true,
+		},
+		{
+			name:     "configured pattern",
+			pod:      corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-tool-check-4hz7k"}},
+			patterns: []string{"other-*", "my-tool-*"},
+			want:     true,
+		},
+		{
+			name:     "application pod",
+			pod:      corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-7d4b9c8f6-x2x9z", Labels: map[string]string{"app": "api"}}},
+			patterns: []string{"my-tool-*"},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isEphemeralPod(&tt.pod, tt.patterns))
+		})
+	}
+}
+
 func Test_ClusterPodStatuses(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -664,6 +725,36 @@ func Test_ClusterPodStatuses(t *testing.T) {
 				"cluster-resources/pods/message-image-pull-fail.json": []byte(messageImagePullFail),
 			},
 		},
+		{
+			name: "terminating ephemeral pods are skipped",
+			analyzer: troubleshootv1beta2.ClusterPodStatuses{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "!= Healthy",
+							Message: "Pod {{ .Name }} is {{ .Status.Reason }}",
+						},
+					},
+				},
+				EphemeralPods: []string{"my-tool-*"},
+			},
+			expectResult: []*AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "Pod default/api-7d4b9c8f6-x2x9z status",
+					Message: "Pod api-7d4b9c8f6-x2x9z is Terminating",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1",
+						Kind:       "Pod",
+						Namespace:  "default",
+						Name:       "api-7d4b9c8f6-x2x9z",
+					},
+				},
+			},
+			files: map[string][]byte{
+				"cluster-resources/pods/default.json": []byte(terminatingEphemeralPods),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,259 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+)
+
+const (
+	clusterVersionSupported   = "supported"
+	clusterVersionDeprecated  = "deprecated"
+	clusterVersionUnsupported = "unsupported"
+	clusterVersionUnknown     = "unknown"
+)
+
+type AnalyzeClusterVersionCompatibility struct {
+	analyzer *troubleshootv1beta2.ClusterVersionCompatibilityAnalyze
+}
+
+// clusterVersionCompatibility is the template data available to outcome messages
+type clusterVersionCompatibility struct {
+	AppVersion     string
+	ClusterVersion string
+	// Supported and Deprecated are the ranges of the matrix entry of AppVersion, as written in the spec
+	Supported  string
+	Deprecated string
+	// Status is one of supported, deprecated, unsupported or unknown
+	Status string
+}
+
+func (a *AnalyzeClusterVersionCompatibility) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Kubernetes Version Compatibility"
+}
+
+func (a *AnalyzeClusterVersionCompatibility) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeClusterVersionCompatibility) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	clusterInfo, err := getFile("cluster-info/cluster_version.json")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get contents of cluster_version.json")
+	}
+
+	collectorClusterVersion := collect.ClusterVersion{}
+	if err := json.Unmarshal(clusterInfo, &collectorClusterVersion); err != nil {
+		return nil, errors.Wrap(err, "failed to parse cluster_version.json")
+	}
+
+	k8sVersion, err := parseK8sVersionString(collectorClusterVersion.String)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse semver from cluster_version.json")
+	}
+
+	compatibility, err := evaluateClusterVersionCompatibility(a.analyzer.AppVersion, a.analyzer.Matrix, k8sVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := evaluateClusterVersionCompatibilityOutcomes(a.analyzer.Outcomes, a.Title(), compatibility)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		result, err = evaluateClusterVersionCompatibilityOutcomes(defaultClusterVersionCompatibilityOutcomes(), a.Title(), compatibility)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.IconKey = "kubernetes_cluster_version"
+	result.IconURI = "https://troubleshoot.sh/images/analyzer-icons/kubernetes.svg?w=16&h=16"
+	result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+
+	return []*AnalyzeResult{result}, nil
+}
+
+// evaluateClusterVersionCompatibility finds the first matrix entry whose app versions include
+// appVersion and checks k8sVersion against its ranges. Pre-release and build metadata of the
+// Kubernetes version, such as the "-gke.100" of managed clusters, are ignored.
+func evaluateClusterVersionCompatibility(appVersion string, matrix []troubleshootv1beta2.ClusterVersionCompatibility, k8sVersion semver.Version) (clusterVersionCompatibility, error) {
+	if appVersion == "" {
+		return clusterVersionCompatibility{}, errors.New("appVersion is required")
+	}
+	if len(matrix) == 0 {
+		return clusterVersionCompatibility{}, errors.New("matrix is required")
+	}
+
+	version, err := semver.ParseTolerant(appVersion)
+	if err != nil {
+		return clusterVersionCompatibility{}, errors.Wrapf(err, "failed to parse app version %s", appVersion)
+	}
+
+	k8sVersion = semver.Version{Major: k8sVersion.Major, Minor: k8sVersion.Minor, Patch: k8sVersion.Patch}
+	compatibility := clusterVersionCompatibility{
+		AppVersion:     appVersion,
+		ClusterVersion: k8sVersion.String(),
+		Status:         clusterVersionUnknown,
+	}
+
+	for i, entry := range matrix {
+		appVersions, err := parseTolerantRange(entry.AppVersions)
+		if err != nil {
+			return clusterVersionCompatibility{}, errors.Wrapf(err, "failed to parse appVersions of matrix entry %d", i)
+		}
+		if !appVersions(version) {
+			continue
+		}
+
+		supported, err := parseTolerantRange(entry.Supported)
+		if err != nil {
+			return clusterVersionCompatibility{}, errors.Wrapf(err, "failed to parse supported of matrix entry %d", i)
+		}
+		compatibility.Supported = entry.Supported
+		compatibility.Deprecated = entry.Deprecated
+
+		if entry.Deprecated != "" {
+			deprecated, err := parseTolerantRange(entry.Deprecated)
+			if err != nil {
+				return clusterVersionCompatibility{}, errors.Wrapf(err, "failed to parse deprecated of matrix entry %d", i)
+			}
+			if deprecated(k8sVersion) {
+				compatibility.Status = clusterVersionDeprecated
+				return compatibility, nil
+			}
+		}
+
+		if supported(k8sVersion) {
+			compatibility.Status = clusterVersionSupported
+		} else {
+			compatibility.Status = clusterVersionUnsupported
+		}
+		return compatibility, nil
+	}
+
+	return compatibility, nil
+}
+
+// evaluateClusterVersionCompatibilityOutcomes returns a result for the first outcome whose "when"
+// is the compatibility status, an empty "when" always matches. nil is returned when no outcome
+// matches.
+func evaluateClusterVersionCompatibilityOutcomes(outcomes []*troubleshootv1beta2.Outcome, title string, compatibility clusterVersionCompatibility) (*AnalyzeResult, error) {
+	for _, outcome := range outcomes {
+		result := &AnalyzeResult{
+			Title: title,
+		}
+
+		var single *troubleshootv1beta2.SingleOutcome
+		switch {
+		case outcome.Fail != nil:
+			single = outcome.Fail
+			result.IsFail = true
+		case outcome.Warn != nil:
+			single = outcome.Warn
+			result.IsWarn = true
+		case outcome.Pass != nil:
+			single = outcome.Pass
+			result.IsPass = true
+		default:
+			continue
+		}
+
+		switch single.When {
+		case "", compatibility.Status:
+		case clusterVersionSupported, clusterVersionDeprecated, clusterVersionUnsupported, clusterVersionUnknown:
+			continue
+		default:
+			return nil, errors.Errorf("invalid when condition %q, expected one of supported, deprecated, unsupported or unknown", single.When)
+		}
+
+		result.Message = renderTemplate(single.Message, compatibility)
+		result.URI = single.URI
+		return result, nil
+	}
+
+	return nil, nil
+}
+
+func defaultClusterVersionCompatibilityOutcomes() []*troubleshootv1beta2.Outcome {
+	return []*troubleshootv1beta2.Outcome{
+		{
+			Fail: &troubleshootv1beta2.SingleOutcome{
+				When:    clusterVersionUnsupported,
+				Message: "Kubernetes {{ .ClusterVersion }} is not supported by app version {{ .AppVersion }}, supported Kubernetes versions are {{ .Supported }}",
+			},
+		},
+		{
+			Warn: &troubleshootv1beta2.SingleOutcome{
+				When:    clusterVersionDeprecated,
+				Message: "Kubernetes {{ .ClusterVersion }} is deprecated for app version {{ .AppVersion }}, supported Kubernetes versions are {{ .Supported }}",
+			},
+		},
+		{
+			Warn: &troubleshootv1beta2.SingleOutcome{
+				When:    clusterVersionUnknown,
+				Message: "App version {{ .AppVersion }} is not in the compatibility matrix, Kubernetes {{ .ClusterVersion }} can not be checked",
+			},
+		},
+		{
+			Pass: &troubleshootv1beta2.SingleOutcome{
+				When:    clusterVersionSupported,
+				Message: "Kubernetes {{ .ClusterVersion }} is supported by app version {{ .AppVersion }}, supported Kubernetes versions are {{ .Supported }}",
+			},
+		},
+	}
+}
+
+// parseTolerantRange parses a semver range, such as ">=1.26 <1.31" or ">=2.0 <2.4 || 3.x", whose
+// versions may omit the minor or patch version or have a "v" prefix
+func parseTolerantRange(s string) (semver.Range, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errors.New("empty range")
+	}
+
+	tokens := []string{}
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if field == "||" {
+			tokens = append(tokens, field)
+			continue
+		}
+
+		operator := ""
+		for _, op := range []string{">=", "<=", "!=", "==", ">", "<", "="} {
+			if strings.HasPrefix(field, op) {
+				operator = op
+				break
+			}
+		}
+		version := strings.TrimPrefix(field, operator)
+		// allow a space between the operator and the version, e.g. ">= 1.26"
+		if version == "" && i+1 < len(fields) {
+			i++
+			version = fields[i]
+		}
+
+		if !strings.ContainsAny(version, "xX*") {
+			v, err := semver.ParseTolerant(version)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse version %q of range %q", version, s)
+			}
+			version = v.String()
+		}
+		tokens = append(tokens, operator+version)
+	}
+
+	r, err := semver.ParseRange(strings.Join(tokens, " "))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse range %q", s)
+	}
+	return r, nil
+}
@@ -0,0 +1,155 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeClusterVersionCompatibility(t *testing.T) {
+	matrix := []troubleshootv1beta2.ClusterVersionCompatibility{
+		{AppVersions: ">=2.4", Supported: ">=1.28 <1.32", Deprecated: "1.28.x"},
+		{AppVersions: ">=2.0 <2.4", Supported: ">=1.26 <1.31"},
+	}
+
+	tests := []struct {
+		name           string
+		analyzer       troubleshootv1beta2.ClusterVersionCompatibilityAnalyze
+		clusterVersion string
+		want           *AnalyzeResult
+		wantErr        string
+	}{
+		{
+			name:           "supported",
+			analyzer:       troubleshootv1beta2.ClusterVersionCompatibilityAnalyze{AppVersion: "2.3.1", Matrix: matrix},
+			clusterVersion: "v1.30.4",
+			want: &AnalyzeResult{
+				IsPass:  true,
+				Message: "Kubernetes 1.30.4 is supported by app version 2.3.1, supported Kubernetes versions are >=1.26 <1.31",
+			},
+		},
+		{
+			name:           "unsupported",
+			analyzer:       troubleshootv1beta2.ClusterVersionCompatibilityAnalyze{AppVersion: "v2.3", Matrix: matrix},
+			clusterVersion: "v1.31.0-gke.1200",
+			want: &AnalyzeResult{
+				IsFail:  true,
+				Message: "Kubernetes 1.31.0 is not supported by app version v2.3, supported Kubernetes versions are >=1.26 <1.31",
+			},
+		},
+		{
+			name:           "deprecated",
+			analyzer:       troubleshootv1beta2.ClusterVersionCompatibilityAnalyze{AppVersion: "2.5.0", Matrix: matrix},
+			clusterVersion: "v1.28.9-eks-036c24b",
+			want: &AnalyzeResult{
+				IsWarn:  true,
+				Message: "Kubernetes 1.28.9 is deprecated for app version 2.5.0, supported Kubernetes versions are >=1.28 <1.32",
+			},
+		},
+		{
+			name:           "app version not in the matrix",
+			analyzer:       troubleshootv1beta2.ClusterVersionCompatibilityAnalyze{AppVersion: "1.9.0", Matrix: matrix},
+			clusterVersion: "v1.30.4",
+			want: &AnalyzeResult{
+				IsWarn:  true,
+				Message: "App version 1.9.0 is not in the compatibility matrix, Kubernetes 1.30.4 can not be checked",
+			},
+		},
+		{
+			name: "custom outcomes",
+			analyzer: troubleshootv1beta2.ClusterVersionCompatibilityAnalyze{
+				AppVersion: "2.5.0",
+				Matrix:     matrix,
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{Fail: &troubleshootv1beta2.SingleOutcome{When: "unsupported", Message: "Upgrade to Kubernetes {{ .Supported }}"}},
+					{Pass: &troubleshootv1beta2.SingleOutcome{Message: "{{ .Status }}: Kubernetes {{ .ClusterVersion }}", URI: "https://example.com/compatibility"}},
+				},
+			},
+			clusterVersion: "v1.29.2",
+			want: &AnalyzeResult{
+				IsPass:  true,
+				Message: "supported: Kubernetes 1.29.2",
+				URI:     "https://example.com/compatibility",
+			},
+		},
+		{
+			name: "invalid when",
+			analyzer: troubleshootv1beta2.ClusterVersionCompatibilityAnalyze{
+				AppVersion: "2.5.0",
+				Matrix:     matrix,
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{Fail: &troubleshootv1beta2.SingleOutcome{When: "< 1.28", Message: "too old"}},
+				},
+			},
+			clusterVersion: "v1.29.2",
+			wantErr:        `invalid when condition "< 1.28"`,
+		},
+		{
+			name: "invalid range",
+			analyzer: troubleshootv1beta2.ClusterVersionCompatibilityAnalyze{
+				AppVersion: "2.5.0",
+				Matrix:     []troubleshootv1beta2.ClusterVersionCompatibility{{AppVersions: ">=2", Supported: ">=one"}},
+			},
+			clusterVersion: "v1.29.2",
+			wantErr:        "failed to parse supported of matrix entry 0",
+		},
+		{
+			name:           "missing app version",
+			analyzer:       troubleshootv1beta2.ClusterVersionCompatibilityAnalyze{Matrix: matrix},
+			clusterVersion: "v1.29.2",
+			wantErr:        "appVersion is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getFile := func(path string) ([]byte, error) {
+				require.Equal(t, "cluster-info/cluster_version.json", path)
+				return []byte(`{"info": {}, "string": "` + tt.clusterVersion + `"}`), nil
+			}
+
+			a := &AnalyzeClusterVersionCompatibility{analyzer: &tt.analyzer}
+			results, err := a.Analyze(getFile, nil)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			tt.want.Title = "Kubernetes Version Compatibility"
+			tt.want.IconKey = "kubernetes_cluster_version"
+			tt.want.IconURI = "https://troubleshoot.sh/images/analyzer-icons/kubernetes.svg?w=16&h=16"
+			assert.Equal(t, []*AnalyzeResult{tt.want}, results)
+		})
+	}
+}
+
+func Test_parseTolerantRange(t *testing.T) {
+	tests := []struct {
+		rangeStr string
+		matches  []string
+		misses   []string
+	}{
+		{rangeStr: ">=1.26 <1.31", matches: []string{"1.26.0", "1.30.9"}, misses: []string{"1.25.9", "1.31.0"}},
+		{rangeStr: ">= v1.26 < v1.31", matches: []string{"1.26.0"}, misses: []string{"1.31.0"}},
+		{rangeStr: "1.28.x || >=1.30", matches: []string{"1.28.3", "1.31.0"}, misses: []string{"1.29.0"}},
+		{rangeStr: "2.3.1", matches: []string{"2.3.1"}, misses: []string{"2.3.2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rangeStr, func(t *testing.T) {
+			r, err := parseTolerantRange(tt.rangeStr)
+			require.NoError(t, err)
+			for _, v := range tt.matches {
+				assert.True(t, r(semver.MustParse(v)), v)
+			}
+			for _, v := range tt.misses {
+				assert.False(t, r(semver.MustParse(v)), v)
+			}
+		})
+	}
+
+	_, err := parseTolerantRange("")
+	assert.Error(t, err)
+}
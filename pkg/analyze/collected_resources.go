@@ -0,0 +1,260 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// collectedNamespaceFiles returns the contents of the per-namespace files the cluster resources
+// collector wrote for a resource, keyed by namespace. When namespaces is empty all of them are returned.
+func collectedNamespaceFiles(findFiles getChildCollectedFileContents, resourceDir string, namespaces []string) (map[string][]byte, error) {
+	collected, err := findFiles(filepath.Join(constants.CLUSTER_RESOURCES_DIR, resourceDir, "*.json"), []string{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read collected %s", resourceDir)
+	}
+
+	files := map[string][]byte{}
+	for fileName, fileContent := range collected {
+		namespace := strings.TrimSuffix(filepath.Base(fileName), ".json")
+		if len(namespaces) > 0 && !slices.Contains(namespaces, namespace) {
+			continue
+		}
+		files[namespace] = fileContent
+	}
+
+	return files, nil
+}
+
+// readCollectedPods returns the pods collected by the cluster resources collector.
+// Older bundles stored a plain array of pods instead of a PodList, both are supported.
+func readCollectedPods(findFiles getChildCollectedFileContents, namespaces []string) ([]corev1.Pod, error) {
+	files, err := collectedNamespaceFiles(findFiles, constants.CLUSTER_RESOURCES_PODS, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := []corev1.Pod{}
+	for namespace, fileContent := range files {
+		var podList corev1.PodList
+		if err := json.Unmarshal(fileContent, &podList); err != nil {
+			var podArr []corev1.Pod
+			if err := json.Unmarshal(fileContent, &podArr); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal pods list for namespace %s", namespace)
+			}
+			pods = append(pods, podArr...)
+			continue
+		}
+		pods = append(pods, podList.Items...)
+	}
+
+	return pods, nil
+}
+
+// readCollectedPVCs returns the persistent volume claims collected by the cluster resources collector.
+func readCollectedPVCs(findFiles getChildCollectedFileContents, namespaces []string) ([]corev1.PersistentVolumeClaim, error) {
+	files, err := collectedNamespaceFiles(findFiles, constants.CLUSTER_RESOURCES_PVCS, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	pvcs := []corev1.PersistentVolumeClaim{}
+	for namespace, fileContent := range files {
+		var pvcList corev1.PersistentVolumeClaimList
+		if err := json.Unmarshal(fileContent, &pvcList); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal pvcs list for namespace %s", namespace)
+		}
+		pvcs = append(pvcs, pvcList.Items...)
+	}
+
+	return pvcs, nil
+}
+
+// readCollectedNodes returns the nodes collected by the cluster resources collector.
+func readCollectedNodes(getFile getCollectedFileContents) ([]corev1.Node, error) {
+	collected, err := getFile(fmt.Sprintf("%s/%s.json", constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_NODES))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get contents of nodes.json")
+	}
+
+	var nodes corev1.NodeList
+	if err := json.Unmarshal(collected, &nodes); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal node list")
+	}
+
+	return nodes.Items, nil
+}
+
+// readCollectedNamespaces returns the namespaces collected by the cluster resources collector.
+// When the collector is limited to a list of namespaces it stores a plain array of the ones that
+// exist instead of a NamespaceList, both are supported.
+func readCollectedNamespaces(getFile getCollectedFileContents) ([]corev1.Namespace, error) {
+	collected, err := getFile(fmt.Sprintf("%s/%s.json", constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_NAMESPACES))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get contents of namespaces.json")
+	}
+
+	var namespaceList corev1.NamespaceList
+	if err := json.Unmarshal(collected, &namespaceList); err != nil {
+		var namespaceArr []corev1.Namespace
+		if err := json.Unmarshal(collected, &namespaceArr); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal namespace list")
+		}
+		return namespaceArr, nil
+	}
+
+	return namespaceList.Items, nil
+}
+
+// readCollectedConfigMaps returns the config maps collected by the cluster resources collector.
+func readCollectedConfigMaps(findFiles getChildCollectedFileContents, namespaces []string) ([]corev1.ConfigMap, error) {
+	files, err := collectedNamespaceFiles(findFiles, constants.CLUSTER_RESOURCES_CONFIGMAPS, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	configMaps := []corev1.ConfigMap{}
+	for namespace, fileContent := range files {
+		var configMapList corev1.ConfigMapList
+		if err := json.Unmarshal(fileContent, &configMapList); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal config maps list for namespace %s", namespace)
+		}
+		configMaps = append(configMaps, configMapList.Items...)
+	}
+
+	return configMaps, nil
+}
+
+// readCollectedDeployments returns the deployments collected by the cluster resources collector.
+func readCollectedDeployments(findFiles getChildCollectedFileContents, namespaces []string) ([]appsv1.Deployment, error) {
+	files, err := collectedNamespaceFiles(findFiles, constants.CLUSTER_RESOURCES_DEPLOYMENTS, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := []appsv1.Deployment{}
+	for namespace, fileContent := range files {
+		var deploymentList appsv1.DeploymentList
+		if err := json.Unmarshal(fileContent, &deploymentList); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal deployments list for namespace %s", namespace)
+		}
+		deployments = append(deployments, deploymentList.Items...)
+	}
+
+	return deployments, nil
+}
+
+// readCollectedStatefulSets returns the statefulsets collected by the cluster resources collector.
+func readCollectedStatefulSets(findFiles getChildCollectedFileContents, namespaces []string) ([]appsv1.StatefulSet, error) {
+	files, err := collectedNamespaceFiles(findFiles, constants.CLUSTER_RESOURCES_STATEFULSETS, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	statefulSets := []appsv1.StatefulSet{}
+	for namespace, fileContent := range files {
+		var statefulSetList appsv1.StatefulSetList
+		if err := json.Unmarshal(fileContent, &statefulSetList); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal statefulsets list for namespace %s", namespace)
+		}
+		statefulSets = append(statefulSets, statefulSetList.Items...)
+	}
+
+	return statefulSets, nil
+}
+
+// readCollectedServices returns the services collected by the cluster resources collector.
+func readCollectedServices(findFiles getChildCollectedFileContents, namespaces []string) ([]corev1.Service, error) {
+	files, err := collectedNamespaceFiles(findFiles, constants.CLUSTER_RESOURCES_SERVICES, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	services := []corev1.Service{}
+	for namespace, fileContent := range files {
+		var serviceList corev1.ServiceList
+		if err := json.Unmarshal(fileContent, &serviceList); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal services list for namespace %s", namespace)
+		}
+		services = append(services, serviceList.Items...)
+	}
+
+	return services, nil
+}
+
+// readCollectedEvents returns the events collected by the cluster resources collector.
+func readCollectedEvents(findFiles getChildCollectedFileContents, namespaces []string) ([]corev1.Event, error) {
+	files, err := collectedNamespaceFiles(findFiles, constants.CLUSTER_RESOURCES_EVENTS, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []corev1.Event{}
+	for namespace, fileContent := range files {
+		var eventList corev1.EventList
+		if err := json.Unmarshal(fileContent, &eventList); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal events list for namespace %s", namespace)
+		}
+		events = append(events, eventList.Items...)
+	}
+
+	return events, nil
+}
+
+// readCollectedPodDisruptionBudgets returns the pod disruption budgets collected by the cluster
+// resources collector. Clusters without policy/v1 have them saved as policy/v1beta1, whose fields
+// the analyzers use are the same.
+func readCollectedPodDisruptionBudgets(findFiles getChildCollectedFileContents, namespaces []string) ([]policyv1.PodDisruptionBudget, error) {
+	files, err := collectedNamespaceFiles(findFiles, constants.CLUSTER_RESOURCES_POD_DISRUPTION_BUDGETS, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	pdbs := []policyv1.PodDisruptionBudget{}
+	for namespace, fileContent := range files {
+		var pdbList policyv1.PodDisruptionBudgetList
+		if err := json.Unmarshal(fileContent, &pdbList); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal pod disruption budgets list for namespace %s", namespace)
+		}
+		pdbs = append(pdbs, pdbList.Items...)
+	}
+
+	return pdbs, nil
+}
+
+func readCollectedClusterRoles(getFile getCollectedFileContents) ([]rbacv1.ClusterRole, error) {
+	collected, err := getFile(fmt.Sprintf("%s/%s.json", constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_CLUSTER_ROLES))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get contents of clusterroles.json")
+	}
+
+	var clusterRoles rbacv1.ClusterRoleList
+	if err := json.Unmarshal(collected, &clusterRoles); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal clusterrole list")
+	}
+
+	return clusterRoles.Items, nil
+}
+
+func readCollectedClusterRoleBindings(getFile getCollectedFileContents) ([]rbacv1.ClusterRoleBinding, error) {
+	collected, err := getFile(fmt.Sprintf("%s/%s.json", constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_CLUSTER_ROLE_BINDINGS))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get contents of clusterrolebindings.json")
+	}
+
+	var clusterRoleBindings rbacv1.ClusterRoleBindingList
+	if err := json.Unmarshal(collected, &clusterRoleBindings); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal clusterrolebinding list")
+	}
+
+	return clusterRoleBindings.Items, nil
+}
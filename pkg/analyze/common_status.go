@@ -9,7 +9,7 @@ import (
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
 )
 
-func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey string, iconURI string, readyReplicas int, exists bool, resourceType string) (*AnalyzeResult, error) {
+func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey string, iconURI string, readyReplicas int, exists bool, resourceType string, templateContext map[string]any) (*AnalyzeResult, error) {
 	result := &AnalyzeResult{
 		Title:   fmt.Sprintf("%s Status", name),
 		IconKey: iconKey,
@@ -31,7 +31,7 @@ func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey
 
 			if outcome.Fail.When == "" {
 				result.IsFail = true
-				result.Message = outcome.Fail.Message
+				result.Message = renderOutcomeMessage(outcome.Fail.Message, templateContext)
 				result.URI = outcome.Fail.URI
 
 				return result, nil
@@ -40,7 +40,7 @@ func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey
 			if outcome.Fail.When == "absent" {
 				if exists == false {
 					result.IsFail = true
-					result.Message = outcome.Fail.Message
+					result.Message = renderOutcomeMessage(outcome.Fail.Message, templateContext)
 					result.URI = outcome.Fail.URI
 					return result, nil
 				} else {
@@ -55,7 +55,7 @@ func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey
 
 			if match {
 				result.IsFail = true
-				result.Message = outcome.Fail.Message
+				result.Message = renderOutcomeMessage(outcome.Fail.Message, templateContext)
 				result.URI = outcome.Fail.URI
 
 				return result, nil
@@ -71,7 +71,7 @@ func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey
 
 			if outcome.Warn.When == "" {
 				result.IsWarn = true
-				result.Message = outcome.Warn.Message
+				result.Message = renderOutcomeMessage(outcome.Warn.Message, templateContext)
 				result.URI = outcome.Warn.URI
 
 				return result, nil
@@ -80,7 +80,7 @@ func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey
 			if outcome.Warn.When == "absent" {
 				if exists == false {
 					result.IsWarn = true
-					result.Message = outcome.Warn.Message
+					result.Message = renderOutcomeMessage(outcome.Warn.Message, templateContext)
 					result.URI = outcome.Warn.URI
 					return result, nil
 				} else {
@@ -95,7 +95,7 @@ func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey
 
 			if match {
 				result.IsWarn = true
-				result.Message = outcome.Warn.Message
+				result.Message = renderOutcomeMessage(outcome.Warn.Message, templateContext)
 				result.URI = outcome.Warn.URI
 
 				return result, nil
@@ -111,7 +111,7 @@ func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey
 
 			if outcome.Pass.When == "" {
 				result.IsPass = true
-				result.Message = outcome.Pass.Message
+				result.Message = renderOutcomeMessage(outcome.Pass.Message, templateContext)
 				result.URI = outcome.Pass.URI
 
 				return result, nil
@@ -120,7 +120,7 @@ func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey
 			if outcome.Pass.When == "absent" {
 				if exists == false {
 					result.IsPass = true
-					result.Message = outcome.Pass.Message
+					result.Message = renderOutcomeMessage(outcome.Pass.Message, templateContext)
 					result.URI = outcome.Pass.URI
 					return result, nil
 				} else {
@@ -135,7 +135,7 @@ func commonStatus(outcomes []*troubleshootv1beta2.Outcome, name string, iconKey
 
 			if match {
 				result.IsPass = true
-				result.Message = outcome.Pass.Message
+				result.Message = renderOutcomeMessage(outcome.Pass.Message, templateContext)
 				result.URI = outcome.Pass.URI
 
 				return result, nil
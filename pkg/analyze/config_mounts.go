@@ -0,0 +1,216 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	defaultConfigMountsMaxMounts      = 20
+	defaultConfigMountsMaxMountedSize = "1Mi"
+)
+
+type AnalyzeConfigMounts struct {
+	analyzer *troubleshootv1beta2.ConfigMountsAnalyze
+}
+
+// configMountsIssue is the template data available to outcome messages, one is reported for each
+// pod over a threshold
+type configMountsIssue struct {
+	Namespace string
+	Name      string
+	// ConfigMaps and Secrets count the distinct objects mounted through volumes, including
+	// projected volume sources
+	ConfigMaps int
+	Secrets    int
+	Mounts     int
+	// MountedBytes is the size of the data of the mounted ConfigMaps. Secret data is not collected
+	// so Secrets only count towards Mounts.
+	MountedBytes   int64
+	MaxMounts      int
+	MaxMountedSize string
+	TooManyMounts  bool
+	TooLarge       bool
+}
+
+func (a *AnalyzeConfigMounts) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "ConfigMap and Secret Mounts"
+}
+
+func (a *AnalyzeConfigMounts) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeConfigMounts) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	maxMounts := a.analyzer.MaxMounts
+	if maxMounts <= 0 {
+		maxMounts = defaultConfigMountsMaxMounts
+	}
+
+	maxMountedSize := a.analyzer.MaxMountedSize
+	if maxMountedSize == "" {
+		maxMountedSize = defaultConfigMountsMaxMountedSize
+	}
+	maxMountedBytes, err := resource.ParseQuantity(maxMountedSize)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse max mounted size %q", maxMountedSize)
+	}
+
+	pods, err := readCollectedPods(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	configMaps, err := readCollectedConfigMaps(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := findConfigMountIssues(pods, configMaps, maxMounts, maxMountedBytes.Value())
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		issue.MaxMountedSize = maxMountedSize
+
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: defaultConfigMountsMessage(issue),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  issue.Namespace,
+			Name:       issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No pods mount an excessive number or size of ConfigMaps and Secrets",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultConfigMountsMessage(issue configMountsIssue) string {
+	reasons := []string{}
+	if issue.TooManyMounts {
+		reasons = append(reasons, fmt.Sprintf("%d ConfigMaps and Secrets (%d ConfigMaps, %d Secrets), more than %d", issue.Mounts, issue.ConfigMaps, issue.Secrets, issue.MaxMounts))
+	}
+	if issue.TooLarge {
+		reasons = append(reasons, fmt.Sprintf("%d bytes of ConfigMap data, more than %s", issue.MountedBytes, issue.MaxMountedSize))
+	}
+	return fmt.Sprintf("Pod %s/%s mounts %s. Every mounted object is fetched and kept in sync by the kubelet, which slows pod startup and adds load on the node.",
+		issue.Namespace, issue.Name, strings.Join(reasons, " and "))
+}
+
+// findConfigMountIssues returns the pods that mount more ConfigMaps and Secrets than maxMounts or
+// more ConfigMap data than maxMountedBytes. Completed pods are ignored.
+func findConfigMountIssues(pods []corev1.Pod, configMaps []corev1.ConfigMap, maxMounts int, maxMountedBytes int64) []configMountsIssue {
+	configMapSizes := map[string]int64{}
+	for _, configMap := range configMaps {
+		configMapSizes[configMap.Namespace+"/"+configMap.Name] = configMapSize(configMap)
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return podKey(pods[i]) < podKey(pods[j])
+	})
+
+	issues := []configMountsIssue{}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		configMapNames, secretNames := podConfigMounts(pod.Spec)
+
+		issue := configMountsIssue{
+			Namespace:  pod.Namespace,
+			Name:       pod.Name,
+			ConfigMaps: len(configMapNames),
+			Secrets:    len(secretNames),
+			Mounts:     len(configMapNames) + len(secretNames),
+			MaxMounts:  maxMounts,
+		}
+		for name := range configMapNames {
+			issue.MountedBytes += configMapSizes[pod.Namespace+"/"+name]
+		}
+		issue.TooManyMounts = issue.Mounts > maxMounts
+		issue.TooLarge = issue.MountedBytes > maxMountedBytes
+
+		if issue.TooManyMounts || issue.TooLarge {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+// podConfigMounts returns the names of the ConfigMaps and Secrets mounted by the pod's volumes
+func podConfigMounts(spec corev1.PodSpec) (map[string]struct{}, map[string]struct{}) {
+	configMapNames := map[string]struct{}{}
+	secretNames := map[string]struct{}{}
+
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			configMapNames[volume.ConfigMap.Name] = struct{}{}
+		}
+		if volume.Secret != nil {
+			secretNames[volume.Secret.SecretName] = struct{}{}
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					configMapNames[source.ConfigMap.Name] = struct{}{}
+				}
+				if source.Secret != nil {
+					secretNames[source.Secret.Name] = struct{}{}
+				}
+			}
+		}
+	}
+
+	return configMapNames, secretNames
+}
+
+func configMapSize(configMap corev1.ConfigMap) int64 {
+	size := int64(0)
+	for _, value := range configMap.Data {
+		size += int64(len(value))
+	}
+	for _, value := range configMap.BinaryData {
+		size += int64(len(value))
+	}
+	return size
+}
@@ -0,0 +1,160 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeConfigMounts(t *testing.T) {
+	podReference := func(name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: name}
+	}
+
+	files := map[string][]byte{
+		"cluster-resources/pods/default.json":       []byte(configMountsPods),
+		"cluster-resources/configmaps/default.json": []byte(configMountsConfigMaps),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.ConfigMountsAnalyze
+		expectResult []AnalyzeResult
+		expectErr    string
+	}{
+		{
+			name:     "default thresholds flag pods mounting many objects",
+			analyzer: troubleshootv1beta2.ConfigMountsAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "ConfigMap and Secret Mounts",
+					Message:        "Pod default/api-7c9d8b6f5-x2k9p mounts 22 ConfigMaps and Secrets (8 ConfigMaps, 14 Secrets), more than 20. Every mounted object is fetched and kept in sync by the kubelet, which slows pod startup and adds load on the node.",
+					InvolvedObject: podReference("api-7c9d8b6f5-x2k9p"),
+				},
+			},
+		},
+		{
+			name: "pods mounting large config maps",
+			analyzer: troubleshootv1beta2.ConfigMountsAnalyze{
+				MaxMounts:      3,
+				MaxMountedSize: "8Ki",
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "ConfigMap and Secret Mounts",
+					Message:        "Pod default/api-7c9d8b6f5-x2k9p mounts 22 ConfigMaps and Secrets (8 ConfigMaps, 14 Secrets), more than 3. Every mounted object is fetched and kept in sync by the kubelet, which slows pod startup and adds load on the node.",
+					InvolvedObject: podReference("api-7c9d8b6f5-x2k9p"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "ConfigMap and Secret Mounts",
+					Message:        "Pod default/nginx-0 mounts 9770 bytes of ConfigMap data, more than 8Ki. Every mounted object is fetched and kept in sync by the kubelet, which slows pod startup and adds load on the node.",
+					InvolvedObject: podReference("nginx-0"),
+				},
+			},
+		},
+		{
+			name: "custom outcomes are templated with the mounts",
+			analyzer: troubleshootv1beta2.ConfigMountsAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Pod Startup",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Name }}: {{ .Mounts }} mounts, {{ .MountedBytes }} bytes, too many={{ .TooManyMounts }}, too large={{ .TooLarge }}",
+						},
+					},
+				},
+				MaxMounts:      2,
+				MaxMountedSize: "10Ki",
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Pod Startup",
+					Message:        "api-7c9d8b6f5-x2k9p: 22 mounts, 123 bytes, too many=true, too large=false",
+					InvolvedObject: podReference("api-7c9d8b6f5-x2k9p"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Pod Startup",
+					Message:        "nginx-0: 3 mounts, 9770 bytes, too many=true, too large=false",
+					InvolvedObject: podReference("nginx-0"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Pod Startup",
+					Message:        "worker-5f6d7c8b9-q4w5e: 3 mounts, 131 bytes, too many=true, too large=false",
+					InvolvedObject: podReference("worker-5f6d7c8b9-q4w5e"),
+				},
+			},
+		},
+		{
+			name: "no pods over the thresholds passes",
+			analyzer: troubleshootv1beta2.ConfigMountsAnalyze{
+				MaxMounts: 50,
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "ConfigMap and Secret Mounts",
+					Message: "No pods mount an excessive number or size of ConfigMaps and Secrets",
+				},
+			},
+		},
+		{
+			name: "invalid size",
+			analyzer: troubleshootv1beta2.ConfigMountsAnalyze{
+				MaxMountedSize: "lots",
+			},
+			expectErr: `failed to parse max mounted size "lots"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeConfigMounts{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			if test.expectErr != "" {
+				req.ErrorContains(err, test.expectErr)
+				return
+			}
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	configMapDriftMissingConfigMap = "ConfigMapMissing"
+	configMapDriftMissingKey       = "KeyMissing"
+	configMapDriftMismatch         = "ValueMismatch"
+)
+
+type AnalyzeConfigMapDrift struct {
+	analyzer *troubleshootv1beta2.ConfigMapDriftAnalyze
+}
+
+// configMapDriftIssue is the template data available to outcome messages, one is reported for
+// each key that does not match its expectation
+type configMapDriftIssue struct {
+	Namespace string
+	Name      string
+	Key       string
+	// Drift is one of ConfigMapMissing, KeyMissing or ValueMismatch
+	Drift string
+	// Expected is the expected value, or the pattern when IsRegex is true. It is empty when the
+	// key only has to exist.
+	Expected string
+	IsRegex  bool
+	Actual   string
+}
+
+func (a *AnalyzeConfigMapDrift) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "ConfigMap Drift"
+}
+
+func (a *AnalyzeConfigMapDrift) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeConfigMapDrift) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	namespaces := []string{}
+	for _, expectation := range a.analyzer.ConfigMaps {
+		namespaces = append(namespaces, expectation.Namespace)
+	}
+
+	configMaps, err := readCollectedConfigMaps(findFiles, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := findConfigMapDrift(configMaps, a.analyzer.ConfigMaps)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsFail:  true,
+				Message: defaultConfigMapDriftMessage(issue),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  issue.Namespace,
+			Name:       issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "All ConfigMap keys match their expected values",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultConfigMapDriftMessage(issue configMapDriftIssue) string {
+	switch issue.Drift {
+	case configMapDriftMissingConfigMap:
+		return fmt.Sprintf("ConfigMap %s/%s was not found, key %s could not be checked", issue.Namespace, issue.Name, issue.Key)
+	case configMapDriftMissingKey:
+		return fmt.Sprintf("ConfigMap %s/%s does not have key %s", issue.Namespace, issue.Name, issue.Key)
+	}
+	if issue.IsRegex {
+		return fmt.Sprintf("Key %s of ConfigMap %s/%s is %q, expected a value matching %q", issue.Key, issue.Namespace, issue.Name, issue.Actual, issue.Expected)
+	}
+	return fmt.Sprintf("Key %s of ConfigMap %s/%s is %q, expected %q", issue.Key, issue.Namespace, issue.Name, issue.Actual, issue.Expected)
+}
+
+// findConfigMapDrift checks each expected key against the collected config maps and returns the
+// keys that drifted, in the order they are listed in the expectations
+func findConfigMapDrift(configMaps []corev1.ConfigMap, expectations []troubleshootv1beta2.ConfigMapDriftExpectation) ([]configMapDriftIssue, error) {
+	byName := map[string]corev1.ConfigMap{}
+	for _, configMap := range configMaps {
+		byName[configMap.Namespace+"/"+configMap.Name] = configMap
+	}
+
+	issues := []configMapDriftIssue{}
+	for _, expectation := range expectations {
+		configMap, configMapExists := byName[expectation.Namespace+"/"+expectation.Name]
+
+		for _, expected := range expectation.Keys {
+			issue := configMapDriftIssue{
+				Namespace: expectation.Namespace,
+				Name:      expectation.Name,
+				Key:       expected.Key,
+				Expected:  expected.Value,
+			}
+
+			var re *regexp.Regexp
+			if expected.Regex != "" {
+				var err error
+				re, err = regexp.Compile(expected.Regex)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to compile regex for key %s of configmap %s/%s", expected.Key, expectation.Namespace, expectation.Name)
+				}
+				issue.Expected = expected.Regex
+				issue.IsRegex = true
+			}
+
+			if !configMapExists {
+				issue.Drift = configMapDriftMissingConfigMap
+				issues = append(issues, issue)
+				continue
+			}
+
+			actual, ok := configMap.Data[expected.Key]
+			if !ok {
+				issue.Drift = configMapDriftMissingKey
+				issues = append(issues, issue)
+				continue
+			}
+			issue.Actual = actual
+
+			matches := true
+			if re != nil {
+				matches = re.MatchString(actual)
+			} else if expected.Value != "" {
+				matches = actual == expected.Value
+			}
+			if matches {
+				continue
+			}
+			issue.Drift = configMapDriftMismatch
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
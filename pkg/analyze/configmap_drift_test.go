@@ -0,0 +1,197 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeConfigMapDrift(t *testing.T) {
+	configMapReference := func(namespace, name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: namespace, Name: name}
+	}
+
+	files := map[string][]byte{
+		"cluster-resources/configmaps/default.json":  []byte(configMapDriftDefault),
+		"cluster-resources/configmaps/payments.json": []byte(configMapDriftPayments),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.ConfigMapDriftAnalyze
+		expectResult []AnalyzeResult
+		expectErr    string
+	}{
+		{
+			name: "matching config passes",
+			analyzer: troubleshootv1beta2.ConfigMapDriftAnalyze{
+				ConfigMaps: []troubleshootv1beta2.ConfigMapDriftExpectation{
+					{
+						Name:      "app-config",
+						Namespace: "default",
+						Keys: []troubleshootv1beta2.ConfigMapDriftKey{
+							{Key: "FEATURE_NEW_CHECKOUT", Value: "false"},
+							{Key: "CACHE_TTL", Regex: `^[0-9]+s$`},
+							{Key: "LOG_LEVEL"},
+						},
+					},
+					{
+						Name:      "payments-config",
+						Namespace: "payments",
+						Keys: []troubleshootv1beta2.ConfigMapDriftKey{
+							{Key: "PROVIDER_URL", Regex: `^https://`},
+						},
+					},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "ConfigMap Drift",
+					Message: "All ConfigMap keys match their expected values",
+				},
+			},
+		},
+		{
+			name: "drifted keys are reported with actual and expected values",
+			analyzer: troubleshootv1beta2.ConfigMapDriftAnalyze{
+				ConfigMaps: []troubleshootv1beta2.ConfigMapDriftExpectation{
+					{
+						Name:      "app-config",
+						Namespace: "default",
+						Keys: []troubleshootv1beta2.ConfigMapDriftKey{
+							{Key: "FEATURE_NEW_CHECKOUT", Value: "true"},
+							{Key: "API_ENDPOINT", Regex: `^https://api\.example\.com$`},
+							{Key: "DATABASE_URL"},
+							{Key: "LOG_LEVEL", Value: "info"},
+						},
+					},
+					{
+						Name:      "worker-config",
+						Namespace: "payments",
+						Keys: []troubleshootv1beta2.ConfigMapDriftKey{
+							{Key: "QUEUE", Value: "payments"},
+						},
+					},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "ConfigMap Drift",
+					Message:        `Key FEATURE_NEW_CHECKOUT of ConfigMap default/app-config is "false", expected "true"`,
+					InvolvedObject: configMapReference("default", "app-config"),
+				},
+				{
+					IsFail:         true,
+					Title:          "ConfigMap Drift",
+					Message:        `Key API_ENDPOINT of ConfigMap default/app-config is "https://api.staging.example.com", expected a value matching "^https://api\\.example\\.com$"`,
+					InvolvedObject: configMapReference("default", "app-config"),
+				},
+				{
+					IsFail:         true,
+					Title:          "ConfigMap Drift",
+					Message:        "ConfigMap default/app-config does not have key DATABASE_URL",
+					InvolvedObject: configMapReference("default", "app-config"),
+				},
+				{
+					IsFail:         true,
+					Title:          "ConfigMap Drift",
+					Message:        "ConfigMap payments/worker-config was not found, key QUEUE could not be checked",
+					InvolvedObject: configMapReference("payments", "worker-config"),
+				},
+			},
+		},
+		{
+			name: "custom outcomes are templated with the drift",
+			analyzer: troubleshootv1beta2.ConfigMapDriftAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Payments Config",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Name }}.{{ .Key }} {{ .Drift }}: {{ .Actual }} != {{ .Expected }}",
+						},
+					},
+				},
+				ConfigMaps: []troubleshootv1beta2.ConfigMapDriftExpectation{
+					{
+						Name:      "payments-config",
+						Namespace: "payments",
+						Keys: []troubleshootv1beta2.ConfigMapDriftKey{
+							{Key: "RETRY_LIMIT", Value: "3"},
+						},
+					},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Payments Config",
+					Message:        "payments-config.RETRY_LIMIT ValueMismatch: 5 != 3",
+					InvolvedObject: configMapReference("payments", "payments-config"),
+				},
+			},
+		},
+		{
+			name: "invalid regex",
+			analyzer: troubleshootv1beta2.ConfigMapDriftAnalyze{
+				ConfigMaps: []troubleshootv1beta2.ConfigMapDriftExpectation{
+					{
+						Name:      "app-config",
+						Namespace: "default",
+						Keys: []troubleshootv1beta2.ConfigMapDriftKey{
+							{Key: "LOG_LEVEL", Regex: `(info`},
+						},
+					},
+				},
+			},
+			expectErr: "failed to compile regex for key LOG_LEVEL of configmap default/app-config",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeConfigMapDrift{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			if test.expectErr != "" {
+				req.ErrorContains(err, test.expectErr)
+				return
+			}
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
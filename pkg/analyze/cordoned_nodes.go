@@ -0,0 +1,272 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultMaintenanceTaints are the taint keys set on nodes that are about to be drained or removed
+var defaultMaintenanceTaints = []string{
+	corev1.TaintNodeUnschedulable,
+	corev1.TaintNodeOutOfService,
+	"ToBeDeletedByClusterAutoscaler",
+	"DeletionCandidateOfClusterAutoscaler",
+	"karpenter.sh/disrupted",
+	"karpenter.sh/disruption",
+	"node.cloudprovider.kubernetes.io/shutdown",
+}
+
+type AnalyzeCordonedNodes struct {
+	analyzer *troubleshootv1beta2.CordonedNodesAnalyze
+}
+
+// cordonedNodeIssue is the template data available to outcome messages, one is reported for each
+// cordoned or maintenance tainted node running pods a drain would evict
+type cordonedNodeIssue struct {
+	Node string
+	// Reason describes why the node will be drained, "cordoned" or "marked for removal by taint
+	// <key>"
+	Reason   string
+	PodCount int
+	// Pods lists the evicted pods as namespace/name
+	Pods        string
+	AtRiskCount int
+	// AtRiskPods lists the evicted pods that will be unavailable until they are rescheduled, with
+	// the reasons, e.g. "default/api-6b9f (single replica, no PodDisruptionBudget)"
+	AtRiskPods string
+}
+
+func (a *AnalyzeCordonedNodes) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Cordoned Nodes"
+}
+
+func (a *AnalyzeCordonedNodes) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeCordonedNodes) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	nodes, err := readCollectedNodes(getFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := readCollectedPods(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads, err := readHAWorkloads(findFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	pdbs, err := readCollectedPodDisruptionBudgets(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	maintenanceTaints := a.analyzer.MaintenanceTaints
+	if len(maintenanceTaints) == 0 {
+		maintenanceTaints = defaultMaintenanceTaints
+	}
+
+	issues, err := findCordonedNodeIssues(nodes, pods, workloads, pdbs, maintenanceTaints)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			message := fmt.Sprintf("Node %s is %s and a drain will evict the pods running on it: %s",
+				issue.Node, issue.Reason, issue.Pods)
+			if issue.AtRiskCount > 0 {
+				message = fmt.Sprintf("Node %s is %s and a drain will evict pods that will be unavailable until they are rescheduled: %s",
+					issue.Node, issue.Reason, issue.AtRiskPods)
+			}
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsFail:  issue.AtRiskCount > 0,
+				IsWarn:  issue.AtRiskCount == 0,
+				Message: message,
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       issue.Node,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No pods are running on cordoned nodes or nodes marked for removal",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// findCordonedNodeIssues returns the cordoned and maintenance tainted nodes, ordered by name, that
+// run pods a drain would evict. DaemonSet, static and completed pods are not evicted and are
+// ignored.
+func findCordonedNodeIssues(nodes []corev1.Node, pods []corev1.Pod, workloads []haWorkload, pdbs []policyv1.PodDisruptionBudget, maintenanceTaints []string) ([]cordonedNodeIssue, error) {
+	podsByNode := map[string][]corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || !isEvictablePod(pod) {
+			continue
+		}
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	issues := []cordonedNodeIssue{}
+	for _, node := range nodes {
+		reason := nodeDrainReason(node, maintenanceTaints)
+		if reason == "" {
+			continue
+		}
+
+		nodePods := podsByNode[node.Name]
+		if len(nodePods) == 0 {
+			continue
+		}
+		sort.Slice(nodePods, func(i, j int) bool {
+			if nodePods[i].Namespace != nodePods[j].Namespace {
+				return nodePods[i].Namespace < nodePods[j].Namespace
+			}
+			return nodePods[i].Name < nodePods[j].Name
+		})
+
+		names := []string{}
+		atRisk := []string{}
+		for _, pod := range nodePods {
+			name := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+			names = append(names, name)
+
+			risks, err := podDisruptionRisks(pod, workloads, pdbs)
+			if err != nil {
+				return nil, err
+			}
+			if len(risks) > 0 {
+				atRisk = append(atRisk, fmt.Sprintf("%s (%s)", name, strings.Join(risks, ", ")))
+			}
+		}
+
+		issues = append(issues, cordonedNodeIssue{
+			Node:        node.Name,
+			Reason:      reason,
+			PodCount:    len(names),
+			Pods:        strings.Join(names, ", "),
+			AtRiskCount: len(atRisk),
+			AtRiskPods:  strings.Join(atRisk, ", "),
+		})
+	}
+
+	return issues, nil
+}
+
+// nodeDrainReason returns why the node is expected to be drained, or an empty string when it is not
+func nodeDrainReason(node corev1.Node, maintenanceTaints []string) string {
+	if node.Spec.Unschedulable {
+		return "cordoned"
+	}
+	for _, taint := range node.Spec.Taints {
+		if slices.Contains(maintenanceTaints, taint.Key) {
+			return fmt.Sprintf("marked for removal by taint %s", taint.Key)
+		}
+	}
+	return ""
+}
+
+// isEvictablePod returns false for the pods a drain leaves in place: completed pods, static pods
+// and pods owned by a DaemonSet
+func isEvictablePod(pod corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+	return true
+}
+
+// podDisruptionRisks returns why evicting the pod leaves its workload unavailable: the pod has no
+// controller to recreate it, its Deployment or StatefulSet runs a single replica, or no
+// PodDisruptionBudget limits how many of its replicas are evicted at once
+func podDisruptionRisks(pod corev1.Pod, workloads []haWorkload, pdbs []policyv1.PodDisruptionBudget) ([]string, error) {
+	risks := []string{}
+
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		risks = append(risks, "not managed by a controller")
+	} else if workload := podWorkload(pod, *owner, workloads); workload != nil && workload.replicas <= 1 {
+		risks = append(risks, "single replica")
+	}
+
+	protected, err := podsHaveDisruptionBudget(pod.Namespace, pod.Labels, pdbs)
+	if err != nil {
+		return nil, err
+	}
+	if !protected {
+		risks = append(risks, "no PodDisruptionBudget")
+	}
+
+	return risks, nil
+}
+
+// podWorkload returns the Deployment or StatefulSet that owns the pod, a Deployment owns the pod
+// through a ReplicaSet named after it and the pod template hash
+func podWorkload(pod corev1.Pod, owner metav1.OwnerReference, workloads []haWorkload) *haWorkload {
+	kind, name := owner.Kind, owner.Name
+	if kind == "ReplicaSet" {
+		hash := pod.Labels["pod-template-hash"]
+		if hash == "" || !strings.HasSuffix(name, "-"+hash) {
+			return nil
+		}
+		kind, name = "Deployment", strings.TrimSuffix(name, "-"+hash)
+	}
+
+	for i, workload := range workloads {
+		if workload.kind == kind && workload.meta.Namespace == pod.Namespace && workload.meta.Name == name {
+			return &workloads[i]
+		}
+	}
+	return nil
+}
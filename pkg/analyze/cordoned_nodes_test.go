@@ -0,0 +1,137 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeCordonedNodes(t *testing.T) {
+	nodeReference := func(name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "v1", Kind: "Node", Name: name}
+	}
+
+	files := map[string][]byte{
+		"cluster-resources/nodes.json":                          []byte(cordonedNodesNodes),
+		"cluster-resources/pods/default.json":                   []byte(cordonedNodesPodsDefault),
+		"cluster-resources/pods/kube-system.json":               []byte(cordonedNodesPodsKubeSystem),
+		"cluster-resources/deployments/default.json":            []byte(cordonedNodesDeployments),
+		"cluster-resources/statefulsets/default.json":           []byte(cordonedNodesStatefulSets),
+		"cluster-resources/pod-disruption-budgets/default.json": []byte(cordonedNodesPodDisruptionBudgets),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.CordonedNodesAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "pods on cordoned and maintenance tainted nodes",
+			analyzer: troubleshootv1beta2.CordonedNodesAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Cordoned Nodes",
+					Message:        "Node node-2 is cordoned and a drain will evict pods that will be unavailable until they are rescheduled: default/api-5c4b9d7f8-vx2lm (single replica, no PodDisruptionBudget), default/debug (not managed by a controller, no PodDisruptionBudget)",
+					InvolvedObject: nodeReference("node-2"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Cordoned Nodes",
+					Message:        "Node node-3 is marked for removal by taint ToBeDeletedByClusterAutoscaler and a drain will evict pods that will be unavailable until they are rescheduled: default/redis-0 (no PodDisruptionBudget)",
+					InvolvedObject: nodeReference("node-3"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "Cordoned Nodes",
+					Message:        "Node node-5 is marked for removal by taint karpenter.sh/disrupted and a drain will evict the pods running on it: default/web-7d9f8c6b5-q9wz7",
+					InvolvedObject: nodeReference("node-5"),
+				},
+			},
+		},
+		{
+			name: "custom maintenance taints and outcomes",
+			analyzer: troubleshootv1beta2.CordonedNodesAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Node Maintenance",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Node }} is {{ .Reason }}: {{ .PodCount }} pods, {{ .AtRiskCount }} at risk",
+						},
+					},
+				},
+				Namespaces:        []string{"default"},
+				MaintenanceTaints: []string{"dedicated"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Node Maintenance",
+					Message:        "node-2 is cordoned: 3 pods, 2 at risk",
+					InvolvedObject: nodeReference("node-2"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "Node Maintenance",
+					Message:        "node-6 is marked for removal by taint dedicated: 1 pods, 1 at risk",
+					InvolvedObject: nodeReference("node-6"),
+				},
+			},
+		},
+		{
+			name: "no pods on cordoned nodes",
+			analyzer: troubleshootv1beta2.CordonedNodesAnalyze{
+				Namespaces: []string{"kube-system"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Cordoned Nodes",
+					Message: "No pods are running on cordoned nodes or nodes marked for removal",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeCordonedNodes{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const defaultCSRPendingThreshold = 10 * time.Minute
+
+const (
+	csrStatusPending = "Pending"
+	csrStatusDenied  = "Denied"
+	csrStatusFailed  = "Failed"
+)
+
+type AnalyzeCSR struct {
+	analyzer *troubleshootv1beta2.CSRAnalyze
+}
+
+// csrIssue is the template data available to outcome messages
+type csrIssue struct {
+	Name       string
+	SignerName string
+	// Username and Groups identify who requested the certificate, for kubelets this is the node
+	Username string
+	Groups   []string
+	// Status is one of Pending, Denied or Failed
+	Status string
+	Age    string
+	// Reason and Message are taken from the Denied or Failed condition
+	Reason  string
+	Message string
+}
+
+func (a *AnalyzeCSR) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Certificate Signing Requests"
+}
+
+func (a *AnalyzeCSR) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeCSR) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	pendingThreshold := defaultCSRPendingThreshold
+	if a.analyzer.PendingThreshold != "" {
+		d, err := time.ParseDuration(a.analyzer.PendingThreshold)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse pending threshold %q", a.analyzer.PendingThreshold)
+		}
+		pendingThreshold = d
+	}
+
+	collected, err := getFile(fmt.Sprintf("%s/%s.json", constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_CSRS))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get contents of certificatesigningrequests.json")
+	}
+
+	var csrs certificatesv1.CertificateSigningRequestList
+	if err := json.Unmarshal(collected, &csrs); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal certificate signing request list")
+	}
+
+	issues := findCSRIssues(csrs.Items, pendingThreshold, time.Now())
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = defaultCSRIssueResult(a.Title(), issue)
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: certificatesv1.SchemeGroupVersion.String(),
+			Kind:       "CertificateSigningRequest",
+			Name:       issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No certificate signing requests are stuck pending, denied or failed",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultCSRIssueResult(title string, issue csrIssue) *AnalyzeResult {
+	var message string
+	switch issue.Status {
+	case csrStatusPending:
+		message = fmt.Sprintf("Certificate signing request %s from %s has been pending for %s.", issue.Name, issue.Username, issue.Age)
+		if issue.SignerName == certificatesv1.KubeletServingSignerName {
+			message += " Kubelet serving certificates are not approved automatically. Until it is approved, metrics, logs and exec will fail for the node."
+		}
+		message += fmt.Sprintf(" Review and approve it with \"kubectl certificate approve %s\", or enable an approver for signer %s.", issue.Name, issue.SignerName)
+	default:
+		message = fmt.Sprintf("Certificate signing request %s from %s was %s", issue.Name, issue.Username, strings.ToLower(issue.Status))
+		if issue.Reason != "" {
+			message += fmt.Sprintf(" (%s)", issue.Reason)
+		}
+		if issue.Message != "" {
+			message += ": " + issue.Message
+		}
+		message += fmt.Sprintf(". The requester will not receive a certificate from %s, delete the request so a new one can be submitted and approved.", issue.SignerName)
+	}
+
+	result := &AnalyzeResult{
+		Title:   title,
+		Message: message,
+	}
+	if issue.Status == csrStatusPending {
+		result.IsWarn = true
+	} else {
+		result.IsFail = true
+	}
+	return result
+}
+
+// findCSRIssues returns the requests that were denied or failed, and those that have been pending
+// for longer than pendingThreshold. Approved requests waiting on their signer are not reported.
+func findCSRIssues(csrs []certificatesv1.CertificateSigningRequest, pendingThreshold time.Duration, now time.Time) []csrIssue {
+	issues := []csrIssue{}
+	for _, csr := range csrs {
+		age := now.Sub(csr.CreationTimestamp.Time)
+		issue := csrIssue{
+			Name:       csr.Name,
+			SignerName: csr.Spec.SignerName,
+			Username:   csr.Spec.Username,
+			Groups:     csr.Spec.Groups,
+			Age:        age.Round(time.Second).String(),
+		}
+
+		approved := false
+		for _, condition := range csr.Status.Conditions {
+			switch condition.Type {
+			case certificatesv1.CertificateDenied:
+				issue.Status = csrStatusDenied
+			case certificatesv1.CertificateFailed:
+				if issue.Status != "" {
+					continue
+				}
+				issue.Status = csrStatusFailed
+			case certificatesv1.CertificateApproved:
+				approved = true
+				continue
+			default:
+				continue
+			}
+			issue.Reason = condition.Reason
+			issue.Message = condition.Message
+		}
+
+		if issue.Status == "" {
+			if approved || age < pendingThreshold {
+				continue
+			}
+			issue.Status = csrStatusPending
+		}
+
+		issues = append(issues, issue)
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].Name < issues[j].Name
+	})
+
+	return issues
+}
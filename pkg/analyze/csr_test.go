@@ -0,0 +1,188 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeCSR(t *testing.T) {
+	csrReference := func(name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "certificates.k8s.io/v1", Kind: "CertificateSigningRequest", Name: name}
+	}
+
+	approvedOnly := []byte(`{
+		"kind": "CertificateSigningRequestList",
+		"apiVersion": "certificates.k8s.io/v1",
+		"items": [
+			{
+				"metadata": {"name": "csr-4b8wd", "creationTimestamp": "2024-03-01T09:10:12Z"},
+				"spec": {"signerName": "kubernetes.io/kube-apiserver-client-kubelet", "username": "system:bootstrap:abcdef"},
+				"status": {"conditions": [{"type": "Approved", "status": "True", "reason": "AutoApproved"}]}
+			}
+		]
+	}`)
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.CSRAnalyze
+		csrs         []byte
+		expectResult []AnalyzeResult
+		expectErr    string
+	}{
+		{
+			name: "denied and failed requests with default outcomes",
+			analyzer: troubleshootv1beta2.CSRAnalyze{
+				PendingThreshold: "876000h",
+			},
+			csrs: []byte(csrCertificateSigningRequests),
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Certificate Signing Requests",
+					Message:        "Certificate signing request csr-2hvzq from system:node:node-c was failed (SignerValidationFailure): subjectAltNames must include the node's addresses. The requester will not receive a certificate from kubernetes.io/kubelet-serving, delete the request so a new one can be submitted and approved.",
+					InvolvedObject: csrReference("csr-2hvzq"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Certificate Signing Requests",
+					Message:        "Certificate signing request jane-client from jane was denied (NotAuthorized): client certificates are issued through the identity provider. The requester will not receive a certificate from kubernetes.io/kube-apiserver-client, delete the request so a new one can be submitted and approved.",
+					InvolvedObject: csrReference("jane-client"),
+				},
+			},
+		},
+		{
+			name: "templated outcomes include pending requests",
+			analyzer: troubleshootv1beta2.CSRAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Name }} {{ .Status }} for {{ .Username }} {{ .Groups }} via {{ .SignerName }} {{ .Reason }}",
+						},
+					},
+				},
+			},
+			csrs: []byte(csrCertificateSigningRequests),
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Certificate Signing Requests",
+					Message:        "csr-2hvzq Failed for system:node:node-c [system:nodes system:authenticated] via kubernetes.io/kubelet-serving SignerValidationFailure",
+					InvolvedObject: csrReference("csr-2hvzq"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "Certificate Signing Requests",
+					Message:        "csr-7xkq2 Pending for system:node:node-b [system:nodes system:authenticated] via kubernetes.io/kubelet-serving ",
+					InvolvedObject: csrReference("csr-7xkq2"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "Certificate Signing Requests",
+					Message:        "jane-client Denied for jane [developers system:authenticated] via kubernetes.io/kube-apiserver-client NotAuthorized",
+					InvolvedObject: csrReference("jane-client"),
+				},
+			},
+		},
+		{
+			name: "approved requests pass",
+			analyzer: troubleshootv1beta2.CSRAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "stuck csrs",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "all csrs are approved",
+						},
+					},
+				},
+			},
+			csrs: approvedOnly,
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Certificate Signing Requests",
+					Message: "all csrs are approved",
+				},
+			},
+		},
+		{
+			name: "invalid pending threshold",
+			analyzer: troubleshootv1beta2.CSRAnalyze{
+				PendingThreshold: "ten minutes",
+			},
+			csrs:      approvedOnly,
+			expectErr: `failed to parse pending threshold "ten minutes"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if n == "cluster-resources/certificatesigningrequests.json" {
+					return test.csrs, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			a := &AnalyzeCSR{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, nil)
+			if test.expectErr != "" {
+				req.ErrorContains(err, test.expectErr)
+				return
+			}
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
+
+func TestFindCSRIssues_PendingThreshold(t *testing.T) {
+	req := require.New(t)
+
+	var csrs certificatesv1.CertificateSigningRequestList
+	req.NoError(json.Unmarshal([]byte(csrCertificateSigningRequests), &csrs))
+
+	// csr-7xkq2 was created at 09:11:05
+	now := time.Date(2024, 3, 1, 9, 41, 5, 0, time.UTC)
+
+	issues := findCSRIssues(csrs.Items, time.Hour, now)
+	for _, issue := range issues {
+		req.NotEqual("csr-7xkq2", issue.Name)
+	}
+
+	issues = findCSRIssues(csrs.Items, 10*time.Minute, now)
+	var pending *csrIssue
+	for i := range issues {
+		if issues[i].Name == "csr-7xkq2" {
+			pending = &issues[i]
+		}
+	}
+	req.NotNil(pending)
+	req.Equal("30m0s", pending.Age)
+
+	result := defaultCSRIssueResult("Certificate Signing Requests", *pending)
+	req.True(result.IsWarn)
+	req.Equal(`Certificate signing request csr-7xkq2 from system:node:node-b has been pending for 30m0s. Kubelet serving certificates are not approved automatically. Until it is approved, metrics, logs and exec will fail for the node. Review and approve it with "kubectl certificate approve csr-7xkq2", or enable an approver for signer kubernetes.io/kubelet-serving.`, result.Message)
+}
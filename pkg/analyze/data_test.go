@@ -75,3 +75,258 @@ var messageImagePullFail string
 
 //go:embed files/events/message-image-pull-fail.json
 var messageImagePullFailEvents string
+
+//go:embed files/wait-for-first-consumer/storage-classes.json
+var waitForFirstConsumerStorageClasses string
+
+//go:embed files/wait-for-first-consumer/pvcs.json
+var waitForFirstConsumerPVCs string
+
+//go:embed files/wait-for-first-consumer/pods.json
+var waitForFirstConsumerPods string
+
+//go:embed files/gitops/helmreleases.helm.toolkit.fluxcd.io/flux-system.json
+var gitOpsFluxHelmReleases string
+
+//go:embed files/gitops/kustomizations.kustomize.toolkit.fluxcd.io/flux-system.json
+var gitOpsFluxKustomizations string
+
+//go:embed files/gitops/applications.argoproj.io/argocd.json
+var gitOpsArgoApplications string
+
+//go:embed files/image-architecture/nodes.json
+var imageArchitectureNodes string
+
+//go:embed files/image-architecture/pods.json
+var imageArchitecturePods string
+
+//go:embed files/image-architecture/logs/api.log
+var imageArchitectureAPILogs string
+
+//go:embed files/topology-spread/nodes.json
+var topologySpreadNodes string
+
+//go:embed files/topology-spread/pods.json
+var topologySpreadPods string
+
+//go:embed files/csr/certificatesigningrequests.json
+var csrCertificateSigningRequests string
+
+//go:embed files/golden-snapshot/drifted/cluster_version.json
+var goldenSnapshotDriftedClusterVersion string
+
+//go:embed files/golden-snapshot/drifted/deployments.json
+var goldenSnapshotDriftedDeployments string
+
+//go:embed files/golden-snapshot/drifted/statefulsets.json
+var goldenSnapshotDriftedStatefulSets string
+
+//go:embed files/golden-snapshot/drifted/services.json
+var goldenSnapshotDriftedServices string
+
+//go:embed files/oom-killed/pods.json
+var oomKilledPods string
+
+//go:embed files/api-warnings/api-warnings.json
+var apiWarningsAPIWarnings string
+
+//go:embed files/configmap-drift/default.json
+var configMapDriftDefault string
+
+//go:embed files/configmap-drift/payments.json
+var configMapDriftPayments string
+
+//go:embed files/vpa/vpa.json
+var vpaVerticalPodAutoscalers string
+
+//go:embed files/vpa/pods.json
+var vpaPods string
+
+//go:embed files/config-mounts/pods.json
+var configMountsPods string
+
+//go:embed files/config-mounts/configmaps.json
+var configMountsConfigMaps string
+
+//go:embed files/high-availability/deployments.json
+var highAvailabilityDeployments string
+
+//go:embed files/high-availability/statefulsets.json
+var highAvailabilityStatefulSets string
+
+//go:embed files/orphaned-resources/pods.json
+var orphanedResourcesPods string
+
+//go:embed files/orphaned-resources/replicasets.json
+var orphanedResourcesReplicaSets string
+
+//go:embed files/orphaned-resources/deployments.json
+var orphanedResourcesDeployments string
+
+//go:embed files/orphaned-resources/statefulsets.json
+var orphanedResourcesStatefulSets string
+
+//go:embed files/orphaned-resources/jobs.json
+var orphanedResourcesJobs string
+
+//go:embed files/last-applied/default.json
+var lastAppliedDefault string
+
+//go:embed files/api-server-features/kube-system.json
+var apiServerFeaturesKubeSystem string
+
+//go:embed files/exposed-services/default.json
+var exposedServicesDefault string
+
+//go:embed files/exposed-services/ingress-nginx.json
+var exposedServicesIngressNginx string
+
+//go:embed files/image-policy/default.json
+var imagePolicyDefault string
+
+//go:embed files/image-policy/monitoring.json
+var imagePolicyMonitoring string
+
+//go:embed files/over-permissive-bindings/clusterroles.json
+var overPermissiveBindingsClusterRoles string
+
+//go:embed files/over-permissive-bindings/clusterrolebindings.json
+var overPermissiveBindingsClusterRoleBindings string
+
+//go:embed files/over-permissive-bindings/aggregated-clusterroles.json
+var overPermissiveBindingsAggregatedClusterRoles string
+
+//go:embed files/duplicate-api-versions/ingress-default.json
+var duplicateAPIVersionsIngressDefault string
+
+//go:embed files/duplicate-api-versions/ingresses-extensions-default.json
+var duplicateAPIVersionsIngressesExtensionsDefault string
+
+//go:embed files/duplicate-api-versions/certificates-cert-manager-io.json
+var duplicateAPIVersionsCertificatesCertManagerIO string
+
+//go:embed files/duplicate-api-versions/certificates-certmanager-k8s-io.json
+var duplicateAPIVersionsCertificatesCertmanagerK8sIO string
+
+//go:embed files/missing-pdb/deployments.json
+var missingPDBDeployments string
+
+//go:embed files/missing-pdb/statefulsets.json
+var missingPDBStatefulSets string
+
+//go:embed files/missing-pdb/pod-disruption-budgets-default.json
+var missingPDBPodDisruptionBudgetsDefault string
+
+//go:embed files/missing-pdb/pod-disruption-budgets-other.json
+var missingPDBPodDisruptionBudgetsOther string
+
+//go:embed files/service-mesh/control-plane-deployments.json
+var serviceMeshControlPlaneDeployments string
+
+//go:embed files/service-mesh/injected-namespaces.json
+var serviceMeshInjectedNamespaces string
+
+//go:embed files/service-mesh/pods-bookinfo.json
+var serviceMeshPodsBookinfo string
+
+//go:embed files/service-mesh/gateways-bookinfo.json
+var serviceMeshGatewaysBookinfo string
+
+//go:embed files/service-mesh/virtualservices-bookinfo.json
+var serviceMeshVirtualServicesBookinfo string
+
+//go:embed files/service-mesh/destinationrules-bookinfo.json
+var serviceMeshDestinationRulesBookinfo string
+
+//go:embed files/cluster-autoscaler/status.json
+var clusterAutoscalerStatusConfigMap string
+
+//go:embed files/cluster-autoscaler/events.json
+var clusterAutoscalerEvents string
+
+//go:embed files/cluster-autoscaler/events-default.json
+var clusterAutoscalerEventsDefault string
+
+//go:embed files/host-mtu/node-1.json
+var hostMTUNode1 string
+
+//go:embed files/host-mtu/node-2.json
+var hostMTUNode2 string
+
+//go:embed files/host-mtu/node-3.json
+var hostMTUNode3 string
+
+//go:embed files/cordoned-nodes/nodes.json
+var cordonedNodesNodes string
+
+//go:embed files/cordoned-nodes/pods-default.json
+var cordonedNodesPodsDefault string
+
+//go:embed files/cordoned-nodes/pods-kube-system.json
+var cordonedNodesPodsKubeSystem string
+
+//go:embed files/cordoned-nodes/deployments.json
+var cordonedNodesDeployments string
+
+//go:embed files/cordoned-nodes/statefulsets.json
+var cordonedNodesStatefulSets string
+
+//go:embed files/cordoned-nodes/pod-disruption-budgets.json
+var cordonedNodesPodDisruptionBudgets string
+
+//go:embed files/required-namespaces/namespaces.json
+var requiredNamespacesNamespaces string
+
+//go:embed files/required-namespaces/namespaces-selected.json
+var requiredNamespacesNamespacesSelected string
+
+//go:embed files/writable-host-path/pods-default.json
+var writableHostPathPodsDefault string
+
+//go:embed files/writable-host-path/pods-kube-system.json
+var writableHostPathPodsKubeSystem string
+
+//go:embed files/kube-state-metrics/metrics.txt
+var kubeStateMetricsMetrics string
+
+//go:embed files/kube-state-metrics/scrape.json
+var kubeStateMetricsScrape string
+
+//go:embed files/log-timestamps/api.log
+var logTimestampsApi string
+
+//go:embed files/log-timestamps/worker.log
+var logTimestampsWorker string
+
+//go:embed files/log-timestamps/billing.log
+var logTimestampsBilling string
+
+//go:embed files/log-timestamps/nginx.log
+var logTimestampsNginx string
+
+//go:embed files/log-timestamps/legacy.log
+var logTimestampsLegacy string
+
+//go:embed files/log-timestamps/manager.log
+var logTimestampsManager string
+
+//go:embed files/node-pod-capacity/nodes.json
+var nodePodCapacityNodes string
+
+//go:embed files/node-pod-capacity/pods-default.json
+var nodePodCapacityPodsDefault string
+
+//go:embed files/node-pod-capacity/pods-kube-system.json
+var nodePodCapacityPodsKubeSystem string
+
+//go:embed files/readiness-gates/pods-default.json
+var readinessGatesPodsDefault string
+
+//go:embed files/managed-fields/default.json
+var managedFieldsDefault string
+
+//go:embed files/managed-fields/monitoring.json
+var managedFieldsMonitoring string
+
+//go:embed files/root-causes/pods-default.json
+var rootCausesPodsDefault string
@@ -50,6 +50,16 @@ func compareDatabaseConditionalToActual(conditional string, result *collect.Data
 		}
 
 		return expectedRange(actual), nil
+
+	case "replicaSetRole":
+		switch parts[1] {
+		case "=", "==", "===":
+			return parts[2] == result.ReplicaSetRole, nil
+		case "!=", "!==":
+			return parts[2] != result.ReplicaSetRole, nil
+		}
+
+		return false, errors.New("unable to parse replicaSetRole analyzer")
 	}
 
 	return false, nil
@@ -57,6 +57,33 @@ func Test_compareDatabaseConditionalToActual(t *testing.T) {
 			},
 			expectedMatch: true,
 		},
+		{
+			name:        "primary, expected primary",
+			conditional: "replicaSetRole == primary",
+			result: collect.DatabaseConnection{
+				IsConnected:    true,
+				ReplicaSetRole: "primary",
+			},
+			expectedMatch: true,
+		},
+		{
+			name:        "secondary, expected not primary",
+			conditional: "replicaSetRole != primary",
+			result: collect.DatabaseConnection{
+				IsConnected:    true,
+				ReplicaSetRole: "secondary",
+			},
+			expectedMatch: true,
+		},
+		{
+			name:        "standalone, expected primary",
+			conditional: "replicaSetRole == primary",
+			result: collect.DatabaseConnection{
+				IsConnected:    true,
+				ReplicaSetRole: "standalone",
+			},
+			expectedMatch: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -81,14 +81,16 @@ func analyzeOneDeploymentStatus(analyzer *troubleshootv1beta2.DeploymentStatus,
 			}
 		}
 
+		templateContext := outcomeTemplateContext(analyzer.Name, analyzer.Namespace, nil)
 		if status == nil {
 			exists = false
 			readyReplicas = 0
 		} else {
 			readyReplicas = int(status.ReadyReplicas)
+			templateContext = outcomeTemplateContext(analyzer.Name, analyzer.Namespace, status)
 		}
 
-		result, err = commonStatus(analyzer.Outcomes, analyzer.Name, "kubernetes_deployment_status", "https://troubleshoot.sh/images/analyzer-icons/deployment-status.svg?w=17&h=17", readyReplicas, exists, "deployment")
+		result, err = commonStatus(analyzer.Outcomes, analyzer.Name, "kubernetes_deployment_status", "https://troubleshoot.sh/images/analyzer-icons/deployment-status.svg?w=17&h=17", readyReplicas, exists, "deployment", templateContext)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to process status")
 		}
@@ -184,6 +184,38 @@ func Test_deploymentStatus(t *testing.T) {
 				"cluster-resources/deployments/kube-system.json": []byte(kubeSystemDeployments),
 			},
 		},
+		{
+			name: "1/2, fail message with collected values",
+			analyzer: troubleshootv1beta2.DeploymentStatus{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "< 2",
+							Message: "{{ .namespace }}/{{ .name }} has {{ .readyReplicas }}/{{ .replicas }} ready replicas",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							Message: "pass",
+						},
+					},
+				},
+				Namespace: "default",
+				Name:      "kotsadm-web",
+			},
+			expectResult: []*AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "kotsadm-web Status",
+					Message: "default/kotsadm-web has 1/2 ready replicas",
+					IconKey: "kubernetes_deployment_status",
+					IconURI: "https://troubleshoot.sh/images/analyzer-icons/deployment-status.svg?w=17&h=17",
+				},
+			},
+			files: map[string][]byte{
+				"cluster-resources/deployments/default.json": []byte(defaultDeployments),
+			},
+		},
 		{
 			name: "multiple namespaces, 2/3",
 			analyzer: troubleshootv1beta2.DeploymentStatus{
@@ -0,0 +1,52 @@
+package analyzer
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+)
+
+// evaluateDetectedOutcomes returns a result for the first outcome whose "when" matches
+// whether the analyzer detected a problem. "when" is parsed as a bool ("true" matches a
+// detected problem, "false" matches a clean bill of health) and an empty "when" always
+// matches. The outcome message is rendered as a template against data.
+// nil is returned when no outcome matches.
+func evaluateDetectedOutcomes(outcomes []*troubleshootv1beta2.Outcome, detected bool, title string, data any) (*AnalyzeResult, error) {
+	for _, outcome := range outcomes {
+		result := &AnalyzeResult{
+			Title: title,
+		}
+
+		var single *troubleshootv1beta2.SingleOutcome
+		switch {
+		case outcome.Fail != nil:
+			single = outcome.Fail
+			result.IsFail = true
+		case outcome.Warn != nil:
+			single = outcome.Warn
+			result.IsWarn = true
+		case outcome.Pass != nil:
+			single = outcome.Pass
+			result.IsPass = true
+		default:
+			continue
+		}
+
+		if single.When != "" {
+			when, err := strconv.ParseBool(single.When)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse when condition: %s", single.When)
+			}
+			if when != detected {
+				continue
+			}
+		}
+
+		result.Message = renderTemplate(single.Message, data)
+		result.URI = single.URI
+		return result, nil
+	}
+
+	return nil, nil
+}
@@ -4,18 +4,29 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"context"
+	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	getter "github.com/hashicorp/go-getter"
 	"github.com/pkg/errors"
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
 	troubleshootscheme "github.com/replicatedhq/troubleshoot/pkg/client/troubleshootclientset/scheme"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
 	"github.com/replicatedhq/troubleshoot/pkg/constants"
 	"github.com/replicatedhq/troubleshoot/pkg/docrewrite"
 	"github.com/replicatedhq/troubleshoot/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 )
@@ -24,12 +35,32 @@ type fileContentProvider struct {
 	rootDir string
 }
 
+// AnalyzeLocalOptions configures how a local bundle is analyzed
+type AnalyzeLocalOptions struct {
+	// MaxConcurrency is the number of analyzers run at the same time. It defaults to the number
+	// of CPUs.
+	MaxConcurrency int
+}
+
 // Analyze local will analyze a locally available (already downloaded) bundle
 func AnalyzeLocal(
 	ctx context.Context,
 	localBundlePath string,
 	analyzers []*troubleshootv1beta2.Analyze,
 	hostAnalyzers []*troubleshootv1beta2.HostAnalyze,
+) ([]*AnalyzeResult, error) {
+	return AnalyzeLocalWithOptions(ctx, localBundlePath, analyzers, hostAnalyzers, AnalyzeLocalOptions{})
+}
+
+// AnalyzeLocalWithOptions analyzes a locally available bundle, running up to
+// opts.MaxConcurrency analyzers at the same time. Results are returned in the order of the
+// analyzers in the spec, followed by the results of the host analyzers.
+func AnalyzeLocalWithOptions(
+	ctx context.Context,
+	localBundlePath string,
+	analyzers []*troubleshootv1beta2.Analyze,
+	hostAnalyzers []*troubleshootv1beta2.HostAnalyze,
+	opts AnalyzeLocalOptions,
 ) ([]*AnalyzeResult, error) {
 	rootDir, err := FindBundleRootDir(localBundlePath)
 	if err != nil {
@@ -38,14 +69,40 @@ func AnalyzeLocal(
 
 	fcp := fileContentProvider{rootDir: rootDir}
 
-	analyzeResults := []*AnalyzeResult{}
-	for _, analyzer := range analyzers {
-		analyzeResult, err := Analyze(ctx, analyzer, fcp.getFileContents, fcp.getChildFileContents)
-		if err != nil {
-			klog.Errorf("An analyzer failed to run: %v", err)
-			continue
-		}
+	maxConcurrency := opts.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = runtime.NumCPU()
+	}
+	if maxConcurrency > len(analyzers) {
+		maxConcurrency = len(analyzers)
+	}
+
+	// each analyzer writes its results to its own slot so they can be put back in spec order
+	resultsByAnalyzer := make([][]*AnalyzeResult, len(analyzers))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < maxConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				analyzeResult, err := Analyze(ctx, analyzers[index], fcp.getFileContents, fcp.getChildFileContents)
+				if err != nil {
+					klog.Errorf("An analyzer failed to run: %v", err)
+					continue
+				}
+				resultsByAnalyzer[index] = analyzeResult
+			}
+		}()
+	}
+	for index := range analyzers {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
 
+	analyzeResults := []*AnalyzeResult{}
+	for _, analyzeResult := range resultsByAnalyzer {
 		// Filter nil results to prevent panic
 		for _, r := range analyzeResult {
 			if r != nil {
@@ -62,8 +119,75 @@ func AnalyzeLocal(
 	return analyzeResults, nil
 }
 
+// DownloadOptions configures retries of support bundle downloads. Only failed downloads are
+// retried, bundles that are not found or can't be extracted are not.
+type DownloadOptions struct {
+	// MaxAttempts is the number of times the download is tried, it defaults to a single attempt
+	MaxAttempts int
+	// BaseDelay is the delay before the second attempt. It doubles for each following attempt,
+	// with up to 50% of jitter added. It defaults to 1s.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts, it defaults to 30s
+	MaxDelay time.Duration
+}
+
+// downloadRetryError is returned when a download still fails after every attempt
+type downloadRetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *downloadRetryError) Error() string {
+	return fmt.Sprintf("failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *downloadRetryError) Unwrap() error {
+	return e.Err
+}
+
+// downloadError is returned when a remote bundle could not be fetched
+type downloadError struct {
+	Err error
+}
+
+func (e *downloadError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *downloadError) Unwrap() error {
+	return e.Err
+}
+
+// getterStatusPattern matches the status code in the errors go-getter returns for HTTP responses
+var getterStatusPattern = regexp.MustCompile(`bad response code: (\d+)`)
+
+// isRetryableDownloadError returns true for failed downloads, except for client errors such as
+// 404 that another attempt would get again
+func isRetryableDownloadError(err error) bool {
+	var downloadErr *downloadError
+	if !errors.As(err, &downloadErr) {
+		return false
+	}
+
+	match := getterStatusPattern.FindStringSubmatch(downloadErr.Error())
+	if match == nil {
+		return true
+	}
+	code, _ := strconv.Atoi(match[1])
+	if code == http.StatusRequestTimeout || code == http.StatusTooManyRequests {
+		return true
+	}
+	return code < 400 || code >= 500
+}
+
 func DownloadAndAnalyze(bundleURL string, analyzersSpec string) ([]*AnalyzeResult, error) {
-	tmpDir, rootDir, err := DownloadAndExtractSupportBundle(bundleURL)
+	return DownloadAndAnalyzeWithOptions(bundleURL, analyzersSpec, DownloadOptions{})
+}
+
+// DownloadAndAnalyzeWithOptions downloads the bundle, retrying failed downloads as set by opts,
+// and analyzes it
+func DownloadAndAnalyzeWithOptions(bundleURL string, analyzersSpec string, opts DownloadOptions) ([]*AnalyzeResult, error) {
+	tmpDir, rootDir, err := DownloadAndExtractSupportBundleWithOptions(bundleURL, opts)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find root dir")
 	}
@@ -91,13 +215,14 @@ func DownloadAndAnalyze(bundleURL string, analyzersSpec string) ([]*AnalyzeResul
 }
 
 func DownloadAndExtractSupportBundle(bundleURL string) (string, string, error) {
-	tmpDir, err := os.MkdirTemp("", "troubleshoot-k8s")
-	if err != nil {
-		return "", "", errors.Wrap(err, "failed to create temp dir")
-	}
+	return DownloadAndExtractSupportBundleWithOptions(bundleURL, DownloadOptions{})
+}
 
-	if err := downloadTroubleshootBundle(bundleURL, tmpDir); err != nil {
-		os.RemoveAll(tmpDir)
+// DownloadAndExtractSupportBundleWithOptions downloads and extracts the bundle, retrying failed
+// downloads of remote bundles with an exponential backoff as set by opts
+func DownloadAndExtractSupportBundleWithOptions(bundleURL string, opts DownloadOptions) (string, string, error) {
+	tmpDir, err := downloadWithRetry(bundleURL, opts)
+	if err != nil {
 		return "", "", errors.Wrap(err, "failed to download bundle")
 	}
 
@@ -116,6 +241,52 @@ func DownloadAndExtractSupportBundle(bundleURL string) (string, string, error) {
 	return tmpDir, bundleDir, nil
 }
 
+// downloadWithRetry downloads and extracts the bundle to a new temp dir. Local bundles are only
+// read once.
+func downloadWithRetry(bundleURL string, opts DownloadOptions) (string, error) {
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts < 1 || (bundleURL != "" && bundleURL[0] == os.PathSeparator) {
+		maxAttempts = 1
+	}
+	backoff := wait.Backoff{
+		Duration: opts.BaseDelay,
+		Factor:   2,
+		Jitter:   0.5,
+		Steps:    maxAttempts,
+		Cap:      opts.MaxDelay,
+	}
+	if backoff.Duration <= 0 {
+		backoff.Duration = time.Second
+	}
+	if backoff.Cap <= 0 {
+		backoff.Cap = 30 * time.Second
+	}
+
+	for attempt := 1; ; attempt++ {
+		tmpDir, err := os.MkdirTemp("", "troubleshoot-k8s")
+		if err != nil {
+			return "", errors.Wrap(err, "failed to create temp dir")
+		}
+
+		err = downloadTroubleshootBundle(bundleURL, tmpDir)
+		if err == nil {
+			return tmpDir, nil
+		}
+		os.RemoveAll(tmpDir)
+
+		if attempt >= maxAttempts || !isRetryableDownloadError(err) {
+			if attempt == 1 {
+				return "", err
+			}
+			return "", &downloadRetryError{Attempts: attempt, Err: err}
+		}
+
+		delay := backoff.Step()
+		klog.V(2).Infof("Support bundle download failed on attempt %d, retrying in %s: %v", attempt, delay, err)
+		time.Sleep(delay)
+	}
+}
+
 func downloadTroubleshootBundle(bundleURL string, destDir string) error {
 	// TODO: Move to separate package support bundle utils package
 	if bundleURL[0] == os.PathSeparator {
@@ -145,7 +316,7 @@ func downloadTroubleshootBundle(bundleURL string, destDir string) error {
 		return nil
 	})
 	if err != nil {
-		return errors.Wrap(err, "failed to read support bundle file")
+		return &downloadError{Err: errors.Wrap(err, "failed to read support bundle file")}
 	}
 
 	f, err := os.Open(dst)
@@ -302,10 +473,21 @@ func FindBundleRootDir(localBundlePath string) (string, error) {
 	return localBundlePath, nil
 }
 
+// getFileContents returns the contents of a file of the bundle. Text files that were gzipped as
+// they were collected, see collect.CompressResultFiles, are read decompressed.
 func (f fileContentProvider) getFileContents(fileName string) ([]byte, error) {
 	contents, err := os.ReadFile(filepath.Join(f.rootDir, fileName))
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
+			if collect.IsCompressibleFile(fileName) {
+				contents, err := collect.ReadCompressedFile(filepath.Join(f.rootDir, fileName+collect.CompressedFileSuffix))
+				if err == nil {
+					return contents, nil
+				}
+				if !errors.Is(err, fs.ErrNotExist) {
+					return nil, err
+				}
+			}
 			return nil, &types.NotFoundError{Name: fileName}
 		}
 		return nil, err
@@ -331,8 +513,11 @@ func excludeFilePaths(files, excludeFiles []string) []string {
 	return nonexcludedFiles
 }
 
+// getChildFileContents returns the contents of the files of the bundle matching the dirName glob.
+// Text files that were gzipped as they were collected match by their name without the .gz suffix
+// and are read decompressed.
 func (f fileContentProvider) getChildFileContents(dirName string, excludeFiles []string) (map[string][]byte, error) {
-	files, err := filepath.Glob(filepath.Join(f.rootDir, dirName))
+	files, err := globBundleFiles(filepath.Join(f.rootDir, dirName))
 	if err != nil {
 		return nil, errors.Wrapf(err, "invalid glob %q", dirName)
 	}
@@ -340,7 +525,7 @@ func (f fileContentProvider) getChildFileContents(dirName string, excludeFiles [
 	if len(excludeFiles) > 0 {
 		excludeFileNames := []string{}
 		for _, excludeFile := range excludeFiles {
-			excludeFileName, err := filepath.Glob(filepath.Join(f.rootDir, excludeFile))
+			excludeFileName, err := globBundleFiles(filepath.Join(f.rootDir, excludeFile))
 			if err != nil {
 				return nil, errors.Wrapf(err, "invalid glob %q", excludeFile)
 			}
@@ -351,6 +536,15 @@ func (f fileContentProvider) getChildFileContents(dirName string, excludeFiles [
 
 	fileArr := map[string][]byte{}
 	for _, filePath := range files {
+		if collect.IsCompressedFile(filePath) {
+			bytes, err := collect.ReadCompressedFile(filePath)
+			if err != nil {
+				return nil, errors.Wrapf(err, "read %q", filePath)
+			}
+			fileArr[strings.TrimSuffix(filePath, collect.CompressedFileSuffix)] = bytes
+			continue
+		}
+
 		bytes, err := os.ReadFile(filePath)
 		if err != nil {
 			return nil, errors.Wrapf(err, "read %q", filePath)
@@ -359,3 +553,23 @@ func (f fileContentProvider) getChildFileContents(dirName string, excludeFiles [
 	}
 	return fileArr, nil
 }
+
+// globBundleFiles returns the files matching pattern, including the text files that were gzipped
+// as they were collected whose name without the .gz suffix matches
+func globBundleFiles(pattern string) ([]string, error) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed, err := filepath.Glob(pattern + collect.CompressedFileSuffix)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range compressed {
+		if collect.IsCompressedFile(file) && !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
@@ -1,12 +1,23 @@
 package analyzer
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/replicatedhq/troubleshoot/internal/testutils"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDownloadAndExtractSupportBundle(t *testing.T) {
@@ -47,3 +58,174 @@ func TestDownloadAndExtractSupportBundle(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzeLocalWithOptions(t *testing.T) {
+	bundleDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(bundleDir, "version.yaml"), []byte("apiVersion: troubleshoot.sh/v1beta2\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(bundleDir, "logs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(bundleDir, "logs", "app.log"), []byte("starting\nerror: connection refused\n"), 0644))
+
+	analyzers := []*troubleshootv1beta2.Analyze{}
+	wantTitles := []string{}
+	for i := 0; i < 20; i++ {
+		pattern := "error"
+		if i == 7 {
+			// fails to run and is left out of the results
+			pattern = "("
+		} else {
+			wantTitles = append(wantTitles, fmt.Sprintf("check-%d", i))
+		}
+		analyzers = append(analyzers, &troubleshootv1beta2.Analyze{
+			TextAnalyze: &troubleshootv1beta2.TextAnalyze{
+				AnalyzeMeta:   troubleshootv1beta2.AnalyzeMeta{CheckName: fmt.Sprintf("check-%d", i)},
+				CollectorName: "logs",
+				FileName:      "app.log",
+				RegexPattern:  pattern,
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{Fail: &troubleshootv1beta2.SingleOutcome{When: "true", Message: "errors logged"}},
+					{Pass: &troubleshootv1beta2.SingleOutcome{When: "false", Message: "no errors"}},
+				},
+			},
+		})
+	}
+
+	for _, maxConcurrency := range []int{0, 1, 4, 50} {
+		t.Run(fmt.Sprintf("max concurrency %d", maxConcurrency), func(t *testing.T) {
+			results, err := AnalyzeLocalWithOptions(context.Background(), bundleDir, analyzers, nil, AnalyzeLocalOptions{MaxConcurrency: maxConcurrency})
+			require.NoError(t, err)
+
+			titles := []string{}
+			for _, result := range results {
+				assert.True(t, result.IsFail)
+				titles = append(titles, result.Title)
+			}
+			assert.Equal(t, wantTitles, titles)
+		})
+	}
+}
+
+func TestDownloadAndExtractSupportBundleWithOptions(t *testing.T) {
+	bundle, err := os.ReadFile(filepath.Join(testutils.FileDir(), "../../testdata/supportbundle/support-bundle.tar.gz"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		failures     int32
+		status       int
+		opts         DownloadOptions
+		wantRequests int32
+		wantErr      string
+	}{
+		{
+			name:         "single attempt by default",
+			failures:     1,
+			wantRequests: 1,
+			wantErr:      "failed to download bundle: failed to read support bundle file: bad response code: 503",
+		},
+		{
+			name:         "succeeds after retries",
+			failures:     2,
+			opts:         DownloadOptions{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			wantRequests: 3,
+		},
+		{
+			name:         "fails after all attempts",
+			failures:     5,
+			opts:         DownloadOptions{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond},
+			wantRequests: 3,
+			wantErr:      "failed to download bundle: failed after 3 attempts: failed to read support bundle file: bad response code: 503",
+		},
+		{
+			name:         "not found is not retried",
+			failures:     5,
+			status:       http.StatusNotFound,
+			opts:         DownloadOptions{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			wantRequests: 1,
+			wantErr:      "failed to download bundle: failed to read support bundle file: bad response code: 404",
+		},
+		{
+			name:         "too many requests is retried",
+			failures:     1,
+			status:       http.StatusTooManyRequests,
+			opts:         DownloadOptions{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			wantRequests: 2,
+		},
+		{
+			name:         "invalid archive is not retried",
+			failures:     5,
+			status:       http.StatusOK,
+			opts:         DownloadOptions{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			wantRequests: 1,
+			wantErr:      "failed to download bundle: failed to create gzip reader",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				if requests.Add(1) <= tt.failures {
+					status := tt.status
+					if status == 0 {
+						status = http.StatusServiceUnavailable
+					}
+					w.WriteHeader(status)
+					w.Write([]byte("not a bundle"))
+					return
+				}
+				w.Write(bundle)
+			}))
+			defer server.Close()
+
+			tmpDir, bundleDir, err := DownloadAndExtractSupportBundleWithOptions(server.URL+"/support-bundle.tar.gz", tt.opts)
+			defer os.RemoveAll(tmpDir)
+
+			assert.Equal(t, tt.wantRequests, requests.Load())
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Equal(t, "", tmpDir)
+				return
+			}
+			require.NoError(t, err)
+			assert.FileExists(t, filepath.Join(bundleDir, "version.yaml"))
+		})
+	}
+}
+
+func TestFileContentProviderCompressedFiles(t *testing.T) {
+	bundleDir := t.TempDir()
+	result := collect.NewResult()
+
+	large := "starting\nerror: connection refused\n"
+	require.NoError(t, result.SaveResult(bundleDir, "logs/app-0.log", bytes.NewBufferString(large)))
+	require.NoError(t, result.SaveResult(bundleDir, "logs/app-1.log", bytes.NewBufferString("ok\n")))
+	require.NoError(t, collect.CompressResultFiles(bundleDir, result, 16))
+	require.FileExists(t, filepath.Join(bundleDir, "logs/app-0.log.gz"))
+
+	provider := fileContentProvider{rootDir: bundleDir}
+
+	data, err := provider.getFileContents("logs/app-0.log")
+	require.NoError(t, err)
+	assert.Equal(t, large, string(data))
+
+	_, err = provider.getFileContents("logs/app-2.log")
+	var notFound *types.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+
+	files, err := provider.getChildFileContents("logs/*.log", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		filepath.Join(bundleDir, "logs/app-0.log"): []byte(large),
+		filepath.Join(bundleDir, "logs/app-1.log"): []byte("ok\n"),
+	}, files)
+
+	files, err = provider.getChildFileContents("logs/*", []string{"logs/app-0.log"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		filepath.Join(bundleDir, "logs/app-1.log"): []byte("ok\n"),
+	}, files)
+}
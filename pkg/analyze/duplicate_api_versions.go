@@ -0,0 +1,200 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type AnalyzeDuplicateAPIVersions struct {
+	analyzer *troubleshootv1beta2.DuplicateAPIVersionsAnalyze
+}
+
+// duplicateAPIVersionsIssue is the template data available to outcome messages
+type duplicateAPIVersionsIssue struct {
+	Kind string
+	// Namespace is empty for cluster scoped objects
+	Namespace string
+	Name      string
+	// APIVersions is the comma separated list of the api versions the object was collected under
+	APIVersions string
+}
+
+// versionedObject holds the type and identity of a collected object, the collectors set the
+// kind and api version of every object they save
+type versionedObject struct {
+	metav1.TypeMeta `json:",inline"`
+	Metadata        struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+}
+
+func (a *AnalyzeDuplicateAPIVersions) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Duplicate Resources Across API Versions"
+}
+
+func (a *AnalyzeDuplicateAPIVersions) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeDuplicateAPIVersions) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	objects, err := readCollectedVersionedObjects(findFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := findDuplicateAPIVersions(objects, a.analyzer.Namespaces, a.analyzer.Kinds)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			name := issue.Name
+			if issue.Namespace != "" {
+				name = fmt.Sprintf("%s/%s", issue.Namespace, issue.Name)
+			}
+			result = &AnalyzeResult{
+				Title:  a.Title(),
+				IsWarn: true,
+				Message: fmt.Sprintf("%s %s exists under %s. Finish migrating it to a single API version and remove the others, so apply and ownership changes do not go to a stale copy.",
+					issue.Kind, name, issue.APIVersions),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			Kind:      issue.Kind,
+			Namespace: issue.Namespace,
+			Name:      issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No resources were found under more than one API version",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// findDuplicateAPIVersions groups the objects by kind, namespace and name and returns the groups
+// collected under more than one api version
+func findDuplicateAPIVersions(objects []versionedObject, namespaces, kinds []string) []duplicateAPIVersionsIssue {
+	type objectKey struct {
+		kind, namespace, name string
+	}
+
+	versions := map[objectKey][]string{}
+	for _, object := range objects {
+		if len(namespaces) > 0 && !slices.Contains(namespaces, object.Metadata.Namespace) {
+			continue
+		}
+		if len(kinds) > 0 && !slices.Contains(kinds, object.Kind) {
+			continue
+		}
+		key := objectKey{kind: object.Kind, namespace: object.Metadata.Namespace, name: object.Metadata.Name}
+		if !slices.Contains(versions[key], object.APIVersion) {
+			versions[key] = append(versions[key], object.APIVersion)
+		}
+	}
+
+	issues := []duplicateAPIVersionsIssue{}
+	for key, apiVersions := range versions {
+		if len(apiVersions) < 2 {
+			continue
+		}
+		sort.Strings(apiVersions)
+		issues = append(issues, duplicateAPIVersionsIssue{
+			Kind:        key.kind,
+			Namespace:   key.namespace,
+			Name:        key.name,
+			APIVersions: strings.Join(apiVersions, ", "),
+		})
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Kind != issues[j].Kind {
+			return issues[i].Kind < issues[j].Kind
+		}
+		if issues[i].Namespace != issues[j].Namespace {
+			return issues[i].Namespace < issues[j].Namespace
+		}
+		return issues[i].Name < issues[j].Name
+	})
+
+	return issues
+}
+
+// readCollectedVersionedObjects reads the kind, api version and identity of every object saved by
+// the cluster resources collector, both the built in resources and the custom resources. Files
+// that are not lists of objects, such as the api group list, are skipped.
+func readCollectedVersionedObjects(findFiles getChildCollectedFileContents) ([]versionedObject, error) {
+	collected := map[string][]byte{}
+	for _, glob := range []string{
+		filepath.Join(constants.CLUSTER_RESOURCES_DIR, "*.json"),
+		filepath.Join(constants.CLUSTER_RESOURCES_DIR, "*", "*.json"),
+		filepath.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_CUSTOM_RESOURCES, "*", "*.json"),
+	} {
+		files, err := findFiles(glob, []string{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read collected cluster resources")
+		}
+		for fileName, fileContent := range files {
+			collected[fileName] = fileContent
+		}
+	}
+
+	objects := []versionedObject{}
+	for fileName, fileContent := range collected {
+		if strings.HasSuffix(fileName, "-errors.json") {
+			continue
+		}
+
+		var list struct {
+			Items []versionedObject `json:"items"`
+		}
+		if err := json.Unmarshal(fileContent, &list); err != nil {
+			if err := json.Unmarshal(fileContent, &list.Items); err != nil {
+				continue
+			}
+		}
+
+		for _, item := range list.Items {
+			if item.APIVersion == "" || item.Kind == "" || item.Metadata.Name == "" {
+				continue
+			}
+			objects = append(objects, item)
+		}
+	}
+
+	return objects, nil
+}
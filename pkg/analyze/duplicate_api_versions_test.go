@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeDuplicateAPIVersions(t *testing.T) {
+	files := map[string][]byte{
+		"cluster-resources/ingress/default.json":                                          []byte(duplicateAPIVersionsIngressDefault),
+		"cluster-resources/ingress-errors.json":                                           []byte(`{"kube-system": "forbidden"}`),
+		"cluster-resources/groups.json":                                                   []byte(`{"kind": "APIGroupList", "groups": []}`),
+		"cluster-resources/custom-resources/ingresses.extensions/default.json":            []byte(duplicateAPIVersionsIngressesExtensionsDefault),
+		"cluster-resources/custom-resources/certificates.cert-manager.io/default.json":    []byte(duplicateAPIVersionsCertificatesCertManagerIO),
+		"cluster-resources/custom-resources/certificates.certmanager.k8s.io/default.json": []byte(duplicateAPIVersionsCertificatesCertmanagerK8sIO),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.DuplicateAPIVersionsAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "objects present under two api versions",
+			analyzer: troubleshootv1beta2.DuplicateAPIVersionsAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Duplicate Resources Across API Versions",
+					Message:        "Certificate default/web-tls exists under cert-manager.io/v1, certmanager.k8s.io/v1alpha1. Finish migrating it to a single API version and remove the others, so apply and ownership changes do not go to a stale copy.",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Certificate", Namespace: "default", Name: "web-tls"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Duplicate Resources Across API Versions",
+					Message:        "Ingress default/web exists under extensions/v1beta1, networking.k8s.io/v1. Finish migrating it to a single API version and remove the others, so apply and ownership changes do not go to a stale copy.",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Ingress", Namespace: "default", Name: "web"},
+				},
+			},
+		},
+		{
+			name: "limited to kinds with custom outcomes",
+			analyzer: troubleshootv1beta2.DuplicateAPIVersionsAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Ingress Migration",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Kind }} {{ .Name }}: {{ .APIVersions }}",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							Message: "Ingresses are migrated",
+						},
+					},
+				},
+				Kinds: []string{"Ingress"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Ingress Migration",
+					Message:        "Ingress web: extensions/v1beta1, networking.k8s.io/v1",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Ingress", Namespace: "default", Name: "web"},
+				},
+			},
+		},
+		{
+			name: "other namespaces pass",
+			analyzer: troubleshootv1beta2.DuplicateAPIVersionsAnalyze{
+				Namespaces: []string{"kube-system"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Duplicate Resources Across API Versions",
+					Message: "No resources were found under more than one API version",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeDuplicateAPIVersions{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
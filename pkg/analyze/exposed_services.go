@@ -0,0 +1,166 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type AnalyzeExposedServices struct {
+	analyzer *troubleshootv1beta2.ExposedServicesAnalyze
+}
+
+// exposedServiceIssue is the template data available to outcome messages, one is reported for
+// each NodePort or LoadBalancer service that is not allowlisted
+type exposedServiceIssue struct {
+	Namespace string
+	Name      string
+	// Type is NodePort or LoadBalancer
+	Type string
+	// Ports lists the service ports with their node ports, e.g. "443/TCP (node port 30443)"
+	Ports string
+	// Addresses are the load balancer ingress IPs and hostnames, empty for NodePort services or
+	// load balancers that were not provisioned
+	Addresses string
+}
+
+func (a *AnalyzeExposedServices) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Exposed Services"
+}
+
+func (a *AnalyzeExposedServices) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeExposedServices) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	for _, pattern := range a.analyzer.Allowlist {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid allowlist pattern %q", pattern)
+		}
+	}
+
+	services, err := readCollectedServices(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := findExposedServices(services, a.analyzer.Allowlist)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: defaultExposedServiceMessage(issue),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Namespace:  issue.Namespace,
+			Name:       issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No unexpected NodePort or LoadBalancer services were found",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultExposedServiceMessage(issue exposedServiceIssue) string {
+	message := fmt.Sprintf("%s service %s/%s is exposed outside the cluster on %s", issue.Type, issue.Namespace, issue.Name, issue.Ports)
+	if issue.Addresses != "" {
+		message += fmt.Sprintf(" at %s", issue.Addresses)
+	}
+	return message
+}
+
+// findExposedServices returns the NodePort and LoadBalancer services that do not match any of
+// the allowlist patterns, sorted by namespace and name
+func findExposedServices(services []corev1.Service, allowlist []string) []exposedServiceIssue {
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Namespace != services[j].Namespace {
+			return services[i].Namespace < services[j].Namespace
+		}
+		return services[i].Name < services[j].Name
+	})
+
+	issues := []exposedServiceIssue{}
+	for _, service := range services {
+		if service.Spec.Type != corev1.ServiceTypeNodePort && service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		if isServiceAllowlisted(service, allowlist) {
+			continue
+		}
+
+		ports := []string{}
+		for _, port := range service.Spec.Ports {
+			p := fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+			if port.NodePort != 0 {
+				p += fmt.Sprintf(" (node port %d)", port.NodePort)
+			}
+			ports = append(ports, p)
+		}
+
+		addresses := []string{}
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				addresses = append(addresses, ingress.IP)
+			}
+			if ingress.Hostname != "" {
+				addresses = append(addresses, ingress.Hostname)
+			}
+		}
+
+		issues = append(issues, exposedServiceIssue{
+			Namespace: service.Namespace,
+			Name:      service.Name,
+			Type:      string(service.Spec.Type),
+			Ports:     strings.Join(ports, ", "),
+			Addresses: strings.Join(addresses, ", "),
+		})
+	}
+
+	return issues
+}
+
+func isServiceAllowlisted(service corev1.Service, allowlist []string) bool {
+	for _, pattern := range allowlist {
+		if ok, _ := path.Match(pattern, service.Namespace+"/"+service.Name); ok {
+			return true
+		}
+	}
+	return false
+}
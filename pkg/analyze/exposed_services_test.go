@@ -0,0 +1,148 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeExposedServices(t *testing.T) {
+	serviceReference := func(namespace, name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "v1", Kind: "Service", Namespace: namespace, Name: name}
+	}
+
+	files := map[string][]byte{
+		"cluster-resources/services/default.json":       []byte(exposedServicesDefault),
+		"cluster-resources/services/ingress-nginx.json": []byte(exposedServicesIngressNginx),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.ExposedServicesAnalyze
+		expectResult []AnalyzeResult
+		expectErr    string
+	}{
+		{
+			name: "services outside the allowlist are reported with their ports",
+			analyzer: troubleshootv1beta2.ExposedServicesAnalyze{
+				Allowlist: []string{"ingress-nginx/*"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Exposed Services",
+					Message:        "NodePort service default/debug-api is exposed outside the cluster on 80/TCP (node port 30080), 6060/TCP (node port 30606)",
+					InvolvedObject: serviceReference("default", "debug-api"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "Exposed Services",
+					Message:        "LoadBalancer service default/postgres-external is exposed outside the cluster on 5432/TCP (node port 31432) at 203.0.113.10",
+					InvolvedObject: serviceReference("default", "postgres-external"),
+				},
+			},
+		},
+		{
+			name: "without an allowlist every exposed service is reported",
+			analyzer: troubleshootv1beta2.ExposedServicesAnalyze{
+				Namespaces: []string{"ingress-nginx"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Exposed Services",
+					Message:        "LoadBalancer service ingress-nginx/ingress-nginx-controller is exposed outside the cluster on 80/TCP (node port 32080), 443/TCP (node port 32443) at a1b2c3.elb.us-east-1.amazonaws.com",
+					InvolvedObject: serviceReference("ingress-nginx", "ingress-nginx-controller"),
+				},
+			},
+		},
+		{
+			name: "allowlisted services pass",
+			analyzer: troubleshootv1beta2.ExposedServicesAnalyze{
+				Allowlist: []string{"ingress-nginx/ingress-nginx-controller", "default/debug-*", "*/postgres-external"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Exposed Services",
+					Message: "No unexpected NodePort or LoadBalancer services were found",
+				},
+			},
+		},
+		{
+			name: "custom outcomes are templated with the service",
+			analyzer: troubleshootv1beta2.ExposedServicesAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "No External Exposure",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Type }} {{ .Name }}: {{ .Ports }}",
+						},
+					},
+				},
+				Allowlist: []string{"ingress-nginx/*", "default/postgres-external"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "No External Exposure",
+					Message:        "NodePort debug-api: 80/TCP (node port 30080), 6060/TCP (node port 30606)",
+					InvolvedObject: serviceReference("default", "debug-api"),
+				},
+			},
+		},
+		{
+			name: "invalid allowlist pattern",
+			analyzer: troubleshootv1beta2.ExposedServicesAnalyze{
+				Allowlist: []string{"default/[api"},
+			},
+			expectErr: `invalid allowlist pattern "default/[api"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeExposedServices{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			if test.expectErr != "" {
+				req.ErrorContains(err, test.expectErr)
+				return
+			}
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
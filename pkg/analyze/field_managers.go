@@ -0,0 +1,228 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultManualFieldManagers are the field managers of the tools used to change objects by hand
+var defaultManualFieldManagers = []string{"kubectl", "kubectl-*", "k9s"}
+
+// fieldManagersMaxFields is the number of fields listed in the default messages
+const fieldManagersMaxFields = 5
+
+type AnalyzeFieldManagers struct {
+	analyzer *troubleshootv1beta2.FieldManagersAnalyze
+}
+
+// fieldManagersIssue is the template data available to outcome messages, one is reported for each
+// manual change to an object managed by a controller
+type fieldManagersIssue struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Manager is the manual field manager, e.g. kubectl-edit
+	Manager   string
+	Operation string
+	// Time is when the manager last changed the object, in RFC3339, empty when it isn't recorded
+	Time string
+	// Fields is the comma separated list of the fields the manager owns
+	Fields string
+	// Controllers is the comma separated list of the managers that own the spec of the object
+	Controllers string
+}
+
+func (a *AnalyzeFieldManagers) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Field Managers"
+}
+
+func (a *AnalyzeFieldManagers) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeFieldManagers) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	histories, err := readCollectedManagedFields(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(histories) == 0 {
+		return []*AnalyzeResult{{
+			Title:   a.Title(),
+			IsWarn:  true,
+			Strict:  a.analyzer.Strict.BoolOrDefaultFalse(),
+			Message: "No field managers were collected, enable managedFields on the clusterResources collector",
+		}}, nil
+	}
+
+	manualManagers := a.analyzer.ManualManagers
+	if len(manualManagers) == 0 {
+		manualManagers = defaultManualFieldManagers
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range findFieldManagersIssues(histories, manualManagers) {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: defaultFieldManagersMessage(issue),
+			}
+		}
+		apiVersion := "apps/v1"
+		if issue.Kind == "Service" {
+			apiVersion = "v1"
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: apiVersion,
+			Kind:       issue.Kind,
+			Namespace:  issue.Namespace,
+			Name:       issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No objects managed by a controller were changed manually",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// findFieldManagersIssues returns the changes made by a manual manager to objects with spec fields
+// owned by another manager. Changes to the status subresource are not reported.
+func findFieldManagersIssues(histories []collect.ManagedFieldsHistory, manualManagers []string) []fieldManagersIssue {
+	issues := []fieldManagersIssue{}
+	for _, history := range histories {
+		controllers := []string{}
+		manual := []collect.FieldManager{}
+		for _, manager := range history.Managers {
+			if manager.Subresource == "status" {
+				continue
+			}
+			if isManualFieldManager(manager.Manager, manualManagers) {
+				manual = append(manual, manager)
+				continue
+			}
+			ownsSpec := slices.ContainsFunc(manager.Fields, func(field string) bool {
+				return strings.HasPrefix(field, "spec.")
+			})
+			if ownsSpec && !slices.Contains(controllers, manager.Manager) {
+				controllers = append(controllers, manager.Manager)
+			}
+		}
+		if len(controllers) == 0 {
+			continue
+		}
+
+		for _, manager := range manual {
+			issue := fieldManagersIssue{
+				Kind:        history.Kind,
+				Namespace:   history.Namespace,
+				Name:        history.Name,
+				Manager:     manager.Manager,
+				Operation:   manager.Operation,
+				Fields:      strings.Join(manager.Fields, ", "),
+				Controllers: strings.Join(controllers, ", "),
+			}
+			if manager.Time != nil {
+				issue.Time = manager.Time.UTC().Format(time.RFC3339)
+			}
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+func isManualFieldManager(manager string, manualManagers []string) bool {
+	for _, pattern := range manualManagers {
+		if matched, _ := path.Match(pattern, manager); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultFieldManagersMessage(issue fieldManagersIssue) string {
+	fields := strings.Split(issue.Fields, ", ")
+	if len(fields) > fieldManagersMaxFields {
+		fields = append(fields[:fieldManagersMaxFields], fmt.Sprintf("and %d more", len(fields)-fieldManagersMaxFields))
+	}
+
+	changed := fmt.Sprintf("was changed with %s", issue.Manager)
+	if issue.Time != "" {
+		changed = fmt.Sprintf("%s at %s", changed, issue.Time)
+	}
+	return fmt.Sprintf("%s %s/%s %s (%s) while it is managed by %s", issue.Kind, issue.Namespace, issue.Name, changed, strings.Join(fields, ", "), issue.Controllers)
+}
+
+// readCollectedManagedFields returns the field manager histories saved by the cluster resources
+// collector, sorted by namespace, kind and name
+func readCollectedManagedFields(findFiles getChildCollectedFileContents, namespaces []string) ([]collect.ManagedFieldsHistory, error) {
+	collected, err := findFiles(filepath.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_MANAGED_FIELDS, "*.json"), []string{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read collected field managers")
+	}
+
+	histories := []collect.ManagedFieldsHistory{}
+	for fileName, fileContent := range collected {
+		namespace := strings.TrimSuffix(filepath.Base(fileName), ".json")
+		if len(namespaces) > 0 && !slices.Contains(namespaces, namespace) {
+			continue
+		}
+
+		var fileHistories []collect.ManagedFieldsHistory
+		if err := json.Unmarshal(fileContent, &fileHistories); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", fileName)
+		}
+		histories = append(histories, fileHistories...)
+	}
+
+	sort.SliceStable(histories, func(i, j int) bool {
+		a, b := histories[i], histories[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	return histories, nil
+}
@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeFieldManagers(t *testing.T) {
+	collected := map[string][]byte{
+		"cluster-resources/managed-fields/default.json":    []byte(managedFieldsDefault),
+		"cluster-resources/managed-fields/monitoring.json": []byte(managedFieldsMonitoring),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.FieldManagersAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "manual changes to controller managed objects",
+			analyzer: troubleshootv1beta2.FieldManagersAnalyze{},
+			files:    collected,
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "Field Managers",
+					Message: "Deployment default/api was changed with kubectl-scale at 2026-10-12T08:14:03Z (spec.replicas) while it is managed by helm",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "api",
+					},
+				},
+				{
+					IsWarn:  true,
+					Title:   "Field Managers",
+					Message: "Deployment default/worker was changed with kubectl-edit at 2026-10-11T22:05:37Z (spec.template.spec.containers[name=worker].env[name=LOG_LEVEL].value, spec.template.spec.containers[name=worker].resources.limits.memory) while it is managed by helm",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "worker",
+					},
+				},
+				{
+					IsWarn:  true,
+					Title:   "Field Managers",
+					Message: "StatefulSet monitoring/prometheus-main was changed with kubectl-annotate at 2026-10-10T07:30:00Z (metadata.annotations.oncall) while it is managed by PrometheusOperator",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "apps/v1", Kind: "StatefulSet", Namespace: "monitoring", Name: "prometheus-main",
+					},
+				},
+			},
+		},
+		{
+			name: "outcomes with namespaces and manual managers",
+			analyzer: troubleshootv1beta2.FieldManagersAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Manual Edits",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Name }}: {{ .Manager }} ({{ .Operation }}) changed {{ .Fields }} owned by {{ .Controllers }}",
+						},
+					},
+				},
+				Namespaces:     []string{"default"},
+				ManualManagers: []string{"kubectl-edit"},
+			},
+			files: collected,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "Manual Edits",
+					Message: "tools: kubectl-edit (Update) changed spec.template.spec.containers[name=tools].image owned by kubectl-client-side-apply",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "tools",
+					},
+				},
+				{
+					IsFail:  true,
+					Title:   "Manual Edits",
+					Message: "worker: kubectl-edit (Update) changed spec.template.spec.containers[name=worker].env[name=LOG_LEVEL].value, spec.template.spec.containers[name=worker].resources.limits.memory owned by helm",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "worker",
+					},
+				},
+			},
+		},
+		{
+			name:     "field managers not collected",
+			analyzer: troubleshootv1beta2.FieldManagersAnalyze{},
+			files:    map[string][]byte{},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "Field Managers",
+					Message: "No field managers were collected, enable managedFields on the clusterResources collector",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			findFiles := func(n string, _ []string) (map[string][]byte, error) {
+				req.Equal("cluster-resources/managed-fields/*.json", n)
+				return test.files, nil
+			}
+
+			a := &AnalyzeFieldManagers{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(nil, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	gitOpsStatusSuspended = "Suspended"
+	gitOpsStatusNotReady  = "NotReady"
+	gitOpsStatusOutOfSync = "OutOfSync"
+	gitOpsStatusDegraded  = "Degraded"
+)
+
+type AnalyzeGitOps struct {
+	analyzer *troubleshootv1beta2.GitOpsAnalyze
+}
+
+// gitOpsIssue is the template data available to outcome messages
+type gitOpsIssue struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	// Status is one of Suspended, NotReady, OutOfSync or Degraded
+	Status string
+	// Message is the error reported by the GitOps controller, if any
+	Message string
+}
+
+func (a *AnalyzeGitOps) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "GitOps Reconciliation"
+}
+
+func (a *AnalyzeGitOps) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeGitOps) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	collected, err := findFiles(filepath.Join(constants.GITOPS_DIR, "*", "*.json"), []string{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read collected gitops resources")
+	}
+
+	objects := []unstructured.Unstructured{}
+	for fileName, fileContent := range collected {
+		namespace := strings.TrimSuffix(filepath.Base(fileName), ".json")
+		if len(a.analyzer.Namespaces) > 0 && !slices.Contains(a.analyzer.Namespaces, namespace) {
+			continue
+		}
+
+		items := []map[string]interface{}{}
+		if err := json.Unmarshal(fileContent, &items); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", fileName)
+		}
+		for _, item := range items {
+			objects = append(objects, unstructured.Unstructured{Object: item})
+		}
+	}
+
+	issues := findGitOpsIssues(objects)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = defaultGitOpsIssueResult(a.Title(), issue)
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: issue.APIVersion,
+			Kind:       issue.Kind,
+			Namespace:  issue.Namespace,
+			Name:       issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "All Flux and Argo CD resources are reconciled",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultGitOpsIssueResult(title string, issue gitOpsIssue) *AnalyzeResult {
+	object := fmt.Sprintf("%s %s/%s", issue.Kind, issue.Namespace, issue.Name)
+
+	var message string
+	switch issue.Status {
+	case gitOpsStatusSuspended:
+		message = fmt.Sprintf("Reconciliation of %s is suspended, changes in source control will not be applied", object)
+	case gitOpsStatusOutOfSync:
+		message = fmt.Sprintf("%s is out of sync with source control", object)
+	case gitOpsStatusDegraded:
+		message = fmt.Sprintf("%s is degraded", object)
+	default:
+		message = fmt.Sprintf("%s is not ready", object)
+	}
+	if issue.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, issue.Message)
+	}
+
+	return &AnalyzeResult{
+		Title:   title,
+		IsWarn:  issue.Status == gitOpsStatusSuspended,
+		IsFail:  issue.Status != gitOpsStatusSuspended,
+		Message: message,
+	}
+}
+
+// findGitOpsIssues returns the Flux and Argo CD resources that are suspended or failing to
+// reconcile, sorted by kind, namespace and name
+func findGitOpsIssues(objects []unstructured.Unstructured) []gitOpsIssue {
+	issues := []gitOpsIssue{}
+	for _, obj := range objects {
+		gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+		if err != nil {
+			continue
+		}
+
+		var status, message string
+		switch {
+		case strings.HasSuffix(gv.Group, ".toolkit.fluxcd.io"):
+			status, message = fluxStatus(obj)
+		case gv.Group == "argoproj.io" && obj.GetKind() == "Application":
+			status, message = argoApplicationStatus(obj)
+		}
+		if status == "" {
+			continue
+		}
+
+		issues = append(issues, gitOpsIssue{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+			Status:     status,
+			Message:    message,
+		})
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Kind != issues[j].Kind {
+			return issues[i].Kind < issues[j].Kind
+		}
+		if issues[i].Namespace != issues[j].Namespace {
+			return issues[i].Namespace < issues[j].Namespace
+		}
+		return issues[i].Name < issues[j].Name
+	})
+
+	return issues
+}
+
+// fluxStatus inspects spec.suspend and the Ready condition of a Flux Kustomization or HelmRelease
+func fluxStatus(obj unstructured.Unstructured) (string, string) {
+	if suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend"); suspended {
+		return gitOpsStatusSuspended, ""
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		if condition["status"] != "False" {
+			return "", ""
+		}
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		if reason != "" && message != "" {
+			message = fmt.Sprintf("%s: %s", reason, message)
+		} else if message == "" {
+			message = reason
+		}
+		return gitOpsStatusNotReady, message
+	}
+
+	return "", ""
+}
+
+// argoApplicationStatus inspects the sync and health status of an Argo CD Application. The
+// message is taken from error conditions first, then from the last sync operation.
+func argoApplicationStatus(obj unstructured.Unstructured) (string, string) {
+	syncStatus, _, _ := unstructured.NestedString(obj.Object, "status", "sync", "status")
+	healthStatus, _, _ := unstructured.NestedString(obj.Object, "status", "health", "status")
+
+	var status string
+	switch {
+	case syncStatus == "OutOfSync":
+		status = gitOpsStatusOutOfSync
+	case healthStatus == "Degraded" || healthStatus == "Missing":
+		status = gitOpsStatusDegraded
+	case healthStatus == "Suspended":
+		status = gitOpsStatusSuspended
+	default:
+		return "", ""
+	}
+
+	messages := []string{}
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _ := condition["type"].(string)
+		message, _ := condition["message"].(string)
+		if strings.HasSuffix(conditionType, "Error") && message != "" {
+			messages = append(messages, message)
+		}
+	}
+
+	if len(messages) == 0 {
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "operationState", "phase")
+		message, _, _ := unstructured.NestedString(obj.Object, "status", "operationState", "message")
+		if (phase == "Failed" || phase == "Error") && message != "" {
+			messages = append(messages, message)
+		}
+	}
+
+	if len(messages) == 0 {
+		if message, _, _ := unstructured.NestedString(obj.Object, "status", "health", "message"); message != "" {
+			messages = append(messages, message)
+		}
+	}
+
+	return status, strings.Join(messages, "; ")
+}
@@ -0,0 +1,167 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeGitOps(t *testing.T) {
+	collectedFiles := map[string][]byte{
+		"gitops/helmreleases.helm.toolkit.fluxcd.io/flux-system.json":        []byte(gitOpsFluxHelmReleases),
+		"gitops/kustomizations.kustomize.toolkit.fluxcd.io/flux-system.json": []byte(gitOpsFluxKustomizations),
+		"gitops/applications.argoproj.io/argocd.json":                        []byte(gitOpsArgoApplications),
+	}
+
+	suspendedKustomization := []byte(`[
+		{
+			"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+			"kind": "Kustomization",
+			"metadata": {"name": "apps", "namespace": "flux-system"},
+			"spec": {"suspend": true},
+			"status": {"conditions": [{"type": "Ready", "status": "True", "reason": "ReconciliationSucceeded"}]}
+		}
+	]`)
+
+	helmReleaseReference := &corev1.ObjectReference{
+		APIVersion: "helm.toolkit.fluxcd.io/v2",
+		Kind:       "HelmRelease",
+		Namespace:  "flux-system",
+		Name:       "podinfo",
+	}
+	applicationReference := &corev1.ObjectReference{
+		APIVersion: "argoproj.io/v1alpha1",
+		Kind:       "Application",
+		Namespace:  "argocd",
+		Name:       "guestbook",
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.GitOpsAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "failing helm release and out of sync application with default outcomes",
+			analyzer: troubleshootv1beta2.GitOpsAnalyze{},
+			files:    collectedFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "GitOps Reconciliation",
+					Message:        `Application argocd/guestbook is out of sync with source control: Failed sync attempt to 53e28ff20cc530b9ada2173fbbd64d48338583ba: one or more objects failed to apply, reason: Deployment.apps "guestbook-ui" is invalid: spec.template.spec.containers[0].image: Required value`,
+					InvolvedObject: applicationReference,
+				},
+				{
+					IsFail:         true,
+					Title:          "GitOps Reconciliation",
+					Message:        "HelmRelease flux-system/podinfo is not ready: InstallFailed: Helm install failed for release flux-system/podinfo with chart podinfo@6.5.4: context deadline exceeded",
+					InvolvedObject: helmReleaseReference,
+				},
+			},
+		},
+		{
+			name: "templated outcome",
+			analyzer: troubleshootv1beta2.GitOpsAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Flux",
+				},
+				Namespaces: []string{"flux-system"},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Kind }} {{ .Name }} is {{ .Status }}",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "Flux is healthy",
+						},
+					},
+				},
+			},
+			files: collectedFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Flux",
+					Message:        "HelmRelease podinfo is NotReady",
+					InvolvedObject: helmReleaseReference,
+				},
+			},
+		},
+		{
+			name:     "suspended kustomization warns",
+			analyzer: troubleshootv1beta2.GitOpsAnalyze{},
+			files: map[string][]byte{
+				"gitops/kustomizations.kustomize.toolkit.fluxcd.io/flux-system.json": suspendedKustomization,
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "GitOps Reconciliation",
+					Message: "Reconciliation of Kustomization flux-system/apps is suspended, changes in source control will not be applied",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "kustomize.toolkit.fluxcd.io/v1",
+						Kind:       "Kustomization",
+						Namespace:  "flux-system",
+						Name:       "apps",
+					},
+				},
+			},
+		},
+		{
+			name:     "healthy resources pass",
+			analyzer: troubleshootv1beta2.GitOpsAnalyze{},
+			files: map[string][]byte{
+				"gitops/kustomizations.kustomize.toolkit.fluxcd.io/flux-system.json": []byte(gitOpsFluxKustomizations),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "GitOps Reconciliation",
+					Message: "All Flux and Argo CD resources are reconciled",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				return nil, errors.New("method not implemented")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeGitOps{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package analyzer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	goldenDriftMissing    = "Missing"
+	goldenDriftUnexpected = "Unexpected"
+	goldenDriftChanged    = "Changed"
+)
+
+var (
+	defaultGoldenSnapshotResources = []string{
+		constants.CLUSTER_RESOURCES_DEPLOYMENTS,
+		constants.CLUSTER_RESOURCES_STATEFULSETS,
+		constants.CLUSTER_RESOURCES_DAEMONSETS,
+		constants.CLUSTER_RESOURCES_SERVICES,
+	}
+	defaultGoldenSnapshotFields = []string{
+		"{.spec.replicas}",
+		"{.spec.template.spec.containers[*].image}",
+	}
+)
+
+type AnalyzeGoldenSnapshot struct {
+	analyzer *troubleshootv1beta2.GoldenSnapshotAnalyze
+}
+
+// goldenDrift is the template data available to outcome messages. Resources are identified by
+// the directory they were collected to, their namespace and their name.
+type goldenDrift struct {
+	Resource  string
+	Kind      string
+	Namespace string
+	Name      string
+	// Drift is Missing when the resource is only in the golden snapshot, Unexpected when it is
+	// only in the analyzed bundle and Changed when a field differs
+	Drift string
+	// Field, Expected and Actual are set for Changed drift. The cluster version is compared as
+	// the field "clusterVersion" of a resource with no name.
+	Field    string
+	Expected string
+	Actual   string
+}
+
+// snapshotFiles reads files from a support bundle
+type snapshotFiles struct {
+	getFile   getCollectedFileContents
+	findFiles getChildCollectedFileContents
+}
+
+func (a *AnalyzeGoldenSnapshot) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Golden Snapshot"
+}
+
+func (a *AnalyzeGoldenSnapshot) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeGoldenSnapshot) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	if a.analyzer.Snapshot == "" {
+		return nil, errors.New("golden snapshot analyzer requires a snapshot")
+	}
+
+	goldenDir, cleanup, err := openGoldenSnapshot(a.analyzer.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	golden := fileContentProvider{rootDir: goldenDir}
+	drifts, err := compareGoldenSnapshot(
+		snapshotFiles{getFile: golden.getFileContents, findFiles: golden.getChildFileContents},
+		snapshotFiles{getFile: getFile, findFiles: findFiles},
+		a.analyzer.Resources, a.analyzer.Fields, a.analyzer.Namespaces,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*AnalyzeResult{}
+	for _, drift := range drifts {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), drift)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = defaultGoldenDriftResult(a.Title(), drift)
+		}
+		if drift.Name != "" {
+			result.InvolvedObject = &corev1.ObjectReference{
+				Kind:      drift.Kind,
+				Namespace: drift.Namespace,
+				Name:      drift.Name,
+			}
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "The cluster matches the golden snapshot",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// openGoldenSnapshot returns the root directory of the golden snapshot. Archives and URLs are
+// extracted to a temporary directory which is removed by the returned cleanup function.
+func openGoldenSnapshot(snapshot string) (string, func(), error) {
+	noop := func() {}
+
+	if info, err := os.Stat(snapshot); err == nil {
+		if info.IsDir() {
+			rootDir, err := FindBundleRootDir(snapshot)
+			if err != nil {
+				return "", noop, errors.Wrap(err, "failed to find golden snapshot root dir")
+			}
+			return rootDir, noop, nil
+		}
+
+		// local archives must be passed as absolute paths to be opened rather than downloaded
+		snapshot, err = filepath.Abs(snapshot)
+		if err != nil {
+			return "", noop, errors.Wrap(err, "failed to get absolute path of golden snapshot")
+		}
+	}
+
+	tmpDir, rootDir, err := DownloadAndExtractSupportBundle(snapshot)
+	if err != nil {
+		return "", noop, errors.Wrap(err, "failed to extract golden snapshot")
+	}
+	return rootDir, func() { os.RemoveAll(tmpDir) }, nil
+}
+
+func defaultGoldenDriftResult(title string, drift goldenDrift) *AnalyzeResult {
+	identity := goldenDriftIdentity(drift)
+
+	switch drift.Drift {
+	case goldenDriftMissing:
+		return &AnalyzeResult{
+			Title:   title,
+			IsFail:  true,
+			Message: fmt.Sprintf("%s is in the golden snapshot but was not found in the cluster", identity),
+		}
+	case goldenDriftUnexpected:
+		return &AnalyzeResult{
+			Title:   title,
+			IsWarn:  true,
+			Message: fmt.Sprintf("%s was found in the cluster but is not in the golden snapshot", identity),
+		}
+	default:
+		return &AnalyzeResult{
+			Title:   title,
+			IsWarn:  true,
+			Message: fmt.Sprintf("%s has drifted from the golden snapshot: %s is %s, expected %s", identity, drift.Field, drift.Actual, drift.Expected),
+		}
+	}
+}
+
+func goldenDriftIdentity(drift goldenDrift) string {
+	if drift.Name == "" {
+		return "The cluster"
+	}
+	if drift.Namespace == "" {
+		return fmt.Sprintf("%s %s", drift.Kind, drift.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", drift.Kind, drift.Namespace, drift.Name)
+}
+
+// compareGoldenSnapshot compares the cluster version and the given resources of a bundle to a
+// golden snapshot. Resources are matched by identity, and the fields of resources found in
+// both are compared.
+func compareGoldenSnapshot(golden, current snapshotFiles, resources, fields, namespaces []string) ([]goldenDrift, error) {
+	if len(resources) == 0 {
+		resources = defaultGoldenSnapshotResources
+	}
+	if len(fields) == 0 {
+		fields = defaultGoldenSnapshotFields
+	}
+
+	drifts := []goldenDrift{}
+
+	expectedVersion, err := snapshotClusterVersion(golden)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read golden snapshot cluster version")
+	}
+	actualVersion, err := snapshotClusterVersion(current)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read cluster version")
+	}
+	if expectedVersion != "" && expectedVersion != actualVersion {
+		drifts = append(drifts, goldenDrift{
+			Drift:    goldenDriftChanged,
+			Field:    "clusterVersion",
+			Expected: expectedVersion,
+			Actual:   actualVersion,
+		})
+	}
+
+	for _, resource := range resources {
+		expected, err := snapshotResources(golden, resource, namespaces)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read golden snapshot %s", resource)
+		}
+		actual, err := snapshotResources(current, resource, namespaces)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", resource)
+		}
+
+		identities := []string{}
+		for identity := range expected {
+			identities = append(identities, identity)
+		}
+		for identity := range actual {
+			if _, ok := expected[identity]; !ok {
+				identities = append(identities, identity)
+			}
+		}
+		sort.Strings(identities)
+
+		for _, identity := range identities {
+			expectedObj, inGolden := expected[identity]
+			actualObj, inCurrent := actual[identity]
+
+			obj := expectedObj
+			if !inGolden {
+				obj = actualObj
+			}
+			drift := goldenDrift{
+				Resource:  resource,
+				Kind:      snapshotObjectKind(obj, resource),
+				Namespace: objectString(obj, "metadata", "namespace"),
+				Name:      objectString(obj, "metadata", "name"),
+			}
+
+			switch {
+			case !inCurrent:
+				drift.Drift = goldenDriftMissing
+				drifts = append(drifts, drift)
+			case !inGolden:
+				drift.Drift = goldenDriftUnexpected
+				drifts = append(drifts, drift)
+			default:
+				for _, field := range fields {
+					expectedValue, err := evaluateSnapshotField(expectedObj, field)
+					if err != nil {
+						return nil, err
+					}
+					actualValue, err := evaluateSnapshotField(actualObj, field)
+					if err != nil {
+						return nil, err
+					}
+					if reflect.DeepEqual(expectedValue, actualValue) {
+						continue
+					}
+
+					changed := drift
+					changed.Drift = goldenDriftChanged
+					changed.Field = field
+					changed.Expected = formatSnapshotValue(expectedValue)
+					changed.Actual = formatSnapshotValue(actualValue)
+					drifts = append(drifts, changed)
+				}
+			}
+		}
+	}
+
+	return drifts, nil
+}
+
+// snapshotResources returns the objects collected for a resource keyed by namespace/name.
+// Namespaced resources are stored in a file per namespace, cluster scoped resources in a
+// single file, and each file holds a list or an array of objects.
+func snapshotResources(files snapshotFiles, resource string, namespaces []string) (map[string]map[string]interface{}, error) {
+	contents, err := collectedNamespaceFiles(files.findFiles, resource, namespaces)
+	if err != nil {
+		return nil, err
+	}
+	if len(contents) == 0 {
+		clusterScoped, err := files.getFile(filepath.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s.json", resource)))
+		if err == nil {
+			contents = map[string][]byte{"": clusterScoped}
+		}
+	}
+
+	objects := map[string]map[string]interface{}{}
+	for _, content := range contents {
+		var list struct {
+			Items []map[string]interface{} `json:"items"`
+		}
+		if err := json.Unmarshal(content, &list); err != nil {
+			if err := json.Unmarshal(content, &list.Items); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal %s", resource)
+			}
+		}
+
+		for _, obj := range list.Items {
+			namespace := objectString(obj, "metadata", "namespace")
+			if namespace != "" && len(namespaces) > 0 && !slices.Contains(namespaces, namespace) {
+				continue
+			}
+			objects[fmt.Sprintf("%s/%s", namespace, objectString(obj, "metadata", "name"))] = obj
+		}
+	}
+
+	return objects, nil
+}
+
+func snapshotClusterVersion(files snapshotFiles) (string, error) {
+	contents, err := files.getFile("cluster-info/cluster_version.json")
+	if err != nil {
+		// bundles collected without the cluster info collector have no version to compare
+		return "", nil
+	}
+
+	clusterVersion := collect.ClusterVersion{}
+	if err := json.Unmarshal(contents, &clusterVersion); err != nil {
+		return "", errors.Wrap(err, "failed to parse cluster_version.json")
+	}
+	return clusterVersion.String, nil
+}
+
+func evaluateSnapshotField(obj map[string]interface{}, field string) (interface{}, error) {
+	jsp := jsonpath.New(field)
+	jsp.AllowMissingKeys(true).EnableJSONOutput(true)
+	if err := jsp.Parse(field); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse jsonpath: %s", field)
+	}
+
+	var data bytes.Buffer
+	if err := jsp.Execute(&data, obj); err != nil {
+		return nil, errors.Wrapf(err, "failed to execute jsonpath: %s", field)
+	}
+
+	var value interface{}
+	if err := json.NewDecoder(&data).Decode(&value); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode jsonpath result: %s", field)
+	}
+
+	// unwrap single results so they are reported as a value rather than a list
+	if values, ok := value.([]interface{}); ok && len(values) == 1 {
+		value = values[0]
+	}
+	return value, nil
+}
+
+func formatSnapshotValue(value interface{}) string {
+	if values, ok := value.([]interface{}); ok && len(values) == 0 {
+		return "<none>"
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
+
+func snapshotObjectKind(obj map[string]interface{}, resource string) string {
+	if kind, ok := obj["kind"].(string); ok && kind != "" {
+		return kind
+	}
+	return resource
+}
+
+func objectString(obj map[string]interface{}, fields ...string) string {
+	s, _, _ := unstructured.NestedString(obj, fields...)
+	return s
+}
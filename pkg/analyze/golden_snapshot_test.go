@@ -0,0 +1,183 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeGoldenSnapshot(t *testing.T) {
+	goldenDir := filepath.Join("files", "golden-snapshot", "golden")
+
+	driftedFiles := map[string][]byte{
+		"cluster-info/cluster_version.json":           []byte(goldenSnapshotDriftedClusterVersion),
+		"cluster-resources/deployments/default.json":  []byte(goldenSnapshotDriftedDeployments),
+		"cluster-resources/statefulsets/default.json": []byte(goldenSnapshotDriftedStatefulSets),
+		"cluster-resources/services/default.json":     []byte(goldenSnapshotDriftedServices),
+	}
+
+	// a bundle identical to the golden snapshot
+	matchingFiles := map[string][]byte{}
+	for _, name := range []string{
+		"cluster-info/cluster_version.json",
+		"cluster-resources/deployments/default.json",
+		"cluster-resources/statefulsets/default.json",
+		"cluster-resources/services/default.json",
+	} {
+		b, err := os.ReadFile(filepath.Join(goldenDir, name))
+		require.NoError(t, err)
+		matchingFiles[name] = b
+	}
+
+	apiReference := &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "api"}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.GoldenSnapshotAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name: "drifted bundle with default resources and fields",
+			analyzer: troubleshootv1beta2.GoldenSnapshotAnalyze{
+				Snapshot: goldenDir,
+			},
+			files: driftedFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "Golden Snapshot",
+					Message: "The cluster has drifted from the golden snapshot: clusterVersion is v1.30.1, expected v1.29.4",
+				},
+				{
+					IsWarn:         true,
+					Title:          "Golden Snapshot",
+					Message:        "Deployment default/api has drifted from the golden snapshot: {.spec.replicas} is 1, expected 3",
+					InvolvedObject: apiReference,
+				},
+				{
+					IsWarn:         true,
+					Title:          "Golden Snapshot",
+					Message:        "Deployment default/api has drifted from the golden snapshot: {.spec.template.spec.containers[*].image} is registry.example.com/api:1.5.0-rc1, expected registry.example.com/api:1.4.2",
+					InvolvedObject: apiReference,
+				},
+				{
+					IsWarn:         true,
+					Title:          "Golden Snapshot",
+					Message:        "Deployment default/debug was found in the cluster but is not in the golden snapshot",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "debug"},
+				},
+				{
+					IsFail:         true,
+					Title:          "Golden Snapshot",
+					Message:        "StatefulSet default/db is in the golden snapshot but was not found in the cluster",
+					InvolvedObject: &corev1.ObjectReference{Kind: "StatefulSet", Namespace: "default", Name: "db"},
+				},
+			},
+		},
+		{
+			name: "only the selected resources and fields are compared",
+			analyzer: troubleshootv1beta2.GoldenSnapshotAnalyze{
+				Snapshot:  goldenDir,
+				Resources: []string{"deployments", "services"},
+				Fields:    []string{"{.spec.template.spec.containers[*].image}", "{.spec.ports[*].port}"},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Drift }} {{ .Resource }} {{ .Name }} {{ .Field }} {{ .Expected }} -> {{ .Actual }}",
+						},
+					},
+				},
+			},
+			files: driftedFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "Golden Snapshot",
+					Message: "Changed   clusterVersion v1.29.4 -> v1.30.1",
+				},
+				{
+					IsFail:         true,
+					Title:          "Golden Snapshot",
+					Message:        "Changed deployments api {.spec.template.spec.containers[*].image} registry.example.com/api:1.4.2 -> registry.example.com/api:1.5.0-rc1",
+					InvolvedObject: apiReference,
+				},
+				{
+					IsFail:         true,
+					Title:          "Golden Snapshot",
+					Message:        "Unexpected deployments debug   -> ",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "debug"},
+				},
+			},
+		},
+		{
+			name: "matching bundle passes",
+			analyzer: troubleshootv1beta2.GoldenSnapshotAnalyze{
+				Snapshot: goldenDir,
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "drift detected",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "cluster matches the standard",
+						},
+					},
+				},
+			},
+			files: matchingFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Golden Snapshot",
+					Message: "cluster matches the standard",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeGoldenSnapshot{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const defaultHighAvailabilityMinReplicas = 2
+
+type AnalyzeHighAvailability struct {
+	analyzer *troubleshootv1beta2.HighAvailabilityAnalyze
+}
+
+// highAvailabilityIssue is the template data available to outcome messages, one is reported for
+// each selected workload that is under-replicated or does not spread its pods
+type highAvailabilityIssue struct {
+	Kind            string
+	Namespace       string
+	Name            string
+	Replicas        int
+	MinReplicas     int
+	UnderReplicated bool
+	// NotSpread is true when the pod template has neither pod anti-affinity nor topology spread
+	// constraints, so all replicas can be scheduled on the same node
+	NotSpread bool
+}
+
+// haWorkload is the part of a Deployment or StatefulSet the analyzer checks
+type haWorkload struct {
+	kind     string
+	meta     metav1.ObjectMeta
+	replicas int
+	// podLabels are the labels of the pod template
+	podLabels map[string]string
+	podSpec   corev1.PodSpec
+}
+
+func (a *AnalyzeHighAvailability) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "High Availability"
+}
+
+func (a *AnalyzeHighAvailability) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeHighAvailability) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	workloads, err := readHAWorkloads(findFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := findHighAvailabilityIssues(workloads, a.analyzer.Workloads)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsFail:  issue.UnderReplicated,
+				IsWarn:  !issue.UnderReplicated,
+				Message: defaultHighAvailabilityMessage(issue),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       issue.Kind,
+			Namespace:  issue.Namespace,
+			Name:       issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "All highly available workloads run enough replicas spread across nodes",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultHighAvailabilityMessage(issue highAvailabilityIssue) string {
+	problems := []string{}
+	if issue.UnderReplicated {
+		problems = append(problems, fmt.Sprintf("runs %d replica(s), fewer than the %d required", issue.Replicas, issue.MinReplicas))
+	}
+	if issue.NotSpread {
+		problems = append(problems, "has no pod anti-affinity or topology spread constraints, so its replicas can all be scheduled on one node")
+	}
+	return fmt.Sprintf("%s %s/%s %s. A single node or pod failure can take it down.", issue.Kind, issue.Namespace, issue.Name, strings.Join(problems, " and "))
+}
+
+// findHighAvailabilityIssues returns the workloads selected by the expectations that run fewer
+// replicas than required or don't spread them. A workload selected by several expectations is
+// checked against the highest minReplicas.
+func findHighAvailabilityIssues(workloads []haWorkload, expectations []troubleshootv1beta2.HighAvailabilityWorkload) ([]highAvailabilityIssue, error) {
+	minReplicas, err := selectHAWorkloads(workloads, expectations, "high availability workloads")
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []highAvailabilityIssue{}
+	for i, workload := range workloads {
+		required, ok := minReplicas[i]
+		if !ok {
+			continue
+		}
+
+		issue := highAvailabilityIssue{
+			Kind:            workload.kind,
+			Namespace:       workload.meta.Namespace,
+			Name:            workload.meta.Name,
+			Replicas:        workload.replicas,
+			MinReplicas:     required,
+			UnderReplicated: workload.replicas < required,
+			NotSpread:       !podSpecSpreadsReplicas(workload.podSpec),
+		}
+		if issue.UnderReplicated || issue.NotSpread {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
+
+// selectHAWorkloads returns the highest minReplicas of the expectations selecting each workload,
+// keyed by the workload's index. Workloads no expectation selects are left out.
+func selectHAWorkloads(workloads []haWorkload, expectations []troubleshootv1beta2.HighAvailabilityWorkload, description string) (map[int]int, error) {
+	minReplicas := map[int]int{}
+	for _, expectation := range expectations {
+		selector := labels.Everything()
+		if len(expectation.Selector) > 0 {
+			var err error
+			selector, err = labels.Parse(strings.Join(expectation.Selector, ","))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse selector %q", strings.Join(expectation.Selector, ","))
+			}
+		} else if expectation.Name == "" {
+			return nil, errors.Errorf("%s require a name or a selector", description)
+		}
+
+		required := expectation.MinReplicas
+		if required <= 0 {
+			required = defaultHighAvailabilityMinReplicas
+		}
+
+		for i, workload := range workloads {
+			if expectation.Kind != "" && !strings.EqualFold(expectation.Kind, workload.kind) {
+				continue
+			}
+			if expectation.Namespace != "" && expectation.Namespace != workload.meta.Namespace {
+				continue
+			}
+			if expectation.Name != "" && expectation.Name != workload.meta.Name {
+				continue
+			}
+			if !selector.Matches(labels.Set(workload.meta.Labels)) {
+				continue
+			}
+			if required > minReplicas[i] {
+				minReplicas[i] = required
+			}
+		}
+	}
+
+	return minReplicas, nil
+}
+
+func podSpecSpreadsReplicas(spec corev1.PodSpec) bool {
+	if len(spec.TopologySpreadConstraints) > 0 {
+		return true
+	}
+	if spec.Affinity == nil || spec.Affinity.PodAntiAffinity == nil {
+		return false
+	}
+	antiAffinity := spec.Affinity.PodAntiAffinity
+	return len(antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) > 0 || len(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) > 0
+}
+
+// readHAWorkloads returns the collected Deployments and StatefulSets sorted by kind, namespace
+// and name
+func readHAWorkloads(findFiles getChildCollectedFileContents) ([]haWorkload, error) {
+	deployments, err := readCollectedDeployments(findFiles, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	statefulSets, err := readCollectedStatefulSets(findFiles, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := []haWorkload{}
+	for _, deployment := range deployments {
+		replicas := 1
+		if deployment.Spec.Replicas != nil {
+			replicas = int(*deployment.Spec.Replicas)
+		}
+		workloads = append(workloads, haWorkload{
+			kind:      "Deployment",
+			meta:      deployment.ObjectMeta,
+			replicas:  replicas,
+			podLabels: deployment.Spec.Template.Labels,
+			podSpec:   deployment.Spec.Template.Spec,
+		})
+	}
+	for _, statefulSet := range statefulSets {
+		replicas := 1
+		if statefulSet.Spec.Replicas != nil {
+			replicas = int(*statefulSet.Spec.Replicas)
+		}
+		workloads = append(workloads, haWorkload{
+			kind:      "StatefulSet",
+			meta:      statefulSet.ObjectMeta,
+			replicas:  replicas,
+			podLabels: statefulSet.Spec.Template.Labels,
+			podSpec:   statefulSet.Spec.Template.Spec,
+		})
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].kind != workloads[j].kind {
+			return workloads[i].kind < workloads[j].kind
+		}
+		if workloads[i].meta.Namespace != workloads[j].meta.Namespace {
+			return workloads[i].meta.Namespace < workloads[j].meta.Namespace
+		}
+		return workloads[i].meta.Name < workloads[j].meta.Name
+	})
+
+	return workloads, nil
+}
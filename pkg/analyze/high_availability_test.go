@@ -0,0 +1,167 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeHighAvailability(t *testing.T) {
+	workloadReference := func(kind, name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "apps/v1", Kind: kind, Namespace: "default", Name: name}
+	}
+
+	files := map[string][]byte{
+		"cluster-resources/deployments/default.json":  []byte(highAvailabilityDeployments),
+		"cluster-resources/statefulsets/default.json": []byte(highAvailabilityStatefulSets),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.HighAvailabilityAnalyze
+		expectResult []AnalyzeResult
+		expectErr    string
+	}{
+		{
+			name: "under-replicated and unspread workloads are reported",
+			analyzer: troubleshootv1beta2.HighAvailabilityAnalyze{
+				Workloads: []troubleshootv1beta2.HighAvailabilityWorkload{
+					{Selector: []string{"ha=true"}},
+					{Kind: "Deployment", Namespace: "default", Name: "worker"},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "High Availability",
+					Message:        "Deployment default/web has no pod anti-affinity or topology spread constraints, so its replicas can all be scheduled on one node. A single node or pod failure can take it down.",
+					InvolvedObject: workloadReference("Deployment", "web"),
+				},
+				{
+					IsFail:         true,
+					Title:          "High Availability",
+					Message:        "Deployment default/worker runs 1 replica(s), fewer than the 2 required and has no pod anti-affinity or topology spread constraints, so its replicas can all be scheduled on one node. A single node or pod failure can take it down.",
+					InvolvedObject: workloadReference("Deployment", "worker"),
+				},
+				{
+					IsFail:         true,
+					Title:          "High Availability",
+					Message:        "StatefulSet default/postgres runs 1 replica(s), fewer than the 2 required. A single node or pod failure can take it down.",
+					InvolvedObject: workloadReference("StatefulSet", "postgres"),
+				},
+			},
+		},
+		{
+			name: "custom outcomes use the highest required replica count",
+			analyzer: troubleshootv1beta2.HighAvailabilityAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Control Plane HA",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Kind }} {{ .Name }} has {{ .Replicas }}/{{ .MinReplicas }} replicas, spread: {{ not .NotSpread }}",
+						},
+					},
+				},
+				Workloads: []troubleshootv1beta2.HighAvailabilityWorkload{
+					{Kind: "StatefulSet", Selector: []string{"app in (etcd, postgres)"}},
+					{Kind: "statefulset", Name: "etcd", MinReplicas: 5},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Control Plane HA",
+					Message:        "StatefulSet etcd has 3/5 replicas, spread: true",
+					InvolvedObject: workloadReference("StatefulSet", "etcd"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Control Plane HA",
+					Message:        "StatefulSet postgres has 1/2 replicas, spread: true",
+					InvolvedObject: workloadReference("StatefulSet", "postgres"),
+				},
+			},
+		},
+		{
+			name: "compliant and missing workloads pass",
+			analyzer: troubleshootv1beta2.HighAvailabilityAnalyze{
+				Workloads: []troubleshootv1beta2.HighAvailabilityWorkload{
+					{Name: "api", MinReplicas: 3},
+					{Name: "etcd"},
+					{Namespace: "payments", Name: "ledger"},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "High Availability",
+					Message: "All highly available workloads run enough replicas spread across nodes",
+				},
+			},
+		},
+		{
+			name: "workload without name or selector",
+			analyzer: troubleshootv1beta2.HighAvailabilityAnalyze{
+				Workloads: []troubleshootv1beta2.HighAvailabilityWorkload{
+					{Kind: "Deployment"},
+				},
+			},
+			expectErr: "high availability workloads require a name or a selector",
+		},
+		{
+			name: "invalid selector",
+			analyzer: troubleshootv1beta2.HighAvailabilityAnalyze{
+				Workloads: []troubleshootv1beta2.HighAvailabilityWorkload{
+					{Selector: []string{"app in (etcd"}},
+				},
+			},
+			expectErr: `failed to parse selector "app in (etcd"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeHighAvailability{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			if test.expectErr != "" {
+				req.ErrorContains(err, test.expectErr)
+				return
+			}
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
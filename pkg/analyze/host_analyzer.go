@@ -65,6 +65,12 @@ func GetHostAnalyzer(analyzer *troubleshootv1beta2.HostAnalyze) (HostAnalyzer, b
 		return &AnalyzeHostNetworkNamespaceConnectivity{analyzer.NetworkNamespaceConnectivity}, true
 	case analyzer.Sysctl != nil:
 		return &AnalyzeHostSysctl{analyzer.Sysctl}, true
+	case analyzer.MTU != nil:
+		return &AnalyzeHostMTU{analyzer.MTU}, true
+	case analyzer.Systemd != nil:
+		return &AnalyzeHostSystemd{analyzer.Systemd}, true
+	case analyzer.Dmesg != nil:
+		return &AnalyzeHostDmesg{analyzer.Dmesg}, true
 	default:
 		return nil, false
 	}
@@ -0,0 +1,163 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+)
+
+// Ensure `AnalyzeHostDmesg` implements `HostAnalyzer` interface at compile time.
+var _ HostAnalyzer = (*AnalyzeHostDmesg)(nil)
+
+var (
+	// the OOM killer logs "Out of memory: Killed process" once per process it kills, or
+	// "Memory cgroup out of memory: Killed process" when the limit of a cgroup was reached
+	dmesgOOMKillRegex = regexp.MustCompile(`(?i)out of memory: kill(ed)? process`)
+	// e.g. "blk_update_request: I/O error, dev sda, sector 2048" or "Buffer I/O error on dev sda1"
+	dmesgIOErrorRegex = regexp.MustCompile(`I/O error`)
+)
+
+type AnalyzeHostDmesg struct {
+	hostAnalyzer *troubleshootv1beta2.HostDmesgAnalyze
+}
+
+func (a *AnalyzeHostDmesg) Title() string {
+	return hostAnalyzerTitleOrDefault(a.hostAnalyzer.AnalyzeMeta, "Kernel Messages")
+}
+
+func (a *AnalyzeHostDmesg) IsExcluded() (bool, error) {
+	return isExcluded(a.hostAnalyzer.Exclude)
+}
+
+func (a *AnalyzeHostDmesg) Analyze(
+	getCollectedFileContents func(string) ([]byte, error), findFiles getChildCollectedFileContents,
+) ([]*AnalyzeResult, error) {
+	collectedContents, err := retrieveCollectedContents(
+		getCollectedFileContents,
+		collect.HostDmesgPath,
+		path.Dir(collect.HostDmesgPath),
+		collect.HostDmesgFileName,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve collected kernel messages")
+	}
+	if len(collectedContents) == 0 {
+		return (&resultCollector{}).get(a.Title()), nil
+	}
+
+	outcomes := a.hostAnalyzer.Outcomes
+	if len(outcomes) == 0 {
+		outcomes = defaultHostDmesgOutcomes()
+	}
+
+	results, err := analyzeHostCollectorResults(collectedContents, outcomes, a.CheckCondition, a.Title())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to analyze kernel messages")
+	}
+
+	for _, result := range results {
+		result.Strict = a.hostAnalyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultHostDmesgOutcomes() []*troubleshootv1beta2.Outcome {
+	return []*troubleshootv1beta2.Outcome{
+		{
+			Warn: &troubleshootv1beta2.SingleOutcome{
+				When:    "unreadable",
+				Message: "The kernel messages could not be read",
+			},
+		},
+		{
+			Warn: &troubleshootv1beta2.SingleOutcome{
+				When:    "oomKills > 0",
+				Message: "The kernel OOM killer killed processes",
+			},
+		},
+		{
+			Warn: &troubleshootv1beta2.SingleOutcome{
+				When:    "ioErrors > 0",
+				Message: "The kernel logged I/O errors",
+			},
+		},
+		{
+			Pass: &troubleshootv1beta2.SingleOutcome{
+				Message: "No OOM kills or I/O errors were logged by the kernel",
+			},
+		},
+	}
+}
+
+func (a *AnalyzeHostDmesg) CheckCondition(when string, data []byte) (bool, error) {
+	var info collect.DmesgInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return false, fmt.Errorf("failed to unmarshal dmesg: %v", err)
+	}
+
+	return compareHostDmesgConditionalToActual(when, info)
+}
+
+// unreadable
+// <oomKills|ioErrors> <op> <count>
+// example: oomKills > 0
+func compareHostDmesgConditionalToActual(conditional string, info collect.DmesgInfo) (bool, error) {
+	parts := strings.Fields(conditional)
+	if len(parts) == 1 && parts[0] == "unreadable" {
+		return info.Error != "", nil
+	}
+	if len(parts) != 3 {
+		return false, fmt.Errorf("expected exactly 3 parts, got %d", len(parts))
+	}
+
+	var re *regexp.Regexp
+	switch parts[0] {
+	case "oomKills":
+		re = dmesgOOMKillRegex
+	case "ioErrors":
+		re = dmesgIOErrorRegex
+	default:
+		return false, fmt.Errorf("unexpected condition %q", parts[0])
+	}
+
+	actual := 0
+	for _, entry := range info.Entries {
+		if re.MatchString(entry.Message) {
+			actual++
+		}
+	}
+
+	op, err := ParseComparisonOperator(parts[1])
+	if err != nil {
+		return false, err
+	}
+	expected, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse %s count", parts[0])
+	}
+
+	switch op {
+	case Equal:
+		return actual == expected, nil
+	case NotEqual:
+		return actual != expected, nil
+	case LessThan:
+		return actual < expected, nil
+	case LessThanOrEqual:
+		return actual <= expected, nil
+	case GreaterThan:
+		return actual > expected, nil
+	case GreaterThanOrEqual:
+		return actual >= expected, nil
+	}
+
+	return false, fmt.Errorf("unexpected operator %q", parts[1])
+}
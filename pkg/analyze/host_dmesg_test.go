@@ -0,0 +1,148 @@
+package analyzer
+
+import (
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"github.com/replicatedhq/troubleshoot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeHostDmesg(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        map[string][]byte
+		hostAnalyzer *troubleshootv1beta2.HostDmesgAnalyze
+		result       []*AnalyzeResult
+		expectErr    bool
+	}{
+		{
+			name: "no errors",
+			files: map[string][]byte{
+				"host-collectors/dmesg.json": []byte(`{"source": "dmesg", "entries": [
+					{"timestamp": 0.5, "facility": "kern", "severity": "info", "message": "Linux version 6.1.0"}
+				]}`),
+			},
+			hostAnalyzer: &troubleshootv1beta2.HostDmesgAnalyze{},
+			result: []*AnalyzeResult{
+				{
+					Title:   "Kernel Messages",
+					IsPass:  true,
+					Message: "No OOM kills or I/O errors were logged by the kernel",
+				},
+			},
+		},
+		{
+			name: "oom kills and io errors on remote nodes",
+			files: map[string][]byte{
+				constants.NODE_LIST_FILE: []byte(`{"nodes": ["node-1", "node-2", "node-3"]}`),
+				"host-collectors/node-1/dmesg.json": []byte(`{"source": "/dev/kmsg", "entries": [
+					{"timestamp": 10.2, "facility": "kern", "severity": "warn", "message": "java invoked oom-killer: gfp_mask=0xcc0(GFP_KERNEL), order=0, oom_score_adj=0"},
+					{"timestamp": 10.3, "facility": "kern", "severity": "err", "message": "Memory cgroup out of memory: Killed process 1234 (java) total-vm:4096kB"}
+				]}`),
+				"host-collectors/node-2/dmesg.json": []byte(`{"source": "dmesg", "entries": [
+					{"timestamp": 20.1, "facility": "kern", "severity": "err", "message": "blk_update_request: I/O error, dev sda, sector 2048 op 0x1:(WRITE)"}
+				]}`),
+				"host-collectors/node-3/dmesg.json": []byte(`{"entries": [], "error": "permission denied reading the kernel ring buffer"}`),
+			},
+			hostAnalyzer: &troubleshootv1beta2.HostDmesgAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{CheckName: "dmesg"},
+			},
+			result: []*AnalyzeResult{
+				{
+					Title:   "dmesg - Node node-1",
+					IsWarn:  true,
+					Message: "The kernel OOM killer killed processes",
+				},
+				{
+					Title:   "dmesg - Node node-2",
+					IsWarn:  true,
+					Message: "The kernel logged I/O errors",
+				},
+				{
+					Title:   "dmesg - Node node-3",
+					IsWarn:  true,
+					Message: "The kernel messages could not be read",
+				},
+			},
+		},
+		{
+			name: "custom outcomes",
+			files: map[string][]byte{
+				"host-collectors/dmesg.json": []byte(`{"source": "dmesg", "entries": [
+					{"timestamp": 1, "message": "Out of memory: Killed process 1 (a)"},
+					{"timestamp": 2, "message": "Out of memory: Kill process 2 (b) score 900 or sacrifice child"}
+				]}`),
+			},
+			hostAnalyzer: &troubleshootv1beta2.HostDmesgAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "oomKills >= 2",
+							Message: "processes were OOM killed",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							Message: "few OOM kills",
+						},
+					},
+				},
+			},
+			result: []*AnalyzeResult{
+				{
+					Title:   "Kernel Messages",
+					IsFail:  true,
+					Message: "processes were OOM killed",
+				},
+			},
+		},
+		{
+			name:         "not collected",
+			files:        map[string][]byte{},
+			hostAnalyzer: &troubleshootv1beta2.HostDmesgAnalyze{},
+			result: []*AnalyzeResult{
+				{
+					Title:   "Kernel Messages",
+					IsWarn:  true,
+					Message: "no results",
+				},
+			},
+		},
+		{
+			name: "invalid condition",
+			files: map[string][]byte{
+				"host-collectors/dmesg.json": []byte(`{"entries": []}`),
+			},
+			hostAnalyzer: &troubleshootv1beta2.HostDmesgAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{When: "panics > 0"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			getCollectedFileContents := func(filename string) ([]byte, error) {
+				if b, ok := test.files[filename]; ok {
+					return b, nil
+				}
+				return nil, &types.NotFoundError{Name: filename}
+			}
+
+			result, err := (&AnalyzeHostDmesg{test.hostAnalyzer}).Analyze(getCollectedFileContents, nil)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.result, result)
+		})
+	}
+}
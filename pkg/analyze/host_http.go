@@ -12,8 +12,9 @@ import (
 )
 
 type httpResult struct {
-	Error    *collect.HTTPError
-	Response *collect.HTTPResponse
+	Error     *collect.HTTPError
+	Response  *collect.HTTPResponse
+	Redirects *collect.HTTPRedirectChain
 }
 
 type AnalyzeHostHTTP struct {
@@ -60,8 +61,13 @@ func (a *AnalyzeHostHTTP) Analyze(
 }
 
 func compareHostHTTPConditionalToActual(conditional string, result *httpResult) (res bool, err error) {
-	if conditional == "error" {
+	switch conditional {
+	case "error":
 		return result.Error != nil, nil
+	case "redirectLoop":
+		return result.Redirects != nil && result.Redirects.Loop, nil
+	case "tooManyRedirects":
+		return result.Redirects != nil && result.Redirects.TooManyRedirects, nil
 	}
 
 	parts := strings.Split(conditional, " ")
@@ -69,6 +75,18 @@ func compareHostHTTPConditionalToActual(conditional string, result *httpResult)
 		return false, fmt.Errorf("Failed to parse conditional: got %d parts", len(parts))
 	}
 
+	if parts[0] == "redirectCount" {
+		if result.Redirects == nil {
+			return false, errors.New("redirects were not recorded, set recordRedirects on the collector")
+		}
+		// the first hop is the original request
+		redirectCount := len(result.Redirects.Hops) - 1
+		if redirectCount < 0 {
+			redirectCount = 0
+		}
+		return doCompareRedirectCount(parts[1], parts[2], redirectCount)
+	}
+
 	if parts[0] != "statusCode" {
 		return false, errors.New(`Conditional must begin with keyword "statusCode"`)
 	}
@@ -88,6 +106,35 @@ func compareHostHTTPConditionalToActual(conditional string, result *httpResult)
 	return result.Response.Status == i, nil
 }
 
+func doCompareRedirectCount(operator string, desired string, actual int) (bool, error) {
+	op, err := ParseComparisonOperator(operator)
+	if err != nil {
+		return false, err
+	}
+
+	desiredInt, err := strconv.Atoi(desired)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse redirect count")
+	}
+
+	switch op {
+	case Equal:
+		return actual == desiredInt, nil
+	case NotEqual:
+		return actual != desiredInt, nil
+	case LessThan:
+		return actual < desiredInt, nil
+	case LessThanOrEqual:
+		return actual <= desiredInt, nil
+	case GreaterThan:
+		return actual > desiredInt, nil
+	case GreaterThanOrEqual:
+		return actual >= desiredInt, nil
+	}
+
+	return false, errors.Errorf("unknown operator: %s", operator)
+}
+
 func analyzeHTTPResult(analyzer *troubleshootv1beta2.HTTPAnalyze, fileName string, getCollectedFileContents getCollectedFileContents, title string) ([]*AnalyzeResult, error) {
 	contents, err := getCollectedFileContents(fileName)
 	if err != nil {
@@ -113,6 +113,110 @@ func TestAnalyzeHostHTTP(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "redirect loop",
+			httpResult: &httpResult{
+				Response: &collect.HTTPResponse{
+					Status: 302,
+				},
+				Redirects: &collect.HTTPRedirectChain{
+					Hops: []collect.HTTPRedirectHop{
+						{Method: "GET", URL: "http://app.example.com/", Status: 302, Location: "/login"},
+						{Method: "GET", URL: "http://app.example.com/login", Status: 302, Location: "/"},
+					},
+					Loop: true,
+				},
+			},
+			hostAnalyzer: &troubleshootv1beta2.HTTPAnalyze{
+				CollectorName: "ingress",
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "redirectLoop",
+							Message: "The ingress redirects in a loop",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "statusCode == 200",
+							Message: "passed",
+						},
+					},
+				},
+			},
+			result: []*AnalyzeResult{
+				{
+					Title:   "HTTP Request",
+					IsFail:  true,
+					Message: "The ingress redirects in a loop",
+				},
+			},
+		},
+		{
+			name: "redirect count",
+			httpResult: &httpResult{
+				Response: &collect.HTTPResponse{
+					Status: 200,
+				},
+				Redirects: &collect.HTTPRedirectChain{
+					Hops: []collect.HTTPRedirectHop{
+						{Method: "GET", URL: "http://app.example.com/", Status: 301, Location: "https://app.example.com/"},
+						{Method: "GET", URL: "https://app.example.com/", Status: 302, Location: "/login"},
+						{Method: "GET", URL: "https://app.example.com/login", Status: 200},
+					},
+				},
+			},
+			hostAnalyzer: &troubleshootv1beta2.HTTPAnalyze{
+				CollectorName: "ingress",
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "redirectLoop",
+							Message: "The ingress redirects in a loop",
+						},
+					},
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "redirectCount > 1",
+							Message: "More than one redirect",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "statusCode == 200",
+							Message: "passed",
+						},
+					},
+				},
+			},
+			result: []*AnalyzeResult{
+				{
+					Title:   "HTTP Request",
+					IsWarn:  true,
+					Message: "More than one redirect",
+				},
+			},
+		},
+		{
+			name: "redirect count without recorded redirects",
+			httpResult: &httpResult{
+				Response: &collect.HTTPResponse{
+					Status: 200,
+				},
+			},
+			hostAnalyzer: &troubleshootv1beta2.HTTPAnalyze{
+				CollectorName: "ingress",
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "redirectCount > 1",
+							Message: "More than one redirect",
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
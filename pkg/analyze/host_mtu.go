@@ -0,0 +1,284 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+)
+
+// Ensure `AnalyzeHostMTU` implements `HostAnalyzer` interface at compile time.
+var _ HostAnalyzer = (*AnalyzeHostMTU)(nil)
+
+const (
+	hostMTUInconsistent    = "Inconsistent"
+	hostMTUOverlayTooLarge = "OverlayTooLarge"
+)
+
+// overlayEncapsulations detect overlay network interfaces by name, with the encapsulation they
+// use and its overhead in bytes over IPv4
+var overlayEncapsulations = []struct {
+	pattern       *regexp.Regexp
+	encapsulation string
+	overhead      int
+}{
+	{regexp.MustCompile(`^(flannel\.\d+|vxlan\.calico|cilium_vxlan|vxlan.*)$`), "VXLAN", 50},
+	{regexp.MustCompile(`^(genev_sys_\d+|cilium_geneve|geneve.*)$`), "Geneve", 50},
+	{regexp.MustCompile(`^(tunl0|ipip.*)$`), "IP-in-IP", 20},
+	{regexp.MustCompile(`^(wireguard\.cali|flannel-wg|cilium_wg\d*|wg\d+)$`), "WireGuard", 60},
+}
+
+type AnalyzeHostMTU struct {
+	hostAnalyzer *troubleshootv1beta2.HostMTUAnalyze
+}
+
+// hostMTUIssue is the template data available to outcome messages
+type hostMTUIssue struct {
+	// Node is empty when the collector ran on the local host only
+	Node string
+	// Problem is Inconsistent when the node's default route interface MTU differs from the
+	// expected MTU, or from the MTU most nodes use, and OverlayTooLarge when an overlay interface
+	// leaves no room for the encapsulation overhead
+	Problem   string
+	Interface string
+	MTU       int
+	// ExpectedMTU is the largest MTU the interface should have
+	ExpectedMTU int
+	// UplinkInterface and UplinkMTU are the node's default route interface and its MTU
+	UplinkInterface string
+	UplinkMTU       int
+	// Encapsulation and Overhead are set for overlay interfaces
+	Encapsulation string
+	Overhead      int
+	// NodeMTUs lists the MTU of each node's default route interface, e.g. "node-1=1500, node-2=9001"
+	NodeMTUs string
+}
+
+// nodeMTUs are the interface MTUs collected on a node
+type nodeMTUs struct {
+	node       string
+	interfaces []collect.MTUInterface
+	// uplink is the interface the default route goes through, nil when it is not known
+	uplink *collect.MTUInterface
+}
+
+func (a *AnalyzeHostMTU) Title() string {
+	return hostAnalyzerTitleOrDefault(a.hostAnalyzer.AnalyzeMeta, "MTU")
+}
+
+func (a *AnalyzeHostMTU) IsExcluded() (bool, error) {
+	return isExcluded(a.hostAnalyzer.Exclude)
+}
+
+func (a *AnalyzeHostMTU) Analyze(
+	getCollectedFileContents func(string) ([]byte, error), findFiles getChildCollectedFileContents,
+) ([]*AnalyzeResult, error) {
+	collectedContents, err := retrieveCollectedContents(
+		getCollectedFileContents,
+		collect.HostMTUPath,
+		collect.NodeInfoBaseDir,
+		collect.HostMTUFileName,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve collected mtus")
+	}
+	if len(collectedContents) == 0 {
+		return (&resultCollector{}).get(a.Title()), nil
+	}
+
+	nodes := []nodeMTUs{}
+	for _, content := range collectedContents {
+		node := nodeMTUs{node: content.NodeName}
+		if err := json.Unmarshal(content.Data, &node.interfaces); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal mtus of node %q", content.NodeName)
+		}
+		for i := range node.interfaces {
+			if node.interfaces[i].DefaultRoute {
+				node.uplink = &node.interfaces[i]
+				break
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].node < nodes[j].node
+	})
+
+	issues := findHostMTUIssues(nodes, a.hostAnalyzer.ExpectedMTU, a.hostAnalyzer.OverlayOverhead)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		title := a.Title()
+		if issue.Node != "" {
+			title = fmt.Sprintf("%s - Node %s", a.Title(), issue.Node)
+		}
+
+		result, err := evaluateDetectedOutcomes(a.hostAnalyzer.Outcomes, true, title, issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   title,
+				IsWarn:  issue.Problem == hostMTUInconsistent,
+				IsFail:  issue.Problem == hostMTUOverlayTooLarge,
+				Message: defaultHostMTUIssueMessage(issue, a.hostAnalyzer.ExpectedMTU != 0),
+			}
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		summary := hostMTUIssue{NodeMTUs: nodeMTUSummary(nodes)}
+		result, err := evaluateDetectedOutcomes(a.hostAnalyzer.Outcomes, false, a.Title(), summary)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: fmt.Sprintf("The MTU is consistent across nodes and leaves room for the overlay encapsulation overhead: %s", summary.NodeMTUs),
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.hostAnalyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultHostMTUIssueMessage(issue hostMTUIssue, expectedMTUSet bool) string {
+	onNode := ""
+	if issue.Node != "" {
+		onNode = fmt.Sprintf(" on node %s", issue.Node)
+	}
+
+	if issue.Problem == hostMTUOverlayTooLarge {
+		return fmt.Sprintf("Overlay interface %s%s has MTU %d, which leaves no room for the %d byte %s overhead on interface %s with MTU %d. Set the overlay MTU to %d or less.",
+			issue.Interface, onNode, issue.MTU, issue.Overhead, issue.Encapsulation, issue.UplinkInterface, issue.UplinkMTU, issue.ExpectedMTU)
+	}
+
+	expected := fmt.Sprintf("most nodes use %d", issue.ExpectedMTU)
+	if expectedMTUSet {
+		expected = fmt.Sprintf("%d is expected", issue.ExpectedMTU)
+	}
+	return fmt.Sprintf("Interface %s%s has MTU %d but %s. Packets larger than the smallest MTU on their path are dropped, so every node should use the same MTU: %s",
+		issue.Interface, onNode, issue.MTU, expected, issue.NodeMTUs)
+}
+
+// findHostMTUIssues compares the MTU of each node's default route interface with the expected
+// MTU, or with the MTU most nodes use, and checks that the MTU of each overlay interface leaves
+// room for its encapsulation overhead. Nodes whose default route interface is not known are
+// skipped.
+func findHostMTUIssues(nodes []nodeMTUs, expectedMTU, overlayOverhead int) []hostMTUIssue {
+	summary := nodeMTUSummary(nodes)
+
+	reference := expectedMTU
+	if reference == 0 {
+		reference = mostCommonUplinkMTU(nodes)
+	}
+
+	issues := []hostMTUIssue{}
+	for _, node := range nodes {
+		if node.uplink == nil {
+			continue
+		}
+
+		if node.uplink.MTU != reference {
+			issues = append(issues, hostMTUIssue{
+				Node:            node.node,
+				Problem:         hostMTUInconsistent,
+				Interface:       node.uplink.Name,
+				MTU:             node.uplink.MTU,
+				ExpectedMTU:     reference,
+				UplinkInterface: node.uplink.Name,
+				UplinkMTU:       node.uplink.MTU,
+				NodeMTUs:        summary,
+			})
+		}
+
+		for _, iface := range node.interfaces {
+			encapsulation, overhead := detectOverlayEncapsulation(iface.Name)
+			if encapsulation == "" {
+				continue
+			}
+			if overlayOverhead > 0 {
+				overhead = overlayOverhead
+			}
+			if iface.MTU+overhead <= node.uplink.MTU {
+				continue
+			}
+
+			issues = append(issues, hostMTUIssue{
+				Node:            node.node,
+				Problem:         hostMTUOverlayTooLarge,
+				Interface:       iface.Name,
+				MTU:             iface.MTU,
+				ExpectedMTU:     node.uplink.MTU - overhead,
+				UplinkInterface: node.uplink.Name,
+				UplinkMTU:       node.uplink.MTU,
+				Encapsulation:   encapsulation,
+				Overhead:        overhead,
+				NodeMTUs:        summary,
+			})
+		}
+	}
+
+	return issues
+}
+
+// detectOverlayEncapsulation returns the encapsulation and overhead of an overlay interface, or
+// an empty encapsulation when the interface is not an overlay
+func detectOverlayEncapsulation(name string) (string, int) {
+	for _, overlay := range overlayEncapsulations {
+		if overlay.pattern.MatchString(name) {
+			return overlay.encapsulation, overlay.overhead
+		}
+	}
+	return "", 0
+}
+
+// mostCommonUplinkMTU returns the default route interface MTU used by most nodes, preferring the
+// smaller MTU on a tie
+func mostCommonUplinkMTU(nodes []nodeMTUs) int {
+	counts := map[int]int{}
+	for _, node := range nodes {
+		if node.uplink != nil {
+			counts[node.uplink.MTU]++
+		}
+	}
+
+	mostCommon := 0
+	for mtu, count := range counts {
+		if mostCommon == 0 || count > counts[mostCommon] || (count == counts[mostCommon] && mtu < mostCommon) {
+			mostCommon = mtu
+		}
+	}
+	return mostCommon
+}
+
+// nodeMTUSummary lists the MTU of each node's default route interface, labelled with the node
+// name, or with the interface name when the collector ran on the local host only
+func nodeMTUSummary(nodes []nodeMTUs) string {
+	values := []string{}
+	for _, node := range nodes {
+		if node.uplink == nil {
+			continue
+		}
+		label := node.node
+		if label == "" {
+			label = node.uplink.Name
+		}
+		values = append(values, fmt.Sprintf("%s=%d", label, node.uplink.MTU))
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/replicatedhq/troubleshoot/pkg/analyze/types"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeHostMTU(t *testing.T) {
+	remoteFiles := map[string][]byte{
+		constants.NODE_LIST_FILE:                 []byte(`{"nodes": ["node-3", "node-1", "node-2"]}`),
+		"host-collectors/system/node-1/mtu.json": []byte(hostMTUNode1),
+		"host-collectors/system/node-2/mtu.json": []byte(hostMTUNode2),
+		"host-collectors/system/node-3/mtu.json": []byte(hostMTUNode3),
+	}
+
+	tests := []struct {
+		name         string
+		files        map[string][]byte
+		hostAnalyzer *troubleshootv1beta2.HostMTUAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:         "inconsistent node and overlay without room for encapsulation",
+			files:        remoteFiles,
+			hostAnalyzer: &troubleshootv1beta2.HostMTUAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "MTU - Node node-2",
+					Message: "Overlay interface flannel.1 on node node-2 has MTU 1500, which leaves no room for the 50 byte VXLAN overhead on interface eth0 with MTU 1500. Set the overlay MTU to 1450 or less.",
+				},
+				{
+					IsWarn:  true,
+					Title:   "MTU - Node node-3",
+					Message: "Interface ens5 on node node-3 has MTU 9001 but most nodes use 1500. Packets larger than the smallest MTU on their path are dropped, so every node should use the same MTU: node-1=1500, node-2=1500, node-3=9001",
+				},
+			},
+		},
+		{
+			name:  "expected mtu with custom outcomes",
+			files: remoteFiles,
+			hostAnalyzer: &troubleshootv1beta2.HostMTUAnalyze{
+				ExpectedMTU: 9001,
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Problem }} {{ .Interface }} {{ .MTU }} {{ .ExpectedMTU }}",
+						},
+					},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "MTU - Node node-1",
+					Message: "Inconsistent eth0 1500 9001",
+				},
+				{
+					IsFail:  true,
+					Title:   "MTU - Node node-2",
+					Message: "Inconsistent eth0 1500 9001",
+				},
+				{
+					IsFail:  true,
+					Title:   "MTU - Node node-2",
+					Message: "OverlayTooLarge flannel.1 1500 1450",
+				},
+			},
+		},
+		{
+			name: "overlay overhead is configurable",
+			files: map[string][]byte{
+				"host-collectors/system/mtu.json": []byte(hostMTUNode1),
+			},
+			hostAnalyzer: &troubleshootv1beta2.HostMTUAnalyze{
+				OverlayOverhead: 60,
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "MTU",
+					Message: "Overlay interface flannel.1 has MTU 1450, which leaves no room for the 60 byte VXLAN overhead on interface eth0 with MTU 1500. Set the overlay MTU to 1440 or less.",
+				},
+			},
+		},
+		{
+			name: "local host",
+			files: map[string][]byte{
+				"host-collectors/system/mtu.json": []byte(hostMTUNode1),
+			},
+			hostAnalyzer: &troubleshootv1beta2.HostMTUAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "MTU",
+					Message: "The MTU is consistent across nodes and leaves room for the overlay encapsulation overhead: eth0=1500",
+				},
+			},
+		},
+		{
+			name:         "nothing collected",
+			files:        map[string][]byte{},
+			hostAnalyzer: &troubleshootv1beta2.HostMTUAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "MTU",
+					Message: "no results",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, &types.NotFoundError{Name: n}
+			}
+
+			a := &AnalyzeHostMTU{test.hostAnalyzer}
+			actual, err := a.Analyze(getFile, nil)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
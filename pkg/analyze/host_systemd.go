@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+)
+
+// Ensure `AnalyzeHostSystemd` implements `HostAnalyzer` interface at compile time.
+var _ HostAnalyzer = (*AnalyzeHostSystemd)(nil)
+
+type AnalyzeHostSystemd struct {
+	hostAnalyzer *troubleshootv1beta2.HostSystemdAnalyze
+}
+
+func (a *AnalyzeHostSystemd) Title() string {
+	return hostAnalyzerTitleOrDefault(a.hostAnalyzer.AnalyzeMeta, fmt.Sprintf("Systemd Unit %s", a.hostAnalyzer.Unit))
+}
+
+func (a *AnalyzeHostSystemd) IsExcluded() (bool, error) {
+	return isExcluded(a.hostAnalyzer.Exclude)
+}
+
+func (a *AnalyzeHostSystemd) Analyze(
+	getCollectedFileContents func(string) ([]byte, error), findFiles getChildCollectedFileContents,
+) ([]*AnalyzeResult, error) {
+	unit := a.hostAnalyzer.Unit
+	if unit == "" {
+		return nil, errors.New("unit is required")
+	}
+
+	collectedContents, err := retrieveCollectedContents(
+		getCollectedFileContents,
+		collect.HostSystemdUnitPath(unit),
+		strings.TrimSuffix(collect.HostSystemdPath, "/"),
+		collect.HostSystemdUnitFileName(unit),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve collected properties of unit %s", unit)
+	}
+	if len(collectedContents) == 0 {
+		return (&resultCollector{}).get(a.Title()), nil
+	}
+
+	outcomes := a.hostAnalyzer.Outcomes
+	if len(outcomes) == 0 {
+		outcomes = defaultHostSystemdOutcomes(unit)
+	}
+
+	results, err := analyzeHostCollectorResults(collectedContents, outcomes, a.CheckCondition, a.Title())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to analyze unit %s", unit)
+	}
+
+	for _, result := range results {
+		result.Strict = a.hostAnalyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultHostSystemdOutcomes(unit string) []*troubleshootv1beta2.Outcome {
+	return []*troubleshootv1beta2.Outcome{
+		{
+			Fail: &troubleshootv1beta2.SingleOutcome{
+				When:    "LoadState != loaded",
+				Message: fmt.Sprintf("Unit %s is not installed", unit),
+			},
+		},
+		{
+			Fail: &troubleshootv1beta2.SingleOutcome{
+				When:    "ActiveState != active",
+				Message: fmt.Sprintf("Unit %s is not active", unit),
+			},
+		},
+		{
+			Pass: &troubleshootv1beta2.SingleOutcome{
+				Message: fmt.Sprintf("Unit %s is active", unit),
+			},
+		},
+	}
+}
+
+func (a *AnalyzeHostSystemd) CheckCondition(when string, data []byte) (bool, error) {
+	var properties map[string]string
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return false, fmt.Errorf("failed to unmarshal unit properties: %v", err)
+	}
+
+	return compareHostSystemdConditionalToActual(when, properties)
+}
+
+// <property> <op> <value>
+// example: ActiveState == active
+func compareHostSystemdConditionalToActual(conditional string, properties map[string]string) (bool, error) {
+	parts := strings.SplitN(strings.TrimSpace(conditional), " ", 3)
+	if len(parts) != 3 {
+		return false, fmt.Errorf("expected exactly 3 parts, got %d", len(parts))
+	}
+
+	actual := properties[parts[0]]
+	expected := strings.TrimSpace(parts[2])
+
+	switch parts[1] {
+	case "=", "==":
+		return actual == expected, nil
+	case "!=", "<>":
+		return actual != expected, nil
+	}
+
+	return false, fmt.Errorf("unexpected operator %q", parts[1])
+}
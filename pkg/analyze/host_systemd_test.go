@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"github.com/replicatedhq/troubleshoot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeHostSystemd(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        map[string][]byte
+		hostAnalyzer *troubleshootv1beta2.HostSystemdAnalyze
+		result       []*AnalyzeResult
+		expectErr    bool
+	}{
+		{
+			name: "active unit",
+			files: map[string][]byte{
+				"host-collectors/systemd/kubelet.service.json": []byte(`{"LoadState": "loaded", "ActiveState": "active", "SubState": "running"}`),
+			},
+			hostAnalyzer: &troubleshootv1beta2.HostSystemdAnalyze{Unit: "kubelet.service"},
+			result: []*AnalyzeResult{
+				{
+					Title:   "Systemd Unit kubelet.service",
+					IsPass:  true,
+					Message: "Unit kubelet.service is active",
+				},
+			},
+		},
+		{
+			name: "failed and missing units on remote nodes",
+			files: map[string][]byte{
+				constants.NODE_LIST_FILE:                                 []byte(`{"nodes": ["node-1", "node-2", "node-3"]}`),
+				"host-collectors/systemd/node-1/containerd.service.json": []byte(`{"LoadState": "loaded", "ActiveState": "active", "SubState": "running"}`),
+				"host-collectors/systemd/node-2/containerd.service.json": []byte(`{"LoadState": "loaded", "ActiveState": "failed", "SubState": "failed"}`),
+				"host-collectors/systemd/node-3/containerd.service.json": []byte(`{"LoadState": "not-found", "ActiveState": "inactive", "SubState": "dead"}`),
+			},
+			hostAnalyzer: &troubleshootv1beta2.HostSystemdAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{CheckName: "containerd"},
+				Unit:        "containerd.service",
+			},
+			result: []*AnalyzeResult{
+				{
+					Title:   "containerd - Node node-1",
+					IsPass:  true,
+					Message: "Unit containerd.service is active",
+				},
+				{
+					Title:   "containerd - Node node-2",
+					IsFail:  true,
+					Message: "Unit containerd.service is not active",
+				},
+				{
+					Title:   "containerd - Node node-3",
+					IsFail:  true,
+					Message: "Unit containerd.service is not installed",
+				},
+			},
+		},
+		{
+			name: "custom outcomes",
+			files: map[string][]byte{
+				"host-collectors/systemd/kubelet.service.json": []byte(`{"LoadState": "loaded", "ActiveState": "activating", "SubState": "auto-restart"}`),
+			},
+			hostAnalyzer: &troubleshootv1beta2.HostSystemdAnalyze{
+				Unit: "kubelet.service",
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "SubState == auto-restart",
+							Message: "kubelet is restarting",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							Message: "kubelet is running",
+						},
+					},
+				},
+			},
+			result: []*AnalyzeResult{
+				{
+					Title:   "Systemd Unit kubelet.service",
+					IsWarn:  true,
+					Message: "kubelet is restarting",
+				},
+			},
+		},
+		{
+			name:         "unit not collected",
+			files:        map[string][]byte{},
+			hostAnalyzer: &troubleshootv1beta2.HostSystemdAnalyze{Unit: "kubelet.service"},
+			result: []*AnalyzeResult{
+				{
+					Title:   "Systemd Unit kubelet.service",
+					IsWarn:  true,
+					Message: "no results",
+				},
+			},
+		},
+		{
+			name: "invalid condition",
+			files: map[string][]byte{
+				"host-collectors/systemd/kubelet.service.json": []byte(`{"ActiveState": "active"}`),
+			},
+			hostAnalyzer: &troubleshootv1beta2.HostSystemdAnalyze{
+				Unit: "kubelet.service",
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{When: "ActiveState > active"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			getCollectedFileContents := func(filename string) ([]byte, error) {
+				if b, ok := test.files[filename]; ok {
+					return b, nil
+				}
+				return nil, &types.NotFoundError{Name: filename}
+			}
+
+			result, err := (&AnalyzeHostSystemd{test.hostAnalyzer}).Analyze(getCollectedFileContents, nil)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.result, result)
+		})
+	}
+}
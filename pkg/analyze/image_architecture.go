@@ -0,0 +1,335 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const execFormatError = "exec format error"
+
+// node labels that pin a pod to a cpu architecture
+var architectureLabels = []string{corev1.LabelArchStable, "beta.kubernetes.io/arch"}
+
+type AnalyzeImageArchitecture struct {
+	analyzer *troubleshootv1beta2.ImageArchitectureAnalyze
+}
+
+// imageArchitectureIssue is the template data available to outcome messages. Issues are
+// reported per workload, pods owned by the same workload are grouped together.
+type imageArchitectureIssue struct {
+	Namespace string
+	Kind      string
+	Name      string
+	Pods      []string
+	// NodeArchitectures are the architectures of the nodes the workload's pods were scheduled to
+	NodeArchitectures []string
+	// ClusterArchitectures are the architectures of all nodes in the cluster
+	ClusterArchitectures []string
+	// ExecFormatError is true when a container failed with "exec format error", meaning its
+	// image was not built for the architecture of the node it ran on
+	ExecFormatError bool
+	// MissingConstraint is true when the workload has no nodeSelector or required node affinity
+	// on kubernetes.io/arch in a cluster with nodes of more than one architecture
+	MissingConstraint bool
+}
+
+func (a *AnalyzeImageArchitecture) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Image Architecture"
+}
+
+func (a *AnalyzeImageArchitecture) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeImageArchitecture) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	nodes, err := readCollectedNodes(getFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := readCollectedPods(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	execFormatErrorPods, err := podsWithExecFormatErrors(pods, findFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := findImageArchitectureIssues(nodes, pods, execFormatErrorPods)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = defaultImageArchitectureIssueResult(a.Title(), issue)
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			Kind:      issue.Kind,
+			Namespace: issue.Namespace,
+			Name:      issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No workloads are at risk of running on a node with an incompatible architecture",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultImageArchitectureIssueResult(title string, issue imageArchitectureIssue) *AnalyzeResult {
+	workload := fmt.Sprintf("%s %s/%s", issue.Kind, issue.Namespace, issue.Name)
+
+	if issue.ExecFormatError {
+		message := fmt.Sprintf("%s is failing with %q on %s nodes, its image does not support that architecture.", workload, execFormatError, strings.Join(issue.NodeArchitectures, ", "))
+		if issue.MissingConstraint {
+			message += fmt.Sprintf(" Add a nodeSelector on %s to schedule it on a supported architecture, or publish a multi-arch image.", corev1.LabelArchStable)
+		} else {
+			message += " Publish an image for this architecture or change the workload's node selection."
+		}
+		return &AnalyzeResult{
+			Title:   title,
+			IsFail:  true,
+			Message: message,
+		}
+	}
+
+	return &AnalyzeResult{
+		Title:  title,
+		IsWarn: true,
+		Message: fmt.Sprintf(
+			"%s has no nodeSelector or node affinity on %s, but the cluster has %s nodes. Unless its images are multi-arch, pin it to a supported architecture.",
+			workload, corev1.LabelArchStable, strings.Join(issue.ClusterArchitectures, " and "),
+		),
+	}
+}
+
+// findImageArchitectureIssues returns the workloads that failed with exec format errors, and on
+// clusters with more than one node architecture, the workloads that are not pinned to one.
+// DaemonSets are not required to be pinned since they are expected to run on every node.
+func findImageArchitectureIssues(nodes []corev1.Node, pods []corev1.Pod, execFormatErrorPods map[string]bool) []imageArchitectureIssue {
+	nodeArchitectures := map[string]string{}
+	clusterArchitectures := []string{}
+	for _, node := range nodes {
+		arch := nodeArchitecture(node)
+		if arch == "" {
+			continue
+		}
+		nodeArchitectures[node.Name] = arch
+		if !slices.Contains(clusterArchitectures, arch) {
+			clusterArchitectures = append(clusterArchitectures, arch)
+		}
+	}
+	sort.Strings(clusterArchitectures)
+	heterogeneous := len(clusterArchitectures) > 1
+
+	issuesByWorkload := map[string]*imageArchitectureIssue{}
+	workloads := []string{}
+	for _, pod := range pods {
+		kind, name := podWorkload(pod)
+		execFormat := execFormatErrorPods[podKey(pod)]
+		missingConstraint := heterogeneous && kind != "DaemonSet" && !hasArchitectureConstraint(pod.Spec)
+		if !execFormat && !missingConstraint {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%s", pod.Namespace, kind, name)
+		issue, ok := issuesByWorkload[key]
+		if !ok {
+			issue = &imageArchitectureIssue{
+				Namespace:            pod.Namespace,
+				Kind:                 kind,
+				Name:                 name,
+				ClusterArchitectures: clusterArchitectures,
+			}
+			issuesByWorkload[key] = issue
+			workloads = append(workloads, key)
+		}
+
+		issue.Pods = append(issue.Pods, pod.Name)
+		issue.ExecFormatError = issue.ExecFormatError || execFormat
+		issue.MissingConstraint = issue.MissingConstraint || missingConstraint
+		if arch := nodeArchitectures[pod.Spec.NodeName]; arch != "" && !slices.Contains(issue.NodeArchitectures, arch) {
+			issue.NodeArchitectures = append(issue.NodeArchitectures, arch)
+		}
+	}
+
+	sort.Strings(workloads)
+	issues := []imageArchitectureIssue{}
+	for _, key := range workloads {
+		issue := issuesByWorkload[key]
+		sort.Strings(issue.Pods)
+		sort.Strings(issue.NodeArchitectures)
+		issues = append(issues, *issue)
+	}
+
+	return issues
+}
+
+// podsWithExecFormatErrors returns the pods, keyed by namespace/name, that have a container
+// which failed with an exec format error, either in its status or in its collected logs
+func podsWithExecFormatErrors(pods []corev1.Pod, findFiles getChildCollectedFileContents) (map[string]bool, error) {
+	found := map[string]bool{}
+	for _, pod := range pods {
+		if !hasFailingContainer(pod) {
+			continue
+		}
+
+		if containerStatusesMention(pod, execFormatError) {
+			found[podKey(pod)] = true
+			continue
+		}
+
+		logsGlob := filepath.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_PODS_LOGS, pod.Namespace, pod.Name, "*.log")
+		logs, err := findFiles(logsGlob, []string{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read logs for pod %s/%s", pod.Namespace, pod.Name)
+		}
+		for _, log := range logs {
+			if bytes.Contains(log, []byte(execFormatError)) {
+				found[podKey(pod)] = true
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+func hasFailingContainer(pod corev1.Pod) bool {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.RestartCount > 0 || status.LastTerminationState.Terminated != nil {
+			return true
+		}
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+			return true
+		}
+		if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func containerStatusesMention(pod corev1.Pod, text string) bool {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		messages := []string{}
+		if status.State.Waiting != nil {
+			messages = append(messages, status.State.Waiting.Message)
+		}
+		if status.State.Terminated != nil {
+			messages = append(messages, status.State.Terminated.Message)
+		}
+		if status.LastTerminationState.Terminated != nil {
+			messages = append(messages, status.LastTerminationState.Terminated.Message)
+		}
+		for _, message := range messages {
+			if strings.Contains(message, text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasArchitectureConstraint reports whether a pod can only be scheduled to nodes of specific
+// architectures. Every required node affinity term must select on the architecture label,
+// otherwise the scheduler may pick a term that does not.
+func hasArchitectureConstraint(spec corev1.PodSpec) bool {
+	for _, label := range architectureLabels {
+		if _, ok := spec.NodeSelector[label]; ok {
+			return true
+		}
+	}
+
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return false
+	}
+
+	terms := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return false
+	}
+	for _, term := range terms {
+		constrained := false
+		for _, expression := range term.MatchExpressions {
+			if slices.Contains(architectureLabels, expression.Key) {
+				constrained = true
+				break
+			}
+		}
+		if !constrained {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeArchitecture(node corev1.Node) string {
+	if node.Status.NodeInfo.Architecture != "" {
+		return node.Status.NodeInfo.Architecture
+	}
+	for _, label := range architectureLabels {
+		if arch := node.Labels[label]; arch != "" {
+			return arch
+		}
+	}
+	return ""
+}
+
+// podWorkload returns the kind and name of the workload that manages a pod. Pods owned by a
+// ReplicaSet created by a Deployment are attributed to the Deployment.
+func podWorkload(pod corev1.Pod) (string, string) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "Pod", pod.Name
+	}
+
+	if owner.Kind == "ReplicaSet" {
+		if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			return "Deployment", strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+	}
+
+	return owner.Kind, owner.Name
+}
+
+func podKey(pod corev1.Pod) string {
+	return fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+}
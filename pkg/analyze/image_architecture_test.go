@@ -0,0 +1,150 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeImageArchitecture(t *testing.T) {
+	mixedArchFiles := map[string][]byte{
+		"cluster-resources/nodes.json":                                    []byte(imageArchitectureNodes),
+		"cluster-resources/pods/default.json":                             []byte(imageArchitecturePods),
+		"cluster-resources/pods/logs/default/api-7d9c8b6f4-x2x9k/api.log": []byte(imageArchitectureAPILogs),
+	}
+
+	singleArchNodes := []byte(`{
+		"kind": "NodeList",
+		"apiVersion": "v1",
+		"items": [
+			{"metadata": {"name": "node-amd64-1"}, "status": {"nodeInfo": {"architecture": "amd64"}}}
+		]
+	}`)
+
+	apiReference := &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "api"}
+	webReference := &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "web"}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.ImageArchitectureAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "mixed architecture cluster with default outcomes",
+			analyzer: troubleshootv1beta2.ImageArchitectureAnalyze{},
+			files:    mixedArchFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Image Architecture",
+					Message:        `Deployment default/api is failing with "exec format error" on arm64 nodes, its image does not support that architecture. Add a nodeSelector on kubernetes.io/arch to schedule it on a supported architecture, or publish a multi-arch image.`,
+					InvolvedObject: apiReference,
+				},
+				{
+					IsWarn:         true,
+					Title:          "Image Architecture",
+					Message:        "Deployment default/web has no nodeSelector or node affinity on kubernetes.io/arch, but the cluster has amd64 and arm64 nodes. Unless its images are multi-arch, pin it to a supported architecture.",
+					InvolvedObject: webReference,
+				},
+			},
+		},
+		{
+			name: "templated outcomes",
+			analyzer: troubleshootv1beta2.ImageArchitectureAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Kind }} {{ .Name }} pods {{ .Pods }} on {{ .NodeArchitectures }} exec format error: {{ .ExecFormatError }}",
+						},
+					},
+				},
+			},
+			files: mixedArchFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Image Architecture",
+					Message:        "Deployment api pods [api-7d9c8b6f4-x2x9k] on [arm64] exec format error: true",
+					InvolvedObject: apiReference,
+				},
+				{
+					IsFail:         true,
+					Title:          "Image Architecture",
+					Message:        "Deployment web pods [web-5f6d7c9b8-k7p2q] on [amd64] exec format error: false",
+					InvolvedObject: webReference,
+				},
+			},
+		},
+		{
+			name: "single architecture cluster passes",
+			analyzer: troubleshootv1beta2.ImageArchitectureAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "architecture mismatch",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "no architecture mismatches",
+						},
+					},
+				},
+			},
+			files: map[string][]byte{
+				"cluster-resources/nodes.json":        singleArchNodes,
+				"cluster-resources/pods/default.json": []byte(imageArchitecturePods),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Image Architecture",
+					Message: "no architecture mismatches",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeImageArchitecture{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
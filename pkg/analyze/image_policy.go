@@ -0,0 +1,185 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	imagePolicyAlwaysMutable = "AlwaysMutableTag"
+	imagePolicyStaleMutable  = "StaleMutableTag"
+	imagePolicyMissingDigest = "MissingDigest"
+)
+
+// defaultMutableTags are tags that are expected to be moved to newer images
+var defaultMutableTags = []string{"latest", "main", "master", "dev", "develop", "edge", "nightly", "stable"}
+
+type AnalyzeImagePolicy struct {
+	analyzer *troubleshootv1beta2.ImagePolicyAnalyze
+}
+
+// imagePolicyIssue is the template data available to outcome messages, one is reported for each
+// container of a workload with a risky image reference
+type imagePolicyIssue struct {
+	Namespace  string
+	Kind       string
+	Name       string
+	Container  string
+	Image      string
+	PullPolicy string
+	// Tag is the image tag, latest when the image has no tag
+	Tag string
+	// Risk is one of AlwaysMutableTag, StaleMutableTag or MissingDigest
+	Risk           string
+	Recommendation string
+}
+
+func (a *AnalyzeImagePolicy) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Image Pull Policy"
+}
+
+func (a *AnalyzeImagePolicy) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeImagePolicy) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	pods, err := readCollectedPods(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	mutableTags := a.analyzer.MutableTags
+	if len(mutableTags) == 0 {
+		mutableTags = defaultMutableTags
+	}
+
+	issues := findImagePolicyIssues(pods, mutableTags, a.analyzer.RequireDigest)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: defaultImagePolicyMessage(issue),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			Kind:      issue.Kind,
+			Namespace: issue.Namespace,
+			Name:      issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "All container images are pinned to immutable references",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultImagePolicyMessage(issue imagePolicyIssue) string {
+	container := fmt.Sprintf("Container %s of %s %s/%s", issue.Container, issue.Kind, issue.Namespace, issue.Name)
+	switch issue.Risk {
+	case imagePolicyAlwaysMutable:
+		return fmt.Sprintf("%s pulls %s with imagePullPolicy Always, each restart may run a different image. %s.", container, issue.Image, issue.Recommendation)
+	case imagePolicyStaleMutable:
+		return fmt.Sprintf("%s uses the mutable tag %s with imagePullPolicy %s, nodes may keep running a stale image. %s.", container, issue.Tag, issue.PullPolicy, issue.Recommendation)
+	default:
+		return fmt.Sprintf("%s image %s is not referenced by digest. %s.", container, issue.Image, issue.Recommendation)
+	}
+}
+
+// findImagePolicyIssues checks the init and regular containers of every pod. Pods of the same
+// workload are expected to share images, each workload container is only reported once.
+func findImagePolicyIssues(pods []corev1.Pod, mutableTags []string, requireDigest bool) []imagePolicyIssue {
+	sort.Slice(pods, func(i, j int) bool {
+		return podKey(pods[i]) < podKey(pods[j])
+	})
+
+	issues := []imagePolicyIssue{}
+	seen := map[string]bool{}
+	for _, pod := range pods {
+		kind, name := podWorkload(pod)
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, container := range containers {
+			key := fmt.Sprintf("%s/%s/%s/%s", pod.Namespace, kind, name, container.Name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			tag, hasDigest := parseImageTag(container.Image)
+			issue := imagePolicyIssue{
+				Namespace:  pod.Namespace,
+				Kind:       kind,
+				Name:       name,
+				Container:  container.Name,
+				Image:      container.Image,
+				PullPolicy: string(container.ImagePullPolicy),
+				Tag:        tag,
+			}
+
+			mutable := !hasDigest && slices.Contains(mutableTags, tag)
+			switch {
+			case mutable && container.ImagePullPolicy == corev1.PullAlways:
+				issue.Risk = imagePolicyAlwaysMutable
+				issue.Recommendation = "Pin the image to a version tag or digest"
+			case mutable:
+				issue.Risk = imagePolicyStaleMutable
+				issue.Recommendation = "Pin the image to a version tag or digest, or set imagePullPolicy to Always"
+			case requireDigest && !hasDigest:
+				issue.Risk = imagePolicyMissingDigest
+				issue.Recommendation = fmt.Sprintf("Reference the image as %s@sha256:<digest>", strings.TrimSuffix(container.Image, ":"+tag))
+			default:
+				continue
+			}
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+// parseImageTag returns the tag of an image reference, latest when it has none, and whether the
+// reference includes a digest
+func parseImageTag(image string) (string, bool) {
+	name, _, hasDigest := strings.Cut(image, "@")
+
+	lastSegment := name
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		lastSegment = name[i+1:]
+	}
+	if _, tag, ok := strings.Cut(lastSegment, ":"); ok {
+		return tag, hasDigest
+	}
+	return "latest", hasDigest
+}
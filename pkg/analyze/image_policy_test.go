@@ -0,0 +1,158 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeImagePolicy(t *testing.T) {
+	workloadReference := func(kind, namespace, name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{Kind: kind, Namespace: namespace, Name: name}
+	}
+
+	files := map[string][]byte{
+		"cluster-resources/pods/default.json":    []byte(imagePolicyDefault),
+		"cluster-resources/pods/monitoring.json": []byte(imagePolicyMonitoring),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.ImagePolicyAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "mutable tags are reported once per workload container",
+			analyzer: troubleshootv1beta2.ImagePolicyAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Image Pull Policy",
+					Message:        "Container api of Deployment default/api pulls registry.example.com:5000/acme/api:latest with imagePullPolicy Always, each restart may run a different image. Pin the image to a version tag or digest.",
+					InvolvedObject: workloadReference("Deployment", "default", "api"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "Image Pull Policy",
+					Message:        "Container wait-for-db of StatefulSet default/worker uses the mutable tag latest with imagePullPolicy IfNotPresent, nodes may keep running a stale image. Pin the image to a version tag or digest, or set imagePullPolicy to Always.",
+					InvolvedObject: workloadReference("StatefulSet", "default", "worker"),
+				},
+			},
+		},
+		{
+			name: "pinned versions pass",
+			analyzer: troubleshootv1beta2.ImagePolicyAnalyze{
+				Namespaces: []string{"monitoring"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Image Pull Policy",
+					Message: "All container images are pinned to immutable references",
+				},
+			},
+		},
+		{
+			name: "digests are required",
+			analyzer: troubleshootv1beta2.ImagePolicyAnalyze{
+				Namespaces:    []string{"monitoring"},
+				RequireDigest: true,
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Image Pull Policy",
+					Message:        "Container redis of DaemonSet monitoring/cache image redis:7.2.4 is not referenced by digest. Reference the image as redis@sha256:<digest>.",
+					InvolvedObject: workloadReference("DaemonSet", "monitoring", "cache"),
+				},
+			},
+		},
+		{
+			name: "custom mutable tags and outcomes",
+			analyzer: troubleshootv1beta2.ImagePolicyAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Image Hygiene",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Name }}/{{ .Container }}: {{ .Risk }} ({{ .Tag }}, {{ .PullPolicy }})",
+						},
+					},
+				},
+				MutableTags: []string{"v1.30.1"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Image Hygiene",
+					Message:        "api/envoy: StaleMutableTag (v1.30.1, IfNotPresent)",
+					InvolvedObject: workloadReference("Deployment", "default", "api"),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeImagePolicy{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
+
+func Test_parseImageTag(t *testing.T) {
+	tests := []struct {
+		image      string
+		wantTag    string
+		wantDigest bool
+	}{
+		{image: "busybox", wantTag: "latest"},
+		{image: "nginx:1.25", wantTag: "1.25"},
+		{image: "registry.example.com:5000/acme/api", wantTag: "latest"},
+		{image: "registry.example.com:5000/acme/api:v2", wantTag: "v2"},
+		{image: "acme/worker@sha256:4d3c2b1a", wantTag: "latest", wantDigest: true},
+		{image: "acme/worker:v1@sha256:4d3c2b1a", wantTag: "v1", wantDigest: true},
+	}
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			tag, hasDigest := parseImageTag(test.image)
+			assert.Equal(t, test.wantTag, tag)
+			assert.Equal(t, test.wantDigest, hasDigest)
+		})
+	}
+}
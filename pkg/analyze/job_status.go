@@ -153,13 +153,14 @@ func jobStatus(outcomes []*troubleshootv1beta2.Outcome, job *batchv1.Job) (*Anal
 		IconKey: "kubernetes_deployment_status",                                                  // TODO: needs new icon
 		IconURI: "https://troubleshoot.sh/images/analyzer-icons/deployment-status.svg?w=17&h=17", // TODO: needs new icon
 	}
+	templateContext := outcomeTemplateContext(job.Name, job.Namespace, job.Status)
 
 	// ordering from the spec is important, the first one that matches returns
 	for _, outcome := range outcomes {
 		if outcome.Fail != nil {
 			if outcome.Fail.When == "" {
 				result.IsFail = true
-				result.Message = outcome.Fail.Message
+				result.Message = renderOutcomeMessage(outcome.Fail.Message, templateContext)
 				result.URI = outcome.Fail.URI
 
 				return result, nil
@@ -172,7 +173,7 @@ func jobStatus(outcomes []*troubleshootv1beta2.Outcome, job *batchv1.Job) (*Anal
 
 			if match {
 				result.IsFail = true
-				result.Message = outcome.Fail.Message
+				result.Message = renderOutcomeMessage(outcome.Fail.Message, templateContext)
 				result.URI = outcome.Fail.URI
 
 				return result, nil
@@ -180,7 +181,7 @@ func jobStatus(outcomes []*troubleshootv1beta2.Outcome, job *batchv1.Job) (*Anal
 		} else if outcome.Warn != nil {
 			if outcome.Warn.When == "" {
 				result.IsWarn = true
-				result.Message = outcome.Warn.Message
+				result.Message = renderOutcomeMessage(outcome.Warn.Message, templateContext)
 				result.URI = outcome.Warn.URI
 
 				return result, nil
@@ -193,7 +194,7 @@ func jobStatus(outcomes []*troubleshootv1beta2.Outcome, job *batchv1.Job) (*Anal
 
 			if match {
 				result.IsWarn = true
-				result.Message = outcome.Warn.Message
+				result.Message = renderOutcomeMessage(outcome.Warn.Message, templateContext)
 				result.URI = outcome.Warn.URI
 
 				return result, nil
@@ -201,7 +202,7 @@ func jobStatus(outcomes []*troubleshootv1beta2.Outcome, job *batchv1.Job) (*Anal
 		} else if outcome.Pass != nil {
 			if outcome.Pass.When == "" {
 				result.IsPass = true
-				result.Message = outcome.Pass.Message
+				result.Message = renderOutcomeMessage(outcome.Pass.Message, templateContext)
 				result.URI = outcome.Pass.URI
 
 				return result, nil
@@ -214,7 +215,7 @@ func jobStatus(outcomes []*troubleshootv1beta2.Outcome, job *batchv1.Job) (*Anal
 
 			if match {
 				result.IsPass = true
-				result.Message = outcome.Pass.Message
+				result.Message = renderOutcomeMessage(outcome.Pass.Message, templateContext)
 				result.URI = outcome.Pass.URI
 
 				return result, nil
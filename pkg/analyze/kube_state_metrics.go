@@ -0,0 +1,236 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const kubeStateMetricsDefaultRestartsPerHour = 1
+
+type AnalyzeKubeStateMetrics struct {
+	analyzer *troubleshootv1beta2.KubeStateMetricsAnalyze
+}
+
+// kubeStateMetricsIssue is the template data available to outcome messages
+type kubeStateMetricsIssue struct {
+	// Kind is Deployment or Pod
+	Kind      string
+	Namespace string
+	Name      string
+	// Problem describes the generation mismatch or the restart rate
+	Problem string
+}
+
+func (a *AnalyzeKubeStateMetrics) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "kube-state-metrics"
+}
+
+func (a *AnalyzeKubeStateMetrics) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeKubeStateMetrics) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	metrics, err := getFile(filepath.Join(constants.KUBE_STATE_METRICS_DIR, "metrics.txt"))
+	if err != nil {
+		return []*AnalyzeResult{{
+			Title:   a.Title(),
+			IsWarn:  true,
+			Message: kubeStateMetricsUnreachableMessage(getFile),
+			Strict:  a.analyzer.Strict.BoolOrDefaultFalse(),
+		}}, nil
+	}
+
+	samples, err := parsePrometheusText(metrics)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse kube-state-metrics output")
+	}
+
+	restartsPerHour := float64(kubeStateMetricsDefaultRestartsPerHour)
+	if a.analyzer.RestartsPerHour != "" {
+		restartsPerHour, err = strconv.ParseFloat(a.analyzer.RestartsPerHour, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse restartsPerHour %q", a.analyzer.RestartsPerHour)
+		}
+	}
+
+	// restart rates are measured up to when the metrics were scraped
+	scrapedAt := time.Now()
+	scrapeFile, err := getFile(filepath.Join(constants.KUBE_STATE_METRICS_DIR, "scrape.json"))
+	if err == nil {
+		scrape := collect.KubeStateMetricsScrape{}
+		if err := json.Unmarshal(scrapeFile, &scrape); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal kube-state-metrics scrape")
+		}
+		scrapedAt = scrape.ScrapedAt
+	}
+
+	issues := findKubeStateMetricsIssues(samples, a.analyzer.Namespaces, restartsPerHour, scrapedAt)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: issue.Problem,
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       issue.Kind,
+			Namespace:  issue.Namespace,
+			Name:       issue.Name,
+		}
+		if issue.Kind == "Deployment" {
+			result.InvolvedObject.APIVersion = "apps/v1"
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "All deployments are up to date and no pods are restarting frequently",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// kubeStateMetricsUnreachableMessage explains why no metrics were collected, using the errors
+// saved by the collector when there are any
+func kubeStateMetricsUnreachableMessage(getFile getCollectedFileContents) string {
+	errorsFile, err := getFile(filepath.Join(constants.KUBE_STATE_METRICS_DIR, "errors.json"))
+	if err != nil {
+		return "kube-state-metrics was not reachable, no metrics were collected"
+	}
+
+	collectErrors := []string{}
+	if err := json.Unmarshal(errorsFile, &collectErrors); err != nil || len(collectErrors) == 0 {
+		return "kube-state-metrics was not reachable, no metrics were collected"
+	}
+	return fmt.Sprintf("kube-state-metrics was not reachable: %s", strings.Join(collectErrors, ", "))
+}
+
+// findKubeStateMetricsIssues returns the deployments with a generation mismatch followed by the
+// pods restarting more than restartsPerHour, each ordered by namespace and name. Pods younger than
+// an hour are measured over an hour so a single early restart is not reported.
+func findKubeStateMetricsIssues(samples []prometheusSample, namespaces []string, restartsPerHour float64, scrapedAt time.Time) []kubeStateMetricsIssue {
+	type objectKey struct {
+		namespace string
+		name      string
+	}
+
+	generations := map[objectKey]float64{}
+	observedGenerations := map[objectKey]float64{}
+	restarts := map[objectKey]float64{}
+	created := map[objectKey]float64{}
+
+	for _, sample := range samples {
+		namespace := sample.Labels["namespace"]
+		if len(namespaces) > 0 && !slices.Contains(namespaces, namespace) {
+			continue
+		}
+		deployment := objectKey{namespace, sample.Labels["deployment"]}
+		pod := objectKey{namespace, sample.Labels["pod"]}
+
+		switch sample.Name {
+		case "kube_deployment_metadata_generation":
+			generations[deployment] = sample.Value
+		case "kube_deployment_status_observed_generation":
+			observedGenerations[deployment] = sample.Value
+		case "kube_pod_container_status_restarts_total":
+			restarts[pod] += sample.Value
+		case "kube_pod_created":
+			created[pod] = sample.Value
+		}
+	}
+
+	sortKeys := func(keys []objectKey) {
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].namespace != keys[j].namespace {
+				return keys[i].namespace < keys[j].namespace
+			}
+			return keys[i].name < keys[j].name
+		})
+	}
+
+	issues := []kubeStateMetricsIssue{}
+
+	deployments := []objectKey{}
+	for deployment, generation := range generations {
+		observed, ok := observedGenerations[deployment]
+		if ok && observed < generation {
+			deployments = append(deployments, deployment)
+		}
+	}
+	sortKeys(deployments)
+	for _, deployment := range deployments {
+		issues = append(issues, kubeStateMetricsIssue{
+			Kind:      "Deployment",
+			Namespace: deployment.namespace,
+			Name:      deployment.name,
+			Problem: fmt.Sprintf("Deployment %s/%s is at generation %.0f but the controller has only observed generation %.0f",
+				deployment.namespace, deployment.name, generations[deployment], observedGenerations[deployment]),
+		})
+	}
+
+	pods := []objectKey{}
+	rates := map[objectKey]float64{}
+	hours := map[objectKey]float64{}
+	for pod, count := range restarts {
+		createdAt, ok := created[pod]
+		if !ok || count == 0 {
+			continue
+		}
+		age := scrapedAt.Sub(time.Unix(int64(createdAt), 0)).Hours()
+		hours[pod] = age
+		rates[pod] = count / max(age, 1)
+		if rates[pod] > restartsPerHour {
+			pods = append(pods, pod)
+		}
+	}
+	sortKeys(pods)
+	for _, pod := range pods {
+		issues = append(issues, kubeStateMetricsIssue{
+			Kind:      "Pod",
+			Namespace: pod.namespace,
+			Name:      pod.name,
+			Problem: fmt.Sprintf("Pod %s/%s restarted %.0f times in %.1f hours, %.1f restarts per hour",
+				pod.namespace, pod.name, restarts[pod], hours[pod], rates[pod]),
+		})
+	}
+
+	return issues
+}
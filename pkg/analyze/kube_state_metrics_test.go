@@ -0,0 +1,197 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeKubeStateMetrics(t *testing.T) {
+	collected := map[string][]byte{
+		"metrics/kube-state/metrics.txt": []byte(kubeStateMetricsMetrics),
+		"metrics/kube-state/scrape.json": []byte(kubeStateMetricsScrape),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.KubeStateMetricsAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "generation mismatch and restarting pods",
+			analyzer: troubleshootv1beta2.KubeStateMetricsAnalyze{},
+			files:    collected,
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "kube-state-metrics",
+					Message: "Deployment default/api is at generation 5 but the controller has only observed generation 4",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "api",
+					},
+				},
+				{
+					IsWarn:  true,
+					Title:   "kube-state-metrics",
+					Message: "Pod default/api-7d9f8b6c5-x2vbn restarted 7 times in 3.0 hours, 2.3 restarts per hour",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "api-7d9f8b6c5-x2vbn",
+					},
+				},
+				{
+					IsWarn:  true,
+					Title:   "kube-state-metrics",
+					Message: "Pod monitoring/prometheus-0 restarted 25 times in 10.0 hours, 2.5 restarts per hour",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1", Kind: "Pod", Namespace: "monitoring", Name: "prometheus-0",
+					},
+				},
+			},
+		},
+		{
+			name: "namespaces, threshold and outcomes",
+			analyzer: troubleshootv1beta2.KubeStateMetricsAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Workload Health",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Kind }} {{ .Namespace }}/{{ .Name }} is unhealthy",
+						},
+					},
+				},
+				Namespaces:      []string{"default", "kube-system"},
+				RestartsPerHour: "3",
+			},
+			files: collected,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "Workload Health",
+					Message: "Deployment default/api is unhealthy",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "api",
+					},
+				},
+			},
+		},
+		{
+			name: "healthy namespace",
+			analyzer: troubleshootv1beta2.KubeStateMetricsAnalyze{
+				Namespaces: []string{"kube-system"},
+			},
+			files: collected,
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "kube-state-metrics",
+					Message: "All deployments are up to date and no pods are restarting frequently",
+				},
+			},
+		},
+		{
+			name:     "not reachable",
+			analyzer: troubleshootv1beta2.KubeStateMetricsAnalyze{},
+			files: map[string][]byte{
+				"metrics/kube-state/errors.json": []byte(`["no kube-state-metrics service was found"]`),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "kube-state-metrics",
+					Message: "kube-state-metrics was not reachable: no kube-state-metrics service was found",
+				},
+			},
+		},
+		{
+			name:     "not collected",
+			analyzer: troubleshootv1beta2.KubeStateMetricsAnalyze{},
+			files:    map[string][]byte{},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "kube-state-metrics",
+					Message: "kube-state-metrics was not reachable, no metrics were collected",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.Errorf("%s was not collected", n)
+			}
+
+			a := &AnalyzeKubeStateMetrics{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, nil)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
+
+func Test_parsePrometheusText(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []prometheusSample
+		wantErr bool
+	}{
+		{
+			name: "labels, comments and timestamps",
+			input: `# HELP kube_pod_info Information about pod.
+# TYPE kube_pod_info gauge
+kube_pod_info{namespace="default",pod="api",node="node-1",} 1 1792152000000
+up 1
+
+kube_pod_labels{namespace="default", label_app="say \"hi\"\\bye"} +Inf
+`,
+			want: []prometheusSample{
+				{Name: "kube_pod_info", Labels: map[string]string{"namespace": "default", "pod": "api", "node": "node-1"}, Value: 1},
+				{Name: "up", Labels: map[string]string{}, Value: 1},
+				{Name: "kube_pod_labels", Labels: map[string]string{"namespace": "default", "label_app": `say "hi"\bye`}, Value: math.Inf(1)},
+			},
+		},
+		{
+			name:    "missing value",
+			input:   "kube_pod_info{namespace=\"default\"}\n",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated label",
+			input:   "kube_pod_info{namespace=\"default} 1\n",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parsePrometheusText([]byte(test.input))
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
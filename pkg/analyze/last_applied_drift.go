@@ -0,0 +1,329 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const lastAppliedNotSet = "<not set>"
+
+type AnalyzeLastAppliedDrift struct {
+	analyzer *troubleshootv1beta2.LastAppliedDriftAnalyze
+}
+
+// lastAppliedDriftIssue is the template data available to outcome messages, one is reported for
+// each object with fields that differ from their last applied value
+type lastAppliedDriftIssue struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Fields    []lastAppliedDriftField
+	// Paths is the comma separated list of the paths of the drifted fields
+	Paths string
+}
+
+type lastAppliedDriftField struct {
+	Path    string
+	Applied string
+	Live    string
+}
+
+func (a *AnalyzeLastAppliedDrift) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Last Applied Configuration Drift"
+}
+
+func (a *AnalyzeLastAppliedDrift) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeLastAppliedDrift) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	configurations, err := readCollectedLastApplied(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []lastAppliedDriftIssue{}
+	for _, configuration := range configurations {
+		fields := diffLastApplied("", configuration.LastApplied, configuration.Live)
+		fields = slices.DeleteFunc(fields, func(field lastAppliedDriftField) bool {
+			return isIgnoredLastAppliedField(field.Path, a.analyzer.IgnoreFields)
+		})
+		if len(fields) == 0 {
+			continue
+		}
+
+		paths := []string{}
+		for _, field := range fields {
+			paths = append(paths, field.Path)
+		}
+		issues = append(issues, lastAppliedDriftIssue{
+			Kind:      configuration.Kind,
+			Namespace: configuration.Namespace,
+			Name:      configuration.Name,
+			Fields:    fields,
+			Paths:     strings.Join(paths, ", "),
+		})
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: defaultLastAppliedDriftMessage(issue),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       issue.Kind,
+			Namespace:  issue.Namespace,
+			Name:       issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "Live objects match their last applied configuration",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultLastAppliedDriftMessage(issue lastAppliedDriftIssue) string {
+	changes := []string{}
+	for _, field := range issue.Fields {
+		changes = append(changes, fmt.Sprintf("%s is %s but %s was applied", field.Path, field.Live, field.Applied))
+	}
+	return fmt.Sprintf("%s %s/%s was changed outside of kubectl apply: %s.", issue.Kind, issue.Namespace, issue.Name, strings.Join(changes, "; "))
+}
+
+func isIgnoredLastAppliedField(path string, ignoreFields []string) bool {
+	for _, ignored := range ignoreFields {
+		if path == ignored || strings.HasPrefix(path, ignored+".") || strings.HasPrefix(path, ignored+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// diffLastApplied returns the fields set in applied whose live value is different. Fields only
+// set in live, such as defaults filled in by the apiserver, are not compared. Lists of objects
+// with a name, like containers and env, are matched by name, other lists by index.
+func diffLastApplied(path string, applied, live interface{}) []lastAppliedDriftField {
+	switch appliedValue := applied.(type) {
+	case nil:
+		return nil
+
+	case map[string]interface{}:
+		if len(appliedValue) == 0 && live == nil {
+			return nil
+		}
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return []lastAppliedDriftField{newLastAppliedDriftField(path, applied, live)}
+		}
+
+		keys := []string{}
+		for key := range appliedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fields := []lastAppliedDriftField{}
+		for _, key := range keys {
+			fields = append(fields, diffLastApplied(joinLastAppliedPath(path, key), appliedValue[key], liveMap[key])...)
+		}
+		return fields
+
+	case []interface{}:
+		if len(appliedValue) == 0 && live == nil {
+			return nil
+		}
+		liveList, ok := live.([]interface{})
+		if !ok {
+			return []lastAppliedDriftField{newLastAppliedDriftField(path, applied, live)}
+		}
+
+		if names, ok := lastAppliedListNames(appliedValue); ok {
+			liveByName := map[string]interface{}{}
+			if liveNames, ok := lastAppliedListNames(liveList); ok {
+				for i, name := range liveNames {
+					liveByName[name] = liveList[i]
+				}
+			}
+
+			fields := []lastAppliedDriftField{}
+			for i, name := range names {
+				fields = append(fields, diffLastApplied(fmt.Sprintf("%s[name=%s]", path, name), appliedValue[i], liveByName[name])...)
+			}
+			return fields
+		}
+
+		if len(appliedValue) != len(liveList) {
+			return []lastAppliedDriftField{newLastAppliedDriftField(path, applied, live)}
+		}
+		fields := []lastAppliedDriftField{}
+		for i := range appliedValue {
+			fields = append(fields, diffLastApplied(fmt.Sprintf("%s[%d]", path, i), appliedValue[i], liveList[i])...)
+		}
+		return fields
+
+	default:
+		if lastAppliedValuesEqual(applied, live) {
+			return nil
+		}
+		return []lastAppliedDriftField{newLastAppliedDriftField(path, applied, live)}
+	}
+}
+
+// lastAppliedListNames returns the name of every element when all elements are objects with a name
+func lastAppliedListNames(list []interface{}) ([]string, bool) {
+	if len(list) == 0 {
+		return nil, false
+	}
+	names := []string{}
+	for _, element := range list {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	return names, true
+}
+
+// lastAppliedValuesEqual compares scalars, treating numbers and strings with the same value, e.g.
+// cpu: 1 and "1", and equal resource quantities, e.g. "0.5" and "500m", as the same
+func lastAppliedValuesEqual(applied, live interface{}) bool {
+	if reflect.DeepEqual(applied, live) {
+		return true
+	}
+	if live == nil {
+		return false
+	}
+
+	appliedString, liveString := formatLastAppliedValue(applied), formatLastAppliedValue(live)
+	if appliedString == liveString {
+		return true
+	}
+
+	appliedQuantity, err := resource.ParseQuantity(appliedString)
+	if err != nil {
+		return false
+	}
+	liveQuantity, err := resource.ParseQuantity(liveString)
+	if err != nil {
+		return false
+	}
+	return appliedQuantity.Cmp(liveQuantity) == 0
+}
+
+func newLastAppliedDriftField(path string, applied, live interface{}) lastAppliedDriftField {
+	return lastAppliedDriftField{
+		Path:    path,
+		Applied: formatLastAppliedValue(applied),
+		Live:    formatLastAppliedValue(live),
+	}
+}
+
+func formatLastAppliedValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return lastAppliedNotSet
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	}
+}
+
+func joinLastAppliedPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// readCollectedLastApplied returns the last applied configurations saved by the cluster resources
+// collector, sorted by namespace, kind and name
+func readCollectedLastApplied(findFiles getChildCollectedFileContents, namespaces []string) ([]collect.LastAppliedConfiguration, error) {
+	collected, err := findFiles(filepath.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_LAST_APPLIED, "*.json"), []string{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read collected last applied configurations")
+	}
+
+	configurations := []collect.LastAppliedConfiguration{}
+	for fileName, fileContent := range collected {
+		namespace := strings.TrimSuffix(filepath.Base(fileName), ".json")
+		if len(namespaces) > 0 && !slices.Contains(namespaces, namespace) {
+			continue
+		}
+
+		var fileConfigurations []collect.LastAppliedConfiguration
+		if err := json.Unmarshal(fileContent, &fileConfigurations); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", fileName)
+		}
+		configurations = append(configurations, fileConfigurations...)
+	}
+
+	sort.SliceStable(configurations, func(i, j int) bool {
+		a, b := configurations[i], configurations[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	return configurations, nil
+}
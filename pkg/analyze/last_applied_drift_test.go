@@ -0,0 +1,143 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeLastAppliedDrift(t *testing.T) {
+	workloadReference := func(kind, name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "apps/v1", Kind: kind, Namespace: "default", Name: name}
+	}
+
+	files := map[string][]byte{
+		"cluster-resources/last-applied/default.json": []byte(lastAppliedDefault),
+		"cluster-resources/last-applied/empty.json":   []byte("[]"),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.LastAppliedDriftAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "drifted fields are reported per workload",
+			analyzer: troubleshootv1beta2.LastAppliedDriftAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Last Applied Configuration Drift",
+					Message:        `DaemonSet default/node-exporter was changed outside of kubectl apply: spec.template.spec.containers[name=node-exporter].args is ["--path.rootfs=/host"] but ["--path.rootfs=/host","--web.listen-address=:9100"] was applied.`,
+					InvolvedObject: workloadReference("DaemonSet", "node-exporter"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "Last Applied Configuration Drift",
+					Message:        "Deployment default/api was changed outside of kubectl apply: spec.replicas is 5 but 3 was applied; spec.template.spec.containers[name=api].env[name=LOG_LEVEL].value is debug but info was applied; spec.template.spec.containers[name=api].image is registry.example.com/api:1.9.1 but registry.example.com/api:1.9.0 was applied.",
+					InvolvedObject: workloadReference("Deployment", "api"),
+				},
+			},
+		},
+		{
+			name: "ignored fields are not reported",
+			analyzer: troubleshootv1beta2.LastAppliedDriftAnalyze{
+				IgnoreFields: []string{
+					"spec.replicas",
+					"spec.template.spec.containers[name=node-exporter]",
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Last Applied Configuration Drift",
+					Message:        "Deployment default/api was changed outside of kubectl apply: spec.template.spec.containers[name=api].env[name=LOG_LEVEL].value is debug but info was applied; spec.template.spec.containers[name=api].image is registry.example.com/api:1.9.1 but registry.example.com/api:1.9.0 was applied.",
+					InvolvedObject: workloadReference("Deployment", "api"),
+				},
+			},
+		},
+		{
+			name: "custom outcomes are templated with the drift",
+			analyzer: troubleshootv1beta2.LastAppliedDriftAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Manual Changes",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Kind }} {{ .Name }}: {{ .Paths }}",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "no drift",
+						},
+					},
+				},
+				IgnoreFields: []string{"spec.template"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Manual Changes",
+					Message:        "Deployment api: spec.replicas",
+					InvolvedObject: workloadReference("Deployment", "api"),
+				},
+			},
+		},
+		{
+			name: "namespace without drift passes",
+			analyzer: troubleshootv1beta2.LastAppliedDriftAnalyze{
+				Namespaces: []string{"empty"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Last Applied Configuration Drift",
+					Message: "Live objects match their last applied configuration",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeLastAppliedDrift{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package analyzer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+)
+
+const (
+	logTimestampsDefaultFileName = "*/*.log"
+	// logTimestampsSampleLines is the number of lines with a timestamp read from each file
+	logTimestampsSampleLines = 100
+)
+
+type AnalyzeLogTimestamps struct {
+	analyzer *troubleshootv1beta2.LogTimestampsAnalyze
+}
+
+// logTimestampsIssue is the template data available to outcome messages
+type logTimestampsIssue struct {
+	// Component is the path of the log file relative to the collector, without its extension
+	Component string
+	// Format is the detected timestamp format, one of ISO 8601, common log format, date, klog or
+	// syslog
+	Format string
+	// Timezone is the zone or offset of the timestamps, empty when they have none
+	Timezone string
+	// Example is a timestamp from the file
+	Example string
+	Problem string
+}
+
+// logTimestampFormat matches a timestamp at the start of a line, or anywhere in the line when
+// anywhere is set. Patterns have a timestamp and a zone group, the zone can be empty.
+type logTimestampFormat struct {
+	name     string
+	pattern  *regexp.Regexp
+	anywhere bool
+}
+
+const logTimestampZoneNames = `UTC|GMT|EST|EDT|CST|CDT|MST|MDT|PST|PDT|AKST|AKDT|HST|WET|WEST|CET|CEST|EET|EEST|MSK|IST|SGT|HKT|JST|KST|AEST|AEDT|NZST|NZDT|BST`
+
+var logTimestampFormats = []logTimestampFormat{
+	{
+		name:     "ISO 8601",
+		pattern:  regexp.MustCompile(`(?P<timestamp>\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?P<zone>Z|[+-]\d{2}:?\d{2}| (?:` + logTimestampZoneNames + `))?)(?:[^\w:.,+-]|$)`),
+		anywhere: true,
+	},
+	{
+		name:    "common log format",
+		pattern: regexp.MustCompile(`^\S+ \S+ \S+ \[(?P<timestamp>\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} (?P<zone>[+-]\d{4}))\]`),
+	},
+	{
+		name:    "date",
+		pattern: regexp.MustCompile(`^(?P<timestamp>[A-Z][a-z]{2} [A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2} (?P<zone>[A-Z]{3,5}) \d{4})(?:\s|$)`),
+	},
+	{
+		name:    "klog",
+		pattern: regexp.MustCompile(`^[IWEF](?P<timestamp>\d{4} \d{2}:\d{2}:\d{2}\.\d{6})(?P<zone>)\s`),
+	},
+	{
+		name:    "syslog",
+		pattern: regexp.MustCompile(`^(?P<timestamp>[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2})(?P<zone>)\s`),
+	},
+}
+
+// logTimestamp is a timestamp detected in a line, start and end are its offsets in the line
+type logTimestamp struct {
+	format  string
+	zone    string
+	example string
+	start   int
+	end     int
+}
+
+func (a *AnalyzeLogTimestamps) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Log Timestamps"
+}
+
+func (a *AnalyzeLogTimestamps) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeLogTimestamps) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	fileName := a.analyzer.FileName
+	if fileName == "" {
+		fileName = logTimestampsDefaultFileName
+	}
+	fullPath := filepath.Join(a.analyzer.CollectorName, fileName)
+	excludeFiles := []string{}
+	for _, excludeFile := range a.analyzer.ExcludeFiles {
+		excludeFiles = append(excludeFiles, filepath.Join(a.analyzer.CollectorName, excludeFile))
+	}
+
+	collected, err := findFiles(fullPath, excludeFiles)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read collected file name: %s", fullPath)
+	}
+
+	if len(collected) == 0 {
+		return []*AnalyzeResult{{
+			Title:   a.Title(),
+			IsWarn:  true,
+			Message: "No matching files",
+			Strict:  a.analyzer.Strict.BoolOrDefaultFalse(),
+		}}, nil
+	}
+
+	issues := findLogTimestampsIssues(a.analyzer.CollectorName, collected)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: issue.Problem,
+			}
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "All components log timestamps in UTC",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// findLogTimestampsIssues returns the components, ordered by name, whose most common timestamp
+// format is not in UTC. Files without timestamps are skipped.
+func findLogTimestampsIssues(collectorName string, collected map[string][]byte) []logTimestampsIssue {
+	issues := []logTimestampsIssue{}
+	for filePath, contents := range collected {
+		timestamp, ok := detectLogTimestampFormat(contents)
+		if !ok || isUTCLogTimestampZone(timestamp.zone) {
+			continue
+		}
+
+		issue := logTimestampsIssue{
+			Component: logTimestampsComponent(collectorName, filePath),
+			Format:    timestamp.format,
+			Timezone:  timestamp.zone,
+			Example:   timestamp.example,
+		}
+		if issue.Timezone == "" {
+			issue.Problem = fmt.Sprintf("%s logs %s timestamps without a timezone, for example %s", issue.Component, issue.Format, issue.Example)
+		} else {
+			issue.Problem = fmt.Sprintf("%s logs %s timestamps in timezone %s, for example %s", issue.Component, issue.Format, issue.Timezone, issue.Example)
+		}
+		issues = append(issues, issue)
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].Component < issues[j].Component
+	})
+	return issues
+}
+
+// detectLogTimestampFormat returns the most common timestamp format in the first lines of a log
+// file, the first format seen wins a tie
+func detectLogTimestampFormat(contents []byte) (logTimestamp, bool) {
+	type formatCount struct {
+		timestamp logTimestamp
+		count     int
+	}
+	counts := []*formatCount{}
+	sampled := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() && sampled < logTimestampsSampleLines {
+		timestamp, ok := detectLineTimestamp(scanner.Text())
+		if !ok {
+			continue
+		}
+		sampled++
+
+		found := false
+		for _, c := range counts {
+			if c.timestamp.format == timestamp.format && c.timestamp.zone == timestamp.zone {
+				c.count++
+				found = true
+				break
+			}
+		}
+		if !found {
+			counts = append(counts, &formatCount{timestamp: timestamp, count: 1})
+		}
+	}
+
+	if len(counts) == 0 {
+		return logTimestamp{}, false
+	}
+	mostCommon := counts[0]
+	for _, c := range counts[1:] {
+		if c.count > mostCommon.count {
+			mostCommon = c
+		}
+	}
+	return mostCommon.timestamp, true
+}
+
+// detectLineTimestamp returns the timestamp of a line. When a line starts with a timestamp added
+// by the container runtime and the message starts with its own, the timestamp of the message is
+// returned.
+func detectLineTimestamp(line string) (logTimestamp, bool) {
+	timestamp, ok := matchLogTimestamp(line)
+	if !ok {
+		return logTimestamp{}, false
+	}
+	if timestamp.start == 0 {
+		message := strings.TrimLeft(line[timestamp.end:], " \t")
+		if inner, ok := matchLogTimestamp(message); ok && inner.start == 0 {
+			return inner, true
+		}
+	}
+	return timestamp, true
+}
+
+// matchLogTimestamp returns the timestamp of the first format matching the line
+func matchLogTimestamp(line string) (logTimestamp, bool) {
+	for _, format := range logTimestampFormats {
+		match := format.pattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+		if !format.anywhere && match[0] != 0 {
+			continue
+		}
+
+		timestamp := logTimestamp{format: format.name, start: match[0], end: match[1]}
+		if i := format.pattern.SubexpIndex("timestamp"); match[2*i] >= 0 {
+			timestamp.example = line[match[2*i]:match[2*i+1]]
+			timestamp.end = match[2*i+1]
+		}
+		if i := format.pattern.SubexpIndex("zone"); match[2*i] >= 0 {
+			timestamp.zone = strings.TrimSpace(line[match[2*i]:match[2*i+1]])
+		}
+		return timestamp, true
+	}
+	return logTimestamp{}, false
+}
+
+func isUTCLogTimestampZone(zone string) bool {
+	switch zone {
+	case "Z", "UTC", "GMT", "+00:00", "-00:00", "+0000", "-0000":
+		return true
+	default:
+		return false
+	}
+}
+
+// logTimestampsComponent names a log file by its path relative to the collector output, without
+// its extension
+func logTimestampsComponent(collectorName string, filePath string) string {
+	component := filepath.ToSlash(filePath)
+	if collectorName != "" {
+		prefix := strings.TrimSuffix(filepath.ToSlash(collectorName), "/") + "/"
+		if i := strings.LastIndex(component, prefix); i != -1 {
+			component = component[i+len(prefix):]
+		}
+	}
+	return strings.TrimSuffix(component, filepath.Ext(component))
+}
@@ -0,0 +1,202 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeLogTimestamps(t *testing.T) {
+	collected := map[string][]byte{
+		"app-logs/api.log":     []byte(logTimestampsApi),
+		"app-logs/worker.log":  []byte(logTimestampsWorker),
+		"app-logs/billing.log": []byte(logTimestampsBilling),
+		"app-logs/nginx.log":   []byte(logTimestampsNginx),
+		"app-logs/legacy.log":  []byte(logTimestampsLegacy),
+		"app-logs/manager.log": []byte(logTimestampsManager),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.LogTimestampsAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name: "utc and local time logs",
+			analyzer: troubleshootv1beta2.LogTimestampsAnalyze{
+				CollectorName: "app-logs",
+				FileName:      "*.log",
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "Log Timestamps",
+					Message: "billing logs ISO 8601 timestamps without a timezone, for example 2026-10-16 14:00:03,118",
+				},
+				{
+					IsWarn:  true,
+					Title:   "Log Timestamps",
+					Message: "legacy logs date timestamps in timezone CEST, for example Fri Oct 16 14:00:05 CEST 2026",
+				},
+				{
+					IsWarn:  true,
+					Title:   "Log Timestamps",
+					Message: "manager logs klog timestamps without a timezone, for example 1016 12:00:06.104221",
+				},
+				{
+					IsWarn:  true,
+					Title:   "Log Timestamps",
+					Message: "worker logs ISO 8601 timestamps in timezone +02:00, for example 2026-10-16T14:00:02+02:00",
+				},
+			},
+		},
+		{
+			name: "outcomes",
+			analyzer: troubleshootv1beta2.LogTimestampsAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Timezones",
+				},
+				CollectorName: "app-logs",
+				FileName:      "*.log",
+				ExcludeFiles:  []string{"billing.log", "manager.log", "legacy.log"},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Component }}: {{ .Format }} {{ .Timezone }}",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "UTC everywhere",
+						},
+					},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "Timezones",
+					Message: "worker: ISO 8601 +02:00",
+				},
+			},
+		},
+		{
+			name: "utc logs",
+			analyzer: troubleshootv1beta2.LogTimestampsAnalyze{
+				CollectorName: "app-logs",
+				FileName:      "*.log",
+				ExcludeFiles:  []string{"billing.log", "manager.log", "legacy.log", "worker.log"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Log Timestamps",
+					Message: "All components log timestamps in UTC",
+				},
+			},
+		},
+		{
+			name: "no matching files",
+			analyzer: troubleshootv1beta2.LogTimestampsAnalyze{
+				CollectorName: "other-logs",
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "Log Timestamps",
+					Message: "No matching files",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			findFiles := func(pattern string, excludeFiles []string) (map[string][]byte, error) {
+				matching := map[string][]byte{}
+				for name, contents := range collected {
+					matched, err := filepath.Match(pattern, name)
+					req.NoError(err)
+					excluded := false
+					for _, excludeFile := range excludeFiles {
+						if excludeFile == name {
+							excluded = true
+						}
+					}
+					if matched && !excluded {
+						matching[name] = contents
+					}
+				}
+				return matching, nil
+			}
+
+			a := &AnalyzeLogTimestamps{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(nil, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
+
+func Test_detectLineTimestamp(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		wantFormat string
+		wantZone   string
+		wantOK     bool
+	}{
+		{
+			name:       "runtime prefix without message timestamp",
+			line:       "2026-10-16T12:00:00.5Z processing report for 2026-10-15",
+			wantFormat: "ISO 8601",
+			wantZone:   "Z",
+			wantOK:     true,
+		},
+		{
+			name:       "named zone is not a log level",
+			line:       "2026-10-16 12:00:00 ESTABLISHED connection",
+			wantFormat: "ISO 8601",
+			wantOK:     true,
+		},
+		{
+			name:       "named zone",
+			line:       "2026-10-16 08:00:00 EST connected",
+			wantFormat: "ISO 8601",
+			wantZone:   "EST",
+			wantOK:     true,
+		},
+		{
+			name:       "syslog",
+			line:       "Oct  6 12:00:00 node-1 kernel: eth0 link up",
+			wantFormat: "syslog",
+			wantOK:     true,
+		},
+		{
+			name:   "no timestamp",
+			line:   "  File \"/app/main.py\", line 3",
+			wantOK: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := detectLineTimestamp(test.line)
+			require.Equal(t, test.wantOK, ok)
+			require.Equal(t, test.wantFormat, got.format)
+			require.Equal(t, test.wantZone, got.zone)
+		})
+	}
+}
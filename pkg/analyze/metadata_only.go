@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+)
+
+// analyzesMetadataOnly returns true for the analyzers that only need the metadata, statuses and
+// structural fields kept in a bundle collected with --metadata-only
+func analyzesMetadataOnly(analyzer Analyzer) bool {
+	switch analyzer.(type) {
+	case *AnalyzeClusterVersion,
+		*AnalyzeStorageClass,
+		*AnalyzeCustomResourceDefinition,
+		*AnalyzeIngress,
+		*AnalyzeDeploymentStatus,
+		*AnalyzeStatefulsetStatus,
+		*AnalyzeJobStatus,
+		*AnalyzeReplicaSetStatus,
+		*AnalyzeClusterPodStatuses,
+		*AnalyzeClusterContainerStatuses,
+		*AnalyzeContainerRuntime,
+		*AnalyzeDistribution,
+		*AnalyzeNodeResources,
+		*AnalyzeOOMKilled,
+		*AnalyzeAPIWarnings,
+		*AnalyzeDuplicateAPIVersions,
+		*AnalyzeRequiredNamespaces,
+		*AnalyzeNodePodCapacity:
+		return true
+	default:
+		return false
+	}
+}
+
+// isMetadataOnlyBundle returns true when the bundle was collected with --metadata-only
+func isMetadataOnlyBundle(getFile getCollectedFileContents) bool {
+	_, err := getFile(constants.METADATA_ONLY_FILENAME)
+	return err == nil
+}
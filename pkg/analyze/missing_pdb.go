@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type AnalyzeMissingPDB struct {
+	analyzer *troubleshootv1beta2.MissingPDBAnalyze
+}
+
+// missingPDBIssue is the template data available to outcome messages, one is reported for each
+// selected workload whose pods no PodDisruptionBudget selects
+type missingPDBIssue struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Replicas  int
+}
+
+func (a *AnalyzeMissingPDB) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Missing Pod Disruption Budgets"
+}
+
+func (a *AnalyzeMissingPDB) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeMissingPDB) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	workloads, err := readHAWorkloads(findFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	pdbs, err := readCollectedPodDisruptionBudgets(findFiles, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := findMissingPDBs(workloads, pdbs, a.analyzer.Workloads)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:  a.Title(),
+				IsWarn: true,
+				Message: fmt.Sprintf("%s %s/%s runs %d replicas but no PodDisruptionBudget selects its pods, so a node drain or cluster upgrade can evict all of them at once. Create a PodDisruptionBudget matching its pod labels with minAvailable or maxUnavailable set.",
+					issue.Kind, issue.Namespace, issue.Name, issue.Replicas),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       issue.Kind,
+			Namespace:  issue.Namespace,
+			Name:       issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "All critical workloads are protected by a PodDisruptionBudget",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// findMissingPDBs returns the workloads selected by the expectations that run at least the
+// required replicas and whose pod template labels no budget in the same namespace selects
+func findMissingPDBs(workloads []haWorkload, pdbs []policyv1.PodDisruptionBudget, expectations []troubleshootv1beta2.HighAvailabilityWorkload) ([]missingPDBIssue, error) {
+	minReplicas, err := selectHAWorkloads(workloads, expectations, "missing pdb workloads")
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []missingPDBIssue{}
+	for i, workload := range workloads {
+		required, ok := minReplicas[i]
+		if !ok || workload.replicas < required {
+			continue
+		}
+
+		protected, err := podsHaveDisruptionBudget(workload.meta.Namespace, workload.podLabels, pdbs)
+		if err != nil {
+			return nil, err
+		}
+		if protected {
+			continue
+		}
+
+		issues = append(issues, missingPDBIssue{
+			Kind:      workload.kind,
+			Namespace: workload.meta.Namespace,
+			Name:      workload.meta.Name,
+			Replicas:  workload.replicas,
+		})
+	}
+
+	return issues, nil
+}
+
+// podsHaveDisruptionBudget returns true when a budget in the namespace selects pods with the given
+// labels. A budget without a selector selects no pods.
+func podsHaveDisruptionBudget(namespace string, podLabels map[string]string, pdbs []policyv1.PodDisruptionBudget) (bool, error) {
+	for _, pdb := range pdbs {
+		if pdb.Namespace != namespace {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to parse selector of pod disruption budget %s/%s", pdb.Namespace, pdb.Name)
+		}
+		if selector.Matches(labels.Set(podLabels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
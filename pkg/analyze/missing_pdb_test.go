@@ -0,0 +1,154 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeMissingPDB(t *testing.T) {
+	workloadReference := func(kind, name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "apps/v1", Kind: kind, Namespace: "default", Name: name}
+	}
+
+	files := map[string][]byte{
+		"cluster-resources/deployments/default.json":            []byte(missingPDBDeployments),
+		"cluster-resources/statefulsets/default.json":           []byte(missingPDBStatefulSets),
+		"cluster-resources/pod-disruption-budgets/default.json": []byte(missingPDBPodDisruptionBudgetsDefault),
+		"cluster-resources/pod-disruption-budgets/other.json":   []byte(missingPDBPodDisruptionBudgetsOther),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.MissingPDBAnalyze
+		expectResult []AnalyzeResult
+		expectErr    string
+	}{
+		{
+			name: "unprotected critical workloads are reported",
+			analyzer: troubleshootv1beta2.MissingPDBAnalyze{
+				Workloads: []troubleshootv1beta2.HighAvailabilityWorkload{
+					{Selector: []string{"critical=true"}},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Missing Pod Disruption Budgets",
+					Message:        "Deployment default/web runs 2 replicas but no PodDisruptionBudget selects its pods, so a node drain or cluster upgrade can evict all of them at once. Create a PodDisruptionBudget matching its pod labels with minAvailable or maxUnavailable set.",
+					InvolvedObject: workloadReference("Deployment", "web"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "Missing Pod Disruption Budgets",
+					Message:        "StatefulSet default/redis runs 3 replicas but no PodDisruptionBudget selects its pods, so a node drain or cluster upgrade can evict all of them at once. Create a PodDisruptionBudget matching its pod labels with minAvailable or maxUnavailable set.",
+					InvolvedObject: workloadReference("StatefulSet", "redis"),
+				},
+			},
+		},
+		{
+			name: "custom outcomes and replica threshold",
+			analyzer: troubleshootv1beta2.MissingPDBAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Disruption Budgets",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Kind }} {{ .Name }} ({{ .Replicas }} replicas) has no PDB",
+						},
+					},
+				},
+				Workloads: []troubleshootv1beta2.HighAvailabilityWorkload{
+					{Kind: "Deployment", Name: "batch"},
+					{Kind: "Deployment", Name: "web", MinReplicas: 3},
+					{Kind: "Deployment", Name: "worker", MinReplicas: 1},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Disruption Budgets",
+					Message:        "Deployment batch (4 replicas) has no PDB",
+					InvolvedObject: workloadReference("Deployment", "batch"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Disruption Budgets",
+					Message:        "Deployment worker (1 replicas) has no PDB",
+					InvolvedObject: workloadReference("Deployment", "worker"),
+				},
+			},
+		},
+		{
+			name: "protected workloads pass",
+			analyzer: troubleshootv1beta2.MissingPDBAnalyze{
+				Workloads: []troubleshootv1beta2.HighAvailabilityWorkload{
+					{Name: "api"},
+					{Kind: "StatefulSet", Name: "postgres"},
+					{Namespace: "payments", Name: "ledger"},
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Missing Pod Disruption Budgets",
+					Message: "All critical workloads are protected by a PodDisruptionBudget",
+				},
+			},
+		},
+		{
+			name: "workload without name or selector",
+			analyzer: troubleshootv1beta2.MissingPDBAnalyze{
+				Workloads: []troubleshootv1beta2.HighAvailabilityWorkload{
+					{Kind: "StatefulSet"},
+				},
+			},
+			expectErr: "missing pdb workloads require a name or a selector",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeMissingPDB{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			if test.expectErr != "" {
+				req.ErrorContains(err, test.expectErr)
+				return
+			}
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
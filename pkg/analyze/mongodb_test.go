@@ -0,0 +1,144 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeMongodb_Analyze(t *testing.T) {
+	outcomes := []*troubleshootv1beta2.Outcome{
+		{
+			Fail: &troubleshootv1beta2.SingleOutcome{
+				When:    "connected == false",
+				Message: "Cannot connect to MongoDB",
+			},
+		},
+		{
+			Fail: &troubleshootv1beta2.SingleOutcome{
+				When:    "version < 6.0.0",
+				Message: "MongoDB must be at least version 6",
+			},
+		},
+		{
+			Warn: &troubleshootv1beta2.SingleOutcome{
+				When:    "replicaSetRole != primary",
+				Message: "MongoDB is not the primary of a replica set",
+			},
+		},
+		{
+			Pass: &troubleshootv1beta2.SingleOutcome{
+				Message: "MongoDB connection checks out",
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		analyzer *troubleshootv1beta2.DatabaseAnalyze
+		want     []*AnalyzeResult
+		data     map[string]any
+		wantErr  bool
+	}{
+		{
+			name: "primary passes",
+			data: map[string]any{
+				"isConnected":    true,
+				"version":        "7.0.12",
+				"replicaSetRole": "primary",
+				"replicaSet":     "rs0",
+			},
+			analyzer: &troubleshootv1beta2.DatabaseAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "MongoDB",
+				},
+				Outcomes: outcomes,
+			},
+			want: []*AnalyzeResult{
+				{
+					Title:   "MongoDB",
+					Message: "MongoDB connection checks out",
+					IsPass:  true,
+				},
+			},
+		},
+		{
+			name: "standalone warns",
+			data: map[string]any{
+				"isConnected":    true,
+				"version":        "7.0.12",
+				"replicaSetRole": "standalone",
+			},
+			analyzer: &troubleshootv1beta2.DatabaseAnalyze{
+				Outcomes: outcomes,
+			},
+			want: []*AnalyzeResult{
+				{
+					Title:   "mongodb",
+					Message: "MongoDB is not the primary of a replica set",
+					IsWarn:  true,
+				},
+			},
+		},
+		{
+			name: "old version fails",
+			data: map[string]any{
+				"isConnected":    true,
+				"version":        "5.0.26",
+				"replicaSetRole": "primary",
+			},
+			analyzer: &troubleshootv1beta2.DatabaseAnalyze{
+				Outcomes: outcomes,
+			},
+			want: []*AnalyzeResult{
+				{
+					Title:   "mongodb",
+					Message: "MongoDB must be at least version 6",
+					IsFail:  true,
+				},
+			},
+		},
+		{
+			name: "not connected fails",
+			data: map[string]any{
+				"isConnected": false,
+				"error":       "server selection error: context deadline exceeded",
+			},
+			analyzer: &troubleshootv1beta2.DatabaseAnalyze{
+				Outcomes: outcomes,
+			},
+			want: []*AnalyzeResult{
+				{
+					Title:   "mongodb",
+					Message: "Cannot connect to MongoDB",
+					IsFail:  true,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AnalyzeMongodb{
+				analyzer: tt.analyzer,
+			}
+
+			getFile := func(name string) ([]byte, error) {
+				assert.Equal(t, "mongodb/mongodb.json", name)
+				return json.Marshal(tt.data)
+			}
+
+			got, err := a.Analyze(getFile, nil)
+			assert.Equalf(t, tt.wantErr, err != nil, "got error = %v, wantErr %v", err, tt.wantErr)
+
+			got2 := fromPointerSlice(got)
+			want2 := fromPointerSlice(tt.want)
+			if !reflect.DeepEqual(got2, want2) {
+				t.Errorf("got = %v, want %v", toJSON(got2), toJSON(want2))
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const nodePodCapacityDefaultNearCapacityPercent = 90
+
+type AnalyzeNodePodCapacity struct {
+	analyzer *troubleshootv1beta2.NodePodCapacityAnalyze
+}
+
+// nodePodCapacity is the template data available to outcome messages, one is reported for each
+// node at or near its pod capacity
+type nodePodCapacity struct {
+	Node string
+	// PodCount is the number of pods on the node that are not Succeeded or Failed, the pods that
+	// count against the kubelet max-pods limit
+	PodCount    int
+	PodCapacity int
+	Percent     int
+	AtCapacity  bool
+}
+
+func (a *AnalyzeNodePodCapacity) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Node Pod Capacity"
+}
+
+func (a *AnalyzeNodePodCapacity) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeNodePodCapacity) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	nodes, err := readCollectedNodes(getFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// pods are counted in all the collected namespaces, a bundle collected from some namespaces
+	// only undercounts them
+	pods, err := readCollectedPods(findFiles, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	nearCapacityPercent := nodePodCapacityDefaultNearCapacityPercent
+	if a.analyzer.NearCapacityPercent != "" {
+		nearCapacityPercent, err = strconv.Atoi(strings.TrimSuffix(a.analyzer.NearCapacityPercent, "%"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse nearCapacityPercent %q", a.analyzer.NearCapacityPercent)
+		}
+	}
+
+	capacities := nodePodCapacities(nodes, pods)
+
+	results := []*AnalyzeResult{}
+	for _, capacity := range capacities {
+		if !capacity.AtCapacity && capacity.Percent < nearCapacityPercent {
+			continue
+		}
+
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), capacity)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: fmt.Sprintf("Node %s is running %d of its %d allocatable pods (%d%%)", capacity.Node, capacity.PodCount, capacity.PodCapacity, capacity.Percent),
+			}
+			if capacity.AtCapacity {
+				result.IsWarn = false
+				result.IsFail = true
+				result.Message = fmt.Sprintf("Node %s is running %d of its %d allocatable pods, no more pods can be scheduled on it regardless of available CPU and memory", capacity.Node, capacity.PodCount, capacity.PodCapacity)
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       capacity.Node,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			counts := []string{}
+			for _, capacity := range capacities {
+				counts = append(counts, fmt.Sprintf("%s %d/%d", capacity.Node, capacity.PodCount, capacity.PodCapacity))
+			}
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: fmt.Sprintf("All nodes are below %d%% of their pod capacity: %s", nearCapacityPercent, strings.Join(counts, ", ")),
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// nodePodCapacities returns the pod count and allocatable pods of each node, ordered by node name.
+// Nodes that don't report allocatable pods are left out.
+func nodePodCapacities(nodes []corev1.Node, pods []corev1.Pod) []nodePodCapacity {
+	podCounts := map[string]int{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		podCounts[pod.Spec.NodeName]++
+	}
+
+	capacities := []nodePodCapacity{}
+	for _, node := range nodes {
+		podCapacity := int(node.Status.Allocatable.Pods().Value())
+		if podCapacity == 0 {
+			continue
+		}
+		podCount := podCounts[node.Name]
+		capacities = append(capacities, nodePodCapacity{
+			Node:        node.Name,
+			PodCount:    podCount,
+			PodCapacity: podCapacity,
+			Percent:     podCount * 100 / podCapacity,
+			AtCapacity:  podCount >= podCapacity,
+		})
+	}
+
+	sort.Slice(capacities, func(i, j int) bool {
+		return capacities[i].Node < capacities[j].Node
+	})
+	return capacities
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeNodePodCapacity(t *testing.T) {
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.NodePodCapacityAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "nodes at and near capacity",
+			analyzer: troubleshootv1beta2.NodePodCapacityAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "Node Pod Capacity",
+					Message: "Node ip-10-0-1-21.ec2.internal is running 11 of its 11 allocatable pods, no more pods can be scheduled on it regardless of available CPU and memory",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1", Kind: "Node", Name: "ip-10-0-1-21.ec2.internal",
+					},
+				},
+				{
+					IsWarn:  true,
+					Title:   "Node Pod Capacity",
+					Message: "Node ip-10-0-2-34.ec2.internal is running 16 of its 17 allocatable pods (94%)",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1", Kind: "Node", Name: "ip-10-0-2-34.ec2.internal",
+					},
+				},
+			},
+		},
+		{
+			name: "outcomes",
+			analyzer: troubleshootv1beta2.NodePodCapacityAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Max Pods",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Node }}: {{ .PodCount }}/{{ .PodCapacity }} pods, at capacity: {{ .AtCapacity }}",
+						},
+					},
+				},
+				NearCapacityPercent: "95%",
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "Max Pods",
+					Message: "ip-10-0-1-21.ec2.internal: 11/11 pods, at capacity: true",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1", Kind: "Node", Name: "ip-10-0-1-21.ec2.internal",
+					},
+				},
+			},
+		},
+		{
+			name: "only nodes at capacity",
+			analyzer: troubleshootv1beta2.NodePodCapacityAnalyze{
+				NearCapacityPercent: "100",
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "Node Pod Capacity",
+					Message: "Node ip-10-0-1-21.ec2.internal is running 11 of its 11 allocatable pods, no more pods can be scheduled on it regardless of available CPU and memory",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1", Kind: "Node", Name: "ip-10-0-1-21.ec2.internal",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if n == "cluster-resources/nodes.json" {
+					return []byte(nodePodCapacityNodes), nil
+				}
+				return nil, errors.Errorf("%s was not collected", n)
+			}
+			findFiles := func(n string, _ []string) (map[string][]byte, error) {
+				req.Equal("cluster-resources/pods/*.json", n)
+				return map[string][]byte{
+					"cluster-resources/pods/default.json":     []byte(nodePodCapacityPodsDefault),
+					"cluster-resources/pods/kube-system.json": []byte(nodePodCapacityPodsKubeSystem),
+				}, nil
+			}
+
+			a := &AnalyzeNodePodCapacity{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
+
+func TestAnalyzeNodePodCapacityNoPods(t *testing.T) {
+	getFile := func(n string) ([]byte, error) {
+		return []byte(nodePodCapacityNodes), nil
+	}
+	findFiles := func(n string, _ []string) (map[string][]byte, error) {
+		return map[string][]byte{}, nil
+	}
+
+	a := &AnalyzeNodePodCapacity{
+		analyzer: &troubleshootv1beta2.NodePodCapacityAnalyze{},
+	}
+	actual, err := a.Analyze(getFile, findFiles)
+	require.NoError(t, err)
+	require.Equal(t, []*AnalyzeResult{{
+		IsPass:  true,
+		Title:   "Node Pod Capacity",
+		Message: "All nodes are below 90% of their pod capacity: ip-10-0-1-21.ec2.internal 0/11, ip-10-0-2-34.ec2.internal 0/17, ip-10-0-3-47.ec2.internal 0/58",
+	}}, actual)
+}
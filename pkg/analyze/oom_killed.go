@@ -0,0 +1,196 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const oomKilledReason = "OOMKilled"
+
+// containers restarted at least this many times after running out of memory are reported as failures
+const oomKilledFailRestarts = 3
+
+type AnalyzeOOMKilled struct {
+	analyzer *troubleshootv1beta2.OOMKilledAnalyze
+}
+
+// oomKilledIssue is the template data available to outcome messages. Issues are reported per
+// container of a workload, pods owned by the same workload are grouped together.
+type oomKilledIssue struct {
+	Namespace string
+	Kind      string
+	Name      string
+	Container string
+	Pods      []string
+	// OOMKills is the number of pods in which the container was last terminated for running out of memory
+	OOMKills int
+	// RestartCount is the sum of the container's restarts across the pods
+	RestartCount int32
+	// MemoryLimit and MemoryRequest are empty when not set
+	MemoryLimit   string
+	MemoryRequest string
+	// LimitTooLow is true when the container reached its own memory limit. When there is no limit
+	// the container was killed because its node ran out of memory.
+	LimitTooLow bool
+}
+
+func (a *AnalyzeOOMKilled) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "OOMKilled Containers"
+}
+
+func (a *AnalyzeOOMKilled) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeOOMKilled) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	pods, err := readCollectedPods(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := findOOMKilledIssues(pods)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = defaultOOMKilledIssueResult(a.Title(), issue)
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			Kind:      issue.Kind,
+			Namespace: issue.Namespace,
+			Name:      issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No containers were OOMKilled",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultOOMKilledIssueResult(title string, issue oomKilledIssue) *AnalyzeResult {
+	message := fmt.Sprintf(
+		"Container %s of %s %s/%s was OOMKilled in %d pod(s) and has restarted %d time(s).",
+		issue.Container, issue.Kind, issue.Namespace, issue.Name, issue.OOMKills, issue.RestartCount,
+	)
+
+	if issue.LimitTooLow {
+		message += fmt.Sprintf(" It reached its memory limit of %s, raise the limit if this is its normal working set or investigate a memory leak.", issue.MemoryLimit)
+	} else {
+		message += " It has no memory limit and was killed because its node ran out of memory."
+		if issue.MemoryRequest == "" {
+			message += " Set a memory request so the scheduler reserves enough memory for it."
+		} else {
+			message += fmt.Sprintf(" Its memory request of %s is likely below its actual usage, raise the request so the scheduler reserves enough memory for it.", issue.MemoryRequest)
+		}
+	}
+
+	result := &AnalyzeResult{
+		Title:   title,
+		Message: message,
+	}
+	if issue.RestartCount >= oomKilledFailRestarts {
+		result.IsFail = true
+	} else {
+		result.IsWarn = true
+	}
+	return result
+}
+
+// findOOMKilledIssues returns the containers, grouped by workload, whose current or last
+// termination was because they ran out of memory
+func findOOMKilledIssues(pods []corev1.Pod) []oomKilledIssue {
+	issuesByContainer := map[string]*oomKilledIssue{}
+	keys := []string{}
+
+	for _, pod := range pods {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if !wasOOMKilled(status) {
+				continue
+			}
+
+			kind, name := podWorkload(pod)
+			key := fmt.Sprintf("%s/%s/%s/%s", pod.Namespace, kind, name, status.Name)
+			issue, ok := issuesByContainer[key]
+			if !ok {
+				issue = &oomKilledIssue{
+					Namespace: pod.Namespace,
+					Kind:      kind,
+					Name:      name,
+					Container: status.Name,
+				}
+				if container := findPodContainer(pod.Spec, status.Name); container != nil {
+					if limit, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+						issue.MemoryLimit = limit.String()
+						issue.LimitTooLow = true
+					}
+					if request, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+						issue.MemoryRequest = request.String()
+					}
+				}
+				issuesByContainer[key] = issue
+				keys = append(keys, key)
+			}
+
+			issue.Pods = append(issue.Pods, pod.Name)
+			issue.OOMKills++
+			issue.RestartCount += status.RestartCount
+		}
+	}
+
+	sort.Strings(keys)
+	issues := []oomKilledIssue{}
+	for _, key := range keys {
+		issue := issuesByContainer[key]
+		sort.Strings(issue.Pods)
+		issues = append(issues, *issue)
+	}
+
+	return issues
+}
+
+func wasOOMKilled(status corev1.ContainerStatus) bool {
+	if status.State.Terminated != nil && status.State.Terminated.Reason == oomKilledReason {
+		return true
+	}
+	return status.LastTerminationState.Terminated != nil && status.LastTerminationState.Terminated.Reason == oomKilledReason
+}
+
+func findPodContainer(spec corev1.PodSpec, name string) *corev1.Container {
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			if containers[i].Name == name {
+				return &containers[i]
+			}
+		}
+	}
+	return nil
+}
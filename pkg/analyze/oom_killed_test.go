@@ -0,0 +1,148 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeOOMKilled(t *testing.T) {
+	oomKilledFiles := map[string][]byte{
+		"cluster-resources/pods/default.json": []byte(oomKilledPods),
+	}
+
+	apiReference := &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "api"}
+	cacheReference := &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "cache"}
+	workerReference := &corev1.ObjectReference{Kind: "StatefulSet", Namespace: "default", Name: "worker"}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.OOMKilledAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "oomkilled containers with default outcomes",
+			analyzer: troubleshootv1beta2.OOMKilledAnalyze{},
+			files:    oomKilledFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "OOMKilled Containers",
+					Message:        "Container api of Deployment default/api was OOMKilled in 2 pod(s) and has restarted 7 time(s). It reached its memory limit of 256Mi, raise the limit if this is its normal working set or investigate a memory leak.",
+					InvolvedObject: apiReference,
+				},
+				{
+					IsWarn:         true,
+					Title:          "OOMKilled Containers",
+					Message:        "Container cache of Deployment default/cache was OOMKilled in 1 pod(s) and has restarted 0 time(s). It reached its memory limit of 64Mi, raise the limit if this is its normal working set or investigate a memory leak.",
+					InvolvedObject: cacheReference,
+				},
+				{
+					IsWarn:         true,
+					Title:          "OOMKilled Containers",
+					Message:        "Container worker of StatefulSet default/worker was OOMKilled in 1 pod(s) and has restarted 1 time(s). It has no memory limit and was killed because its node ran out of memory. Its memory request of 512Mi is likely below its actual usage, raise the request so the scheduler reserves enough memory for it.",
+					InvolvedObject: workerReference,
+				},
+			},
+		},
+		{
+			name: "templated outcomes",
+			analyzer: troubleshootv1beta2.OOMKilledAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Name }}/{{ .Container }} pods={{ .Pods }} kills={{ .OOMKills }} restarts={{ .RestartCount }} limit={{ .MemoryLimit }} request={{ .MemoryRequest }} limitTooLow={{ .LimitTooLow }}",
+						},
+					},
+				},
+			},
+			files: oomKilledFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "OOMKilled Containers",
+					Message:        "api/api pods=[api-7d9c8b6f4-h5x2m api-7d9c8b6f4-q8w4n] kills=2 restarts=7 limit=256Mi request=128Mi limitTooLow=true",
+					InvolvedObject: apiReference,
+				},
+				{
+					IsFail:         true,
+					Title:          "OOMKilled Containers",
+					Message:        "cache/cache pods=[cache-6b8f9d7c5-p4l8s] kills=1 restarts=0 limit=64Mi request=64Mi limitTooLow=true",
+					InvolvedObject: cacheReference,
+				},
+				{
+					IsFail:         true,
+					Title:          "OOMKilled Containers",
+					Message:        "worker/worker pods=[worker-0] kills=1 restarts=1 limit= request=512Mi limitTooLow=false",
+					InvolvedObject: workerReference,
+				},
+			},
+		},
+		{
+			name: "no oomkilled containers in namespace",
+			analyzer: troubleshootv1beta2.OOMKilledAnalyze{
+				Namespaces: []string{"kube-system"},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "no containers ran out of memory",
+						},
+					},
+				},
+			},
+			files: map[string][]byte{
+				"cluster-resources/pods/default.json":     []byte(oomKilledPods),
+				"cluster-resources/pods/kube-system.json": []byte(`{"kind": "PodList", "apiVersion": "v1", "items": []}`),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "OOMKilled Containers",
+					Message: "no containers ran out of memory",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeOOMKilled{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	orphanOwnerMissing     = "OwnerMissing"
+	orphanOwnerUIDMismatch = "OwnerUIDMismatch"
+	orphanNoController     = "NoController"
+)
+
+// orphanCheckedKinds are the collected kinds whose owner references are checked, and that can be
+// owners themselves
+var orphanCheckedKinds = []struct {
+	kind       string
+	apiVersion string
+	dir        string
+}{
+	{"CronJob", "batch/v1", constants.CLUSTER_RESOURCES_CRONJOBS},
+	{"DaemonSet", "apps/v1", constants.CLUSTER_RESOURCES_DAEMONSETS},
+	{"Deployment", "apps/v1", constants.CLUSTER_RESOURCES_DEPLOYMENTS},
+	{"Job", "batch/v1", constants.CLUSTER_RESOURCES_JOBS},
+	{"Pod", "v1", constants.CLUSTER_RESOURCES_PODS},
+	{"ReplicaSet", "apps/v1", constants.CLUSTER_RESOURCES_REPLICASETS},
+	{"StatefulSet", "apps/v1", constants.CLUSTER_RESOURCES_STATEFULSETS},
+}
+
+type AnalyzeOrphanedResources struct {
+	analyzer *troubleshootv1beta2.OrphanedResourcesAnalyze
+}
+
+// orphanedResourceIssue is the template data available to outcome messages
+type orphanedResourceIssue struct {
+	Kind       string
+	APIVersion string
+	Namespace  string
+	Name       string
+	// Reason is one of OwnerMissing, OwnerUIDMismatch or NoController
+	Reason    string
+	OwnerKind string
+	OwnerName string
+}
+
+func (a *AnalyzeOrphanedResources) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Orphaned Resources"
+}
+
+func (a *AnalyzeOrphanedResources) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeOrphanedResources) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	objects := map[string]map[string][]metav1.ObjectMeta{}
+	for _, checked := range orphanCheckedKinds {
+		byNamespace, err := readCollectedObjectMeta(findFiles, checked.dir, a.analyzer.Namespaces)
+		if err != nil {
+			return nil, err
+		}
+		objects[checked.kind] = byNamespace
+	}
+
+	issues := findOrphanedResources(objects)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: defaultOrphanedResourceMessage(issue),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: issue.APIVersion,
+			Kind:       issue.Kind,
+			Namespace:  issue.Namespace,
+			Name:       issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "All owner references point to existing resources",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultOrphanedResourceMessage(issue orphanedResourceIssue) string {
+	object := fmt.Sprintf("%s %s/%s", issue.Kind, issue.Namespace, issue.Name)
+	switch issue.Reason {
+	case orphanOwnerUIDMismatch:
+		return fmt.Sprintf("%s is owned by a previous %s %s that was deleted and recreated. The garbage collector did not remove it.", object, issue.OwnerKind, issue.OwnerName)
+	case orphanNoController:
+		return fmt.Sprintf("%s has no owning %s. It was likely orphaned when its %s was deleted.", object, issue.OwnerKind, issue.OwnerKind)
+	default:
+		return fmt.Sprintf("%s references owner %s %s which does not exist. The garbage collector did not remove it.", object, issue.OwnerKind, issue.OwnerName)
+	}
+}
+
+// findOrphanedResources checks every owner reference to one of the checked kinds against the
+// objects of that kind, keyed by kind and then namespace. References to owners in namespaces
+// where the owner kind was not collected are not checked, since they can't be told apart from
+// collection failures.
+func findOrphanedResources(objects map[string]map[string][]metav1.ObjectMeta) []orphanedResourceIssue {
+	apiVersions := map[string]string{}
+	for _, checked := range orphanCheckedKinds {
+		apiVersions[checked.kind] = checked.apiVersion
+	}
+
+	issues := []orphanedResourceIssue{}
+	for _, checked := range orphanCheckedKinds {
+		for namespace, metas := range objects[checked.kind] {
+			for _, meta := range metas {
+				issue := orphanedResourceIssue{
+					Kind:       checked.kind,
+					APIVersion: checked.apiVersion,
+					Namespace:  namespace,
+					Name:       meta.Name,
+				}
+
+				if checked.kind == "ReplicaSet" && metav1.GetControllerOfNoCopy(&meta) == nil && meta.Labels[appsv1.DefaultDeploymentUniqueLabelKey] != "" {
+					issue.Reason = orphanNoController
+					issue.OwnerKind = "Deployment"
+					issues = append(issues, issue)
+					continue
+				}
+
+				for _, owner := range meta.OwnerReferences {
+					if _, ok := apiVersions[owner.Kind]; !ok {
+						continue
+					}
+					owners, collected := objects[owner.Kind][namespace]
+					if !collected {
+						continue
+					}
+
+					reason := orphanOwnerMissing
+					for _, candidate := range owners {
+						if candidate.Name != owner.Name {
+							continue
+						}
+						if owner.UID != "" && candidate.UID != "" && owner.UID != candidate.UID {
+							reason = orphanOwnerUIDMismatch
+							continue
+						}
+						reason = ""
+						break
+					}
+					if reason == "" {
+						continue
+					}
+
+					issue.Reason = reason
+					issue.OwnerKind = owner.Kind
+					issue.OwnerName = owner.Name
+					issues = append(issues, issue)
+					break
+				}
+			}
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Kind != issues[j].Kind {
+			return issues[i].Kind < issues[j].Kind
+		}
+		if issues[i].Namespace != issues[j].Namespace {
+			return issues[i].Namespace < issues[j].Namespace
+		}
+		return issues[i].Name < issues[j].Name
+	})
+
+	return issues
+}
+
+// readCollectedObjectMeta returns the metadata of the objects in the per-namespace list files the
+// cluster resources collector wrote for a resource, keyed by namespace. Plain arrays of objects
+// written by older versions are supported too.
+func readCollectedObjectMeta(findFiles getChildCollectedFileContents, resourceDir string, namespaces []string) (map[string][]metav1.ObjectMeta, error) {
+	files, err := collectedNamespaceFiles(findFiles, resourceDir, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	type object struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+	}
+
+	metas := map[string][]metav1.ObjectMeta{}
+	for namespace, fileContent := range files {
+		var list struct {
+			Items []object `json:"items"`
+		}
+		if err := json.Unmarshal(fileContent, &list); err != nil {
+			if err := json.Unmarshal(fileContent, &list.Items); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal %s list for namespace %s", resourceDir, namespace)
+			}
+		}
+
+		metas[namespace] = []metav1.ObjectMeta{}
+		for _, item := range list.Items {
+			metas[namespace] = append(metas[namespace], item.Metadata)
+		}
+	}
+
+	return metas, nil
+}
@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeOrphanedResources(t *testing.T) {
+	collected := map[string][]byte{
+		"cluster-resources/pods/default.json":         []byte(orphanedResourcesPods),
+		"cluster-resources/replicasets/default.json":  []byte(orphanedResourcesReplicaSets),
+		"cluster-resources/deployments/default.json":  []byte(orphanedResourcesDeployments),
+		"cluster-resources/statefulsets/default.json": []byte(orphanedResourcesStatefulSets),
+		"cluster-resources/jobs/default.json":         []byte(orphanedResourcesJobs),
+	}
+	withCronJobs := map[string][]byte{
+		"cluster-resources/cronjobs/default.json": []byte(`{"kind":"CronJobList","apiVersion":"batch/v1","metadata":{},"items":[]}`),
+	}
+	for n, b := range collected {
+		withCronJobs[n] = b
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.OrphanedResourcesAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "dangling owner references and orphaned replicasets",
+			analyzer: troubleshootv1beta2.OrphanedResourcesAnalyze{},
+			files:    collected,
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Orphaned Resources",
+					Message:        "Pod default/api-5c8b7d9f4-q2w3e references owner ReplicaSet api-5c8b7d9f4 which does not exist. The garbage collector did not remove it.",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "api-5c8b7d9f4-q2w3e"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Orphaned Resources",
+					Message:        "Pod default/db-0 is owned by a previous StatefulSet db that was deleted and recreated. The garbage collector did not remove it.",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "db-0"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Orphaned Resources",
+					Message:        "ReplicaSet default/web-7b9c6d5f8 has no owning Deployment. It was likely orphaned when its Deployment was deleted.",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Namespace: "default", Name: "web-7b9c6d5f8"},
+				},
+			},
+		},
+		{
+			name: "custom outcomes and owners of collected kinds",
+			analyzer: troubleshootv1beta2.OrphanedResourcesAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Kind }}/{{ .Name }} -> {{ .OwnerKind }}/{{ .OwnerName }}: {{ .Reason }}",
+						},
+					},
+				},
+			},
+			files: withCronJobs,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Orphaned Resources",
+					Message:        "Job/migrate-28461120 -> CronJob/migrate: OwnerMissing",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "batch/v1", Kind: "Job", Namespace: "default", Name: "migrate-28461120"},
+				},
+				{
+					IsFail:         true,
+					Title:          "Orphaned Resources",
+					Message:        "Pod/api-5c8b7d9f4-q2w3e -> ReplicaSet/api-5c8b7d9f4: OwnerMissing",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "api-5c8b7d9f4-q2w3e"},
+				},
+				{
+					IsFail:         true,
+					Title:          "Orphaned Resources",
+					Message:        "Pod/db-0 -> StatefulSet/db: OwnerUIDMismatch",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "db-0"},
+				},
+				{
+					IsFail:         true,
+					Title:          "Orphaned Resources",
+					Message:        "ReplicaSet/web-7b9c6d5f8 -> Deployment/: NoController",
+					InvolvedObject: &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Namespace: "default", Name: "web-7b9c6d5f8"},
+				},
+			},
+		},
+		{
+			name: "other namespaces pass",
+			analyzer: troubleshootv1beta2.OrphanedResourcesAnalyze{
+				Namespaces: []string{"kube-system"},
+			},
+			files: collected,
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Orphaned Resources",
+					Message: "All owner references point to existing resources",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeOrphanedResources{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
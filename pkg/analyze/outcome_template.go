@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"k8s.io/klog/v2"
+)
+
+// outcomeTemplateContext returns the values the outcome messages of the workload status analyzers
+// can use: the fields of the collected object's status by their json names, e.g. readyReplicas, and
+// the name and namespace of the object. Fields left out of the collected object because they are
+// zero, like the readyReplicas of a deployment with no ready pods, are set to their zero value. The
+// status is nil when the object was not collected.
+func outcomeTemplateContext(name, namespace string, status any) map[string]any {
+	context := map[string]any{}
+	if status != nil {
+		b, err := json.Marshal(status)
+		if err == nil {
+			err = json.Unmarshal(b, &context)
+		}
+		if err != nil {
+			klog.V(2).Infof("Failed to convert status of %s/%s for outcome messages: %v", namespace, name, err)
+		}
+
+		statusType := reflect.Indirect(reflect.ValueOf(status)).Type()
+		for i := 0; statusType.Kind() == reflect.Struct && i < statusType.NumField(); i++ {
+			field := statusType.Field(i)
+			key, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || key == "" || key == "-" {
+				continue
+			}
+			if _, ok := context[key]; !ok {
+				context[key] = reflect.Zero(field.Type).Interface()
+			}
+		}
+	}
+	context["name"] = name
+	context["namespace"] = namespace
+	return context
+}
+
+// renderOutcomeMessage renders the template placeholders of an outcome message, e.g.
+// {{ .readyReplicas }}/{{ .replicas }}, with the values of an outcomeTemplateContext. A message that
+// is not a valid template, or that uses a value missing from the context, is returned as written.
+func renderOutcomeMessage(message string, context map[string]any) string {
+	if !strings.Contains(message, "{{") {
+		return message
+	}
+
+	t, err := template.New("message").Option("missingkey=error").Parse(message)
+	if err != nil {
+		klog.V(2).Infof("Failed to parse outcome message template: %v", err)
+		return message
+	}
+
+	var rendered bytes.Buffer
+	if err := t.Execute(&rendered, context); err != nil {
+		klog.V(2).Infof("Failed to render outcome message template: %v", err)
+		return message
+	}
+	return rendered.String()
+}
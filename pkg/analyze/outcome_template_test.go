@@ -0,0 +1,46 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func Test_renderOutcomeMessage(t *testing.T) {
+	// a deployment with no ready pods has no readyReplicas in its collected status
+	context := outcomeTemplateContext("api", "default", appsv1.DeploymentStatus{Replicas: 3})
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "no placeholders",
+			message: "The api deployment is not ready",
+			want:    "The api deployment is not ready",
+		},
+		{
+			name:    "status fields",
+			message: "{{ .namespace }}/{{ .name }} has {{ .readyReplicas }}/{{ .replicas }} ready replicas",
+			want:    "default/api has 0/3 ready replicas",
+		},
+		{
+			name:    "missing key",
+			message: "{{ .name }} runs {{ .image }}",
+			want:    "{{ .name }} runs {{ .image }}",
+		},
+		{
+			name:    "invalid template",
+			message: "{{ .name ",
+			want:    "{{ .name ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, renderOutcomeMessage(tt.message, context))
+		})
+	}
+}
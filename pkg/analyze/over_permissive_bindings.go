@@ -0,0 +1,220 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// defaultWideSubjects match every user, every service account or every pod of a namespace
+var defaultWideSubjects = []string{
+	"Group:system:authenticated",
+	"Group:system:unauthenticated",
+	"Group:system:serviceaccounts",
+	"Group:system:serviceaccounts:*",
+	"User:system:anonymous",
+	"ServiceAccount:*/default",
+}
+
+type AnalyzeOverPermissiveBindings struct {
+	analyzer *troubleshootv1beta2.OverPermissiveBindingsAnalyze
+}
+
+// overPermissiveBindingIssue is the template data available to outcome messages
+type overPermissiveBindingIssue struct {
+	Binding     string
+	ClusterRole string
+	// Subjects is the comma separated list of the wide subjects of the binding
+	Subjects string
+	// Permissions is the semicolon separated list of the wildcard rules of the ClusterRole
+	Permissions string
+}
+
+// collectedAggregatedClusterRole holds the fields of the aggregated ClusterRoles saved by the
+// security collector the analyzer needs
+type collectedAggregatedClusterRole struct {
+	Name  string              `json:"name"`
+	Rules []rbacv1.PolicyRule `json:"rules"`
+}
+
+func (a *AnalyzeOverPermissiveBindings) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Over-permissive ClusterRoleBindings"
+}
+
+func (a *AnalyzeOverPermissiveBindings) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeOverPermissiveBindings) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	for _, pattern := range a.analyzer.Allowlist {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid allowlist pattern %q", pattern)
+		}
+	}
+	for _, pattern := range a.analyzer.WideSubjects {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid wide subject pattern %q", pattern)
+		}
+	}
+
+	clusterRoles, err := readCollectedClusterRoles(getFile)
+	if err != nil {
+		return nil, err
+	}
+	clusterRoleBindings, err := readCollectedClusterRoleBindings(getFile)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := map[string][]rbacv1.PolicyRule{}
+	for _, clusterRole := range clusterRoles {
+		rules[clusterRole.Name] = clusterRole.Rules
+	}
+	// the security collector resolves aggregated ClusterRoles itself, prefer its rules when collected
+	if collected, err := getFile(path.Join(constants.SECURITY_DIR, constants.SECURITY_AGGREGATED_CLUSTER_ROLES)); err == nil {
+		var aggregated []collectedAggregatedClusterRole
+		if err := json.Unmarshal(collected, &aggregated); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal aggregated clusterroles")
+		}
+		for _, clusterRole := range aggregated {
+			rules[clusterRole.Name] = clusterRole.Rules
+		}
+	}
+
+	wideSubjects := a.analyzer.WideSubjects
+	if len(wideSubjects) == 0 {
+		wideSubjects = defaultWideSubjects
+	}
+
+	issues := findOverPermissiveBindings(clusterRoleBindings, rules, wideSubjects, a.analyzer.Allowlist)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:  a.Title(),
+				IsFail: true,
+				Message: fmt.Sprintf("ClusterRoleBinding %s grants %s to %s through ClusterRole %s. Bind the role to specific users or service accounts, or replace it with a role listing only the verbs and resources they need.",
+					issue.Binding, issue.Permissions, issue.Subjects, issue.ClusterRole),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterRoleBinding",
+			Name:       issue.Binding,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No ClusterRoleBindings grant wildcard permissions to wide subjects",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// findOverPermissiveBindings returns the bindings of ClusterRoles with wildcard verbs or resources
+// that have at least one wide subject
+func findOverPermissiveBindings(bindings []rbacv1.ClusterRoleBinding, rules map[string][]rbacv1.PolicyRule, wideSubjects, allowlist []string) []overPermissiveBindingIssue {
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].Name < bindings[j].Name
+	})
+
+	issues := []overPermissiveBindingIssue{}
+	for _, binding := range bindings {
+		if matchesAnyGlob(allowlist, binding.Name) || binding.RoleRef.Kind != "ClusterRole" {
+			continue
+		}
+
+		permissions := []string{}
+		for _, rule := range rules[binding.RoleRef.Name] {
+			if slices.Contains(rule.Verbs, rbacv1.VerbAll) || slices.Contains(rule.Resources, rbacv1.ResourceAll) {
+				permissions = append(permissions, formatPolicyRule(rule))
+			}
+		}
+		if len(permissions) == 0 {
+			continue
+		}
+
+		subjects := []string{}
+		for _, subject := range binding.Subjects {
+			name := subject.Name
+			if subject.Kind == rbacv1.ServiceAccountKind {
+				name = fmt.Sprintf("%s/%s", subject.Namespace, subject.Name)
+			}
+			if matchesAnyGlob(wideSubjects, fmt.Sprintf("%s:%s", subject.Kind, name)) {
+				subjects = append(subjects, fmt.Sprintf("%s %s", subject.Kind, name))
+			}
+		}
+		if len(subjects) == 0 {
+			continue
+		}
+
+		issues = append(issues, overPermissiveBindingIssue{
+			Binding:     binding.Name,
+			ClusterRole: binding.RoleRef.Name,
+			Subjects:    strings.Join(subjects, ", "),
+			Permissions: strings.Join(permissions, "; "),
+		})
+	}
+
+	return issues
+}
+
+func matchesAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// formatPolicyRule describes a rule as "<verbs> on <resources> in apiGroups <groups>", with the
+// core api group shown as core
+func formatPolicyRule(rule rbacv1.PolicyRule) string {
+	verbs := strings.Join(rule.Verbs, ",")
+	if len(rule.NonResourceURLs) > 0 {
+		return fmt.Sprintf("%s on %s", verbs, strings.Join(rule.NonResourceURLs, ","))
+	}
+
+	groups := []string{}
+	for _, group := range rule.APIGroups {
+		if group == "" {
+			group = "core"
+		}
+		groups = append(groups, group)
+	}
+	return fmt.Sprintf("%s on %s in apiGroups %s", verbs, strings.Join(rule.Resources, ","), strings.Join(groups, ","))
+}
@@ -0,0 +1,135 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeOverPermissiveBindings(t *testing.T) {
+	bindingReference := func(name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding", Name: name}
+	}
+
+	clusterResources := map[string][]byte{
+		"cluster-resources/clusterroles.json":        []byte(overPermissiveBindingsClusterRoles),
+		"cluster-resources/clusterrolebindings.json": []byte(overPermissiveBindingsClusterRoleBindings),
+	}
+	withAggregated := map[string][]byte{
+		"security/aggregated-clusterroles.json": []byte(overPermissiveBindingsAggregatedClusterRoles),
+	}
+	for n, b := range clusterResources {
+		withAggregated[n] = b
+	}
+
+	tests := []struct {
+		name         string
+		files        map[string][]byte
+		analyzer     troubleshootv1beta2.OverPermissiveBindingsAnalyze
+		expectResult []AnalyzeResult
+		expectErr    string
+	}{
+		{
+			name:     "wildcard roles bound to wide subjects, including resolved aggregated roles",
+			files:    withAggregated,
+			analyzer: troubleshootv1beta2.OverPermissiveBindingsAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Over-permissive ClusterRoleBindings",
+					Message:        "ClusterRoleBinding acme-anonymous-admin grants * on * in apiGroups *; * on * to User system:anonymous through ClusterRole cluster-admin. Bind the role to specific users or service accounts, or replace it with a role listing only the verbs and resources they need.",
+					InvolvedObject: bindingReference("acme-anonymous-admin"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Over-permissive ClusterRoleBindings",
+					Message:        "ClusterRoleBinding acme-ci-admin grants * on * in apiGroups *; * on * to ServiceAccount ci/default through ClusterRole cluster-admin. Bind the role to specific users or service accounts, or replace it with a role listing only the verbs and resources they need.",
+					InvolvedObject: bindingReference("acme-ci-admin"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Over-permissive ClusterRoleBindings",
+					Message:        "ClusterRoleBinding acme-public-view grants get,list,watch on * in apiGroups core to Group system:authenticated through ClusterRole view. Bind the role to specific users or service accounts, or replace it with a role listing only the verbs and resources they need.",
+					InvolvedObject: bindingReference("acme-public-view"),
+				},
+			},
+		},
+		{
+			name:  "allowlisted bindings and custom outcomes",
+			files: clusterResources,
+			analyzer: troubleshootv1beta2.OverPermissiveBindingsAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Binding }} -> {{ .ClusterRole }}: {{ .Subjects }}",
+						},
+					},
+				},
+				Allowlist: []string{"acme-anonymous-*"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Over-permissive ClusterRoleBindings",
+					Message:        "acme-ci-admin -> cluster-admin: ServiceAccount ci/default",
+					InvolvedObject: bindingReference("acme-ci-admin"),
+				},
+			},
+		},
+		{
+			name:  "custom wide subjects",
+			files: withAggregated,
+			analyzer: troubleshootv1beta2.OverPermissiveBindingsAnalyze{
+				WideSubjects: []string{"Group:system:serviceaccounts*"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Over-permissive ClusterRoleBindings",
+					Message: "No ClusterRoleBindings grant wildcard permissions to wide subjects",
+				},
+			},
+		},
+		{
+			name:  "invalid wide subject pattern",
+			files: clusterResources,
+			analyzer: troubleshootv1beta2.OverPermissiveBindingsAnalyze{
+				WideSubjects: []string{"Group:[system"},
+			},
+			expectErr: `invalid wide subject pattern "Group:[system"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			a := &AnalyzeOverPermissiveBindings{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, nil)
+			if test.expectErr != "" {
+				req.ErrorContains(err, test.expectErr)
+				return
+			}
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+)
+
+// prometheusWhenRegex matches a threshold such as "> 0.9" or ">=90"
+var prometheusWhenRegex = regexp.MustCompile(`^\s*([=!<>]+)\s*(\S+)\s*$`)
+
+type AnalyzePrometheus struct {
+	analyzer *troubleshootv1beta2.PrometheusAnalyze
+}
+
+// prometheusQueryResponse is the response of the Prometheus HTTP API to an instant query
+type prometheusQueryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// prometheusQuerySample is the template data available to outcome messages
+type prometheusQuerySample struct {
+	Value float64
+	// Metric holds the labels of a vector sample, including __name__ when the query keeps it
+	Metric map[string]string
+	// Series is the metric name and labels in the Prometheus text format, e.g. up{job="api"}, and
+	// is empty for a scalar
+	Series string
+}
+
+func (a *AnalyzePrometheus) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	if a.analyzer.QueryName != "" {
+		return fmt.Sprintf("Prometheus %s", a.analyzer.QueryName)
+	}
+	return "Prometheus"
+}
+
+func (a *AnalyzePrometheus) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzePrometheus) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	fileName := a.analyzer.FileName
+	if fileName == "" {
+		if a.analyzer.QueryName == "" {
+			return nil, errors.New("queryName or fileName is required")
+		}
+		fileName = path.Join(constants.PROMETHEUS_DIR, a.analyzer.CollectorName, a.analyzer.QueryName+".json")
+	}
+
+	contents, err := getFile(fileName)
+	if err != nil {
+		return []*AnalyzeResult{{
+			Title:   a.Title(),
+			IsWarn:  true,
+			Message: prometheusNotCollectedMessage(getFile, a.analyzer),
+			Strict:  a.analyzer.Strict.BoolOrDefaultFalse(),
+		}}, nil
+	}
+
+	response := prometheusQueryResponse{}
+	if err := json.Unmarshal(contents, &response); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal prometheus query response %s", fileName)
+	}
+	if response.Status != "success" {
+		return []*AnalyzeResult{{
+			Title:   a.Title(),
+			IsWarn:  true,
+			Message: fmt.Sprintf("The Prometheus query failed: %s: %s", response.ErrorType, response.Error),
+			Strict:  a.analyzer.Strict.BoolOrDefaultFalse(),
+		}}, nil
+	}
+
+	samples, err := prometheusQuerySamples(response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read prometheus query response %s", fileName)
+	}
+
+	results := []*AnalyzeResult{}
+	if len(samples) == 0 {
+		// without a value only the outcomes without a threshold can match
+		result, err := evaluatePrometheusOutcomes(a.analyzer.Outcomes, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+	for i := range samples {
+		result, err := evaluatePrometheusOutcomes(a.analyzer.Outcomes, a.Title(), &samples[i])
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// prometheusNotCollectedMessage explains why the query result is missing, using the errors saved by
+// the prometheus collector when there are any
+func prometheusNotCollectedMessage(getFile getCollectedFileContents, analyzer *troubleshootv1beta2.PrometheusAnalyze) string {
+	if analyzer.FileName != "" {
+		return fmt.Sprintf("The Prometheus query result %s was not collected", analyzer.FileName)
+	}
+
+	errorsFile, err := getFile(path.Join(constants.PROMETHEUS_DIR, analyzer.CollectorName, "errors.json"))
+	if err != nil {
+		return fmt.Sprintf("The Prometheus query %s was not collected", analyzer.QueryName)
+	}
+
+	collectErrors := []string{}
+	if err := json.Unmarshal(errorsFile, &collectErrors); err != nil || len(collectErrors) == 0 {
+		return fmt.Sprintf("The Prometheus query %s was not collected", analyzer.QueryName)
+	}
+	return fmt.Sprintf("The Prometheus query %s was not collected: %s", analyzer.QueryName, strings.Join(collectErrors, ", "))
+}
+
+// prometheusQuerySamples returns the value of a scalar result, or the samples of a vector result in
+// the order Prometheus returned them
+func prometheusQuerySamples(response prometheusQueryResponse) ([]prometheusQuerySample, error) {
+	switch response.Data.ResultType {
+	case "scalar":
+		value, err := parsePrometheusQueryValue(response.Data.Result)
+		if err != nil {
+			return nil, err
+		}
+		return []prometheusQuerySample{{Value: value}}, nil
+
+	case "vector":
+		vector := []struct {
+			Metric map[string]string `json:"metric"`
+			Value  json.RawMessage   `json:"value"`
+		}{}
+		if err := json.Unmarshal(response.Data.Result, &vector); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal vector")
+		}
+
+		samples := []prometheusQuerySample{}
+		for _, s := range vector {
+			value, err := parsePrometheusQueryValue(s.Value)
+			if err != nil {
+				return nil, err
+			}
+			samples = append(samples, prometheusQuerySample{
+				Value:  value,
+				Metric: s.Metric,
+				Series: prometheusSeries(s.Metric),
+			})
+		}
+		return samples, nil
+	}
+
+	return nil, errors.Errorf("unsupported result type %q, expected scalar or vector", response.Data.ResultType)
+}
+
+// parsePrometheusQueryValue parses a [<unix time>, "<value>"] pair. Values are strings so that NaN
+// and infinities can be represented.
+func parsePrometheusQueryValue(data json.RawMessage) (float64, error) {
+	pair := []json.RawMessage{}
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return 0, errors.Wrap(err, "failed to unmarshal sample value")
+	}
+	if len(pair) != 2 {
+		return 0, errors.Errorf("expected a timestamp and a value, got %s", string(data))
+	}
+
+	s := ""
+	if err := json.Unmarshal(pair[1], &s); err != nil {
+		return 0, errors.Wrap(err, "failed to unmarshal sample value")
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse sample value %q", s)
+	}
+	return value, nil
+}
+
+// prometheusSeries formats a metric as name{label="value", ...} with the labels sorted by name
+func prometheusSeries(metric map[string]string) string {
+	labels := []string{}
+	for name, value := range metric {
+		if name == "__name__" {
+			continue
+		}
+		labels = append(labels, fmt.Sprintf("%s=%q", name, value))
+	}
+	sort.Strings(labels)
+
+	if len(labels) == 0 {
+		return metric["__name__"]
+	}
+	return fmt.Sprintf("%s{%s}", metric["__name__"], strings.Join(labels, ", "))
+}
+
+// evaluatePrometheusOutcomes returns a result for the first outcome whose "when" threshold matches
+// the value of sample, an empty "when" always matches. sample is nil when the query returned no
+// samples, only outcomes without a threshold match then. nil is returned when no outcome matches.
+func evaluatePrometheusOutcomes(outcomes []*troubleshootv1beta2.Outcome, title string, sample *prometheusQuerySample) (*AnalyzeResult, error) {
+	for _, outcome := range outcomes {
+		result := &AnalyzeResult{
+			Title: title,
+		}
+
+		var single *troubleshootv1beta2.SingleOutcome
+		switch {
+		case outcome.Fail != nil:
+			single = outcome.Fail
+			result.IsFail = true
+		case outcome.Warn != nil:
+			single = outcome.Warn
+			result.IsWarn = true
+		case outcome.Pass != nil:
+			single = outcome.Pass
+			result.IsPass = true
+		default:
+			continue
+		}
+
+		if single.When != "" {
+			match, err := comparePrometheusValue(single.When, sample)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+		}
+
+		result.Message = single.Message
+		if sample != nil {
+			result.Message = renderTemplate(single.Message, sample)
+		}
+		result.URI = single.URI
+		return result, nil
+	}
+
+	return nil, nil
+}
+
+// comparePrometheusValue compares the value of sample with a threshold such as "> 0.9". The
+// threshold is checked even when there is no sample so that a mistake in the spec is reported.
+func comparePrometheusValue(when string, sample *prometheusQuerySample) (bool, error) {
+	parts := prometheusWhenRegex.FindStringSubmatch(when)
+	if parts == nil {
+		return false, errors.Errorf("invalid when condition %q, expected an operator and a number such as \"> 0.9\"", when)
+	}
+
+	op, err := ParseComparisonOperator(parts[1])
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse when condition %q", when)
+	}
+	threshold, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse threshold of when condition %q", when)
+	}
+
+	if sample == nil {
+		return false, nil
+	}
+
+	value := sample.Value
+	switch op {
+	case Equal:
+		return value == threshold, nil
+	case NotEqual:
+		return value != threshold, nil
+	case LessThan:
+		return value < threshold, nil
+	case LessThanOrEqual:
+		return value <= threshold, nil
+	case GreaterThan:
+		return value > threshold, nil
+	case GreaterThanOrEqual:
+		return value >= threshold, nil
+	}
+
+	return false, errors.Errorf("unknown operator in when condition %q", when)
+}
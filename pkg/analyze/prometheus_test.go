@@ -0,0 +1,174 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzePrometheus(t *testing.T) {
+	diskOutcomes := []*troubleshootv1beta2.Outcome{
+		{
+			Fail: &troubleshootv1beta2.SingleOutcome{
+				When:    "> 0.9",
+				Message: "{{ .Metric.instance }} disk is {{ .Value }} full",
+			},
+		},
+		{
+			Warn: &troubleshootv1beta2.SingleOutcome{
+				When:    ">=0.8",
+				Message: "{{ .Series }} is {{ .Value }}",
+			},
+		},
+		{
+			Pass: &troubleshootv1beta2.SingleOutcome{
+				Message: "{{ .Metric.instance }} has enough disk space",
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.PrometheusAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+		expectErr    string
+	}{
+		{
+			name: "vector samples are evaluated on their own",
+			analyzer: troubleshootv1beta2.PrometheusAnalyze{
+				CollectorName: "slo",
+				QueryName:     "disk",
+				Outcomes:      diskOutcomes,
+			},
+			files: map[string][]byte{
+				"metrics/prometheus/slo/disk.json": []byte(`{"status":"success","data":{"resultType":"vector","result":[
+					{"metric":{"instance":"node-1","mountpoint":"/"},"value":[1760600000.123,"0.95"]},
+					{"metric":{"__name__":"disk_used_ratio","instance":"node-2","mountpoint":"/"},"value":[1760600000.123,"0.85"]},
+					{"metric":{"instance":"node-3","mountpoint":"/"},"value":[1760600000.123,"0.4"]}
+				]}}`),
+			},
+			expectResult: []AnalyzeResult{
+				{IsFail: true, Title: "Prometheus disk", Message: "node-1 disk is 0.95 full"},
+				{IsWarn: true, Title: "Prometheus disk", Message: `disk_used_ratio{instance="node-2", mountpoint="/"} is 0.85`},
+				{IsPass: true, Title: "Prometheus disk", Message: "node-3 has enough disk space"},
+			},
+		},
+		{
+			name: "scalar",
+			analyzer: troubleshootv1beta2.PrometheusAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{CheckName: "Error Rate"},
+				QueryName:   "error-rate",
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{Fail: &troubleshootv1beta2.SingleOutcome{When: "> 0.05", Message: "Error rate is {{ .Value }}"}},
+					{Pass: &troubleshootv1beta2.SingleOutcome{When: "<= 0.05", Message: "Error rate is {{ .Value }}"}},
+				},
+			},
+			files: map[string][]byte{
+				"metrics/prometheus/error-rate.json": []byte(`{"status":"success","data":{"resultType":"scalar","result":[1760600000,"0.125"]}}`),
+			},
+			expectResult: []AnalyzeResult{
+				{IsFail: true, Title: "Error Rate", Message: "Error rate is 0.125"},
+			},
+		},
+		{
+			name: "empty vector matches outcomes without a threshold",
+			analyzer: troubleshootv1beta2.PrometheusAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{CheckName: "Firing Alerts"},
+				FileName:    "alerts/firing.json",
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{Fail: &troubleshootv1beta2.SingleOutcome{When: "== 1", Message: "{{ .Metric.alertname }} is firing"}},
+					{Pass: &troubleshootv1beta2.SingleOutcome{Message: "No alerts are firing"}},
+				},
+			},
+			files: map[string][]byte{
+				"alerts/firing.json": []byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`),
+			},
+			expectResult: []AnalyzeResult{
+				{IsPass: true, Title: "Firing Alerts", Message: "No alerts are firing"},
+			},
+		},
+		{
+			name: "failed query",
+			analyzer: troubleshootv1beta2.PrometheusAnalyze{
+				QueryName: "disk",
+				Outcomes:  diskOutcomes,
+			},
+			files: map[string][]byte{
+				"metrics/prometheus/disk.json": []byte(`{"status":"error","errorType":"bad_data","error":"invalid parameter \"query\": 1:5: parse error"}`),
+			},
+			expectResult: []AnalyzeResult{
+				{IsWarn: true, Title: "Prometheus disk", Message: `The Prometheus query failed: bad_data: invalid parameter "query": 1:5: parse error`},
+			},
+		},
+		{
+			name: "not collected",
+			analyzer: troubleshootv1beta2.PrometheusAnalyze{
+				QueryName: "disk",
+				Outcomes:  diskOutcomes,
+			},
+			files: map[string][]byte{
+				"metrics/prometheus/errors.json": []byte(`["no prometheus service was found"]`),
+			},
+			expectResult: []AnalyzeResult{
+				{IsWarn: true, Title: "Prometheus disk", Message: "The Prometheus query disk was not collected: no prometheus service was found"},
+			},
+		},
+		{
+			name: "matrix is not supported",
+			analyzer: troubleshootv1beta2.PrometheusAnalyze{
+				QueryName: "disk",
+				Outcomes:  diskOutcomes,
+			},
+			files: map[string][]byte{
+				"metrics/prometheus/disk.json": []byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`),
+			},
+			expectErr: `unsupported result type "matrix"`,
+		},
+		{
+			name: "invalid threshold",
+			analyzer: troubleshootv1beta2.PrometheusAnalyze{
+				QueryName: "error-rate",
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{Fail: &troubleshootv1beta2.SingleOutcome{When: "above 0.05"}},
+				},
+			},
+			files: map[string][]byte{
+				"metrics/prometheus/error-rate.json": []byte(`{"status":"success","data":{"resultType":"scalar","result":[1760600000,"0.125"]}}`),
+			},
+			expectErr: `invalid when condition "above 0.05"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.Errorf("%s was not collected", n)
+			}
+
+			a := &AnalyzePrometheus{
+				analyzer: &test.analyzer,
+			}
+
+			results, err := a.Analyze(getFile, nil)
+			if test.expectErr != "" {
+				req.ErrorContains(err, test.expectErr)
+				return
+			}
+			req.NoError(err)
+
+			actual := []AnalyzeResult{}
+			for _, result := range results {
+				actual = append(actual, *result)
+			}
+			req.Equal(test.expectResult, actual)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// prometheusSample is a single sample of the Prometheus text exposition format
+type prometheusSample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// parsePrometheusText parses metrics in the Prometheus text exposition format. Comments, including
+// the HELP and TYPE lines, and sample timestamps are ignored.
+func parsePrometheusText(data []byte) ([]prometheusSample, error) {
+	samples := []prometheusSample{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		sample, err := parsePrometheusSample(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse line %d", lineNumber)
+		}
+		samples = append(samples, sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read metrics")
+	}
+
+	return samples, nil
+}
+
+func parsePrometheusSample(line string) (prometheusSample, error) {
+	sample := prometheusSample{
+		Labels: map[string]string{},
+	}
+
+	nameEnd := strings.IndexAny(line, "{ \t")
+	if nameEnd <= 0 {
+		return sample, errors.New("missing sample value")
+	}
+	sample.Name = line[:nameEnd]
+	rest := line[nameEnd:]
+
+	if strings.HasPrefix(rest, "{") {
+		labels, remaining, err := parsePrometheusLabels(rest[1:])
+		if err != nil {
+			return sample, err
+		}
+		sample.Labels = labels
+		rest = remaining
+	}
+
+	// the value may be followed by a timestamp
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return sample, errors.New("missing sample value")
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, errors.Wrapf(err, "invalid value for %s", sample.Name)
+	}
+	sample.Value = value
+
+	return sample, nil
+}
+
+// parsePrometheusLabels parses the labels following the opening brace and returns them with the
+// rest of the line after the closing brace
+func parsePrometheusLabels(s string) (map[string]string, string, error) {
+	labels := map[string]string{}
+
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if strings.HasPrefix(s, "}") {
+			return labels, s[1:], nil
+		}
+
+		nameEnd := strings.Index(s, "=")
+		if nameEnd <= 0 {
+			return nil, "", errors.New("invalid label")
+		}
+		name := strings.TrimSpace(s[:nameEnd])
+		s = strings.TrimLeft(s[nameEnd+1:], " \t")
+		if !strings.HasPrefix(s, `"`) {
+			return nil, "", errors.Errorf("unquoted value for label %s", name)
+		}
+
+		value := strings.Builder{}
+		i := 1
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			value.WriteByte(s[i])
+		}
+		if i == len(s) {
+			return nil, "", errors.Errorf("unterminated value for label %s", name)
+		}
+		labels[name] = value.String()
+
+		s = strings.TrimLeft(s[i+1:], " \t")
+		s = strings.TrimPrefix(s, ",")
+	}
+}
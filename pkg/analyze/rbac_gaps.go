@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type AnalyzeRBACGaps struct {
+	analyzer *troubleshootv1beta2.RBACGapsAnalyze
+}
+
+func (a *AnalyzeRBACGaps) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "RBAC Gaps"
+}
+
+func (a *AnalyzeRBACGaps) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+// Analyze reports a result for each namespace that was skipped, the outcome messages are
+// templates of the collect.RBACGap of the namespace
+func (a *AnalyzeRBACGaps) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	collected, err := getFile(path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s.json", constants.CLUSTER_RESOURCES_RBAC_GAPS)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get contents of %s.json", constants.CLUSTER_RESOURCES_RBAC_GAPS)
+	}
+
+	var gaps []collect.RBACGap
+	if err := json.Unmarshal(collected, &gaps); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal rbac gaps")
+	}
+
+	results := []*AnalyzeResult{}
+	for _, gap := range gaps {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), gap)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: rbacGapMessage(gap),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+			Name:       gap.Namespace,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No namespace was skipped for lack of permissions",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func rbacGapMessage(gap collect.RBACGap) string {
+	resource := gap.Resource
+	if gap.APIGroup != "" {
+		resource = fmt.Sprintf("%s.%s", gap.Resource, gap.APIGroup)
+	}
+
+	message := fmt.Sprintf("The support bundle is incomplete due to RBAC: the resources of namespace %s were not collected, the collector is not allowed to %s %s", gap.Namespace, gap.Verb, resource)
+	if gap.EvaluationError != "" {
+		message = fmt.Sprintf("%s (%s)", message, gap.EvaluationError)
+	}
+	return message
+}
@@ -0,0 +1,123 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeRBACGaps(t *testing.T) {
+	namespaceReference := func(name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "v1", Kind: "Namespace", Name: name}
+	}
+
+	gaps := `[
+  {"namespace": "kube-system", "verb": "get", "apiGroup": "", "resource": "pods"},
+  {"namespace": "monitoring", "verb": "get", "apiGroup": "", "resource": "pods", "evaluationError": "webhook authorizer failed"}
+]`
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.RBACGapsAnalyze
+		gaps         string
+		expectResult []AnalyzeResult
+		expectErr    bool
+	}{
+		{
+			name: "skipped namespaces",
+			gaps: gaps,
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "RBAC Gaps",
+					Message:        "The support bundle is incomplete due to RBAC: the resources of namespace kube-system were not collected, the collector is not allowed to get pods",
+					InvolvedObject: namespaceReference("kube-system"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "RBAC Gaps",
+					Message:        "The support bundle is incomplete due to RBAC: the resources of namespace monitoring were not collected, the collector is not allowed to get pods (webhook authorizer failed)",
+					InvolvedObject: namespaceReference("monitoring"),
+				},
+			},
+		},
+		{
+			name: "custom outcomes",
+			analyzer: troubleshootv1beta2.RBACGapsAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Bundle Completeness",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "Grant {{ .Verb }} on {{ .Resource }} in {{ .Namespace }}",
+						},
+					},
+				},
+			},
+			gaps: gaps,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Bundle Completeness",
+					Message:        "Grant get on pods in kube-system",
+					InvolvedObject: namespaceReference("kube-system"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Bundle Completeness",
+					Message:        "Grant get on pods in monitoring",
+					InvolvedObject: namespaceReference("monitoring"),
+				},
+			},
+		},
+		{
+			name: "no gaps",
+			gaps: `[]`,
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "RBAC Gaps",
+					Message: "No namespace was skipped for lack of permissions",
+				},
+			},
+		},
+		{
+			name:      "not collected",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if n == "cluster-resources/rbac-gaps.json" && test.gaps != "" {
+					return []byte(test.gaps), nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			a := &AnalyzeRBACGaps{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, nil)
+			if test.expectErr {
+				req.Error(err)
+				return
+			}
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
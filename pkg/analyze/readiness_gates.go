@@ -0,0 +1,154 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type AnalyzeReadinessGates struct {
+	analyzer *troubleshootv1beta2.ReadinessGatesAnalyze
+}
+
+// readinessGateIssue is the template data available to outcome messages, one is reported for
+// each readiness gate holding back a pod
+type readinessGateIssue struct {
+	Namespace string
+	Pod       string
+	// Condition is the condition type of the readiness gate, e.g.
+	// target-health.elbv2.k8s.aws/k8s-default-api-1f2e3d4c5b
+	Condition string
+	// Status is the status of the condition, empty when the condition has not been set
+	Status  string
+	Reason  string
+	Message string
+	// ContainersReady is true when the readiness gate is the only thing keeping the pod from being
+	// ready
+	ContainersReady bool
+}
+
+func (a *AnalyzeReadinessGates) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Pod Readiness Gates"
+}
+
+func (a *AnalyzeReadinessGates) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeReadinessGates) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	pods, err := readCollectedPods(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range findReadinessGateIssues(pods) {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: readinessGateIssueMessage(issue),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  issue.Namespace,
+			Name:       issue.Pod,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No pods are held back by a readiness gate",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// findReadinessGateIssues returns the readiness gates of the pods that are not True, ordered by pod
+// namespace and name. Ready pods and pods that have completed are left out.
+func findReadinessGateIssues(pods []corev1.Pod) []readinessGateIssue {
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	issues := []readinessGateIssue{}
+	for _, pod := range pods {
+		if len(pod.Spec.ReadinessGates) == 0 || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		conditions := map[corev1.PodConditionType]corev1.PodCondition{}
+		for _, condition := range pod.Status.Conditions {
+			conditions[condition.Type] = condition
+		}
+		if conditions[corev1.PodReady].Status == corev1.ConditionTrue {
+			continue
+		}
+
+		for _, gate := range pod.Spec.ReadinessGates {
+			condition, ok := conditions[gate.ConditionType]
+			if ok && condition.Status == corev1.ConditionTrue {
+				continue
+			}
+			issues = append(issues, readinessGateIssue{
+				Namespace:       pod.Namespace,
+				Pod:             pod.Name,
+				Condition:       string(gate.ConditionType),
+				Status:          string(condition.Status),
+				Reason:          condition.Reason,
+				Message:         condition.Message,
+				ContainersReady: conditions[corev1.ContainersReady].Status == corev1.ConditionTrue,
+			})
+		}
+	}
+
+	return issues
+}
+
+func readinessGateIssueMessage(issue readinessGateIssue) string {
+	if issue.Status == "" {
+		return fmt.Sprintf("Pod %s/%s is not ready, readiness gate %s has not been set by its controller", issue.Namespace, issue.Pod, issue.Condition)
+	}
+
+	message := fmt.Sprintf("Pod %s/%s is not ready, readiness gate %s is %s", issue.Namespace, issue.Pod, issue.Condition, issue.Status)
+	details := []string{}
+	for _, detail := range []string{issue.Reason, issue.Message} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	if len(details) > 0 {
+		message = fmt.Sprintf("%s: %s", message, strings.Join(details, ": "))
+	}
+	return message
+}
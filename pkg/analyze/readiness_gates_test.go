@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeReadinessGates(t *testing.T) {
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.ReadinessGatesAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "failing and unset readiness gates",
+			analyzer: troubleshootv1beta2.ReadinessGatesAnalyze{},
+			files: map[string][]byte{
+				"cluster-resources/pods/default.json": []byte(readinessGatesPodsDefault),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "Pod Readiness Gates",
+					Message: "Pod default/api-6d8f7b9c4-7xk2p is not ready, readiness gate target-health.elbv2.k8s.aws/k8s-default-api-1f2e3d4c5b is False: Target.FailedHealthChecks: Health checks failed",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "api-6d8f7b9c4-7xk2p",
+					},
+				},
+				{
+					IsWarn:  true,
+					Title:   "Pod Readiness Gates",
+					Message: "Pod default/web-5b7c9d8f6-2hjkl is not ready, readiness gate cloud.google.com/load-balancer-neg-ready has not been set by its controller",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "web-5b7c9d8f6-2hjkl",
+					},
+				},
+			},
+		},
+		{
+			name: "outcomes",
+			analyzer: troubleshootv1beta2.ReadinessGatesAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Load Balancer Registration",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Pod }} waits on {{ .Condition }} ({{ .Status }}, {{ .Reason }}), containers ready: {{ .ContainersReady }}",
+						},
+					},
+				},
+			},
+			files: map[string][]byte{
+				"cluster-resources/pods/default.json": []byte(readinessGatesPodsDefault),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "Load Balancer Registration",
+					Message: "api-6d8f7b9c4-7xk2p waits on target-health.elbv2.k8s.aws/k8s-default-api-1f2e3d4c5b (False, Target.FailedHealthChecks), containers ready: true",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "api-6d8f7b9c4-7xk2p",
+					},
+				},
+				{
+					IsFail:  true,
+					Title:   "Load Balancer Registration",
+					Message: "web-5b7c9d8f6-2hjkl waits on cloud.google.com/load-balancer-neg-ready (, ), containers ready: true",
+					InvolvedObject: &corev1.ObjectReference{
+						APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "web-5b7c9d8f6-2hjkl",
+					},
+				},
+			},
+		},
+		{
+			name:     "no pods with readiness gates",
+			analyzer: troubleshootv1beta2.ReadinessGatesAnalyze{},
+			files:    map[string][]byte{},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Pod Readiness Gates",
+					Message: "No pods are held back by a readiness gate",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			findFiles := func(n string, _ []string) (map[string][]byte, error) {
+				req.Equal("cluster-resources/pods/*.json", n)
+				return test.files, nil
+			}
+
+			a := &AnalyzeReadinessGates{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(nil, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
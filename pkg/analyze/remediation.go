@@ -0,0 +1,150 @@
+package analyzer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+)
+
+// getOutcomes returns the outcomes of the analyzer set in an Analyze or HostAnalyze spec
+func getOutcomes(spec interface{}) []*troubleshootv1beta2.Outcome {
+	reflected := reflect.ValueOf(spec).Elem()
+	for i := 0; i < reflected.NumField(); i++ {
+		if reflected.Field(i).Kind() != reflect.Ptr || reflected.Field(i).IsNil() {
+			continue
+		}
+
+		field := reflect.Indirect(reflected.Field(i)).FieldByName("Outcomes")
+		if !field.IsValid() {
+			continue
+		}
+		outcomes, ok := field.Interface().([]*troubleshootv1beta2.Outcome)
+		if !ok {
+			continue
+		}
+		return outcomes
+	}
+
+	return nil
+}
+
+// attachRemediation sets the remediation steps of the outcome each result was built from. The
+// outcome is the first of the result's severity with the same message, or the only outcome of that
+// severity when messages were rendered from templates.
+func attachRemediation(results []*AnalyzeResult, outcomes []*troubleshootv1beta2.Outcome) {
+	for _, result := range results {
+		if result == nil || len(result.Remediation) > 0 {
+			continue
+		}
+
+		candidates := []*troubleshootv1beta2.SingleOutcome{}
+		for _, outcome := range outcomes {
+			var single *troubleshootv1beta2.SingleOutcome
+			switch {
+			case result.IsFail:
+				single = outcome.Fail
+			case result.IsWarn:
+				single = outcome.Warn
+			case result.IsPass:
+				single = outcome.Pass
+			}
+			if single != nil {
+				candidates = append(candidates, single)
+			}
+		}
+
+		var matched *troubleshootv1beta2.SingleOutcome
+		for _, candidate := range candidates {
+			if candidate.Message == result.Message {
+				matched = candidate
+				break
+			}
+		}
+		if matched == nil && len(candidates) == 1 {
+			matched = candidates[0]
+		}
+		if matched != nil && len(matched.Remediation) > 0 {
+			result.Remediation = append([]troubleshootv1beta2.RemediationStep{}, matched.Remediation...)
+		}
+	}
+}
+
+// remediationEntry is a remediation step with the result it fixes
+type remediationEntry struct {
+	step   troubleshootv1beta2.RemediationStep
+	result *AnalyzeResult
+}
+
+// ExportRemediationScript returns a bash script with the remediation steps of the failed and warning
+// results, ordered by priority. The commands of automatable steps are run by the script, the other
+// steps are only added as comments to be reviewed and run by hand. Passing and suppressed results
+// are left out.
+func ExportRemediationScript(results []*AnalyzeResult) (string, error) {
+	entries := []remediationEntry{}
+	for _, result := range results {
+		if result == nil || result.IsPass || result.Suppression != nil {
+			continue
+		}
+		for _, step := range result.Remediation {
+			if step.IsAutomatable && strings.TrimSpace(step.Command) == "" {
+				return "", errors.Errorf("automatable remediation step of %q has no command", result.Title)
+			}
+			entries = append(entries, remediationEntry{step: step, result: result})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].step.Priority < entries[j].step.Priority
+	})
+
+	var b strings.Builder
+	b.WriteString("#!/usr/bin/env bash\n")
+	b.WriteString("#\n")
+	b.WriteString("# Remediation steps exported from the analysis results. Review the commands before running\n")
+	b.WriteString("# this script, steps that can't be automated are commented out.\n")
+	b.WriteString("set -euo pipefail\n")
+
+	if len(entries) == 0 {
+		b.WriteString("\n# No remediation steps\n")
+		return b.String(), nil
+	}
+
+	for i, entry := range entries {
+		severity := "warn"
+		if entry.result.IsFail {
+			severity = "fail"
+		}
+
+		b.WriteString("\n")
+		writeComment(&b, fmt.Sprintf("[%d] %s (%s, priority %d)", i+1, entry.result.Title, severity, entry.step.Priority))
+		writeComment(&b, entry.result.Message)
+		writeComment(&b, entry.step.Description)
+
+		switch {
+		case entry.step.Command == "":
+		case entry.step.IsAutomatable:
+			b.WriteString(strings.TrimSpace(entry.step.Command))
+			b.WriteString("\n")
+		default:
+			b.WriteString("# Not automatable, run by hand after review:\n")
+			writeComment(&b, entry.step.Command)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// writeComment writes each line of text as a comment, nothing is written for empty text
+func writeComment(b *strings.Builder, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(strings.TrimRight("# "+line, " "))
+		b.WriteString("\n")
+	}
+}
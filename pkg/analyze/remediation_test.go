@@ -0,0 +1,162 @@
+package analyzer
+
+import (
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachRemediation(t *testing.T) {
+	restart := []troubleshootv1beta2.RemediationStep{{Description: "Restart the deployment", Command: "kubectl rollout restart deployment/api", IsAutomatable: true}}
+	scale := []troubleshootv1beta2.RemediationStep{{Description: "Scale up the deployment", Command: "kubectl scale deployment/api --replicas=3"}}
+
+	outcomes := []*troubleshootv1beta2.Outcome{
+		{Fail: &troubleshootv1beta2.SingleOutcome{When: "absent", Message: "The deployment is missing"}},
+		{Fail: &troubleshootv1beta2.SingleOutcome{When: "< 1", Message: "No replicas are ready", Remediation: restart}},
+		{Warn: &troubleshootv1beta2.SingleOutcome{When: "< 3", Message: "Only {{ .Ready }} replicas are ready", Remediation: scale}},
+		{Pass: &troubleshootv1beta2.SingleOutcome{Message: "All replicas are ready"}},
+	}
+
+	tests := []struct {
+		name   string
+		result *AnalyzeResult
+		want   []troubleshootv1beta2.RemediationStep
+	}{
+		{
+			name:   "same message",
+			result: &AnalyzeResult{IsFail: true, Message: "No replicas are ready"},
+			want:   restart,
+		},
+		{
+			name:   "matching outcome without remediation",
+			result: &AnalyzeResult{IsFail: true, Message: "The deployment is missing"},
+		},
+		{
+			name:   "only outcome of the severity",
+			result: &AnalyzeResult{IsWarn: true, Message: "Only 2 replicas are ready"},
+			want:   scale,
+		},
+		{
+			name:   "ambiguous outcome",
+			result: &AnalyzeResult{IsFail: true, Message: "Failed to parse the deployment"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachRemediation([]*AnalyzeResult{tt.result}, outcomes)
+			assert.Equal(t, tt.want, tt.result.Remediation)
+		})
+	}
+}
+
+func TestGetOutcomes(t *testing.T) {
+	outcomes := []*troubleshootv1beta2.Outcome{{Pass: &troubleshootv1beta2.SingleOutcome{Message: "ok"}}}
+
+	assert.Equal(t, outcomes, getOutcomes(&troubleshootv1beta2.Analyze{
+		DeploymentStatus: &troubleshootv1beta2.DeploymentStatus{Outcomes: outcomes},
+	}))
+	assert.Equal(t, outcomes, getOutcomes(&troubleshootv1beta2.HostAnalyze{
+		Memory: &troubleshootv1beta2.MemoryAnalyze{Outcomes: outcomes},
+	}))
+	assert.Nil(t, getOutcomes(&troubleshootv1beta2.Analyze{}))
+}
+
+func TestExportRemediationScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []*AnalyzeResult
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "ordered by priority",
+			results: []*AnalyzeResult{
+				{
+					IsWarn:  true,
+					Title:   "API replicas",
+					Message: "Only 2 replicas are ready",
+					Remediation: []troubleshootv1beta2.RemediationStep{
+						{Description: "Check the node capacity", Command: "kubectl describe nodes", Priority: 2},
+						{Description: "Scale up the deployment", Command: "kubectl scale deployment/api --replicas=3", IsAutomatable: true, Priority: 3},
+					},
+				},
+				{
+					IsPass:      true,
+					Title:       "Cluster version",
+					Remediation: []troubleshootv1beta2.RemediationStep{{Command: "echo pass", IsAutomatable: true}},
+				},
+				{
+					IsFail:      true,
+					Title:       "Ingress",
+					Suppression: &Suppression{},
+					Remediation: []troubleshootv1beta2.RemediationStep{{Command: "echo suppressed", IsAutomatable: true}},
+				},
+				{
+					IsFail:  true,
+					Title:   "Database",
+					Message: "The database is not running\nCheck the logs",
+					Remediation: []troubleshootv1beta2.RemediationStep{
+						{Description: "Restart the database", Command: "kubectl rollout restart statefulset/db", IsAutomatable: true, Priority: 1},
+					},
+				},
+			},
+			want: `#!/usr/bin/env bash
+#
+# Remediation steps exported from the analysis results. Review the commands before running
+# this script, steps that can't be automated are commented out.
+set -euo pipefail
+
+# [1] Database (fail, priority 1)
+# The database is not running
+# Check the logs
+# Restart the database
+kubectl rollout restart statefulset/db
+
+# [2] API replicas (warn, priority 2)
+# Only 2 replicas are ready
+# Check the node capacity
+# Not automatable, run by hand after review:
+# kubectl describe nodes
+
+# [3] API replicas (warn, priority 3)
+# Only 2 replicas are ready
+# Scale up the deployment
+kubectl scale deployment/api --replicas=3
+`,
+		},
+		{
+			name:    "no remediation",
+			results: []*AnalyzeResult{{IsFail: true, Title: "Database"}},
+			want: `#!/usr/bin/env bash
+#
+# Remediation steps exported from the analysis results. Review the commands before running
+# this script, steps that can't be automated are commented out.
+set -euo pipefail
+
+# No remediation steps
+`,
+		},
+		{
+			name: "automatable step without command",
+			results: []*AnalyzeResult{{
+				IsFail:      true,
+				Title:       "Database",
+				Remediation: []troubleshootv1beta2.RemediationStep{{Description: "Restart the database", IsAutomatable: true}},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExportRemediationScript(tt.results)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
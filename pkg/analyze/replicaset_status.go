@@ -171,13 +171,14 @@ func replicasetStatus(outcomes []*troubleshootv1beta2.Outcome, replicaset *appsv
 		IconKey: "kubernetes_deployment_status",                                                  // TODO: needs new icon
 		IconURI: "https://troubleshoot.sh/images/analyzer-icons/deployment-status.svg?w=17&h=17", // TODO: needs new icon
 	}
+	templateContext := outcomeTemplateContext(replicaset.Name, replicaset.Namespace, replicaset.Status)
 
 	// ordering from the spec is important, the first one that matches returns
 	for _, outcome := range outcomes {
 		if outcome.Fail != nil {
 			if outcome.Fail.When == "" {
 				result.IsFail = true
-				result.Message = outcome.Fail.Message
+				result.Message = renderOutcomeMessage(outcome.Fail.Message, templateContext)
 				result.URI = outcome.Fail.URI
 
 				return result, nil
@@ -190,7 +191,7 @@ func replicasetStatus(outcomes []*troubleshootv1beta2.Outcome, replicaset *appsv
 
 			if match {
 				result.IsFail = true
-				result.Message = outcome.Fail.Message
+				result.Message = renderOutcomeMessage(outcome.Fail.Message, templateContext)
 				result.URI = outcome.Fail.URI
 
 				return result, nil
@@ -198,7 +199,7 @@ func replicasetStatus(outcomes []*troubleshootv1beta2.Outcome, replicaset *appsv
 		} else if outcome.Warn != nil {
 			if outcome.Warn.When == "" {
 				result.IsWarn = true
-				result.Message = outcome.Warn.Message
+				result.Message = renderOutcomeMessage(outcome.Warn.Message, templateContext)
 				result.URI = outcome.Warn.URI
 
 				return result, nil
@@ -211,7 +212,7 @@ func replicasetStatus(outcomes []*troubleshootv1beta2.Outcome, replicaset *appsv
 
 			if match {
 				result.IsWarn = true
-				result.Message = outcome.Warn.Message
+				result.Message = renderOutcomeMessage(outcome.Warn.Message, templateContext)
 				result.URI = outcome.Warn.URI
 
 				return result, nil
@@ -219,7 +220,7 @@ func replicasetStatus(outcomes []*troubleshootv1beta2.Outcome, replicaset *appsv
 		} else if outcome.Pass != nil {
 			if outcome.Pass.When == "" {
 				result.IsPass = true
-				result.Message = outcome.Pass.Message
+				result.Message = renderOutcomeMessage(outcome.Pass.Message, templateContext)
 				result.URI = outcome.Pass.URI
 
 				return result, nil
@@ -232,7 +233,7 @@ func replicasetStatus(outcomes []*troubleshootv1beta2.Outcome, replicaset *appsv
 
 			if match {
 				result.IsPass = true
-				result.Message = outcome.Pass.Message
+				result.Message = renderOutcomeMessage(outcome.Pass.Message, templateContext)
 				result.URI = outcome.Pass.URI
 
 				return result, nil
@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type AnalyzeRequiredNamespaces struct {
+	analyzer *troubleshootv1beta2.RequiredNamespacesAnalyze
+}
+
+// requiredNamespaceIssue is the template data available to outcome messages, one is reported for
+// each required namespace that is missing or not Active
+type requiredNamespaceIssue struct {
+	Namespace string
+	// Status is "missing" when the namespace was not collected, otherwise the namespace phase,
+	// e.g. "Terminating"
+	Status string
+}
+
+func (a *AnalyzeRequiredNamespaces) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Required Namespaces"
+}
+
+func (a *AnalyzeRequiredNamespaces) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeRequiredNamespaces) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	if len(a.analyzer.Namespaces) == 0 {
+		return nil, errors.New("no required namespaces specified")
+	}
+
+	namespaces, err := readCollectedNamespaces(getFile)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range findRequiredNamespaceIssues(a.analyzer.Namespaces, namespaces) {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			message := fmt.Sprintf("Namespace %s is %s", issue.Namespace, issue.Status)
+			if issue.Status == "missing" {
+				message = fmt.Sprintf("Namespace %s does not exist", issue.Namespace)
+			}
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsFail:  true,
+				Message: message,
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+			Name:       issue.Namespace,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "All required namespaces exist and are Active",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// findRequiredNamespaceIssues returns the required namespaces, in the order they are listed, that
+// were not collected or are not Active. A namespace being deleted is Terminating even if its phase
+// has not been updated yet.
+func findRequiredNamespaceIssues(required []string, namespaces []corev1.Namespace) []requiredNamespaceIssue {
+	byName := map[string]corev1.Namespace{}
+	for _, namespace := range namespaces {
+		byName[namespace.Name] = namespace
+	}
+
+	issues := []requiredNamespaceIssue{}
+	for _, name := range required {
+		namespace, ok := byName[name]
+		switch {
+		case !ok:
+			issues = append(issues, requiredNamespaceIssue{Namespace: name, Status: "missing"})
+		case namespace.DeletionTimestamp != nil || namespace.Status.Phase == corev1.NamespaceTerminating:
+			issues = append(issues, requiredNamespaceIssue{Namespace: name, Status: string(corev1.NamespaceTerminating)})
+		case namespace.Status.Phase != "" && namespace.Status.Phase != corev1.NamespaceActive:
+			issues = append(issues, requiredNamespaceIssue{Namespace: name, Status: string(namespace.Status.Phase)})
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,131 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeRequiredNamespaces(t *testing.T) {
+	namespaceReference := func(name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "v1", Kind: "Namespace", Name: name}
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.RequiredNamespacesAnalyze
+		namespaces   string
+		expectResult []AnalyzeResult
+	}{
+		{
+			name: "missing and terminating namespaces",
+			analyzer: troubleshootv1beta2.RequiredNamespacesAnalyze{
+				Namespaces: []string{"app", "staging", "legacy", "default"},
+			},
+			namespaces: requiredNamespacesNamespaces,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Required Namespaces",
+					Message:        "Namespace staging does not exist",
+					InvolvedObject: namespaceReference("staging"),
+				},
+				{
+					IsFail:         true,
+					Title:          "Required Namespaces",
+					Message:        "Namespace legacy is Terminating",
+					InvolvedObject: namespaceReference("legacy"),
+				},
+			},
+		},
+		{
+			name: "custom outcomes",
+			analyzer: troubleshootv1beta2.RequiredNamespacesAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "App Namespaces",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "Create the {{ .Namespace }} namespace, it is {{ .Status }}",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "The app namespaces are ready",
+						},
+					},
+				},
+				Namespaces: []string{"staging"},
+			},
+			namespaces: requiredNamespacesNamespaces,
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "App Namespaces",
+					Message:        "Create the staging namespace, it is missing",
+					InvolvedObject: namespaceReference("staging"),
+				},
+			},
+		},
+		{
+			name: "all namespaces active",
+			analyzer: troubleshootv1beta2.RequiredNamespacesAnalyze{
+				Namespaces: []string{"default", "kube-system", "app"},
+			},
+			namespaces: requiredNamespacesNamespaces,
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Required Namespaces",
+					Message: "All required namespaces exist and are Active",
+				},
+			},
+		},
+		{
+			name: "namespaces collected by name",
+			analyzer: troubleshootv1beta2.RequiredNamespacesAnalyze{
+				Namespaces: []string{"app", "legacy"},
+			},
+			namespaces: requiredNamespacesNamespacesSelected,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Required Namespaces",
+					Message:        "Namespace legacy does not exist",
+					InvolvedObject: namespaceReference("legacy"),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if n == "cluster-resources/namespaces.json" {
+					return []byte(test.namespaces), nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			a := &AnalyzeRequiredNamespaces{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, nil)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
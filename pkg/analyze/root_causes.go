@@ -0,0 +1,254 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RootCause is a probable root cause of a group of related failures and warnings. Object is the
+// most upstream object of the group, e.g. the node whose disk pressure evicted the pods of an
+// unavailable deployment, and Evidence lists the findings of every analyzer in the group, the
+// findings on Object first.
+type RootCause struct {
+	Object   corev1.ObjectReference `json:"object" yaml:"object"`
+	Severity string                 `json:"severity" yaml:"severity"`
+	// Score ranks the root causes, each failure counts 2 and each warning 1
+	Score    int                 `json:"score" yaml:"score"`
+	Summary  string              `json:"summary" yaml:"summary"`
+	Evidence []RootCauseEvidence `json:"evidence" yaml:"evidence"`
+}
+
+type RootCauseEvidence struct {
+	Title          string                  `json:"title" yaml:"title"`
+	Message        string                  `json:"message" yaml:"message"`
+	Severity       string                  `json:"severity" yaml:"severity"`
+	InvolvedObject *corev1.ObjectReference `json:"involvedObject" yaml:"involvedObject"`
+}
+
+// rootCauseKindRanks orders the kinds of objects from the most upstream, the lowest rank is the
+// most likely root cause of a group
+var rootCauseKindRanks = map[string]int{
+	"Node":                  0,
+	"Namespace":             1,
+	"PersistentVolume":      2,
+	"PersistentVolumeClaim": 3,
+	"Pod":                   4,
+	"ReplicaSet":            5,
+	"Deployment":            6,
+	"StatefulSet":           6,
+	"DaemonSet":             6,
+	"Job":                   6,
+	"CronJob":               7,
+}
+
+const rootCauseDefaultKindRank = 8
+
+// CorrelateLocal correlates the results of the analysis of a local bundle, see CorrelateResults
+func CorrelateLocal(localBundlePath string, results []*AnalyzeResult) ([]RootCause, error) {
+	rootDir, err := FindBundleRootDir(localBundlePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find root dir")
+	}
+
+	fcp := fileContentProvider{rootDir: rootDir}
+	return CorrelateResults(results, fcp.getFileContents, fcp.getChildFileContents)
+}
+
+// CorrelateResults links the failures and warnings of different analyzers that involve related
+// objects, and returns the groups of two or more findings as probable root causes, the highest
+// score first. Findings are related when they involve the same object, when one involves a
+// namespace and the other an object in it, or through a collected pod that has a finding or is
+// unhealthy: the pod is related to its node, its volume claims and its owners.
+// Suppressed results and results without an involved object are left out.
+func CorrelateResults(results []*AnalyzeResult, getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]RootCause, error) {
+	findings := []*AnalyzeResult{}
+	findingKeys := map[string]bool{}
+	for _, result := range results {
+		if result == nil || result.InvolvedObject == nil || result.Suppression != nil || !(result.IsFail || result.IsWarn) {
+			continue
+		}
+		findings = append(findings, result)
+		findingKeys[rootCauseObjectKey(*result.InvolvedObject)] = true
+	}
+	if len(findings) < 2 {
+		return []RootCause{}, nil
+	}
+
+	pods, err := readCollectedPods(findFiles, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := newRootCauseGroups()
+	for _, pod := range pods {
+		podKey := rootCauseObjectKey(corev1.ObjectReference{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name})
+		if !findingKeys[podKey] && !k8sutil.IsPodUnhealthy(&pod) {
+			continue
+		}
+		for _, related := range podRelatedObjects(pod) {
+			if key := rootCauseObjectKey(related); findingKeys[key] {
+				groups.union(podKey, key)
+			}
+		}
+	}
+	for _, finding := range findings {
+		object := finding.InvolvedObject
+		if object.Namespace == "" {
+			continue
+		}
+		namespaceKey := rootCauseObjectKey(corev1.ObjectReference{Kind: "Namespace", Name: object.Namespace})
+		if findingKeys[namespaceKey] {
+			groups.union(namespaceKey, rootCauseObjectKey(*object))
+		}
+	}
+
+	grouped := map[string][]*AnalyzeResult{}
+	groupOrder := []string{}
+	for _, finding := range findings {
+		root := groups.find(rootCauseObjectKey(*finding.InvolvedObject))
+		if _, ok := grouped[root]; !ok {
+			groupOrder = append(groupOrder, root)
+		}
+		grouped[root] = append(grouped[root], finding)
+	}
+
+	rootCauses := []RootCause{}
+	for _, root := range groupOrder {
+		if len(grouped[root]) < 2 {
+			continue
+		}
+		rootCauses = append(rootCauses, newRootCause(grouped[root]))
+	}
+
+	sort.SliceStable(rootCauses, func(i, j int) bool {
+		return rootCauses[i].Score > rootCauses[j].Score
+	})
+
+	return rootCauses, nil
+}
+
+// newRootCause returns the root cause of a group of related findings
+func newRootCause(findings []*AnalyzeResult) RootCause {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i].InvolvedObject, findings[j].InvolvedObject
+		if rootCauseKindRank(a.Kind) != rootCauseKindRank(b.Kind) {
+			return rootCauseKindRank(a.Kind) < rootCauseKindRank(b.Kind)
+		}
+		if findings[i].IsFail != findings[j].IsFail {
+			return findings[i].IsFail
+		}
+		return rootCauseObjectKey(*a) < rootCauseObjectKey(*b)
+	})
+
+	rootCause := RootCause{
+		Object:   *findings[0].InvolvedObject,
+		Severity: "warn",
+	}
+	rootKey := rootCauseObjectKey(rootCause.Object)
+
+	affected := []string{}
+	for _, finding := range findings {
+		severity := "warn"
+		if finding.IsFail {
+			severity = "fail"
+			rootCause.Severity = "fail"
+			rootCause.Score += 2
+		} else {
+			rootCause.Score++
+		}
+		rootCause.Evidence = append(rootCause.Evidence, RootCauseEvidence{
+			Title:          finding.Title,
+			Message:        finding.Message,
+			Severity:       severity,
+			InvolvedObject: finding.InvolvedObject,
+		})
+
+		if key := rootCauseObjectKey(*finding.InvolvedObject); key != rootKey && !slices.Contains(affected, key) {
+			affected = append(affected, key)
+		}
+	}
+
+	rootCause.Summary = fmt.Sprintf("%s is the probable root cause of the findings on %s", rootCauseObjectKey(rootCause.Object), strings.Join(affected, ", "))
+	if len(affected) == 0 {
+		rootCause.Summary = fmt.Sprintf("%s has %d related findings", rootCauseObjectKey(rootCause.Object), len(findings))
+	}
+	return rootCause
+}
+
+// podRelatedObjects returns the node a pod runs on, its namespace, its volume claims and its
+// owners
+func podRelatedObjects(pod corev1.Pod) []corev1.ObjectReference {
+	related := []corev1.ObjectReference{{Kind: "Namespace", Name: pod.Namespace}}
+	if pod.Spec.NodeName != "" {
+		related = append(related, corev1.ObjectReference{Kind: "Node", Name: pod.Spec.NodeName})
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			related = append(related, corev1.ObjectReference{Kind: "PersistentVolumeClaim", Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName})
+		}
+	}
+	return append(related, podOwners(pod)...)
+}
+
+// podOwners returns the owners of a pod, each replica set followed by the deployment that owns it.
+// The deployment is found with the pod-template-hash label, replica sets are named after their
+// deployment and that hash.
+func podOwners(pod corev1.Pod) []corev1.ObjectReference {
+	owners := []corev1.ObjectReference{}
+	for _, owner := range pod.OwnerReferences {
+		owners = append(owners, corev1.ObjectReference{APIVersion: owner.APIVersion, Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name})
+		hash := pod.Labels["pod-template-hash"]
+		if owner.Kind == "ReplicaSet" && hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			owners = append(owners, corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: pod.Namespace, Name: strings.TrimSuffix(owner.Name, "-"+hash)})
+		}
+	}
+	return owners
+}
+
+func rootCauseKindRank(kind string) int {
+	if rank, ok := rootCauseKindRanks[kind]; ok {
+		return rank
+	}
+	return rootCauseDefaultKindRank
+}
+
+// rootCauseObjectKey identifies an object by kind, namespace and name, e.g. Pod default/api-0 or
+// Node worker-1
+func rootCauseObjectKey(object corev1.ObjectReference) string {
+	if object.Namespace == "" {
+		return fmt.Sprintf("%s %s", object.Kind, object.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", object.Kind, object.Namespace, object.Name)
+}
+
+// rootCauseGroups is a union-find of object keys
+type rootCauseGroups struct {
+	parents map[string]string
+}
+
+func newRootCauseGroups() *rootCauseGroups {
+	return &rootCauseGroups{parents: map[string]string{}}
+}
+
+func (g *rootCauseGroups) find(key string) string {
+	parent, ok := g.parents[key]
+	if !ok || parent == key {
+		return key
+	}
+	root := g.find(parent)
+	g.parents[key] = root
+	return root
+}
+
+func (g *rootCauseGroups) union(a, b string) {
+	rootA, rootB := g.find(a), g.find(b)
+	if rootA != rootB {
+		g.parents[rootB] = rootA
+	}
+}
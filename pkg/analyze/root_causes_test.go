@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCorrelateResults(t *testing.T) {
+	node := &corev1.ObjectReference{APIVersion: "v1", Kind: "Node", Name: "ip-10-0-1-21.ec2.internal"}
+	evictedPod1 := &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "api-7d9f8c6b5-k2x4p"}
+	evictedPod2 := &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "api-7d9f8c6b5-q8w3n"}
+	deployment := &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "api"}
+
+	results := []*AnalyzeResult{
+		{
+			IsWarn:         true,
+			Title:          "Deployment Status",
+			Message:        "The api deployment has 1 of 3 replicas available",
+			InvolvedObject: deployment,
+		},
+		{
+			IsFail:         true,
+			Title:          "Pod default/api-7d9f8c6b5-q8w3n status",
+			Message:        "Pod default/api-7d9f8c6b5-q8w3n status is Evicted. Message is: The node was low on resource: ephemeral-storage.",
+			InvolvedObject: evictedPod2,
+		},
+		{
+			IsFail:         true,
+			Title:          "Node Conditions",
+			Message:        "Node ip-10-0-1-21.ec2.internal has DiskPressure",
+			InvolvedObject: node,
+		},
+		{
+			IsFail:         true,
+			Title:          "Pod default/api-7d9f8c6b5-k2x4p status",
+			Message:        "Pod default/api-7d9f8c6b5-k2x4p status is Evicted. Message is: The node was low on resource: ephemeral-storage.",
+			InvolvedObject: evictedPod1,
+		},
+		{
+			IsFail:         true,
+			Title:          "Pod default/worker-5c8d7f9b4-h7t2j status",
+			Message:        "Pod default/worker-5c8d7f9b4-h7t2j status is CrashLoopBackOff",
+			InvolvedObject: &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "worker-5c8d7f9b4-h7t2j"},
+		},
+		{
+			IsPass:         true,
+			Title:          "Deployment Status",
+			Message:        "The worker deployment is ready",
+			InvolvedObject: &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "worker"},
+		},
+		{
+			IsWarn:         true,
+			Title:          "Deployment Replicas",
+			Message:        "The api deployment runs a single replica per zone",
+			InvolvedObject: deployment,
+			Suppression:    &Suppression{Title: "Deployment Replicas"},
+		},
+		{
+			IsWarn:  true,
+			Title:   "Cluster Version",
+			Message: "The cluster is running an old version of Kubernetes",
+		},
+	}
+
+	findFiles := func(n string, _ []string) (map[string][]byte, error) {
+		require.Equal(t, "cluster-resources/pods/*.json", n)
+		return map[string][]byte{
+			"cluster-resources/pods/default.json": []byte(rootCausesPodsDefault),
+		}, nil
+	}
+
+	rootCauses, err := CorrelateResults(results, nil, findFiles)
+	require.NoError(t, err)
+	require.Equal(t, []RootCause{
+		{
+			Object:   *node,
+			Severity: "fail",
+			Score:    7,
+			Summary:  "Node ip-10-0-1-21.ec2.internal is the probable root cause of the findings on Pod default/api-7d9f8c6b5-k2x4p, Pod default/api-7d9f8c6b5-q8w3n, Deployment default/api",
+			Evidence: []RootCauseEvidence{
+				{
+					Title:          "Node Conditions",
+					Message:        "Node ip-10-0-1-21.ec2.internal has DiskPressure",
+					Severity:       "fail",
+					InvolvedObject: node,
+				},
+				{
+					Title:          "Pod default/api-7d9f8c6b5-k2x4p status",
+					Message:        "Pod default/api-7d9f8c6b5-k2x4p status is Evicted. Message is: The node was low on resource: ephemeral-storage.",
+					Severity:       "fail",
+					InvolvedObject: evictedPod1,
+				},
+				{
+					Title:          "Pod default/api-7d9f8c6b5-q8w3n status",
+					Message:        "Pod default/api-7d9f8c6b5-q8w3n status is Evicted. Message is: The node was low on resource: ephemeral-storage.",
+					Severity:       "fail",
+					InvolvedObject: evictedPod2,
+				},
+				{
+					Title:          "Deployment Status",
+					Message:        "The api deployment has 1 of 3 replicas available",
+					Severity:       "warn",
+					InvolvedObject: deployment,
+				},
+			},
+		},
+	}, rootCauses)
+}
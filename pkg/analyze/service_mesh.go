@@ -0,0 +1,373 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	serviceMeshMissingSidecar        = "MissingSidecar"
+	serviceMeshControlPlaneUnhealthy = "ControlPlaneUnhealthy"
+	serviceMeshConfigError           = "ConfigError"
+)
+
+// serviceMeshes are the meshes the service mesh collector detects, keyed by the directory the
+// mesh is saved under
+var serviceMeshes = map[string]struct {
+	displayName string
+	// proxy is the name of the sidecar container
+	proxy string
+}{
+	"istio":   {displayName: "Istio", proxy: "istio-proxy"},
+	"linkerd": {displayName: "Linkerd", proxy: "linkerd-proxy"},
+}
+
+type AnalyzeServiceMesh struct {
+	analyzer *troubleshootv1beta2.ServiceMeshAnalyze
+}
+
+// serviceMeshIssue is the template data available to outcome messages
+type serviceMeshIssue struct {
+	// Mesh is istio or linkerd
+	Mesh string
+	// Problem is one of MissingSidecar, ControlPlaneUnhealthy or ConfigError
+	Problem   string
+	Kind      string
+	Namespace string
+	Name      string
+	// Message describes the problem
+	Message string
+}
+
+func (a *AnalyzeServiceMesh) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Service Mesh"
+}
+
+func (a *AnalyzeServiceMesh) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeServiceMesh) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	meshes := []string{}
+	for mesh := range serviceMeshes {
+		for _, fileName := range []string{"control-plane/deployments.json", "injected-namespaces.json"} {
+			if _, err := getFile(filepath.Join(constants.MESH_DIR, mesh, fileName)); err == nil {
+				meshes = append(meshes, mesh)
+				break
+			}
+		}
+	}
+	sort.Strings(meshes)
+
+	issues := []serviceMeshIssue{}
+	for _, mesh := range meshes {
+		controlPlaneIssues, err := findServiceMeshControlPlaneIssues(getFile, mesh)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, controlPlaneIssues...)
+
+		sidecarIssues, err := findServiceMeshSidecarIssues(getFile, findFiles, mesh, a.analyzer.Namespaces)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, sidecarIssues...)
+
+		configIssues, err := findServiceMeshConfigIssues(findFiles, mesh, a.analyzer.Namespaces)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, configIssues...)
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = defaultServiceMeshIssueResult(a.Title(), issue)
+		}
+		if issue.Name != "" {
+			result.InvolvedObject = &corev1.ObjectReference{
+				Kind:      issue.Kind,
+				Namespace: issue.Namespace,
+				Name:      issue.Name,
+			}
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			message := "No service mesh was detected"
+			if len(meshes) > 0 {
+				message = "The service mesh control plane is healthy and all pods in injected namespaces have sidecars"
+			}
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: message,
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultServiceMeshIssueResult(title string, issue serviceMeshIssue) *AnalyzeResult {
+	mesh := serviceMeshes[issue.Mesh].displayName
+	object := fmt.Sprintf("%s %s/%s", issue.Kind, issue.Namespace, issue.Name)
+
+	var message string
+	switch issue.Problem {
+	case serviceMeshControlPlaneUnhealthy:
+		if issue.Name == "" {
+			message = fmt.Sprintf("The %s control plane is unhealthy: %s. Meshed pods cannot receive configuration or certificates until it recovers.", mesh, issue.Message)
+		} else {
+			message = fmt.Sprintf("The %s control plane %s is unhealthy: %s. Meshed pods cannot receive configuration or certificates until it recovers.", mesh, object, issue.Message)
+		}
+	case serviceMeshMissingSidecar:
+		message = fmt.Sprintf("%s has no %s sidecar although %s sidecar injection is enabled for namespace %s. Restart the pod so the injector adds it, and check the injector webhook if it is still missing.",
+			object, serviceMeshes[issue.Mesh].proxy, mesh, issue.Namespace)
+	default:
+		message = fmt.Sprintf("%s %s has a configuration error: %s", mesh, object, issue.Message)
+	}
+
+	return &AnalyzeResult{
+		Title:   title,
+		IsWarn:  issue.Problem == serviceMeshMissingSidecar,
+		IsFail:  issue.Problem != serviceMeshMissingSidecar,
+		Message: message,
+	}
+}
+
+// findServiceMeshControlPlaneIssues reports control plane deployments with fewer ready replicas
+// than desired, or no control plane at all
+func findServiceMeshControlPlaneIssues(getFile getCollectedFileContents, mesh string) ([]serviceMeshIssue, error) {
+	collected, err := getFile(filepath.Join(constants.MESH_DIR, mesh, "control-plane", "deployments.json"))
+	if err != nil {
+		// the collector could not list the control plane, the errors are in mesh/errors.json
+		return nil, nil
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := json.Unmarshal(collected, &deployments); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s control plane deployments", mesh)
+	}
+
+	if len(deployments.Items) == 0 {
+		return []serviceMeshIssue{{
+			Mesh:    mesh,
+			Problem: serviceMeshControlPlaneUnhealthy,
+			Message: "no control plane deployments were found",
+		}}, nil
+	}
+
+	issues := []serviceMeshIssue{}
+	for _, deployment := range deployments.Items {
+		desired := int32(1)
+		if deployment.Spec.Replicas != nil {
+			desired = *deployment.Spec.Replicas
+		}
+		if deployment.Status.ReadyReplicas >= desired {
+			continue
+		}
+		issues = append(issues, serviceMeshIssue{
+			Mesh:      mesh,
+			Problem:   serviceMeshControlPlaneUnhealthy,
+			Kind:      "Deployment",
+			Namespace: deployment.Namespace,
+			Name:      deployment.Name,
+			Message:   fmt.Sprintf("%d/%d replicas ready", deployment.Status.ReadyReplicas, desired),
+		})
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Namespace != issues[j].Namespace {
+			return issues[i].Namespace < issues[j].Namespace
+		}
+		return issues[i].Name < issues[j].Name
+	})
+
+	return issues, nil
+}
+
+// findServiceMeshSidecarIssues reports the running pods in namespaces with sidecar injection
+// enabled that have no proxy container and have not opted out of injection
+func findServiceMeshSidecarIssues(getFile getCollectedFileContents, findFiles getChildCollectedFileContents, mesh string, namespaces []string) ([]serviceMeshIssue, error) {
+	collected, err := getFile(filepath.Join(constants.MESH_DIR, mesh, "injected-namespaces.json"))
+	if err != nil {
+		return nil, nil
+	}
+
+	injected := []string{}
+	if err := json.Unmarshal(collected, &injected); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s injected namespaces", mesh)
+	}
+	if len(namespaces) > 0 {
+		injected = slices.DeleteFunc(injected, func(namespace string) bool {
+			return !slices.Contains(namespaces, namespace)
+		})
+	}
+	if len(injected) == 0 {
+		return nil, nil
+	}
+
+	pods, err := readCollectedPods(findFiles, injected)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []serviceMeshIssue{}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed || pod.Spec.HostNetwork {
+			continue
+		}
+		proxy := serviceMeshes[mesh].proxy
+		if serviceMeshInjectionDisabled(mesh, pod) || findPodContainer(pod.Spec, proxy) != nil {
+			continue
+		}
+		issues = append(issues, serviceMeshIssue{
+			Mesh:      mesh,
+			Problem:   serviceMeshMissingSidecar,
+			Kind:      "Pod",
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Message:   fmt.Sprintf("no %s container", proxy),
+		})
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Namespace != issues[j].Namespace {
+			return issues[i].Namespace < issues[j].Namespace
+		}
+		return issues[i].Name < issues[j].Name
+	})
+
+	return issues, nil
+}
+
+func serviceMeshInjectionDisabled(mesh string, pod corev1.Pod) bool {
+	switch mesh {
+	case "istio":
+		return pod.Annotations["sidecar.istio.io/inject"] == "false" || pod.Labels["sidecar.istio.io/inject"] == "false"
+	case "linkerd":
+		return pod.Annotations["linkerd.io/inject"] == "disabled"
+	}
+	return false
+}
+
+// findServiceMeshConfigIssues reports the Istio resources whose status has error level validation
+// messages and the VirtualServices bound to a Gateway that does not exist
+func findServiceMeshConfigIssues(findFiles getChildCollectedFileContents, mesh string, namespaces []string) ([]serviceMeshIssue, error) {
+	if mesh != "istio" {
+		return nil, nil
+	}
+
+	collected, err := findFiles(filepath.Join(constants.MESH_DIR, mesh, "*.istio.io", "*.json"), []string{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read collected %s resources", mesh)
+	}
+
+	objects := []unstructured.Unstructured{}
+	gateways := map[string]bool{}
+	for fileName, fileContent := range collected {
+		items := []map[string]interface{}{}
+		if err := json.Unmarshal(fileContent, &items); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", fileName)
+		}
+		for _, item := range items {
+			object := unstructured.Unstructured{Object: item}
+			if object.GetKind() == "Gateway" {
+				gateways[object.GetNamespace()+"/"+object.GetName()] = true
+			}
+			if len(namespaces) > 0 && !slices.Contains(namespaces, object.GetNamespace()) {
+				continue
+			}
+			objects = append(objects, object)
+		}
+	}
+
+	issues := []serviceMeshIssue{}
+	for _, object := range objects {
+		newIssue := func(message string) serviceMeshIssue {
+			return serviceMeshIssue{
+				Mesh:      mesh,
+				Problem:   serviceMeshConfigError,
+				Kind:      object.GetKind(),
+				Namespace: object.GetNamespace(),
+				Name:      object.GetName(),
+				Message:   message,
+			}
+		}
+
+		validationMessages, _, _ := unstructured.NestedSlice(object.Object, "status", "validationMessages")
+		for _, m := range validationMessages {
+			validationMessage, ok := m.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			level, _, _ := unstructured.NestedString(validationMessage, "level")
+			if !strings.EqualFold(level, "ERROR") {
+				continue
+			}
+			code, _, _ := unstructured.NestedString(validationMessage, "type", "code")
+			name, _, _ := unstructured.NestedString(validationMessage, "type", "name")
+			issues = append(issues, newIssue(strings.TrimSpace(fmt.Sprintf("%s %s", code, name))))
+		}
+
+		if object.GetKind() != "VirtualService" {
+			continue
+		}
+		references, _, _ := unstructured.NestedStringSlice(object.Object, "spec", "gateways")
+		for _, reference := range references {
+			if reference == "mesh" {
+				continue
+			}
+			gateway := reference
+			if !strings.Contains(gateway, "/") {
+				gateway = object.GetNamespace() + "/" + gateway
+			}
+			if !gateways[gateway] {
+				issues = append(issues, newIssue(fmt.Sprintf("gateway %s does not exist", gateway)))
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Kind != issues[j].Kind {
+			return issues[i].Kind < issues[j].Kind
+		}
+		if issues[i].Namespace != issues[j].Namespace {
+			return issues[i].Namespace < issues[j].Namespace
+		}
+		return issues[i].Name < issues[j].Name
+	})
+
+	return issues, nil
+}
@@ -0,0 +1,168 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeServiceMesh(t *testing.T) {
+	istioFiles := map[string][]byte{
+		"mesh/istio/control-plane/deployments.json":                     []byte(serviceMeshControlPlaneDeployments),
+		"mesh/istio/injected-namespaces.json":                           []byte(serviceMeshInjectedNamespaces),
+		"mesh/istio/gateways.networking.istio.io/bookinfo.json":         []byte(serviceMeshGatewaysBookinfo),
+		"mesh/istio/virtualservices.networking.istio.io/bookinfo.json":  []byte(serviceMeshVirtualServicesBookinfo),
+		"mesh/istio/destinationrules.networking.istio.io/bookinfo.json": []byte(serviceMeshDestinationRulesBookinfo),
+		"mesh/istio/proxy-status/istiod.json":                           []byte(`[]`),
+		"cluster-resources/pods/bookinfo.json":                          []byte(serviceMeshPodsBookinfo),
+		"cluster-resources/pods/default.json":                           []byte(`{"kind": "PodList", "items": []}`),
+	}
+
+	unhealthyControlPlane := AnalyzeResult{
+		IsFail:         true,
+		Title:          "Service Mesh",
+		Message:        "The Istio control plane Deployment istio-system/istiod-canary is unhealthy: 1/2 replicas ready. Meshed pods cannot receive configuration or certificates until it recovers.",
+		InvolvedObject: &corev1.ObjectReference{Kind: "Deployment", Namespace: "istio-system", Name: "istiod-canary"},
+	}
+
+	tests := []struct {
+		name         string
+		files        map[string][]byte
+		analyzer     troubleshootv1beta2.ServiceMeshAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "istio control plane, sidecar and config issues",
+			files:    istioFiles,
+			analyzer: troubleshootv1beta2.ServiceMeshAnalyze{},
+			expectResult: []AnalyzeResult{
+				unhealthyControlPlane,
+				{
+					IsWarn:         true,
+					Title:          "Service Mesh",
+					Message:        "Pod bookinfo/ratings-v1-77c9f86b4-wz5tn has no istio-proxy sidecar although Istio sidecar injection is enabled for namespace bookinfo. Restart the pod so the injector adds it, and check the injector webhook if it is still missing.",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Pod", Namespace: "bookinfo", Name: "ratings-v1-77c9f86b4-wz5tn"},
+				},
+				{
+					IsFail:         true,
+					Title:          "Service Mesh",
+					Message:        "Istio DestinationRule bookinfo/ratings has a configuration error: IST0101 ReferencedResourceNotFound",
+					InvolvedObject: &corev1.ObjectReference{Kind: "DestinationRule", Namespace: "bookinfo", Name: "ratings"},
+				},
+				{
+					IsFail:         true,
+					Title:          "Service Mesh",
+					Message:        "Istio VirtualService bookinfo/legacy has a configuration error: gateway istio-system/public-gateway does not exist",
+					InvolvedObject: &corev1.ObjectReference{Kind: "VirtualService", Namespace: "bookinfo", Name: "legacy"},
+				},
+			},
+		},
+		{
+			name:  "namespaces limit sidecar and config checks",
+			files: istioFiles,
+			analyzer: troubleshootv1beta2.ServiceMeshAnalyze{
+				Namespaces: []string{"default"},
+			},
+			expectResult: []AnalyzeResult{
+				unhealthyControlPlane,
+			},
+		},
+		{
+			name:  "custom outcomes",
+			files: istioFiles,
+			analyzer: troubleshootv1beta2.ServiceMeshAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Mesh Health",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Problem }} {{ .Kind }} {{ .Namespace }}/{{ .Name }}: {{ .Message }}",
+						},
+					},
+				},
+				Namespaces: []string{"bookinfo"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Mesh Health",
+					Message:        "ControlPlaneUnhealthy Deployment istio-system/istiod-canary: 1/2 replicas ready",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Deployment", Namespace: "istio-system", Name: "istiod-canary"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Mesh Health",
+					Message:        "MissingSidecar Pod bookinfo/ratings-v1-77c9f86b4-wz5tn: no istio-proxy container",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Pod", Namespace: "bookinfo", Name: "ratings-v1-77c9f86b4-wz5tn"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Mesh Health",
+					Message:        "ConfigError DestinationRule bookinfo/ratings: IST0101 ReferencedResourceNotFound",
+					InvolvedObject: &corev1.ObjectReference{Kind: "DestinationRule", Namespace: "bookinfo", Name: "ratings"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Mesh Health",
+					Message:        "ConfigError VirtualService bookinfo/legacy: gateway istio-system/public-gateway does not exist",
+					InvolvedObject: &corev1.ObjectReference{Kind: "VirtualService", Namespace: "bookinfo", Name: "legacy"},
+				},
+			},
+		},
+		{
+			name: "no mesh installed",
+			files: map[string][]byte{
+				"cluster-resources/pods/default.json": []byte(`{"kind": "PodList", "items": []}`),
+			},
+			analyzer: troubleshootv1beta2.ServiceMeshAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Service Mesh",
+					Message: "No service mesh was detected",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeServiceMesh{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -82,14 +82,16 @@ func analyzeOneStatefulsetStatus(analyzer *troubleshootv1beta2.StatefulsetStatus
 			}
 		}
 
+		templateContext := outcomeTemplateContext(analyzer.Name, analyzer.Namespace, nil)
 		if statefulset == nil {
 			exists = false
 			readyReplicas = 0
 		} else {
 			readyReplicas = int(statefulset.Status.ReadyReplicas)
+			templateContext = outcomeTemplateContext(analyzer.Name, analyzer.Namespace, statefulset.Status)
 		}
 		if len(analyzer.Outcomes) > 0 {
-			result, err = commonStatus(analyzer.Outcomes, analyzer.Name, "kubernetes_statefulset_status", "https://troubleshoot.sh/images/analyzer-icons/statefulset-status.svg?w=23&h=14", readyReplicas, exists, "statefulset")
+			result, err = commonStatus(analyzer.Outcomes, analyzer.Name, "kubernetes_statefulset_status", "https://troubleshoot.sh/images/analyzer-icons/statefulset-status.svg?w=23&h=14", readyReplicas, exists, "statefulset", templateContext)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to process status")
 			}
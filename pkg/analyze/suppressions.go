@@ -0,0 +1,112 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/klog/v2"
+)
+
+// Suppression is an entry of an ignore-list. Failures and warnings whose title matches Title, a
+// check name or a glob of check names, are downgraded to informational until Expires.
+type Suppression struct {
+	Title  string `json:"title" yaml:"title"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// Expires is a date, 2006-01-02, or an RFC3339 time. The suppression no longer applies from
+	// that time on.
+	Expires string `json:"expires,omitempty" yaml:"expires,omitempty"`
+}
+
+// SuppressionList is the content of an ignore-list file
+type SuppressionList struct {
+	Suppressions []Suppression `json:"suppressions" yaml:"suppressions"`
+}
+
+// LoadSuppressions reads an ignore-list file, in YAML or JSON
+func LoadSuppressions(filename string) ([]Suppression, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read ignore-list %s", filename)
+	}
+
+	list := SuppressionList{}
+	if err := yaml.Unmarshal(b, &list); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse ignore-list %s", filename)
+	}
+
+	for _, suppression := range list.Suppressions {
+		if suppression.Title == "" {
+			return nil, errors.Errorf("ignore-list %s has an entry without a title", filename)
+		}
+		if _, err := path.Match(suppression.Title, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid title pattern %q in ignore-list %s", suppression.Title, filename)
+		}
+		if _, err := suppression.expiresAt(); err != nil {
+			return nil, errors.Wrapf(err, "invalid expiry for %q in ignore-list %s", suppression.Title, filename)
+		}
+	}
+
+	return list.Suppressions, nil
+}
+
+// SuppressResults sets Suppression on the failures and warnings matching an ignore-list entry that
+// has not expired. Results keep their outcome so the suppression can be audited, but they are
+// reported as informational and not counted as failures or warnings.
+func SuppressResults(results []*AnalyzeResult, suppressions []Suppression) {
+	suppressResults(results, suppressions, time.Now())
+}
+
+func suppressResults(results []*AnalyzeResult, suppressions []Suppression, now time.Time) {
+	active := []Suppression{}
+	for _, suppression := range suppressions {
+		expiresAt, err := suppression.expiresAt()
+		if err != nil {
+			klog.Warningf("ignoring suppression of %q: %v", suppression.Title, err)
+			continue
+		}
+		if !expiresAt.IsZero() && !now.Before(expiresAt) {
+			klog.Warningf("suppression of %q expired on %s", suppression.Title, suppression.Expires)
+			continue
+		}
+		active = append(active, suppression)
+	}
+
+	for _, result := range results {
+		if result == nil || !(result.IsFail || result.IsWarn) {
+			continue
+		}
+		for i := range active {
+			if matched, _ := path.Match(active[i].Title, result.Title); matched {
+				suppression := active[i]
+				result.Suppression = &suppression
+				break
+			}
+		}
+	}
+}
+
+func (s Suppression) expiresAt() (time.Time, error) {
+	if s.Expires == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s.Expires); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s.Expires)
+}
+
+// Summary describes why a result is suppressed and until when, for display next to the result
+func (s Suppression) Summary() string {
+	summary := s.Reason
+	if summary == "" {
+		summary = fmt.Sprintf("matched ignore-list entry %q", s.Title)
+	}
+	if s.Expires != "" {
+		summary = fmt.Sprintf("%s, until %s", summary, s.Expires)
+	}
+	return summary
+}
@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_suppressResults(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		results        []*AnalyzeResult
+		suppressions   []Suppression
+		wantSuppressed map[string]*Suppression
+	}{
+		{
+			name: "by title",
+			results: []*AnalyzeResult{
+				{Title: "Node Count", IsFail: true},
+				{Title: "Kubernetes Version", IsWarn: true},
+			},
+			suppressions: []Suppression{
+				{Title: "Node Count", Reason: "single node dev cluster"},
+			},
+			wantSuppressed: map[string]*Suppression{
+				"Node Count": {Title: "Node Count", Reason: "single node dev cluster"},
+			},
+		},
+		{
+			name: "glob",
+			results: []*AnalyzeResult{
+				{Title: "Pod default/api status", IsWarn: true},
+				{Title: "Pod kube-system/coredns status", IsWarn: true},
+			},
+			suppressions: []Suppression{
+				{Title: "Pod default/* status"},
+			},
+			wantSuppressed: map[string]*Suppression{
+				"Pod default/api status": {Title: "Pod default/* status"},
+			},
+		},
+		{
+			name: "passing results are not suppressed",
+			results: []*AnalyzeResult{
+				{Title: "Node Count", IsPass: true},
+			},
+			suppressions: []Suppression{
+				{Title: "Node Count"},
+			},
+			wantSuppressed: map[string]*Suppression{},
+		},
+		{
+			name: "expiry",
+			results: []*AnalyzeResult{
+				{Title: "Node Count", IsFail: true},
+				{Title: "Storage Class", IsWarn: true},
+				{Title: "Ingress", IsWarn: true},
+			},
+			suppressions: []Suppression{
+				{Title: "Node Count", Expires: "2026-10-16"},
+				{Title: "Storage Class", Expires: "2026-10-17"},
+				{Title: "Ingress", Expires: "2026-10-16T11:59:59Z"},
+			},
+			wantSuppressed: map[string]*Suppression{
+				"Storage Class": {Title: "Storage Class", Expires: "2026-10-17"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suppressResults(tt.results, tt.suppressions, now)
+
+			gotSuppressed := map[string]*Suppression{}
+			for _, result := range tt.results {
+				if result.Suppression != nil {
+					gotSuppressed[result.Title] = result.Suppression
+				}
+			}
+			assert.Equal(t, tt.wantSuppressed, gotSuppressed)
+		})
+	}
+}
+
+func TestLoadSuppressions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Suppression
+		wantErr bool
+	}{
+		{
+			name: "yaml",
+			content: `suppressions:
+- title: Node Count
+  reason: single node dev cluster
+  expires: "2026-12-31"
+- title: Pod default/* status
+`,
+			want: []Suppression{
+				{Title: "Node Count", Reason: "single node dev cluster", Expires: "2026-12-31"},
+				{Title: "Pod default/* status"},
+			},
+		},
+		{
+			name:    "json",
+			content: `{"suppressions": [{"title": "Node Count", "expires": "2026-12-31T00:00:00Z"}]}`,
+			want: []Suppression{
+				{Title: "Node Count", Expires: "2026-12-31T00:00:00Z"},
+			},
+		},
+		{
+			name:    "missing title",
+			content: "suppressions:\n- reason: no title\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid expiry",
+			content: "suppressions:\n- title: Node Count\n  expires: next week\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid pattern",
+			content: "suppressions:\n- title: \"Node [Count\"\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "ignore-list.yaml")
+			require.NoError(t, os.WriteFile(filename, []byte(tt.content), 0644))
+
+			got, err := LoadSuppressions(filename)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -66,25 +66,33 @@ func analyzeTextAnalyze(
 
 	results := []*AnalyzeResult{}
 
+	showContext := analyzer.ShowContext || analyzer.ContextLines > 0
+
 	if analyzer.RegexPattern != "" {
-		for _, fileContents := range collected {
+		for fileName, fileContents := range collected {
 			result, err := analyzeRegexPattern(analyzer.RegexPattern, fileContents, analyzer.Outcomes, title)
 			if err != nil {
 				return nil, err
 			}
 			if result != nil {
+				if showContext {
+					appendMatchContext(result, analyzer.RegexPattern, fileName, fileContents, analyzer.ContextLines)
+				}
 				results = append(results, result)
 			}
 		}
 	}
 
 	if analyzer.RegexGroups != "" {
-		for _, fileContents := range collected {
+		for fileName, fileContents := range collected {
 			result, err := analyzeRegexGroups(analyzer.RegexGroups, fileContents, analyzer.Outcomes, title)
 			if err != nil {
 				return nil, err
 			}
 			if result != nil {
+				if showContext {
+					appendMatchContext(result, analyzer.RegexGroups, fileName, fileContents, analyzer.ContextLines)
+				}
 				results = append(results, result)
 			}
 		}
@@ -259,6 +267,78 @@ func analyzeRegexGroups(pattern string, collected []byte, outcomes []*troublesho
 	return result, nil
 }
 
+// maxContextLineLength is the number of bytes of each line of context shown in a message
+const maxContextLineLength = 500
+
+// appendMatchContext adds the line numbers of the first match of pattern in the file, with
+// contextLines lines before and after it, to the message of the result. Nothing is added when
+// the pattern doesn't match.
+func appendMatchContext(result *AnalyzeResult, pattern string, fileName string, collected []byte, contextLines int) {
+	context := matchContext(pattern, fileName, collected, contextLines)
+	if context == "" {
+		return
+	}
+	if result.Message == "" {
+		result.Message = context
+		return
+	}
+	result.Message = fmt.Sprintf("%s\n\n%s", result.Message, context)
+}
+
+// matchContext returns the lines around the first match of pattern in the file, with the matched
+// lines marked by ">". A match spanning several lines, e.g. of a pattern with (?s) or \n, marks all
+// of them.
+func matchContext(pattern string, fileName string, collected []byte, contextLines int) string {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return ""
+	}
+	loc := re.FindIndex(collected)
+	if loc == nil {
+		return ""
+	}
+
+	lines := strings.Split(string(collected), "\n")
+	// line indexes are 0 based, an empty match at the end of a line is on that line
+	firstLine := bytes.Count(collected[:loc[0]], []byte("\n"))
+	lastLine := firstLine
+	if loc[1] > loc[0] {
+		lastLine = bytes.Count(collected[:loc[1]-1], []byte("\n"))
+	}
+
+	if contextLines < 0 {
+		contextLines = 0
+	}
+	from := firstLine - contextLines
+	if from < 0 {
+		from = 0
+	}
+	to := lastLine + contextLines
+	if to > len(lines)-1 {
+		to = len(lines) - 1
+	}
+
+	var b strings.Builder
+	if firstLine == lastLine {
+		fmt.Fprintf(&b, "%s line %d:", fileName, firstLine+1)
+	} else {
+		fmt.Fprintf(&b, "%s lines %d-%d:", fileName, firstLine+1, lastLine+1)
+	}
+	width := len(strconv.Itoa(to + 1))
+	for i := from; i <= to; i++ {
+		marker := " "
+		if i >= firstLine && i <= lastLine {
+			marker = ">"
+		}
+		line := strings.TrimSuffix(lines[i], "\r")
+		if len(line) > maxContextLineLength {
+			line = line[:maxContextLineLength] + "..."
+		}
+		fmt.Fprintf(&b, "\n%s %*d | %s", marker, width, i+1, line)
+	}
+	return b.String()
+}
+
 // templateRegExGroup takes a tpl and replaces the variables using matches.
 func templateRegExGroup(tpl string, matches map[string]string) (string, error) {
 	t, err := template.New("").Parse(tpl)
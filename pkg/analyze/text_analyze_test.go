@@ -776,6 +776,76 @@ func Test_textAnalyze(t *testing.T) {
 				"text-collector-1/cfile-2.txt":        []byte("Yes it all succeeded"),
 			},
 		},
+		{
+			name: "match with context",
+			analyzer: troubleshootv1beta2.TextAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "The database is out of connections",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "pass",
+						},
+					},
+				},
+				CollectorName: "postgres",
+				FileName:      "postgres.log",
+				RegexPattern:  "too many connections",
+				ContextLines:  1,
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "postgres",
+					Message: "The database is out of connections\n\npostgres/postgres.log line 3:\n  2 | LOG:  checkpoint complete\n> 3 | FATAL:  sorry, too many connections already\n  4 | LOG:  connection received",
+					IconKey: "kubernetes_text_analyze",
+					IconURI: "https://troubleshoot.sh/images/analyzer-icons/text-analyze.svg",
+				},
+			},
+			files: map[string][]byte{
+				"postgres/postgres.log": []byte("LOG:  database system is ready\nLOG:  checkpoint complete\nFATAL:  sorry, too many connections already\nLOG:  connection received\nLOG:  connection authorized\n"),
+			},
+		},
+		{
+			name: "no context without a match",
+			analyzer: troubleshootv1beta2.TextAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "The database is out of connections",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "pass",
+						},
+					},
+				},
+				CollectorName: "postgres",
+				FileName:      "postgres.log",
+				RegexPattern:  "too many connections",
+				ShowContext:   true,
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "postgres",
+					Message: "pass",
+					IconKey: "kubernetes_text_analyze",
+					IconURI: "https://troubleshoot.sh/images/analyzer-icons/text-analyze.svg",
+				},
+			},
+			files: map[string][]byte{
+				"postgres/postgres.log": []byte("LOG:  database system is ready\n"),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -828,6 +898,45 @@ func Test_textAnalyze(t *testing.T) {
 	}
 }
 
+func Test_matchContext(t *testing.T) {
+	contents := []byte("line 1\nline 2\npanic: runtime error\ngoroutine 1 [running]:\nmain.main()\nline 6\n")
+
+	tests := []struct {
+		name         string
+		pattern      string
+		contextLines int
+		want         string
+	}{
+		{
+			name:    "matched line only",
+			pattern: "panic: .*",
+			want:    "app.log line 3:\n> 3 | panic: runtime error",
+		},
+		{
+			name:         "context at the start of the file",
+			pattern:      "line 1",
+			contextLines: 2,
+			want:         "app.log line 1:\n> 1 | line 1\n  2 | line 2\n  3 | panic: runtime error",
+		},
+		{
+			name:         "multi-line match",
+			pattern:      `(?s)panic: .*?main\.main\(\)`,
+			contextLines: 1,
+			want:         "app.log lines 3-5:\n  2 | line 2\n> 3 | panic: runtime error\n> 4 | goroutine 1 [running]:\n> 5 | main.main()\n  6 | line 6",
+		},
+		{
+			name:    "no match",
+			pattern: "fatal error",
+			want:    "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, matchContext(test.pattern, "app.log", contents, test.contextLines))
+		})
+	}
+}
+
 func Test_compareRegex(t *testing.T) {
 	tests := []struct {
 		name         string
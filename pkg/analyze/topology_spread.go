@@ -0,0 +1,290 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	topologyScopeNode = "node"
+	topologyScopeZone = "zone"
+
+	topologyConstraintNone = "none"
+	topologyConstraintSoft = "soft"
+	topologyConstraintHard = "hard"
+)
+
+// node labels that place a node in a zone
+var zoneLabels = []string{corev1.LabelTopologyZone, corev1.LabelFailureDomainBetaZone}
+
+type AnalyzeTopologySpread struct {
+	analyzer *troubleshootv1beta2.TopologySpreadAnalyze
+}
+
+// topologySpreadIssue is the template data available to outcome messages. An issue is reported
+// for a workload with more than one replica when all of its replicas run on a single node, or
+// in a single zone of a cluster with several zones.
+type topologySpreadIssue struct {
+	Namespace string
+	Kind      string
+	Name      string
+	Replicas  int
+	// Scope is "node" when all replicas share a node, or "zone" when they are spread across
+	// nodes of the same zone
+	Scope string
+	// Distribution is the number of replicas running on each node, or in each zone
+	Distribution map[string]int
+	// Constraint is the strongest topology spread constraint or pod anti-affinity the workload
+	// declares for the scope: "none", "soft" (preferred / ScheduleAnyway) or "hard"
+	Constraint string
+}
+
+func (a *AnalyzeTopologySpread) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Topology Spread"
+}
+
+func (a *AnalyzeTopologySpread) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeTopologySpread) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	nodes, err := readCollectedNodes(getFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := readCollectedPods(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := findTopologySpreadIssues(nodes, pods)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = defaultTopologySpreadIssueResult(a.Title(), issue)
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			Kind:      issue.Kind,
+			Namespace: issue.Namespace,
+			Name:      issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No multi-replica workloads are concentrated on a single node or zone",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultTopologySpreadIssueResult(title string, issue topologySpreadIssue) *AnalyzeResult {
+	message := fmt.Sprintf(
+		"All %d replicas of %s %s/%s are running in a single %s (%s), a failure of that %s takes down the whole workload.",
+		issue.Replicas, issue.Kind, issue.Namespace, issue.Name, issue.Scope, formatDistribution(issue.Distribution), issue.Scope,
+	)
+
+	switch issue.Constraint {
+	case topologyConstraintNone:
+		message += fmt.Sprintf(" Add a topologySpreadConstraint or pod anti-affinity on %s.", topologyKeyForScope(issue.Scope))
+	case topologyConstraintSoft:
+		message += fmt.Sprintf(" Its spread constraints on %s are only preferred, make them required to enforce the spread.", topologyKeyForScope(issue.Scope))
+	}
+
+	result := &AnalyzeResult{
+		Title:   title,
+		Message: message,
+	}
+	if issue.Scope == topologyScopeNode {
+		result.IsFail = true
+	} else {
+		result.IsWarn = true
+	}
+	return result
+}
+
+// findTopologySpreadIssues groups scheduled pods by workload and returns the workloads with
+// more than one replica that are concentrated on a single node, or in a single zone when the
+// cluster spans more than one. A workload on a single node is not also reported for its zone.
+// DaemonSets and bare pods are skipped.
+func findTopologySpreadIssues(nodes []corev1.Node, pods []corev1.Pod) []topologySpreadIssue {
+	nodeZones := map[string]string{}
+	schedulableNodes := 0
+	zones := map[string]bool{}
+	for _, node := range nodes {
+		if !node.Spec.Unschedulable {
+			schedulableNodes++
+		}
+		if zone := nodeZone(node); zone != "" {
+			nodeZones[node.Name] = zone
+			zones[zone] = true
+		}
+	}
+
+	type workload struct {
+		namespace string
+		kind      string
+		name      string
+		pods      []corev1.Pod
+	}
+	workloads := map[string]*workload{}
+	keys := []string{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		kind, name := podWorkload(pod)
+		if kind == "Pod" || kind == "DaemonSet" {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%s", pod.Namespace, kind, name)
+		w, ok := workloads[key]
+		if !ok {
+			w = &workload{namespace: pod.Namespace, kind: kind, name: name}
+			workloads[key] = w
+			keys = append(keys, key)
+		}
+		w.pods = append(w.pods, pod)
+	}
+
+	sort.Strings(keys)
+	issues := []topologySpreadIssue{}
+	for _, key := range keys {
+		w := workloads[key]
+		if len(w.pods) < 2 {
+			continue
+		}
+
+		byNode := map[string]int{}
+		byZone := map[string]int{}
+		for _, pod := range w.pods {
+			byNode[pod.Spec.NodeName]++
+			if zone := nodeZones[pod.Spec.NodeName]; zone != "" {
+				byZone[zone]++
+			}
+		}
+
+		issue := topologySpreadIssue{
+			Namespace: w.namespace,
+			Kind:      w.kind,
+			Name:      w.name,
+			Replicas:  len(w.pods),
+		}
+		switch {
+		case len(byNode) == 1 && schedulableNodes > 1:
+			issue.Scope = topologyScopeNode
+			issue.Distribution = byNode
+		case len(byZone) == 1 && len(zones) > 1:
+			issue.Scope = topologyScopeZone
+			issue.Distribution = byZone
+		default:
+			continue
+		}
+
+		issue.Constraint = topologySpreadConstraint(w.pods[0].Spec, topologyKeysForScope(issue.Scope))
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
+
+// topologySpreadConstraint returns the strongest constraint a pod spec declares to spread its
+// replicas across any of the given topology keys
+func topologySpreadConstraint(spec corev1.PodSpec, topologyKeys []string) string {
+	constraint := topologyConstraintNone
+
+	for _, tsc := range spec.TopologySpreadConstraints {
+		if !slices.Contains(topologyKeys, tsc.TopologyKey) {
+			continue
+		}
+		if tsc.WhenUnsatisfiable == corev1.DoNotSchedule {
+			return topologyConstraintHard
+		}
+		constraint = topologyConstraintSoft
+	}
+
+	if spec.Affinity == nil || spec.Affinity.PodAntiAffinity == nil {
+		return constraint
+	}
+	for _, term := range spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		if slices.Contains(topologyKeys, term.TopologyKey) {
+			return topologyConstraintHard
+		}
+	}
+	for _, term := range spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		if slices.Contains(topologyKeys, term.PodAffinityTerm.TopologyKey) {
+			constraint = topologyConstraintSoft
+		}
+	}
+
+	return constraint
+}
+
+func topologyKeysForScope(scope string) []string {
+	if scope == topologyScopeZone {
+		return zoneLabels
+	}
+	return []string{corev1.LabelHostname}
+}
+
+func topologyKeyForScope(scope string) string {
+	return topologyKeysForScope(scope)[0]
+}
+
+func nodeZone(node corev1.Node) string {
+	for _, label := range zoneLabels {
+		if zone := node.Labels[label]; zone != "" {
+			return zone
+		}
+	}
+	return ""
+}
+
+// formatDistribution renders replica counts as "name=count" pairs sorted by name
+func formatDistribution(distribution map[string]int) string {
+	names := make([]string, 0, len(distribution))
+	for name := range distribution {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, distribution[name]))
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeTopologySpread(t *testing.T) {
+	concentratedFiles := map[string][]byte{
+		"cluster-resources/nodes.json":        []byte(topologySpreadNodes),
+		"cluster-resources/pods/default.json": []byte(topologySpreadPods),
+	}
+
+	singleNode := []byte(`{
+		"kind": "NodeList",
+		"apiVersion": "v1",
+		"items": [
+			{"metadata": {"name": "node-a", "labels": {"topology.kubernetes.io/zone": "us-east-1a"}}}
+		]
+	}`)
+
+	apiReference := &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "api"}
+	workerReference := &corev1.ObjectReference{Kind: "StatefulSet", Namespace: "default", Name: "worker"}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.TopologySpreadAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "concentrated replicas with default outcomes",
+			analyzer: troubleshootv1beta2.TopologySpreadAnalyze{},
+			files:    concentratedFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Topology Spread",
+					Message:        "All 3 replicas of Deployment default/api are running in a single node (node-a=3), a failure of that node takes down the whole workload. Add a topologySpreadConstraint or pod anti-affinity on kubernetes.io/hostname.",
+					InvolvedObject: apiReference,
+				},
+				{
+					IsWarn:         true,
+					Title:          "Topology Spread",
+					Message:        "All 2 replicas of StatefulSet default/worker are running in a single zone (us-east-1a=2), a failure of that zone takes down the whole workload. Its spread constraints on topology.kubernetes.io/zone are only preferred, make them required to enforce the spread.",
+					InvolvedObject: workerReference,
+				},
+			},
+		},
+		{
+			name: "templated outcomes",
+			analyzer: troubleshootv1beta2.TopologySpreadAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Warn: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Kind }} {{ .Name }} has {{ .Replicas }} replicas in one {{ .Scope }} {{ .Distribution }}, constraint: {{ .Constraint }}",
+						},
+					},
+				},
+			},
+			files: concentratedFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Topology Spread",
+					Message:        "Deployment api has 3 replicas in one node map[node-a:3], constraint: none",
+					InvolvedObject: apiReference,
+				},
+				{
+					IsWarn:         true,
+					Title:          "Topology Spread",
+					Message:        "StatefulSet worker has 2 replicas in one zone map[us-east-1a:2], constraint: soft",
+					InvolvedObject: workerReference,
+				},
+			},
+		},
+		{
+			name: "single node cluster passes",
+			analyzer: troubleshootv1beta2.TopologySpreadAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "replicas are concentrated",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "replicas are spread",
+						},
+					},
+				},
+			},
+			files: map[string][]byte{
+				"cluster-resources/nodes.json":        singleNode,
+				"cluster-resources/pods/default.json": []byte(topologySpreadPods),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Topology Spread",
+					Message: "replicas are spread",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeTopologySpread{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
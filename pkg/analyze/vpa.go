@@ -0,0 +1,262 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	vpaOverProvisioned  = "over"
+	vpaUnderProvisioned = "under"
+)
+
+type AnalyzeVPA struct {
+	analyzer *troubleshootv1beta2.VPAAnalyze
+}
+
+// verticalPodAutoscaler holds the fields of an autoscaling.k8s.io VerticalPodAutoscaler the
+// analyzer needs
+type verticalPodAutoscaler struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		TargetRef struct {
+			Kind string `json:"kind"`
+			Name string `json:"name"`
+		} `json:"targetRef"`
+	} `json:"spec"`
+	Status struct {
+		Recommendation *struct {
+			ContainerRecommendations []vpaContainerRecommendation `json:"containerRecommendations"`
+		} `json:"recommendation"`
+	} `json:"status"`
+}
+
+type vpaContainerRecommendation struct {
+	ContainerName string              `json:"containerName"`
+	Target        corev1.ResourceList `json:"target"`
+	LowerBound    corev1.ResourceList `json:"lowerBound"`
+	UpperBound    corev1.ResourceList `json:"upperBound"`
+}
+
+// vpaIssue is the template data available to outcome messages. A container is over-provisioned
+// when its request is above the recommended upper bound, and under-provisioned when it is below
+// the lower bound or not set at all.
+type vpaIssue struct {
+	Namespace string
+	// VPA is the name of the VerticalPodAutoscaler
+	VPA       string
+	Kind      string
+	Name      string
+	Container string
+	// Resource is "cpu" or "memory"
+	Resource string
+	// Provisioning is "over" or "under"
+	Provisioning string
+	// Request is empty when the container has no request for the resource
+	Request    string
+	Target     string
+	LowerBound string
+	UpperBound string
+}
+
+func (a *AnalyzeVPA) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Vertical Pod Autoscaler Recommendations"
+}
+
+func (a *AnalyzeVPA) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeVPA) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	vpas, err := readCollectedVPAs(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := readCollectedPods(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := findVPAIssues(vpas, pods)
+
+	results := []*AnalyzeResult{}
+	for _, issue := range issues {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: defaultVPAIssueMessage(issue),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			Kind:      issue.Kind,
+			Namespace: issue.Namespace,
+			Name:      issue.Name,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "Resource requests are within the bounds recommended by VerticalPodAutoscalers",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+func defaultVPAIssueMessage(issue vpaIssue) string {
+	workload := fmt.Sprintf("Container %s of %s %s/%s", issue.Container, issue.Kind, issue.Namespace, issue.Name)
+	switch {
+	case issue.Request == "":
+		return fmt.Sprintf("%s has no %s request, VerticalPodAutoscaler %s recommends %s.", workload, issue.Resource, issue.VPA, issue.Target)
+	case issue.Provisioning == vpaOverProvisioned:
+		return fmt.Sprintf("%s requests %s %s, above the upper bound of %s recommended by VerticalPodAutoscaler %s. Lower the request towards %s to free capacity for other workloads.",
+			workload, issue.Request, issue.Resource, issue.UpperBound, issue.VPA, issue.Target)
+	default:
+		return fmt.Sprintf("%s requests %s %s, below the lower bound of %s recommended by VerticalPodAutoscaler %s. Raise the request towards %s so the scheduler reserves enough for it.",
+			workload, issue.Request, issue.Resource, issue.LowerBound, issue.VPA, issue.Target)
+	}
+}
+
+// findVPAIssues compares the requests of the first running pod of each VPA's target workload
+// against the VPA's recommendation. VPAs without a recommendation or running pods are skipped.
+func findVPAIssues(vpas []verticalPodAutoscaler, pods []corev1.Pod) []vpaIssue {
+	sort.Slice(vpas, func(i, j int) bool {
+		if vpas[i].Namespace != vpas[j].Namespace {
+			return vpas[i].Namespace < vpas[j].Namespace
+		}
+		return vpas[i].Name < vpas[j].Name
+	})
+	sort.Slice(pods, func(i, j int) bool {
+		return podKey(pods[i]) < podKey(pods[j])
+	})
+
+	issues := []vpaIssue{}
+	for _, vpa := range vpas {
+		if vpa.Status.Recommendation == nil {
+			continue
+		}
+
+		idx := slices.IndexFunc(pods, func(pod corev1.Pod) bool {
+			if pod.Namespace != vpa.Namespace || pod.Status.Phase != corev1.PodRunning {
+				return false
+			}
+			kind, name := podWorkload(pod)
+			return kind == vpa.Spec.TargetRef.Kind && name == vpa.Spec.TargetRef.Name
+		})
+		if idx < 0 {
+			continue
+		}
+		pod := pods[idx]
+
+		for _, recommendation := range vpa.Status.Recommendation.ContainerRecommendations {
+			container := findPodContainer(pod.Spec, recommendation.ContainerName)
+			if container == nil {
+				continue
+			}
+
+			for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				target, ok := recommendation.Target[resourceName]
+				if !ok {
+					continue
+				}
+
+				issue := vpaIssue{
+					Namespace: vpa.Namespace,
+					VPA:       vpa.Name,
+					Kind:      vpa.Spec.TargetRef.Kind,
+					Name:      vpa.Spec.TargetRef.Name,
+					Container: container.Name,
+					Resource:  string(resourceName),
+					Target:    target.String(),
+				}
+				lowerBound, hasLowerBound := recommendation.LowerBound[resourceName]
+				if hasLowerBound {
+					issue.LowerBound = lowerBound.String()
+				}
+				upperBound, hasUpperBound := recommendation.UpperBound[resourceName]
+				if hasUpperBound {
+					issue.UpperBound = upperBound.String()
+				}
+
+				request, hasRequest := container.Resources.Requests[resourceName]
+				switch {
+				case !hasRequest:
+					issue.Provisioning = vpaUnderProvisioned
+				case hasUpperBound && request.Cmp(upperBound) > 0:
+					issue.Provisioning = vpaOverProvisioned
+				case hasLowerBound && request.Cmp(lowerBound) < 0:
+					issue.Provisioning = vpaUnderProvisioned
+				default:
+					continue
+				}
+				if hasRequest {
+					issue.Request = request.String()
+				}
+
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	return issues
+}
+
+// readCollectedVPAs returns the VerticalPodAutoscalers saved by the vpa collector
+func readCollectedVPAs(findFiles getChildCollectedFileContents, namespaces []string) ([]verticalPodAutoscaler, error) {
+	collected, err := findFiles(filepath.Join(constants.VPA_DIR, "*.json"), []string{filepath.Join(constants.VPA_DIR, "errors.json")})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read collected vertical pod autoscalers")
+	}
+
+	vpas := []verticalPodAutoscaler{}
+	for fileName, fileContent := range collected {
+		if filepath.Base(fileName) == "errors.json" {
+			continue
+		}
+
+		var fileVPAs []verticalPodAutoscaler
+		if err := json.Unmarshal(fileContent, &fileVPAs); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", fileName)
+		}
+		for _, vpa := range fileVPAs {
+			if len(namespaces) > 0 && !slices.Contains(namespaces, vpa.Namespace) {
+				continue
+			}
+			vpas = append(vpas, vpa)
+		}
+	}
+
+	return vpas, nil
+}
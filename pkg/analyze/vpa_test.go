@@ -0,0 +1,157 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeVPA(t *testing.T) {
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.VPAAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "under and over provisioned containers are reported",
+			analyzer: troubleshootv1beta2.VPAAnalyze{},
+			files: map[string][]byte{
+				"autoscaling/vpa/default.json":            []byte(vpaVerticalPodAutoscalers),
+				"cluster-resources/pods/default.json":     []byte(vpaPods),
+				"autoscaling/vpa/errors.json":             []byte(`[]`),
+				"cluster-resources/pods/kube-system.json": []byte(`{"kind":"PodList","apiVersion":"v1","items":[]}`),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Vertical Pod Autoscaler Recommendations",
+					Message:        "Container api of Deployment default/api requests 2 cpu, above the upper bound of 500m recommended by VerticalPodAutoscaler api. Lower the request towards 250m to free capacity for other workloads.",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "api"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Vertical Pod Autoscaler Recommendations",
+					Message:        "Container api of Deployment default/api requests 256Mi memory, below the lower bound of 400Mi recommended by VerticalPodAutoscaler api. Raise the request towards 512Mi so the scheduler reserves enough for it.",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "api"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Vertical Pod Autoscaler Recommendations",
+					Message:        "Container postgres of StatefulSet default/db has no memory request, VerticalPodAutoscaler db recommends 1536Mi.",
+					InvolvedObject: &corev1.ObjectReference{Kind: "StatefulSet", Namespace: "default", Name: "db"},
+				},
+			},
+		},
+		{
+			name: "custom outcomes are templated with the recommendation",
+			analyzer: troubleshootv1beta2.VPAAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Right-sizing",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Name }}/{{ .Container }} {{ .Resource }} is {{ .Provisioning }}-provisioned, target {{ .Target }} in [{{ .LowerBound }}, {{ .UpperBound }}]",
+						},
+					},
+				},
+			},
+			files: map[string][]byte{
+				"autoscaling/vpa/default.json":        []byte(vpaVerticalPodAutoscalers),
+				"cluster-resources/pods/default.json": []byte(vpaPods),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Right-sizing",
+					Message:        "api/api cpu is over-provisioned, target 250m in [200m, 500m]",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "api"},
+				},
+				{
+					IsFail:         true,
+					Title:          "Right-sizing",
+					Message:        "api/api memory is under-provisioned, target 512Mi in [400Mi, 1Gi]",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "api"},
+				},
+				{
+					IsFail:         true,
+					Title:          "Right-sizing",
+					Message:        "db/postgres memory is under-provisioned, target 1536Mi in [1Gi, 3Gi]",
+					InvolvedObject: &corev1.ObjectReference{Kind: "StatefulSet", Namespace: "default", Name: "db"},
+				},
+			},
+		},
+		{
+			name: "namespaces without vpas pass",
+			analyzer: troubleshootv1beta2.VPAAnalyze{
+				Namespaces: []string{"kube-system"},
+			},
+			files: map[string][]byte{
+				"autoscaling/vpa/default.json":        []byte(vpaVerticalPodAutoscalers),
+				"cluster-resources/pods/default.json": []byte(vpaPods),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Vertical Pod Autoscaler Recommendations",
+					Message: "Resource requests are within the bounds recommended by VerticalPodAutoscalers",
+				},
+			},
+		},
+		{
+			name:     "vpa not installed passes",
+			analyzer: troubleshootv1beta2.VPAAnalyze{},
+			files: map[string][]byte{
+				"cluster-resources/pods/default.json": []byte(vpaPods),
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Vertical Pod Autoscaler Recommendations",
+					Message: "Resource requests are within the bounds recommended by VerticalPodAutoscalers",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeVPA{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+type AnalyzeWaitForFirstConsumer struct {
+	analyzer *troubleshootv1beta2.WaitForFirstConsumerAnalyze
+}
+
+// waitForFirstConsumerDeadlock is the template data available to outcome messages
+type waitForFirstConsumerDeadlock struct {
+	Namespace    string
+	PVC          string
+	StorageClass string
+	Pod          string
+	Reason       string
+}
+
+func (a *AnalyzeWaitForFirstConsumer) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "WaitForFirstConsumer Volume Binding"
+}
+
+func (a *AnalyzeWaitForFirstConsumer) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeWaitForFirstConsumer) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	storageClassesData, err := getFile(fmt.Sprintf("%s/%s.json", constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_STORAGE_CLASS))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read collected storage classes")
+	}
+
+	var storageClasses storagev1.StorageClassList
+	if err := json.Unmarshal(storageClassesData, &storageClasses); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal storage class list")
+	}
+
+	pvcs, err := readCollectedPVCs(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := readCollectedPods(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	deadlocks := findWaitForFirstConsumerDeadlocks(storageClasses.Items, pvcs, pods)
+
+	results := []*AnalyzeResult{}
+	for _, deadlock := range deadlocks {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), deadlock)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:  a.Title(),
+				IsFail: true,
+				Message: fmt.Sprintf(
+					"PersistentVolumeClaim %s/%s uses StorageClass %q with volumeBindingMode WaitForFirstConsumer, so it will stay Pending until pod %s is scheduled, but that pod is unschedulable. Resolve the pod's scheduling constraints (resource requests, node selectors, affinity, taints) or make sure the StorageClass can provision volumes in a topology the pod can be scheduled to. Scheduler message: %s",
+					deadlock.Namespace, deadlock.PVC, deadlock.StorageClass, deadlock.Pod, deadlock.Reason,
+				),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+			Namespace:  deadlock.Namespace,
+			Name:       deadlock.PVC,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No PersistentVolumeClaims are waiting on an unschedulable consumer",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+		result.IconKey = "kubernetes_storage_class"
+		result.IconURI = "https://troubleshoot.sh/images/analyzer-icons/storage-class.svg?w=12&h=12"
+	}
+
+	return results, nil
+}
+
+// findWaitForFirstConsumerDeadlocks returns the Pending PVCs bound to a WaitForFirstConsumer
+// storage class whose consuming pod cannot be scheduled. Neither side can make progress: the
+// volume is only provisioned once the pod is scheduled, and the pod is not scheduled.
+func findWaitForFirstConsumerDeadlocks(storageClasses []storagev1.StorageClass, pvcs []corev1.PersistentVolumeClaim, pods []corev1.Pod) []waitForFirstConsumerDeadlock {
+	bindingModes := map[string]storagev1.VolumeBindingMode{}
+	defaultStorageClass := ""
+	for _, sc := range storageClasses {
+		if sc.VolumeBindingMode != nil {
+			bindingModes[sc.Name] = *sc.VolumeBindingMode
+		}
+		if sc.Annotations["storageclass.kubernetes.io/is-default-class"] == "true" ||
+			sc.Annotations["storageclass.beta.kubernetes.io/is-default-class"] == "true" {
+			defaultStorageClass = sc.Name
+		}
+	}
+
+	deadlocks := []waitForFirstConsumerDeadlock{}
+	for _, pvc := range pvcs {
+		if pvc.Status.Phase != corev1.ClaimPending {
+			continue
+		}
+
+		storageClassName := defaultStorageClass
+		if pvc.Spec.StorageClassName != nil {
+			storageClassName = *pvc.Spec.StorageClassName
+		}
+		if bindingModes[storageClassName] != storagev1.VolumeBindingWaitForFirstConsumer {
+			continue
+		}
+
+		for _, pod := range pods {
+			if pod.Namespace != pvc.Namespace || !podUsesClaim(&pod, pvc.Name) {
+				continue
+			}
+
+			unschedulable, reason := isPodUnschedulable(&pod)
+			if !unschedulable {
+				continue
+			}
+
+			deadlocks = append(deadlocks, waitForFirstConsumerDeadlock{
+				Namespace:    pvc.Namespace,
+				PVC:          pvc.Name,
+				StorageClass: storageClassName,
+				Pod:          pod.Name,
+				Reason:       reason,
+			})
+		}
+	}
+
+	sort.Slice(deadlocks, func(i, j int) bool {
+		if deadlocks[i].Namespace != deadlocks[j].Namespace {
+			return deadlocks[i].Namespace < deadlocks[j].Namespace
+		}
+		if deadlocks[i].PVC != deadlocks[j].PVC {
+			return deadlocks[i].PVC < deadlocks[j].PVC
+		}
+		return deadlocks[i].Pod < deadlocks[j].Pod
+	})
+
+	return deadlocks
+}
+
+func podUsesClaim(pod *corev1.Pod, claimName string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claimName {
+			return true
+		}
+	}
+	return false
+}
+
+// isPodUnschedulable reports whether the scheduler gave up placing a pod, along with the
+// scheduler's explanation
+func isPodUnschedulable(pod *corev1.Pod) (bool, string) {
+	if pod.Status.Phase != corev1.PodPending || pod.Spec.NodeName != "" {
+		return false, ""
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+			return true, condition.Message
+		}
+	}
+
+	return false, ""
+}
@@ -0,0 +1,159 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeWaitForFirstConsumer(t *testing.T) {
+	deadlockFiles := map[string][]byte{
+		"cluster-resources/storage-classes.json": []byte(waitForFirstConsumerStorageClasses),
+		"cluster-resources/pvcs/default.json":    []byte(waitForFirstConsumerPVCs),
+		"cluster-resources/pods/default.json":    []byte(waitForFirstConsumerPods),
+	}
+
+	pvcReference := &corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "PersistentVolumeClaim",
+		Namespace:  "default",
+		Name:       "data-postgres-0",
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.WaitForFirstConsumerAnalyze
+		files        map[string][]byte
+		expectResult []AnalyzeResult
+		err          string
+	}{
+		{
+			name: "deadlock detected with templated outcome",
+			analyzer: troubleshootv1beta2.WaitForFirstConsumerAnalyze{
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Namespace }}/{{ .PVC }} on {{ .StorageClass }} is waiting for {{ .Pod }}",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "no deadlocks",
+						},
+					},
+				},
+			},
+			files: deadlockFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "WaitForFirstConsumer Volume Binding",
+					Message:        "default/data-postgres-0 on local-path is waiting for postgres-0",
+					IconKey:        "kubernetes_storage_class",
+					IconURI:        "https://troubleshoot.sh/images/analyzer-icons/storage-class.svg?w=12&h=12",
+					InvolvedObject: pvcReference,
+				},
+			},
+		},
+		{
+			name: "deadlock detected with default outcome",
+			analyzer: troubleshootv1beta2.WaitForFirstConsumerAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Volume binding",
+				},
+			},
+			files: deadlockFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Volume binding",
+					Message:        `PersistentVolumeClaim default/data-postgres-0 uses StorageClass "local-path" with volumeBindingMode WaitForFirstConsumer, so it will stay Pending until pod postgres-0 is scheduled, but that pod is unschedulable. Resolve the pod's scheduling constraints (resource requests, node selectors, affinity, taints) or make sure the StorageClass can provision volumes in a topology the pod can be scheduled to. Scheduler message: 0/3 nodes are available: 3 Insufficient cpu, 3 Insufficient memory. preemption: 0/3 nodes are available: 3 No preemption victims found for incoming pod.`,
+					IconKey:        "kubernetes_storage_class",
+					IconURI:        "https://troubleshoot.sh/images/analyzer-icons/storage-class.svg?w=12&h=12",
+					InvolvedObject: pvcReference,
+				},
+			},
+		},
+		{
+			name: "namespace without a deadlock passes",
+			analyzer: troubleshootv1beta2.WaitForFirstConsumerAnalyze{
+				Namespaces: []string{"kube-system"},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "deadlock",
+						},
+					},
+					{
+						Pass: &troubleshootv1beta2.SingleOutcome{
+							When:    "false",
+							Message: "no deadlocks",
+						},
+					},
+				},
+			},
+			files: deadlockFiles,
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "WaitForFirstConsumer Volume Binding",
+					Message: "no deadlocks",
+					IconKey: "kubernetes_storage_class",
+					IconURI: "https://troubleshoot.sh/images/analyzer-icons/storage-class.svg?w=12&h=12",
+				},
+			},
+		},
+		{
+			name:     "missing storage classes",
+			analyzer: troubleshootv1beta2.WaitForFirstConsumerAnalyze{},
+			files:    map[string][]byte{},
+			err:      "failed to read collected storage classes: file not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			getFile := func(n string) ([]byte, error) {
+				if b, ok := test.files[n]; ok {
+					return b, nil
+				}
+				return nil, errors.New("file not found")
+			}
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range test.files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeWaitForFirstConsumer{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(getFile, findFiles)
+			if test.err != "" {
+				req.EqualError(err, test.err)
+				return
+			}
+
+			req.NoError(err)
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.ElementsMatch(test.expectResult, unPointered)
+		})
+	}
+}
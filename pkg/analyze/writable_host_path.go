@@ -0,0 +1,182 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultSensitiveHostPaths are the node paths a pod should not be able to write to
+var defaultSensitiveHostPaths = []string{
+	"/",
+	"/etc",
+	"/var/lib",
+	"/var/run/docker.sock",
+	"/run/docker.sock",
+	"/var/run/containerd/containerd.sock",
+	"/run/containerd/containerd.sock",
+	"/var/run/crio/crio.sock",
+	"/run/crio/crio.sock",
+}
+
+type AnalyzeWritableHostPath struct {
+	analyzer *troubleshootv1beta2.WritableHostPathAnalyze
+}
+
+// writableHostPathIssue is the template data available to outcome messages, one is reported for
+// each pod with writable hostPath mounts to sensitive paths
+type writableHostPathIssue struct {
+	Namespace string
+	Pod       string
+	// Paths lists the sensitive host paths mounted read-write
+	Paths string
+	// Mounts describes each of the mounts, e.g. "/etc mounted read-write at /host/etc in container
+	// agent"
+	Mounts string
+}
+
+func (a *AnalyzeWritableHostPath) Title() string {
+	if a.analyzer.CheckName != "" {
+		return a.analyzer.CheckName
+	}
+	return "Writable HostPath Mounts"
+}
+
+func (a *AnalyzeWritableHostPath) IsExcluded() (bool, error) {
+	return isExcluded(a.analyzer.Exclude)
+}
+
+func (a *AnalyzeWritableHostPath) Analyze(getFile getCollectedFileContents, findFiles getChildCollectedFileContents) ([]*AnalyzeResult, error) {
+	pods, err := readCollectedPods(findFiles, a.analyzer.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	sensitivePaths := a.analyzer.SensitivePaths
+	if len(sensitivePaths) == 0 {
+		sensitivePaths = defaultSensitiveHostPaths
+	}
+
+	results := []*AnalyzeResult{}
+	for _, issue := range findWritableHostPathIssues(pods, sensitivePaths) {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, true, a.Title(), issue)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsWarn:  true,
+				Message: fmt.Sprintf("Pod %s/%s has writable hostPath mounts to sensitive paths: %s", issue.Namespace, issue.Pod, issue.Mounts),
+			}
+		}
+		result.InvolvedObject = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  issue.Namespace,
+			Name:       issue.Pod,
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		result, err := evaluateDetectedOutcomes(a.analyzer.Outcomes, false, a.Title(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = &AnalyzeResult{
+				Title:   a.Title(),
+				IsPass:  true,
+				Message: "No pods have writable hostPath mounts to sensitive paths",
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		result.Strict = a.analyzer.Strict.BoolOrDefaultFalse()
+	}
+
+	return results, nil
+}
+
+// findWritableHostPathIssues returns the pods, ordered by namespace and name, with init or app
+// containers that mount a hostPath volume read-write to a sensitive path
+func findWritableHostPathIssues(pods []corev1.Pod, sensitivePaths []string) []writableHostPathIssue {
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	issues := []writableHostPathIssue{}
+	for _, pod := range pods {
+		hostPaths := map[string]string{}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.HostPath != nil {
+				hostPaths[volume.Name] = path.Clean(volume.HostPath.Path)
+			}
+		}
+		if len(hostPaths) == 0 {
+			continue
+		}
+
+		paths := []string{}
+		mounts := []string{}
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, container := range containers {
+			for _, mount := range container.VolumeMounts {
+				hostPath, ok := hostPaths[mount.Name]
+				if !ok || mount.ReadOnly || !isSensitiveHostPath(hostPath, sensitivePaths) {
+					continue
+				}
+				if !slices.Contains(paths, hostPath) {
+					paths = append(paths, hostPath)
+				}
+				mounts = append(mounts, fmt.Sprintf("%s mounted read-write at %s in container %s", hostPath, mount.MountPath, container.Name))
+			}
+		}
+		if len(mounts) == 0 {
+			continue
+		}
+
+		issues = append(issues, writableHostPathIssue{
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			Paths:     strings.Join(paths, ", "),
+			Mounts:    strings.Join(mounts, ", "),
+		})
+	}
+
+	return issues
+}
+
+// isSensitiveHostPath returns true when hostPath is a sensitive path, is under one, or is a parent
+// of one and so gives access to it. Every path is under /, so / only matches itself.
+func isSensitiveHostPath(hostPath string, sensitivePaths []string) bool {
+	for _, sensitive := range sensitivePaths {
+		sensitive = path.Clean(sensitive)
+		if hostPath == sensitive || isUnderPath(sensitive, hostPath) {
+			return true
+		}
+		if sensitive != "/" && isUnderPath(hostPath, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnderPath returns true when p is a descendant of dir
+func isUnderPath(p, dir string) bool {
+	if dir == "/" {
+		return p != "/"
+	}
+	return strings.HasPrefix(p, dir+"/")
+}
@@ -0,0 +1,138 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzeWritableHostPath(t *testing.T) {
+	podReference := func(namespace, name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: namespace, Name: name}
+	}
+
+	files := map[string][]byte{
+		"cluster-resources/pods/default.json":     []byte(writableHostPathPodsDefault),
+		"cluster-resources/pods/kube-system.json": []byte(writableHostPathPodsKubeSystem),
+	}
+
+	tests := []struct {
+		name         string
+		analyzer     troubleshootv1beta2.WritableHostPathAnalyze
+		expectResult []AnalyzeResult
+	}{
+		{
+			name:     "writable mounts to sensitive paths",
+			analyzer: troubleshootv1beta2.WritableHostPathAnalyze{},
+			expectResult: []AnalyzeResult{
+				{
+					IsWarn:         true,
+					Title:          "Writable HostPath Mounts",
+					Message:        "Pod default/backup-agent-7f9c6d5b8-k2xwq has writable hostPath mounts to sensitive paths: /etc mounted read-write at /host/etc in container agent, /var/lib/postgresql mounted read-write at /data in container agent",
+					InvolvedObject: podReference("default", "backup-agent-7f9c6d5b8-k2xwq"),
+				},
+				{
+					IsWarn:         true,
+					Title:          "Writable HostPath Mounts",
+					Message:        "Pod kube-system/image-builder-5d4f8 has writable hostPath mounts to sensitive paths: /var/run mounted read-write at /host/run in container setup, /var/run/docker.sock mounted read-write at /var/run/docker.sock in container builder",
+					InvolvedObject: podReference("kube-system", "image-builder-5d4f8"),
+				},
+			},
+		},
+		{
+			name: "custom sensitive paths and outcomes",
+			analyzer: troubleshootv1beta2.WritableHostPathAnalyze{
+				AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{
+					CheckName: "Host Isolation",
+				},
+				Outcomes: []*troubleshootv1beta2.Outcome{
+					{
+						Fail: &troubleshootv1beta2.SingleOutcome{
+							When:    "true",
+							Message: "{{ .Namespace }}/{{ .Pod }} can write to {{ .Paths }}",
+						},
+					},
+				},
+				SensitivePaths: []string{"/var/log/"},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "Host Isolation",
+					Message:        "kube-system/log-shipper-2nfpd can write to /var/log",
+					InvolvedObject: podReference("kube-system", "log-shipper-2nfpd"),
+				},
+			},
+		},
+		{
+			name: "only benign mounts",
+			analyzer: troubleshootv1beta2.WritableHostPathAnalyze{
+				Namespaces: []string{"default"},
+				SensitivePaths: []string{
+					"/run/containerd/containerd.sock",
+				},
+			},
+			expectResult: []AnalyzeResult{
+				{
+					IsPass:  true,
+					Title:   "Writable HostPath Mounts",
+					Message: "No pods have writable hostPath mounts to sensitive paths",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			findFiles := func(glob string, _ []string) (map[string][]byte, error) {
+				matches := map[string][]byte{}
+				for n, b := range files {
+					if ok, _ := filepath.Match(glob, n); ok {
+						matches[n] = b
+					}
+				}
+				return matches, nil
+			}
+
+			a := &AnalyzeWritableHostPath{
+				analyzer: &test.analyzer,
+			}
+			actual, err := a.Analyze(nil, findFiles)
+			req.NoError(err)
+
+			unPointered := []AnalyzeResult{}
+			for _, v := range actual {
+				unPointered = append(unPointered, *v)
+			}
+			req.Equal(test.expectResult, unPointered)
+		})
+	}
+}
+
+func Test_isSensitiveHostPath(t *testing.T) {
+	tests := []struct {
+		hostPath string
+		want     bool
+	}{
+		{hostPath: "/", want: true},
+		{hostPath: "/etc", want: true},
+		{hostPath: "/etc/kubernetes", want: true},
+		{hostPath: "/var", want: true},
+		{hostPath: "/var/lib/kubelet", want: true},
+		{hostPath: "/var/run", want: true},
+		{hostPath: "/run/containerd/containerd.sock", want: true},
+		{hostPath: "/var/log", want: false},
+		{hostPath: "/etcd", want: false},
+		{hostPath: "/tmp", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.hostPath, func(t *testing.T) {
+			require.Equal(t, test.want, isSensitiveHostPath(test.hostPath, defaultSensitiveHostPaths))
+		})
+	}
+}
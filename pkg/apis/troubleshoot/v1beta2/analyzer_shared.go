@@ -103,6 +103,10 @@ type ClusterPodStatuses struct {
 	AnalyzeMeta `json:",inline" yaml:",inline"`
 	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
 	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	// EphemeralPods are globs of the names of short-lived pods, e.g. "my-tool-*", that are skipped
+	// while they are terminating, like the pods troubleshoot creates to run collectors. A pod also
+	// matches when the name of one of its owners does.
+	EphemeralPods []string `json:"ephemeralPods,omitempty" yaml:"ephemeralPods,omitempty"`
 }
 
 type ClusterContainerStatuses struct {
@@ -158,6 +162,10 @@ type TextAnalyze struct {
 	IgnoreIfNoFiles bool       `json:"ignoreIfNoFiles,omitempty" yaml:"ignoreIfNoFiles,omitempty"`
 	Outcomes        []*Outcome `json:"outcomes" yaml:"outcomes"`
 	ExcludeFiles    []string   `json:"excludeFiles,omitempty" yaml:"excludeFiles,omitempty"`
+	// ShowContext adds the file and line numbers of the first match in each file to the message,
+	// with ContextLines lines before and after it. Setting ContextLines alone also shows it.
+	ShowContext  bool `json:"showContext,omitempty" yaml:"showContext,omitempty"`
+	ContextLines int  `json:"contextLines,omitempty" yaml:"contextLines,omitempty"`
 }
 
 type YamlCompare struct {
@@ -231,6 +239,8 @@ type AnalyzeMeta struct {
 	Exclude     *multitype.BoolOrString `json:"exclude,omitempty" yaml:"exclude,omitempty"`
 	Strict      *multitype.BoolOrString `json:"strict,omitempty" yaml:"strict,omitempty"`
 	Annotations map[string]string       `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	// Blocking makes warnings from this check gate a preflight like failures do
+	Blocking *multitype.BoolOrString `json:"blocking,omitempty" yaml:"blocking,omitempty"`
 }
 
 type CertificatesAnalyze struct {
@@ -271,40 +281,424 @@ type PVCRef struct {
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
+type WaitForFirstConsumerAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+type GitOpsAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+type ImageArchitectureAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+type TopologySpreadAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+type CSRAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	// PendingThreshold is how long a CSR can stay pending before it is reported, defaults to 10m
+	PendingThreshold string `json:"pendingThreshold,omitempty" yaml:"pendingThreshold,omitempty"`
+}
+
+type GoldenSnapshotAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	// Snapshot is the path or URL of a support bundle, archived or extracted, collected from a
+	// cluster in the desired state
+	Snapshot string `json:"snapshot" yaml:"snapshot"`
+	// Resources are the cluster resources to compare, named as in the cluster-resources
+	// directory of the bundle. Defaults to deployments, statefulsets, daemonsets and services.
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+	// Fields are JSONPath expressions evaluated against each resource, defaults to the replica
+	// count and container images
+	Fields     []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+type OOMKilledAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+// APIWarningsAnalyze reports the deprecated APIs the apiserver warned about while the bundle
+// was collected
+type APIWarningsAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	// TargetVersion is the Kubernetes version the cluster is going to be upgraded to. APIs that
+	// are unavailable in this version are reported as failures.
+	TargetVersion string `json:"targetVersion,omitempty" yaml:"targetVersion,omitempty"`
+}
+
+// ConfigMapDriftAnalyze compares keys of the ConfigMaps collected with cluster resources
+// against expected values
+type ConfigMapDriftAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome                  `json:"outcomes" yaml:"outcomes"`
+	ConfigMaps  []ConfigMapDriftExpectation `json:"configMaps" yaml:"configMaps"`
+}
+
+type ConfigMapDriftExpectation struct {
+	Name      string              `json:"name" yaml:"name"`
+	Namespace string              `json:"namespace" yaml:"namespace"`
+	Keys      []ConfigMapDriftKey `json:"keys" yaml:"keys"`
+}
+
+// ConfigMapDriftKey is the expected value of a ConfigMap key, either an exact Value or a Regex
+// that must match the value. Regex takes precedence when both are set, and when neither is set
+// the key only has to exist.
+type ConfigMapDriftKey struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+	Regex string `json:"regex,omitempty" yaml:"regex,omitempty"`
+}
+
+// VPAAnalyze compares the resource requests of running pods against the recommendations of the
+// VerticalPodAutoscalers that target their workloads
+type VPAAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+// ConfigMountsAnalyze flags pods that mount more ConfigMaps and Secrets, or more ConfigMap data,
+// than the given thresholds. MaxMounts defaults to 20 and MaxMountedSize to 1Mi.
+type ConfigMountsAnalyze struct {
+	AnalyzeMeta    `json:",inline" yaml:",inline"`
+	Outcomes       []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces     []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	MaxMounts      int        `json:"maxMounts,omitempty" yaml:"maxMounts,omitempty"`
+	MaxMountedSize string     `json:"maxMountedSize,omitempty" yaml:"maxMountedSize,omitempty"`
+}
+
+// HighAvailabilityAnalyze checks that the workloads expected to be highly available run enough
+// replicas and spread them with pod anti-affinity or topology spread constraints
+type HighAvailabilityAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome                 `json:"outcomes" yaml:"outcomes"`
+	Workloads   []HighAvailabilityWorkload `json:"workloads" yaml:"workloads"`
+}
+
+// HighAvailabilityWorkload selects Deployments and StatefulSets by name or by label selector.
+// Workloads that are not found are not reported.
+type HighAvailabilityWorkload struct {
+	// Kind is Deployment or StatefulSet, both are checked when it is empty
+	Kind      string   `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Namespace string   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name      string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Selector  []string `json:"selector,omitempty" yaml:"selector,omitempty"`
+	// MinReplicas defaults to 2
+	MinReplicas int `json:"minReplicas,omitempty" yaml:"minReplicas,omitempty"`
+}
+
+// OrphanedResourcesAnalyze checks the ownerReferences of collected workloads and pods against the
+// collected owners, and flags ReplicaSets left behind by deleted Deployments
+type OrphanedResourcesAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+// LastAppliedDriftAnalyze compares the last-applied-configuration saved by the clusterResources
+// collector with lastApplied enabled against the live objects, and reports the fields that were
+// changed outside of kubectl apply. Fields under an IgnoreFields path, e.g. spec.replicas for
+// autoscaled workloads, are not reported.
+type LastAppliedDriftAnalyze struct {
+	AnalyzeMeta  `json:",inline" yaml:",inline"`
+	Outcomes     []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces   []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	IgnoreFields []string   `json:"ignoreFields,omitempty" yaml:"ignoreFields,omitempty"`
+}
+
+// APIServerFeaturesAnalyze checks the flags of the kube-apiserver static pods collected from
+// kube-system for the feature gates and admission plugins an application requires. Managed
+// clusters do not run the apiserver as a visible pod, the analyzer warns when no flags are found.
+type APIServerFeaturesAnalyze struct {
+	AnalyzeMeta      `json:",inline" yaml:",inline"`
+	Outcomes         []*Outcome `json:"outcomes" yaml:"outcomes"`
+	FeatureGates     []string   `json:"featureGates,omitempty" yaml:"featureGates,omitempty"`
+	AdmissionPlugins []string   `json:"admissionPlugins,omitempty" yaml:"admissionPlugins,omitempty"`
+}
+
+// ExposedServicesAnalyze reports every NodePort and LoadBalancer service that is not in the
+// Allowlist. Allowlist entries are <namespace>/<name> and may use glob patterns, e.g.
+// ingress-nginx/* or */public-api.
+type ExposedServicesAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	Allowlist   []string   `json:"allowlist,omitempty" yaml:"allowlist,omitempty"`
+}
+
+// ImagePolicyAnalyze inspects the images and pull policies of the containers of collected pods.
+// It reports images with a mutable tag such as latest pulled with Always, which makes rollouts
+// nondeterministic, and with IfNotPresent or Never, which leaves nodes running stale images.
+// MutableTags replaces the default list of tags considered mutable, and with RequireDigest every
+// image not referenced by digest is reported.
+type ImagePolicyAnalyze struct {
+	AnalyzeMeta   `json:",inline" yaml:",inline"`
+	Outcomes      []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces    []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	MutableTags   []string   `json:"mutableTags,omitempty" yaml:"mutableTags,omitempty"`
+	RequireDigest bool       `json:"requireDigest,omitempty" yaml:"requireDigest,omitempty"`
+}
+
+// OverPermissiveBindingsAnalyze reports ClusterRoleBindings that grant wildcard verbs or resources
+// to wide subjects. WideSubjects replaces the default list of subjects considered wide, entries
+// are globs in the form Kind:name, with ServiceAccounts named namespace/name. Bindings whose
+// names match an Allowlist glob are not reported.
+type OverPermissiveBindingsAnalyze struct {
+	AnalyzeMeta  `json:",inline" yaml:",inline"`
+	Outcomes     []*Outcome `json:"outcomes" yaml:"outcomes"`
+	WideSubjects []string   `json:"wideSubjects,omitempty" yaml:"wideSubjects,omitempty"`
+	Allowlist    []string   `json:"allowlist,omitempty" yaml:"allowlist,omitempty"`
+}
+
+// DuplicateAPIVersionsAnalyze reports objects collected under more than one API version or group
+// with the same kind, namespace and name, such as an Ingress left behind in extensions/v1beta1
+// after moving to networking.k8s.io/v1. Kinds limits the check to the listed kinds.
+type DuplicateAPIVersionsAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	Kinds       []string   `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+}
+
+// MissingPDBAnalyze flags the critical Deployments and StatefulSets whose pods are not covered by
+// any PodDisruptionBudget, so a node drain can evict all of their replicas at once
+type MissingPDBAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	// Workloads selects the critical workloads. A workload is only checked when it runs at least
+	// MinReplicas replicas, which defaults to 2.
+	Workloads []HighAvailabilityWorkload `json:"workloads" yaml:"workloads"`
+}
+
+// ServiceMeshAnalyze checks the Istio and Linkerd state saved by the service mesh collector for an
+// unhealthy control plane, pods missing their proxy sidecar in injected namespaces, and Istio
+// configuration errors
+type ServiceMeshAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+// ClusterAutoscalerAnalyze reports failed cluster-autoscaler scale-ups, from the node group backoffs
+// in the autoscaler status and the scale-up events the autoscaler recorded
+type ClusterAutoscalerAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+}
+
+// CordonedNodesAnalyze reports the pods running on nodes that are cordoned or carry a maintenance
+// taint, which a drain will evict, and flags the ones with a single replica or no
+// PodDisruptionBudget. MaintenanceTaints replaces the default list of taint keys that mark a node
+// for removal.
+type CordonedNodesAnalyze struct {
+	AnalyzeMeta       `json:",inline" yaml:",inline"`
+	Outcomes          []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces        []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	MaintenanceTaints []string   `json:"maintenanceTaints,omitempty" yaml:"maintenanceTaints,omitempty"`
+}
+
+// RequiredNamespacesAnalyze checks that each of Namespaces was collected and is Active, and
+// reports the ones that are missing or Terminating
+type RequiredNamespacesAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces" yaml:"namespaces"`
+}
+
+// WritableHostPathAnalyze reports the pods with containers that mount a hostPath volume read-write
+// at, under or above a sensitive path. SensitivePaths replaces the default list of /, /etc,
+// /var/lib and the container runtime sockets.
+type WritableHostPathAnalyze struct {
+	AnalyzeMeta    `json:",inline" yaml:",inline"`
+	Outcomes       []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces     []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	SensitivePaths []string   `json:"sensitivePaths,omitempty" yaml:"sensitivePaths,omitempty"`
+}
+
+// KubeStateMetricsAnalyze reports, from the kube-state-metrics output, the deployments whose latest
+// generation has not been observed by the deployment controller and the pods restarting more often
+// than RestartsPerHour since they were created. RestartsPerHour defaults to 1.
+type KubeStateMetricsAnalyze struct {
+	AnalyzeMeta     `json:",inline" yaml:",inline"`
+	Outcomes        []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces      []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	RestartsPerHour string     `json:"restartsPerHour,omitempty" yaml:"restartsPerHour,omitempty"`
+}
+
+// LogTimestampsAnalyze detects the timestamp format of the log files matching FileName in the
+// output of CollectorName, and reports the components logging in a timezone other than UTC or
+// without a timezone. FileName defaults to */*.log, the layout of the logs collector.
+type LogTimestampsAnalyze struct {
+	AnalyzeMeta   `json:",inline" yaml:",inline"`
+	Outcomes      []*Outcome `json:"outcomes" yaml:"outcomes"`
+	CollectorName string     `json:"collectorName,omitempty" yaml:"collectorName,omitempty"`
+	FileName      string     `json:"fileName,omitempty" yaml:"fileName,omitempty"`
+	ExcludeFiles  []string   `json:"excludeFiles,omitempty" yaml:"excludeFiles,omitempty"`
+}
+
+// NodePodCapacityAnalyze compares the number of pods running on each node with the number of pods
+// the node can run, its allocatable pods. Nodes at capacity fail, nodes at or above
+// NearCapacityPercent of it warn. NearCapacityPercent defaults to 90.
+type NodePodCapacityAnalyze struct {
+	AnalyzeMeta         `json:",inline" yaml:",inline"`
+	Outcomes            []*Outcome `json:"outcomes" yaml:"outcomes"`
+	NearCapacityPercent string     `json:"nearCapacityPercent,omitempty" yaml:"nearCapacityPercent,omitempty"`
+}
+
+// ReadinessGatesAnalyze reports the pods that are not ready because a condition listed in their
+// spec.readinessGates, typically set by a load balancer controller once the pod is registered as a
+// target, is not True or has not been set.
+type ReadinessGatesAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces  []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+// FieldManagersAnalyze reads the field managers saved by the clusterResources collector with
+// managedFields enabled, and reports the objects managed by a controller, such as helm or an
+// operator, that were also changed with a manual tool such as kubectl edit or kubectl scale.
+// ManualManagers replaces the default kubectl, kubectl-* and k9s manager patterns.
+type FieldManagersAnalyze struct {
+	AnalyzeMeta    `json:",inline" yaml:",inline"`
+	Outcomes       []*Outcome `json:"outcomes" yaml:"outcomes"`
+	Namespaces     []string   `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	ManualManagers []string   `json:"manualManagers,omitempty" yaml:"manualManagers,omitempty"`
+}
+
+// RBACGapsAnalyze reports the namespaces the clusterResources collector skipped because it was
+// not allowed to read them, which leaves the support bundle incomplete
+type RBACGapsAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+}
+
+// ClusterVersionCompatibilityAnalyze evaluates the collected Kubernetes version against the
+// range of Kubernetes versions the Matrix lists as supported for AppVersion. The "when" of outcomes
+// is one of "supported", "deprecated", "unsupported" or "unknown" when AppVersion is not in Matrix.
+type ClusterVersionCompatibilityAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome                    `json:"outcomes,omitempty" yaml:"outcomes,omitempty"`
+	AppVersion  string                        `json:"appVersion" yaml:"appVersion"`
+	Matrix      []ClusterVersionCompatibility `json:"matrix" yaml:"matrix"`
+}
+
+// ClusterVersionCompatibility is an entry of a compatibility matrix. Versions are semver ranges
+// such as ">=1.26 <1.31", versions without a minor or patch version are completed with zeros.
+type ClusterVersionCompatibility struct {
+	// AppVersions is the range of app versions the entry applies to, the first matching entry is used
+	AppVersions string `json:"appVersions" yaml:"appVersions"`
+	// Supported is the range of Kubernetes versions the app versions support
+	Supported string `json:"supported" yaml:"supported"`
+	// Deprecated is a range of Kubernetes versions that work but are deprecated
+	Deprecated string `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+// PrometheusAnalyze evaluates the result of a Prometheus query saved by the prometheus collector,
+// or a query response saved as FileName by another collector. The "when" of outcomes compares the
+// value with a threshold, such as "> 0.9". Each sample of a vector is evaluated on its own, a
+// matrix result is not supported.
+type PrometheusAnalyze struct {
+	AnalyzeMeta   `json:",inline" yaml:",inline"`
+	Outcomes      []*Outcome `json:"outcomes" yaml:"outcomes"`
+	CollectorName string     `json:"collectorName,omitempty" yaml:"collectorName,omitempty"`
+	QueryName     string     `json:"queryName,omitempty" yaml:"queryName,omitempty"`
+	FileName      string     `json:"fileName,omitempty" yaml:"fileName,omitempty"`
+}
+
+// AdmissionWebhooksAnalyze reports the admission webhooks collected by the admissionWebhooks
+// collector whose service is missing or has no ready endpoint, or whose endpoint could not be
+// reached. Outcome messages are templates of the webhook status.
+type AdmissionWebhooksAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+}
+
 type Analyze struct {
-	ClusterVersion           *ClusterVersion           `json:"clusterVersion,omitempty" yaml:"clusterVersion,omitempty"`
-	StorageClass             *StorageClass             `json:"storageClass,omitempty" yaml:"storageClass,omitempty"`
-	CustomResourceDefinition *CustomResourceDefinition `json:"customResourceDefinition,omitempty" yaml:"customResourceDefinition,omitempty"`
-	Ingress                  *Ingress                  `json:"ingress,omitempty" yaml:"ingress,omitempty"`
-	Secret                   *AnalyzeSecret            `json:"secret,omitempty" yaml:"secret,omitempty"`
-	ConfigMap                *AnalyzeConfigMap         `json:"configMap,omitempty" yaml:"configMap,omitempty"`
-	ImagePullSecret          *ImagePullSecret          `json:"imagePullSecret,omitempty" yaml:"imagePullSecret,omitempty"`
-	DeploymentStatus         *DeploymentStatus         `json:"deploymentStatus,omitempty" yaml:"deploymentStatus,omitempty"`
-	StatefulsetStatus        *StatefulsetStatus        `json:"statefulsetStatus,omitempty" yaml:"statefulsetStatus,omitempty"`
-	JobStatus                *JobStatus                `json:"jobStatus,omitempty" yaml:"jobStatus,omitempty"`
-	ReplicaSetStatus         *ReplicaSetStatus         `json:"replicasetStatus,omitempty" yaml:"replicasetStatus,omitempty"`
-	ClusterPodStatuses       *ClusterPodStatuses       `json:"clusterPodStatuses,omitempty" yaml:"clusterPodStatuses,omitempty"`
-	ClusterContainerStatuses *ClusterContainerStatuses `json:"clusterContainerStatuses,omitempty" yaml:"clusterContainerStatuses,omitempty"`
-	ContainerRuntime         *ContainerRuntime         `json:"containerRuntime,omitempty" yaml:"containerRuntime,omitempty"`
-	Distribution             *Distribution             `json:"distribution,omitempty" yaml:"distribution,omitempty"`
-	NodeResources            *NodeResources            `json:"nodeResources,omitempty" yaml:"nodeResources,omitempty"`
-	TextAnalyze              *TextAnalyze              `json:"textAnalyze,omitempty" yaml:"textAnalyze,omitempty"`
-	YamlCompare              *YamlCompare              `json:"yamlCompare,omitempty" yaml:"yamlCompare,omitempty"`
-	JsonCompare              *JsonCompare              `json:"jsonCompare,omitempty" yaml:"jsonCompare,omitempty"`
-	Postgres                 *DatabaseAnalyze          `json:"postgres,omitempty" yaml:"postgres,omitempty"`
-	Mssql                    *DatabaseAnalyze          `json:"mssql,omitempty" yaml:"mssql,omitempty"`
-	Mysql                    *DatabaseAnalyze          `json:"mysql,omitempty" yaml:"mysql,omitempty"`
-	Redis                    *DatabaseAnalyze          `json:"redis,omitempty" yaml:"redis,omitempty"`
-	CephStatus               *CephStatusAnalyze        `json:"cephStatus,omitempty" yaml:"cephStatus,omitempty"`
-	Velero                   *VeleroAnalyze            `json:"velero,omitempty" yaml:"velero,omitempty"`
-	Longhorn                 *LonghornAnalyze          `json:"longhorn,omitempty" yaml:"longhorn,omitempty"`
-	RegistryImages           *RegistryImagesAnalyze    `json:"registryImages,omitempty" yaml:"registryImages,omitempty"`
-	WeaveReport              *WeaveReportAnalyze       `json:"weaveReport,omitempty" yaml:"weaveReport,omitempty"`
-	Sysctl                   *SysctlAnalyze            `json:"sysctl,omitempty" yaml:"sysctl,omitempty"`
-	ClusterResource          *ClusterResource          `json:"clusterResource,omitempty" yaml:"clusterResource,omitempty"`
-	Certificates             *CertificatesAnalyze      `json:"certificates,omitempty" yaml:"certificates,omitempty"`
-	Goldpinger               *GoldpingerAnalyze        `json:"goldpinger,omitempty" yaml:"goldpinger,omitempty"`
-	Event                    *EventAnalyze             `json:"event,omitempty" yaml:"event,omitempty"`
-	NodeMetrics              *NodeMetricsAnalyze       `json:"nodeMetrics,omitempty" yaml:"nodeMetrics,omitempty"`
-	HTTP                     *HTTPAnalyze              `json:"http,omitempty" yaml:"http,omitempty"`
+	ClusterVersion              *ClusterVersion                     `json:"clusterVersion,omitempty" yaml:"clusterVersion,omitempty"`
+	StorageClass                *StorageClass                       `json:"storageClass,omitempty" yaml:"storageClass,omitempty"`
+	CustomResourceDefinition    *CustomResourceDefinition           `json:"customResourceDefinition,omitempty" yaml:"customResourceDefinition,omitempty"`
+	Ingress                     *Ingress                            `json:"ingress,omitempty" yaml:"ingress,omitempty"`
+	Secret                      *AnalyzeSecret                      `json:"secret,omitempty" yaml:"secret,omitempty"`
+	ConfigMap                   *AnalyzeConfigMap                   `json:"configMap,omitempty" yaml:"configMap,omitempty"`
+	ImagePullSecret             *ImagePullSecret                    `json:"imagePullSecret,omitempty" yaml:"imagePullSecret,omitempty"`
+	DeploymentStatus            *DeploymentStatus                   `json:"deploymentStatus,omitempty" yaml:"deploymentStatus,omitempty"`
+	StatefulsetStatus           *StatefulsetStatus                  `json:"statefulsetStatus,omitempty" yaml:"statefulsetStatus,omitempty"`
+	JobStatus                   *JobStatus                          `json:"jobStatus,omitempty" yaml:"jobStatus,omitempty"`
+	ReplicaSetStatus            *ReplicaSetStatus                   `json:"replicasetStatus,omitempty" yaml:"replicasetStatus,omitempty"`
+	ClusterPodStatuses          *ClusterPodStatuses                 `json:"clusterPodStatuses,omitempty" yaml:"clusterPodStatuses,omitempty"`
+	ClusterContainerStatuses    *ClusterContainerStatuses           `json:"clusterContainerStatuses,omitempty" yaml:"clusterContainerStatuses,omitempty"`
+	ContainerRuntime            *ContainerRuntime                   `json:"containerRuntime,omitempty" yaml:"containerRuntime,omitempty"`
+	Distribution                *Distribution                       `json:"distribution,omitempty" yaml:"distribution,omitempty"`
+	NodeResources               *NodeResources                      `json:"nodeResources,omitempty" yaml:"nodeResources,omitempty"`
+	TextAnalyze                 *TextAnalyze                        `json:"textAnalyze,omitempty" yaml:"textAnalyze,omitempty"`
+	YamlCompare                 *YamlCompare                        `json:"yamlCompare,omitempty" yaml:"yamlCompare,omitempty"`
+	JsonCompare                 *JsonCompare                        `json:"jsonCompare,omitempty" yaml:"jsonCompare,omitempty"`
+	Postgres                    *DatabaseAnalyze                    `json:"postgres,omitempty" yaml:"postgres,omitempty"`
+	Mssql                       *DatabaseAnalyze                    `json:"mssql,omitempty" yaml:"mssql,omitempty"`
+	Mysql                       *DatabaseAnalyze                    `json:"mysql,omitempty" yaml:"mysql,omitempty"`
+	Redis                       *DatabaseAnalyze                    `json:"redis,omitempty" yaml:"redis,omitempty"`
+	Mongodb                     *DatabaseAnalyze                    `json:"mongodb,omitempty" yaml:"mongodb,omitempty"`
+	CephStatus                  *CephStatusAnalyze                  `json:"cephStatus,omitempty" yaml:"cephStatus,omitempty"`
+	Velero                      *VeleroAnalyze                      `json:"velero,omitempty" yaml:"velero,omitempty"`
+	Longhorn                    *LonghornAnalyze                    `json:"longhorn,omitempty" yaml:"longhorn,omitempty"`
+	RegistryImages              *RegistryImagesAnalyze              `json:"registryImages,omitempty" yaml:"registryImages,omitempty"`
+	WeaveReport                 *WeaveReportAnalyze                 `json:"weaveReport,omitempty" yaml:"weaveReport,omitempty"`
+	Sysctl                      *SysctlAnalyze                      `json:"sysctl,omitempty" yaml:"sysctl,omitempty"`
+	ClusterResource             *ClusterResource                    `json:"clusterResource,omitempty" yaml:"clusterResource,omitempty"`
+	Certificates                *CertificatesAnalyze                `json:"certificates,omitempty" yaml:"certificates,omitempty"`
+	Goldpinger                  *GoldpingerAnalyze                  `json:"goldpinger,omitempty" yaml:"goldpinger,omitempty"`
+	Event                       *EventAnalyze                       `json:"event,omitempty" yaml:"event,omitempty"`
+	NodeMetrics                 *NodeMetricsAnalyze                 `json:"nodeMetrics,omitempty" yaml:"nodeMetrics,omitempty"`
+	HTTP                        *HTTPAnalyze                        `json:"http,omitempty" yaml:"http,omitempty"`
+	WaitForFirstConsumer        *WaitForFirstConsumerAnalyze        `json:"waitForFirstConsumer,omitempty" yaml:"waitForFirstConsumer,omitempty"`
+	GitOps                      *GitOpsAnalyze                      `json:"gitops,omitempty" yaml:"gitops,omitempty"`
+	ImageArchitecture           *ImageArchitectureAnalyze           `json:"imageArchitecture,omitempty" yaml:"imageArchitecture,omitempty"`
+	TopologySpread              *TopologySpreadAnalyze              `json:"topologySpread,omitempty" yaml:"topologySpread,omitempty"`
+	CSR                         *CSRAnalyze                         `json:"csr,omitempty" yaml:"csr,omitempty"`
+	GoldenSnapshot              *GoldenSnapshotAnalyze              `json:"goldenSnapshot,omitempty" yaml:"goldenSnapshot,omitempty"`
+	OOMKilled                   *OOMKilledAnalyze                   `json:"oomKilled,omitempty" yaml:"oomKilled,omitempty"`
+	APIWarnings                 *APIWarningsAnalyze                 `json:"apiWarnings,omitempty" yaml:"apiWarnings,omitempty"`
+	ConfigMapDrift              *ConfigMapDriftAnalyze              `json:"configMapDrift,omitempty" yaml:"configMapDrift,omitempty"`
+	VPA                         *VPAAnalyze                         `json:"vpa,omitempty" yaml:"vpa,omitempty"`
+	ConfigMounts                *ConfigMountsAnalyze                `json:"configMounts,omitempty" yaml:"configMounts,omitempty"`
+	HighAvailability            *HighAvailabilityAnalyze            `json:"highAvailability,omitempty" yaml:"highAvailability,omitempty"`
+	OrphanedResources           *OrphanedResourcesAnalyze           `json:"orphanedResources,omitempty" yaml:"orphanedResources,omitempty"`
+	LastAppliedDrift            *LastAppliedDriftAnalyze            `json:"lastAppliedDrift,omitempty" yaml:"lastAppliedDrift,omitempty"`
+	APIServerFeatures           *APIServerFeaturesAnalyze           `json:"apiServerFeatures,omitempty" yaml:"apiServerFeatures,omitempty"`
+	ExposedServices             *ExposedServicesAnalyze             `json:"exposedServices,omitempty" yaml:"exposedServices,omitempty"`
+	ImagePolicy                 *ImagePolicyAnalyze                 `json:"imagePolicy,omitempty" yaml:"imagePolicy,omitempty"`
+	OverPermissiveBindings      *OverPermissiveBindingsAnalyze      `json:"overPermissiveBindings,omitempty" yaml:"overPermissiveBindings,omitempty"`
+	DuplicateAPIVersions        *DuplicateAPIVersionsAnalyze        `json:"duplicateAPIVersions,omitempty" yaml:"duplicateAPIVersions,omitempty"`
+	MissingPDB                  *MissingPDBAnalyze                  `json:"missingPDB,omitempty" yaml:"missingPDB,omitempty"`
+	ServiceMesh                 *ServiceMeshAnalyze                 `json:"serviceMesh,omitempty" yaml:"serviceMesh,omitempty"`
+	ClusterAutoscaler           *ClusterAutoscalerAnalyze           `json:"clusterAutoscaler,omitempty" yaml:"clusterAutoscaler,omitempty"`
+	CordonedNodes               *CordonedNodesAnalyze               `json:"cordonedNodes,omitempty" yaml:"cordonedNodes,omitempty"`
+	RequiredNamespaces          *RequiredNamespacesAnalyze          `json:"requiredNamespaces,omitempty" yaml:"requiredNamespaces,omitempty"`
+	WritableHostPath            *WritableHostPathAnalyze            `json:"writableHostPath,omitempty" yaml:"writableHostPath,omitempty"`
+	KubeStateMetrics            *KubeStateMetricsAnalyze            `json:"kubeStateMetrics,omitempty" yaml:"kubeStateMetrics,omitempty"`
+	LogTimestamps               *LogTimestampsAnalyze               `json:"logTimestamps,omitempty" yaml:"logTimestamps,omitempty"`
+	NodePodCapacity             *NodePodCapacityAnalyze             `json:"nodePodCapacity,omitempty" yaml:"nodePodCapacity,omitempty"`
+	ReadinessGates              *ReadinessGatesAnalyze              `json:"readinessGates,omitempty" yaml:"readinessGates,omitempty"`
+	FieldManagers               *FieldManagersAnalyze               `json:"fieldManagers,omitempty" yaml:"fieldManagers,omitempty"`
+	RBACGaps                    *RBACGapsAnalyze                    `json:"rbacGaps,omitempty" yaml:"rbacGaps,omitempty"`
+	ClusterVersionCompatibility *ClusterVersionCompatibilityAnalyze `json:"clusterVersionCompatibility,omitempty" yaml:"clusterVersionCompatibility,omitempty"`
+	Prometheus                  *PrometheusAnalyze                  `json:"prometheus,omitempty" yaml:"prometheus,omitempty"`
+	AdmissionWebhooks           *AdmissionWebhooksAnalyze           `json:"admissionWebhooks,omitempty" yaml:"admissionWebhooks,omitempty"`
 }
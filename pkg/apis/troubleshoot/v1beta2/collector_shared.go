@@ -15,6 +15,21 @@ type CollectorMeta struct {
 	CollectorName string `json:"collectorName,omitempty" yaml:"collectorName,omitempty"`
 	// +optional
 	Exclude *multitype.BoolOrString `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+	// DependsOn lists the collectorNames of collectors that have to finish before this one starts
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	// Transformers names the output transformers, e.g. eventsSummary, that rewrite this
+	// collector's files before they are added to the bundle. They run in the order listed.
+	// +optional
+	Transformers []string `json:"transformers,omitempty" yaml:"transformers,omitempty"`
+	// SizeBudget caps the total size of the files this collector adds to the bundle, e.g. "50Mi".
+	// Files that don't fit are left out of the bundle and listed in collector-sizes.json.
+	// +optional
+	SizeBudget string `json:"sizeBudget,omitempty" yaml:"sizeBudget,omitempty"`
+	// CollectorTimeout is how long the collector can run, e.g. "5m", before it is stopped and the
+	// collection continues without it. Collectors have no timeout by default.
+	// +optional
+	CollectorTimeout string `json:"collectorTimeout,omitempty" yaml:"collectorTimeout,omitempty"`
 }
 
 type ClusterInfo struct {
@@ -24,7 +39,40 @@ type ClusterInfo struct {
 type ClusterResources struct {
 	CollectorMeta `json:",inline" yaml:",inline"`
 	Namespaces    []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
-	IgnoreRBAC    bool     `json:"ignoreRBAC,omitempty" yaml:"ignoreRBAC"`
+	// ExcludeNamespaces are left out when the namespaces are listed from the cluster, that is when
+	// Namespaces is empty. Glob patterns such as kube-* are supported.
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty" yaml:"excludeNamespaces,omitempty"`
+	IgnoreRBAC        bool     `json:"ignoreRBAC,omitempty" yaml:"ignoreRBAC"`
+	// LastApplied saves the last-applied-configuration of Deployments, StatefulSets and DaemonSets
+	// next to their live object under cluster-resources/last-applied
+	LastApplied bool `json:"lastApplied,omitempty" yaml:"lastApplied,omitempty"`
+	// ManagedFields saves the field managers of Deployments, StatefulSets, DaemonSets and Services,
+	// when each of them last changed the object and the fields it owns, under
+	// cluster-resources/managed-fields
+	ManagedFields bool `json:"managedFields,omitempty" yaml:"managedFields,omitempty"`
+	// NamespaceProfiles limit what is collected from some namespaces, e.g. to skip logs and events
+	// in system namespaces on large clusters. Namespaces no profile matches are fully collected.
+	NamespaceProfiles []ClusterResourcesNamespaceProfile `json:"namespaceProfiles,omitempty" yaml:"namespaceProfiles,omitempty"`
+	// PodLogLimits limit the logs collected from unhealthy pods, defaults to the last 500 lines
+	// and at most 5MB of each container
+	PodLogLimits *LogLimits `json:"podLogLimits,omitempty" yaml:"podLogLimits,omitempty"`
+}
+
+// ClusterResourcesNamespaceProfile sets how much is collected from the namespaces it matches.
+// Profiles are evaluated in order and the first one matching a namespace applies.
+//
+//   - full collects every namespaced resource and the logs of unhealthy pods, it is the default
+//   - light collects every namespaced resource but skips events and pod logs
+//   - custom only collects the resources listed in Resources
+type ClusterResourcesNamespaceProfile struct {
+	// Namespaces the profile applies to, glob patterns such as kube-* are supported
+	Namespaces []string `json:"namespaces" yaml:"namespaces"`
+	// Profile is one of full, light or custom, defaults to full
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+	// Resources are the resources a custom profile collects, named after the directory they are
+	// saved under in cluster-resources, e.g. pods, deployments or events. Use pods/logs for the
+	// logs of unhealthy pods.
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
 }
 
 // MetricRequest the details of the MetricValuesList to be retrieved
@@ -74,6 +122,9 @@ type LogLimits struct {
 	MaxLines  int64       `json:"maxLines,omitempty" yaml:"maxLines,omitempty"`
 	SinceTime metav1.Time `json:"sinceTime,omitempty" yaml:"sinceTime,omitempty"`
 	MaxBytes  int64       `json:"maxBytes,omitempty" yaml:"maxBytes,omitempty"`
+	// SinceSeconds returns the logs written in the given number of seconds before they are
+	// collected. Like SinceTime and MaxAge, it replaces the line and byte limits.
+	SinceSeconds int64 `json:"sinceSeconds,omitempty" yaml:"sinceSeconds,omitempty"`
 }
 
 type Logs struct {
@@ -83,6 +134,9 @@ type Logs struct {
 	Namespace      string     `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 	ContainerNames []string   `json:"containerNames,omitempty" yaml:"containerNames,omitempty"`
 	Limits         *LogLimits `json:"limits,omitempty" yaml:"limits,omitempty"`
+	// TailDuration switches the collector to follow the logs of the selected pods for the given
+	// duration (e.g. "60s") and save only the lines emitted during that window
+	TailDuration string `json:"tailDuration,omitempty" yaml:"tailDuration,omitempty"`
 }
 
 type Data struct {
@@ -189,6 +243,10 @@ type Get struct {
 	Timeout string     `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 	TLS     *TLSParams `json:"tls,omitempty" yaml:"tls,omitempty"`
 	Proxy   string     `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	// RecordRedirects records every request made to follow the redirects of the response, with
+	// their status, Location header and TLS connection, up to MaxRedirects redirects (10 by default).
+	RecordRedirects bool `json:"recordRedirects,omitempty" yaml:"recordRedirects,omitempty"`
+	MaxRedirects    int  `json:"maxRedirects,omitempty" yaml:"maxRedirects,omitempty"`
 }
 
 type Post struct {
@@ -201,6 +259,10 @@ type Post struct {
 	Timeout string     `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 	TLS     *TLSParams `json:"tls,omitempty" yaml:"tls,omitempty"`
 	Proxy   string     `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	// RecordRedirects records every request made to follow the redirects of the response, with
+	// their status, Location header and TLS connection, up to MaxRedirects redirects (10 by default).
+	RecordRedirects bool `json:"recordRedirects,omitempty" yaml:"recordRedirects,omitempty"`
+	MaxRedirects    int  `json:"maxRedirects,omitempty" yaml:"maxRedirects,omitempty"`
 }
 
 type Put struct {
@@ -213,6 +275,10 @@ type Put struct {
 	Timeout string     `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 	TLS     *TLSParams `json:"tls,omitempty" yaml:"tls,omitempty"`
 	Proxy   string     `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	// RecordRedirects records every request made to follow the redirects of the response, with
+	// their status, Location header and TLS connection, up to MaxRedirects redirects (10 by default).
+	RecordRedirects bool `json:"recordRedirects,omitempty" yaml:"recordRedirects,omitempty"`
+	MaxRedirects    int  `json:"maxRedirects,omitempty" yaml:"maxRedirects,omitempty"`
 }
 
 type Database struct {
@@ -315,37 +381,214 @@ type Etcd struct {
 	Image         string `json:"image" yaml:"image"`
 }
 
+// GitOps collects the reconciliation state of Flux Kustomizations and HelmReleases and
+// Argo CD Applications. Nothing is collected when neither controller is installed.
+type GitOps struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	Namespaces    []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+// ServiceMesh collects the control plane health, sidecar injection settings and configuration of
+// Istio and Linkerd meshes. Nothing is collected when neither mesh is installed.
+type ServiceMesh struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	// Namespaces limits the mesh configuration resources collected, the control plane and the
+	// injection settings are always collected for the whole cluster
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+// ClusterAutoscaler collects the cluster-autoscaler status ConfigMap and the events recorded by the
+// autoscaler. Nothing is collected when the status ConfigMap does not exist.
+type ClusterAutoscaler struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	// Namespace and ConfigMapName locate the status ConfigMap, they default to kube-system and
+	// cluster-autoscaler-status
+	Namespace     string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	ConfigMapName string `json:"configMapName,omitempty" yaml:"configMapName,omitempty"`
+}
+
+// KubeStateMetrics scrapes the metrics endpoint of kube-state-metrics. When ServiceName is not set
+// the service is found by its app.kubernetes.io/name or k8s-app label in Namespace, or in any
+// namespace when Namespace is not set either. A named service defaults to kube-system.
+type KubeStateMetrics struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	Namespace     string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	ServiceName   string `json:"serviceName,omitempty" yaml:"serviceName,omitempty"`
+	// Port is the name or number of the service port to scrape, defaults to the first port of the
+	// service
+	Port string `json:"port,omitempty" yaml:"port,omitempty"`
+	// Path defaults to /metrics
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// VPA collects VerticalPodAutoscaler objects and their recommendations. Nothing is collected
+// when the VPA custom resource definitions are not installed.
+type VPA struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	Namespaces    []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+}
+
+// Focus collects a single named object together with its owner chain, the pods it manages, the
+// PersistentVolumeClaims those pods mount and the events involving any of them.
+type Focus struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	// Kind is one of Pod, Deployment, ReplicaSet, StatefulSet, DaemonSet, Job or CronJob
+	Kind      string `json:"kind" yaml:"kind"`
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+// Security collects the kube-apiserver audit policy configuration, where the apiserver pods are
+// visible, and the resolved rules of aggregated ClusterRoles.
+type Security struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+}
+
+// NodeCommands runs commands on each ready node from a privileged pod that enters the host's
+// namespaces. Commands are selected by name from an allowlist, arbitrary commands can not be run.
+type NodeCommands struct {
+	CollectorMeta   `json:",inline" yaml:",inline"`
+	Namespace       string            `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Image           string            `json:"image,omitempty" yaml:"image,omitempty"`
+	ImagePullPolicy string            `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
+	ImagePullSecret *ImagePullSecrets `json:"imagePullSecret,omitempty" yaml:"imagePullSecret,omitempty"`
+	Timeout         string            `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Commands        []string          `json:"commands" yaml:"commands"`
+	NodeSelector    map[string]string `json:"nodeSelector,omitempty" yaml:"nodeSelector,omitempty"`
+	// MaxOutputSize caps the output saved for each command, e.g. "512Ki". Defaults to 1Mi.
+	MaxOutputSize string `json:"maxOutputSize,omitempty" yaml:"maxOutputSize,omitempty"`
+}
+
+// Traces receives the spans exported over OTLP to Endpoint for Duration. Both OTLP/HTTP, with
+// protobuf or JSON payloads, and OTLP/gRPC are accepted on the same port, so an application or an
+// OpenTelemetry Collector exporter can be pointed at it while the bundle is collected.
+type Traces struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	// Endpoint is the address to listen on, defaults to 0.0.0.0:4318
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	// Duration is how long spans are recorded for, defaults to 30s
+	Duration string `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+// Kubelet saves the metrics and health check of the kubelet of each node, read through the API
+// server node proxy. All nodes are collected unless NodeNames or Selector are set.
+type Kubelet struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	NodeNames     []string `json:"nodeNames,omitempty" yaml:"nodeNames,omitempty"`
+	Selector      []string `json:"selector,omitempty" yaml:"selector,omitempty"`
+}
+
+// HelmReleases saves the latest revision of each Helm release, with its history and user-supplied
+// values, read from the release Secrets and ConfigMaps in the cluster. Releases in all namespaces
+// are collected unless Namespaces is set.
+type HelmReleases struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	Namespaces    []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	ReleaseNames  []string `json:"releaseNames,omitempty" yaml:"releaseNames,omitempty"`
+	// OmitValues leaves the user-supplied values out of the collected releases
+	OmitValues bool `json:"omitValues,omitempty" yaml:"omitValues,omitempty"`
+}
+
+// NetworkThroughput measures the TCP throughput from a pod on ClientNode to a pod on ServerNode
+// with iperf3. When the nodes are not set, the first two ready nodes matching Selector are used.
+type NetworkThroughput struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	Namespace     string   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Image         string   `json:"image,omitempty" yaml:"image,omitempty"`
+	ServerNode    string   `json:"serverNode,omitempty" yaml:"serverNode,omitempty"`
+	ClientNode    string   `json:"clientNode,omitempty" yaml:"clientNode,omitempty"`
+	Selector      []string `json:"selector,omitempty" yaml:"selector,omitempty"`
+	// Duration of the measurement, defaults to 5s
+	Duration string `json:"duration,omitempty" yaml:"duration,omitempty"`
+	// Timeout for the pods to be scheduled and the measurement to complete, defaults to 2m
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// MetricsServer saves the node and pod metrics served by metrics-server through the
+// metrics.k8s.io API. When the API is not served, that metrics-server is not installed is recorded
+// in the errors file.
+type MetricsServer struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+}
+
+// Prometheus runs instant queries against the Prometheus HTTP API through the Kubernetes API
+// server service proxy and saves each response as returned by /api/v1/query. When ServiceName is
+// not set the service is found by its app.kubernetes.io/name, operated-prometheus or app label in
+// Namespace, or in any namespace when Namespace is not set either. A named service defaults to
+// the monitoring namespace.
+type Prometheus struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	Namespace     string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	ServiceName   string `json:"serviceName,omitempty" yaml:"serviceName,omitempty"`
+	// Port is the name or number of the service port to query, defaults to the first port of the
+	// service
+	Port    string            `json:"port,omitempty" yaml:"port,omitempty"`
+	Queries []PrometheusQuery `json:"queries" yaml:"queries"`
+}
+
+// PrometheusQuery is a PromQL expression, its result is saved as <name>.json
+type PrometheusQuery struct {
+	Name  string `json:"name" yaml:"name"`
+	Query string `json:"query" yaml:"query"`
+}
+
+// AdmissionWebhooks collects the ValidatingWebhookConfigurations and MutatingWebhookConfigurations
+// and dials the endpoint of each webhook with TLS to record whether it is reachable and when its
+// serving certificate expires. Service endpoints only resolve when the collector runs in the
+// cluster, elsewhere the ready endpoints of the service are still recorded.
+type AdmissionWebhooks struct {
+	CollectorMeta `json:",inline" yaml:",inline"`
+	// Timeout of each dial, e.g. "3s", defaults to 5s
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
 type Collect struct {
-	ClusterInfo      *ClusterInfo      `json:"clusterInfo,omitempty" yaml:"clusterInfo,omitempty"`
-	ClusterResources *ClusterResources `json:"clusterResources,omitempty" yaml:"clusterResources,omitempty"`
-	Secret           *Secret           `json:"secret,omitempty" yaml:"secret,omitempty"`
-	CustomMetrics    *CustomMetrics    `json:"customMetrics,omitempty" yaml:"customMetrics,omitempty"`
-	ConfigMap        *ConfigMap        `json:"configMap,omitempty" yaml:"configMap,omitempty"`
-	Logs             *Logs             `json:"logs,omitempty" yaml:"logs,omitempty"`
-	Run              *Run              `json:"run,omitempty" yaml:"run,omitempty"`
-	RunPod           *RunPod           `json:"runPod,omitempty" yaml:"runPod,omitempty"`
-	RunDaemonSet     *RunDaemonSet     `json:"runDaemonSet,omitempty" yaml:"runDaemonSet,omitempty"`
-	Exec             *Exec             `json:"exec,omitempty" yaml:"exec,omitempty"`
-	Data             *Data             `json:"data,omitempty" yaml:"data,omitempty"`
-	Copy             *Copy             `json:"copy,omitempty" yaml:"copy,omitempty"`
-	CopyFromHost     *CopyFromHost     `json:"copyFromHost,omitempty" yaml:"copyFromHost,omitempty"`
-	HTTP             *HTTP             `json:"http,omitempty" yaml:"http,omitempty"`
-	Postgres         *Database         `json:"postgres,omitempty" yaml:"postgres,omitempty"`
-	Mssql            *Database         `json:"mssql,omitempty" yaml:"mssql,omitempty"`
-	Mysql            *Database         `json:"mysql,omitempty" yaml:"mysql,omitempty"`
-	Redis            *Database         `json:"redis,omitempty" yaml:"redis,omitempty"`
-	Collectd         *Collectd         `json:"collectd,omitempty" yaml:"collectd,omitempty"`
-	Ceph             *Ceph             `json:"ceph,omitempty" yaml:"ceph,omitempty"`
-	Longhorn         *Longhorn         `json:"longhorn,omitempty" yaml:"longhorn,omitempty"`
-	RegistryImages   *RegistryImages   `json:"registryImages,omitempty" yaml:"registryImages,omitempty"`
-	Sysctl           *Sysctl           `json:"sysctl,omitempty" yaml:"sysctl,omitempty"`
-	Certificates     *Certificates     `json:"certificates,omitempty" yaml:"certificates,omitempty"`
-	Helm             *Helm             `json:"helm,omitempty" yaml:"helm,omitempty"`
-	Goldpinger       *Goldpinger       `json:"goldpinger,omitempty" yaml:"goldpinger,omitempty"`
-	Sonobuoy         *Sonobuoy         `json:"sonobuoy,omitempty" yaml:"sonobuoy,omitempty"`
-	NodeMetrics      *NodeMetrics      `json:"nodeMetrics,omitempty" yaml:"nodeMetrics,omitempty"`
-	DNS              *DNS              `json:"dns,omitempty" yaml:"dns,omitempty"`
-	Etcd             *Etcd             `json:"etcd,omitempty" yaml:"etcd,omitempty"`
+	ClusterInfo       *ClusterInfo       `json:"clusterInfo,omitempty" yaml:"clusterInfo,omitempty"`
+	ClusterResources  *ClusterResources  `json:"clusterResources,omitempty" yaml:"clusterResources,omitempty"`
+	Secret            *Secret            `json:"secret,omitempty" yaml:"secret,omitempty"`
+	CustomMetrics     *CustomMetrics     `json:"customMetrics,omitempty" yaml:"customMetrics,omitempty"`
+	ConfigMap         *ConfigMap         `json:"configMap,omitempty" yaml:"configMap,omitempty"`
+	Logs              *Logs              `json:"logs,omitempty" yaml:"logs,omitempty"`
+	Run               *Run               `json:"run,omitempty" yaml:"run,omitempty"`
+	RunPod            *RunPod            `json:"runPod,omitempty" yaml:"runPod,omitempty"`
+	RunDaemonSet      *RunDaemonSet      `json:"runDaemonSet,omitempty" yaml:"runDaemonSet,omitempty"`
+	Exec              *Exec              `json:"exec,omitempty" yaml:"exec,omitempty"`
+	Data              *Data              `json:"data,omitempty" yaml:"data,omitempty"`
+	Copy              *Copy              `json:"copy,omitempty" yaml:"copy,omitempty"`
+	CopyFromHost      *CopyFromHost      `json:"copyFromHost,omitempty" yaml:"copyFromHost,omitempty"`
+	HTTP              *HTTP              `json:"http,omitempty" yaml:"http,omitempty"`
+	Postgres          *Database          `json:"postgres,omitempty" yaml:"postgres,omitempty"`
+	Mssql             *Database          `json:"mssql,omitempty" yaml:"mssql,omitempty"`
+	Mysql             *Database          `json:"mysql,omitempty" yaml:"mysql,omitempty"`
+	Redis             *Database          `json:"redis,omitempty" yaml:"redis,omitempty"`
+	Mongodb           *Database          `json:"mongodb,omitempty" yaml:"mongodb,omitempty"`
+	Collectd          *Collectd          `json:"collectd,omitempty" yaml:"collectd,omitempty"`
+	Ceph              *Ceph              `json:"ceph,omitempty" yaml:"ceph,omitempty"`
+	Longhorn          *Longhorn          `json:"longhorn,omitempty" yaml:"longhorn,omitempty"`
+	RegistryImages    *RegistryImages    `json:"registryImages,omitempty" yaml:"registryImages,omitempty"`
+	Sysctl            *Sysctl            `json:"sysctl,omitempty" yaml:"sysctl,omitempty"`
+	Certificates      *Certificates      `json:"certificates,omitempty" yaml:"certificates,omitempty"`
+	Helm              *Helm              `json:"helm,omitempty" yaml:"helm,omitempty"`
+	Goldpinger        *Goldpinger        `json:"goldpinger,omitempty" yaml:"goldpinger,omitempty"`
+	Sonobuoy          *Sonobuoy          `json:"sonobuoy,omitempty" yaml:"sonobuoy,omitempty"`
+	NodeMetrics       *NodeMetrics       `json:"nodeMetrics,omitempty" yaml:"nodeMetrics,omitempty"`
+	DNS               *DNS               `json:"dns,omitempty" yaml:"dns,omitempty"`
+	Etcd              *Etcd              `json:"etcd,omitempty" yaml:"etcd,omitempty"`
+	GitOps            *GitOps            `json:"gitops,omitempty" yaml:"gitops,omitempty"`
+	NodeCommands      *NodeCommands      `json:"nodeCommands,omitempty" yaml:"nodeCommands,omitempty"`
+	VPA               *VPA               `json:"vpa,omitempty" yaml:"vpa,omitempty"`
+	Focus             *Focus             `json:"focus,omitempty" yaml:"focus,omitempty"`
+	Security          *Security          `json:"security,omitempty" yaml:"security,omitempty"`
+	ServiceMesh       *ServiceMesh       `json:"serviceMesh,omitempty" yaml:"serviceMesh,omitempty"`
+	ClusterAutoscaler *ClusterAutoscaler `json:"clusterAutoscaler,omitempty" yaml:"clusterAutoscaler,omitempty"`
+	KubeStateMetrics  *KubeStateMetrics  `json:"kubeStateMetrics,omitempty" yaml:"kubeStateMetrics,omitempty"`
+	Traces            *Traces            `json:"traces,omitempty" yaml:"traces,omitempty"`
+	Kubelet           *Kubelet           `json:"kubelet,omitempty" yaml:"kubelet,omitempty"`
+	HelmReleases      *HelmReleases      `json:"helmReleases,omitempty" yaml:"helmReleases,omitempty"`
+	NetworkThroughput *NetworkThroughput `json:"networkThroughput,omitempty" yaml:"networkThroughput,omitempty"`
+	MetricsServer     *MetricsServer     `json:"metricsServer,omitempty" yaml:"metricsServer,omitempty"`
+	Prometheus        *Prometheus        `json:"prometheus,omitempty" yaml:"prometheus,omitempty"`
+	AdmissionWebhooks *AdmissionWebhooks `json:"admissionWebhooks,omitempty" yaml:"admissionWebhooks,omitempty"`
 }
 
 func (c *Collect) AccessReviewSpecs(overrideNS string) []authorizationv1.SelfSubjectAccessReviewSpec {
@@ -644,6 +887,10 @@ func (c *Collect) GetName() string {
 		collector = "redis"
 		name = c.Redis.CollectorName
 	}
+	if c.Mongodb != nil {
+		collector = "mongodb"
+		name = c.Mongodb.CollectorName
+	}
 	if c.Collectd != nil {
 		collector = "collectd"
 		name = c.Collectd.CollectorName
@@ -141,6 +141,38 @@ type HostSysctlAnalyze struct {
 	Outcomes      []*Outcome `json:"outcomes" yaml:"outcomes"`
 }
 
+// HostMTUAnalyze compares the MTU of the interface each node's default route goes through across
+// nodes, and checks that the MTU of overlay network interfaces leaves room for the encapsulation
+// overhead
+type HostMTUAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	// ExpectedMTU is the MTU every node should use. When it is not set the nodes are compared
+	// with the MTU most of them use.
+	ExpectedMTU int `json:"expectedMTU,omitempty" yaml:"expectedMTU,omitempty"`
+	// OverlayOverhead is the encapsulation overhead in bytes of the overlay network. By default
+	// it is detected from the overlay interface, e.g. 50 for VXLAN.
+	OverlayOverhead int        `json:"overlayOverhead,omitempty" yaml:"overlayOverhead,omitempty"`
+	Outcomes        []*Outcome `json:"outcomes" yaml:"outcomes"`
+}
+
+// HostSystemdAnalyze checks the properties of a systemd unit collected with the systemd host
+// collector. Outcome conditions compare a property with a value, e.g. "ActiveState == active" or
+// "SubState != running". Without outcomes, the check fails when the unit is not active.
+type HostSystemdAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Unit        string     `json:"unit" yaml:"unit"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+}
+
+// HostDmesgAnalyze checks the kernel messages collected with the dmesg host collector. Outcome
+// conditions compare the number of messages of a kind with a value, e.g. "oomKills > 0" or
+// "ioErrors > 0", and "unreadable" is true when the messages could not be collected. Without
+// outcomes, the check warns about OOM kills, I/O errors and unreadable messages.
+type HostDmesgAnalyze struct {
+	AnalyzeMeta `json:",inline" yaml:",inline"`
+	Outcomes    []*Outcome `json:"outcomes" yaml:"outcomes"`
+}
+
 type HostAnalyze struct {
 	CPU                          *CPUAnalyze                          `json:"cpu,omitempty" yaml:"cpu,omitempty"`
 	TCPLoadBalancer              *TCPLoadBalancerAnalyze              `json:"tcpLoadBalancer,omitempty" yaml:"tcpLoadBalancer,omitempty"`
@@ -167,4 +199,7 @@ type HostAnalyze struct {
 	JsonCompare                  *JsonCompare                         `json:"jsonCompare,omitempty" yaml:"jsonCompare,omitempty"`
 	NetworkNamespaceConnectivity *NetworkNamespaceConnectivityAnalyze `json:"networkNamespaceConnectivity,omitempty" yaml:"networkNamespaceConnectivity,omitempty"`
 	Sysctl                       *HostSysctlAnalyze                   `json:"sysctl,omitempty" yaml:"sysctl,omitempty"`
+	MTU                          *HostMTUAnalyze                      `json:"mtu,omitempty" yaml:"mtu,omitempty"`
+	Systemd                      *HostSystemdAnalyze                  `json:"systemd,omitempty" yaml:"systemd,omitempty"`
+	Dmesg                        *HostDmesgAnalyze                    `json:"dmesg,omitempty" yaml:"dmesg,omitempty"`
 }
@@ -235,6 +235,32 @@ type HostSysctl struct {
 	HostCollectorMeta `json:",inline" yaml:",inline"`
 }
 
+// HostMTU collects the MTU of the host's network interfaces that are up
+type HostMTU struct {
+	HostCollectorMeta `json:",inline" yaml:",inline"`
+}
+
+// HostSystemd collects the properties of systemd units, as shown by systemctl show
+type HostSystemd struct {
+	HostCollectorMeta `json:",inline" yaml:",inline"`
+	// Units to collect, e.g. kubelet.service or containerd.service
+	Units []string `json:"units" yaml:"units"`
+}
+
+// HostDmesg collects the messages of the kernel ring buffer, as shown by dmesg
+type HostDmesg struct {
+	HostCollectorMeta `json:",inline" yaml:",inline"`
+}
+
+// HostCRI collects the pods, containers and images of the container runtime through its CRI socket
+type HostCRI struct {
+	HostCollectorMeta `json:",inline" yaml:",inline"`
+	// Socket is the path of the CRI socket, it defaults to /run/containerd/containerd.sock
+	Socket string `json:"socket,omitempty" yaml:"socket,omitempty"`
+	// Timeout of each call to the container runtime, it defaults to 10s
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
 type HostCollect struct {
 	CPU                          *CPU                              `json:"cpu,omitempty" yaml:"cpu,omitempty"`
 	Memory                       *Memory                           `json:"memory,omitempty" yaml:"memory,omitempty"`
@@ -265,6 +291,10 @@ type HostCollect struct {
 	HostDNS                      *HostDNS                          `json:"dns,omitempty" yaml:"dns,omitempty"`
 	NetworkNamespaceConnectivity *HostNetworkNamespaceConnectivity `json:"networkNamespaceConnectivity,omitempty" yaml:"networkNamespaceConnectivity,omitempty"`
 	HostSysctl                   *HostSysctl                       `json:"sysctl,omitempty" yaml:"sysctl,omitempty"`
+	HostMTU                      *HostMTU                          `json:"mtu,omitempty" yaml:"mtu,omitempty"`
+	HostSystemd                  *HostSystemd                      `json:"systemd,omitempty" yaml:"systemd,omitempty"`
+	HostDmesg                    *HostDmesg                        `json:"dmesg,omitempty" yaml:"dmesg,omitempty"`
+	HostCRI                      *HostCRI                          `json:"cri,omitempty" yaml:"cri,omitempty"`
 }
 
 // GetName gets the name of the collector
@@ -4,6 +4,18 @@ type SingleOutcome struct {
 	When    string `json:"when,omitempty" yaml:"when,omitempty"`
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 	URI     string `json:"uri,omitempty" yaml:"uri,omitempty"`
+	// Remediation are the steps that fix the problem the outcome reports
+	Remediation []RemediationStep `json:"remediation,omitempty" yaml:"remediation,omitempty"`
+}
+
+// RemediationStep is an action that fixes a problem, with the command to run when there is one
+type RemediationStep struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Command     string `json:"command,omitempty" yaml:"command,omitempty"`
+	// IsAutomatable is set when the command can be run as is, without being reviewed or edited
+	IsAutomatable bool `json:"isAutomatable,omitempty" yaml:"isAutomatable,omitempty"`
+	// Priority orders the steps of all results, steps with a lower priority come first
+	Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
 }
 
 type Outcome struct {
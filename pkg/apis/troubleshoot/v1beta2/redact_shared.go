@@ -10,14 +10,68 @@ type FileSelector struct {
 	Files []string `json:"files,omitempty" yaml:"files,omitempty"`
 }
 
+// EntropyRemoval redacts tokens that look randomly generated. It catches secrets that do not
+// match any known pattern, at the risk of also matching identifiers such as hashes, so it is
+// only enabled when configured.
+type EntropyRemoval struct {
+	// MinLength is the shortest token considered, defaults to 20
+	MinLength int `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	// MinEntropy is the Shannon entropy, in bits per character, a token must reach, defaults to "4.2"
+	MinEntropy string `json:"minEntropy,omitempty" yaml:"minEntropy,omitempty"`
+	// MinCharacterClasses is how many of lowercase letters, uppercase letters, digits and
+	// symbols a token must contain, defaults to 3
+	MinCharacterClasses int `json:"minCharacterClasses,omitempty" yaml:"minCharacterClasses,omitempty"`
+}
+
+// SecretRemoval redacts the data of collected Secret objects by type. The metadata and type are
+// kept, as are the values known to be public such as TLS certificates, and registry credentials
+// in docker config secrets are masked while the registries and usernames are kept. All other
+// values are masked.
+type SecretRemoval struct {
+	// PreserveKeys are data keys whose values are kept in secrets of every type
+	PreserveKeys []string `json:"preserveKeys,omitempty" yaml:"preserveKeys,omitempty"`
+}
+
+// JSONPathRemoval redacts the values a JSONPath expression matches in JSON documents, e.g.
+// $.items[*].data.password. Object keys in the expression can be globs such as *password*, and
+// .. matches at any depth. The rest of the document is kept byte for byte.
+type JSONPathRemoval struct {
+	Path string `json:"path" yaml:"path"`
+	// Regex limits the redaction to the parts of the matched string values it matches, whole
+	// values are masked when it is not set
+	Regex string `json:"regex,omitempty" yaml:"regex,omitempty"`
+}
+
+// NetworkAddressRemoval replaces IPv4, IPv6 and MAC addresses with tokens, an address getting the
+// same token everywhere in the bundle. Loopback and unspecified addresses are kept. Internal
+// addresses are often considered sensitive, but they are also useful for debugging, so it is only
+// enabled when configured.
+type NetworkAddressRemoval struct {
+	// AllowCIDRs are ranges whose addresses are kept, such as the service and pod CIDRs of the
+	// cluster, e.g. 10.96.0.0/12
+	AllowCIDRs []string `json:"allowCIDRs,omitempty" yaml:"allowCIDRs,omitempty"`
+}
+
 type Removals struct {
-	Values   []string `json:"values,omitempty" yaml:"values,omitempty"`
-	Regex    []Regex  `json:"regex,omitempty" yaml:"regex,omitempty"`
-	YamlPath []string `json:"yamlPath,omitempty" yaml:"yamlPath,omitempty"`
+	Values           []string               `json:"values,omitempty" yaml:"values,omitempty"`
+	Regex            []Regex                `json:"regex,omitempty" yaml:"regex,omitempty"`
+	YamlPath         []string               `json:"yamlPath,omitempty" yaml:"yamlPath,omitempty"`
+	JSONPath         []JSONPathRemoval      `json:"jsonPath,omitempty" yaml:"jsonPath,omitempty"`
+	Entropy          *EntropyRemoval        `json:"entropy,omitempty" yaml:"entropy,omitempty"`
+	Secrets          *SecretRemoval         `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+	NetworkAddresses *NetworkAddressRemoval `json:"networkAddresses,omitempty" yaml:"networkAddresses,omitempty"`
 }
 
 type Redact struct {
 	Name         string       `json:"name,omitempty" yaml:"name,omitempty"`
 	FileSelector FileSelector `json:"fileSelector,omitempty" yaml:"fileSelector,omitempty"`
 	Removals     Removals     `json:"removals,omitempty" yaml:"removals,omitempty"`
+	// SkipRedaction are globs of files that are copied as they are, without running any redactor
+	// over them, e.g. binary files that are not detected as binary
+	SkipRedaction []string `json:"skipRedaction,omitempty" yaml:"skipRedaction,omitempty"`
+	// Tokenize replaces the values matched by the values, regex and entropy removals with tokens
+	// such as ***TOKEN_3f9a0c1b7d2e*** instead of ***HIDDEN***. The same value gets the same token
+	// across the whole bundle, so values can be correlated without being revealed. The mapping of
+	// tokens to values is saved outside of the bundle.
+	Tokenize bool `json:"tokenize,omitempty" yaml:"tokenize,omitempty"`
 }
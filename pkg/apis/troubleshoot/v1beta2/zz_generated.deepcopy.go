@@ -26,6 +26,113 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerFeaturesAnalyze) DeepCopyInto(out *APIServerFeaturesAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdmissionPlugins != nil {
+		in, out := &in.AdmissionPlugins, &out.AdmissionPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerFeaturesAnalyze.
+func (in *APIServerFeaturesAnalyze) DeepCopy() *APIServerFeaturesAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerFeaturesAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIWarningsAnalyze) DeepCopyInto(out *APIWarningsAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIWarningsAnalyze.
+func (in *APIWarningsAnalyze) DeepCopy() *APIWarningsAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(APIWarningsAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionWebhooks) DeepCopyInto(out *AdmissionWebhooks) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionWebhooks.
+func (in *AdmissionWebhooks) DeepCopy() *AdmissionWebhooks {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionWebhooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionWebhooksAnalyze) DeepCopyInto(out *AdmissionWebhooksAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionWebhooksAnalyze.
+func (in *AdmissionWebhooksAnalyze) DeepCopy() *AdmissionWebhooksAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionWebhooksAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AfterCollection) DeepCopyInto(out *AfterCollection) {
 	*out = *in
@@ -169,6 +276,11 @@ func (in *Analyze) DeepCopyInto(out *Analyze) {
 		*out = new(DatabaseAnalyze)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Mongodb != nil {
+		in, out := &in.Mongodb, &out.Mongodb
+		*out = new(DatabaseAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CephStatus != nil {
 		in, out := &in.CephStatus, &out.CephStatus
 		*out = new(CephStatusAnalyze)
@@ -229,6 +341,176 @@ func (in *Analyze) DeepCopyInto(out *Analyze) {
 		*out = new(HTTPAnalyze)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WaitForFirstConsumer != nil {
+		in, out := &in.WaitForFirstConsumer, &out.WaitForFirstConsumer
+		*out = new(WaitForFirstConsumerAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitOps != nil {
+		in, out := &in.GitOps, &out.GitOps
+		*out = new(GitOpsAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageArchitecture != nil {
+		in, out := &in.ImageArchitecture, &out.ImageArchitecture
+		*out = new(ImageArchitectureAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpread != nil {
+		in, out := &in.TopologySpread, &out.TopologySpread
+		*out = new(TopologySpreadAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CSR != nil {
+		in, out := &in.CSR, &out.CSR
+		*out = new(CSRAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GoldenSnapshot != nil {
+		in, out := &in.GoldenSnapshot, &out.GoldenSnapshot
+		*out = new(GoldenSnapshotAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OOMKilled != nil {
+		in, out := &in.OOMKilled, &out.OOMKilled
+		*out = new(OOMKilledAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIWarnings != nil {
+		in, out := &in.APIWarnings, &out.APIWarnings
+		*out = new(APIWarningsAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapDrift != nil {
+		in, out := &in.ConfigMapDrift, &out.ConfigMapDrift
+		*out = new(ConfigMapDriftAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(VPAAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMounts != nil {
+		in, out := &in.ConfigMounts, &out.ConfigMounts
+		*out = new(ConfigMountsAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(HighAvailabilityAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanedResources != nil {
+		in, out := &in.OrphanedResources, &out.OrphanedResources
+		*out = new(OrphanedResourcesAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastAppliedDrift != nil {
+		in, out := &in.LastAppliedDrift, &out.LastAppliedDrift
+		*out = new(LastAppliedDriftAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerFeatures != nil {
+		in, out := &in.APIServerFeatures, &out.APIServerFeatures
+		*out = new(APIServerFeaturesAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExposedServices != nil {
+		in, out := &in.ExposedServices, &out.ExposedServices
+		*out = new(ExposedServicesAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePolicy != nil {
+		in, out := &in.ImagePolicy, &out.ImagePolicy
+		*out = new(ImagePolicyAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OverPermissiveBindings != nil {
+		in, out := &in.OverPermissiveBindings, &out.OverPermissiveBindings
+		*out = new(OverPermissiveBindingsAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DuplicateAPIVersions != nil {
+		in, out := &in.DuplicateAPIVersions, &out.DuplicateAPIVersions
+		*out = new(DuplicateAPIVersionsAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MissingPDB != nil {
+		in, out := &in.MissingPDB, &out.MissingPDB
+		*out = new(MissingPDBAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMeshAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAutoscaler != nil {
+		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
+		*out = new(ClusterAutoscalerAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CordonedNodes != nil {
+		in, out := &in.CordonedNodes, &out.CordonedNodes
+		*out = new(CordonedNodesAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequiredNamespaces != nil {
+		in, out := &in.RequiredNamespaces, &out.RequiredNamespaces
+		*out = new(RequiredNamespacesAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WritableHostPath != nil {
+		in, out := &in.WritableHostPath, &out.WritableHostPath
+		*out = new(WritableHostPathAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeStateMetrics != nil {
+		in, out := &in.KubeStateMetrics, &out.KubeStateMetrics
+		*out = new(KubeStateMetricsAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogTimestamps != nil {
+		in, out := &in.LogTimestamps, &out.LogTimestamps
+		*out = new(LogTimestampsAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodePodCapacity != nil {
+		in, out := &in.NodePodCapacity, &out.NodePodCapacity
+		*out = new(NodePodCapacityAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = new(ReadinessGatesAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FieldManagers != nil {
+		in, out := &in.FieldManagers, &out.FieldManagers
+		*out = new(FieldManagersAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RBACGaps != nil {
+		in, out := &in.RBACGaps, &out.RBACGaps
+		*out = new(RBACGapsAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterVersionCompatibility != nil {
+		in, out := &in.ClusterVersionCompatibility, &out.ClusterVersionCompatibility
+		*out = new(ClusterVersionCompatibilityAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionWebhooks != nil {
+		in, out := &in.AdmissionWebhooks, &out.AdmissionWebhooks
+		*out = new(AdmissionWebhooksAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Analyze.
@@ -288,6 +570,11 @@ func (in *AnalyzeMeta) DeepCopyInto(out *AnalyzeMeta) {
 			(*out)[key] = val
 		}
 	}
+	if in.Blocking != nil {
+		in, out := &in.Blocking, &out.Blocking
+		*out = new(multitype.BoolOrString)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalyzeMeta.
@@ -508,6 +795,33 @@ func (in *CPUAnalyze) DeepCopy() *CPUAnalyze {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSRAnalyze) DeepCopyInto(out *CSRAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRAnalyze.
+func (in *CSRAnalyze) DeepCopy() *CSRAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(CSRAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ceph) DeepCopyInto(out *Ceph) {
 	*out = *in
@@ -671,6 +985,49 @@ func (in *CertificatesAnalyze) DeepCopy() *CertificatesAnalyze {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscaler) DeepCopyInto(out *ClusterAutoscaler) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscaler.
+func (in *ClusterAutoscaler) DeepCopy() *ClusterAutoscaler {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalerAnalyze) DeepCopyInto(out *ClusterAutoscalerAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerAnalyze.
+func (in *ClusterAutoscalerAnalyze) DeepCopy() *ClusterAutoscalerAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscalerAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterContainerStatuses) DeepCopyInto(out *ClusterContainerStatuses) {
 	*out = *in
@@ -739,6 +1096,11 @@ func (in *ClusterPodStatuses) DeepCopyInto(out *ClusterPodStatuses) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EphemeralPods != nil {
+		in, out := &in.EphemeralPods, &out.EphemeralPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPodStatuses.
@@ -787,6 +1149,23 @@ func (in *ClusterResources) DeepCopyInto(out *ClusterResources) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExcludeNamespaces != nil {
+		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceProfiles != nil {
+		in, out := &in.NamespaceProfiles, &out.NamespaceProfiles
+		*out = make([]ClusterResourcesNamespaceProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodLogLimits != nil {
+		in, out := &in.PodLogLimits, &out.PodLogLimits
+		*out = new(LogLimits)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResources.
@@ -799,6 +1178,31 @@ func (in *ClusterResources) DeepCopy() *ClusterResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourcesNamespaceProfile) DeepCopyInto(out *ClusterResourcesNamespaceProfile) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourcesNamespaceProfile.
+func (in *ClusterResourcesNamespaceProfile) DeepCopy() *ClusterResourcesNamespaceProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourcesNamespaceProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterVersion) DeepCopyInto(out *ClusterVersion) {
 	*out = *in
@@ -827,12 +1231,59 @@ func (in *ClusterVersion) DeepCopy() *ClusterVersion {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Collect) DeepCopyInto(out *Collect) {
+func (in *ClusterVersionCompatibility) DeepCopyInto(out *ClusterVersionCompatibility) {
 	*out = *in
-	if in.ClusterInfo != nil {
-		in, out := &in.ClusterInfo, &out.ClusterInfo
-		*out = new(ClusterInfo)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionCompatibility.
+func (in *ClusterVersionCompatibility) DeepCopy() *ClusterVersionCompatibility {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersionCompatibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVersionCompatibilityAnalyze) DeepCopyInto(out *ClusterVersionCompatibilityAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = make([]ClusterVersionCompatibility, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionCompatibilityAnalyze.
+func (in *ClusterVersionCompatibilityAnalyze) DeepCopy() *ClusterVersionCompatibilityAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersionCompatibilityAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Collect) DeepCopyInto(out *Collect) {
+	*out = *in
+	if in.ClusterInfo != nil {
+		in, out := &in.ClusterInfo, &out.ClusterInfo
+		*out = new(ClusterInfo)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ClusterResources != nil {
 		in, out := &in.ClusterResources, &out.ClusterResources
@@ -919,6 +1370,11 @@ func (in *Collect) DeepCopyInto(out *Collect) {
 		*out = new(Database)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Mongodb != nil {
+		in, out := &in.Mongodb, &out.Mongodb
+		*out = new(Database)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Collectd != nil {
 		in, out := &in.Collectd, &out.Collectd
 		*out = new(Collectd)
@@ -979,6 +1435,81 @@ func (in *Collect) DeepCopyInto(out *Collect) {
 		*out = new(Etcd)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GitOps != nil {
+		in, out := &in.GitOps, &out.GitOps
+		*out = new(GitOps)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeCommands != nil {
+		in, out := &in.NodeCommands, &out.NodeCommands
+		*out = new(NodeCommands)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(VPA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Focus != nil {
+		in, out := &in.Focus, &out.Focus
+		*out = new(Focus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(Security)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMesh)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAutoscaler != nil {
+		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
+		*out = new(ClusterAutoscaler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeStateMetrics != nil {
+		in, out := &in.KubeStateMetrics, &out.KubeStateMetrics
+		*out = new(KubeStateMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Traces != nil {
+		in, out := &in.Traces, &out.Traces
+		*out = new(Traces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kubelet != nil {
+		in, out := &in.Kubelet, &out.Kubelet
+		*out = new(Kubelet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HelmReleases != nil {
+		in, out := &in.HelmReleases, &out.HelmReleases
+		*out = new(HelmReleases)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkThroughput != nil {
+		in, out := &in.NetworkThroughput, &out.NetworkThroughput
+		*out = new(NetworkThroughput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricsServer != nil {
+		in, out := &in.MetricsServer, &out.MetricsServer
+		*out = new(MetricsServer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(Prometheus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionWebhooks != nil {
+		in, out := &in.AdmissionWebhooks, &out.AdmissionWebhooks
+		*out = new(AdmissionWebhooks)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Collect.
@@ -1106,6 +1637,16 @@ func (in *CollectorMeta) DeepCopyInto(out *CollectorMeta) {
 		*out = new(multitype.BoolOrString)
 		**out = **in
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transformers != nil {
+		in, out := &in.Transformers, &out.Transformers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorMeta.
@@ -1202,6 +1743,107 @@ func (in *ConfigMap) DeepCopy() *ConfigMap {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapDriftAnalyze) DeepCopyInto(out *ConfigMapDriftAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]ConfigMapDriftExpectation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapDriftAnalyze.
+func (in *ConfigMapDriftAnalyze) DeepCopy() *ConfigMapDriftAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapDriftAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapDriftExpectation) DeepCopyInto(out *ConfigMapDriftExpectation) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]ConfigMapDriftKey, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapDriftExpectation.
+func (in *ConfigMapDriftExpectation) DeepCopy() *ConfigMapDriftExpectation {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapDriftExpectation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapDriftKey) DeepCopyInto(out *ConfigMapDriftKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapDriftKey.
+func (in *ConfigMapDriftKey) DeepCopy() *ConfigMapDriftKey {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapDriftKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMountsAnalyze) DeepCopyInto(out *ConfigMountsAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMountsAnalyze.
+func (in *ConfigMountsAnalyze) DeepCopy() *ConfigMountsAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMountsAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerRuntime) DeepCopyInto(out *ContainerRuntime) {
 	*out = *in
@@ -1271,6 +1913,43 @@ func (in *CopyFromHost) DeepCopy() *CopyFromHost {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CordonedNodesAnalyze) DeepCopyInto(out *CordonedNodesAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaintenanceTaints != nil {
+		in, out := &in.MaintenanceTaints, &out.MaintenanceTaints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CordonedNodesAnalyze.
+func (in *CordonedNodesAnalyze) DeepCopy() *CordonedNodesAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(CordonedNodesAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomMetrics) DeepCopyInto(out *CustomMetrics) {
 	*out = *in
@@ -1506,6 +2185,58 @@ func (in *Distribution) DeepCopy() *Distribution {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DuplicateAPIVersionsAnalyze) DeepCopyInto(out *DuplicateAPIVersionsAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DuplicateAPIVersionsAnalyze.
+func (in *DuplicateAPIVersionsAnalyze) DeepCopy() *DuplicateAPIVersionsAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(DuplicateAPIVersionsAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntropyRemoval) DeepCopyInto(out *EntropyRemoval) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntropyRemoval.
+func (in *EntropyRemoval) DeepCopy() *EntropyRemoval {
+	if in == nil {
+		return nil
+	}
+	out := new(EntropyRemoval)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Etcd) DeepCopyInto(out *Etcd) {
 	*out = *in
@@ -1581,17 +2312,91 @@ func (in *Exec) DeepCopy() *Exec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileSelector) DeepCopyInto(out *FileSelector) {
+func (in *ExposedServicesAnalyze) DeepCopyInto(out *ExposedServicesAnalyze) {
 	*out = *in
-	if in.Files != nil {
-		in, out := &in.Files, &out.Files
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Allowlist != nil {
+		in, out := &in.Allowlist, &out.Allowlist
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSelector.
-func (in *FileSelector) DeepCopy() *FileSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposedServicesAnalyze.
+func (in *ExposedServicesAnalyze) DeepCopy() *ExposedServicesAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposedServicesAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldManagersAnalyze) DeepCopyInto(out *FieldManagersAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManualManagers != nil {
+		in, out := &in.ManualManagers, &out.ManualManagers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldManagersAnalyze.
+func (in *FieldManagersAnalyze) DeepCopy() *FieldManagersAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldManagersAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSelector) DeepCopyInto(out *FileSelector) {
+	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSelector.
+func (in *FileSelector) DeepCopy() *FileSelector {
 	if in == nil {
 		return nil
 	}
@@ -1648,6 +2453,22 @@ func (in *FilesystemPerformanceAnalyze) DeepCopy() *FilesystemPerformanceAnalyze
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Focus) DeepCopyInto(out *Focus) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Focus.
+func (in *Focus) DeepCopy() *Focus {
+	if in == nil {
+		return nil
+	}
+	out := new(Focus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Get) DeepCopyInto(out *Get) {
 	*out = *in
@@ -1675,6 +2496,101 @@ func (in *Get) DeepCopy() *Get {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOps) DeepCopyInto(out *GitOps) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOps.
+func (in *GitOps) DeepCopy() *GitOps {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsAnalyze) DeepCopyInto(out *GitOpsAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsAnalyze.
+func (in *GitOpsAnalyze) DeepCopy() *GitOpsAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenSnapshotAnalyze) DeepCopyInto(out *GoldenSnapshotAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenSnapshotAnalyze.
+func (in *GoldenSnapshotAnalyze) DeepCopy() *GoldenSnapshotAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenSnapshotAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Goldpinger) DeepCopyInto(out *Goldpinger) {
 	*out = *in
@@ -1840,6 +2756,86 @@ func (in *Helm) DeepCopy() *Helm {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmReleases) DeepCopyInto(out *HelmReleases) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReleaseNames != nil {
+		in, out := &in.ReleaseNames, &out.ReleaseNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmReleases.
+func (in *HelmReleases) DeepCopy() *HelmReleases {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmReleases)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityAnalyze) DeepCopyInto(out *HighAvailabilityAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]HighAvailabilityWorkload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityAnalyze.
+func (in *HighAvailabilityAnalyze) DeepCopy() *HighAvailabilityAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityWorkload) DeepCopyInto(out *HighAvailabilityWorkload) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityWorkload.
+func (in *HighAvailabilityWorkload) DeepCopy() *HighAvailabilityWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostAnalyze) DeepCopyInto(out *HostAnalyze) {
 	*out = *in
@@ -1968,6 +2964,21 @@ func (in *HostAnalyze) DeepCopyInto(out *HostAnalyze) {
 		*out = new(HostSysctlAnalyze)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(HostMTUAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Systemd != nil {
+		in, out := &in.Systemd, &out.Systemd
+		*out = new(HostSystemdAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dmesg != nil {
+		in, out := &in.Dmesg, &out.Dmesg
+		*out = new(HostDmesgAnalyze)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostAnalyze.
@@ -2012,6 +3023,22 @@ func (in *HostCGroups) DeepCopy() *HostCGroups {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostCRI) DeepCopyInto(out *HostCRI) {
+	*out = *in
+	in.HostCollectorMeta.DeepCopyInto(&out.HostCollectorMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostCRI.
+func (in *HostCRI) DeepCopy() *HostCRI {
+	if in == nil {
+		return nil
+	}
+	out := new(HostCRI)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostCertificatesCollection) DeepCopyInto(out *HostCertificatesCollection) {
 	*out = *in
@@ -2208,6 +3235,26 @@ func (in *HostCollect) DeepCopyInto(out *HostCollect) {
 		*out = new(HostSysctl)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HostMTU != nil {
+		in, out := &in.HostMTU, &out.HostMTU
+		*out = new(HostMTU)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostSystemd != nil {
+		in, out := &in.HostSystemd, &out.HostSystemd
+		*out = new(HostSystemd)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostDmesg != nil {
+		in, out := &in.HostDmesg, &out.HostDmesg
+		*out = new(HostDmesg)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostCRI != nil {
+		in, out := &in.HostCRI, &out.HostCRI
+		*out = new(HostCRI)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostCollect.
@@ -2389,45 +3436,88 @@ func (in *HostDNS) DeepCopy() *HostDNS {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostHTTP) DeepCopyInto(out *HostHTTP) {
+func (in *HostDmesg) DeepCopyInto(out *HostDmesg) {
 	*out = *in
 	in.HostCollectorMeta.DeepCopyInto(&out.HostCollectorMeta)
-	if in.Get != nil {
-		in, out := &in.Get, &out.Get
-		*out = new(Get)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Post != nil {
-		in, out := &in.Post, &out.Post
-		*out = new(Post)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Put != nil {
-		in, out := &in.Put, &out.Put
-		*out = new(Put)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostHTTP.
-func (in *HostHTTP) DeepCopy() *HostHTTP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostDmesg.
+func (in *HostDmesg) DeepCopy() *HostDmesg {
 	if in == nil {
 		return nil
 	}
-	out := new(HostHTTP)
+	out := new(HostDmesg)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostJournald) DeepCopyInto(out *HostJournald) {
+func (in *HostDmesgAnalyze) DeepCopyInto(out *HostDmesgAnalyze) {
 	*out = *in
-	in.HostCollectorMeta.DeepCopyInto(&out.HostCollectorMeta)
-	if in.Units != nil {
-		in, out := &in.Units, &out.Units
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostDmesgAnalyze.
+func (in *HostDmesgAnalyze) DeepCopy() *HostDmesgAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(HostDmesgAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostHTTP) DeepCopyInto(out *HostHTTP) {
+	*out = *in
+	in.HostCollectorMeta.DeepCopyInto(&out.HostCollectorMeta)
+	if in.Get != nil {
+		in, out := &in.Get, &out.Get
+		*out = new(Get)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Post != nil {
+		in, out := &in.Post, &out.Post
+		*out = new(Post)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Put != nil {
+		in, out := &in.Put, &out.Put
+		*out = new(Put)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostHTTP.
+func (in *HostHTTP) DeepCopy() *HostHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(HostHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostJournald) DeepCopyInto(out *HostJournald) {
+	*out = *in
+	in.HostCollectorMeta.DeepCopyInto(&out.HostCollectorMeta)
+	if in.Units != nil {
+		in, out := &in.Units, &out.Units
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostJournald.
@@ -2472,6 +3562,49 @@ func (in *HostKernelModules) DeepCopy() *HostKernelModules {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostMTU) DeepCopyInto(out *HostMTU) {
+	*out = *in
+	in.HostCollectorMeta.DeepCopyInto(&out.HostCollectorMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostMTU.
+func (in *HostMTU) DeepCopy() *HostMTU {
+	if in == nil {
+		return nil
+	}
+	out := new(HostMTU)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostMTUAnalyze) DeepCopyInto(out *HostMTUAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostMTUAnalyze.
+func (in *HostMTUAnalyze) DeepCopy() *HostMTUAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(HostMTUAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostNetworkNamespaceConnectivity) DeepCopyInto(out *HostNetworkNamespaceConnectivity) {
 	*out = *in
@@ -2898,6 +4031,54 @@ func (in *HostSystemPackages) DeepCopy() *HostSystemPackages {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSystemd) DeepCopyInto(out *HostSystemd) {
+	*out = *in
+	in.HostCollectorMeta.DeepCopyInto(&out.HostCollectorMeta)
+	if in.Units != nil {
+		in, out := &in.Units, &out.Units
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSystemd.
+func (in *HostSystemd) DeepCopy() *HostSystemd {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSystemd)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSystemdAnalyze) DeepCopyInto(out *HostSystemdAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSystemdAnalyze.
+func (in *HostSystemdAnalyze) DeepCopy() *HostSystemdAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSystemdAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostTime) DeepCopyInto(out *HostTime) {
 	*out = *in
@@ -2957,6 +4138,75 @@ func (in *IPV4InterfacesAnalyze) DeepCopy() *IPV4InterfacesAnalyze {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageArchitectureAnalyze) DeepCopyInto(out *ImageArchitectureAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageArchitectureAnalyze.
+func (in *ImageArchitectureAnalyze) DeepCopy() *ImageArchitectureAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageArchitectureAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicyAnalyze) DeepCopyInto(out *ImagePolicyAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MutableTags != nil {
+		in, out := &in.MutableTags, &out.MutableTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicyAnalyze.
+func (in *ImagePolicyAnalyze) DeepCopy() *ImagePolicyAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicyAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePullSecret) DeepCopyInto(out *ImagePullSecret) {
 	*out = *in
@@ -3033,6 +4283,21 @@ func (in *Ingress) DeepCopy() *Ingress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONPathRemoval) DeepCopyInto(out *JSONPathRemoval) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPathRemoval.
+func (in *JSONPathRemoval) DeepCopy() *JSONPathRemoval {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONPathRemoval)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobStatus) DeepCopyInto(out *JobStatus) {
 	*out = *in
@@ -3152,75 +4417,218 @@ func (in *KernelModulesAnalyze) DeepCopy() *KernelModulesAnalyze {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Kubernetes) DeepCopyInto(out *Kubernetes) {
+func (in *KubeStateMetrics) DeepCopyInto(out *KubeStateMetrics) {
 	*out = *in
-	in.HostCollectorMeta.DeepCopyInto(&out.HostCollectorMeta)
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kubernetes.
-func (in *Kubernetes) DeepCopy() *Kubernetes {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetrics.
+func (in *KubeStateMetrics) DeepCopy() *KubeStateMetrics {
 	if in == nil {
 		return nil
 	}
-	out := new(Kubernetes)
+	out := new(KubeStateMetrics)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogLimits) DeepCopyInto(out *LogLimits) {
+func (in *KubeStateMetricsAnalyze) DeepCopyInto(out *KubeStateMetricsAnalyze) {
 	*out = *in
-	in.SinceTime.DeepCopyInto(&out.SinceTime)
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogLimits.
-func (in *LogLimits) DeepCopy() *LogLimits {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetricsAnalyze.
+func (in *KubeStateMetricsAnalyze) DeepCopy() *KubeStateMetricsAnalyze {
 	if in == nil {
 		return nil
 	}
-	out := new(LogLimits)
+	out := new(KubeStateMetricsAnalyze)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Logs) DeepCopyInto(out *Logs) {
+func (in *Kubelet) DeepCopyInto(out *Kubelet) {
 	*out = *in
 	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
+	if in.NodeNames != nil {
+		in, out := &in.NodeNames, &out.NodeNames
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ContainerNames != nil {
-		in, out := &in.ContainerNames, &out.ContainerNames
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Limits != nil {
-		in, out := &in.Limits, &out.Limits
-		*out = new(LogLimits)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logs.
-func (in *Logs) DeepCopy() *Logs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kubelet.
+func (in *Kubelet) DeepCopy() *Kubelet {
 	if in == nil {
 		return nil
 	}
-	out := new(Logs)
+	out := new(Kubelet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Longhorn) DeepCopyInto(out *Longhorn) {
+func (in *Kubernetes) DeepCopyInto(out *Kubernetes) {
 	*out = *in
-	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+	in.HostCollectorMeta.DeepCopyInto(&out.HostCollectorMeta)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Longhorn.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kubernetes.
+func (in *Kubernetes) DeepCopy() *Kubernetes {
+	if in == nil {
+		return nil
+	}
+	out := new(Kubernetes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastAppliedDriftAnalyze) DeepCopyInto(out *LastAppliedDriftAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreFields != nil {
+		in, out := &in.IgnoreFields, &out.IgnoreFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastAppliedDriftAnalyze.
+func (in *LastAppliedDriftAnalyze) DeepCopy() *LastAppliedDriftAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(LastAppliedDriftAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogLimits) DeepCopyInto(out *LogLimits) {
+	*out = *in
+	in.SinceTime.DeepCopyInto(&out.SinceTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogLimits.
+func (in *LogLimits) DeepCopy() *LogLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(LogLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogTimestampsAnalyze) DeepCopyInto(out *LogTimestampsAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ExcludeFiles != nil {
+		in, out := &in.ExcludeFiles, &out.ExcludeFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogTimestampsAnalyze.
+func (in *LogTimestampsAnalyze) DeepCopy() *LogTimestampsAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(LogTimestampsAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Logs) DeepCopyInto(out *Logs) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContainerNames != nil {
+		in, out := &in.ContainerNames, &out.ContainerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(LogLimits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logs.
+func (in *Logs) DeepCopy() *Logs {
+	if in == nil {
+		return nil
+	}
+	out := new(Logs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Longhorn) DeepCopyInto(out *Longhorn) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Longhorn.
 func (in *Longhorn) DeepCopy() *Longhorn {
 	if in == nil {
 		return nil
@@ -3315,6 +4723,76 @@ func (in *MetricRequest) DeepCopy() *MetricRequest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsServer) DeepCopyInto(out *MetricsServer) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsServer.
+func (in *MetricsServer) DeepCopy() *MetricsServer {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MissingPDBAnalyze) DeepCopyInto(out *MissingPDBAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]HighAvailabilityWorkload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MissingPDBAnalyze.
+func (in *MissingPDBAnalyze) DeepCopy() *MissingPDBAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(MissingPDBAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkAddressRemoval) DeepCopyInto(out *NetworkAddressRemoval) {
+	*out = *in
+	if in.AllowCIDRs != nil {
+		in, out := &in.AllowCIDRs, &out.AllowCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAddressRemoval.
+func (in *NetworkAddressRemoval) DeepCopy() *NetworkAddressRemoval {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAddressRemoval)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkNamespaceConnectivityAnalyze) DeepCopyInto(out *NetworkNamespaceConnectivityAnalyze) {
 	*out = *in
@@ -3342,6 +4820,60 @@ func (in *NetworkNamespaceConnectivityAnalyze) DeepCopy() *NetworkNamespaceConne
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkThroughput) DeepCopyInto(out *NetworkThroughput) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkThroughput.
+func (in *NetworkThroughput) DeepCopy() *NetworkThroughput {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkThroughput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeCommands) DeepCopyInto(out *NodeCommands) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+	if in.ImagePullSecret != nil {
+		in, out := &in.ImagePullSecret, &out.ImagePullSecret
+		*out = new(ImagePullSecrets)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCommands.
+func (in *NodeCommands) DeepCopy() *NodeCommands {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeCommands)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeMetrics) DeepCopyInto(out *NodeMetrics) {
 	*out = *in
@@ -3416,6 +4948,33 @@ func (in *NodeMetricsAnalyzeFilters) DeepCopy() *NodeMetricsAnalyzeFilters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePodCapacityAnalyze) DeepCopyInto(out *NodePodCapacityAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePodCapacityAnalyze.
+func (in *NodePodCapacityAnalyze) DeepCopy() *NodePodCapacityAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePodCapacityAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeResourceFilters) DeepCopyInto(out *NodeResourceFilters) {
 	*out = *in
@@ -3497,23 +5056,87 @@ func (in *NodeResources) DeepCopy() *NodeResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OOMKilledAnalyze) DeepCopyInto(out *OOMKilledAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OOMKilledAnalyze.
+func (in *OOMKilledAnalyze) DeepCopy() *OOMKilledAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(OOMKilledAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedResourcesAnalyze) DeepCopyInto(out *OrphanedResourcesAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedResourcesAnalyze.
+func (in *OrphanedResourcesAnalyze) DeepCopy() *OrphanedResourcesAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedResourcesAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Outcome) DeepCopyInto(out *Outcome) {
 	*out = *in
 	if in.Fail != nil {
 		in, out := &in.Fail, &out.Fail
 		*out = new(SingleOutcome)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Warn != nil {
 		in, out := &in.Warn, &out.Warn
 		*out = new(SingleOutcome)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Pass != nil {
 		in, out := &in.Pass, &out.Pass
 		*out = new(SingleOutcome)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -3527,6 +5150,43 @@ func (in *Outcome) DeepCopy() *Outcome {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverPermissiveBindingsAnalyze) DeepCopyInto(out *OverPermissiveBindingsAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.WideSubjects != nil {
+		in, out := &in.WideSubjects, &out.WideSubjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Allowlist != nil {
+		in, out := &in.Allowlist, &out.Allowlist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverPermissiveBindingsAnalyze.
+func (in *OverPermissiveBindingsAnalyze) DeepCopy() *OverPermissiveBindingsAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(OverPermissiveBindingsAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PVCRef) DeepCopyInto(out *PVCRef) {
 	*out = *in
@@ -3711,6 +5371,69 @@ func (in *PreflightStatus) DeepCopy() *PreflightStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Prometheus) DeepCopyInto(out *Prometheus) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+	if in.Queries != nil {
+		in, out := &in.Queries, &out.Queries
+		*out = make([]PrometheusQuery, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prometheus.
+func (in *Prometheus) DeepCopy() *Prometheus {
+	if in == nil {
+		return nil
+	}
+	out := new(Prometheus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAnalyze) DeepCopyInto(out *PrometheusAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAnalyze.
+func (in *PrometheusAnalyze) DeepCopy() *PrometheusAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusQuery) DeepCopyInto(out *PrometheusQuery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusQuery.
+func (in *PrometheusQuery) DeepCopy() *PrometheusQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Put) DeepCopyInto(out *Put) {
 	*out = *in
@@ -3738,11 +5461,75 @@ func (in *Put) DeepCopy() *Put {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACGapsAnalyze) DeepCopyInto(out *RBACGapsAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACGapsAnalyze.
+func (in *RBACGapsAnalyze) DeepCopy() *RBACGapsAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACGapsAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessGatesAnalyze) DeepCopyInto(out *ReadinessGatesAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessGatesAnalyze.
+func (in *ReadinessGatesAnalyze) DeepCopy() *ReadinessGatesAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessGatesAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Redact) DeepCopyInto(out *Redact) {
 	*out = *in
 	in.FileSelector.DeepCopyInto(&out.FileSelector)
 	in.Removals.DeepCopyInto(&out.Removals)
+	if in.SkipRedaction != nil {
+		in, out := &in.SkipRedaction, &out.SkipRedaction
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Redact.
@@ -3918,7 +5705,22 @@ func (in *RegistryImagesAnalyze) DeepCopy() *RegistryImagesAnalyze {
 	if in == nil {
 		return nil
 	}
-	out := new(RegistryImagesAnalyze)
+	out := new(RegistryImagesAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationStep) DeepCopyInto(out *RemediationStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStep.
+func (in *RemediationStep) DeepCopy() *RemediationStep {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationStep)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4514,6 +6316,26 @@ func (in *Removals) DeepCopyInto(out *Removals) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.JSONPath != nil {
+		in, out := &in.JSONPath, &out.JSONPath
+		*out = make([]JSONPathRemoval, len(*in))
+		copy(*out, *in)
+	}
+	if in.Entropy != nil {
+		in, out := &in.Entropy, &out.Entropy
+		*out = new(EntropyRemoval)
+		**out = **in
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = new(SecretRemoval)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkAddresses != nil {
+		in, out := &in.NetworkAddresses, &out.NetworkAddresses
+		*out = new(NetworkAddressRemoval)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Removals.
@@ -4563,6 +6385,38 @@ func (in *ReplicaSetStatus) DeepCopy() *ReplicaSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredNamespacesAnalyze) DeepCopyInto(out *RequiredNamespacesAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredNamespacesAnalyze.
+func (in *RequiredNamespacesAnalyze) DeepCopy() *RequiredNamespacesAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(RequiredNamespacesAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResultRequest) DeepCopyInto(out *ResultRequest) {
 	*out = *in
@@ -4681,9 +6535,103 @@ func (in *Secret) DeepCopy() *Secret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRemoval) DeepCopyInto(out *SecretRemoval) {
+	*out = *in
+	if in.PreserveKeys != nil {
+		in, out := &in.PreserveKeys, &out.PreserveKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRemoval.
+func (in *SecretRemoval) DeepCopy() *SecretRemoval {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRemoval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Security) DeepCopyInto(out *Security) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Security.
+func (in *Security) DeepCopy() *Security {
+	if in == nil {
+		return nil
+	}
+	out := new(Security)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMesh) DeepCopyInto(out *ServiceMesh) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMesh.
+func (in *ServiceMesh) DeepCopy() *ServiceMesh {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMesh)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMeshAnalyze) DeepCopyInto(out *ServiceMeshAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMeshAnalyze.
+func (in *ServiceMeshAnalyze) DeepCopy() *ServiceMeshAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMeshAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SingleOutcome) DeepCopyInto(out *SingleOutcome) {
 	*out = *in
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = make([]RemediationStep, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleOutcome.
@@ -5287,6 +7235,54 @@ func (in *TimeAnalyze) DeepCopy() *TimeAnalyze {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpreadAnalyze) DeepCopyInto(out *TopologySpreadAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpreadAnalyze.
+func (in *TopologySpreadAnalyze) DeepCopy() *TopologySpreadAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpreadAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Traces) DeepCopyInto(out *Traces) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Traces.
+func (in *Traces) DeepCopy() *Traces {
+	if in == nil {
+		return nil
+	}
+	out := new(Traces)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UDPPortStatus) DeepCopyInto(out *UDPPortStatus) {
 	*out = *in
@@ -5330,6 +7326,59 @@ func (in *UDPPortStatusAnalyze) DeepCopy() *UDPPortStatusAnalyze {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPA) DeepCopyInto(out *VPA) {
+	*out = *in
+	in.CollectorMeta.DeepCopyInto(&out.CollectorMeta)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPA.
+func (in *VPA) DeepCopy() *VPA {
+	if in == nil {
+		return nil
+	}
+	out := new(VPA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPAAnalyze) DeepCopyInto(out *VPAAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPAAnalyze.
+func (in *VPAAnalyze) DeepCopy() *VPAAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(VPAAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VeleroAnalyze) DeepCopyInto(out *VeleroAnalyze) {
 	*out = *in
@@ -5346,6 +7395,38 @@ func (in *VeleroAnalyze) DeepCopy() *VeleroAnalyze {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitForFirstConsumerAnalyze) DeepCopyInto(out *WaitForFirstConsumerAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitForFirstConsumerAnalyze.
+func (in *WaitForFirstConsumerAnalyze) DeepCopy() *WaitForFirstConsumerAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitForFirstConsumerAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WeaveReportAnalyze) DeepCopyInto(out *WeaveReportAnalyze) {
 	*out = *in
@@ -5362,6 +7443,43 @@ func (in *WeaveReportAnalyze) DeepCopy() *WeaveReportAnalyze {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WritableHostPathAnalyze) DeepCopyInto(out *WritableHostPathAnalyze) {
+	*out = *in
+	in.AnalyzeMeta.DeepCopyInto(&out.AnalyzeMeta)
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]*Outcome, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Outcome)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SensitivePaths != nil {
+		in, out := &in.SensitivePaths, &out.SensitivePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WritableHostPathAnalyze.
+func (in *WritableHostPathAnalyze) DeepCopy() *WritableHostPathAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(WritableHostPathAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *YamlCompare) DeepCopyInto(out *YamlCompare) {
 	*out = *in
@@ -0,0 +1,252 @@
+package bundleserver
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ChecksumHeader carries the hex encoded SHA-256 checksum of the uploaded archive. When it is
+	// set the upload is rejected unless the received archive matches it.
+	ChecksumHeader = "X-Bundle-Sha256"
+
+	// BundleFormField is the multipart form field holding the archive
+	BundleFormField = "bundle"
+)
+
+var bundleIDPattern = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
+
+type Options struct {
+	// Store saves the received archives, named <id>.tar.gz
+	Store collect.BundleStore
+	// Token must be sent as a bearer token with every request
+	Token string
+	// MaxBundleSize is the largest archive accepted in bytes, 0 accepts any size
+	MaxBundleSize int64
+}
+
+// UploadResponse is returned for an accepted upload
+type UploadResponse struct {
+	ID     string `json:"id"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Server receives support bundle archives over HTTP and saves them to a bundle store. Archives are
+// uploaded with POST /v1/bundles, either as the request body or as the "bundle" field of a
+// multipart form, and are streamed to the store without being buffered in memory. They are
+// downloaded again with GET /v1/bundles/{id}.
+type Server struct {
+	opts Options
+}
+
+func NewServer(opts Options) (*Server, error) {
+	if opts.Store == nil {
+		return nil, errors.New("a bundle store is required")
+	}
+	if opts.Token == "" {
+		return nil, errors.New("an auth token is required")
+	}
+	return &Server{opts: opts}, nil
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/bundles", s.upload)
+	mux.HandleFunc("GET /v1/bundles/{id}", s.download)
+	return s.authenticate(mux)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="support-bundles"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) upload(w http.ResponseWriter, r *http.Request) {
+	body := r.Body
+	if s.opts.MaxBundleSize > 0 {
+		body = http.MaxBytesReader(w, r.Body, s.opts.MaxBundleSize)
+	}
+
+	archive, err := uploadedArchive(r, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, err := newBundleID()
+	if err != nil {
+		klog.Errorf("Failed to generate bundle id: %v", err)
+		http.Error(w, "failed to save bundle", http.StatusInternalServerError)
+		return
+	}
+
+	hash := sha256.New()
+	size, err := s.opts.Store.Write(r.Context(), bundleName(id), io.TeeReader(archive, hash))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, fmt.Sprintf("bundle is larger than %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+			return
+		}
+		klog.Errorf("Failed to save bundle %s: %v", id, err)
+		http.Error(w, "failed to save bundle", http.StatusInternalServerError)
+		return
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+
+	if err := s.verify(r, id, checksum); err != nil {
+		if deleteErr := s.opts.Store.Delete(r.Context(), bundleName(id)); deleteErr != nil {
+			klog.Errorf("Failed to delete rejected bundle %s: %v", id, deleteErr)
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	klog.Infof("Received bundle %s (%d bytes, sha256 %s)", id, size, checksum)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/v1/bundles/"+id)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(UploadResponse{ID: id, Size: size, SHA256: checksum})
+}
+
+// verify checks the checksum sent with the upload, then reads the saved archive back through to
+// the end so a truncated or corrupted gzip stream is caught by its trailing checksum
+func (s *Server) verify(r *http.Request, id, checksum string) error {
+	if expected := r.Header.Get(ChecksumHeader); expected != "" {
+		expected = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(expected), "sha256:"))
+		if expected != checksum {
+			return errors.Errorf("checksum mismatch: expected sha256 %s, got %s", expected, checksum)
+		}
+	}
+
+	saved, err := s.opts.Store.Read(r.Context(), bundleName(id))
+	if err != nil {
+		return errors.Wrap(err, "failed to read saved bundle")
+	}
+	defer saved.Close()
+
+	return validateArchive(saved)
+}
+
+// bundleName is the name a bundle is saved with in the store
+func bundleName(id string) string {
+	return id + ".tar.gz"
+}
+
+func (s *Server) download(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !bundleIDPattern.MatchString(id) {
+		http.Error(w, "invalid bundle id", http.StatusBadRequest)
+		return
+	}
+
+	bundle, err := s.opts.Store.Read(r.Context(), bundleName(id))
+	if errors.Is(err, collect.ErrBundleNotFound) {
+		http.Error(w, "bundle not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		klog.Errorf("Failed to open bundle %s: %v", id, err)
+		http.Error(w, "failed to read bundle", http.StatusInternalServerError)
+		return
+	}
+	defer bundle.Close()
+
+	w.Header().Set("Content-Type", "application/tar+gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", bundleName(id)))
+	if _, err := io.Copy(w, bundle); err != nil {
+		klog.Errorf("Failed to send bundle %s: %v", id, err)
+	}
+}
+
+// uploadedArchive returns the archive of a multipart upload, reading the form part by part, or
+// the request body itself for any other content type
+func uploadedArchive(r *http.Request, body io.Reader) (io.Reader, error) {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return body, nil
+	}
+
+	form := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := form.NextPart()
+		if err == io.EOF {
+			return nil, errors.Errorf("multipart upload has no %q field", BundleFormField)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read multipart upload")
+		}
+		if part.FormName() == BundleFormField {
+			return part, nil
+		}
+	}
+}
+
+// validateArchive reads every entry of a gzipped tar archive and the rest of the gzip stream
+func validateArchive(r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "bundle is not a gzip archive")
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	entries := 0
+	for {
+		_, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "bundle is not a valid tar archive")
+		}
+		if _, err := io.Copy(io.Discard, tr); err != nil {
+			return errors.Wrap(err, "bundle is truncated or corrupted")
+		}
+		entries++
+	}
+	if entries == 0 {
+		return errors.New("bundle archive is empty")
+	}
+
+	// the gzip checksum is only verified once the stream is read past the end of the tar archive
+	if _, err := io.Copy(io.Discard, gz); err != nil {
+		return errors.Wrap(err, "bundle is truncated or corrupted")
+	}
+	return nil
+}
+
+// newBundleID returns a sortable id made of the upload time and a random suffix
+func newBundleID() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102-150405"), hex.EncodeToString(b)), nil
+}
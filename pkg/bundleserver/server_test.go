@@ -0,0 +1,153 @@
+package bundleserver
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBundle(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{
+		"support-bundle/version.yaml":                     "apiVersion: troubleshoot.sh/v1beta2\nkind: SupportBundle\n",
+		"support-bundle/cluster-resources/nodes.json":     `{"items": []}`,
+		"support-bundle/cluster-resources/pods/kube.json": `{"items": []}`,
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func multipartBody(t *testing.T, field string, content []byte) (io.Reader, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	require.NoError(t, mw.WriteField("note", "uploaded from ci"))
+	part, err := mw.CreateFormFile(field, "support-bundle.tar.gz")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+	return &buf, mw.FormDataContentType()
+}
+
+func TestServer_UploadAndDownload(t *testing.T) {
+	dir := t.TempDir()
+	store, err := collect.NewLocalBundleStore(dir)
+	require.NoError(t, err)
+	server, err := NewServer(Options{Store: store, Token: "s3cret", MaxBundleSize: 1 << 20})
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	bundle := testBundle(t)
+	sum := sha256.Sum256(bundle)
+	checksum := hex.EncodeToString(sum[:])
+
+	upload := func(body io.Reader, contentType, token string, headers map[string]string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/v1/bundles", body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("multipart upload is stored and downloaded", func(t *testing.T) {
+		body, contentType := multipartBody(t, BundleFormField, bundle)
+		resp := upload(body, contentType, "s3cret", map[string]string{ChecksumHeader: "sha256:" + checksum})
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var uploaded UploadResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&uploaded))
+		assert.Regexp(t, bundleIDPattern, uploaded.ID)
+		assert.Equal(t, int64(len(bundle)), uploaded.Size)
+		assert.Equal(t, checksum, uploaded.SHA256)
+		assert.Equal(t, "/v1/bundles/"+uploaded.ID, resp.Header.Get("Location"))
+
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/bundles/"+uploaded.ID, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		download, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer download.Body.Close()
+		require.Equal(t, http.StatusOK, download.StatusCode)
+
+		downloaded, err := io.ReadAll(download.Body)
+		require.NoError(t, err)
+		assert.Equal(t, bundle, downloaded)
+	})
+
+	t.Run("raw body upload", func(t *testing.T) {
+		resp := upload(bytes.NewReader(bundle), "application/tar+gzip", "s3cret", nil)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	rejected := []struct {
+		name       string
+		body       []byte
+		token      string
+		headers    map[string]string
+		wantStatus int
+	}{
+		{name: "missing token", body: bundle, wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", body: bundle, token: "guess", wantStatus: http.StatusUnauthorized},
+		{name: "checksum mismatch", body: bundle, token: "s3cret", headers: map[string]string{ChecksumHeader: "deadbeef"}, wantStatus: http.StatusBadRequest},
+		{name: "truncated archive", body: bundle[:len(bundle)-10], token: "s3cret", wantStatus: http.StatusBadRequest},
+		{name: "not an archive", body: []byte("hello"), token: "s3cret", wantStatus: http.StatusBadRequest},
+		{name: "too large", body: bytes.Repeat([]byte("a"), 2<<20), token: "s3cret", wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, test := range rejected {
+		t.Run(test.name, func(t *testing.T) {
+			before, err := os.ReadDir(dir)
+			require.NoError(t, err)
+
+			resp := upload(bytes.NewReader(test.body), "application/tar+gzip", test.token, test.headers)
+			assert.Equal(t, test.wantStatus, resp.StatusCode)
+
+			after, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, after, len(before), "rejected bundles must not be kept")
+		})
+	}
+
+	t.Run("unknown bundle", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/bundles/20260101-000000-abcdef", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,307 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const admissionWebhookDefaultTimeout = 5 * time.Second
+
+type CollectAdmissionWebhooks struct {
+	Collector    *troubleshootv1beta2.AdmissionWebhooks
+	BundlePath   string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+// AdmissionWebhookStatus is the reachability of an admission webhook, saved in reachability.json
+// by the admission webhooks collector
+type AdmissionWebhookStatus struct {
+	// Kind is ValidatingWebhookConfiguration or MutatingWebhookConfiguration
+	Kind          string `json:"kind"`
+	Configuration string `json:"configuration"`
+	Webhook       string `json:"webhook"`
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+	// Service is the namespace/name of the service the webhook calls, URL is set instead when the
+	// webhook calls a URL
+	Service string `json:"service,omitempty"`
+	URL     string `json:"url,omitempty"`
+	// ServiceFound and ReadyEndpoints are nil when the service or its endpoints could not be read
+	ServiceFound   *bool `json:"serviceFound,omitempty"`
+	ReadyEndpoints *int  `json:"readyEndpoints,omitempty"`
+	// Address is the host:port that was dialed. Reachable is nil when it was not dialed or could
+	// not be resolved, as happens for services when the collector does not run in the cluster.
+	Address   string `json:"address,omitempty"`
+	Reachable *bool  `json:"reachable,omitempty"`
+	Error     string `json:"error,omitempty"`
+	// CertificateNotAfter is the expiry of the serving certificate. CertificateError is set when the
+	// certificate is not valid for the address or not signed by the caBundle of the webhook.
+	CertificateNotAfter *time.Time `json:"certificateNotAfter,omitempty"`
+	CertificateError    string     `json:"certificateError,omitempty"`
+}
+
+// dialContextFunc opens the connection to a webhook, a net.Dialer outside of tests
+type dialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+func (c *CollectAdmissionWebhooks) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectAdmissionWebhooks) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectAdmissionWebhooks) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	timeout := admissionWebhookDefaultTimeout
+	if c.Collector.Timeout != "" {
+		parsed, err := time.ParseDuration(c.Collector.Timeout)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse timeout")
+		}
+		timeout = parsed
+	}
+
+	dialer := &net.Dialer{}
+	files, errs := admissionWebhooks(c.Context, c.Client, timeout, dialer.DialContext)
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(constants.ADMISSION_WEBHOOKS_DIR, fileName), bytes.NewBuffer(data))
+	}
+	output.SaveResult(c.BundlePath, path.Join(constants.ADMISSION_WEBHOOKS_DIR, "errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// admissionWebhooks returns the validating and mutating webhook configurations as validating.json
+// and mutating.json, and the reachability of each of their webhooks as reachability.json
+func admissionWebhooks(ctx context.Context, client kubernetes.Interface, timeout time.Duration, dial dialContextFunc) (map[string][]byte, []string) {
+	files := map[string][]byte{}
+	errorList := []string{}
+	statuses := []AdmissionWebhookStatus{}
+
+	validating, err := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		errorList = append(errorList, errors.Wrap(err, "failed to list validating webhook configurations").Error())
+	} else {
+		gvk, err := apiutil.GVKForObject(validating, scheme.Scheme)
+		if err == nil {
+			validating.GetObjectKind().SetGroupVersionKind(gvk)
+		}
+
+		for i, o := range validating.Items {
+			gvk, err := apiutil.GVKForObject(&o, scheme.Scheme)
+			if err == nil {
+				validating.Items[i].GetObjectKind().SetGroupVersionKind(gvk)
+			}
+
+			for _, webhook := range o.Webhooks {
+				status := AdmissionWebhookStatus{
+					Kind:          "ValidatingWebhookConfiguration",
+					Configuration: o.Name,
+					Webhook:       webhook.Name,
+					FailurePolicy: string(ptr.Deref(webhook.FailurePolicy, "")),
+				}
+				statuses = append(statuses, dialAdmissionWebhook(ctx, client, status, webhook.ClientConfig, timeout, dial))
+			}
+		}
+
+		b, err := json.MarshalIndent(validating, "", "  ")
+		if err != nil {
+			errorList = append(errorList, errors.Wrap(err, "failed to marshal validating webhook configurations").Error())
+		} else {
+			files["validating.json"] = b
+		}
+	}
+
+	mutating, err := client.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		errorList = append(errorList, errors.Wrap(err, "failed to list mutating webhook configurations").Error())
+	} else {
+		gvk, err := apiutil.GVKForObject(mutating, scheme.Scheme)
+		if err == nil {
+			mutating.GetObjectKind().SetGroupVersionKind(gvk)
+		}
+
+		for i, o := range mutating.Items {
+			gvk, err := apiutil.GVKForObject(&o, scheme.Scheme)
+			if err == nil {
+				mutating.Items[i].GetObjectKind().SetGroupVersionKind(gvk)
+			}
+
+			for _, webhook := range o.Webhooks {
+				status := AdmissionWebhookStatus{
+					Kind:          "MutatingWebhookConfiguration",
+					Configuration: o.Name,
+					Webhook:       webhook.Name,
+					FailurePolicy: string(ptr.Deref(webhook.FailurePolicy, "")),
+				}
+				statuses = append(statuses, dialAdmissionWebhook(ctx, client, status, webhook.ClientConfig, timeout, dial))
+			}
+		}
+
+		b, err := json.MarshalIndent(mutating, "", "  ")
+		if err != nil {
+			errorList = append(errorList, errors.Wrap(err, "failed to marshal mutating webhook configurations").Error())
+		} else {
+			files["mutating.json"] = b
+		}
+	}
+
+	b, err := json.MarshalIndent(statuses, "", "  ")
+	if err != nil {
+		errorList = append(errorList, errors.Wrap(err, "failed to marshal webhook reachability").Error())
+		return files, errorList
+	}
+	files["reachability.json"] = b
+
+	return files, errorList
+}
+
+// dialAdmissionWebhook completes status with the service or URL the webhook calls and the result
+// of a TLS handshake with it
+func dialAdmissionWebhook(ctx context.Context, client kubernetes.Interface, status AdmissionWebhookStatus, clientConfig admissionregistrationv1.WebhookClientConfig, timeout time.Duration, dial dialContextFunc) AdmissionWebhookStatus {
+	serverName := ""
+
+	switch {
+	case clientConfig.Service != nil:
+		service := clientConfig.Service
+		status.Service = fmt.Sprintf("%s/%s", service.Namespace, service.Name)
+		// the API server calls services by this name, the serving certificate must be valid for it
+		serverName = fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+		status.Address = net.JoinHostPort(serverName, strconv.Itoa(int(ptr.Deref(service.Port, 443))))
+
+		_, err := client.CoreV1().Services(service.Namespace).Get(ctx, service.Name, metav1.GetOptions{})
+		if kuberneteserrors.IsNotFound(err) {
+			status.ServiceFound = ptr.To(false)
+			status.Error = fmt.Sprintf("service %s was not found", status.Service)
+			return status
+		}
+		if err != nil {
+			status.Error = errors.Wrapf(err, "failed to get service %s", status.Service).Error()
+		} else {
+			status.ServiceFound = ptr.To(true)
+		}
+
+		endpointSlices, err := client.DiscoveryV1().EndpointSlices(service.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, service.Name),
+		})
+		if err != nil {
+			status.Error = errors.Wrapf(err, "failed to list endpoint slices of service %s", status.Service).Error()
+		} else {
+			status.ReadyEndpoints = ptr.To(readyEndpoints(endpointSlices.Items))
+		}
+
+	case clientConfig.URL != nil:
+		status.URL = *clientConfig.URL
+		u, err := url.Parse(*clientConfig.URL)
+		if err != nil {
+			status.Error = errors.Wrap(err, "failed to parse url").Error()
+			return status
+		}
+		serverName = u.Hostname()
+		port := u.Port()
+		if port == "" {
+			port = "443"
+		}
+		status.Address = net.JoinHostPort(serverName, port)
+
+	default:
+		status.Error = "the webhook calls neither a service nor a url"
+		return status
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := dial(ctx, "tcp", status.Address)
+	if err != nil {
+		status.Error = err.Error()
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) {
+			status.Reachable = ptr.To(false)
+		}
+		return status
+	}
+	defer conn.Close()
+
+	// the certificate is verified against the caBundle of the webhook below, the handshake must
+	// succeed even when it is not trusted to record its expiry
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: serverName, InsecureSkipVerify: true}) // nolint:gosec
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		status.Reachable = ptr.To(false)
+		status.Error = errors.Wrap(err, "tls handshake failed").Error()
+		return status
+	}
+	status.Reachable = ptr.To(true)
+
+	certs := tlsConn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return status
+	}
+	status.CertificateNotAfter = ptr.To(certs[0].NotAfter)
+	if err := verifyWebhookCertificate(certs, serverName, clientConfig.CABundle); err != nil {
+		status.CertificateError = err.Error()
+	}
+
+	return status
+}
+
+// verifyWebhookCertificate verifies the serving certificate of a webhook the way the API server
+// does, against the caBundle of the webhook or the system roots when it has none
+func verifyWebhookCertificate(certs []*x509.Certificate, serverName string, caBundle []byte) error {
+	opts := x509.VerifyOptions{
+		DNSName:       serverName,
+		Intermediates: x509.NewCertPool(),
+	}
+	if len(caBundle) > 0 {
+		opts.Roots = x509.NewCertPool()
+		if !opts.Roots.AppendCertsFromPEM(caBundle) {
+			return errors.New("the caBundle of the webhook has no valid certificate")
+		}
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+// readyEndpoints counts the endpoints of the slices that are ready, an endpoint without a ready
+// condition is ready
+func readyEndpoints(endpointSlices []discoveryv1.EndpointSlice) int {
+	ready := 0
+	for _, slice := range endpointSlices {
+		for _, endpoint := range slice.Endpoints {
+			if ptr.Deref(endpoint.Conditions.Ready, true) {
+				ready++
+			}
+		}
+	}
+	return ready
+}
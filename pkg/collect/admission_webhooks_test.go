@@ -0,0 +1,154 @@
+package collect
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func Test_admissionWebhooks(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	client := testclient.NewSimpleClientset(
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "policy"}},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "policy-abc",
+				Namespace: "policy",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "policy"},
+			},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}},
+				{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+			},
+		},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "scaled-down", Namespace: "policy"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "sidecar", Namespace: "mesh"}},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{
+				{
+					Name:          "validate.policy.io",
+					FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+					ClientConfig: admissionregistrationv1.WebhookClientConfig{
+						Service:  &admissionregistrationv1.ServiceReference{Namespace: "policy", Name: "policy"},
+						CABundle: caBundle,
+					},
+				},
+				{
+					Name:          "scaled-down.policy.io",
+					FailurePolicy: ptr.To(admissionregistrationv1.Ignore),
+					ClientConfig: admissionregistrationv1.WebhookClientConfig{
+						Service: &admissionregistrationv1.ServiceReference{Namespace: "policy", Name: "scaled-down", Port: ptr.To(int32(8443))},
+					},
+				},
+				{
+					Name: "removed.policy.io",
+					ClientConfig: admissionregistrationv1.WebhookClientConfig{
+						Service: &admissionregistrationv1.ServiceReference{Namespace: "policy", Name: "removed"},
+					},
+				},
+			},
+		},
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "external"},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name: "external.example.com",
+					ClientConfig: admissionregistrationv1.WebhookClientConfig{
+						URL:      ptr.To(server.URL + "/mutate"),
+						CABundle: caBundle,
+					},
+				},
+				{
+					Name: "sidecar.mesh.io",
+					ClientConfig: admissionregistrationv1.WebhookClientConfig{
+						Service: &admissionregistrationv1.ServiceReference{Namespace: "mesh", Name: "sidecar"},
+					},
+				},
+			},
+		},
+	)
+
+	// policy.policy.svc is served by the test server, scaled-down refuses connections and the
+	// other services do not resolve as if the collector ran outside of the cluster
+	dial := func(ctx context.Context, network, address string) (net.Conn, error) {
+		switch address {
+		case "policy.policy.svc:443":
+			address = server.Listener.Addr().String()
+		case "scaled-down.policy.svc:8443":
+			return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connect: connection refused")}
+		}
+		host, _, _ := net.SplitHostPort(address)
+		if host != "127.0.0.1" {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return (&net.Dialer{}).DialContext(ctx, network, address)
+	}
+
+	files, errs := admissionWebhooks(context.Background(), client, time.Second, dial)
+	assert.Empty(t, errs)
+
+	validating := admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	require.NoError(t, json.Unmarshal(files["validating.json"], &validating))
+	require.Len(t, validating.Items, 1)
+	assert.Equal(t, "ValidatingWebhookConfiguration", validating.Items[0].Kind)
+	assert.Equal(t, "admissionregistration.k8s.io/v1", validating.Items[0].APIVersion)
+	assert.Contains(t, files, "mutating.json")
+
+	statuses := []AdmissionWebhookStatus{}
+	require.NoError(t, json.Unmarshal(files["reachability.json"], &statuses))
+	require.Len(t, statuses, 5)
+
+	// the test server certificate is only valid for example.com and 127.0.0.1
+	policy := statuses[0]
+	assert.Equal(t, "validate.policy.io", policy.Webhook)
+	assert.Equal(t, "Fail", policy.FailurePolicy)
+	assert.Equal(t, "policy/policy", policy.Service)
+	assert.Equal(t, ptr.To(true), policy.ServiceFound)
+	assert.Equal(t, ptr.To(1), policy.ReadyEndpoints)
+	assert.Equal(t, ptr.To(true), policy.Reachable)
+	require.NotNil(t, policy.CertificateNotAfter)
+	assert.True(t, policy.CertificateNotAfter.Equal(server.Certificate().NotAfter))
+	assert.Contains(t, policy.CertificateError, "not policy.policy.svc")
+
+	scaledDown := statuses[1]
+	assert.Equal(t, "scaled-down.policy.svc:8443", scaledDown.Address)
+	assert.Equal(t, ptr.To(true), scaledDown.ServiceFound)
+	assert.Equal(t, ptr.To(0), scaledDown.ReadyEndpoints)
+	assert.Equal(t, ptr.To(false), scaledDown.Reachable)
+	assert.Contains(t, scaledDown.Error, "connection refused")
+
+	removed := statuses[2]
+	assert.Equal(t, ptr.To(false), removed.ServiceFound)
+	assert.Nil(t, removed.Reachable)
+	assert.Equal(t, "service policy/removed was not found", removed.Error)
+
+	external := statuses[3]
+	assert.Equal(t, "MutatingWebhookConfiguration", external.Kind)
+	assert.Equal(t, server.URL+"/mutate", external.URL)
+	assert.Equal(t, ptr.To(true), external.Reachable)
+	assert.Empty(t, external.CertificateError)
+
+	sidecar := statuses[4]
+	assert.Equal(t, ptr.To(true), sidecar.ServiceFound)
+	assert.Nil(t, sidecar.Reachable)
+	assert.Contains(t, sidecar.Error, "no such host")
+}
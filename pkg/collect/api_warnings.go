@@ -0,0 +1,60 @@
+package collect
+
+import (
+	"sort"
+	"sync"
+)
+
+// APIWarning is a warning returned by the apiserver in a Warning header while collecting, such
+// as the notice sent when a deprecated API version is read.
+type APIWarning struct {
+	Code    int    `json:"code"`
+	Agent   string `json:"agent"`
+	Message string `json:"message"`
+	// Count is the number of responses the warning was returned in
+	Count int `json:"count"`
+}
+
+// apiWarningRecorder is a rest.WarningHandler that keeps the warnings it is given, counting
+// repeated warnings once
+type apiWarningRecorder struct {
+	mtx      sync.Mutex
+	warnings map[string]*APIWarning
+}
+
+func newAPIWarningRecorder() *apiWarningRecorder {
+	return &apiWarningRecorder{
+		warnings: map[string]*APIWarning{},
+	}
+}
+
+func (r *apiWarningRecorder) HandleWarningHeader(code int, agent string, message string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if w, ok := r.warnings[message]; ok {
+		w.Count++
+		return
+	}
+	r.warnings[message] = &APIWarning{
+		Code:    code,
+		Agent:   agent,
+		Message: message,
+		Count:   1,
+	}
+}
+
+// Warnings returns the recorded warnings sorted by message
+func (r *apiWarningRecorder) Warnings() []APIWarning {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	warnings := make([]APIWarning, 0, len(r.warnings))
+	for _, w := range r.warnings {
+		warnings = append(warnings, *w)
+	}
+	sort.Slice(warnings, func(i, j int) bool {
+		return warnings[i].Message < warnings[j].Message
+	})
+	return warnings
+}
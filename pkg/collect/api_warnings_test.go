@@ -0,0 +1,54 @@
+package collect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func Test_apiWarningRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Warning", `299 - "batch/v1beta1 CronJob is deprecated in v1.21+, unavailable in v1.25+; use batch/v1 CronJob"`)
+		if r.URL.Path == "/api/v1/namespaces/default/pods" {
+			w.Header().Add("Warning", `299 - "metadata.finalizers: \"example.com/cleanup\": prefer a domain-qualified finalizer name"`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"List","apiVersion":"v1","items":[]}`))
+	}))
+	defer server.Close()
+
+	recorder := newAPIWarningRecorder()
+	client, err := kubernetes.NewForConfig(&rest.Config{
+		Host:           server.URL,
+		WarningHandler: recorder,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = client.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	_, err = client.CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []APIWarning{
+		{
+			Code:    299,
+			Agent:   "-",
+			Message: "batch/v1beta1 CronJob is deprecated in v1.21+, unavailable in v1.25+; use batch/v1 CronJob",
+			Count:   2,
+		},
+		{
+			Code:    299,
+			Agent:   "-",
+			Message: `metadata.finalizers: "example.com/cleanup": prefer a domain-qualified finalizer name`,
+			Count:   1,
+		},
+	}, recorder.Warnings())
+}
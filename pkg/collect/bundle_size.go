@@ -0,0 +1,384 @@
+package collect
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+)
+
+// tarEntryOverhead estimates the compressed size of the tar header and padding of a file
+const tarEntryOverhead = 128
+
+// Precedence of the files of a bundle when it exceeds its size budget, lower values are kept first
+const (
+	// bundleFileCritical files are always kept: the files at the root of the bundle, e.g. the
+	// analysis, and the cluster info, events, nodes and pods with their status
+	bundleFileCritical = iota
+	// bundleFileRegular files are kept smallest first, the ones that don't fit are left out
+	bundleFileRegular
+	// bundleFileLog files share what is left of the budget, the oldest lines of the ones that
+	// don't fit are removed
+	bundleFileLog
+)
+
+var criticalBundlePrefixes = []string{
+	"cluster-info/",
+	constants.CLUSTER_RESOURCES_DIR + "/" + constants.CLUSTER_RESOURCES_EVENTS + "/",
+	constants.CLUSTER_RESOURCES_DIR + "/" + constants.CLUSTER_RESOURCES_EVENTS + ".json",
+	constants.CLUSTER_RESOURCES_DIR + "/" + constants.CLUSTER_RESOURCES_NODES + ".json",
+	constants.CLUSTER_RESOURCES_DIR + "/" + constants.CLUSTER_RESOURCES_PODS + "/",
+}
+
+// BundleSizeBudget is the content of bundle-size-budget.json, it lists the files that were trimmed
+// or left out to keep a bundle within its size budget
+type BundleSizeBudget struct {
+	MaxBytes int64 `json:"maxBytes"`
+	// EstimatedBytes and EstimatedBytesAfter are the estimated sizes of the compressed archive,
+	// before and after the budget was applied
+	EstimatedBytes      int64 `json:"estimatedBytes"`
+	EstimatedBytesAfter int64 `json:"estimatedBytesAfter"`
+	// Critical is set when the files that are always kept exceed the budget on their own
+	Critical bool                `json:"critical,omitempty"`
+	Trimmed  []BundleTrimmedFile `json:"trimmed,omitempty"`
+	Skipped  []BundleSkippedFile `json:"skipped,omitempty"`
+}
+
+// BundleTrimmedFile is a log file whose oldest lines were removed. Bytes and KeptBytes are the
+// sizes of the log before and after, decompressed when it was gzipped by CompressResultFiles.
+type BundleTrimmedFile struct {
+	Path         string `json:"path"`
+	Bytes        int64  `json:"bytes"`
+	KeptBytes    int64  `json:"keptBytes"`
+	RemovedLines int    `json:"removedLines"`
+}
+
+// BundleSkippedFile is a file that was left out of the bundle
+type BundleSkippedFile struct {
+	Path            string `json:"path"`
+	Bytes           int64  `json:"bytes"`
+	CompressedBytes int64  `json:"compressedBytes"`
+}
+
+type bundleFile struct {
+	path       string
+	priority   int
+	bytes      int64
+	compressed int64
+}
+
+// ApplyBundleSizeBudget keeps the compressed archive of a bundle within maxBytes. The compressed
+// size of each file is estimated, and when the bundle is larger than its budget files are kept by
+// precedence: critical files (events, pods and their status, nodes, cluster info and the files at
+// the root of the bundle) are always kept, then other files are kept smallest first, the large
+// ones that don't fit being left out, and the logs share what is left, with the oldest lines of
+// the ones that don't fit removed. The trimmed and skipped files are listed in
+// bundle-size-budget.json. It returns nil when the bundle is within its budget.
+func (r CollectorResult) ApplyBundleSizeBudget(bundlePath string, maxBytes int64, progressChan chan<- interface{}) (*BundleSizeBudget, error) {
+	if bundlePath == "" {
+		return nil, errors.New("the size budget of a bundle can only be applied to files on disk")
+	}
+
+	files, links, err := r.measureBundleFiles(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := &BundleSizeBudget{MaxBytes: maxBytes}
+	for _, f := range files {
+		budget.EstimatedBytes += f.compressed
+	}
+	if budget.EstimatedBytes <= maxBytes {
+		return nil, nil
+	}
+
+	sendProgress(progressChan, fmt.Sprintf("Bundle exceeds its size budget of %d bytes, trimming", maxBytes))
+
+	used := int64(0)
+	var regular, logs []bundleFile
+	for _, f := range files {
+		switch f.priority {
+		case bundleFileCritical:
+			used += f.compressed
+		case bundleFileRegular:
+			regular = append(regular, f)
+		default:
+			logs = append(logs, f)
+		}
+	}
+	budget.Critical = used > maxBytes
+
+	skip := func(f bundleFile) error {
+		if err := r.RemoveResult(bundlePath, f.path); err != nil {
+			return errors.Wrapf(err, "failed to remove %s", f.path)
+		}
+		for _, link := range links[f.path] {
+			if err := r.RemoveResult(bundlePath, link); err != nil {
+				return errors.Wrapf(err, "failed to remove %s", link)
+			}
+		}
+		budget.Skipped = append(budget.Skipped, BundleSkippedFile{Path: f.path, Bytes: f.bytes, CompressedBytes: f.compressed})
+		return nil
+	}
+
+	sortBundleFiles(regular)
+	for _, f := range regular {
+		if used+f.compressed <= maxBytes {
+			used += f.compressed
+			continue
+		}
+		if err := skip(f); err != nil {
+			return nil, err
+		}
+	}
+
+	sortBundleFiles(logs)
+	for i, f := range logs {
+		share := max(maxBytes-used, 0) / int64(len(logs)-i)
+		if f.compressed <= share {
+			used += f.compressed
+			continue
+		}
+
+		trimmed, kept, err := r.trimLog(bundlePath, f, share)
+		if err != nil {
+			return nil, err
+		}
+		if trimmed == nil {
+			if err := skip(f); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		used += kept
+		budget.Trimmed = append(budget.Trimmed, *trimmed)
+	}
+	budget.EstimatedBytesAfter = used
+
+	sort.Slice(budget.Trimmed, func(i, j int) bool { return budget.Trimmed[i].Path < budget.Trimmed[j].Path })
+	sort.Slice(budget.Skipped, func(i, j int) bool { return budget.Skipped[i].Path < budget.Skipped[j].Path })
+
+	b, err := json.MarshalIndent(budget, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal bundle size budget")
+	}
+	if err := r.SaveResult(bundlePath, constants.BUNDLE_SIZE_BUDGET_FILENAME, bytes.NewBuffer(b)); err != nil {
+		return nil, errors.Wrap(err, "failed to save bundle size budget")
+	}
+
+	msg := fmt.Sprintf("bundle exceeded its size budget of %d bytes: %d log files were trimmed and %d files were left out, see %s",
+		maxBytes, len(budget.Trimmed), len(budget.Skipped), constants.BUNDLE_SIZE_BUDGET_FILENAME)
+	if budget.Critical {
+		msg += ", the events, pods and other critical files exceed the budget on their own"
+	}
+	sendProgress(progressChan, errors.New(msg))
+
+	return budget, nil
+}
+
+// measureBundleFiles returns the files of the result with their estimated compressed size, and
+// the symlinks of the result by the file they point to. Symlinks are not measured.
+func (r CollectorResult) measureBundleFiles(bundlePath string) ([]bundleFile, map[string][]string, error) {
+	files := []bundleFile{}
+	links := map[string][]string{}
+
+	for relativePath := range r {
+		filename := filepath.Join(bundlePath, relativePath)
+		info, err := os.Lstat(filename)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to stat %s", relativePath)
+		}
+
+		if info.Mode().Type() == os.ModeSymlink {
+			target, err := os.Readlink(filename)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to read symlink %s", relativePath)
+			}
+			if rel, err := filepath.Rel(bundlePath, target); err == nil {
+				links[filepath.ToSlash(rel)] = append(links[filepath.ToSlash(rel)], relativePath)
+			}
+			continue
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		compressed, err := compressedFileSize(filename)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to measure %s", relativePath)
+		}
+		files = append(files, bundleFile{
+			path:       relativePath,
+			priority:   bundleFilePriority(relativePath),
+			bytes:      info.Size(),
+			compressed: compressed + tarEntryOverhead,
+		})
+	}
+
+	return files, links, nil
+}
+
+// trimLog removes the oldest lines of a log file so that it fits in maxBytes once compressed, and
+// adds a line at its start with the number of lines removed. A log gzipped by CompressResultFiles
+// is trimmed decompressed and compressed back. It returns nil when not even one line fits.
+func (r CollectorResult) trimLog(bundlePath string, f bundleFile, maxBytes int64) (*BundleTrimmedFile, int64, error) {
+	filename := filepath.Join(bundlePath, f.path)
+	compressedFile := IsCompressedFile(f.path)
+
+	var data []byte
+	var err error
+	if compressedFile {
+		data, err = ReadCompressedFile(filename)
+	} else {
+		data, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to read %s", f.path)
+	}
+	if len(data) == 0 {
+		return nil, 0, nil
+	}
+
+	// compressed bytes per byte of the log, to estimate how much of the log fits
+	ratio := float64(f.compressed-tarEntryOverhead) / float64(len(data))
+
+	available := maxBytes - tarEntryOverhead
+	// the estimate is refined when the kept lines compress worse than the whole log
+	for attempt := 0; attempt < 3 && available > 0; attempt++ {
+		keep := int(float64(available) / ratio)
+		if keep >= len(data) {
+			keep = len(data) - 1
+		}
+		start := len(data) - keep
+		// start at the beginning of a line
+		i := bytes.IndexByte(data[start-1:], '\n')
+		if i < 0 || start+i >= len(data) {
+			return nil, 0, nil
+		}
+		start += i
+
+		removedLines := bytes.Count(data[:start], []byte("\n"))
+		trimmed := append([]byte(fmt.Sprintf("... %d lines removed to fit the bundle size budget\n", removedLines)), data[start:]...)
+
+		compressed, err := compressedSize(bytes.NewReader(trimmed))
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to measure %s", f.path)
+		}
+		if compressed+tarEntryOverhead > maxBytes {
+			available -= compressed + tarEntryOverhead - maxBytes
+			ratio = float64(compressed) / float64(len(trimmed))
+			continue
+		}
+
+		content := trimmed
+		if compressedFile {
+			if content, err = gzipBytes(trimmed); err != nil {
+				return nil, 0, errors.Wrapf(err, "failed to compress %s", f.path)
+			}
+		}
+		if err := r.ReplaceResult(bundlePath, f.path, bytes.NewReader(content)); err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to trim %s", f.path)
+		}
+		return &BundleTrimmedFile{
+			Path:         f.path,
+			Bytes:        int64(len(data)),
+			KeptBytes:    int64(len(trimmed)),
+			RemovedLines: removedLines,
+		}, compressed + tarEntryOverhead, nil
+	}
+
+	return nil, 0, nil
+}
+
+// bundleFilePriority returns the precedence of a file when a bundle exceeds its size budget. Files
+// gzipped by CompressResultFiles have the precedence of their name without the .gz suffix.
+func bundleFilePriority(relativePath string) int {
+	if IsCompressedFile(relativePath) {
+		relativePath = strings.TrimSuffix(relativePath, CompressedFileSuffix)
+	}
+	if strings.HasSuffix(relativePath, ".log") {
+		return bundleFileLog
+	}
+	if !strings.Contains(relativePath, "/") {
+		return bundleFileCritical
+	}
+	for _, prefix := range criticalBundlePrefixes {
+		if strings.HasPrefix(relativePath, prefix) {
+			return bundleFileCritical
+		}
+	}
+	return bundleFileRegular
+}
+
+// sortBundleFiles sorts files smallest first, by path for files of the same size
+func sortBundleFiles(files []bundleFile) {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].compressed != files[j].compressed {
+			return files[i].compressed < files[j].compressed
+		}
+		return files[i].path < files[j].path
+	})
+}
+
+func compressedFileSize(filename string) (int64, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return compressedSize(f)
+}
+
+// compressedSize returns the gzipped size of the content of reader
+func compressedSize(reader io.Reader) (int64, error) {
+	counter := &countingWriter{}
+	gzipWriter := gzip.NewWriter(counter)
+	if _, err := io.Copy(gzipWriter, reader); err != nil {
+		return 0, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return 0, err
+	}
+	return counter.n, nil
+}
+
+// gzipBytes returns data gzipped
+func gzipBytes(data []byte) ([]byte, error) {
+	var b bytes.Buffer
+	gzipWriter := gzip.NewWriter(&b)
+	if _, err := gzipWriter.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// sendProgress sends a message to progressChan, when there is one
+func sendProgress(progressChan chan<- interface{}, msg interface{}) {
+	if progressChan != nil {
+		progressChan <- msg
+	}
+}
@@ -0,0 +1,173 @@
+package collect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyBundleSizeBudget(t *testing.T) {
+	// random content compresses poorly, so the compressed size is close to the file size
+	random := func(n int) string {
+		b := make([]byte, n/2)
+		rand.New(rand.NewSource(int64(n))).Read(b)
+		return fmt.Sprintf("%x", b)
+	}
+	logLines := func(n int) string {
+		lines := make([]string, n)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("line %d %s", i, random(64+i))
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	bundlePath := t.TempDir()
+	result := NewResult()
+	save := func(relativePath, content string) {
+		require.NoError(t, result.SaveResult(bundlePath, relativePath, bytes.NewBufferString(content)))
+	}
+	save("version.yaml", "version: 1")
+	save("cluster-resources/events/default.json", random(4000))
+	save("cluster-resources/pods/default.json", random(4000))
+	save("cluster-resources/deployments/default.json", random(1000))
+	save("copy-from-host/large.bin", random(20000))
+	save("app/app-0/app.log", logLines(200))
+	save("app/app-1/app.log", logLines(5))
+	require.NoError(t, result.SymLinkResult(bundlePath, "cluster-resources/pods/logs/default/app-0/app.log", "app/app-0/app.log"))
+	require.NoError(t, result.SymLinkResult(bundlePath, "copy-from-host/latest.bin", "copy-from-host/large.bin"))
+
+	progressChan := make(chan interface{}, 10)
+	budget, err := result.ApplyBundleSizeBudget(bundlePath, 16000, progressChan)
+	require.NoError(t, err)
+	require.NotNil(t, budget)
+
+	assert.False(t, budget.Critical)
+	assert.LessOrEqual(t, budget.EstimatedBytesAfter, int64(16000))
+	assert.Greater(t, budget.EstimatedBytes, int64(16000))
+
+	// the large file is left out, with the symlink pointing to it
+	require.Len(t, budget.Skipped, 1)
+	assert.Equal(t, "copy-from-host/large.bin", budget.Skipped[0].Path)
+	assert.NotContains(t, result, "copy-from-host/large.bin")
+	assert.NotContains(t, result, "copy-from-host/latest.bin")
+	_, err = os.Lstat(filepath.Join(bundlePath, "copy-from-host/latest.bin"))
+	assert.True(t, os.IsNotExist(err))
+
+	// critical and small files are kept
+	for _, relativePath := range []string{
+		"version.yaml",
+		"cluster-resources/events/default.json",
+		"cluster-resources/pods/default.json",
+		"cluster-resources/deployments/default.json",
+		"app/app-1/app.log",
+		"cluster-resources/pods/logs/default/app-0/app.log",
+	} {
+		assert.Contains(t, result, relativePath)
+	}
+
+	// the oldest lines of the large log are removed
+	require.Len(t, budget.Trimmed, 1)
+	trimmed := budget.Trimmed[0]
+	assert.Equal(t, "app/app-0/app.log", trimmed.Path)
+	assert.Greater(t, trimmed.RemovedLines, 0)
+	data, err := os.ReadFile(filepath.Join(bundlePath, "app/app-0/app.log"))
+	require.NoError(t, err)
+	assert.Equal(t, trimmed.KeptBytes, int64(len(data)))
+	assert.True(t, strings.HasPrefix(string(data), fmt.Sprintf("... %d lines removed to fit the bundle size budget\nline %d ", trimmed.RemovedLines, trimmed.RemovedLines)))
+	assert.True(t, strings.HasSuffix(string(data), fmt.Sprintf("line 199 %s\n", random(64+199))))
+
+	data, err = os.ReadFile(filepath.Join(bundlePath, "bundle-size-budget.json"))
+	require.NoError(t, err)
+	var saved BundleSizeBudget
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, *budget, saved)
+	assert.Contains(t, result, "bundle-size-budget.json")
+
+	close(progressChan)
+	msgs := []interface{}{}
+	for msg := range progressChan {
+		msgs = append(msgs, msg)
+	}
+	require.Len(t, msgs, 2)
+	assert.EqualError(t, msgs[1].(error), "bundle exceeded its size budget of 16000 bytes: 1 log files were trimmed and 1 files were left out, see bundle-size-budget.json")
+}
+
+func TestApplyBundleSizeBudgetWithinBudget(t *testing.T) {
+	bundlePath := t.TempDir()
+	result := NewResult()
+	require.NoError(t, result.SaveResult(bundlePath, "app/app-0/app.log", bytes.NewBufferString("hello\n")))
+
+	budget, err := result.ApplyBundleSizeBudget(bundlePath, 1000, nil)
+	require.NoError(t, err)
+	assert.Nil(t, budget)
+	assert.Equal(t, CollectorResult{"app/app-0/app.log": nil}, result)
+}
+
+func TestApplyBundleSizeBudgetCompressedLogs(t *testing.T) {
+	lines := make([]string, 400)
+	for i := range lines {
+		b := make([]byte, 32)
+		rand.New(rand.NewSource(int64(i))).Read(b)
+		lines[i] = fmt.Sprintf("line %d %x", i, b)
+	}
+	log := strings.Join(lines, "\n") + "\n"
+
+	bundlePath := t.TempDir()
+	result := NewResult()
+	compressed, err := gzipBytes([]byte(log))
+	require.NoError(t, err)
+	require.NoError(t, result.SaveResult(bundlePath, "cluster-resources/pods/logs/default/app-0/app.log.gz", bytes.NewReader(compressed)))
+	require.NoError(t, result.SaveResult(bundlePath, "version.yaml", bytes.NewBufferString("version: 1")))
+
+	budget, err := result.ApplyBundleSizeBudget(bundlePath, int64(len(compressed))/2, nil)
+	require.NoError(t, err)
+	require.NotNil(t, budget)
+	assert.False(t, budget.Critical)
+	assert.Empty(t, budget.Skipped)
+
+	// the log is trimmed decompressed and compressed back
+	require.Len(t, budget.Trimmed, 1)
+	trimmed := budget.Trimmed[0]
+	assert.Equal(t, "cluster-resources/pods/logs/default/app-0/app.log.gz", trimmed.Path)
+	assert.Equal(t, int64(len(log)), trimmed.Bytes)
+	data, err := ReadCompressedFile(filepath.Join(bundlePath, trimmed.Path))
+	require.NoError(t, err)
+	assert.Equal(t, trimmed.KeptBytes, int64(len(data)))
+	assert.True(t, strings.HasPrefix(string(data), fmt.Sprintf("... %d lines removed to fit the bundle size budget\nline %d ", trimmed.RemovedLines, trimmed.RemovedLines)))
+	assert.True(t, strings.HasSuffix(string(data), lines[399]+"\n"))
+	assert.LessOrEqual(t, budget.EstimatedBytesAfter, int64(len(compressed))/2)
+}
+
+func Test_bundleFilePriority(t *testing.T) {
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "version.yaml", want: bundleFileCritical},
+		{path: "analysis.json", want: bundleFileCritical},
+		{path: "cluster-info/cluster_version.json", want: bundleFileCritical},
+		{path: "cluster-resources/events/default.json", want: bundleFileCritical},
+		{path: "cluster-resources/pods/default.json", want: bundleFileCritical},
+		{path: "cluster-resources/nodes.json", want: bundleFileCritical},
+		{path: "cluster-resources/deployments/default.json", want: bundleFileRegular},
+		{path: "copy-from-host/data.bin", want: bundleFileRegular},
+		{path: "cluster-resources/pods/logs/default/app-0/app.log", want: bundleFileLog},
+		{path: "app/app-0/app-previous.log", want: bundleFileLog},
+		{path: "cluster-resources/pods/logs/default/app-0/app.log.gz", want: bundleFileLog},
+		{path: "host-collectors/run-host/journalctl.txt.gz", want: bundleFileRegular},
+		{path: "cluster-info/cluster_version.txt.gz", want: bundleFileCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, bundleFilePriority(tt.path))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package collect
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrBundleNotFound is returned by a BundleStore when no bundle has the requested name
+var ErrBundleNotFound = errors.New("bundle not found")
+
+// BundleStore saves finished bundle archives by name, e.g. on local disk or in an object store.
+// Implementations must not keep a partial bundle when Write fails.
+type BundleStore interface {
+	// Write saves the archive read from r and returns the number of bytes written
+	Write(ctx context.Context, name string, r io.Reader) (int64, error)
+	// Read opens a saved archive, it returns ErrBundleNotFound for unknown names
+	Read(ctx context.Context, name string) (io.ReadCloser, error)
+	// List returns the names of the saved archives in lexical order
+	List(ctx context.Context) ([]string, error)
+	// Delete removes a saved archive, deleting an unknown name is not an error
+	Delete(ctx context.Context, name string) error
+}
+
+// Ensure `LocalBundleStore` implements `BundleStore` interface at compile time.
+var _ BundleStore = (*LocalBundleStore)(nil)
+
+// LocalBundleStore saves bundles as files in a local directory, names are file names relative to
+// the directory
+type LocalBundleStore struct {
+	Dir string
+}
+
+func NewLocalBundleStore(dir string) (*LocalBundleStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create bundle directory %s", dir)
+	}
+	return &LocalBundleStore{Dir: dir}, nil
+}
+
+// Write writes the archive to a temporary file first so readers never see a partial bundle
+func (s *LocalBundleStore) Write(ctx context.Context, name string, r io.Reader) (int64, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".bundle-*")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create temporary file")
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return n, errors.Wrap(err, "failed to write bundle")
+	}
+	if err := tmp.Close(); err != nil {
+		return n, errors.Wrap(err, "failed to close bundle")
+	}
+
+	// os.CreateTemp creates files readable by the owner only
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return n, errors.Wrap(err, "failed to set bundle permissions")
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return n, errors.Wrap(err, "failed to save bundle")
+	}
+	return n, nil
+}
+
+func (s *LocalBundleStore) Read(ctx context.Context, name string) (io.ReadCloser, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrBundleNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open bundle")
+	}
+	return f, nil
+}
+
+// List returns the regular files in the directory, skipping hidden files such as archives that
+// are still being written
+func (s *LocalBundleStore) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list bundles")
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *LocalBundleStore) Delete(ctx context.Context, name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to delete bundle")
+	}
+	return nil
+}
+
+func (s *LocalBundleStore) path(name string) (string, error) {
+	if name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", errors.Errorf("invalid bundle name %q", name)
+	}
+	return filepath.Join(s.Dir, name), nil
+}
@@ -0,0 +1,178 @@
+package collect
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBundleStore keeps bundles in memory
+type memoryBundleStore struct {
+	mu      sync.Mutex
+	bundles map[string][]byte
+}
+
+func (s *memoryBundleStore) Write(ctx context.Context, name string, r io.Reader) (int64, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return int64(len(b)), err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.bundles == nil {
+		s.bundles = map[string][]byte{}
+	}
+	s.bundles[name] = b
+	return int64(len(b)), nil
+}
+
+func (s *memoryBundleStore) Read(ctx context.Context, name string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.bundles[name]
+	if !ok {
+		return nil, ErrBundleNotFound
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (s *memoryBundleStore) List(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := []string{}
+	for name := range s.bundles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *memoryBundleStore) Delete(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.bundles, name)
+	return nil
+}
+
+func TestCollectorResult_ArchiveBundleToStore(t *testing.T) {
+	ctx := context.Background()
+	bundlePath := filepath.Join(t.TempDir(), "support-bundle")
+
+	result := NewResult()
+	for _, data := range []*troubleshootv1beta2.Data{
+		{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "config.yaml"}, Name: "static", Data: "key: value"},
+		{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "motd"}, Name: "static", Data: "hello"},
+	} {
+		collector := &CollectData{Collector: data, BundlePath: bundlePath}
+		output, err := collector.Collect(nil)
+		require.NoError(t, err)
+		result.AddResult(output)
+	}
+
+	store := &memoryBundleStore{}
+	n, err := result.ArchiveBundleToStore(ctx, bundlePath, store, "support-bundle.tar.gz")
+	require.NoError(t, err)
+	assert.Positive(t, n)
+
+	names, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"support-bundle.tar.gz"}, names)
+
+	r, err := store.Read(ctx, "support-bundle.tar.gz")
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, map[string]string{
+		"support-bundle/static/config.yaml": "key: value",
+		"support-bundle/static/motd":        "hello",
+	}, readArchive(t, r))
+
+	require.NoError(t, store.Delete(ctx, "support-bundle.tar.gz"))
+	_, err = store.Read(ctx, "support-bundle.tar.gz")
+	assert.ErrorIs(t, err, ErrBundleNotFound)
+}
+
+func TestCollectorResult_ArchiveBundleToStoreMissingFile(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalBundleStore(t.TempDir())
+	require.NoError(t, err)
+
+	result := CollectorResult{"missing.json": nil}
+	_, err = result.ArchiveBundleToStore(ctx, t.TempDir(), store, "support-bundle.tar.gz")
+	require.Error(t, err)
+
+	// No partial archive is left behind
+	names, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+	entries, err := os.ReadDir(store.Dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLocalBundleStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalBundleStore(filepath.Join(t.TempDir(), "bundles"))
+	require.NoError(t, err)
+
+	for _, name := range []string{"b.tar.gz", "a.tar.gz"} {
+		n, err := store.Write(ctx, name, bytes.NewBufferString(name))
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(name)), n)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(store.Dir, ".bundle-123"), []byte("partial"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(store.Dir, "dir"), 0755))
+
+	names, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.tar.gz", "b.tar.gz"}, names)
+
+	r, err := store.Read(ctx, "a.tar.gz")
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "a.tar.gz", string(b))
+
+	require.NoError(t, store.Delete(ctx, "a.tar.gz"))
+	require.NoError(t, store.Delete(ctx, "a.tar.gz"))
+	_, err = store.Read(ctx, "a.tar.gz")
+	assert.ErrorIs(t, err, ErrBundleNotFound)
+
+	for _, name := range []string{"../escape.tar.gz", "nested/bundle.tar.gz", ".hidden", ""} {
+		_, err := store.Write(ctx, name, bytes.NewBufferString("data"))
+		assert.Error(t, err, name)
+	}
+}
+
+func readArchive(t *testing.T, r io.Reader) map[string]string {
+	t.Helper()
+
+	gzipReader, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+
+	files := map[string]string{}
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		files[hdr.Name] = string(b)
+	}
+	return files
+}
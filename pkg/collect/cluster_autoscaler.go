@@ -0,0 +1,118 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+const (
+	clusterAutoscalerComponent            = "cluster-autoscaler"
+	clusterAutoscalerDefaultNamespace     = "kube-system"
+	clusterAutoscalerDefaultConfigMapName = "cluster-autoscaler-status"
+)
+
+// ClusterAutoscalerStatus is saved as status.json, with the status ConfigMap when it exists
+type ClusterAutoscalerStatus struct {
+	Namespace       string            `json:"namespace"`
+	Name            string            `json:"name"`
+	ConfigMapExists bool              `json:"configMapExists"`
+	ConfigMap       *corev1.ConfigMap `json:"configMap,omitempty"`
+}
+
+type CollectClusterAutoscaler struct {
+	Collector    *troubleshootv1beta2.ClusterAutoscaler
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+func (c *CollectClusterAutoscaler) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectClusterAutoscaler) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectClusterAutoscaler) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	namespace := c.Collector.Namespace
+	if namespace == "" {
+		namespace = clusterAutoscalerDefaultNamespace
+	}
+	name := c.Collector.ConfigMapName
+	if name == "" {
+		name = clusterAutoscalerDefaultConfigMapName
+	}
+
+	files, errs := clusterAutoscalerStatus(c.Context, c.Client, namespace, name)
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_AUTOSCALER_DIR, fileName), bytes.NewBuffer(data))
+	}
+	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_AUTOSCALER_DIR, "errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// clusterAutoscalerStatus returns the autoscaler status ConfigMap in a ClusterAutoscalerStatus
+// saved as status.json, which notes when the ConfigMap does not exist, and the events recorded by the
+// autoscaler in any namespace as events.json. Events are listed whether or not the ConfigMap exists,
+// the API server only keeps the recent ones, an hour's worth by default.
+func clusterAutoscalerStatus(ctx context.Context, client kubernetes.Interface, namespace, name string) (map[string][]byte, []string) {
+	files := map[string][]byte{}
+	errorList := []string{}
+
+	var status *ClusterAutoscalerStatus
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case kuberneteserrors.IsNotFound(err):
+		klog.V(2).Infof("cluster-autoscaler status configmap %s/%s was not found", namespace, name)
+		status = &ClusterAutoscalerStatus{Namespace: namespace, Name: name}
+	case err != nil:
+		errorList = append(errorList, errors.Wrapf(err, "failed to get configmap %s/%s", namespace, name).Error())
+	default:
+		status = &ClusterAutoscalerStatus{Namespace: namespace, Name: name, ConfigMapExists: true, ConfigMap: configMap}
+	}
+
+	if status != nil {
+		b, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			errorList = append(errorList, errors.Wrap(err, "failed to marshal status configmap").Error())
+		} else {
+			files["status.json"] = b
+		}
+	}
+
+	events, err := client.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "source=" + clusterAutoscalerComponent,
+	})
+	if err != nil {
+		errorList = append(errorList, errors.Wrap(err, "failed to list cluster-autoscaler events").Error())
+		return files, errorList
+	}
+
+	b, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		errorList = append(errorList, errors.Wrap(err, "failed to marshal cluster-autoscaler events").Error())
+		return files, errorList
+	}
+	files["events.json"] = b
+
+	return files, errorList
+}
@@ -0,0 +1,82 @@
+package collect
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_clusterAutoscalerStatus(t *testing.T) {
+	statusConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-autoscaler-status", Namespace: "kube-system"},
+		Data:       map[string]string{"status": "autoscalerStatus: Running\n"},
+	}
+	scaleUpEvent := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "gpu-job.17a8", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "gpu-job"},
+		Reason:         "NotTriggerScaleUp",
+		Source:         corev1.EventSource{Component: "cluster-autoscaler"},
+	}
+
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		wantFound  bool
+		wantEvents int
+	}{
+		{
+			name:       "no status configmap",
+			objects:    []runtime.Object{scaleUpEvent},
+			wantEvents: 1,
+		},
+		{
+			name:       "status and events",
+			objects:    []runtime.Object{statusConfigMap, scaleUpEvent},
+			wantFound:  true,
+			wantEvents: 1,
+		},
+		{
+			name:      "no events",
+			objects:   []runtime.Object{statusConfigMap},
+			wantFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testclient.NewSimpleClientset(tt.objects...)
+
+			files, errs := clusterAutoscalerStatus(context.Background(), client, "kube-system", "cluster-autoscaler-status")
+			assert.Empty(t, errs)
+
+			fileNames := []string{}
+			for fileName := range files {
+				fileNames = append(fileNames, fileName)
+			}
+			assert.ElementsMatch(t, []string{"status.json", "events.json"}, fileNames)
+
+			var status ClusterAutoscalerStatus
+			require.NoError(t, json.Unmarshal(files["status.json"], &status))
+			assert.Equal(t, "kube-system", status.Namespace)
+			assert.Equal(t, "cluster-autoscaler-status", status.Name)
+			assert.Equal(t, tt.wantFound, status.ConfigMapExists)
+			if tt.wantFound {
+				require.NotNil(t, status.ConfigMap)
+				assert.Equal(t, statusConfigMap.Data, status.ConfigMap.Data)
+			} else {
+				assert.Nil(t, status.ConfigMap)
+			}
+
+			var events corev1.EventList
+			require.NoError(t, json.Unmarshal(files["events.json"], &events))
+			assert.Len(t, events.Items, tt.wantEvents)
+		})
+	}
+}
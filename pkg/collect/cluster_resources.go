@@ -8,12 +8,16 @@ import (
 	"fmt"
 	"path" // this code uses 'path' and not 'path/filepath' because we don't want backslashes on windows
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
 	"github.com/replicatedhq/troubleshoot/pkg/constants"
 	"github.com/replicatedhq/troubleshoot/pkg/k8sutil"
+	"golang.org/x/sync/errgroup"
 	"gopkg.in/yaml.v2"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -22,6 +26,7 @@ import (
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apiextensionsv1clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
 	apiextensionsv1beta1clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -41,13 +46,28 @@ type CollectClusterResources struct {
 	BundlePath   string
 	Namespace    string
 	ClientConfig *rest.Config
+	// SinceTime replaces the limits of the logs collected from unhealthy pods when set
+	SinceTime *time.Time
 	RBACErrors
+
+	// includeNamespaces are collected even when ExcludeNamespaces match them, they are listed by
+	// collectors merged into this one
+	includeNamespaces []string
+	apiTimer          *apiRequestTimer
 }
 
 func (c *CollectClusterResources) Title() string {
 	return getCollectorName(c)
 }
 
+// APIRequestTimings returns the time spent in API requests by resource type and by namespace
+func (c *CollectClusterResources) APIRequestTimings() ([]TimingEntry, []TimingEntry) {
+	if c.apiTimer == nil {
+		return nil, nil
+	}
+	return c.apiTimer.Timings()
+}
+
 func (c *CollectClusterResources) IsExcluded() (bool, error) {
 	return isExcluded(c.Collector.Exclude)
 }
@@ -76,10 +96,27 @@ EMPTY_NAMESPACE_FOUND:
 		}
 	}
 
+	excludeNamespaces, includeNamespaces := mergeExcludeNamespaces(allCollectors)
 	clusterResourcesCollector := c
 
+	var namespaceProfiles []troubleshootv1beta2.ClusterResourcesNamespaceProfile
+	for _, collectorInterface := range allCollectors {
+		if collector, ok := collectorInterface.(*CollectClusterResources); ok {
+			if collector.Collector.LastApplied {
+				clusterResourcesCollector.Collector.LastApplied = true
+			}
+			if collector.Collector.ManagedFields {
+				clusterResourcesCollector.Collector.ManagedFields = true
+			}
+			namespaceProfiles = append(namespaceProfiles, collector.Collector.NamespaceProfiles...)
+		}
+	}
+	clusterResourcesCollector.Collector.NamespaceProfiles = namespaceProfiles
+
 	if hasEmptyNameSpaceCollector {
 		clusterResourcesCollector.Collector.Namespaces = nil
+		clusterResourcesCollector.Collector.ExcludeNamespaces = excludeNamespaces
+		clusterResourcesCollector.includeNamespaces = includeNamespaces
 		result = append(result, clusterResourcesCollector)
 		return result, nil
 	}
@@ -94,6 +131,7 @@ EMPTY_NAMESPACE_FOUND:
 	sort.Strings(allNamespaces)
 
 	clusterResourcesCollector.Collector.Namespaces = allNamespaces
+	clusterResourcesCollector.Collector.ExcludeNamespaces = nil
 
 	result = append(result, clusterResourcesCollector)
 
@@ -102,12 +140,28 @@ EMPTY_NAMESPACE_FOUND:
 
 func (c *CollectClusterResources) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
 	klog.V(4).Infof("CollectClusterResources.Collect")
-	client, err := kubernetes.NewForConfig(c.ClientConfig)
+
+	if err := validateNamespaceProfiles(c.Collector.NamespaceProfiles); err != nil {
+		return nil, err
+	}
+	if err := validateExcludeNamespaces(c.Collector.ExcludeNamespaces); err != nil {
+		return nil, err
+	}
+
+	// keep the warnings the apiserver returns, deprecated API usage is reported in them
+	apiWarnings := newAPIWarningRecorder()
+	clientConfig := rest.CopyConfig(c.ClientConfig)
+	clientConfig.WarningHandler = apiWarnings
+	// time the requests by resource type and namespace for the collection timing breakdown
+	c.apiTimer = newAPIRequestTimer()
+	clientConfig.Wrap(c.apiTimer.WrapTransport)
+
+	client, err := kubernetes.NewForConfig(clientConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	dynamicClient, err := dynamic.NewForConfig(c.ClientConfig)
+	dynamicClient, err := dynamic.NewForConfig(clientConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +183,7 @@ func (c *CollectClusterResources) Collect(progressChan chan<- interface{}) (Coll
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_NAMESPACES)), marshalErrors(namespaceErrors))
 		namespaceNames = append(namespaceNames, c.Namespace)
 	} else {
-		namespaces, namespaceList, namespaceErrors := getAllNamespaces(ctx, client)
+		namespaces, namespaceList, namespaceErrors := getAllNamespaces(ctx, client, c.Collector.ExcludeNamespaces, c.includeNamespaces)
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s.json", constants.CLUSTER_RESOURCES_NAMESPACES)), bytes.NewBuffer(namespaces))
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_NAMESPACES)), marshalErrors(namespaceErrors))
 		if namespaceList != nil {
@@ -150,12 +204,15 @@ func (c *CollectClusterResources) Collect(progressChan chan<- interface{}) (Coll
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_AUTH_CANI)), marshalErrors(reviewStatusErrors))
 
+	rbacGaps := []RBACGap{}
 	if nsListedFromCluster && !c.Collector.IgnoreRBAC {
 		filteredNamespaces := []string{}
 		for _, ns := range namespaceNames {
 			status := reviewStatuses[ns]
 			if status == nil || canCollectNamespaceResources(status) { // TODO: exclude nil ones?
 				filteredNamespaces = append(filteredNamespaces, ns)
+			} else {
+				rbacGaps = append(rbacGaps, namespaceRBACGap(ns, status))
 			}
 		}
 		if len(filteredNamespaces) != len(namespaceNames) {
@@ -165,25 +222,27 @@ func (c *CollectClusterResources) Collect(progressChan chan<- interface{}) (Coll
 		}
 		namespaceNames = filteredNamespaces
 	}
+	rbacGapsJSON, err := json.MarshalIndent(rbacGaps, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s.json", constants.CLUSTER_RESOURCES_RBAC_GAPS)), bytes.NewBuffer(rbacGapsJSON))
 
 	// pods
-	pods, podErrors, unhealthyPods := pods(ctx, client, namespaceNames)
+	pods, podErrors, unhealthyPods := pods(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_PODS, namespaceNames))
 	for k, v := range pods {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_PODS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_PODS)), marshalErrors(podErrors))
 
+	logNamespaces := c.namespacesCollecting(constants.CLUSTER_RESOURCES_PODS_LOGS, namespaceNames)
 	for _, pod := range unhealthyPods {
+		if !slices.Contains(logNamespaces, pod.Namespace) {
+			continue
+		}
 		allContainers := append(pod.Spec.InitContainers, pod.Spec.Containers...)
 		for _, container := range allContainers {
-			limits := &troubleshootv1beta2.LogLimits{
-				MaxLines: 500,
-				// MaxBytes has been introduced to be able to limit the size of a pods logfile. This will in turn
-				// limit the total support bundle size as well as make sure the log(s) don't contain information
-				// that is too old/not relevant.
-				MaxBytes: 5000000,
-			}
-			podLogs, err := savePodLogs(ctx, c.BundlePath, client, &pod, "", container.Name, limits, false, false)
+			podLogs, err := savePodLogs(ctx, c.BundlePath, client, &pod, "", container.Name, c.unhealthyPodLogLimits(), 0, false, false)
 			if err != nil {
 				errPath := filepath.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_PODS_LOGS, pod.Namespace, pod.Name, fmt.Sprintf("%s-logs-errors.log", container.Name))
 				output.SaveResult(c.BundlePath, errPath, bytes.NewBuffer([]byte(err.Error())))
@@ -195,77 +254,102 @@ func (c *CollectClusterResources) Collect(progressChan chan<- interface{}) (Coll
 
 	// pod disruption budgets
 
-	PodDisruptionBudgets, pdbError := getPodDisruptionBudgets(ctx, client, namespaceNames)
+	PodDisruptionBudgets, pdbError := getPodDisruptionBudgets(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_POD_DISRUPTION_BUDGETS, namespaceNames))
 	for k, v := range PodDisruptionBudgets {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_POD_DISRUPTION_BUDGETS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_POD_DISRUPTION_BUDGETS)), marshalErrors(pdbError))
 
 	// services
-	services, servicesErrors := services(ctx, client, namespaceNames)
+	services, servicesErrors := services(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_SERVICES, namespaceNames))
 	for k, v := range services {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_SERVICES, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_SERVICES)), marshalErrors(servicesErrors))
 
 	// deployments
-	deployments, deploymentsErrors := deployments(ctx, client, namespaceNames)
+	deployments, deploymentsErrors := deployments(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_DEPLOYMENTS, namespaceNames))
 	for k, v := range deployments {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_DEPLOYMENTS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_DEPLOYMENTS)), marshalErrors(deploymentsErrors))
 
 	// statefulsets
-	statefulsets, statefulsetsErrors := statefulsets(ctx, client, namespaceNames)
+	statefulsets, statefulsetsErrors := statefulsets(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_STATEFULSETS, namespaceNames))
 	for k, v := range statefulsets {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_STATEFULSETS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_STATEFULSETS)), marshalErrors(statefulsetsErrors))
 
 	// daemonsets
-	daemonsets, daemonsetsErrors := daemonsets(ctx, client, namespaceNames)
+	daemonsets, daemonsetsErrors := daemonsets(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_DAEMONSETS, namespaceNames))
 	for k, v := range daemonsets {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_DAEMONSETS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_DAEMONSETS)), marshalErrors(daemonsetsErrors))
 
+	if c.Collector.LastApplied {
+		lastApplied, lastAppliedErrors := lastAppliedConfigurations(map[string]map[string][]byte{
+			"Deployment":  deployments,
+			"StatefulSet": statefulsets,
+			"DaemonSet":   daemonsets,
+		})
+		for k, v := range lastApplied {
+			output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_LAST_APPLIED, k), bytes.NewBuffer(v))
+		}
+		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_LAST_APPLIED)), marshalErrors(lastAppliedErrors))
+	}
+
+	if c.Collector.ManagedFields {
+		managedFields, managedFieldsErrors := managedFieldsHistories(map[string]map[string][]byte{
+			"Deployment":  deployments,
+			"StatefulSet": statefulsets,
+			"DaemonSet":   daemonsets,
+			"Service":     services,
+		})
+		for k, v := range managedFields {
+			output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_MANAGED_FIELDS, k), bytes.NewBuffer(v))
+		}
+		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_MANAGED_FIELDS)), marshalErrors(managedFieldsErrors))
+	}
+
 	// replicasets
-	replicasets, replicasetsErrors := replicasets(ctx, client, namespaceNames)
+	replicasets, replicasetsErrors := replicasets(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_REPLICASETS, namespaceNames))
 	for k, v := range replicasets {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_STATEFULSETS), k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_REPLICASETS)), marshalErrors(replicasetsErrors))
 
 	// jobs
-	jobs, jobsErrors := jobs(ctx, client, namespaceNames)
+	jobs, jobsErrors := jobs(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_JOBS, namespaceNames))
 	for k, v := range jobs {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_JOBS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_JOBS)), marshalErrors(jobsErrors))
 
 	// cronJobs
-	cronJobs, cronJobsErrors := cronJobs(ctx, client, namespaceNames)
+	cronJobs, cronJobsErrors := cronJobs(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_CRONJOBS, namespaceNames))
 	for k, v := range cronJobs {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_CRONJOBS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_CRONJOBS)), marshalErrors(cronJobsErrors))
 
 	// ingress
-	ingress, ingressErrors := ingress(ctx, client, namespaceNames)
+	ingress, ingressErrors := ingress(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_INGRESS, namespaceNames))
 	for k, v := range ingress {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_INGRESS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_INGRESS)), marshalErrors(ingressErrors))
 
 	// network policy
-	networkPolicy, networkPolicyErrors := networkPolicy(ctx, client, namespaceNames)
+	networkPolicy, networkPolicyErrors := networkPolicy(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_NETWORK_POLICY, namespaceNames))
 	for k, v := range networkPolicy {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_NETWORK_POLICY, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_NETWORK_POLICY)), marshalErrors(networkPolicyErrors))
 
 	// resource quotas
-	resourceQuota, resourceQuotaErrors := resourceQuota(ctx, client, namespaceNames)
+	resourceQuota, resourceQuotaErrors := resourceQuota(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_RESOURCE_QUOTA, namespaceNames))
 	for k, v := range resourceQuota {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_RESOURCE_QUOTA, k), bytes.NewBuffer(v))
 	}
@@ -282,19 +366,19 @@ func (c *CollectClusterResources) Collect(progressChan chan<- interface{}) (Coll
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_PRIORITY_CLASS)), marshalErrors(priorityErrors))
 
 	// crds
-	customResourceDefinitions, crdErrors := crds(ctx, client, c.ClientConfig)
+	customResourceDefinitions, crdErrors := crds(ctx, client, clientConfig)
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s.json", constants.CLUSTER_RESOURCES_CUSTOM_RESOURCE_DEFINITIONS)), bytes.NewBuffer(customResourceDefinitions))
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_CUSTOM_RESOURCE_DEFINITIONS)), marshalErrors(crdErrors))
 
 	// crs
-	customResources, crErrors := crs(ctx, dynamicClient, client, c.ClientConfig, namespaceNames)
+	customResources, crErrors := crs(ctx, dynamicClient, client, clientConfig, c.namespacesCollecting(constants.CLUSTER_RESOURCES_CUSTOM_RESOURCES, namespaceNames))
 	for k, v := range customResources {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_CUSTOM_RESOURCES, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_CUSTOM_RESOURCES, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_CUSTOM_RESOURCES)), marshalErrors(crErrors))
 
 	// imagepullsecrets
-	imagePullSecrets, pullSecretsErrors := imagePullSecrets(ctx, client, namespaceNames)
+	imagePullSecrets, pullSecretsErrors := imagePullSecrets(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_IMAGE_PULL_SECRETS, namespaceNames))
 	for k, v := range imagePullSecrets {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_IMAGE_PULL_SECRETS, k), bytes.NewBuffer(v))
 	}
@@ -311,14 +395,14 @@ func (c *CollectClusterResources) Collect(progressChan chan<- interface{}) (Coll
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-%s-errors.json", constants.CLUSTER_RESOURCES_GROUPS, constants.CLUSTER_RESOURCES_RESOURCES)), marshalErrors(groupsResourcesErrors))
 
 	// limit ranges
-	limitRanges, limitRangesErrors := limitRanges(ctx, client, namespaceNames)
+	limitRanges, limitRangesErrors := limitRanges(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_LIMITRANGES, namespaceNames))
 	for k, v := range limitRanges {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_LIMITRANGES, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_LIMITRANGES)), marshalErrors(limitRangesErrors))
 
 	//Events
-	events, eventsErrors := events(ctx, client, namespaceNames)
+	events, eventsErrors := events(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_EVENTS, namespaceNames))
 	for k, v := range events {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_EVENTS, k), bytes.NewBuffer(v))
 	}
@@ -330,21 +414,21 @@ func (c *CollectClusterResources) Collect(progressChan chan<- interface{}) (Coll
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_PVS)), marshalErrors(pvsErrors))
 
 	//Persistent Volume Claims
-	pvcs, pvcsErrors := pvcs(ctx, client, namespaceNames)
+	pvcs, pvcsErrors := pvcs(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_PVCS, namespaceNames))
 	for k, v := range pvcs {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_PVCS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_PVCS)), marshalErrors(pvcsErrors))
 
 	//Roles
-	roles, rolesErrors := roles(ctx, client, namespaceNames)
+	roles, rolesErrors := roles(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_ROLES, namespaceNames))
 	for k, v := range roles {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_ROLES, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_ROLES)), marshalErrors(rolesErrors))
 
 	//Role Bindings
-	roleBindings, roleBindingsErrors := roleBindings(ctx, client, namespaceNames)
+	roleBindings, roleBindingsErrors := roleBindings(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_ROLE_BINDINGS, namespaceNames))
 	for k, v := range roleBindings {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_ROLE_BINDINGS, k), bytes.NewBuffer(v))
 	}
@@ -361,28 +445,28 @@ func (c *CollectClusterResources) Collect(progressChan chan<- interface{}) (Coll
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_CLUSTER_ROLE_BINDINGS)), marshalErrors(clusterRoleBindingsErrors))
 
 	// endpoints
-	endpoints, endpointsErrors := endpoints(ctx, client, namespaceNames)
+	endpoints, endpointsErrors := endpoints(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_ENDPOINTS, namespaceNames))
 	for k, v := range endpoints {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_ENDPOINTS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_ENDPOINTS)), marshalErrors(endpointsErrors))
 
 	// endpointslices
-	endpointslices, endpointslicesErrors := endpointslices(ctx, client, namespaceNames)
+	endpointslices, endpointslicesErrors := endpointslices(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_ENDPOINTSICES, namespaceNames))
 	for k, v := range endpointslices {
 		_ = output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_ENDPOINTSICES, k), bytes.NewBuffer(v))
 	}
 	_ = output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_ENDPOINTSICES)), marshalErrors(endpointslicesErrors))
 
 	// Service Accounts
-	servicesAccounts, servicesAccountsErrors := serviceAccounts(ctx, client, namespaceNames)
+	servicesAccounts, servicesAccountsErrors := serviceAccounts(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_SERVICE_ACCOUNTS, namespaceNames))
 	for k, v := range servicesAccounts {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_SERVICE_ACCOUNTS, k), bytes.NewBuffer(v))
 	}
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_SERVICE_ACCOUNTS)), marshalErrors(servicesAccountsErrors))
 
 	// Leases
-	leases, leasesErrors := leases(ctx, client, namespaceNames)
+	leases, leasesErrors := leases(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_LEASES, namespaceNames))
 	for k, v := range leases {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_LEASES, k), bytes.NewBuffer(v))
 	}
@@ -393,22 +477,72 @@ func (c *CollectClusterResources) Collect(progressChan chan<- interface{}) (Coll
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s.json", constants.CLUSTER_RESOURCES_VOLUME_ATTACHMENTS)), bytes.NewBuffer(volumeAttachments))
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_VOLUME_ATTACHMENTS)), marshalErrors(volumeAttachmentsErrors))
 
+	// Certificate Signing Requests
+	csrs, csrsErrors := certificateSigningRequests(ctx, client)
+	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s.json", constants.CLUSTER_RESOURCES_CSRS)), bytes.NewBuffer(csrs))
+	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_CSRS)), marshalErrors(csrsErrors))
+
+	// API Services
+	apiServices, apiServicesErrors := apiServices(ctx, client, clientConfig)
+	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s.json", constants.CLUSTER_RESOURCES_API_SERVICES)), bytes.NewBuffer(apiServices))
+	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_API_SERVICES)), marshalErrors(apiServicesErrors))
+
 	// ConfigMaps
-	configMaps, configMapsErrors := configMaps(ctx, client, namespaceNames)
+	configMaps, configMapsErrors := configMaps(ctx, client, c.namespacesCollecting(constants.CLUSTER_RESOURCES_CONFIGMAPS, namespaceNames))
 	for k, v := range configMaps {
 		output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_CONFIGMAPS, k), bytes.NewBuffer(v))
 	}
 
 	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s-errors.json", constants.CLUSTER_RESOURCES_CONFIGMAPS)), marshalErrors(configMapsErrors))
+
+	// API warnings, saved last so they include the warnings for every request above
+	warnings, err := json.MarshalIndent(apiWarnings.Warnings(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	output.SaveResult(c.BundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, fmt.Sprintf("%s.json", constants.CLUSTER_RESOURCES_API_WARNINGS)), bytes.NewBuffer(warnings))
+
 	return output, nil
 }
 
-func getAllNamespaces(ctx context.Context, client *kubernetes.Clientset) ([]byte, *corev1.NamespaceList, []string) {
-	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+// namespacesCollecting returns the namespaces the resource is collected from under the
+// collector's namespace profiles
+func (c *CollectClusterResources) namespacesCollecting(resource string, namespaces []string) []string {
+	return namespacesCollectingResource(c.Collector.NamespaceProfiles, resource, namespaces)
+}
+
+// unhealthyPodLogLimits returns the limits of the logs collected from unhealthy pods
+func (c *CollectClusterResources) unhealthyPodLogLimits() *troubleshootv1beta2.LogLimits {
+	if c.SinceTime != nil {
+		return &troubleshootv1beta2.LogLimits{SinceTime: metav1.NewTime(*c.SinceTime)}
+	}
+	if c.Collector.PodLogLimits != nil {
+		return c.Collector.PodLogLimits
+	}
+	return &troubleshootv1beta2.LogLimits{
+		MaxLines: 500,
+		// MaxBytes has been introduced to be able to limit the size of a pods logfile. This will in turn
+		// limit the total support bundle size as well as make sure the log(s) don't contain information
+		// that is too old/not relevant.
+		MaxBytes: 5000000,
+	}
+}
+
+// getAllNamespaces lists the namespaces of the cluster, leaving out those the exclude patterns match
+// unless they are in includeNamespaces
+func getAllNamespaces(ctx context.Context, client *kubernetes.Clientset, excludeNamespaces []string, includeNamespaces []string) ([]byte, *corev1.NamespaceList, []string) {
+	namespaces, err := listWithRetry(ctx, client.CoreV1().Namespaces().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, nil, []string{err.Error()}
 	}
 
+	if len(excludeNamespaces) > 0 {
+		namespaces.Items = slices.DeleteFunc(namespaces.Items, func(namespace corev1.Namespace) bool {
+			return namespaceMatches(excludeNamespaces, namespace.Name) && !slices.Contains(includeNamespaces, namespace.Name)
+		})
+		klog.V(2).Infof("excluded namespaces matching [%s]", strings.Join(excludeNamespaces, ", "))
+	}
+
 	gvk, err := apiutil.GVKForObject(namespaces, scheme.Scheme)
 	if err == nil {
 		namespaces.GetObjectKind().SetGroupVersionKind(gvk)
@@ -470,16 +604,54 @@ func getNamespace(ctx context.Context, client *kubernetes.Clientset, namespace s
 	return b, nil
 }
 
-func pods(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string, []corev1.Pod) {
-	podsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-	unhealthyPods := []corev1.Pod{}
+// clusterResourcesNamespaceWorkers bounds how many namespaces a helper of the cluster resources
+// collector lists at once. The client's QPS and burst limits still apply to the requests.
+const clusterResourcesNamespaceWorkers = 10
 
+// forEachNamespace calls fn for every namespace from a bounded pool of workers and returns once
+// all calls have returned. fn runs concurrently and must guard the state it shares.
+func forEachNamespace(namespaces []string, fn func(namespace string)) {
+	var g errgroup.Group
+	g.SetLimit(clusterResourcesNamespaceWorkers)
 	for _, namespace := range namespaces {
-		pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		g.Go(func() error {
+			fn(namespace)
+			return nil
+		})
+	}
+	_ = g.Wait()
+}
+
+// collectByNamespace collects the namespaces in parallel, returning the output of collect for each
+// namespace as <namespace>.json, or the error it returned keyed by the namespace
+func collectByNamespace(namespaces []string, collect func(namespace string) ([]byte, error)) (map[string][]byte, map[string]string) {
+	filesByNamespace := make(map[string][]byte)
+	errorsByNamespace := make(map[string]string)
+
+	var mtx sync.Mutex
+	forEachNamespace(namespaces, func(namespace string) {
+		b, err := collect(namespace)
+
+		mtx.Lock()
+		defer mtx.Unlock()
 		if err != nil {
 			errorsByNamespace[namespace] = err.Error()
-			continue
+			return
+		}
+		filesByNamespace[namespace+".json"] = b
+	})
+
+	return filesByNamespace, errorsByNamespace
+}
+
+func pods(ctx context.Context, client kubernetes.Interface, namespaces []string) (map[string][]byte, map[string]string, []corev1.Pod) {
+	var mtx sync.Mutex
+	unhealthyPodsByNamespace := make(map[string][]corev1.Pod)
+
+	podsByNamespace, errorsByNamespace := collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		pods, err := listWithRetry(ctx, client.CoreV1().Pods(namespace).List, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(pods, scheme.Scheme)
@@ -496,17 +668,26 @@ func pods(ctx context.Context, client *kubernetes.Clientset, namespaces []string
 
 		b, err := json.MarshalIndent(pods, "", "  ")
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
+		unhealthy := []corev1.Pod{}
 		for _, pod := range pods.Items {
 			if k8sutil.IsPodUnhealthy(&pod) {
-				unhealthyPods = append(unhealthyPods, pod)
+				unhealthy = append(unhealthy, pod)
 			}
 		}
+		mtx.Lock()
+		unhealthyPodsByNamespace[namespace] = unhealthy
+		mtx.Unlock()
+
+		return b, nil
+	})
 
-		podsByNamespace[namespace+".json"] = b
+	// keep the unhealthy pods in the order of the namespaces, however the workers finished
+	unhealthyPods := []corev1.Pod{}
+	for _, namespace := range namespaces {
+		unhealthyPods = append(unhealthyPods, unhealthyPodsByNamespace[namespace]...)
 	}
 
 	return podsByNamespace, errorsByNamespace, unhealthyPods
@@ -526,14 +707,10 @@ func getPodDisruptionBudgets(ctx context.Context, client *kubernetes.Clientset,
 
 // TODO: The below function (`pdbV1`) needs to be DRY'd and moved into the main `getPodDisruptionBudgets` function.
 func pdbV1(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	pdbByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		PodDisruptionBudgets, err := client.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		PodDisruptionBudgets, err := listWithRetry(ctx, client.PolicyV1().PodDisruptionBudgets(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(PodDisruptionBudgets, scheme.Scheme)
@@ -548,28 +725,16 @@ func pdbV1(ctx context.Context, client *kubernetes.Clientset, namespaces []strin
 			}
 		}
 
-		b, err := json.MarshalIndent(PodDisruptionBudgets, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		pdbByNamespace[namespace+".json"] = b
-	}
-
-	return pdbByNamespace, errorsByNamespace
+		return json.MarshalIndent(PodDisruptionBudgets, "", "  ")
+	})
 }
 
 // This block/function can remain as is
 func pdbV1beta(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	pdbByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		PodDisruptionBudgets, err := client.PolicyV1beta1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		PodDisruptionBudgets, err := listWithRetry(ctx, client.PolicyV1beta1().PodDisruptionBudgets(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(PodDisruptionBudgets, scheme.Scheme)
@@ -584,27 +749,15 @@ func pdbV1beta(ctx context.Context, client *kubernetes.Clientset, namespaces []s
 			}
 		}
 
-		b, err := json.MarshalIndent(PodDisruptionBudgets, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		pdbByNamespace[namespace+".json"] = b
-	}
-
-	return pdbByNamespace, errorsByNamespace
+		return json.MarshalIndent(PodDisruptionBudgets, "", "  ")
+	})
 }
 
 func services(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	servicesByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		services, err := client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		services, err := listWithRetry(ctx, client.CoreV1().Services(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(services, scheme.Scheme)
@@ -619,27 +772,15 @@ func services(ctx context.Context, client *kubernetes.Clientset, namespaces []st
 			}
 		}
 
-		b, err := json.MarshalIndent(services, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		servicesByNamespace[namespace+".json"] = b
-	}
-
-	return servicesByNamespace, errorsByNamespace
+		return json.MarshalIndent(services, "", "  ")
+	})
 }
 
 func deployments(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	deploymentsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		deployments, err := listWithRetry(ctx, client.AppsV1().Deployments(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(deployments, scheme.Scheme)
@@ -654,27 +795,15 @@ func deployments(ctx context.Context, client *kubernetes.Clientset, namespaces [
 			}
 		}
 
-		b, err := json.MarshalIndent(deployments, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		deploymentsByNamespace[namespace+".json"] = b
-	}
-
-	return deploymentsByNamespace, errorsByNamespace
+		return json.MarshalIndent(deployments, "", "  ")
+	})
 }
 
 func statefulsets(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	statefulsetsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		statefulsets, err := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		statefulsets, err := listWithRetry(ctx, client.AppsV1().StatefulSets(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(statefulsets, scheme.Scheme)
@@ -689,28 +818,16 @@ func statefulsets(ctx context.Context, client *kubernetes.Clientset, namespaces
 			}
 		}
 
-		b, err := json.MarshalIndent(statefulsets, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		statefulsetsByNamespace[namespace+".json"] = b
-	}
-
-	return statefulsetsByNamespace, errorsByNamespace
+		return json.MarshalIndent(statefulsets, "", "  ")
+	})
 }
 
 func daemonsets(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	daemonsetsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		daemonsets, err := client.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		daemonsets, err := listWithRetry(ctx, client.AppsV1().DaemonSets(namespace).List, metav1.ListOptions{})
 
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(daemonsets, scheme.Scheme)
@@ -725,27 +842,15 @@ func daemonsets(ctx context.Context, client *kubernetes.Clientset, namespaces []
 			}
 		}
 
-		b, err := json.MarshalIndent(daemonsets, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		daemonsetsByNamespace[namespace+".json"] = b
-	}
-
-	return daemonsetsByNamespace, errorsByNamespace
+		return json.MarshalIndent(daemonsets, "", "  ")
+	})
 }
 
 func replicasets(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	replicasetsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		replicasets, err := client.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		replicasets, err := listWithRetry(ctx, client.AppsV1().ReplicaSets(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(replicasets, scheme.Scheme)
@@ -760,27 +865,15 @@ func replicasets(ctx context.Context, client *kubernetes.Clientset, namespaces [
 			}
 		}
 
-		b, err := json.MarshalIndent(replicasets, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		replicasetsByNamespace[namespace+".json"] = b
-	}
-
-	return replicasetsByNamespace, errorsByNamespace
+		return json.MarshalIndent(replicasets, "", "  ")
+	})
 }
 
 func jobs(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	jobsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		nsJobs, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		nsJobs, err := listWithRetry(ctx, client.BatchV1().Jobs(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(nsJobs, scheme.Scheme)
@@ -795,16 +888,8 @@ func jobs(ctx context.Context, client *kubernetes.Clientset, namespaces []string
 			}
 		}
 
-		b, err := json.MarshalIndent(nsJobs, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		jobsByNamespace[namespace+".json"] = b
-	}
-
-	return jobsByNamespace, errorsByNamespace
+		return json.MarshalIndent(nsJobs, "", "  ")
+	})
 }
 
 func cronJobs(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
@@ -820,14 +905,10 @@ func cronJobs(ctx context.Context, client *kubernetes.Clientset, namespaces []st
 }
 
 func cronJobsV1(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	cronJobsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		cronJobs, err := client.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		cronJobs, err := listWithRetry(ctx, client.BatchV1().CronJobs(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(cronJobs, scheme.Scheme)
@@ -842,27 +923,15 @@ func cronJobsV1(ctx context.Context, client *kubernetes.Clientset, namespaces []
 			}
 		}
 
-		b, err := json.MarshalIndent(cronJobs, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		cronJobsByNamespace[namespace+".json"] = b
-	}
-
-	return cronJobsByNamespace, errorsByNamespace
+		return json.MarshalIndent(cronJobs, "", "  ")
+	})
 }
 
 func cronJobsV1beta(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	cronJobsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		cronJobs, err := client.BatchV1beta1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		cronJobs, err := listWithRetry(ctx, client.BatchV1beta1().CronJobs(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(cronJobs, scheme.Scheme)
@@ -877,16 +946,8 @@ func cronJobsV1beta(ctx context.Context, client *kubernetes.Clientset, namespace
 			}
 		}
 
-		b, err := json.MarshalIndent(cronJobs, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		cronJobsByNamespace[namespace+".json"] = b
-	}
-
-	return cronJobsByNamespace, errorsByNamespace
+		return json.MarshalIndent(cronJobs, "", "  ")
+	})
 }
 
 func ingress(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
@@ -902,14 +963,10 @@ func ingress(ctx context.Context, client *kubernetes.Clientset, namespaces []str
 }
 
 func ingressV1(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	ingressByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		ingress, err := client.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		ingress, err := listWithRetry(ctx, client.NetworkingV1().Ingresses(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(ingress, scheme.Scheme)
@@ -924,27 +981,15 @@ func ingressV1(ctx context.Context, client *kubernetes.Clientset, namespaces []s
 			}
 		}
 
-		b, err := json.MarshalIndent(ingress, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		ingressByNamespace[namespace+".json"] = b
-	}
-
-	return ingressByNamespace, errorsByNamespace
+		return json.MarshalIndent(ingress, "", "  ")
+	})
 }
 
 func ingressV1beta(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	ingressByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		ingress, err := client.ExtensionsV1beta1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		ingress, err := listWithRetry(ctx, client.ExtensionsV1beta1().Ingresses(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(ingress, scheme.Scheme)
@@ -959,27 +1004,15 @@ func ingressV1beta(ctx context.Context, client *kubernetes.Clientset, namespaces
 			}
 		}
 
-		b, err := json.MarshalIndent(ingress, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		ingressByNamespace[namespace+".json"] = b
-	}
-
-	return ingressByNamespace, errorsByNamespace
+		return json.MarshalIndent(ingress, "", "  ")
+	})
 }
 
 func networkPolicy(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	networkPolicyByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		networkPolicy, err := client.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		networkPolicy, err := listWithRetry(ctx, client.NetworkingV1().NetworkPolicies(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(networkPolicy, scheme.Scheme)
@@ -994,27 +1027,15 @@ func networkPolicy(ctx context.Context, client *kubernetes.Clientset, namespaces
 			}
 		}
 
-		b, err := json.MarshalIndent(networkPolicy, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		networkPolicyByNamespace[namespace+".json"] = b
-	}
-
-	return networkPolicyByNamespace, errorsByNamespace
+		return json.MarshalIndent(networkPolicy, "", "  ")
+	})
 }
 
 func resourceQuota(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	resourceQuotaByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		resourceQuota, err := client.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		resourceQuota, err := listWithRetry(ctx, client.CoreV1().ResourceQuotas(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(resourceQuota, scheme.Scheme)
@@ -1029,16 +1050,8 @@ func resourceQuota(ctx context.Context, client *kubernetes.Clientset, namespaces
 			}
 		}
 
-		b, err := json.MarshalIndent(resourceQuota, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		resourceQuotaByNamespace[namespace+".json"] = b
-	}
-
-	return resourceQuotaByNamespace, errorsByNamespace
+		return json.MarshalIndent(resourceQuota, "", "  ")
+	})
 }
 
 func storageClasses(ctx context.Context, client *kubernetes.Clientset) ([]byte, []string) {
@@ -1054,7 +1067,7 @@ func storageClasses(ctx context.Context, client *kubernetes.Clientset) ([]byte,
 }
 
 func storageClassesV1(ctx context.Context, client *kubernetes.Clientset) ([]byte, []string) {
-	storageClasses, err := client.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	storageClasses, err := listWithRetry(ctx, client.StorageV1().StorageClasses().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -1080,7 +1093,7 @@ func storageClassesV1(ctx context.Context, client *kubernetes.Clientset) ([]byte
 }
 
 func storageClassesV1beta(ctx context.Context, client *kubernetes.Clientset) ([]byte, []string) {
-	storageClasses, err := client.StorageV1beta1().StorageClasses().List(ctx, metav1.ListOptions{})
+	storageClasses, err := listWithRetry(ctx, client.StorageV1beta1().StorageClasses().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -1118,7 +1131,7 @@ func priorityClasses(ctx context.Context, client *kubernetes.Clientset) ([]byte,
 }
 
 func priorityClassesV1(ctx context.Context, client *kubernetes.Clientset) ([]byte, []string) {
-	priorityClasses, err := client.SchedulingV1().PriorityClasses().List(ctx, metav1.ListOptions{})
+	priorityClasses, err := listWithRetry(ctx, client.SchedulingV1().PriorityClasses().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -1144,7 +1157,7 @@ func priorityClassesV1(ctx context.Context, client *kubernetes.Clientset) ([]byt
 }
 
 func priorityClassesV1beta1(ctx context.Context, client *kubernetes.Clientset) ([]byte, []string) {
-	priorityClasses, err := client.SchedulingV1beta1().PriorityClasses().List(ctx, metav1.ListOptions{})
+	priorityClasses, err := listWithRetry(ctx, client.SchedulingV1beta1().PriorityClasses().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -1187,7 +1200,7 @@ func crdsV1(ctx context.Context, config *rest.Config) ([]byte, []string) {
 		return nil, []string{err.Error()}
 	}
 
-	crds, err := client.CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	crds, err := listWithRetry(ctx, client.CustomResourceDefinitions().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -1218,7 +1231,7 @@ func crdsV1beta(ctx context.Context, config *rest.Config) ([]byte, []string) {
 		return nil, []string{err.Error()}
 	}
 
-	crds, err := client.CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	crds, err := listWithRetry(ctx, client.CustomResourceDefinitions().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -1287,7 +1300,7 @@ func crsV1(
 	customResources := make(map[string][]byte)
 	errorList := make(map[string]string)
 
-	crds, err := crdClient.CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	crds, err := listWithRetry(ctx, crdClient.CustomResourceDefinitions().List, metav1.ListOptions{})
 	if err != nil {
 		errorList["crdList"] = err.Error()
 		return customResources, errorList
@@ -1323,7 +1336,7 @@ func crsV1(
 		isNamespacedResource := crd.Spec.Scope == apiextensionsv1.NamespaceScoped
 
 		// Fetch all resources of given type
-		customResourceList, err := client.Resource(gvr).List(ctx, metav1.ListOptions{})
+		customResourceList, err := listWithRetry(ctx, client.Resource(gvr).List, metav1.ListOptions{})
 		if err != nil {
 			errorList[crd.Name] = err.Error()
 			continue
@@ -1392,7 +1405,7 @@ func crsV1beta(
 	customResources := make(map[string][]byte)
 	errorList := make(map[string]string)
 
-	crds, err := crdClient.CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	crds, err := listWithRetry(ctx, crdClient.CustomResourceDefinitions().List, metav1.ListOptions{})
 	if err != nil {
 		errorList["crdList"] = err.Error()
 		return customResources, errorList
@@ -1430,7 +1443,7 @@ func crsV1beta(
 		isNamespacedResource := crd.Spec.Scope == apiextensionsv1beta1.NamespaceScoped
 
 		// Fetch all resources of given type
-		customResourceList, err := client.Resource(gvr).List(ctx, metav1.ListOptions{})
+		customResourceList, err := listWithRetry(ctx, client.Resource(gvr).List, metav1.ListOptions{})
 		if err != nil {
 			errorList[crd.Name] = err.Error()
 			continue
@@ -1504,11 +1517,15 @@ func imagePullSecrets(ctx context.Context, client *kubernetes.Clientset, namespa
 		Auths map[string]DockerConfigEntry `json:"auths"`
 	}
 
-	for _, namespace := range namespaces {
-		secrets, err := client.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	var mtx sync.Mutex
+	forEachNamespace(namespaces, func(namespace string) {
+		secrets, err := listWithRetry(ctx, client.CoreV1().Secrets(namespace).List, metav1.ListOptions{})
+
+		mtx.Lock()
+		defer mtx.Unlock()
 		if err != nil {
 			errors[namespace] = err.Error()
-			continue
+			return
 		}
 
 		for _, secret := range secrets.Items {
@@ -1539,20 +1556,16 @@ func imagePullSecrets(ctx context.Context, client *kubernetes.Clientset, namespa
 				imagePullSecrets[fmt.Sprintf("%s/%s.json", namespace, secret.Name)] = b
 			}
 		}
-	}
+	})
 
 	return imagePullSecrets, errors
 }
 
 func limitRanges(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	limitRangesByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		limitRanges, err := client.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		limitRanges, err := listWithRetry(ctx, client.CoreV1().LimitRanges(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(limitRanges, scheme.Scheme)
@@ -1567,20 +1580,12 @@ func limitRanges(ctx context.Context, client *kubernetes.Clientset, namespaces [
 			}
 		}
 
-		b, err := json.MarshalIndent(limitRanges, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		limitRangesByNamespace[namespace+".json"] = b
-	}
-
-	return limitRangesByNamespace, errorsByNamespace
+		return json.MarshalIndent(limitRanges, "", "  ")
+	})
 }
 
 func nodes(ctx context.Context, client *kubernetes.Clientset) ([]byte, []string) {
-	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	nodes, err := listWithRetry(ctx, client.CoreV1().Nodes().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -1632,16 +1637,20 @@ func getSelfSubjectRulesReviews(ctx context.Context, client *kubernetes.Clientse
 	statusByNamespace := make(map[string]*authorizationv1.SubjectRulesReviewStatus)
 	errorsByNamespace := make(map[string]string)
 
-	for _, namespace := range namespaces {
+	var mtx sync.Mutex
+	forEachNamespace(namespaces, func(namespace string) {
 		sar := &authorizationv1.SelfSubjectRulesReview{
 			Spec: authorizationv1.SelfSubjectRulesReviewSpec{
 				Namespace: namespace,
 			},
 		}
 		response, err := client.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, sar, metav1.CreateOptions{})
+
+		mtx.Lock()
+		defer mtx.Unlock()
 		if err != nil {
 			errorsByNamespace[namespace] = err.Error()
-			continue
+			return
 		}
 
 		if response.Status.Incomplete {
@@ -1649,7 +1658,7 @@ func getSelfSubjectRulesReviews(ctx context.Context, client *kubernetes.Clientse
 		}
 
 		statusByNamespace[namespace] = response.Status.DeepCopy()
-	}
+	})
 
 	return statusByNamespace, errorsByNamespace
 }
@@ -1674,14 +1683,10 @@ func authCanI(accessStatuses map[string]*authorizationv1.SubjectRulesReviewStatu
 }
 
 func events(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	eventsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		events, err := listWithRetry(ctx, client.CoreV1().Events(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(events, scheme.Scheme)
@@ -1696,16 +1701,8 @@ func events(ctx context.Context, client *kubernetes.Clientset, namespaces []stri
 			}
 		}
 
-		b, err := json.MarshalIndent(events, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		eventsByNamespace[namespace+".json"] = b
-	}
-
-	return eventsByNamespace, errorsByNamespace
+		return json.MarshalIndent(events, "", "  ")
+	})
 }
 
 func canCollectNamespaceResources(status *authorizationv1.SubjectRulesReviewStatus) bool {
@@ -1753,6 +1750,33 @@ func canCollectNamespaceResources(status *authorizationv1.SubjectRulesReviewStat
 	return false
 }
 
+// RBACGap is a namespace whose resources were not collected because the permissions of the
+// collector, as reported by a SelfSubjectRulesReview, do not allow to
+type RBACGap struct {
+	Namespace string `json:"namespace"`
+	// Verb, APIGroup and Resource are the permission that is missing, the namespace is collected
+	// when pods can be read in it
+	Verb     string `json:"verb"`
+	APIGroup string `json:"apiGroup"`
+	Resource string `json:"resource"`
+	// EvaluationError is set when the rules of the namespace could not all be evaluated
+	EvaluationError string `json:"evaluationError,omitempty"`
+}
+
+// namespaceRBACGap returns the gap of a namespace that canCollectNamespaceResources rejected
+func namespaceRBACGap(namespace string, status *authorizationv1.SubjectRulesReviewStatus) RBACGap {
+	gap := RBACGap{
+		Namespace: namespace,
+		Verb:      "get",
+		APIGroup:  "",
+		Resource:  "pods",
+	}
+	if status.Incomplete {
+		gap.EvaluationError = status.EvaluationError
+	}
+	return gap
+}
+
 // not exported from: https://github.com/kubernetes/kubernetes/blob/master/pkg/kubectl/cmd/auth/cani.go#L339
 func convertToPolicyRule(status *authorizationv1.SubjectRulesReviewStatus) []rbacv1.PolicyRule {
 	ret := []rbacv1.PolicyRule{}
@@ -1776,7 +1800,7 @@ func convertToPolicyRule(status *authorizationv1.SubjectRulesReviewStatus) []rba
 }
 
 func pvs(ctx context.Context, client *kubernetes.Clientset) ([]byte, []string) {
-	pv, err := client.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	pv, err := listWithRetry(ctx, client.CoreV1().PersistentVolumes().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -1801,14 +1825,10 @@ func pvs(ctx context.Context, client *kubernetes.Clientset) ([]byte, []string) {
 }
 
 func pvcs(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	pvcsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		pvcs, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		pvcs, err := listWithRetry(ctx, client.CoreV1().PersistentVolumeClaims(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(pvcs, scheme.Scheme)
@@ -1823,27 +1843,15 @@ func pvcs(ctx context.Context, client *kubernetes.Clientset, namespaces []string
 			}
 		}
 
-		b, err := json.MarshalIndent(pvcs, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		pvcsByNamespace[namespace+".json"] = b
-	}
-
-	return pvcsByNamespace, errorsByNamespace
+		return json.MarshalIndent(pvcs, "", "  ")
+	})
 }
 
 func roles(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	rolesByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		roles, err := client.RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		roles, err := listWithRetry(ctx, client.RbacV1().Roles(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(roles, scheme.Scheme)
@@ -1858,27 +1866,15 @@ func roles(ctx context.Context, client *kubernetes.Clientset, namespaces []strin
 			}
 		}
 
-		b, err := json.MarshalIndent(roles, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		rolesByNamespace[namespace+".json"] = b
-	}
-
-	return rolesByNamespace, errorsByNamespace
+		return json.MarshalIndent(roles, "", "  ")
+	})
 }
 
 func roleBindings(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	roleBindingsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		roleBindings, err := client.RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		roleBindings, err := listWithRetry(ctx, client.RbacV1().RoleBindings(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(roleBindings, scheme.Scheme)
@@ -1893,20 +1889,12 @@ func roleBindings(ctx context.Context, client *kubernetes.Clientset, namespaces
 			}
 		}
 
-		b, err := json.MarshalIndent(roleBindings, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		roleBindingsByNamespace[namespace+".json"] = b
-	}
-
-	return roleBindingsByNamespace, errorsByNamespace
+		return json.MarshalIndent(roleBindings, "", "  ")
+	})
 }
 
 func clusterRoles(ctx context.Context, client *kubernetes.Clientset) ([]byte, []string) {
-	clusterRoles, err := client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	clusterRoles, err := listWithRetry(ctx, client.RbacV1().ClusterRoles().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -1931,7 +1919,7 @@ func clusterRoles(ctx context.Context, client *kubernetes.Clientset) ([]byte, []
 }
 
 func clusterRoleBindings(ctx context.Context, client *kubernetes.Clientset) ([]byte, []string) {
-	clusterRoleBindings, err := client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	clusterRoleBindings, err := listWithRetry(ctx, client.RbacV1().ClusterRoleBindings().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -1956,14 +1944,10 @@ func clusterRoleBindings(ctx context.Context, client *kubernetes.Clientset) ([]b
 }
 
 func endpoints(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	endpointsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		endpoints, err := client.CoreV1().Endpoints(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		endpoints, err := listWithRetry(ctx, client.CoreV1().Endpoints(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(endpoints, scheme.Scheme)
@@ -1978,27 +1962,15 @@ func endpoints(ctx context.Context, client *kubernetes.Clientset, namespaces []s
 			}
 		}
 
-		b, err := json.MarshalIndent(endpoints, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		endpointsByNamespace[namespace+".json"] = b
-	}
-
-	return endpointsByNamespace, errorsByNamespace
+		return json.MarshalIndent(endpoints, "", "  ")
+	})
 }
 
 func endpointslices(ctx context.Context, client *kubernetes.Clientset, namespaces []string) (map[string][]byte, map[string]string) {
-	objsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		objs, err := client.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		objs, err := listWithRetry(ctx, client.DiscoveryV1().EndpointSlices(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		// TODO: Can we DRY this? We repeat this pattern a lot
@@ -2014,27 +1986,15 @@ func endpointslices(ctx context.Context, client *kubernetes.Clientset, namespace
 			}
 		}
 
-		b, err := json.MarshalIndent(objs, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		objsByNamespace[namespace+".json"] = b
-	}
-
-	return objsByNamespace, errorsByNamespace
+		return json.MarshalIndent(objs, "", "  ")
+	})
 }
 
 func serviceAccounts(ctx context.Context, client kubernetes.Interface, namespaces []string) (map[string][]byte, map[string]string) {
-	serviceAccountsByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		serviceAccounts, err := client.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		serviceAccounts, err := listWithRetry(ctx, client.CoreV1().ServiceAccounts(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(serviceAccounts, scheme.Scheme)
@@ -2049,27 +2009,15 @@ func serviceAccounts(ctx context.Context, client kubernetes.Interface, namespace
 			}
 		}
 
-		b, err := json.MarshalIndent(serviceAccounts, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		serviceAccountsByNamespace[namespace+".json"] = b
-	}
-
-	return serviceAccountsByNamespace, errorsByNamespace
+		return json.MarshalIndent(serviceAccounts, "", "  ")
+	})
 }
 
 func leases(ctx context.Context, client kubernetes.Interface, namespaces []string) (map[string][]byte, map[string]string) {
-	leasesByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
-
-	for _, namespace := range namespaces {
-		leases, err := client.CoordinationV1().Leases(namespace).List(ctx, metav1.ListOptions{})
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		leases, err := listWithRetry(ctx, client.CoordinationV1().Leases(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(leases, scheme.Scheme)
@@ -2084,20 +2032,12 @@ func leases(ctx context.Context, client kubernetes.Interface, namespaces []strin
 			}
 		}
 
-		b, err := json.MarshalIndent(leases, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		leasesByNamespace[namespace+".json"] = b
-	}
-
-	return leasesByNamespace, errorsByNamespace
+		return json.MarshalIndent(leases, "", "  ")
+	})
 }
 
 func volumeAttachments(ctx context.Context, client kubernetes.Interface) ([]byte, []string) {
-	volumeAttachments, err := client.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	volumeAttachments, err := listWithRetry(ctx, client.StorageV1().VolumeAttachments().List, metav1.ListOptions{})
 	if err != nil {
 		return nil, []string{err.Error()}
 	}
@@ -2122,15 +2062,80 @@ func volumeAttachments(ctx context.Context, client kubernetes.Interface) ([]byte
 	return b, nil
 }
 
-func configMaps(ctx context.Context, client kubernetes.Interface, namespaces []string) (map[string][]byte, map[string]string) {
-	configmapByNamespace := make(map[string][]byte)
-	errorsByNamespace := make(map[string]string)
+func certificateSigningRequests(ctx context.Context, client kubernetes.Interface) ([]byte, []string) {
+	csrs, err := listWithRetry(ctx, client.CertificatesV1().CertificateSigningRequests().List, metav1.ListOptions{})
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
 
-	for _, namespace := range namespaces {
-		configmaps, err := client.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	gvk, err := apiutil.GVKForObject(csrs, scheme.Scheme)
+	if err == nil {
+		csrs.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+
+	for i, o := range csrs.Items {
+		gvk, err := apiutil.GVKForObject(&o, scheme.Scheme)
+		if err == nil {
+			csrs.Items[i].GetObjectKind().SetGroupVersionKind(gvk)
+		}
+	}
+
+	b, err := json.MarshalIndent(csrs, "", "  ")
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	return b, nil
+}
+
+// apiServices lists the APIServices that register the built-in and the aggregated APIs with the
+// apiserver. Their Available condition tells when an aggregated API server is unreachable.
+func apiServices(ctx context.Context, client kubernetes.Interface, config *rest.Config) ([]byte, []string) {
+	if _, err := client.Discovery().ServerResourcesForGroupVersion(apiServiceGVR.GroupVersion().String()); err != nil {
+		if kuberneteserrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, []string{err.Error()}
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	return apiServicesV1(ctx, dynamicClient)
+}
+
+var apiServiceGVR = schema.GroupVersionResource{Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices"}
+
+func apiServicesV1(ctx context.Context, client dynamic.Interface) ([]byte, []string) {
+	apiServices, err := listWithRetry(ctx, client.Resource(apiServiceGVR).List, metav1.ListOptions{})
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	// the apiregistration types are not in the client scheme, the items keep the kind they are
+	// served with
+	apiServices.SetGroupVersionKind(apiServiceGVR.GroupVersion().WithKind("APIServiceList"))
+	for i := range apiServices.Items {
+		if apiServices.Items[i].GetKind() == "" {
+			apiServices.Items[i].SetGroupVersionKind(apiServiceGVR.GroupVersion().WithKind("APIService"))
+		}
+	}
+
+	b, err := json.MarshalIndent(apiServices, "", "  ")
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	return b, nil
+}
+
+func configMaps(ctx context.Context, client kubernetes.Interface, namespaces []string) (map[string][]byte, map[string]string) {
+	return collectByNamespace(namespaces, func(namespace string) ([]byte, error) {
+		configmaps, err := listWithRetry(ctx, client.CoreV1().ConfigMaps(namespace).List, metav1.ListOptions{})
 		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
+			return nil, err
 		}
 
 		gvk, err := apiutil.GVKForObject(configmaps, scheme.Scheme)
@@ -2145,16 +2150,8 @@ func configMaps(ctx context.Context, client kubernetes.Interface, namespaces []s
 			}
 		}
 
-		b, err := json.MarshalIndent(configmaps, "", "  ")
-		if err != nil {
-			errorsByNamespace[namespace] = err.Error()
-			continue
-		}
-
-		configmapByNamespace[namespace+".json"] = b
-	}
-
-	return configmapByNamespace, errorsByNamespace
+		return json.MarshalIndent(configmaps, "", "  ")
+	})
 }
 
 // storeCustomResource stores a custom resource as JSON and YAML
@@ -0,0 +1,136 @@
+package collect
+
+import (
+	"fmt"
+	"path"
+	"slices"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+)
+
+const (
+	namespaceProfileFull   = "full"
+	namespaceProfileLight  = "light"
+	namespaceProfileCustom = "custom"
+)
+
+// lightProfileSkippedResources are left out of namespaces with the light profile, they are the
+// largest part of most bundles and the least useful for system namespaces
+var lightProfileSkippedResources = []string{
+	constants.CLUSTER_RESOURCES_EVENTS,
+	constants.CLUSTER_RESOURCES_PODS_LOGS,
+}
+
+func validateNamespaceProfiles(profiles []troubleshootv1beta2.ClusterResourcesNamespaceProfile) error {
+	for i, profile := range profiles {
+		switch profile.Profile {
+		case "", namespaceProfileFull, namespaceProfileLight, namespaceProfileCustom:
+		default:
+			return fmt.Errorf("namespace profile %d: unknown profile %q, expected full, light or custom", i, profile.Profile)
+		}
+		for _, pattern := range profile.Namespaces {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("namespace profile %d: invalid namespace pattern %q: %w", i, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateExcludeNamespaces(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid excluded namespace pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// namespaceMatches returns true when one of the glob patterns matches the namespace
+func namespaceMatches(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeExcludeNamespaces returns the exclude patterns of the cluster resources collectors that
+// still apply once they are merged into one that lists every namespace, and the namespaces the
+// patterns must not exclude. As merging unions the namespaces, a pattern is kept only when every
+// collector of all namespaces has it, and the namespaces the other collectors list are collected
+// even when a kept pattern matches them.
+func mergeExcludeNamespaces(allCollectors []Collector) ([]string, []string) {
+	var common []string
+	requested := []string{}
+	first := true
+	for _, collectorInterface := range allCollectors {
+		collector, ok := collectorInterface.(*CollectClusterResources)
+		if !ok {
+			continue
+		}
+		if collector.Collector.Namespaces != nil && !slices.Contains(collector.Collector.Namespaces, "") {
+			requested = append(requested, collector.Collector.Namespaces...)
+			continue
+		}
+		if first {
+			common = slices.Clone(collector.Collector.ExcludeNamespaces)
+			first = false
+			continue
+		}
+		common = slices.DeleteFunc(common, func(pattern string) bool {
+			return !slices.Contains(collector.Collector.ExcludeNamespaces, pattern)
+		})
+	}
+
+	var merged []string
+	for _, pattern := range common {
+		if !slices.Contains(merged, pattern) {
+			merged = append(merged, pattern)
+		}
+	}
+
+	var exempt []string
+	for _, namespace := range requested {
+		if namespaceMatches(merged, namespace) && !slices.Contains(exempt, namespace) {
+			exempt = append(exempt, namespace)
+		}
+	}
+	return merged, exempt
+}
+
+// namespacesCollectingResource returns the namespaces whose profile includes the resource, in
+// the order they were given
+func namespacesCollectingResource(profiles []troubleshootv1beta2.ClusterResourcesNamespaceProfile, resource string, namespaces []string) []string {
+	if len(profiles) == 0 {
+		return namespaces
+	}
+
+	filtered := []string{}
+	for _, namespace := range namespaces {
+		if namespaceProfileIncludes(profiles, namespace, resource) {
+			filtered = append(filtered, namespace)
+		}
+	}
+	return filtered
+}
+
+func namespaceProfileIncludes(profiles []troubleshootv1beta2.ClusterResourcesNamespaceProfile, namespace, resource string) bool {
+	for _, profile := range profiles {
+		if !namespaceMatches(profile.Namespaces, namespace) {
+			continue
+		}
+
+		switch profile.Profile {
+		case namespaceProfileLight:
+			return !slices.Contains(lightProfileSkippedResources, resource)
+		case namespaceProfileCustom:
+			return slices.Contains(profile.Resources, resource)
+		default:
+			return true
+		}
+	}
+	return true
+}
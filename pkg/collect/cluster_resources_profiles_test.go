@@ -0,0 +1,92 @@
+package collect
+
+import (
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_namespacesCollectingResource(t *testing.T) {
+	namespaces := []string{"app", "kube-system", "kube-public", "monitoring"}
+	profiles := []troubleshootv1beta2.ClusterResourcesNamespaceProfile{
+		{Namespaces: []string{"app"}, Profile: "full"},
+		{Namespaces: []string{"kube-*"}, Profile: "light"},
+		{Namespaces: []string{"monitoring"}, Profile: "custom", Resources: []string{"pods", "events"}},
+	}
+
+	tests := []struct {
+		name     string
+		profiles []troubleshootv1beta2.ClusterResourcesNamespaceProfile
+		resource string
+		want     []string
+	}{
+		{
+			name:     "no profiles collects everything",
+			resource: constants.CLUSTER_RESOURCES_EVENTS,
+			want:     namespaces,
+		},
+		{
+			name:     "light namespaces skip events",
+			profiles: profiles,
+			resource: constants.CLUSTER_RESOURCES_EVENTS,
+			want:     []string{"app", "monitoring"},
+		},
+		{
+			name:     "light namespaces skip pod logs",
+			profiles: profiles,
+			resource: constants.CLUSTER_RESOURCES_PODS_LOGS,
+			want:     []string{"app"},
+		},
+		{
+			name:     "light namespaces still collect other resources",
+			profiles: profiles,
+			resource: constants.CLUSTER_RESOURCES_DEPLOYMENTS,
+			want:     []string{"app", "kube-system", "kube-public"},
+		},
+		{
+			name:     "custom namespaces collect the listed resources",
+			profiles: profiles,
+			resource: constants.CLUSTER_RESOURCES_PODS,
+			want:     []string{"app", "kube-system", "kube-public", "monitoring"},
+		},
+		{
+			name: "first matching profile applies and unmatched namespaces are fully collected",
+			profiles: []troubleshootv1beta2.ClusterResourcesNamespaceProfile{
+				{Namespaces: []string{"kube-system"}},
+				{Namespaces: []string{"kube-*"}, Profile: "light"},
+			},
+			resource: constants.CLUSTER_RESOURCES_PODS_LOGS,
+			want:     []string{"app", "kube-system", "monitoring"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, namespacesCollectingResource(tt.profiles, tt.resource, namespaces))
+		})
+	}
+}
+
+func Test_validateNamespaceProfiles(t *testing.T) {
+	assert.NoError(t, validateNamespaceProfiles([]troubleshootv1beta2.ClusterResourcesNamespaceProfile{
+		{Namespaces: []string{"kube-*"}, Profile: "light"},
+		{Namespaces: []string{"app"}},
+	}))
+
+	err := validateNamespaceProfiles([]troubleshootv1beta2.ClusterResourcesNamespaceProfile{
+		{Namespaces: []string{"kube-system"}, Profile: "minimal"},
+	})
+	assert.EqualError(t, err, `namespace profile 0: unknown profile "minimal", expected full, light or custom`)
+
+	err = validateNamespaceProfiles([]troubleshootv1beta2.ClusterResourcesNamespaceProfile{
+		{Namespaces: []string{"kube-[system"}, Profile: "light"},
+	})
+	assert.ErrorContains(t, err, `namespace profile 0: invalid namespace pattern "kube-[system"`)
+}
+
+func Test_validateExcludeNamespaces(t *testing.T) {
+	assert.NoError(t, validateExcludeNamespaces([]string{"kube-*", "monitoring"}))
+	assert.ErrorContains(t, validateExcludeNamespaces([]string{"kube-[system"}), `invalid excluded namespace pattern "kube-[system"`)
+}
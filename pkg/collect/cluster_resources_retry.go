@@ -0,0 +1,92 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// listBackoff bounds the retries of a List call to 4 attempts over about 3.5 seconds
+var listBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    4,
+}
+
+// maxListRetryAfter caps how long a Retry-After from the apiserver can delay the next attempt
+const maxListRetryAfter = 10 * time.Second
+
+// listRetryError is returned when a List call still fails with a transient error after every
+// attempt, so the namespace error says the call was retried and why it kept failing
+type listRetryError struct {
+	Attempts int
+	Reason   metav1.StatusReason
+	Err      error
+}
+
+func (e *listRetryError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("failed after %d attempts (%s): %v", e.Attempts, e.Reason, e.Err)
+	}
+	return fmt.Sprintf("failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *listRetryError) Unwrap() error {
+	return e.Err
+}
+
+// listWithRetry calls list until it succeeds, fails with an error that is not transient or
+// listBackoff is exhausted. Waiting between attempts stops as soon as ctx is cancelled.
+func listWithRetry[T any](ctx context.Context, list func(context.Context, metav1.ListOptions) (T, error), opts metav1.ListOptions) (T, error) {
+	backoff := listBackoff
+
+	for attempt := 1; ; attempt++ {
+		result, err := list(ctx, opts)
+		if err == nil || !isTransientListError(err) {
+			return result, err
+		}
+		if backoff.Steps <= 1 {
+			return result, &listRetryError{Attempts: attempt, Reason: kuberneteserrors.ReasonForError(err), Err: err}
+		}
+
+		delay := backoff.Step()
+		if seconds, ok := kuberneteserrors.SuggestsClientDelay(err); ok {
+			delay = max(delay, min(time.Duration(seconds)*time.Second, maxListRetryAfter))
+		}
+		klog.V(2).Infof("List call failed on attempt %d, retrying in %s: %v", attempt, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, errors.Wrap(ctx.Err(), err.Error())
+		case <-timer.C:
+		}
+	}
+}
+
+// isTransientListError reports whether a List call may succeed when retried: apiserver timeouts,
+// throttling, unavailability and dropped connections
+func isTransientListError(err error) bool {
+	switch {
+	case kuberneteserrors.IsTimeout(err),
+		kuberneteserrors.IsServerTimeout(err),
+		kuberneteserrors.IsTooManyRequests(err),
+		kuberneteserrors.IsServiceUnavailable(err),
+		utilnet.IsConnectionReset(err),
+		utilnet.IsProbableEOF(err):
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
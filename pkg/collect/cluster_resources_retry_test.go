@@ -0,0 +1,136 @@
+package collect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func withFastListBackoff(t *testing.T) {
+	saved := listBackoff
+	listBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 4}
+	t.Cleanup(func() {
+		listBackoff = saved
+	})
+}
+
+// failingPodLists makes listing pods in each namespace fail with err the given number of times
+// before the fake clientset's own reactor is reached, and counts the calls
+func failingPodLists(client *testclient.Clientset, failures map[string]int, err error) map[string]int {
+	calls := map[string]int{}
+	client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		namespace := action.GetNamespace()
+		calls[namespace]++
+		if calls[namespace] <= failures[namespace] {
+			return true, nil, err
+		}
+		return false, nil, nil
+	})
+	return calls
+}
+
+func Test_listWithRetry(t *testing.T) {
+	withFastListBackoff(t)
+
+	podsResource := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		name        string
+		failures    int
+		err         error
+		wantCalls   int
+		wantPods    int
+		wantErr     string
+		wantRetried bool
+	}{
+		{
+			name:      "throttled twice then succeeds",
+			failures:  2,
+			err:       kuberneteserrors.NewTooManyRequests("slow down", 0),
+			wantCalls: 3,
+			wantPods:  1,
+		},
+		{
+			name:        "persistent timeout",
+			failures:    10,
+			err:         kuberneteserrors.NewTimeoutError("request timed out", 0),
+			wantCalls:   4,
+			wantErr:     "failed after 4 attempts (Timeout)",
+			wantRetried: true,
+		},
+		{
+			name:      "forbidden is not retried",
+			failures:  10,
+			err:       kuberneteserrors.NewForbidden(podsResource, "", errors.New("rbac denied")),
+			wantCalls: 1,
+			wantErr:   "forbidden",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := testclient.NewSimpleClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}})
+			calls := failingPodLists(client, map[string]int{"default": test.failures}, test.err)
+
+			pods, err := listWithRetry(context.Background(), client.CoreV1().Pods("default").List, metav1.ListOptions{})
+			assert.Equal(t, test.wantCalls, calls["default"])
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				var retryErr *listRetryError
+				assert.Equal(t, test.wantRetried, errors.As(err, &retryErr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, pods.Items, test.wantPods)
+		})
+	}
+
+	t.Run("cancelled context stops retrying", func(t *testing.T) {
+		listBackoff = wait.Backoff{Duration: time.Hour, Factor: 1, Steps: 4}
+
+		client := testclient.NewSimpleClientset()
+		calls := failingPodLists(client, map[string]int{"default": 10}, kuberneteserrors.NewServiceUnavailable("apiserver is shutting down"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		_, err := listWithRetry(ctx, client.CoreV1().Pods("default").List, metav1.ListOptions{})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls["default"])
+	})
+}
+
+func Test_podsRetriesTransientErrors(t *testing.T) {
+	withFastListBackoff(t)
+
+	client := testclient.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}},
+	)
+	failingPodLists(client, map[string]int{"default": 2, "kube-system": 10}, kuberneteserrors.NewServerTimeout(schema.GroupResource{Resource: "pods"}, "list", 0))
+
+	podsByNamespace, errorsByNamespace, _ := pods(context.Background(), client, []string{"default", "kube-system"})
+
+	require.Contains(t, podsByNamespace, "default.json")
+	var podList corev1.PodList
+	require.NoError(t, json.Unmarshal(podsByNamespace["default.json"], &podList))
+	require.Len(t, podList.Items, 1)
+	assert.Equal(t, "api", podList.Items[0].Name)
+
+	assert.NotContains(t, podsByNamespace, "kube-system.json")
+	require.Contains(t, errorsByNamespace, "kube-system")
+	assert.Contains(t, errorsByNamespace["kube-system"], "failed after 4 attempts (ServerTimeout)")
+	assert.NotContains(t, errorsByNamespace, "default")
+}
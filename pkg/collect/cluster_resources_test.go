@@ -3,22 +3,31 @@ package collect
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
 	"github.com/replicatedhq/troubleshoot/pkg/client/troubleshootclientset/scheme"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
 	v1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apixfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	testdynamicclient "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"sigs.k8s.io/yaml"
 )
 
@@ -139,6 +148,85 @@ func createTestVolumeAttachments(client kubernetes.Interface, volumeAttachmentNa
 	return nil
 }
 
+func Test_CertificateSigningRequests(t *testing.T) {
+	client := testclient.NewSimpleClientset(
+		&certificatesv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "csr-kubelet-serving"},
+			Spec: certificatesv1.CertificateSigningRequestSpec{
+				SignerName: certificatesv1.KubeletServingSignerName,
+				Username:   "system:node:node-a",
+			},
+		},
+		&certificatesv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "csr-client"},
+			Spec: certificatesv1.CertificateSigningRequestSpec{
+				SignerName: certificatesv1.KubeAPIServerClientSignerName,
+				Username:   "jane",
+			},
+		},
+	)
+
+	csrs, errs := certificateSigningRequests(context.Background(), client)
+	require.Empty(t, errs)
+
+	var csrList certificatesv1.CertificateSigningRequestList
+	require.NoError(t, json.Unmarshal(csrs, &csrList))
+	require.Len(t, csrList.Items, 2)
+	for _, csr := range csrList.Items {
+		assert.Contains(t, []string{"csr-kubelet-serving", "csr-client"}, csr.Name)
+		assert.Equal(t, "CertificateSigningRequest", csr.Kind)
+	}
+}
+
+func Test_APIServices(t *testing.T) {
+	apiService := func(name string, available string, reason string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apiregistration.k8s.io/v1",
+			"kind":       "APIService",
+			"metadata":   map[string]interface{}{"name": name},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Available", "status": available, "reason": reason},
+				},
+			},
+		}}
+	}
+	client := testdynamicclient.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{apiServiceGVR: "APIServiceList"},
+		apiService("v1.apps", "True", "Local"),
+		apiService("v1beta1.metrics.k8s.io", "False", "FailedDiscoveryCheck"),
+	)
+
+	b, errs := apiServicesV1(context.Background(), client)
+	require.Empty(t, errs)
+
+	var apiServices struct {
+		metav1.TypeMeta `json:",inline"`
+		Items           []struct {
+			metav1.TypeMeta   `json:",inline"`
+			metav1.ObjectMeta `json:"metadata"`
+			Status            struct {
+				Conditions []metav1.Condition `json:"conditions"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(b, &apiServices))
+	assert.Equal(t, "APIServiceList", apiServices.Kind)
+	assert.Equal(t, "apiregistration.k8s.io/v1", apiServices.APIVersion)
+	require.Len(t, apiServices.Items, 2)
+
+	available := map[string]string{}
+	for _, item := range apiServices.Items {
+		assert.Equal(t, "APIService", item.Kind)
+		require.Len(t, item.Status.Conditions, 1)
+		available[item.Name] = string(item.Status.Conditions[0].Status) + "/" + item.Status.Conditions[0].Reason
+	}
+	assert.Equal(t, map[string]string{
+		"v1.apps":                "True/Local",
+		"v1beta1.metrics.k8s.io": "False/FailedDiscoveryCheck",
+	}, available)
+}
+
 func Test_Leases(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -265,6 +353,52 @@ func createTestServiceAccounts(client kubernetes.Interface, serviceAccountNames
 	return nil
 }
 
+func Test_Pods(t *testing.T) {
+	namespaces := []string{}
+	objects := []runtime.Object{}
+	for i := 0; i < 3*clusterResourcesNamespaceWorkers; i++ {
+		namespace := fmt.Sprintf("ns-%02d", i)
+		namespaces = append(namespaces, namespace)
+		objects = append(objects,
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: namespace},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "failed", Namespace: namespace},
+				Status:     corev1.PodStatus{Phase: corev1.PodFailed},
+			},
+		)
+	}
+	namespaces = append(namespaces, "forbidden")
+
+	client := testclient.NewSimpleClientset(objects...)
+	client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "forbidden" {
+			return true, nil, errors.New("pods is forbidden")
+		}
+		return false, nil, nil
+	})
+
+	podsByNamespace, errorsByNamespace, unhealthyPods := pods(context.Background(), client, namespaces)
+	assert.Equal(t, map[string]string{"forbidden": "pods is forbidden"}, errorsByNamespace)
+	assert.Len(t, podsByNamespace, len(namespaces)-1)
+
+	for _, namespace := range namespaces[:len(namespaces)-1] {
+		var podList corev1.PodList
+		require.NoError(t, json.Unmarshal(podsByNamespace[namespace+".json"], &podList))
+		assert.Len(t, podList.Items, 2)
+	}
+
+	// unhealthy pods are returned in the order of the namespaces
+	unhealthyNamespaces := []string{}
+	for _, pod := range unhealthyPods {
+		assert.Equal(t, "failed", pod.Name)
+		unhealthyNamespaces = append(unhealthyNamespaces, pod.Namespace)
+	}
+	assert.Equal(t, namespaces[:len(namespaces)-1], unhealthyNamespaces)
+}
+
 func Test_SelectCRDVersionByPriority(t *testing.T) {
 	assert.Equal(t, "v1alpha3", selectCRDVersionByPriority([]string{"v1alpha2", "v1alpha3"}))
 	assert.Equal(t, "v1alpha3", selectCRDVersionByPriority([]string{"v1alpha3", "v1alpha2"}))
@@ -272,6 +406,51 @@ func Test_SelectCRDVersionByPriority(t *testing.T) {
 	assert.Equal(t, "v1", selectCRDVersionByPriority([]string{"v1", "v1alpha2", "v1alpha3"}))
 }
 
+func Test_namespaceRBACGap(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *authorizationv1.SubjectRulesReviewStatus
+		want   *RBACGap
+	}{
+		{
+			name: "can get pods",
+			status: &authorizationv1.SubjectRulesReviewStatus{
+				ResourceRules: []authorizationv1.ResourceRule{
+					{Verbs: []string{"get", "list"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+				},
+			},
+		},
+		{
+			name: "can only get configmaps",
+			status: &authorizationv1.SubjectRulesReviewStatus{
+				ResourceRules: []authorizationv1.ResourceRule{
+					{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"configmaps"}},
+					{Verbs: []string{"create"}, APIGroups: []string{"authorization.k8s.io"}, Resources: []string{"selfsubjectrulesreviews"}},
+				},
+			},
+			want: &RBACGap{Namespace: "app", Verb: "get", APIGroup: "", Resource: "pods"},
+		},
+		{
+			name: "incomplete evaluation",
+			status: &authorizationv1.SubjectRulesReviewStatus{
+				Incomplete:      true,
+				EvaluationError: "webhook authorizer failed",
+			},
+			want: &RBACGap{Namespace: "app", Verb: "get", APIGroup: "", Resource: "pods", EvaluationError: "webhook authorizer failed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if canCollectNamespaceResources(tt.status) {
+				assert.Nil(t, tt.want)
+				return
+			}
+			require.NotNil(t, tt.want)
+			assert.Equal(t, *tt.want, namespaceRBACGap("app", tt.status))
+		})
+	}
+}
+
 func TestClusterResources_Merge(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -439,6 +618,128 @@ func TestClusterResources_Merge(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "excluded namespaces of every collector of all namespaces are kept",
+			Collectors: []troubleshootv1beta2.Collect{
+				{
+					ClusterResources: &troubleshootv1beta2.ClusterResources{
+						CollectorMeta: troubleshootv1beta2.CollectorMeta{
+							CollectorName: "collectorname",
+						},
+						ExcludeNamespaces: []string{"kube-*", "monitoring"},
+					},
+				},
+				{
+					ClusterResources: &troubleshootv1beta2.ClusterResources{
+						CollectorMeta: troubleshootv1beta2.CollectorMeta{
+							CollectorName: "collectorname",
+						},
+						Namespaces:        []string{""},
+						ExcludeNamespaces: []string{"monitoring", "kube-*"},
+					},
+				},
+			},
+			want: &CollectClusterResources{
+				Collector: &troubleshootv1beta2.ClusterResources{
+					CollectorMeta: troubleshootv1beta2.CollectorMeta{
+						CollectorName: "collectorname",
+					},
+					Namespaces:        nil,
+					ExcludeNamespaces: []string{"kube-*", "monitoring"},
+				},
+			},
+		},
+		{
+			name: "excluded namespaces other collectors collect are not excluded",
+			Collectors: []troubleshootv1beta2.Collect{
+				{
+					ClusterResources: &troubleshootv1beta2.ClusterResources{
+						CollectorMeta: troubleshootv1beta2.CollectorMeta{
+							CollectorName: "collectorname",
+						},
+						ExcludeNamespaces: []string{"kube-*", "monitoring", "logging"},
+					},
+				},
+				{
+					ClusterResources: &troubleshootv1beta2.ClusterResources{
+						CollectorMeta: troubleshootv1beta2.CollectorMeta{
+							CollectorName: "collectorname",
+						},
+						Namespaces: []string{"kube-system"},
+					},
+				},
+				{
+					ClusterResources: &troubleshootv1beta2.ClusterResources{
+						CollectorMeta: troubleshootv1beta2.CollectorMeta{
+							CollectorName: "collectorname",
+						},
+						ExcludeNamespaces: []string{"logging", "monitoring"},
+					},
+				},
+			},
+			want: &CollectClusterResources{
+				Collector: &troubleshootv1beta2.ClusterResources{
+					CollectorMeta: troubleshootv1beta2.CollectorMeta{
+						CollectorName: "collectorname",
+					},
+					Namespaces:        nil,
+					ExcludeNamespaces: []string{"monitoring", "logging"},
+				},
+			},
+		},
+		{
+			name: "namespaces other collectors list are exempt from the excluded patterns",
+			Collectors: []troubleshootv1beta2.Collect{
+				{
+					ClusterResources: &troubleshootv1beta2.ClusterResources{
+						CollectorMeta: troubleshootv1beta2.CollectorMeta{
+							CollectorName: "collectorname",
+						},
+						ExcludeNamespaces: []string{"kube-*", "monitoring"},
+					},
+				},
+				{
+					ClusterResources: &troubleshootv1beta2.ClusterResources{
+						CollectorMeta: troubleshootv1beta2.CollectorMeta{
+							CollectorName: "collectorname",
+						},
+						Namespaces: []string{"kube-system", "default"},
+					},
+				},
+			},
+			want: &CollectClusterResources{
+				Collector: &troubleshootv1beta2.ClusterResources{
+					CollectorMeta: troubleshootv1beta2.CollectorMeta{
+						CollectorName: "collectorname",
+					},
+					Namespaces:        nil,
+					ExcludeNamespaces: []string{"kube-*", "monitoring"},
+				},
+				includeNamespaces: []string{"kube-system"},
+			},
+		},
+		{
+			name: "excluded namespaces are dropped when namespaces are listed",
+			Collectors: []troubleshootv1beta2.Collect{
+				{
+					ClusterResources: &troubleshootv1beta2.ClusterResources{
+						CollectorMeta: troubleshootv1beta2.CollectorMeta{
+							CollectorName: "collectorname",
+						},
+						Namespaces:        []string{"hello"},
+						ExcludeNamespaces: []string{"kube-*"},
+					},
+				},
+			},
+			want: &CollectClusterResources{
+				Collector: &troubleshootv1beta2.ClusterResources{
+					CollectorMeta: troubleshootv1beta2.CollectorMeta{
+						CollectorName: "collectorname",
+					},
+					Namespaces: []string{"hello"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -508,6 +809,20 @@ func TestCollectClusterResources_CustomResource(t *testing.T) {
 	assert.Equal(t, fromYAML(t, res["supportbundles.troubleshoot.sh/default.yaml"]), sbObject)
 }
 
+func TestCollectClusterResources_unhealthyPodLogLimits(t *testing.T) {
+	sinceTime := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	specLimits := &troubleshootv1beta2.LogLimits{SinceSeconds: 600}
+
+	c := &CollectClusterResources{Collector: &troubleshootv1beta2.ClusterResources{}}
+	assert.Equal(t, &troubleshootv1beta2.LogLimits{MaxLines: 500, MaxBytes: 5000000}, c.unhealthyPodLogLimits())
+
+	c.Collector.PodLogLimits = specLimits
+	assert.Equal(t, specLimits, c.unhealthyPodLogLimits())
+
+	c.SinceTime = &sinceTime
+	assert.Equal(t, &troubleshootv1beta2.LogLimits{SinceTime: metav1.NewTime(sinceTime)}, c.unhealthyPodLogLimits())
+}
+
 func fromYAML(t *testing.T, dat []byte) troubleshootv1beta2.SupportBundle {
 	sb := []troubleshootv1beta2.SupportBundle{}
 	err := yaml.Unmarshal(dat, &sb)
@@ -38,6 +38,8 @@ type CollectProgress struct {
 	CurrentStatus  string
 	CompletedCount int
 	TotalCount     int
+	// BytesWritten is the size of the results of a completed collector, when known
+	BytesWritten int64
 }
 
 type HostCollectResult struct {
@@ -71,20 +73,38 @@ func CollectHost(c *troubleshootv1beta2.HostCollector, additionalRedactors *trou
 		Spec:       c,
 	}
 
-	for _, collector := range collectors {
+	for i, collector := range collectors {
 		isExcluded, _ := collector.IsExcluded()
 		if isExcluded {
 			opts.ProgressChan <- fmt.Sprintf("[%s] Excluding collector", collector.Title())
 			continue
 		}
 
-		opts.ProgressChan <- fmt.Sprintf("[%s] Running collector...", collector.Title())
+		opts.ProgressChan <- CollectProgress{
+			CurrentName:    collector.Title(),
+			CurrentStatus:  "running",
+			CompletedCount: i,
+			TotalCount:     len(collectors),
+		}
+
 		result, err := collector.Collect(opts.ProgressChan)
+		status := "completed"
 		if err != nil {
+			status = "failed"
 			opts.ProgressChan <- errors.Errorf("failed to run collector: %s: %v", collector.Title(), err)
 		}
+
+		var bytesWritten int64
 		for k, v := range result {
 			allCollectedData[k] = v
+			bytesWritten += int64(len(v))
+		}
+		opts.ProgressChan <- CollectProgress{
+			CurrentName:    collector.Title(),
+			CurrentStatus:  status,
+			CompletedCount: i + 1,
+			TotalCount:     len(collectors),
+			BytesWritten:   bytesWritten,
 		}
 	}
 
@@ -157,11 +177,16 @@ func CollectRemote(c *troubleshootv1beta2.RemoteCollector, additionalRedactors *
 			continue
 		}
 
+		var bytesWritten int64
+		for _, v := range result {
+			bytesWritten += int64(len(v))
+		}
 		opts.ProgressChan <- CollectProgress{
 			CurrentName:    collector.GetDisplayName(),
 			CurrentStatus:  "completed",
 			CompletedCount: i + 1,
 			TotalCount:     len(collectors),
+			BytesWritten:   bytesWritten,
 		}
 
 		for k, v := range result {
@@ -62,7 +62,7 @@ func GetCollector(collector *troubleshootv1beta2.Collect, bundlePath string, nam
 	case collector.ClusterInfo != nil:
 		return &CollectClusterInfo{collector.ClusterInfo, bundlePath, namespace, clientConfig, RBACErrors}, true
 	case collector.ClusterResources != nil:
-		return &CollectClusterResources{collector.ClusterResources, bundlePath, namespace, clientConfig, RBACErrors}, true
+		return &CollectClusterResources{Collector: collector.ClusterResources, BundlePath: bundlePath, Namespace: namespace, ClientConfig: clientConfig, SinceTime: sinceTime, RBACErrors: RBACErrors}, true
 	case collector.CustomMetrics != nil:
 		return &CollectMetrics{collector.CustomMetrics, bundlePath, clientConfig, client, ctx, RBACErrors}, true
 	case collector.Secret != nil:
@@ -70,7 +70,7 @@ func GetCollector(collector *troubleshootv1beta2.Collect, bundlePath string, nam
 	case collector.ConfigMap != nil:
 		return &CollectConfigMap{collector.ConfigMap, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
 	case collector.Logs != nil:
-		return &CollectLogs{collector.Logs, bundlePath, namespace, clientConfig, client, ctx, sinceTime, RBACErrors}, true
+		return &CollectLogs{collector.Logs, bundlePath, namespace, clientConfig, client, ctx, sinceTime, 0, RBACErrors}, true
 	case collector.Run != nil:
 		return &CollectRun{collector.Run, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
 	case collector.RunPod != nil:
@@ -104,6 +104,8 @@ func GetCollector(collector *troubleshootv1beta2.Collect, bundlePath string, nam
 		return &CollectMysql{collector.Mysql, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
 	case collector.Redis != nil:
 		return &CollectRedis{collector.Redis, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.Mongodb != nil:
+		return &CollectMongodb{collector.Mongodb, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
 	case collector.Collectd != nil:
 		return &CollectCollectd{collector.Collectd, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
 	case collector.Ceph != nil:
@@ -128,6 +130,36 @@ func GetCollector(collector *troubleshootv1beta2.Collect, bundlePath string, nam
 		return &CollectDNS{collector.DNS, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
 	case collector.Etcd != nil:
 		return &CollectEtcd{collector.Etcd, bundlePath, clientConfig, client, ctx, RBACErrors}, true
+	case collector.GitOps != nil:
+		return &CollectGitOps{collector.GitOps, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.NodeCommands != nil:
+		return &CollectNodeCommands{collector.NodeCommands, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.VPA != nil:
+		return &CollectVPA{collector.VPA, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.Focus != nil:
+		return &CollectFocus{collector.Focus, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.Security != nil:
+		return &CollectSecurity{collector.Security, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.ServiceMesh != nil:
+		return &CollectServiceMesh{collector.ServiceMesh, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.ClusterAutoscaler != nil:
+		return &CollectClusterAutoscaler{collector.ClusterAutoscaler, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.KubeStateMetrics != nil:
+		return &CollectKubeStateMetrics{collector.KubeStateMetrics, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.Traces != nil:
+		return &CollectTraces{collector.Traces, bundlePath, ctx, RBACErrors}, true
+	case collector.Kubelet != nil:
+		return &CollectKubelet{collector.Kubelet, bundlePath, clientConfig, client, ctx, RBACErrors}, true
+	case collector.HelmReleases != nil:
+		return &CollectHelmReleases{collector.HelmReleases, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.NetworkThroughput != nil:
+		return &CollectNetworkThroughput{collector.NetworkThroughput, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.MetricsServer != nil:
+		return &CollectMetricsServer{collector.MetricsServer, bundlePath, clientConfig, client, ctx, RBACErrors}, true
+	case collector.Prometheus != nil:
+		return &CollectPrometheus{collector.Prometheus, bundlePath, namespace, clientConfig, client, ctx, RBACErrors}, true
+	case collector.AdmissionWebhooks != nil:
+		return &CollectAdmissionWebhooks{collector.AdmissionWebhooks, bundlePath, clientConfig, client, ctx, RBACErrors}, true
 	default:
 		return nil, false
 	}
@@ -194,6 +226,9 @@ func getCollectorName(c interface{}) string {
 	case *CollectRedis:
 		collector = "redis"
 		name = v.Collector.CollectorName
+	case *CollectMongodb:
+		collector = "mongodb"
+		name = v.Collector.CollectorName
 	case *CollectCollectd:
 		collector = "collectd"
 		name = v.Collector.CollectorName
@@ -223,6 +258,51 @@ func getCollectorName(c interface{}) string {
 		collector = "dns"
 	case *CollectEtcd:
 		collector = "etcd"
+	case *CollectGitOps:
+		collector = "gitops"
+		name = v.Collector.CollectorName
+	case *CollectNodeCommands:
+		collector = "node-commands"
+		name = v.Collector.CollectorName
+	case *CollectVPA:
+		collector = "vpa"
+		name = v.Collector.CollectorName
+	case *CollectFocus:
+		collector = "focus"
+		name = v.Collector.CollectorName
+	case *CollectSecurity:
+		collector = "security"
+		name = v.Collector.CollectorName
+	case *CollectServiceMesh:
+		collector = "service-mesh"
+		name = v.Collector.CollectorName
+	case *CollectClusterAutoscaler:
+		collector = "cluster-autoscaler"
+		name = v.Collector.CollectorName
+	case *CollectKubeStateMetrics:
+		collector = "kube-state-metrics"
+		name = v.Collector.CollectorName
+	case *CollectTraces:
+		collector = "traces"
+		name = v.Collector.CollectorName
+	case *CollectKubelet:
+		collector = "kubelet"
+		name = v.Collector.CollectorName
+	case *CollectHelmReleases:
+		collector = "helm-releases"
+		name = v.Collector.CollectorName
+	case *CollectNetworkThroughput:
+		collector = "network-throughput"
+		name = v.Collector.CollectorName
+	case *CollectMetricsServer:
+		collector = "metrics-server"
+		name = v.Collector.CollectorName
+	case *CollectPrometheus:
+		collector = "prometheus"
+		name = v.Collector.CollectorName
+	case *CollectAdmissionWebhooks:
+		collector = "admission-webhooks"
+		name = v.Collector.CollectorName
 	default:
 		collector = "<none>"
 	}
@@ -286,20 +366,3 @@ func DedupCollectors(allCollectors []*troubleshootv1beta2.Collect) []*troublesho
 	}
 	return finalCollectors
 }
-
-// Ensure Copy collectors are last in the list
-// This is because copy collectors are expected to copy files from other collectors such as Exec, RunPod, RunDaemonSet
-func EnsureCopyLast(allCollectors []Collector) []Collector {
-	otherCollectors := make([]Collector, 0)
-	copyCollectors := make([]Collector, 0)
-
-	for _, collector := range allCollectors {
-		if _, ok := collector.(*CollectCopy); ok {
-			copyCollectors = append(copyCollectors, collector)
-		} else {
-			otherCollectors = append(otherCollectors, collector)
-		}
-	}
-
-	return append(otherCollectors, copyCollectors...)
-}
@@ -46,7 +46,7 @@ func (c *CollectCopy) Collect(progressChan chan<- interface{}) (CollectorResult,
 
 	output := NewResult()
 
-	ctx := context.Background()
+	ctx := collectorContext(c.Context)
 
 	pods, podsErrors := listPodsInSelectors(ctx, client, c.Collector.Namespace, c.Collector.Selector)
 	if len(podsErrors) > 0 {
@@ -5,4 +5,7 @@ type DatabaseConnection struct {
 	Error       string            `json:"error,omitempty"`
 	Version     string            `json:"version,omitempty"`
 	Variables   map[string]string `json:"variables,omitempty"`
+	// ReplicaSetRole and ReplicaSet are only collected for mongodb
+	ReplicaSetRole string `json:"replicaSetRole,omitempty"`
+	ReplicaSet     string `json:"replicaSet,omitempty"`
 }
@@ -0,0 +1,132 @@
+package collect
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+)
+
+// OrderCollectorsByDependencies groups collectors into stages that have to run one after the other.
+// Collectors in the same stage do not depend on each other and can run concurrently. A collector
+// is placed in the stage after the last of the collectors named in its dependsOn.
+//
+// The existing implicit ordering is kept: cluster resources are collected before anything else so
+// the pod list does not include pods started by collectors, and copy collectors run after every
+// other collector since they copy files those collectors produce.
+//
+// A collector that depends on a name no collector has is still ordered by its other dependencies,
+// and returned with its error so it can be reported as a failed collector instead of failing the
+// collection. An error is returned when the dependencies form a cycle.
+func OrderCollectorsByDependencies(collectors []Collector) ([][]Collector, map[Collector]error, error) {
+	byName := map[string][]int{}
+	for i, collector := range collectors {
+		if meta := getCollectorMeta(collector); meta != nil && meta.CollectorName != "" {
+			byName[meta.CollectorName] = append(byName[meta.CollectorName], i)
+		}
+	}
+
+	// prerequisites[i] holds the indexes of the collectors that have to finish before collector i
+	prerequisites := make([]map[int]struct{}, len(collectors))
+	dependencyErrs := map[Collector]error{}
+	for i, collector := range collectors {
+		prerequisites[i] = map[int]struct{}{}
+
+		if meta := getCollectorMeta(collector); meta != nil {
+			for _, name := range meta.DependsOn {
+				dependencies, ok := byName[name]
+				if !ok {
+					dependencyErrs[collector] = errors.Errorf("collector %q depends on unknown collector %q", collector.Title(), name)
+					continue
+				}
+				for _, j := range dependencies {
+					if j == i {
+						return nil, nil, errors.Errorf("collector %q depends on itself", collector.Title())
+					}
+					prerequisites[i][j] = struct{}{}
+				}
+			}
+		}
+
+		_, isClusterResources := collector.(*CollectClusterResources)
+		_, isCopy := collector.(*CollectCopy)
+		for j, other := range collectors {
+			if _, ok := other.(*CollectClusterResources); ok && !isClusterResources {
+				prerequisites[i][j] = struct{}{}
+			}
+			if _, ok := other.(*CollectCopy); isCopy && !ok {
+				prerequisites[i][j] = struct{}{}
+			}
+		}
+	}
+
+	stages := [][]Collector{}
+	done := make([]bool, len(collectors))
+	remaining := len(collectors)
+	for remaining > 0 {
+		ready := []int{}
+		for i := range collectors {
+			if done[i] {
+				continue
+			}
+			isReady := true
+			for j := range prerequisites[i] {
+				if !done[j] {
+					isReady = false
+					break
+				}
+			}
+			if isReady {
+				ready = append(ready, i)
+			}
+		}
+
+		if len(ready) == 0 {
+			cycle := []string{}
+			for i, collector := range collectors {
+				if !done[i] {
+					cycle = append(cycle, collector.Title())
+				}
+			}
+			sort.Strings(cycle)
+			return nil, nil, errors.Errorf("collector dependencies form a cycle, unable to order %s", strings.Join(cycle, ", "))
+		}
+
+		stage := []Collector{}
+		for _, i := range ready {
+			done[i] = true
+			stage = append(stage, collectors[i])
+		}
+		remaining -= len(ready)
+		stages = append(stages, stage)
+	}
+
+	return stages, dependencyErrs, nil
+}
+
+// getCollectorMeta returns the CollectorMeta of the spec a collector was created from, or nil
+// when the collector has no spec
+func getCollectorMeta(collector Collector) *troubleshootv1beta2.CollectorMeta {
+	v := reflect.ValueOf(collector)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	spec := v.Elem().FieldByName("Collector")
+	if !spec.IsValid() || spec.Kind() != reflect.Ptr || spec.IsNil() || spec.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	meta := spec.Elem().FieldByName("CollectorMeta")
+	if !meta.IsValid() || !meta.CanAddr() {
+		return nil
+	}
+
+	collectorMeta, ok := meta.Addr().Interface().(*troubleshootv1beta2.CollectorMeta)
+	if !ok {
+		return nil
+	}
+	return collectorMeta
+}
@@ -0,0 +1,119 @@
+package collect
+
+import (
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderCollectorsByDependencies(t *testing.T) {
+	data := func(name string, dependsOn ...string) *CollectData {
+		return &CollectData{
+			Collector: &troubleshootv1beta2.Data{
+				CollectorMeta: troubleshootv1beta2.CollectorMeta{
+					CollectorName: name,
+					DependsOn:     dependsOn,
+				},
+			},
+		}
+	}
+
+	clusterResources := &CollectClusterResources{Collector: &troubleshootv1beta2.ClusterResources{}}
+	clusterInfo := &CollectClusterInfo{Collector: &troubleshootv1beta2.ClusterInfo{}}
+	copyCollector := &CollectCopy{Collector: &troubleshootv1beta2.Copy{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "copy"}}}
+
+	crds := data("crds")
+	crs := data("crs", "crds")
+	config := data("config")
+	logs := data("logs", "config", "crs")
+	independent := data("independent")
+	unknownDependency := data("a", "missing", "crds")
+
+	tests := []struct {
+		name       string
+		collectors []Collector
+		want       [][]Collector
+		wantDepErr map[Collector]string
+		wantErr    string
+	}{
+		{
+			name:       "independent collectors share a stage",
+			collectors: []Collector{crds, config, independent},
+			want:       [][]Collector{{crds, config, independent}},
+		},
+		{
+			name:       "dependency chain",
+			collectors: []Collector{logs, independent, crs, config, crds},
+			want: [][]Collector{
+				{independent, config, crds},
+				{crs},
+				{logs},
+			},
+		},
+		{
+			name:       "cluster resources first and copy collectors last",
+			collectors: []Collector{copyCollector, crs, clusterInfo, crds, clusterResources},
+			want: [][]Collector{
+				{clusterResources},
+				{clusterInfo, crds},
+				{crs},
+				{copyCollector},
+			},
+		},
+		{
+			name:       "collectors sharing a name are all prerequisites",
+			collectors: []Collector{data("dependent", "shared"), data("shared"), data("shared", "other"), data("other")},
+			want: [][]Collector{
+				{data("shared"), data("other")},
+				{data("shared", "other")},
+				{data("dependent", "shared")},
+			},
+		},
+		{
+			name:       "cycle",
+			collectors: []Collector{data("a", "c"), data("b", "a"), data("c", "b"), independent},
+			wantErr:    "collector dependencies form a cycle, unable to order data/a, data/b, data/c",
+		},
+		{
+			name:       "self dependency",
+			collectors: []Collector{data("a", "a")},
+			wantErr:    `collector "data/a" depends on itself`,
+		},
+		{
+			name:       "unknown dependency",
+			collectors: []Collector{unknownDependency, crds},
+			want:       [][]Collector{{crds}, {unknownDependency}},
+			wantDepErr: map[Collector]string{
+				unknownDependency: `collector "data/a" depends on unknown collector "missing"`,
+			},
+		},
+		{
+			name:       "collectors without specs",
+			collectors: []Collector{&CollectClusterInfo{}, &CollectCopy{}},
+			want:       [][]Collector{{&CollectClusterInfo{}}, {&CollectCopy{}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, depErrs, err := OrderCollectorsByDependencies(tt.collectors)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			gotDepErrs := map[Collector]string{}
+			for collector, err := range depErrs {
+				gotDepErrs[collector] = err.Error()
+			}
+			if tt.wantDepErr == nil {
+				tt.wantDepErr = map[Collector]string{}
+			}
+			assert.Equal(t, tt.wantDepErr, gotDepErrs)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package collect
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// SortJSONResults rewrites the JSON files of the result with their object keys sorted, and with
+// the Kubernetes objects of lists sorted by namespace and name, as the API does not return them in
+// a stable order. Lists are either top level arrays or the items of a top level object. Files that
+// are not valid JSON, and symlinks, are left as they are.
+func (r CollectorResult) SortJSONResults(bundlePath string) error {
+	for relativePath := range r {
+		if filepath.Ext(relativePath) != ".json" {
+			continue
+		}
+
+		if bundlePath != "" {
+			info, err := os.Lstat(filepath.Join(bundlePath, relativePath))
+			if err != nil {
+				return errors.Wrapf(err, "failed to stat %s", relativePath)
+			}
+			if info.Mode().Type() == os.ModeSymlink {
+				continue
+			}
+		}
+
+		data, err := r.readResult(bundlePath, relativePath)
+		if err != nil {
+			return err
+		}
+
+		sorted, ok := sortJSON(data)
+		if !ok {
+			klog.V(2).Infof("Not sorting %s, it is not valid JSON", relativePath)
+			continue
+		}
+		if bytes.Equal(sorted, data) {
+			continue
+		}
+
+		if err := r.ReplaceResult(bundlePath, relativePath, bytes.NewReader(sorted)); err != nil {
+			return errors.Wrapf(err, "failed to replace %s", relativePath)
+		}
+	}
+	return nil
+}
+
+func (r CollectorResult) readResult(bundlePath string, relativePath string) ([]byte, error) {
+	if bundlePath == "" {
+		return r[relativePath], nil
+	}
+	data, err := os.ReadFile(filepath.Join(bundlePath, relativePath))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", relativePath)
+	}
+	return data, nil
+}
+
+// sortJSON returns the document indented like the collectors write it, with sorted keys and
+// lists of objects. It returns false when data is not JSON.
+func sortJSON(data []byte) ([]byte, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// numbers are kept as written instead of being converted to floats
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, false
+	}
+	if decoder.More() {
+		// e.g. JSON lines
+		return nil, false
+	}
+
+	switch doc := doc.(type) {
+	case []interface{}:
+		sortObjects(doc)
+	case map[string]interface{}:
+		if items, ok := doc["items"].([]interface{}); ok {
+			sortObjects(items)
+		}
+	}
+
+	// maps are marshalled with sorted keys
+	sorted, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, false
+	}
+	return sorted, true
+}
+
+// sortObjects sorts a list of Kubernetes objects by namespace and name. Lists with other elements
+// are left in their order.
+func sortObjects(list []interface{}) {
+	keys := make([]string, len(list))
+	for i, element := range list {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			return
+		}
+		metadata, ok := object["metadata"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		name, ok := metadata["name"].(string)
+		if !ok {
+			return
+		}
+		namespace, _ := metadata["namespace"].(string)
+		// "/" can't be in names, so the namespace is always compared first
+		keys[i] = strings.Join([]string{namespace, name}, "/")
+	}
+
+	indexes := make([]int, len(list))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return keys[indexes[i]] < keys[indexes[j]]
+	})
+
+	sorted := make([]interface{}, len(list))
+	for i, index := range indexes {
+		sorted[i] = list[index]
+	}
+	copy(list, sorted)
+}
@@ -0,0 +1,169 @@
+package collect
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sortJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{
+			name: "items of a list",
+			input: `{"kind": "PodList", "items": [
+				{"metadata": {"name": "b", "namespace": "default"}, "spec": {"priority": 1000000000}},
+				{"metadata": {"namespace": "default", "name": "a"}}
+			]}`,
+			want: `{
+  "items": [
+    {
+      "metadata": {
+        "name": "a",
+        "namespace": "default"
+      }
+    },
+    {
+      "metadata": {
+        "name": "b",
+        "namespace": "default"
+      },
+      "spec": {
+        "priority": 1000000000
+      }
+    }
+  ],
+  "kind": "PodList"
+}`,
+			wantOK: true,
+		},
+		{
+			name: "array sorted by namespace first",
+			input: `[
+				{"metadata": {"name": "a", "namespace": "kube-system"}},
+				{"metadata": {"name": "z", "namespace": "default"}},
+				{"metadata": {"name": "node-1"}}
+			]`,
+			want: `[
+  {
+    "metadata": {
+      "name": "node-1"
+    }
+  },
+  {
+    "metadata": {
+      "name": "z",
+      "namespace": "default"
+    }
+  },
+  {
+    "metadata": {
+      "name": "a",
+      "namespace": "kube-system"
+    }
+  }
+]`,
+			wantOK: true,
+		},
+		{
+			name:   "other arrays keep their order",
+			input:  `{"errors": ["b", "a"], "items": [{"name": "b"}, {"name": "a"}]}`,
+			want:   "{\n  \"errors\": [\n    \"b\",\n    \"a\"\n  ],\n  \"items\": [\n    {\n      \"name\": \"b\"\n    },\n    {\n      \"name\": \"a\"\n    }\n  ]\n}",
+			wantOK: true,
+		},
+		{
+			name:  "json lines",
+			input: "{\"b\": 1}\n{\"a\": 1}\n",
+		},
+		{
+			name:  "not json",
+			input: "not json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sortJSON([]byte(tt.input))
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestCollectorResult_DeterministicArchive(t *testing.T) {
+	writeBundle := func(modTime time.Time, files map[string]string) []byte {
+		// the bundle directory is named after the time the collection started
+		bundleDir := filepath.Join(t.TempDir(), "support-bundle-"+modTime.Format("2006-01-02T15_04_05"))
+		result := NewResult()
+		for _, name := range []string{"b.json", "a/c.txt", "a/b.json"} {
+			require.NoError(t, result.SaveResult(bundleDir, name, bytes.NewBufferString(files[name])))
+			require.NoError(t, os.Chtimes(filepath.Join(bundleDir, name), modTime, modTime))
+		}
+		require.NoError(t, result.SymLinkResult(bundleDir, "link.json", "b.json"))
+		require.NoError(t, result.SortJSONResults(bundleDir))
+
+		var archive bytes.Buffer
+		require.NoError(t, result.WriteArchiveWithOptions(&archive, bundleDir, ArchiveOptions{Deterministic: true}))
+		return archive.Bytes()
+	}
+
+	first := writeBundle(time.Now(), map[string]string{
+		"b.json":   `[{"metadata": {"name": "y"}}, {"metadata": {"name": "x"}}]`,
+		"a/c.txt":  "text",
+		"a/b.json": `{"b": 1, "a": 2}`,
+	})
+	second := writeBundle(time.Now().Add(time.Hour), map[string]string{
+		"b.json":   `[{"metadata": {"name": "x"}}, {"metadata": {"name": "y"}}]`,
+		"a/c.txt":  "text",
+		"a/b.json": `{"a": 2, "b": 1}`,
+	})
+
+	assert.Equal(t, first, second)
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(first))
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	names := []string{}
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+	assert.Equal(t, []string{
+		"support-bundle/a/b.json",
+		"support-bundle/a/c.txt",
+		"support-bundle/b.json",
+		"support-bundle/link.json",
+	}, names)
+}
+
+func TestCollectorResult_SortJSONResultsKeepsSymlinks(t *testing.T) {
+	bundleDir := t.TempDir()
+	result := NewResult()
+	require.NoError(t, result.SaveResult(bundleDir, "pods.json", bytes.NewBufferString(`{"b": 1, "a": 2}`)))
+	require.NoError(t, result.SymLinkResult(bundleDir, "link.json", "pods.json"))
+
+	require.NoError(t, result.SortJSONResults(bundleDir))
+
+	info, err := os.Lstat(filepath.Join(bundleDir, "link.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSymlink, info.Mode().Type())
+
+	data, err := os.ReadFile(filepath.Join(bundleDir, "link.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": 2,\n  \"b\": 1\n}", string(data))
+}
@@ -36,7 +36,7 @@ func (c *CollectExec) IsExcluded() (bool, error) {
 
 func (c *CollectExec) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
 	if c.Collector.Timeout == "" {
-		return execWithoutTimeout(c.ClientConfig, c.BundlePath, c.Collector)
+		return execWithoutTimeout(collectorContext(c.Context), c.ClientConfig, c.BundlePath, c.Collector)
 	}
 
 	timeout, err := time.ParseDuration(c.Collector.Timeout)
@@ -49,7 +49,7 @@ func (c *CollectExec) Collect(progressChan chan<- interface{}) (CollectorResult,
 
 	// TODO: Use a context with timeout instead of a goroutine
 	go func() {
-		b, err := execWithoutTimeout(c.ClientConfig, c.BundlePath, c.Collector)
+		b, err := execWithoutTimeout(collectorContext(c.Context), c.ClientConfig, c.BundlePath, c.Collector)
 		if err != nil {
 			errCh <- err
 		} else {
@@ -67,7 +67,7 @@ func (c *CollectExec) Collect(progressChan chan<- interface{}) (CollectorResult,
 	}
 }
 
-func execWithoutTimeout(clientConfig *rest.Config, bundlePath string, execCollector *troubleshootv1beta2.Exec) (CollectorResult, error) {
+func execWithoutTimeout(ctx context.Context, clientConfig *rest.Config, bundlePath string, execCollector *troubleshootv1beta2.Exec) (CollectorResult, error) {
 	client, err := kubernetes.NewForConfig(clientConfig)
 	if err != nil {
 		return nil, err
@@ -75,8 +75,6 @@ func execWithoutTimeout(clientConfig *rest.Config, bundlePath string, execCollec
 
 	output := NewResult()
 
-	ctx := context.Background()
-
 	pods, podsErrors := listPodsInSelectors(ctx, client, execCollector.Namespace, execCollector.Selector)
 	if len(podsErrors) > 0 {
 		output.SaveResult(bundlePath, getExecErrorsFileName(execCollector), marshalErrors(podsErrors))
@@ -0,0 +1,268 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// maxFocusOwnerDepth stops following owner references that loop back on themselves
+const maxFocusOwnerDepth = 10
+
+type CollectFocus struct {
+	Collector    *troubleshootv1beta2.Focus
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+// focusGraph is the focused object and the objects related to it
+type focusGraph struct {
+	Object metav1.Object
+	// Owners is the chain of controllers from the object's immediate owner up
+	Owners []metav1.Object
+	// Children are the intermediate controllers between the object and its pods, the ReplicaSets
+	// of a Deployment or the Jobs of a CronJob
+	Children []metav1.Object
+	Pods     []corev1.Pod
+	PVCs     []corev1.PersistentVolumeClaim
+	Events   []corev1.Event
+}
+
+func (c *CollectFocus) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectFocus) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectFocus) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	if c.Collector.Kind == "" || c.Collector.Name == "" {
+		return nil, errors.New("focus collector requires a kind and a name")
+	}
+
+	namespace := c.Collector.Namespace
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+
+	output := NewResult()
+	dir := path.Join(constants.FOCUS_DIR, strings.ToLower(c.Collector.Kind), c.Collector.Name)
+
+	graph, errs := buildFocusGraph(c.Context, c.Client, c.Collector.Kind, namespace, c.Collector.Name)
+	if graph != nil {
+		files := map[string]interface{}{
+			"object.json":   graph.Object,
+			"owners.json":   graph.Owners,
+			"children.json": graph.Children,
+			"pods.json":     graph.Pods,
+			"pvcs.json":     graph.PVCs,
+			"events.json":   graph.Events,
+		}
+		for fileName, objects := range files {
+			b, err := json.MarshalIndent(objects, "", "  ")
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to marshal %s", fileName).Error())
+				continue
+			}
+			output.SaveResult(c.BundlePath, path.Join(dir, fileName), bytes.NewBuffer(b))
+		}
+	}
+	output.SaveResult(c.BundlePath, path.Join(dir, "errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// buildFocusGraph gets the named object and walks to the objects related to it. The graph is nil
+// when the object itself could not be read, related objects that could not be read are reported
+// as errors and left out.
+func buildFocusGraph(ctx context.Context, client kubernetes.Interface, kind, namespace, name string) (*focusGraph, []string) {
+	object, err := getFocusObject(ctx, client, kind, namespace, name)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	graph := &focusGraph{
+		Object:   object,
+		Owners:   []metav1.Object{},
+		Children: []metav1.Object{},
+		Pods:     []corev1.Pod{},
+		PVCs:     []corev1.PersistentVolumeClaim{},
+		Events:   []corev1.Event{},
+	}
+	errs := []string{}
+
+	current := object
+	for i := 0; i < maxFocusOwnerDepth; i++ {
+		ref := metav1.GetControllerOf(current)
+		if ref == nil {
+			break
+		}
+		owner, err := getFocusObject(ctx, client, ref.Kind, namespace, ref.Name)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to get owner of %s", current.GetName()).Error())
+			break
+		}
+		graph.Owners = append(graph.Owners, owner)
+		current = owner
+	}
+
+	// pods are controlled by the object itself or by one of its children
+	controllers := map[types.UID]bool{object.GetUID(): true}
+	switch strings.ToLower(kind) {
+	case "deployment":
+		replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to list replicasets").Error())
+			break
+		}
+		for i := range replicaSets.Items {
+			replicaSet := &replicaSets.Items[i]
+			if metav1.IsControlledBy(replicaSet, object) {
+				replicaSet.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))
+				graph.Children = append(graph.Children, replicaSet)
+				controllers[replicaSet.UID] = true
+			}
+		}
+	case "cronjob":
+		jobs, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to list jobs").Error())
+			break
+		}
+		for i := range jobs.Items {
+			job := &jobs.Items[i]
+			if metav1.IsControlledBy(job, object) {
+				job.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("Job"))
+				graph.Children = append(graph.Children, job)
+				controllers[job.UID] = true
+			}
+		}
+	}
+
+	if pod, ok := object.(*corev1.Pod); ok {
+		graph.Pods = append(graph.Pods, *pod)
+	} else {
+		pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to list pods").Error())
+		} else {
+			for _, pod := range pods.Items {
+				if ref := metav1.GetControllerOf(&pod); ref != nil && controllers[ref.UID] {
+					pod.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+					graph.Pods = append(graph.Pods, pod)
+				}
+			}
+		}
+	}
+
+	claimNames := []string{}
+	seenClaims := map[string]bool{}
+	for _, pod := range graph.Pods {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil || seenClaims[volume.PersistentVolumeClaim.ClaimName] {
+				continue
+			}
+			seenClaims[volume.PersistentVolumeClaim.ClaimName] = true
+			claimNames = append(claimNames, volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	for _, claimName := range claimNames {
+		pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claimName, metav1.GetOptions{})
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to get persistentvolumeclaim %s", claimName).Error())
+			continue
+		}
+		pvc.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))
+		graph.PVCs = append(graph.PVCs, *pvc)
+	}
+
+	related := map[types.UID]bool{}
+	for _, o := range append(append([]metav1.Object{object}, graph.Owners...), graph.Children...) {
+		related[o.GetUID()] = true
+	}
+	for _, pod := range graph.Pods {
+		related[pod.UID] = true
+	}
+	for _, pvc := range graph.PVCs {
+		related[pvc.UID] = true
+	}
+
+	events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		errs = append(errs, errors.Wrap(err, "failed to list events").Error())
+	} else {
+		for _, event := range events.Items {
+			if event.InvolvedObject.UID != "" && related[event.InvolvedObject.UID] {
+				graph.Events = append(graph.Events, event)
+			}
+		}
+	}
+
+	return graph, errs
+}
+
+// getFocusObject gets an object of one of the kinds the focus collector supports, with its kind
+// and api version set so they are kept when the object is saved
+func getFocusObject(ctx context.Context, client kubernetes.Interface, kind, namespace, name string) (metav1.Object, error) {
+	var object interface {
+		metav1.Object
+		SetGroupVersionKind(gvk schema.GroupVersionKind)
+	}
+	var err error
+	var gvk schema.GroupVersionKind
+
+	switch strings.ToLower(kind) {
+	case "pod":
+		object, err = client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		gvk = corev1.SchemeGroupVersion.WithKind("Pod")
+	case "deployment":
+		object, err = client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		gvk = appsv1.SchemeGroupVersion.WithKind("Deployment")
+	case "replicaset":
+		object, err = client.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		gvk = appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	case "statefulset":
+		object, err = client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		gvk = appsv1.SchemeGroupVersion.WithKind("StatefulSet")
+	case "daemonset":
+		object, err = client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		gvk = appsv1.SchemeGroupVersion.WithKind("DaemonSet")
+	case "job":
+		object, err = client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		gvk = batchv1.SchemeGroupVersion.WithKind("Job")
+	case "cronjob":
+		object, err = client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		gvk = batchv1.SchemeGroupVersion.WithKind("CronJob")
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", kind)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s %s/%s", kind, namespace, name)
+	}
+
+	object.SetGroupVersionKind(gvk)
+	return object, nil
+}
@@ -0,0 +1,113 @@
+package collect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func Test_buildFocusGraph(t *testing.T) {
+	objectMeta := func(name, uid string, owner *metav1.OwnerReference) metav1.ObjectMeta {
+		meta := metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(uid)}
+		if owner != nil {
+			meta.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return meta
+	}
+	controller := func(kind, name, uid string) *metav1.OwnerReference {
+		return &metav1.OwnerReference{Kind: kind, Name: name, UID: types.UID(uid), Controller: ptr.To(true)}
+	}
+	event := func(name, kind, objectName, uid string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: objectName, Namespace: "default", UID: types.UID(uid)},
+		}
+	}
+
+	client := testclient.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: objectMeta("api", "deploy-api", nil)},
+		&appsv1.Deployment{ObjectMeta: objectMeta("worker", "deploy-worker", nil)},
+		&appsv1.ReplicaSet{ObjectMeta: objectMeta("api-6d4f", "rs-api-new", controller("Deployment", "api", "deploy-api"))},
+		&appsv1.ReplicaSet{ObjectMeta: objectMeta("api-5b9c", "rs-api-old", controller("Deployment", "api", "deploy-api"))},
+		&appsv1.ReplicaSet{ObjectMeta: objectMeta("worker-7f2a", "rs-worker", controller("Deployment", "worker", "deploy-worker"))},
+		&corev1.Pod{
+			ObjectMeta: objectMeta("api-6d4f-x1", "pod-api", controller("ReplicaSet", "api-6d4f", "rs-api-new")),
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "api-data"}}},
+					{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+				},
+			},
+		},
+		&corev1.Pod{ObjectMeta: objectMeta("worker-7f2a-y1", "pod-worker", controller("ReplicaSet", "worker-7f2a", "rs-worker"))},
+		&corev1.PersistentVolumeClaim{ObjectMeta: objectMeta("api-data", "pvc-api", nil)},
+		&corev1.PersistentVolumeClaim{ObjectMeta: objectMeta("worker-data", "pvc-worker", nil)},
+		event("api.1", "Deployment", "api", "deploy-api"),
+		event("api-6d4f.1", "ReplicaSet", "api-6d4f", "rs-api-new"),
+		event("api-6d4f-x1.1", "Pod", "api-6d4f-x1", "pod-api"),
+		event("api-data.1", "PersistentVolumeClaim", "api-data", "pvc-api"),
+		event("worker-7f2a-y1.1", "Pod", "worker-7f2a-y1", "pod-worker"),
+	)
+
+	names := func(objects []metav1.Object) []string {
+		result := []string{}
+		for _, o := range objects {
+			result = append(result, o.GetName())
+		}
+		return result
+	}
+
+	t.Run("deployment", func(t *testing.T) {
+		graph, errs := buildFocusGraph(context.Background(), client, "Deployment", "default", "api")
+		require.Empty(t, errs)
+		require.NotNil(t, graph)
+
+		assert.Equal(t, "api", graph.Object.GetName())
+		assert.Equal(t, "Deployment", graph.Object.(*appsv1.Deployment).Kind)
+		assert.Empty(t, graph.Owners)
+		assert.ElementsMatch(t, []string{"api-6d4f", "api-5b9c"}, names(graph.Children))
+
+		require.Len(t, graph.Pods, 1)
+		assert.Equal(t, "api-6d4f-x1", graph.Pods[0].Name)
+		require.Len(t, graph.PVCs, 1)
+		assert.Equal(t, "api-data", graph.PVCs[0].Name)
+
+		events := []string{}
+		for _, e := range graph.Events {
+			events = append(events, e.Name)
+		}
+		assert.ElementsMatch(t, []string{"api.1", "api-6d4f.1", "api-6d4f-x1.1", "api-data.1"}, events)
+	})
+
+	t.Run("pod owner chain", func(t *testing.T) {
+		graph, errs := buildFocusGraph(context.Background(), client, "pod", "default", "api-6d4f-x1")
+		require.Empty(t, errs)
+		require.NotNil(t, graph)
+
+		assert.Equal(t, []string{"api-6d4f", "api"}, names(graph.Owners))
+		assert.Empty(t, graph.Children)
+		require.Len(t, graph.Pods, 1)
+		assert.Len(t, graph.Events, 4)
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		graph, errs := buildFocusGraph(context.Background(), client, "StatefulSet", "default", "db")
+		assert.Nil(t, graph)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0], "failed to get StatefulSet default/db")
+	})
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		_, errs := buildFocusGraph(context.Background(), client, "Service", "default", "api")
+		require.Len(t, errs, 1)
+		assert.Equal(t, "unsupported kind Service", errs[0])
+	})
+}
@@ -0,0 +1,169 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+type CollectGitOps struct {
+	Collector    *troubleshootv1beta2.GitOps
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+// gitOpsResource is a GitOps controller custom resource that describes reconciliation state
+type gitOpsResource struct {
+	Group    string
+	Resource string
+}
+
+var gitOpsResources = []gitOpsResource{
+	{Group: "kustomize.toolkit.fluxcd.io", Resource: "kustomizations"},
+	{Group: "helm.toolkit.fluxcd.io", Resource: "helmreleases"},
+	{Group: "argoproj.io", Resource: "applications"},
+}
+
+func (c *CollectGitOps) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectGitOps) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectGitOps) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	dynamicClient, err := dynamic.NewForConfig(c.ClientConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create dynamic client")
+	}
+
+	namespaces := c.Collector.Namespaces
+	if len(namespaces) == 0 && c.Namespace != "" {
+		namespaces = []string{c.Namespace}
+	}
+
+	files, errs := gitOps(c.Context, c.Client.Discovery(), dynamicClient, namespaces)
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(constants.GITOPS_DIR, fileName), bytes.NewBuffer(data))
+	}
+	output.SaveResult(c.BundlePath, path.Join(constants.GITOPS_DIR, "errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// gitOps lists the Flux and Argo CD custom resources served by the cluster and returns them
+// as JSON arrays keyed by "<resource>.<group>/<namespace>.json". Nothing is returned when
+// neither controller's API group is installed.
+func gitOps(ctx context.Context, dc discovery.DiscoveryInterface, client dynamic.Interface, namespaces []string) (map[string][]byte, []string) {
+	groups, err := dc.ServerGroups()
+	if err != nil {
+		return nil, []string{errors.Wrap(err, "failed to list api groups").Error()}
+	}
+
+	preferredVersions := map[string]string{}
+	for _, group := range groups.Groups {
+		preferredVersions[group.Name] = group.PreferredVersion.Version
+	}
+
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	files := map[string][]byte{}
+	errorList := []string{}
+	detected := false
+
+	for _, r := range gitOpsResources {
+		version, ok := preferredVersions[r.Group]
+		if !ok {
+			continue
+		}
+
+		// argoproj.io is shared with other Argo projects, so check the resource itself is served
+		served, err := isResourceServed(dc, schema.GroupVersion{Group: r.Group, Version: version}, r.Resource)
+		if err != nil {
+			errorList = append(errorList, err.Error())
+			continue
+		}
+		if !served {
+			continue
+		}
+		detected = true
+
+		gvr := schema.GroupVersionResource{Group: r.Group, Version: version, Resource: r.Resource}
+		resourceFiles, resourceErrs := listCustomResourcesByNamespace(ctx, client, gvr, namespaces)
+		for fileName, data := range resourceFiles {
+			files[fileName] = data
+		}
+		errorList = append(errorList, resourceErrs...)
+	}
+
+	if !detected {
+		klog.V(2).Info("neither flux nor argo cd api groups were found, skipping gitops collection")
+	}
+
+	return files, errorList
+}
+
+// listCustomResourcesByNamespace lists the objects of a resource in each of the namespaces and
+// returns them as JSON arrays keyed by "<resource>.<group>/<namespace>.json"
+func listCustomResourcesByNamespace(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, namespaces []string) (map[string][]byte, []string) {
+	files := map[string][]byte{}
+	errorList := []string{}
+
+	objectsByNamespace := map[string][]map[string]interface{}{}
+	for _, namespace := range namespaces {
+		list, err := client.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errorList = append(errorList, errors.Wrapf(err, "failed to list %s", gvr.GroupResource()).Error())
+			continue
+		}
+		for _, item := range list.Items {
+			objectsByNamespace[item.GetNamespace()] = append(objectsByNamespace[item.GetNamespace()], item.Object)
+		}
+	}
+
+	for namespace, objects := range objectsByNamespace {
+		b, err := json.MarshalIndent(objects, "", "  ")
+		if err != nil {
+			errorList = append(errorList, errors.Wrapf(err, "failed to marshal %s", gvr.GroupResource()).Error())
+			continue
+		}
+		files[fmt.Sprintf("%s/%s.json", gvr.GroupResource(), namespace)] = b
+	}
+
+	return files, errorList
+}
+
+func isResourceServed(dc discovery.DiscoveryInterface, gv schema.GroupVersion, resource string) (bool, error) {
+	resources, err := dc.ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list resources for %s", gv)
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
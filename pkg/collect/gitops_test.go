@@ -0,0 +1,109 @@
+package collect
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	testdynamicclient "k8s.io/client-go/dynamic/fake"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_gitOps(t *testing.T) {
+	helmRelease := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "helm.toolkit.fluxcd.io/v2",
+		"kind":       "HelmRelease",
+		"metadata": map[string]interface{}{
+			"name":      "podinfo",
+			"namespace": "flux-system",
+		},
+	}}
+	application := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata": map[string]interface{}{
+			"name":      "guestbook",
+			"namespace": "argocd",
+		},
+	}}
+
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: "helm.toolkit.fluxcd.io", Version: "v2", Resource: "helmreleases"}: "HelmReleaseList",
+		{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}:      "ApplicationList",
+	}
+
+	tests := []struct {
+		name       string
+		resources  []*metav1.APIResourceList
+		namespaces []string
+		wantFiles  []string
+	}{
+		{
+			name:      "no gitops controllers",
+			resources: []*metav1.APIResourceList{},
+			wantFiles: []string{},
+		},
+		{
+			name: "argo rollouts without argo cd",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "argoproj.io/v1alpha1", APIResources: []metav1.APIResource{{Name: "rollouts", Kind: "Rollout", Namespaced: true}}},
+			},
+			wantFiles: []string{},
+		},
+		{
+			name: "flux and argo cd",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "helm.toolkit.fluxcd.io/v2", APIResources: []metav1.APIResource{{Name: "helmreleases", Kind: "HelmRelease", Namespaced: true}}},
+				{GroupVersion: "argoproj.io/v1alpha1", APIResources: []metav1.APIResource{{Name: "applications", Kind: "Application", Namespaced: true}}},
+			},
+			wantFiles: []string{
+				"helmreleases.helm.toolkit.fluxcd.io/flux-system.json",
+				"applications.argoproj.io/argocd.json",
+			},
+		},
+		{
+			name: "only selected namespaces",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "helm.toolkit.fluxcd.io/v2", APIResources: []metav1.APIResource{{Name: "helmreleases", Kind: "HelmRelease", Namespaced: true}}},
+				{GroupVersion: "argoproj.io/v1alpha1", APIResources: []metav1.APIResource{{Name: "applications", Kind: "Application", Namespaced: true}}},
+			},
+			namespaces: []string{"argocd"},
+			wantFiles: []string{
+				"applications.argoproj.io/argocd.json",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testclient.NewSimpleClientset()
+			fakeDiscovery, ok := client.Discovery().(*fakediscovery.FakeDiscovery)
+			require.True(t, ok)
+			fakeDiscovery.Resources = tt.resources
+
+			dynamicClient := testdynamicclient.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, helmRelease, application)
+
+			files, errs := gitOps(context.Background(), fakeDiscovery, dynamicClient, tt.namespaces)
+			assert.Empty(t, errs)
+
+			fileNames := []string{}
+			for fileName := range files {
+				fileNames = append(fileNames, fileName)
+			}
+			assert.ElementsMatch(t, tt.wantFiles, fileNames)
+
+			for _, data := range files {
+				objects := []map[string]interface{}{}
+				require.NoError(t, json.Unmarshal(data, &objects))
+				assert.Len(t, objects, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type CollectHelmReleases struct {
+	Collector    *troubleshootv1beta2.HelmReleases
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+// HelmRelease is the latest revision of a Helm release, with the revisions kept in its history
+type HelmRelease struct {
+	Name          string                 `json:"name"`
+	Namespace     string                 `json:"namespace"`
+	Storage       string                 `json:"storage"`
+	Revision      int                    `json:"revision"`
+	Status        string                 `json:"status,omitempty"`
+	Description   string                 `json:"description,omitempty"`
+	FirstDeployed string                 `json:"firstDeployed,omitempty"`
+	LastDeployed  string                 `json:"lastDeployed,omitempty"`
+	Chart         string                 `json:"chart,omitempty"`
+	ChartVersion  string                 `json:"chartVersion,omitempty"`
+	AppVersion    string                 `json:"appVersion,omitempty"`
+	Values        map[string]interface{} `json:"values,omitempty"`
+	History       []HelmReleaseRevision  `json:"history"`
+}
+
+// HelmReleaseRevision is a revision of a Helm release, as listed by `helm history`
+type HelmReleaseRevision struct {
+	Revision     int    `json:"revision"`
+	Updated      string `json:"updated,omitempty"`
+	Status       string `json:"status,omitempty"`
+	ChartVersion string `json:"chartVersion,omitempty"`
+	AppVersion   string `json:"appVersion,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+func (c *CollectHelmReleases) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectHelmReleases) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+// Collect decodes the releases from the Secrets and ConfigMaps Helm stores them in, so neither
+// the Helm CLI nor a kubeconfig for it is needed. Each release is saved under
+// helm/<namespace>/<release>.json and the namespaces that could not be read are listed in
+// helm/releases-errors.json.
+func (c *CollectHelmReleases) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	namespaces := c.Collector.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{c.Namespace}
+	}
+
+	releases, errs := collectHelmReleases(c.Client, namespaces, c.Collector.ReleaseNames, !c.Collector.OmitValues)
+	for _, helmRelease := range releases {
+		b, err := json.MarshalIndent(helmRelease, "", "  ")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal helm release %s/%s", helmRelease.Namespace, helmRelease.Name)
+		}
+		fileName := path.Join(constants.HELM_RELEASES_DIR, helmRelease.Namespace, fmt.Sprintf("%s.json", helmRelease.Name))
+		output.SaveResult(c.BundlePath, fileName, bytes.NewBuffer(b))
+	}
+	if len(errs) > 0 {
+		output.SaveResult(c.BundlePath, path.Join(constants.HELM_RELEASES_DIR, "releases-errors.json"), marshalErrors(errs))
+	}
+
+	return output, nil
+}
+
+// collectHelmReleases returns the releases stored in the namespaces, sorted by namespace and name.
+// An empty namespace lists the releases of all namespaces.
+func collectHelmReleases(client kubernetes.Interface, namespaces []string, releaseNames []string, withValues bool) ([]HelmRelease, []string) {
+	errs := []string{}
+	revisions := map[string][]*release.Release{}
+	storage := map[string]string{}
+
+	for _, namespace := range namespaces {
+		drivers := []driver.Driver{
+			driver.NewSecrets(client.CoreV1().Secrets(namespace)),
+			driver.NewConfigMaps(client.CoreV1().ConfigMaps(namespace)),
+		}
+		for _, d := range drivers {
+			found, err := d.List(func(r *release.Release) bool {
+				return len(releaseNames) == 0 || slices.Contains(releaseNames, r.Name)
+			})
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to list helm releases in %s storage of namespace %q", d.Name(), namespace).Error())
+				continue
+			}
+			for _, r := range found {
+				key := fmt.Sprintf("%s/%s", r.Namespace, r.Name)
+				revisions[key] = append(revisions[key], r)
+				storage[key] = d.Name()
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(revisions))
+	for key := range revisions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	releases := make([]HelmRelease, 0, len(keys))
+	for _, key := range keys {
+		releases = append(releases, helmReleaseFromRevisions(revisions[key], storage[key], withValues))
+	}
+	return releases, errs
+}
+
+// helmReleaseFromRevisions returns the latest of the revisions of a release, with all revisions
+// as its history
+func helmReleaseFromRevisions(revisions []*release.Release, storage string, withValues bool) HelmRelease {
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Version < revisions[j].Version
+	})
+	latest := revisions[len(revisions)-1]
+
+	helmRelease := HelmRelease{
+		Name:      latest.Name,
+		Namespace: latest.Namespace,
+		Storage:   storage,
+		Revision:  latest.Version,
+		History:   make([]HelmReleaseRevision, 0, len(revisions)),
+	}
+	if latest.Info != nil {
+		helmRelease.Status = latest.Info.Status.String()
+		helmRelease.Description = latest.Info.Description
+		helmRelease.FirstDeployed = formatHelmTime(latest.Info.FirstDeployed.Time)
+		helmRelease.LastDeployed = formatHelmTime(latest.Info.LastDeployed.Time)
+	}
+	if latest.Chart != nil && latest.Chart.Metadata != nil {
+		helmRelease.Chart = latest.Chart.Metadata.Name
+		helmRelease.ChartVersion = latest.Chart.Metadata.Version
+		helmRelease.AppVersion = latest.Chart.Metadata.AppVersion
+	}
+	if withValues {
+		// only the values set by the user, as `helm get values` shows without --all
+		helmRelease.Values = latest.Config
+	}
+
+	for _, r := range revisions {
+		revision := HelmReleaseRevision{Revision: r.Version}
+		if r.Info != nil {
+			revision.Updated = formatHelmTime(r.Info.LastDeployed.Time)
+			revision.Status = r.Info.Status.String()
+			revision.Description = r.Info.Description
+		}
+		if r.Chart != nil && r.Chart.Metadata != nil {
+			revision.ChartVersion = r.Chart.Metadata.Version
+			revision.AppVersion = r.Chart.Metadata.AppVersion
+		}
+		helmRelease.History = append(helmRelease.History, revision)
+	}
+
+	return helmRelease
+}
+
+func formatHelmTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,124 @@
+package collect
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	helmtime "helm.sh/helm/v3/pkg/time"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_collectHelmReleases(t *testing.T) {
+	deployed := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	newRelease := func(name, namespace string, revision int, status release.Status, chartVersion string) *release.Release {
+		return &release.Release{
+			Name:      name,
+			Namespace: namespace,
+			Version:   revision,
+			Info: &release.Info{
+				FirstDeployed: helmtime.Time{Time: deployed},
+				LastDeployed:  helmtime.Time{Time: deployed.Add(time.Duration(revision) * time.Hour)},
+				Status:        status,
+				Description:   fmt.Sprintf("revision %d", revision),
+			},
+			Chart: &chart.Chart{
+				Metadata: &chart.Metadata{Name: name, Version: chartVersion, AppVersion: "1.0.0"},
+				Values:   map[string]interface{}{"replicaCount": 1},
+			},
+			Config: map[string]interface{}{"replicaCount": 3},
+		}
+	}
+
+	client := testclient.NewSimpleClientset()
+	secrets := driver.NewSecrets(client.CoreV1().Secrets("default"))
+	require.NoError(t, secrets.Create("sh.helm.release.v1.api.v1", newRelease("api", "default", 1, release.StatusSuperseded, "0.1.0")))
+	require.NoError(t, secrets.Create("sh.helm.release.v1.api.v2", newRelease("api", "default", 2, release.StatusDeployed, "0.2.0")))
+	configMaps := driver.NewConfigMaps(client.CoreV1().ConfigMaps("monitoring"))
+	require.NoError(t, configMaps.Create("prometheus.v1", newRelease("prometheus", "monitoring", 1, release.StatusFailed, "25.0.0")))
+
+	api := HelmRelease{
+		Name:          "api",
+		Namespace:     "default",
+		Storage:       driver.SecretsDriverName,
+		Revision:      2,
+		Status:        "deployed",
+		Description:   "revision 2",
+		FirstDeployed: "2024-05-01T12:00:00Z",
+		LastDeployed:  "2024-05-01T14:00:00Z",
+		Chart:         "api",
+		ChartVersion:  "0.2.0",
+		AppVersion:    "1.0.0",
+		Values:        map[string]interface{}{"replicaCount": float64(3)},
+		History: []HelmReleaseRevision{
+			{Revision: 1, Updated: "2024-05-01T13:00:00Z", Status: "superseded", ChartVersion: "0.1.0", AppVersion: "1.0.0", Description: "revision 1"},
+			{Revision: 2, Updated: "2024-05-01T14:00:00Z", Status: "deployed", ChartVersion: "0.2.0", AppVersion: "1.0.0", Description: "revision 2"},
+		},
+	}
+	prometheus := HelmRelease{
+		Name:          "prometheus",
+		Namespace:     "monitoring",
+		Storage:       driver.ConfigMapsDriverName,
+		Revision:      1,
+		Status:        "failed",
+		Description:   "revision 1",
+		FirstDeployed: "2024-05-01T12:00:00Z",
+		LastDeployed:  "2024-05-01T13:00:00Z",
+		Chart:         "prometheus",
+		ChartVersion:  "25.0.0",
+		AppVersion:    "1.0.0",
+		Values:        map[string]interface{}{"replicaCount": float64(3)},
+		History: []HelmReleaseRevision{
+			{Revision: 1, Updated: "2024-05-01T13:00:00Z", Status: "failed", ChartVersion: "25.0.0", AppVersion: "1.0.0", Description: "revision 1"},
+		},
+	}
+	withoutValues := func(r HelmRelease) HelmRelease {
+		r.Values = nil
+		return r
+	}
+
+	tests := []struct {
+		name         string
+		namespaces   []string
+		releaseNames []string
+		withValues   bool
+		want         []HelmRelease
+	}{
+		{
+			name:       "all namespaces",
+			namespaces: []string{""},
+			withValues: true,
+			want:       []HelmRelease{api, prometheus},
+		},
+		{
+			name:       "selected namespace",
+			namespaces: []string{"monitoring"},
+			withValues: true,
+			want:       []HelmRelease{prometheus},
+		},
+		{
+			name:         "selected release",
+			namespaces:   []string{""},
+			releaseNames: []string{"api"},
+			withValues:   true,
+			want:         []HelmRelease{api},
+		},
+		{
+			name:       "without values",
+			namespaces: []string{"default"},
+			want:       []HelmRelease{withoutValues(api)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := collectHelmReleases(client, tt.namespaces, tt.releaseNames, tt.withValues)
+			assert.Empty(t, errs)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -103,6 +103,14 @@ func GetHostCollector(collector *troubleshootv1beta2.HostCollect, bundlePath str
 		return &CollectHostNetworkNamespaceConnectivity{collector.NetworkNamespaceConnectivity, bundlePath}, true
 	case collector.HostSysctl != nil:
 		return &CollectHostSysctl{collector.HostSysctl, bundlePath}, true
+	case collector.HostMTU != nil:
+		return &CollectHostMTU{collector.HostMTU, bundlePath}, true
+	case collector.HostSystemd != nil:
+		return &CollectHostSystemd{collector.HostSystemd, bundlePath}, true
+	case collector.HostDmesg != nil:
+		return &CollectHostDmesg{collector.HostDmesg, bundlePath}, true
+	case collector.HostCRI != nil:
+		return &CollectHostCRI{collector.HostCRI, bundlePath}, true
 	default:
 		return nil, false
 	}
@@ -0,0 +1,249 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+	"k8s.io/klog/v2"
+)
+
+// Ensure `CollectHostCRI` implements `HostCollector` interface at compile time.
+var _ HostCollector = (*CollectHostCRI)(nil)
+
+const HostCRIPath = `host-collectors/cri/`
+
+const (
+	DefaultCRISocket  = "/run/containerd/containerd.sock"
+	defaultCRITimeout = 10 * time.Second
+)
+
+// CRIPod is a pod sandbox of the container runtime, in host-collectors/cri/pods.json
+type CRIPod struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	UID       string            `json:"uid"`
+	Attempt   uint32            `json:"attempt"`
+	State     string            `json:"state"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// CRIContainer is a container of the container runtime, in host-collectors/cri/containers.json
+type CRIContainer struct {
+	ID           string    `json:"id"`
+	PodID        string    `json:"podId"`
+	PodName      string    `json:"podName,omitempty"`
+	PodNamespace string    `json:"podNamespace,omitempty"`
+	Name         string    `json:"name"`
+	Attempt      uint32    `json:"attempt"`
+	Image        string    `json:"image"`
+	ImageRef     string    `json:"imageRef"`
+	State        string    `json:"state"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// CRIImage is an image of the container runtime, in host-collectors/cri/images.json
+type CRIImage struct {
+	ID          string   `json:"id"`
+	RepoTags    []string `json:"repoTags,omitempty"`
+	RepoDigests []string `json:"repoDigests,omitempty"`
+	Size        uint64   `json:"size"`
+	Pinned      bool     `json:"pinned,omitempty"`
+}
+
+// criClients connects to the CRI socket, it is a var to allow stubbing in tests
+var criClients = func(socket string) (runtimeapi.RuntimeServiceClient, runtimeapi.ImageServiceClient, func() error, error) {
+	conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "failed to connect to %s", socket)
+	}
+	return runtimeapi.NewRuntimeServiceClient(conn), runtimeapi.NewImageServiceClient(conn), conn.Close, nil
+}
+
+type CollectHostCRI struct {
+	hostCollector *troubleshootv1beta2.HostCRI
+	BundlePath    string
+}
+
+func (c *CollectHostCRI) Title() string {
+	return hostCollectorTitleOrDefault(c.hostCollector.HostCollectorMeta, "CRI")
+}
+
+func (c *CollectHostCRI) IsExcluded() (bool, error) {
+	return isExcluded(c.hostCollector.Exclude)
+}
+
+// Collect saves the pods, containers and images the container runtime reports through its CRI
+// socket to host-collectors/cri/pods.json, containers.json and images.json. They are the ground
+// truth of what runs on the host when the kubelet and the API server disagree. When the socket
+// does not exist or a list can not be retrieved, the errors are saved to
+// host-collectors/cri/errors.json instead of failing the collector.
+func (c *CollectHostCRI) Collect(progressChan chan<- interface{}) (map[string][]byte, error) {
+	socket := c.hostCollector.Socket
+	if socket == "" {
+		socket = DefaultCRISocket
+	}
+
+	timeout := defaultCRITimeout
+	if c.hostCollector.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(c.hostCollector.Timeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse timeout %q", c.hostCollector.Timeout)
+		}
+	}
+
+	output := NewResult()
+	errs := []string{}
+	save := func(fileName string, v interface{}) error {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal %s", fileName)
+		}
+		return output.SaveResult(c.BundlePath, filepath.Join(HostCRIPath, fileName), bytes.NewBuffer(b))
+	}
+
+	if _, err := os.Stat(socket); err != nil {
+		if os.IsNotExist(err) {
+			errs = append(errs, fmt.Sprintf("CRI socket %s not found, set socket to the CRI socket of the container runtime", socket))
+		} else {
+			errs = append(errs, fmt.Sprintf("failed to stat CRI socket %s: %v", socket, err))
+		}
+		if err := save("errors.json", errs); err != nil {
+			return nil, err
+		}
+		return output, nil
+	}
+
+	runtimeClient, imageClient, closeConn, err := criClients(socket)
+	if err != nil {
+		errs = append(errs, err.Error())
+		if err := save("errors.json", errs); err != nil {
+			return nil, err
+		}
+		return output, nil
+	}
+	defer closeConn()
+
+	pods, err := listCRIPods(runtimeClient, timeout)
+	if err != nil {
+		klog.V(2).Infof("failed to list CRI pods: %v", err)
+		errs = append(errs, fmt.Sprintf("failed to list pods: %v", err))
+	} else if err := save("pods.json", pods); err != nil {
+		return nil, err
+	}
+
+	containers, err := listCRIContainers(runtimeClient, timeout)
+	if err != nil {
+		klog.V(2).Infof("failed to list CRI containers: %v", err)
+		errs = append(errs, fmt.Sprintf("failed to list containers: %v", err))
+	} else if err := save("containers.json", containers); err != nil {
+		return nil, err
+	}
+
+	images, err := listCRIImages(imageClient, timeout)
+	if err != nil {
+		klog.V(2).Infof("failed to list CRI images: %v", err)
+		errs = append(errs, fmt.Sprintf("failed to list images: %v", err))
+	} else if err := save("images.json", images); err != nil {
+		return nil, err
+	}
+
+	if len(errs) > 0 {
+		if err := save("errors.json", errs); err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+func listCRIPods(client runtimeapi.RuntimeServiceClient, timeout time.Duration) ([]CRIPod, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.ListPodSandbox(ctx, &runtimeapi.ListPodSandboxRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]CRIPod, 0, len(resp.GetItems()))
+	for _, item := range resp.GetItems() {
+		pods = append(pods, CRIPod{
+			ID:        item.GetId(),
+			Name:      item.GetMetadata().GetName(),
+			Namespace: item.GetMetadata().GetNamespace(),
+			UID:       item.GetMetadata().GetUid(),
+			Attempt:   item.GetMetadata().GetAttempt(),
+			State:     item.GetState().String(),
+			CreatedAt: criTime(item.GetCreatedAt()),
+			Labels:    item.GetLabels(),
+		})
+	}
+	return pods, nil
+}
+
+func listCRIContainers(client runtimeapi.RuntimeServiceClient, timeout time.Duration) ([]CRIContainer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.ListContainers(ctx, &runtimeapi.ListContainersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]CRIContainer, 0, len(resp.GetContainers()))
+	for _, item := range resp.GetContainers() {
+		containers = append(containers, CRIContainer{
+			ID:           item.GetId(),
+			PodID:        item.GetPodSandboxId(),
+			PodName:      item.GetLabels()["io.kubernetes.pod.name"],
+			PodNamespace: item.GetLabels()["io.kubernetes.pod.namespace"],
+			Name:         item.GetMetadata().GetName(),
+			Attempt:      item.GetMetadata().GetAttempt(),
+			Image:        item.GetImage().GetImage(),
+			ImageRef:     item.GetImageRef(),
+			State:        item.GetState().String(),
+			CreatedAt:    criTime(item.GetCreatedAt()),
+		})
+	}
+	return containers, nil
+}
+
+func listCRIImages(client runtimeapi.ImageServiceClient, timeout time.Duration) ([]CRIImage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.ListImages(ctx, &runtimeapi.ListImagesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]CRIImage, 0, len(resp.GetImages()))
+	for _, item := range resp.GetImages() {
+		images = append(images, CRIImage{
+			ID:          item.GetId(),
+			RepoTags:    item.GetRepoTags(),
+			RepoDigests: item.GetRepoDigests(),
+			Size:        item.GetSize_(),
+			Pinned:      item.GetPinned(),
+		})
+	}
+	return images, nil
+}
+
+// criTime converts the nanoseconds since the epoch of CRI timestamps
+func criTime(nanos int64) time.Time {
+	return time.Unix(0, nanos).UTC()
+}
@@ -0,0 +1,154 @@
+package collect
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+type fakeCRIRuntimeClient struct {
+	runtimeapi.RuntimeServiceClient
+	pods       []*runtimeapi.PodSandbox
+	containers []*runtimeapi.Container
+	err        error
+}
+
+func (c *fakeCRIRuntimeClient) ListPodSandbox(ctx context.Context, in *runtimeapi.ListPodSandboxRequest, opts ...grpc.CallOption) (*runtimeapi.ListPodSandboxResponse, error) {
+	return &runtimeapi.ListPodSandboxResponse{Items: c.pods}, nil
+}
+
+func (c *fakeCRIRuntimeClient) ListContainers(ctx context.Context, in *runtimeapi.ListContainersRequest, opts ...grpc.CallOption) (*runtimeapi.ListContainersResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &runtimeapi.ListContainersResponse{Containers: c.containers}, nil
+}
+
+type fakeCRIImageClient struct {
+	runtimeapi.ImageServiceClient
+	images []*runtimeapi.Image
+}
+
+func (c *fakeCRIImageClient) ListImages(ctx context.Context, in *runtimeapi.ListImagesRequest, opts ...grpc.CallOption) (*runtimeapi.ListImagesResponse, error) {
+	return &runtimeapi.ListImagesResponse{Images: c.images}, nil
+}
+
+func TestCollectHostCRI(t *testing.T) {
+	original := criClients
+	defer func() {
+		criClients = original
+	}()
+
+	socket := filepath.Join(t.TempDir(), "containerd.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0600))
+
+	runtimeClient := &fakeCRIRuntimeClient{
+		pods: []*runtimeapi.PodSandbox{
+			{
+				Id:        "7e1c",
+				Metadata:  &runtimeapi.PodSandboxMetadata{Name: "app-0", Namespace: "default", Uid: "0f3b", Attempt: 1},
+				State:     runtimeapi.PodSandboxState_SANDBOX_READY,
+				CreatedAt: 1700000000000000000,
+				Labels:    map[string]string{"app": "app"},
+			},
+		},
+		containers: []*runtimeapi.Container{
+			{
+				Id:           "c0a4",
+				PodSandboxId: "7e1c",
+				Metadata:     &runtimeapi.ContainerMetadata{Name: "app", Attempt: 3},
+				Image:        &runtimeapi.ImageSpec{Image: "docker.io/library/nginx:1.27"},
+				ImageRef:     "sha256:5ef7",
+				State:        runtimeapi.ContainerState_CONTAINER_EXITED,
+				CreatedAt:    1700000060000000000,
+				Labels: map[string]string{
+					"io.kubernetes.pod.name":      "app-0",
+					"io.kubernetes.pod.namespace": "default",
+				},
+			},
+		},
+	}
+	imageClient := &fakeCRIImageClient{
+		images: []*runtimeapi.Image{
+			{Id: "sha256:5ef7", RepoTags: []string{"docker.io/library/nginx:1.27"}, Size_: 72000000},
+		},
+	}
+	criClients = func(socket string) (runtimeapi.RuntimeServiceClient, runtimeapi.ImageServiceClient, func() error, error) {
+		return runtimeClient, imageClient, func() error { return nil }, nil
+	}
+
+	t.Run("lists", func(t *testing.T) {
+		c := &CollectHostCRI{hostCollector: &troubleshootv1beta2.HostCRI{Socket: socket}}
+		result, err := c.Collect(nil)
+		require.NoError(t, err)
+
+		require.Len(t, result, 3)
+		assert.JSONEq(t, `[{
+			"id": "7e1c",
+			"name": "app-0",
+			"namespace": "default",
+			"uid": "0f3b",
+			"attempt": 1,
+			"state": "SANDBOX_READY",
+			"createdAt": "2023-11-14T22:13:20Z",
+			"labels": {"app": "app"}
+		}]`, string(result["host-collectors/cri/pods.json"]))
+		assert.JSONEq(t, `[{
+			"id": "c0a4",
+			"podId": "7e1c",
+			"podName": "app-0",
+			"podNamespace": "default",
+			"name": "app",
+			"attempt": 3,
+			"image": "docker.io/library/nginx:1.27",
+			"imageRef": "sha256:5ef7",
+			"state": "CONTAINER_EXITED",
+			"createdAt": "2023-11-14T22:14:20Z"
+		}]`, string(result["host-collectors/cri/containers.json"]))
+		assert.JSONEq(t, `[{
+			"id": "sha256:5ef7",
+			"repoTags": ["docker.io/library/nginx:1.27"],
+			"size": 72000000
+		}]`, string(result["host-collectors/cri/images.json"]))
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		runtimeClient.err = errors.New("rpc error: code = Unavailable")
+		defer func() {
+			runtimeClient.err = nil
+		}()
+
+		c := &CollectHostCRI{hostCollector: &troubleshootv1beta2.HostCRI{Socket: socket}}
+		result, err := c.Collect(nil)
+		require.NoError(t, err)
+
+		assert.Contains(t, result, "host-collectors/cri/pods.json")
+		assert.NotContains(t, result, "host-collectors/cri/containers.json")
+		assert.Contains(t, result, "host-collectors/cri/images.json")
+		assert.JSONEq(t, `["failed to list containers: rpc error: code = Unavailable"]`, string(result["host-collectors/cri/errors.json"]))
+	})
+
+	t.Run("socket not found", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing.sock")
+		c := &CollectHostCRI{hostCollector: &troubleshootv1beta2.HostCRI{Socket: missing}}
+		result, err := c.Collect(nil)
+		require.NoError(t, err)
+
+		require.Len(t, result, 1)
+		assert.JSONEq(t, `["CRI socket `+missing+` not found, set socket to the CRI socket of the container runtime"]`, string(result["host-collectors/cri/errors.json"]))
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		c := &CollectHostCRI{hostCollector: &troubleshootv1beta2.HostCRI{Socket: socket, Timeout: "soon"}}
+		_, err := c.Collect(nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,234 @@
+package collect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"k8s.io/klog/v2"
+)
+
+// Ensure `CollectHostDmesg` implements `HostCollector` interface at compile time.
+var _ HostCollector = (*CollectHostDmesg)(nil)
+
+const HostDmesgPath = `host-collectors/dmesg.json`
+const HostDmesgFileName = `dmesg.json`
+
+const (
+	DmesgSourceCommand = "dmesg"
+	DmesgSourceKmsg    = "/dev/kmsg"
+)
+
+// DmesgInfo is the content of host-collectors/dmesg.json
+type DmesgInfo struct {
+	// Source is where the messages were read from, the dmesg command or /dev/kmsg
+	Source  string       `json:"source,omitempty"`
+	Entries []DmesgEntry `json:"entries"`
+	// Error is set when the kernel ring buffer can not be read, e.g. when kernel.dmesg_restrict
+	// denies access to it
+	Error string `json:"error,omitempty"`
+}
+
+type DmesgEntry struct {
+	// Timestamp is the number of seconds since boot the message was logged at
+	Timestamp float64 `json:"timestamp"`
+	Facility  string  `json:"facility,omitempty"`
+	Severity  string  `json:"severity"`
+	Message   string  `json:"message"`
+}
+
+var dmesgSeverities = []string{"emerg", "alert", "crit", "err", "warn", "notice", "info", "debug"}
+
+var dmesgFacilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "", "", "", "",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+// dmesgJSON returns the output of dmesg --json, it is a var to allow stubbing in tests
+var dmesgJSON = func() ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("dmesg", "--json")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Wrap(err, msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// readKmsg returns the records of /dev/kmsg, it is a var to allow stubbing in tests
+var readKmsg = readKmsgRecords
+
+type CollectHostDmesg struct {
+	hostCollector *troubleshootv1beta2.HostDmesg
+	BundlePath    string
+}
+
+func (c *CollectHostDmesg) Title() string {
+	return hostCollectorTitleOrDefault(c.hostCollector.HostCollectorMeta, "dmesg")
+}
+
+func (c *CollectHostDmesg) IsExcluded() (bool, error) {
+	return isExcluded(c.hostCollector.Exclude)
+}
+
+// Collect saves the kernel messages to host-collectors/dmesg.json. They are read with dmesg --json,
+// or from /dev/kmsg with versions of dmesg that can't output JSON. When neither can be read, e.g.
+// without the permission to, the error is saved in the file instead of failing the collector.
+func (c *CollectHostDmesg) Collect(progressChan chan<- interface{}) (map[string][]byte, error) {
+	info := collectDmesg()
+
+	b, err := json.Marshal(info)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal dmesg")
+	}
+
+	output := NewResult()
+	output.SaveResult(c.BundlePath, HostDmesgPath, bytes.NewBuffer(b))
+
+	return output, nil
+}
+
+func collectDmesg() DmesgInfo {
+	out, cmdErr := dmesgJSON()
+	if cmdErr == nil {
+		entries, err := parseDmesgJSON(out)
+		if err == nil {
+			return DmesgInfo{Source: DmesgSourceCommand, Entries: entries}
+		}
+		cmdErr = err
+	}
+	klog.V(2).Infof("Failed to run dmesg --json, reading %s: %v", DmesgSourceKmsg, cmdErr)
+
+	records, err := readKmsg()
+	if err != nil {
+		info := DmesgInfo{Entries: []DmesgEntry{}}
+		if os.IsPermission(err) {
+			info.Error = fmt.Sprintf("permission denied reading the kernel ring buffer, collect as root or set kernel.dmesg_restrict to 0: %v", err)
+		} else {
+			info.Error = fmt.Sprintf("failed to read the kernel ring buffer: dmesg: %v, %s: %v", cmdErr, DmesgSourceKmsg, err)
+		}
+		return info
+	}
+
+	entries := []DmesgEntry{}
+	for _, record := range records {
+		entry, ok := parseKmsgRecord(record)
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+	return DmesgInfo{Source: DmesgSourceKmsg, Entries: entries}
+}
+
+// parseDmesgJSON parses the output of dmesg --json, e.g.
+// {"dmesg": [{"pri": 6, "time": 0.000000, "msg": "Linux version 6.1.0"}]}
+// Depending on the version of dmesg, the facility is part of pri or in a separate fac field.
+func parseDmesgJSON(out []byte) ([]DmesgEntry, error) {
+	var parsed struct {
+		Dmesg []struct {
+			Facility *string `json:"fac"`
+			Priority any     `json:"pri"`
+			Time     float64 `json:"time"`
+			Message  string  `json:"msg"`
+		} `json:"dmesg"`
+	}
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return nil, errors.Wrap(err, "failed to parse dmesg output")
+	}
+
+	entries := make([]DmesgEntry, 0, len(parsed.Dmesg))
+	for _, record := range parsed.Dmesg {
+		entry := DmesgEntry{
+			Timestamp: record.Time,
+			Message:   record.Message,
+		}
+
+		switch priority := record.Priority.(type) {
+		case float64:
+			entry.Facility, entry.Severity = dmesgPriority(int(priority))
+		case string:
+			// dmesg --decode prints names instead of numbers
+			entry.Severity = priority
+		}
+		if record.Facility != nil {
+			entry.Facility = *record.Facility
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseKmsgRecord parses a record of /dev/kmsg, e.g. "6,339,5140900,-;NET: Registered PF_INET6",
+// where the fields before ; are the priority, the sequence number, the timestamp in microseconds
+// since boot and flags. The lines of the record after the first one are key value pairs that are
+// not kept.
+func parseKmsgRecord(record string) (DmesgEntry, bool) {
+	header, message, ok := strings.Cut(record, ";")
+	if !ok {
+		return DmesgEntry{}, false
+	}
+	message, _, _ = strings.Cut(message, "\n")
+
+	fields := strings.Split(header, ",")
+	if len(fields) < 3 {
+		return DmesgEntry{}, false
+	}
+	priority, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return DmesgEntry{}, false
+	}
+	usec, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return DmesgEntry{}, false
+	}
+
+	facility, severity := dmesgPriority(priority)
+	return DmesgEntry{
+		Timestamp: float64(usec) / 1e6,
+		Facility:  facility,
+		Severity:  severity,
+		Message:   unescapeKmsg(message),
+	}, true
+}
+
+// dmesgPriority splits a syslog priority into the names of its facility and severity
+func dmesgPriority(priority int) (string, string) {
+	facility := ""
+	if f := priority >> 3; f >= 0 && f < len(dmesgFacilities) {
+		facility = dmesgFacilities[f]
+	}
+	return facility, dmesgSeverities[priority&7]
+}
+
+// unescapeKmsg replaces the \xNN escapes the kernel uses for non-printable characters
+func unescapeKmsg(message string) string {
+	if !strings.Contains(message, `\x`) {
+		return message
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		if message[i] == '\\' && i+3 < len(message) && message[i+1] == 'x' {
+			if c, err := strconv.ParseUint(message[i+2:i+4], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(message[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+//go:build linux
+
+package collect
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// readKmsgRecords reads the records currently in the kernel ring buffer from /dev/kmsg. Each read
+// returns one record, the file is opened non-blocking so that reading stops at the last one
+// instead of waiting for new messages.
+func readKmsgRecords() ([]string, error) {
+	fd, err := syscall.Open(DmesgSourceKmsg, syscall.O_RDONLY|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: DmesgSourceKmsg, Err: err}
+	}
+	defer syscall.Close(fd)
+
+	records := []string{}
+	buf := make([]byte, 8192)
+	for {
+		n, err := syscall.Read(fd, buf)
+		if errors.Is(err, syscall.EINTR) {
+			continue
+		}
+		if errors.Is(err, syscall.EPIPE) {
+			// the record was overwritten while reading, the next read returns the next one
+			continue
+		}
+		if errors.Is(err, syscall.EAGAIN) || (err == nil && n == 0) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, &os.PathError{Op: "read", Path: DmesgSourceKmsg, Err: err}
+		}
+		records = append(records, string(buf[:n]))
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package collect
+
+import (
+	"fmt"
+)
+
+func readKmsgRecords() ([]string, error) {
+	return nil, fmt.Errorf("reading %s is not implemented for this OS", DmesgSourceKmsg)
+}
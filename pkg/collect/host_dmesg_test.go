@@ -0,0 +1,167 @@
+package collect
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseDmesgJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     string
+		want    []DmesgEntry
+		wantErr bool
+	}{
+		{
+			name: "numeric priority",
+			out: `{"dmesg": [
+				{"pri": 6, "time": 0.000000, "msg": "Linux version 6.1.0"},
+				{"pri": 3, "time": 12.345678, "msg": "blk_update_request: I/O error, dev sda"},
+				{"pri": 30, "time": 13.5, "msg": "systemd[1]: Started Journal Service."}
+			]}`,
+			want: []DmesgEntry{
+				{Timestamp: 0, Facility: "kern", Severity: "info", Message: "Linux version 6.1.0"},
+				{Timestamp: 12.345678, Facility: "kern", Severity: "err", Message: "blk_update_request: I/O error, dev sda"},
+				{Timestamp: 13.5, Facility: "daemon", Severity: "info", Message: "systemd[1]: Started Journal Service."},
+			},
+		},
+		{
+			name: "decoded priority",
+			out:  `{"dmesg": [{"fac": "kern", "pri": "warn", "time": 1.5, "msg": "java invoked oom-killer"}]}`,
+			want: []DmesgEntry{
+				{Timestamp: 1.5, Facility: "kern", Severity: "warn", Message: "java invoked oom-killer"},
+			},
+		},
+		{
+			name: "empty",
+			out:  `{}`,
+			want: []DmesgEntry{},
+		},
+		{
+			name:    "not json",
+			out:     `[    0.000000] Linux version 6.1.0`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDmesgJSON([]byte(tt.out))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseKmsgRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		record string
+		want   DmesgEntry
+		wantOK bool
+	}{
+		{
+			name:   "message",
+			record: "6,339,5140900,-;NET: Registered PF_INET6 protocol family\n",
+			want:   DmesgEntry{Timestamp: 5.1409, Facility: "kern", Severity: "info", Message: "NET: Registered PF_INET6 protocol family"},
+			wantOK: true,
+		},
+		{
+			name:   "continuation lines are dropped",
+			record: "3,1234,100000000,-;sd 0:0:0:0: [sda] tag#0 FAILED Result\n SUBSYSTEM=scsi\n DEVICE=+scsi:0:0:0:0\n",
+			want:   DmesgEntry{Timestamp: 100, Facility: "kern", Severity: "err", Message: "sd 0:0:0:0: [sda] tag#0 FAILED Result"},
+			wantOK: true,
+		},
+		{
+			name:   "escaped characters",
+			record: `12,7,2000,-,caller=T1;tab\x09here` + "\n",
+			want:   DmesgEntry{Timestamp: 0.002, Facility: "user", Severity: "warn", Message: "tab\there"},
+			wantOK: true,
+		},
+		{
+			name:   "no header",
+			record: "just a message",
+		},
+		{
+			name:   "invalid timestamp",
+			record: "6,1,soon,-;message",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseKmsgRecord(tt.record)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_collectDmesg(t *testing.T) {
+	origDmesgJSON, origReadKmsg := dmesgJSON, readKmsg
+	defer func() {
+		dmesgJSON, readKmsg = origDmesgJSON, origReadKmsg
+	}()
+
+	dmesgUnavailable := func() ([]byte, error) {
+		return nil, errors.New("dmesg: unrecognized option '--json'")
+	}
+
+	tests := []struct {
+		name      string
+		dmesgJSON func() ([]byte, error)
+		readKmsg  func() ([]string, error)
+		want      DmesgInfo
+		wantError string
+	}{
+		{
+			name: "dmesg",
+			dmesgJSON: func() ([]byte, error) {
+				return []byte(`{"dmesg": [{"pri": 6, "time": 1.0, "msg": "hello"}]}`), nil
+			},
+			want: DmesgInfo{
+				Source:  DmesgSourceCommand,
+				Entries: []DmesgEntry{{Timestamp: 1, Facility: "kern", Severity: "info", Message: "hello"}},
+			},
+		},
+		{
+			name:      "kmsg",
+			dmesgJSON: dmesgUnavailable,
+			readKmsg: func() ([]string, error) {
+				return []string{"6,1,1000000,-;hello\n", "invalid"}, nil
+			},
+			want: DmesgInfo{
+				Source:  DmesgSourceKmsg,
+				Entries: []DmesgEntry{{Timestamp: 1, Facility: "kern", Severity: "info", Message: "hello"}},
+			},
+		},
+		{
+			name:      "permission denied",
+			dmesgJSON: dmesgUnavailable,
+			readKmsg: func() ([]string, error) {
+				return nil, &os.PathError{Op: "open", Path: DmesgSourceKmsg, Err: syscall.EPERM}
+			},
+			want:      DmesgInfo{Entries: []DmesgEntry{}},
+			wantError: "permission denied reading the kernel ring buffer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dmesgJSON, readKmsg = tt.dmesgJSON, tt.readKmsg
+
+			got := collectDmesg()
+			if tt.wantError != "" {
+				assert.Contains(t, got.Error, tt.wantError)
+				got.Error = ""
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -26,26 +26,30 @@ func (c *CollectHostHTTP) Collect(progressChan chan<- interface{}) (map[string][
 	httpCollector := c.hostCollector
 
 	var response *http.Response
+	var redirects *HTTPRedirectChain
 	var err error
 
 	switch {
 	case httpCollector.Get != nil:
-		response, err = doRequest(
+		response, redirects, err = doRequest(
 			"GET", httpCollector.Get.URL, httpCollector.Get.Headers,
-			"", httpCollector.Get.InsecureSkipVerify, httpCollector.Get.Timeout, httpCollector.Get.TLS, httpCollector.Get.Proxy)
+			"", httpCollector.Get.InsecureSkipVerify, httpCollector.Get.Timeout, httpCollector.Get.TLS, httpCollector.Get.Proxy,
+			httpCollector.Get.RecordRedirects, httpCollector.Get.MaxRedirects)
 	case httpCollector.Post != nil:
-		response, err = doRequest(
+		response, redirects, err = doRequest(
 			"POST", httpCollector.Post.URL, httpCollector.Post.Headers,
-			httpCollector.Post.Body, httpCollector.Post.InsecureSkipVerify, httpCollector.Post.Timeout, httpCollector.Post.TLS, httpCollector.Post.Proxy)
+			httpCollector.Post.Body, httpCollector.Post.InsecureSkipVerify, httpCollector.Post.Timeout, httpCollector.Post.TLS, httpCollector.Post.Proxy,
+			httpCollector.Post.RecordRedirects, httpCollector.Post.MaxRedirects)
 	case httpCollector.Put != nil:
-		response, err = doRequest(
+		response, redirects, err = doRequest(
 			"PUT", httpCollector.Put.URL, httpCollector.Put.Headers,
-			httpCollector.Put.Body, httpCollector.Put.InsecureSkipVerify, httpCollector.Put.Timeout, httpCollector.Put.TLS, httpCollector.Put.Proxy)
+			httpCollector.Put.Body, httpCollector.Put.InsecureSkipVerify, httpCollector.Put.Timeout, httpCollector.Put.TLS, httpCollector.Put.Proxy,
+			httpCollector.Put.RecordRedirects, httpCollector.Put.MaxRedirects)
 	default:
 		return nil, errors.New("no supported http request type")
 	}
 
-	responseOutput, err := responseToOutput(response, err)
+	responseOutput, err := responseAndRedirectsToOutput(response, redirects, err)
 	if err != nil {
 		return nil, err
 	}
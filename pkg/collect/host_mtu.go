@@ -0,0 +1,102 @@
+package collect
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"k8s.io/klog/v2"
+)
+
+// Ensure `CollectHostMTU` implements `HostCollector` interface at compile time.
+var _ HostCollector = (*CollectHostMTU)(nil)
+
+const HostMTUPath = `host-collectors/system/mtu.json`
+const HostMTUFileName = `mtu.json`
+
+// procNetRoutePath is the kernel's IPv4 routing table, it is a var to allow stubbing in tests
+var procNetRoutePath = "/proc/net/route"
+
+// MTUInterface is the MTU of a network interface that is up
+type MTUInterface struct {
+	Name string `json:"name"`
+	MTU  int    `json:"mtu"`
+	// DefaultRoute is true for the interface the IPv4 default route goes through. It is only
+	// detected on Linux.
+	DefaultRoute bool `json:"defaultRoute,omitempty"`
+}
+
+type CollectHostMTU struct {
+	hostCollector *troubleshootv1beta2.HostMTU
+	BundlePath    string
+}
+
+func (c *CollectHostMTU) Title() string {
+	return hostCollectorTitleOrDefault(c.hostCollector.HostCollectorMeta, "MTU")
+}
+
+func (c *CollectHostMTU) IsExcluded() (bool, error) {
+	return isExcluded(c.hostCollector.Exclude)
+}
+
+func (c *CollectHostMTU) Collect(progressChan chan<- interface{}) (map[string][]byte, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, errors.Wrap(err, "list host network interfaces")
+	}
+
+	defaultRoutes := map[string]bool{}
+	routes, err := os.ReadFile(procNetRoutePath)
+	if err != nil {
+		klog.V(2).Infof("failed to read %s, the default route interface will not be detected: %v", procNetRoutePath, err)
+	} else {
+		defaultRoutes = parseDefaultRouteInterfaces(routes)
+	}
+
+	mtuInterfaces := []MTUInterface{}
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		mtuInterfaces = append(mtuInterfaces, MTUInterface{
+			Name:         iface.Name,
+			MTU:          iface.MTU,
+			DefaultRoute: defaultRoutes[iface.Name],
+		})
+	}
+
+	b, err := json.Marshal(mtuInterfaces)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal network interface mtus")
+	}
+
+	output := NewResult()
+	output.SaveResult(c.BundlePath, HostMTUPath, bytes.NewBuffer(b))
+
+	return output, nil
+}
+
+// parseDefaultRouteInterfaces returns the interfaces with a default route in the contents of
+// /proc/net/route, the routes whose destination and mask are both 0.0.0.0
+func parseDefaultRouteInterfaces(routes []byte) map[string]bool {
+	interfaces := map[string]bool{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(routes))
+	for scanner.Scan() {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask MTU Window IRTT
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 || fields[0] == "Iface" {
+			continue
+		}
+		if fields[1] == "00000000" && fields[7] == "00000000" {
+			interfaces[fields[0]] = true
+		}
+	}
+
+	return interfaces
+}
@@ -0,0 +1,73 @@
+package collect
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseDefaultRouteInterfaces(t *testing.T) {
+	tests := []struct {
+		name   string
+		routes string
+		want   map[string]bool
+	}{
+		{
+			name: "default route",
+			routes: `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	00000000	0101A8C0	0003	0	0	100	00000000	0	0	0
+eth0	0001A8C0	00000000	0001	0	0	100	00FFFFFF	0	0	0
+flannel.1	00004A0A	00004A0A	0003	0	0	0	00FFFFFF	0	0	0
+`,
+			want: map[string]bool{"eth0": true},
+		},
+		{
+			name: "no default route",
+			routes: `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	0001A8C0	00000000	0001	0	0	100	00FFFFFF	0	0	0
+`,
+			want: map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseDefaultRouteInterfaces([]byte(tt.routes)))
+		})
+	}
+}
+
+func TestCollectHostMTU(t *testing.T) {
+	routes := filepath.Join(t.TempDir(), "route")
+	require.NoError(t, os.WriteFile(routes, []byte("Iface\tDestination\tGateway\tFlags\tRefCnt\tUse\tMetric\tMask\tMTU\tWindow\tIRTT\n"), 0644))
+
+	original := procNetRoutePath
+	procNetRoutePath = routes
+	defer func() {
+		procNetRoutePath = original
+	}()
+
+	c := &CollectHostMTU{
+		hostCollector: &troubleshootv1beta2.HostMTU{},
+		BundlePath:    t.TempDir(),
+	}
+	result, err := c.Collect(nil)
+	require.NoError(t, err)
+	require.Contains(t, result, HostMTUPath)
+
+	b, err := os.ReadFile(filepath.Join(c.BundlePath, HostMTUPath))
+	require.NoError(t, err)
+
+	var interfaces []MTUInterface
+	require.NoError(t, json.Unmarshal(b, &interfaces))
+	for _, iface := range interfaces {
+		assert.NotEqual(t, "lo", iface.Name)
+		assert.False(t, iface.DefaultRoute)
+		assert.Positive(t, iface.MTU)
+	}
+}
@@ -0,0 +1,116 @@
+package collect
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"k8s.io/klog/v2"
+)
+
+// Ensure `CollectHostSystemd` implements `HostCollector` interface at compile time.
+var _ HostCollector = (*CollectHostSystemd)(nil)
+
+const HostSystemdPath = `host-collectors/systemd/`
+
+// systemctlShow returns the output of systemctl show for a unit, it is a var to allow stubbing in
+// tests
+var systemctlShow = func(unit string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("systemctl", "show", unit, "--no-pager")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Wrap(err, msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+type CollectHostSystemd struct {
+	hostCollector *troubleshootv1beta2.HostSystemd
+	BundlePath    string
+}
+
+func (c *CollectHostSystemd) Title() string {
+	return hostCollectorTitleOrDefault(c.hostCollector.HostCollectorMeta, "systemd")
+}
+
+func (c *CollectHostSystemd) IsExcluded() (bool, error) {
+	return isExcluded(c.hostCollector.Exclude)
+}
+
+// Collect saves the properties of each unit to host-collectors/systemd/<unit>.json. Units that
+// can not be shown, e.g. on hosts without systemd, are listed in host-collectors/systemd/errors.json
+// instead of failing the collector.
+func (c *CollectHostSystemd) Collect(progressChan chan<- interface{}) (map[string][]byte, error) {
+	if len(c.hostCollector.Units) == 0 {
+		return nil, errors.New("at least one unit is required")
+	}
+
+	output := NewResult()
+	errs := []string{}
+	for _, unit := range c.hostCollector.Units {
+		if unit == "" || strings.ContainsAny(unit, `/\`) {
+			errs = append(errs, fmt.Sprintf("invalid unit name %q", unit))
+			continue
+		}
+
+		out, err := systemctlShow(unit)
+		if err != nil {
+			klog.V(2).Infof("failed to show systemd unit %s: %v", unit, err)
+			errs = append(errs, fmt.Sprintf("failed to show unit %s: %v", unit, err))
+			continue
+		}
+
+		b, err := json.Marshal(parseSystemctlShow(out))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal properties of unit %s", unit)
+		}
+		output.SaveResult(c.BundlePath, HostSystemdUnitPath(unit), bytes.NewBuffer(b))
+	}
+
+	if len(errs) > 0 {
+		b, err := json.Marshal(errs)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal errors")
+		}
+		output.SaveResult(c.BundlePath, filepath.Join(HostSystemdPath, "errors.json"), bytes.NewBuffer(b))
+	}
+
+	return output, nil
+}
+
+// HostSystemdUnitPath is the path of the properties of a unit in the bundle
+func HostSystemdUnitPath(unit string) string {
+	return filepath.Join(HostSystemdPath, HostSystemdUnitFileName(unit))
+}
+
+func HostSystemdUnitFileName(unit string) string {
+	return unit + ".json"
+}
+
+// parseSystemctlShow parses the Key=Value lines of systemctl show
+func parseSystemctlShow(out []byte) map[string]string {
+	properties := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok || key == "" {
+			continue
+		}
+		properties[key] = value
+	}
+
+	return properties
+}
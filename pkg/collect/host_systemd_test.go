@@ -0,0 +1,93 @@
+package collect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseSystemctlShow(t *testing.T) {
+	out := `Type=notify
+Restart=always
+ExecStart={ path=/usr/bin/containerd ; argv[]=/usr/bin/containerd ; ignore_errors=no }
+Environment=A=1 B=2
+ActiveState=failed
+SubState=failed
+Description=
+not a property
+`
+	assert.Equal(t, map[string]string{
+		"Type":        "notify",
+		"Restart":     "always",
+		"ExecStart":   "{ path=/usr/bin/containerd ; argv[]=/usr/bin/containerd ; ignore_errors=no }",
+		"Environment": "A=1 B=2",
+		"ActiveState": "failed",
+		"SubState":    "failed",
+		"Description": "",
+	}, parseSystemctlShow([]byte(out)))
+}
+
+func TestCollectHostSystemd(t *testing.T) {
+	original := systemctlShow
+	defer func() {
+		systemctlShow = original
+	}()
+
+	tests := []struct {
+		name      string
+		units     []string
+		show      func(unit string) ([]byte, error)
+		wantFiles map[string]string
+	}{
+		{
+			name:  "units",
+			units: []string{"kubelet.service", "containerd.service"},
+			show: func(unit string) ([]byte, error) {
+				if unit == "kubelet.service" {
+					return []byte("Id=kubelet.service\nActiveState=active\nSubState=running\n"), nil
+				}
+				return []byte("Id=containerd.service\nActiveState=failed\nSubState=failed\n"), nil
+			},
+			wantFiles: map[string]string{
+				"host-collectors/systemd/kubelet.service.json":    `{"ActiveState":"active","Id":"kubelet.service","SubState":"running"}`,
+				"host-collectors/systemd/containerd.service.json": `{"ActiveState":"failed","Id":"containerd.service","SubState":"failed"}`,
+			},
+		},
+		{
+			name:  "host without systemd",
+			units: []string{"kubelet.service", "../kubelet"},
+			show: func(unit string) ([]byte, error) {
+				return nil, errors.New("System has not been booted with systemd as init system (PID 1). Can't operate.: exit status 1")
+			},
+			wantFiles: map[string]string{
+				"host-collectors/systemd/errors.json": `["failed to show unit kubelet.service: System has not been booted with systemd as init system (PID 1). Can't operate.: exit status 1","invalid unit name \"../kubelet\""]`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			systemctlShow = tt.show
+			c := &CollectHostSystemd{
+				hostCollector: &troubleshootv1beta2.HostSystemd{Units: tt.units},
+				BundlePath:    t.TempDir(),
+			}
+
+			result, err := c.Collect(nil)
+			require.NoError(t, err)
+			require.Len(t, result, len(tt.wantFiles))
+
+			for path, want := range tt.wantFiles {
+				require.Contains(t, result, path)
+				b, err := os.ReadFile(filepath.Join(c.BundlePath, path))
+				require.NoError(t, err)
+				assert.JSONEq(t, want, string(b))
+			}
+		})
+	}
+}
@@ -51,23 +51,27 @@ func (c *CollectHTTP) IsExcluded() (bool, error) {
 
 func (c *CollectHTTP) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
 	var response *http.Response
+	var redirects *HTTPRedirectChain
 	var err error
 
 	switch {
 	case c.Collector.Get != nil:
-		response, err = doRequest(
-			"GET", c.Collector.Get.URL, c.Collector.Get.Headers, "", c.Collector.Get.InsecureSkipVerify, c.Collector.Get.Timeout, c.Collector.Get.TLS, c.Collector.Get.Proxy)
+		response, redirects, err = doRequest(
+			"GET", c.Collector.Get.URL, c.Collector.Get.Headers, "", c.Collector.Get.InsecureSkipVerify, c.Collector.Get.Timeout, c.Collector.Get.TLS, c.Collector.Get.Proxy,
+			c.Collector.Get.RecordRedirects, c.Collector.Get.MaxRedirects)
 	case c.Collector.Post != nil:
-		response, err = doRequest(
-			"POST", c.Collector.Post.URL, c.Collector.Post.Headers, c.Collector.Post.Body, c.Collector.Post.InsecureSkipVerify, c.Collector.Post.Timeout, c.Collector.Post.TLS, c.Collector.Post.Proxy)
+		response, redirects, err = doRequest(
+			"POST", c.Collector.Post.URL, c.Collector.Post.Headers, c.Collector.Post.Body, c.Collector.Post.InsecureSkipVerify, c.Collector.Post.Timeout, c.Collector.Post.TLS, c.Collector.Post.Proxy,
+			c.Collector.Post.RecordRedirects, c.Collector.Post.MaxRedirects)
 	case c.Collector.Put != nil:
-		response, err = doRequest(
-			"PUT", c.Collector.Put.URL, c.Collector.Put.Headers, c.Collector.Put.Body, c.Collector.Put.InsecureSkipVerify, c.Collector.Put.Timeout, c.Collector.Put.TLS, c.Collector.Put.Proxy)
+		response, redirects, err = doRequest(
+			"PUT", c.Collector.Put.URL, c.Collector.Put.Headers, c.Collector.Put.Body, c.Collector.Put.InsecureSkipVerify, c.Collector.Put.Timeout, c.Collector.Put.TLS, c.Collector.Put.Proxy,
+			c.Collector.Put.RecordRedirects, c.Collector.Put.MaxRedirects)
 	default:
 		return nil, errors.New("no supported http request type")
 	}
 
-	o, err := responseToOutput(response, err)
+	o, err := responseAndRedirectsToOutput(response, redirects, err)
 	if err != nil {
 		return nil, err
 	}
@@ -103,8 +107,32 @@ func isPEMCertificate(s string) bool {
 	return strings.Contains(s, "BEGIN CERTIFICATE") || strings.Contains(s, "BEGIN RSA PRIVATE KEY")
 }
 
-func doRequest(method, url string, headers map[string]string, body string, insecureSkipVerify bool, timeout string, tlsParams *troubleshootv1beta2.TLSParams, proxy string) (*http.Response, error) {
+// doRequest sends the request of an http collector. When recordRedirects is set, redirects are
+// followed one at a time and the returned chain records each of them, otherwise the chain is nil.
+func doRequest(method, url string, headers map[string]string, body string, insecureSkipVerify bool, timeout string, tlsParams *troubleshootv1beta2.TLSParams, proxy string, recordRedirects bool, maxRedirects int) (*http.Response, *HTTPRedirectChain, error) {
+	httpClient, err := newHTTPClient(insecureSkipVerify, timeout, tlsParams, proxy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if recordRedirects {
+		return doRequestRecordingRedirects(httpClient, method, url, headers, body, maxRedirects)
+	}
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	response, err := httpClient.Do(req)
+	return response, nil, err
+}
 
+func newHTTPClient(insecureSkipVerify bool, timeout string, tlsParams *troubleshootv1beta2.TLSParams, proxy string) (*http.Client, error) {
 	t, err := parseTimeout(timeout)
 	if err != nil {
 		return nil, err
@@ -151,16 +179,7 @@ func doRequest(method, url string, headers map[string]string, body string, insec
 		},
 	}
 
-	req, err := http.NewRequest(method, url, strings.NewReader(body))
-	if err != nil {
-		return nil, err
-	}
-
-	for k, v := range headers {
-		req.Header.Set(k, v)
-	}
-
-	return httpClient.Do(req)
+	return httpClient, nil
 }
 
 type LoggingTransport struct {
@@ -194,7 +213,16 @@ func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 }
 
 func responseToOutput(response *http.Response, err error) ([]byte, error) {
+	return responseAndRedirectsToOutput(response, nil, err)
+}
+
+// responseAndRedirectsToOutput is responseToOutput with the redirect chain of the request, which
+// is saved under "redirects" when it was recorded
+func responseAndRedirectsToOutput(response *http.Response, redirects *HTTPRedirectChain, err error) ([]byte, error) {
 	output := make(map[string]interface{})
+	if redirects != nil {
+		output["redirects"] = redirects
+	}
 	if err != nil {
 		output["error"] = HTTPError{
 			Message: err.Error(),
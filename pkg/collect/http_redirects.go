@@ -0,0 +1,166 @@
+package collect
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// defaultMaxRedirects is the number of redirects the standard http client follows
+const defaultMaxRedirects = 10
+
+// HTTPRedirectChain is the chain of requests made to follow the redirects of an http collector
+type HTTPRedirectChain struct {
+	Hops []HTTPRedirectHop `json:"hops"`
+	// Loop is set when a response redirects to a URL that was already requested. The redirect is
+	// not followed, the response of the collector is the redirect.
+	Loop bool `json:"loop,omitempty"`
+	// TooManyRedirects is set when the maximum number of redirects was followed and the last
+	// response is still a redirect
+	TooManyRedirects bool `json:"tooManyRedirects,omitempty"`
+}
+
+// HTTPRedirectHop is one request of a redirect chain
+type HTTPRedirectHop struct {
+	Method   string       `json:"method"`
+	URL      string       `json:"url"`
+	Status   int          `json:"status,omitempty"`
+	Location string       `json:"location,omitempty"`
+	TLS      *HTTPTLSInfo `json:"tls,omitempty"`
+	Error    string       `json:"error,omitempty"`
+}
+
+// HTTPTLSInfo describes the TLS connection a response was received on
+type HTTPTLSInfo struct {
+	Version     string `json:"version"`
+	CipherSuite string `json:"cipherSuite"`
+	ServerName  string `json:"serverName,omitempty"`
+	// Subject, Issuer and NotAfter are those of the certificate presented by the server
+	Subject  string `json:"subject,omitempty"`
+	Issuer   string `json:"issuer,omitempty"`
+	NotAfter string `json:"notAfter,omitempty"`
+}
+
+// doRequestRecordingRedirects follows the redirects of the request one at a time, recording each
+// request made. Like the standard http client, 301, 302 and 303 redirects of a request other than
+// GET or HEAD are followed with a GET without a body, and headers are not sent to other hosts.
+func doRequestRecordingRedirects(httpClient *http.Client, method, url string, headers map[string]string, body string, maxRedirects int) (*http.Response, *HTTPRedirectChain, error) {
+	if maxRedirects < 0 {
+		return nil, nil, errors.New("maxRedirects must not be negative")
+	}
+	if maxRedirects == 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	client := *httpClient
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	chain := &HTTPRedirectChain{
+		Hops: []HTTPRedirectHop{},
+	}
+	requested := map[string]bool{}
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	originalHost := req.URL.Host
+
+	for {
+		requested[req.URL.String()] = true
+		hop := HTTPRedirectHop{
+			Method: req.Method,
+			URL:    req.URL.String(),
+		}
+
+		response, err := client.Do(req)
+		if err != nil {
+			hop.Error = err.Error()
+			chain.Hops = append(chain.Hops, hop)
+			return nil, chain, err
+		}
+
+		hop.Status = response.StatusCode
+		hop.Location = response.Header.Get("Location")
+		hop.TLS = tlsInfo(response.TLS)
+		chain.Hops = append(chain.Hops, hop)
+
+		if !isRedirect(response.StatusCode) || hop.Location == "" {
+			return response, chain, nil
+		}
+
+		next, err := req.URL.Parse(hop.Location)
+		if err != nil {
+			klog.V(2).Infof("Not following redirect to %q: %v", hop.Location, err)
+			return response, chain, nil
+		}
+		if requested[next.String()] {
+			chain.Loop = true
+			return response, chain, nil
+		}
+		if len(chain.Hops) > maxRedirects {
+			chain.TooManyRedirects = true
+			return response, chain, nil
+		}
+
+		// the body of a redirect is not saved, it is read so that the connection is reused
+		io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+
+		nextMethod, nextBody := req.Method, body
+		switch response.StatusCode {
+		case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther:
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				nextMethod, nextBody = http.MethodGet, ""
+			}
+		}
+
+		req, err = http.NewRequest(nextMethod, next.String(), strings.NewReader(nextBody))
+		if err != nil {
+			return nil, chain, err
+		}
+		if next.Host == originalHost {
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+		}
+	}
+}
+
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+func tlsInfo(state *tls.ConnectionState) *HTTPTLSInfo {
+	if state == nil {
+		return nil
+	}
+
+	info := &HTTPTLSInfo{
+		Version:     tls.VersionName(state.Version),
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+		ServerName:  state.ServerName,
+	}
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		info.Subject = cert.Subject.String()
+		info.Issuer = cert.Issuer.String()
+		info.NotAfter = cert.NotAfter.UTC().Format(time.RFC3339)
+	}
+
+	return info
+}
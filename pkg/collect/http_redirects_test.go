@@ -0,0 +1,148 @@
+package collect
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_doRequestRecordingRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/start", func(res http.ResponseWriter, req *http.Request) {
+		http.Redirect(res, req, "/login", http.StatusFound)
+	})
+	mux.HandleFunc("/login", func(res http.ResponseWriter, req *http.Request) {
+		res.Write([]byte(req.Method + " " + req.Header.Get("X-Token")))
+	})
+	mux.HandleFunc("/loop-a", func(res http.ResponseWriter, req *http.Request) {
+		http.Redirect(res, req, "/loop-b", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/loop-b", func(res http.ResponseWriter, req *http.Request) {
+		http.Redirect(res, req, "/loop-a", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/chain", func(res http.ResponseWriter, req *http.Request) {
+		http.Redirect(res, req, "/start", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/keep-method", func(res http.ResponseWriter, req *http.Request) {
+		http.Redirect(res, req, "/login", http.StatusTemporaryRedirect)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		maxRedirects int
+		wantBody     string
+		wantStatus   int
+		wantChain    HTTPRedirectChain
+	}{
+		{
+			name:       "redirect is followed",
+			method:     "GET",
+			path:       "/start",
+			wantBody:   "GET token",
+			wantStatus: http.StatusOK,
+			wantChain: HTTPRedirectChain{
+				Hops: []HTTPRedirectHop{
+					{Method: "GET", URL: server.URL + "/start", Status: http.StatusFound, Location: "/login"},
+					{Method: "GET", URL: server.URL + "/login", Status: http.StatusOK},
+				},
+			},
+		},
+		{
+			name:       "post becomes get on 302",
+			method:     "POST",
+			path:       "/start",
+			wantBody:   "GET token",
+			wantStatus: http.StatusOK,
+			wantChain: HTTPRedirectChain{
+				Hops: []HTTPRedirectHop{
+					{Method: "POST", URL: server.URL + "/start", Status: http.StatusFound, Location: "/login"},
+					{Method: "GET", URL: server.URL + "/login", Status: http.StatusOK},
+				},
+			},
+		},
+		{
+			name:       "post is kept on 307",
+			method:     "POST",
+			path:       "/keep-method",
+			wantBody:   "POST token",
+			wantStatus: http.StatusOK,
+			wantChain: HTTPRedirectChain{
+				Hops: []HTTPRedirectHop{
+					{Method: "POST", URL: server.URL + "/keep-method", Status: http.StatusTemporaryRedirect, Location: "/login"},
+					{Method: "POST", URL: server.URL + "/login", Status: http.StatusOK},
+				},
+			},
+		},
+		{
+			name:       "loop",
+			method:     "GET",
+			path:       "/loop-a",
+			wantStatus: http.StatusMovedPermanently,
+			wantChain: HTTPRedirectChain{
+				Hops: []HTTPRedirectHop{
+					{Method: "GET", URL: server.URL + "/loop-a", Status: http.StatusMovedPermanently, Location: "/loop-b"},
+					{Method: "GET", URL: server.URL + "/loop-b", Status: http.StatusMovedPermanently, Location: "/loop-a"},
+				},
+				Loop: true,
+			},
+		},
+		{
+			name:         "too many redirects",
+			method:       "GET",
+			path:         "/chain",
+			maxRedirects: 1,
+			wantStatus:   http.StatusFound,
+			wantChain: HTTPRedirectChain{
+				Hops: []HTTPRedirectHop{
+					{Method: "GET", URL: server.URL + "/chain", Status: http.StatusMovedPermanently, Location: "/start"},
+					{Method: "GET", URL: server.URL + "/start", Status: http.StatusFound, Location: "/login"},
+				},
+				TooManyRedirects: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{"X-Token": "token"}
+			response, chain, err := doRequestRecordingRedirects(server.Client(), tt.method, server.URL+tt.path, headers, "body", tt.maxRedirects)
+			require.NoError(t, err)
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, response.StatusCode)
+			assert.Equal(t, tt.wantChain, *chain)
+
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(response.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func Test_doRequestRecordingRedirectsTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	response, chain, err := doRequestRecordingRedirects(server.Client(), "GET", server.URL, nil, "", 0)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	require.Len(t, chain.Hops, 1)
+	hop := chain.Hops[0]
+	assert.Equal(t, http.StatusNoContent, hop.Status)
+	require.NotNil(t, hop.TLS)
+	assert.NotEmpty(t, hop.TLS.Version)
+	assert.NotEmpty(t, hop.TLS.CipherSuite)
+	assert.Equal(t, "O=Acme Co", hop.TLS.Issuer)
+}
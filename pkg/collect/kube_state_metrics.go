@@ -0,0 +1,164 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const kubeStateMetricsDefaultPath = "/metrics"
+
+// kubeStateMetricsSelectors find the kube-state-metrics service, the first is set by the helm
+// chart and the second by the upstream manifests
+var kubeStateMetricsSelectors = []string{
+	"app.kubernetes.io/name=kube-state-metrics",
+	"k8s-app=kube-state-metrics",
+}
+
+type CollectKubeStateMetrics struct {
+	Collector    *troubleshootv1beta2.KubeStateMetrics
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+// KubeStateMetricsScrape records where the kube-state-metrics output was scraped from, and when
+type KubeStateMetricsScrape struct {
+	Namespace string    `json:"namespace"`
+	Service   string    `json:"service"`
+	Port      string    `json:"port"`
+	Path      string    `json:"path"`
+	ScrapedAt time.Time `json:"scrapedAt"`
+}
+
+func (c *CollectKubeStateMetrics) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectKubeStateMetrics) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectKubeStateMetrics) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	files, errs := scrapeKubeStateMetrics(c.Context, c.Client, c.Collector)
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(constants.KUBE_STATE_METRICS_DIR, fileName), bytes.NewBuffer(data))
+	}
+	output.SaveResult(c.BundlePath, path.Join(constants.KUBE_STATE_METRICS_DIR, "errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// scrapeKubeStateMetrics returns the kube-state-metrics output as metrics.txt and where it was
+// scraped from as scrape.json. Errors are returned when the service can not be found or scraped,
+// so the analyzers can tell that kube-state-metrics was not reachable.
+func scrapeKubeStateMetrics(ctx context.Context, client kubernetes.Interface, collector *troubleshootv1beta2.KubeStateMetrics) (map[string][]byte, []string) {
+	service, err := findKubeStateMetricsService(ctx, client, collector.Namespace, collector.ServiceName)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	port := collector.Port
+	if port == "" {
+		port = firstServicePort(service)
+	}
+	metricsPath := collector.Path
+	if metricsPath == "" {
+		metricsPath = kubeStateMetricsDefaultPath
+	}
+
+	scrapedAt := time.Now().UTC()
+	metrics, err := client.CoreV1().Services(service.Namespace).ProxyGet("http", service.Name, port, metricsPath, nil).DoRaw(ctx)
+	if err != nil {
+		return nil, []string{errors.Wrapf(err, "failed to scrape kube-state-metrics service %s/%s", service.Namespace, service.Name).Error()}
+	}
+
+	files := map[string][]byte{
+		"metrics.txt": metrics,
+	}
+
+	b, err := json.MarshalIndent(KubeStateMetricsScrape{
+		Namespace: service.Namespace,
+		Service:   service.Name,
+		Port:      port,
+		Path:      metricsPath,
+		ScrapedAt: scrapedAt,
+	}, "", "  ")
+	if err != nil {
+		return files, []string{errors.Wrap(err, "failed to marshal kube-state-metrics scrape").Error()}
+	}
+	files["scrape.json"] = b
+
+	return files, nil
+}
+
+// findKubeStateMetricsService returns the named service, in kube-system when namespace is empty, or
+// when name is empty the first kube-state-metrics service found by label
+func findKubeStateMetricsService(ctx context.Context, client kubernetes.Interface, namespace, name string) (*corev1.Service, error) {
+	if name != "" && namespace == "" {
+		namespace = metav1.NamespaceSystem
+	}
+	return findLabeledService(ctx, client, namespace, name, "kube-state-metrics", kubeStateMetricsSelectors)
+}
+
+// findLabeledService returns the named service, or when name is empty the first service matching
+// one of selectors, tried in order, ordered by namespace and name. app names the application in
+// errors.
+func findLabeledService(ctx context.Context, client kubernetes.Interface, namespace, name, app string, selectors []string) (*corev1.Service, error) {
+	if name != "" {
+		service, err := client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get %s service %s/%s", app, namespace, name)
+		}
+		return service, nil
+	}
+
+	for _, selector := range selectors {
+		services, err := client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list services with label %s", selector)
+		}
+		if len(services.Items) == 0 {
+			continue
+		}
+		sort.Slice(services.Items, func(i, j int) bool {
+			if services.Items[i].Namespace != services.Items[j].Namespace {
+				return services.Items[i].Namespace < services.Items[j].Namespace
+			}
+			return services.Items[i].Name < services.Items[j].Name
+		})
+		return &services.Items[0], nil
+	}
+
+	return nil, errors.Errorf("no %s service was found", app)
+}
+
+// firstServicePort returns the name of the first port of the service, or its number when it is
+// not named. kube-state-metrics lists its metrics port before its telemetry port, and Prometheus
+// its web port first.
+func firstServicePort(service *corev1.Service) string {
+	if len(service.Spec.Ports) == 0 {
+		return ""
+	}
+	if service.Spec.Ports[0].Name != "" {
+		return service.Spec.Ports[0].Name
+	}
+	return strconv.Itoa(int(service.Spec.Ports[0].Port))
+}
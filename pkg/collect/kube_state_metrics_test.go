@@ -0,0 +1,87 @@
+package collect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_findKubeStateMetricsService(t *testing.T) {
+	helmService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prometheus-kube-state-metrics",
+			Namespace: "monitoring",
+			Labels:    map[string]string{"app.kubernetes.io/name": "kube-state-metrics"},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+	manifestService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-state-metrics",
+			Namespace: "kube-system",
+			Labels:    map[string]string{"k8s-app": "kube-state-metrics"},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Port: 8080}, {Name: "telemetry", Port: 8081}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		namespace   string
+		serviceName string
+		wantService string
+		wantPort    string
+		wantErr     bool
+	}{
+		{
+			name:        "helm chart label",
+			objects:     []runtime.Object{helmService, manifestService},
+			wantService: "monitoring/prometheus-kube-state-metrics",
+			wantPort:    "http",
+		},
+		{
+			name:        "manifest label",
+			objects:     []runtime.Object{manifestService},
+			wantService: "kube-system/kube-state-metrics",
+			wantPort:    "8080",
+		},
+		{
+			name:        "named service",
+			objects:     []runtime.Object{helmService, manifestService},
+			serviceName: "kube-state-metrics",
+			wantService: "kube-system/kube-state-metrics",
+			wantPort:    "8080",
+		},
+		{
+			name:      "not in namespace",
+			objects:   []runtime.Object{helmService},
+			namespace: "kube-system",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testclient.NewSimpleClientset(tt.objects...)
+
+			service, err := findKubeStateMetricsService(context.Background(), client, tt.namespace, tt.serviceName)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantService, service.Namespace+"/"+service.Name)
+			assert.Equal(t, tt.wantPort, firstServicePort(service))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// kubeletEndpoints are the kubelet paths read through the node proxy, by the suffix of the file
+// they are saved to
+var kubeletEndpoints = []struct {
+	path   string
+	suffix string
+}{
+	{path: "metrics", suffix: "metrics.txt"},
+	{path: "healthz", suffix: "healthz.txt"},
+}
+
+// kubeletGetter returns the response of a kubelet endpoint of a node
+type kubeletGetter func(ctx context.Context, nodeName, endpointPath string) ([]byte, error)
+
+type CollectKubelet struct {
+	Collector    *troubleshootv1beta2.Kubelet
+	BundlePath   string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+func (c *CollectKubelet) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectKubelet) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+// Collect saves the output of the kubelet endpoints of each node under host-collectors/kubelet/.
+// Nodes that can not be read, e.g. when nodes/proxy is forbidden, are listed in
+// host-collectors/kubelet/errors.json and the other nodes are still collected.
+func (c *CollectKubelet) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	files, errs := collectKubelets(c.Context, c.Client, c.Collector, func(ctx context.Context, nodeName, endpointPath string) ([]byte, error) {
+		// Equivalent to `kubectl get --raw "/api/v1/nodes/<nodeName>/proxy/<path>"`
+		return c.Client.CoreV1().RESTClient().Get().AbsPath("/api/v1/nodes", nodeName, "proxy", endpointPath).DoRaw(ctx)
+	})
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(constants.KUBELET_DIR, fileName), bytes.NewBuffer(data))
+	}
+	if len(errs) > 0 {
+		output.SaveResult(c.BundlePath, path.Join(constants.KUBELET_DIR, "errors.json"), marshalErrors(errs))
+	}
+
+	return output, nil
+}
+
+func collectKubelets(ctx context.Context, client kubernetes.Interface, collector *troubleshootv1beta2.Kubelet, get kubeletGetter) (map[string][]byte, []string) {
+	nodeNames, err := kubeletNodeNames(ctx, client, collector)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	files := map[string][]byte{}
+	errs := []string{}
+	for _, nodeName := range nodeNames {
+		for _, endpoint := range kubeletEndpoints {
+			data, err := get(ctx, nodeName, endpoint.path)
+			// an unhealthy kubelet responds with an error status and the failed checks
+			if len(data) > 0 && !kuberneteserrors.IsForbidden(err) {
+				files[fmt.Sprintf("%s-%s", nodeName, endpoint.suffix)] = data
+			}
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to get /%s of node %s", endpoint.path, nodeName).Error())
+				if kuberneteserrors.IsForbidden(err) {
+					// the other endpoints of the node are forbidden as well
+					break
+				}
+			}
+		}
+	}
+
+	return files, errs
+}
+
+// kubeletNodeNames returns the sorted names of the nodes in NodeNames and of those matching
+// Selector, or of all nodes when neither is set
+func kubeletNodeNames(ctx context.Context, client kubernetes.Interface, collector *troubleshootv1beta2.Kubelet) ([]string, error) {
+	names := map[string]bool{}
+	for _, nodeName := range collector.NodeNames {
+		names[nodeName] = true
+	}
+
+	if len(collector.NodeNames) == 0 || len(collector.Selector) > 0 {
+		nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+			LabelSelector: strings.Join(collector.Selector, ","),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list nodes")
+		}
+		for _, node := range nodes.Items {
+			names[node.Name] = true
+		}
+	}
+
+	nodeNames := make([]string, 0, len(names))
+	for nodeName := range names {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	return nodeNames, nil
+}
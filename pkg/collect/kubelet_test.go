@@ -0,0 +1,90 @@
+package collect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_collectKubelets(t *testing.T) {
+	tests := []struct {
+		name      string
+		collector troubleshootv1beta2.Kubelet
+		wantFiles map[string]string
+		wantErrs  []string
+	}{
+		{
+			name: "all nodes",
+			wantFiles: map[string]string{
+				"node1-metrics.txt": "kubelet_running_pods 3\n",
+				"node1-healthz.txt": "ok",
+				"node2-metrics.txt": "kubelet_running_pods 1\n",
+				"node2-healthz.txt": "[-]syncloop failed\nhealthz check failed",
+			},
+			wantErrs: []string{
+				"failed to get /healthz of node node2: the server is currently unable to handle the request",
+				`failed to get /metrics of node node3: nodes "node3" is forbidden: User "troubleshoot" cannot get resource "nodes/proxy"`,
+			},
+		},
+		{
+			name:      "selected nodes",
+			collector: troubleshootv1beta2.Kubelet{NodeNames: []string{"node1"}},
+			wantFiles: map[string]string{
+				"node1-metrics.txt": "kubelet_running_pods 3\n",
+				"node1-healthz.txt": "ok",
+			},
+			wantErrs: []string{},
+		},
+		{
+			name:      "selector",
+			collector: troubleshootv1beta2.Kubelet{Selector: []string{"node-role.kubernetes.io/control-plane"}},
+			wantFiles: map[string]string{
+				"node2-metrics.txt": "kubelet_running_pods 1\n",
+				"node2-healthz.txt": "[-]syncloop failed\nhealthz check failed",
+			},
+			wantErrs: []string{
+				"failed to get /healthz of node node2: the server is currently unable to handle the request",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testclient.NewSimpleClientset(
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"node-role.kubernetes.io/control-plane": ""}}},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node3"}},
+			)
+			get := func(ctx context.Context, nodeName, endpointPath string) ([]byte, error) {
+				switch nodeName + "/" + endpointPath {
+				case "node1/metrics":
+					return []byte("kubelet_running_pods 3\n"), nil
+				case "node1/healthz":
+					return []byte("ok"), nil
+				case "node2/metrics":
+					return []byte("kubelet_running_pods 1\n"), nil
+				case "node2/healthz":
+					return []byte("[-]syncloop failed\nhealthz check failed"), kuberneteserrors.NewServiceUnavailable("the server is currently unable to handle the request")
+				}
+				forbidden := kuberneteserrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, nodeName, errors.New(`User "troubleshoot" cannot get resource "nodes/proxy"`))
+				return []byte(`{"kind": "Status"}`), forbidden
+			}
+
+			files, errs := collectKubelets(context.Background(), client, &tt.collector, get)
+
+			gotFiles := map[string]string{}
+			for fileName, data := range files {
+				gotFiles[fileName] = string(data)
+			}
+			assert.Equal(t, tt.wantFiles, gotFiles)
+			assert.Equal(t, tt.wantErrs, errs)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package collect
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LastAppliedConfiguration pairs the kubectl.kubernetes.io/last-applied-configuration annotation
+// of an object with the object as it is live in the cluster. Status, managed fields and the
+// annotation itself are left out of the live object.
+type LastAppliedConfiguration struct {
+	Kind        string                 `json:"kind"`
+	Namespace   string                 `json:"namespace"`
+	Name        string                 `json:"name"`
+	LastApplied map[string]interface{} `json:"lastApplied"`
+	Live        map[string]interface{} `json:"live"`
+}
+
+// lastAppliedConfigurations reads the per-namespace lists of objects collected for each kind and
+// returns the LastAppliedConfiguration of every object that was created with kubectl apply, as
+// JSON arrays keyed by "<namespace>.json"
+func lastAppliedConfigurations(listsByKind map[string]map[string][]byte) (map[string][]byte, map[string]string) {
+	byNamespace := map[string][]LastAppliedConfiguration{}
+	errorsByNamespace := map[string]string{}
+
+	kinds := []string{}
+	for kind := range listsByKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		for fileName, data := range listsByKind[kind] {
+			namespace := strings.TrimSuffix(fileName, ".json")
+
+			var list struct {
+				Items []map[string]interface{} `json:"items"`
+			}
+			if err := json.Unmarshal(data, &list); err != nil {
+				errorsByNamespace[namespace] = fmt.Sprintf("failed to unmarshal %s list: %v", kind, err)
+				continue
+			}
+
+			for _, item := range list.Items {
+				metadata, _ := item["metadata"].(map[string]interface{})
+				annotations, _ := metadata["annotations"].(map[string]interface{})
+				annotation, _ := annotations[corev1.LastAppliedConfigAnnotation].(string)
+				if annotation == "" {
+					continue
+				}
+
+				name, _ := metadata["name"].(string)
+				var lastApplied map[string]interface{}
+				if err := json.Unmarshal([]byte(annotation), &lastApplied); err != nil {
+					errorsByNamespace[namespace] = fmt.Sprintf("failed to unmarshal last applied configuration of %s %s: %v", kind, name, err)
+					continue
+				}
+
+				byNamespace[namespace] = append(byNamespace[namespace], LastAppliedConfiguration{
+					Kind:        kind,
+					Namespace:   namespace,
+					Name:        name,
+					LastApplied: lastApplied,
+					Live:        liveObjectWithoutLastApplied(item),
+				})
+			}
+		}
+	}
+
+	files := map[string][]byte{}
+	for namespace, configurations := range byNamespace {
+		sort.SliceStable(configurations, func(i, j int) bool {
+			if configurations[i].Kind != configurations[j].Kind {
+				return configurations[i].Kind < configurations[j].Kind
+			}
+			return configurations[i].Name < configurations[j].Name
+		})
+
+		b, err := json.MarshalIndent(configurations, "", "  ")
+		if err != nil {
+			errorsByNamespace[namespace] = fmt.Sprintf("failed to marshal last applied configurations: %v", err)
+			continue
+		}
+		files[namespace+".json"] = b
+	}
+
+	return files, errorsByNamespace
+}
+
+// liveObjectWithoutLastApplied returns a shallow copy of the object without status, managed
+// fields and the last-applied-configuration annotation
+func liveObjectWithoutLastApplied(object map[string]interface{}) map[string]interface{} {
+	live := map[string]interface{}{}
+	for k, v := range object {
+		if k != "status" {
+			live[k] = v
+		}
+	}
+
+	metadata, ok := object["metadata"].(map[string]interface{})
+	if !ok {
+		return live
+	}
+	liveMetadata := map[string]interface{}{}
+	for k, v := range metadata {
+		if k != "managedFields" {
+			liveMetadata[k] = v
+		}
+	}
+	if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+		liveAnnotations := map[string]interface{}{}
+		for k, v := range annotations {
+			if k != corev1.LastAppliedConfigAnnotation {
+				liveAnnotations[k] = v
+			}
+		}
+		liveMetadata["annotations"] = liveAnnotations
+	}
+	live["metadata"] = liveMetadata
+
+	return live
+}
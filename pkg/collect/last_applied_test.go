@@ -0,0 +1,80 @@
+package collect
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_lastAppliedConfigurations(t *testing.T) {
+	deployments := map[string][]byte{
+		"default.json": []byte(`{
+  "kind": "DeploymentList",
+  "items": [
+    {
+      "kind": "Deployment",
+      "metadata": {
+        "name": "api",
+        "namespace": "default",
+        "annotations": {
+          "deployment.kubernetes.io/revision": "2",
+          "kubectl.kubernetes.io/last-applied-configuration": "{\"kind\":\"Deployment\",\"metadata\":{\"name\":\"api\"},\"spec\":{\"replicas\":3}}"
+        },
+        "managedFields": [{"manager": "kubectl-client-side-apply"}]
+      },
+      "spec": {"replicas": 5},
+      "status": {"replicas": 5}
+    },
+    {
+      "kind": "Deployment",
+      "metadata": {"name": "helm-managed", "namespace": "default"},
+      "spec": {"replicas": 1}
+    }
+  ]
+}`),
+		"broken.json": []byte(`{
+  "items": [
+    {
+      "metadata": {
+        "name": "broken",
+        "namespace": "broken",
+        "annotations": {"kubectl.kubernetes.io/last-applied-configuration": "{not json"}
+      }
+    }
+  ]
+}`),
+	}
+
+	files, errs := lastAppliedConfigurations(map[string]map[string][]byte{"Deployment": deployments})
+
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs["broken"], "failed to unmarshal last applied configuration of Deployment broken")
+
+	require.Len(t, files, 1)
+	var configurations []LastAppliedConfiguration
+	require.NoError(t, json.Unmarshal(files["default.json"], &configurations))
+
+	assert.Equal(t, []LastAppliedConfiguration{
+		{
+			Kind:      "Deployment",
+			Namespace: "default",
+			Name:      "api",
+			LastApplied: map[string]interface{}{
+				"kind":     "Deployment",
+				"metadata": map[string]interface{}{"name": "api"},
+				"spec":     map[string]interface{}{"replicas": float64(3)},
+			},
+			Live: map[string]interface{}{
+				"kind": "Deployment",
+				"metadata": map[string]interface{}{
+					"name":        "api",
+					"namespace":   "default",
+					"annotations": map[string]interface{}{"deployment.kubernetes.io/revision": "2"},
+				},
+				"spec": map[string]interface{}{"replicas": float64(5)},
+			},
+		},
+	}, configurations)
+}
@@ -0,0 +1,140 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultLiveLogsMaxBytes caps the output of each tailed container when the collector does not
+// set limits.maxBytes
+const defaultLiveLogsMaxBytes = int64(5000000)
+
+// podLogStreamer opens a log stream for a container, it is swapped out in tests
+type podLogStreamer func(ctx context.Context, pod corev1.Pod, opts *corev1.PodLogOptions) (io.ReadCloser, error)
+
+func kubernetesPodLogStreamer(client kubernetes.Interface) podLogStreamer {
+	return func(ctx context.Context, pod corev1.Pod, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+		return client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+	}
+}
+
+func (c *CollectLogs) collectLiveLogs(pods []corev1.Pod, client kubernetes.Interface) (CollectorResult, error) {
+	output := NewResult()
+
+	duration, err := time.ParseDuration(c.Collector.TailDuration)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse tail duration %q", c.Collector.TailDuration)
+	}
+
+	maxBytes := defaultLiveLogsMaxBytes
+	if c.Collector.Limits != nil && c.Collector.Limits.MaxBytes > 0 {
+		maxBytes = c.Collector.Limits.MaxBytes
+	}
+
+	files, errs := tailPodLogs(c.Context, kubernetesPodLogStreamer(client), pods, c.Collector.ContainerNames, duration, maxBytes)
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(getLiveLogsDir(c.Collector), fileName), bytes.NewBuffer(data))
+	}
+	if len(errs) > 0 {
+		output.SaveResult(c.BundlePath, path.Join(constants.LIVE_LOGS_DIR, getLogsErrorsFileName(c.Collector)), marshalErrors(errs))
+	}
+
+	return output, nil
+}
+
+// getLiveLogsDir returns the directory the live logs of a collector are saved in, named after the
+// collector so that collectors tailing the same pods don't overwrite each other's files
+func getLiveLogsDir(logsCollector *troubleshootv1beta2.Logs) string {
+	if len(logsCollector.Name) > 0 {
+		return path.Join(constants.LIVE_LOGS_DIR, logsCollector.Name)
+	} else if len(logsCollector.CollectorName) > 0 {
+		return path.Join(constants.LIVE_LOGS_DIR, logsCollector.CollectorName)
+	}
+	return constants.LIVE_LOGS_DIR
+}
+
+// tailPodLogs follows the logs of the containers of every pod concurrently until duration has
+// passed or ctx is done, whichever is first, so the overall collection timeout still applies.
+// Only lines emitted after the window opens are kept, up to maxBytes per container. Files are
+// keyed "<namespace>/<pod>/<container>.log".
+func tailPodLogs(ctx context.Context, stream podLogStreamer, pods []corev1.Pod, containerNames []string, duration time.Duration, maxBytes int64) (map[string][]byte, []string) {
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	sinceTime := metav1.Now()
+
+	var mtx sync.Mutex
+	files := map[string][]byte{}
+	errorList := []string{}
+
+	var wg sync.WaitGroup
+	for _, pod := range pods {
+		names := containerNames
+		if len(names) == 0 {
+			for _, container := range pod.Spec.Containers {
+				names = append(names, container.Name)
+			}
+		}
+
+		for _, containerName := range names {
+			wg.Add(1)
+			go func(pod corev1.Pod, containerName string) {
+				defer wg.Done()
+
+				opts := &corev1.PodLogOptions{
+					Container: containerName,
+					Follow:    true,
+					SinceTime: &sinceTime,
+				}
+				data, err := tailContainerLogs(ctx, stream, pod, opts, maxBytes)
+
+				mtx.Lock()
+				defer mtx.Unlock()
+				if err != nil {
+					errorList = append(errorList, fmt.Sprintf("failed to tail logs for pod %s/%s container %s: %v", pod.Namespace, pod.Name, containerName, err))
+				}
+				if len(data) > 0 {
+					files[path.Join(pod.Namespace, pod.Name, containerName+".log")] = data
+				}
+			}(pod, containerName)
+		}
+	}
+	wg.Wait()
+
+	sort.Strings(errorList)
+	return files, errorList
+}
+
+// tailContainerLogs reads a followed log stream until it ends or ctx is done. Lines read before
+// ctx is done are returned along with any error.
+func tailContainerLogs(ctx context.Context, stream podLogStreamer, pod corev1.Pod, opts *corev1.PodLogOptions, maxBytes int64) ([]byte, error) {
+	logs, err := stream(ctx, pod, opts)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get log stream")
+	}
+	defer logs.Close()
+
+	buf := &bytes.Buffer{}
+	_, err = io.Copy(buf, io.LimitReader(logs, maxBytes))
+	if err != nil && ctx.Err() == nil {
+		return buf.Bytes(), errors.Wrap(err, "failed to read log stream")
+	}
+
+	return buf.Bytes(), nil
+}
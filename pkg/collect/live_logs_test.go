@@ -0,0 +1,139 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+// emittingLogStreamer returns a stream that writes a numbered line every interval until the
+// request context is done, like a followed container log
+func emittingLogStreamer(interval time.Duration) podLogStreamer {
+	return func(ctx context.Context, pod corev1.Pod, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+		if !opts.Follow || opts.SinceTime == nil {
+			return nil, errors.New("live logs must follow from the start of the window")
+		}
+
+		r, w := io.Pipe()
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for i := 0; ; i++ {
+				select {
+				case <-ctx.Done():
+					w.CloseWithError(ctx.Err())
+					return
+				case <-ticker.C:
+					fmt.Fprintf(w, "%s line %d\n", opts.Container, i)
+				}
+			}
+		}()
+		return r, nil
+	}
+}
+
+func Test_tailPodLogs(t *testing.T) {
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-0", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "api"}, {Name: "proxy"}},
+			},
+		},
+	}
+
+	t.Run("lines emitted during the window are captured", func(t *testing.T) {
+		start := time.Now()
+		files, errs := tailPodLogs(context.Background(), emittingLogStreamer(10*time.Millisecond), pods, nil, 200*time.Millisecond, defaultLiveLogsMaxBytes)
+		elapsed := time.Since(start)
+
+		require.Empty(t, errs)
+		require.Len(t, files, 2)
+		assert.Less(t, elapsed, 2*time.Second)
+
+		for _, container := range []string{"api", "proxy"} {
+			logs := string(files["default/api-0/"+container+".log"])
+			assert.True(t, strings.HasPrefix(logs, container+" line 0\n"+container+" line 1\n"), logs)
+			assert.True(t, strings.HasSuffix(logs, "\n"), logs)
+		}
+	})
+
+	t.Run("selected containers only", func(t *testing.T) {
+		files, errs := tailPodLogs(context.Background(), emittingLogStreamer(10*time.Millisecond), pods, []string{"proxy"}, 50*time.Millisecond, defaultLiveLogsMaxBytes)
+
+		require.Empty(t, errs)
+		require.Len(t, files, 1)
+		assert.Contains(t, files, "default/api-0/proxy.log")
+	})
+
+	t.Run("output is capped at max bytes", func(t *testing.T) {
+		files, errs := tailPodLogs(context.Background(), emittingLogStreamer(time.Millisecond), pods, []string{"api"}, 200*time.Millisecond, 20)
+
+		require.Empty(t, errs)
+		assert.Equal(t, "api line 0\napi line", string(files["default/api-0/api.log"]))
+	})
+
+	t.Run("the run context ends the window early", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, errs := tailPodLogs(ctx, emittingLogStreamer(10*time.Millisecond), pods, nil, time.Minute, defaultLiveLogsMaxBytes)
+
+		require.Empty(t, errs)
+		assert.Less(t, time.Since(start), 10*time.Second)
+	})
+
+	t.Run("stream errors are reported", func(t *testing.T) {
+		stream := func(ctx context.Context, pod corev1.Pod, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+			return nil, errors.New("container is waiting to start")
+		}
+		files, errs := tailPodLogs(context.Background(), stream, pods, []string{"api"}, 50*time.Millisecond, defaultLiveLogsMaxBytes)
+
+		assert.Empty(t, files)
+		assert.Equal(t, []string{"failed to tail logs for pod default/api-0 container api: failed to get log stream: container is waiting to start"}, errs)
+	})
+}
+
+func Test_getLiveLogsDir(t *testing.T) {
+	assert.Equal(t, "live-logs/app", getLiveLogsDir(&troubleshootv1beta2.Logs{Name: "app"}))
+	assert.Equal(t, "live-logs/app-logs", getLiveLogsDir(&troubleshootv1beta2.Logs{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "app-logs"}}))
+	assert.Equal(t, "live-logs", getLiveLogsDir(&troubleshootv1beta2.Logs{}))
+}
+
+func Test_CollectLogs_TailDuration(t *testing.T) {
+	client := testclient.NewSimpleClientset()
+	_, err := createPod(client, "nginx", "firstPod", "my-namespace")
+	require.NoError(t, err)
+
+	c := &CollectLogs{
+		Context:   context.Background(),
+		Namespace: "my-namespace",
+		Collector: &troubleshootv1beta2.Logs{
+			Name:         "live",
+			Namespace:    "my-namespace",
+			TailDuration: "1s",
+		},
+	}
+	got, err := c.CollectWithClient(make(chan any), client)
+
+	require.NoError(t, err)
+	assert.Equal(t, CollectorResult{
+		"live-logs/live/my-namespace/firstPod/nginx.log": []byte("fake logs"),
+	}, got)
+
+	c.Collector.TailDuration = "a minute"
+	_, err = c.CollectWithClient(make(chan any), client)
+	assert.ErrorContains(t, err, `failed to parse tail duration "a minute"`)
+}
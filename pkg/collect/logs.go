@@ -26,6 +26,9 @@ type CollectLogs struct {
 	Client       kubernetes.Interface
 	Context      context.Context
 	SinceTime    *time.Time
+	// GzipFileThreshold is the size, in bytes, over which the logs are gzipped as they are written,
+	// see GetCompressingWriter
+	GzipFileThreshold int64
 	RBACErrors
 }
 
@@ -69,6 +72,15 @@ func (c *CollectLogs) CollectWithClient(progressChan chan<- interface{}, client
 		output.SaveResult(c.BundlePath, getLogsErrorsFileName(c.Collector), marshalErrors(podsErrors))
 	}
 
+	if c.Collector.TailDuration != "" {
+		liveLogs, err := c.collectLiveLogs(pods, client)
+		if err != nil {
+			return nil, err
+		}
+		output.AddResult(liveLogs)
+		return output, nil
+	}
+
 	for _, pod := range pods {
 		if len(c.Collector.ContainerNames) == 0 {
 			// make a list of all the containers in the pod, so that we can get logs from all of them
@@ -81,7 +93,7 @@ func (c *CollectLogs) CollectWithClient(progressChan chan<- interface{}, client
 			}
 
 			for _, containerName := range containerNames {
-				podLogs, err := savePodLogs(ctx, c.BundlePath, client, &pod, c.Collector.Name, containerName, c.Collector.Limits, false, true)
+				podLogs, err := savePodLogs(ctx, c.BundlePath, client, &pod, c.Collector.Name, containerName, c.Collector.Limits, c.GzipFileThreshold, false, true)
 				if err != nil {
 					if errors.Is(err, context.DeadlineExceeded) {
 						klog.Errorf("Pod logs timed out for pod %s and container %s: %v", pod.Name, containerName, err)
@@ -100,7 +112,7 @@ func (c *CollectLogs) CollectWithClient(progressChan chan<- interface{}, client
 			}
 		} else {
 			for _, containerName := range c.Collector.ContainerNames {
-				containerLogs, err := savePodLogs(ctx, c.BundlePath, client, &pod, c.Collector.Name, containerName, c.Collector.Limits, false, true)
+				containerLogs, err := savePodLogs(ctx, c.BundlePath, client, &pod, c.Collector.Name, containerName, c.Collector.Limits, c.GzipFileThreshold, false, true)
 				if err != nil {
 					if errors.Is(err, context.DeadlineExceeded) {
 						klog.Errorf("Pod logs timed out for pod %s and container %s: %v", pod.Name, containerName, err)
@@ -142,6 +154,7 @@ func savePodLogs(
 	pod *corev1.Pod,
 	collectorName, container string,
 	limits *troubleshootv1beta2.LogLimits,
+	gzipThreshold int64,
 	follow bool,
 	createSymLinks bool,
 ) (CollectorResult, error) {
@@ -180,7 +193,7 @@ func savePodLogs(
 	}
 	defer podLogs.Close()
 
-	logWriter, err := result.GetWriter(bundlePath, filePathPrefix+".log")
+	logWriter, err := result.GetCompressingWriter(bundlePath, filePathPrefix+".log", gzipThreshold)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get log writer")
 	}
@@ -204,7 +217,7 @@ func savePodLogs(
 	}
 	defer podLogs.Close()
 
-	prevLogWriter, err := result.GetWriter(bundlePath, filePathPrefix+"-previous.log")
+	prevLogWriter, err := result.GetCompressingWriter(bundlePath, filePathPrefix+"-previous.log", gzipThreshold)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get previous log writer")
 	}
@@ -212,7 +225,7 @@ func savePodLogs(
 	if createSymLinks {
 		defer result.SymLinkResult(bundlePath, linkRelPathPrefix+"-previous.log", filePathPrefix+"-previous.log")
 	}
-	defer result.CloseWriter(bundlePath, filePathPrefix+"-previous.log", logWriter)
+	defer result.CloseWriter(bundlePath, filePathPrefix+"-previous.log", prevLogWriter)
 
 	_, err = io.Copy(prevLogWriter, podLogs)
 	if err != nil {
@@ -252,6 +265,11 @@ func setLogLimits(podLogOpts *corev1.PodLogOptions, limits *troubleshootv1beta2.
 		return
 	}
 
+	if limits.SinceSeconds > 0 {
+		podLogOpts.SinceSeconds = &limits.SinceSeconds
+		return
+	}
+
 	if limits.MaxAge != "" {
 		podLogOpts.SinceTime = maxAgeParser(limits.MaxAge)
 		return
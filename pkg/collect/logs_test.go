@@ -19,6 +19,7 @@ func Test_setLogLimits(t *testing.T) {
 	defaultMaxLines := int64(10000)
 	customLines := int64(20)
 	maxAge := "10h"
+	sinceSeconds := int64(600)
 	sinceWhen := metav1.NewTime(time.Now().Add(-10 * time.Hour))
 
 	convertMaxAgeToTime := func(maxAge string) *metav1.Time {
@@ -67,6 +68,16 @@ func Test_setLogLimits(t *testing.T) {
 				SinceTime: &sinceWhen,
 			},
 		},
+		{
+			name: "since seconds",
+			limits: &troubleshootv1beta2.LogLimits{
+				SinceSeconds: sinceSeconds,
+				MaxLines:     customLines,
+			},
+			expected: corev1.PodLogOptions{
+				SinceSeconds: &sinceSeconds,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -93,6 +104,13 @@ func Test_setLogLimits(t *testing.T) {
 			} else {
 				assert.Nil(t, actual.SinceTime)
 			}
+
+			if test.expected.SinceSeconds != nil {
+				assert.NotNil(t, actual.SinceSeconds)
+				assert.Equal(t, *test.expected.SinceSeconds, *actual.SinceSeconds)
+			} else {
+				assert.Nil(t, actual.SinceSeconds)
+			}
 		})
 	}
 }
@@ -165,7 +183,7 @@ func Test_savePodLogs(t *testing.T) {
 			if !tt.withContainerName {
 				containerName = ""
 			}
-			got, err := savePodLogs(ctx, "", client, pod, tt.collectorName, containerName, limits, false, tt.createSymLinks)
+			got, err := savePodLogs(ctx, "", client, pod, tt.collectorName, containerName, limits, 0, false, tt.createSymLinks)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.want, got)
 		})
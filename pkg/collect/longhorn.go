@@ -311,7 +311,7 @@ func (c *CollectLonghorn) collectLonghornLogs(namespace string, results Collecto
 	}
 
 	rbacErrors := c.GetRBACErrors()
-	logsCollector := &CollectLogs{logsCollectorSpec, c.BundlePath, namespace, c.ClientConfig, c.Client, c.Context, nil, rbacErrors}
+	logsCollector := &CollectLogs{logsCollectorSpec, c.BundlePath, namespace, c.ClientConfig, c.Client, c.Context, nil, 0, rbacErrors}
 
 	logs, err := logsCollector.Collect(progressChan)
 	if err != nil {
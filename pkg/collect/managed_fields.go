@@ -0,0 +1,171 @@
+package collect
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ManagedFieldsHistory lists the field managers of an object from its metadata.managedFields, the
+// most recent change first
+type ManagedFieldsHistory struct {
+	Kind      string         `json:"kind"`
+	Namespace string         `json:"namespace"`
+	Name      string         `json:"name"`
+	Managers  []FieldManager `json:"managers"`
+}
+
+// FieldManager is a manager of an object, such as helm, kubectl-edit or kube-controller-manager,
+// with the time it last changed the object and the paths of the fields it owns, e.g.
+// spec.template.spec.containers[name=api].image
+type FieldManager struct {
+	Manager     string       `json:"manager"`
+	Operation   string       `json:"operation"`
+	Subresource string       `json:"subresource,omitempty"`
+	Time        *metav1.Time `json:"time,omitempty"`
+	Fields      []string     `json:"fields"`
+}
+
+// managedFieldsHistories reads the per-namespace lists of objects collected for each kind and
+// returns the ManagedFieldsHistory of every object with managed fields, as JSON arrays keyed by
+// "<namespace>.json"
+func managedFieldsHistories(listsByKind map[string]map[string][]byte) (map[string][]byte, map[string]string) {
+	byNamespace := map[string][]ManagedFieldsHistory{}
+	errorsByNamespace := map[string]string{}
+
+	for kind, lists := range listsByKind {
+		for fileName, data := range lists {
+			namespace := strings.TrimSuffix(fileName, ".json")
+
+			var list struct {
+				Items []struct {
+					Metadata metav1.ObjectMeta `json:"metadata"`
+				} `json:"items"`
+			}
+			if err := json.Unmarshal(data, &list); err != nil {
+				errorsByNamespace[namespace] = fmt.Sprintf("failed to unmarshal %s list: %v", kind, err)
+				continue
+			}
+
+			for _, item := range list.Items {
+				if len(item.Metadata.ManagedFields) == 0 {
+					continue
+				}
+
+				managers := []FieldManager{}
+				for _, entry := range item.Metadata.ManagedFields {
+					fields := []string{}
+					if entry.FieldsV1 != nil {
+						var fieldSet map[string]interface{}
+						if err := json.Unmarshal(entry.FieldsV1.Raw, &fieldSet); err != nil {
+							errorsByNamespace[namespace] = fmt.Sprintf("failed to unmarshal managed fields of %s %s: %v", kind, item.Metadata.Name, err)
+							continue
+						}
+						fields = managedFieldPaths("", fieldSet)
+					}
+					managers = append(managers, FieldManager{
+						Manager:     entry.Manager,
+						Operation:   string(entry.Operation),
+						Subresource: entry.Subresource,
+						Time:        entry.Time,
+						Fields:      fields,
+					})
+				}
+				sort.SliceStable(managers, func(i, j int) bool {
+					if managers[i].Time == nil || managers[j].Time == nil {
+						return managers[j].Time == nil && managers[i].Time != nil
+					}
+					return managers[j].Time.Before(managers[i].Time)
+				})
+
+				byNamespace[namespace] = append(byNamespace[namespace], ManagedFieldsHistory{
+					Kind:      kind,
+					Namespace: namespace,
+					Name:      item.Metadata.Name,
+					Managers:  managers,
+				})
+			}
+		}
+	}
+
+	files := map[string][]byte{}
+	for namespace, histories := range byNamespace {
+		sort.SliceStable(histories, func(i, j int) bool {
+			if histories[i].Kind != histories[j].Kind {
+				return histories[i].Kind < histories[j].Kind
+			}
+			return histories[i].Name < histories[j].Name
+		})
+
+		b, err := json.MarshalIndent(histories, "", "  ")
+		if err != nil {
+			errorsByNamespace[namespace] = fmt.Sprintf("failed to marshal managed fields: %v", err)
+			continue
+		}
+		files[namespace+".json"] = b
+	}
+
+	return files, errorsByNamespace
+}
+
+// managedFieldPaths returns the sorted paths of the leaves of a FieldsV1 set. "f:" keys are field
+// names, "k:" keys select list items by their key fields, "v:" keys by their value and "i:" keys by
+// their index.
+func managedFieldPaths(prefix string, fieldSet map[string]interface{}) []string {
+	paths := []string{}
+	for key, value := range fieldSet {
+		if key == "." {
+			continue
+		}
+
+		var fieldPath string
+		switch {
+		case strings.HasPrefix(key, "f:"):
+			fieldPath = strings.TrimPrefix(key, "f:")
+			if prefix != "" {
+				fieldPath = prefix + "." + fieldPath
+			}
+		case strings.HasPrefix(key, "k:"):
+			fieldPath = prefix + "[" + managedFieldListKey(strings.TrimPrefix(key, "k:")) + "]"
+		case strings.HasPrefix(key, "v:"), strings.HasPrefix(key, "i:"):
+			fieldPath = prefix + "[" + key[2:] + "]"
+		default:
+			continue
+		}
+
+		children, _ := value.(map[string]interface{})
+		childPaths := managedFieldPaths(fieldPath, children)
+		if len(childPaths) == 0 {
+			paths = append(paths, fieldPath)
+			continue
+		}
+		paths = append(paths, childPaths...)
+	}
+
+	sort.Strings(paths)
+	return paths
+}
+
+// managedFieldListKey formats the key fields of a list item, e.g. {"containerPort":8080,"protocol":"TCP"}
+// as containerPort=8080,protocol=TCP
+func managedFieldListKey(key string) string {
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(key), &fields); err != nil {
+		return key
+	}
+
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := []string{}
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", name, fields[name]))
+	}
+	return strings.Join(pairs, ",")
+}
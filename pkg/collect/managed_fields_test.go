@@ -0,0 +1,124 @@
+package collect
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_managedFieldsHistories(t *testing.T) {
+	deployments := map[string][]byte{
+		"default.json": []byte(`{
+  "kind": "DeploymentList",
+  "items": [
+    {
+      "kind": "Deployment",
+      "metadata": {
+        "name": "api",
+        "namespace": "default",
+        "managedFields": [
+          {
+            "manager": "helm",
+            "operation": "Update",
+            "apiVersion": "apps/v1",
+            "time": "2026-10-01T10:00:00Z",
+            "fieldsType": "FieldsV1",
+            "fieldsV1": {
+              "f:metadata": {"f:labels": {".": {}, "f:app": {}}},
+              "f:spec": {
+                "f:template": {
+                  "f:spec": {
+                    "f:containers": {
+                      "k:{\"name\":\"api\"}": {
+                        ".": {},
+                        "f:image": {},
+                        "f:ports": {"k:{\"containerPort\":8080,\"protocol\":\"TCP\"}": {".": {}, "f:containerPort": {}}}
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          {
+            "manager": "kube-controller-manager",
+            "operation": "Update",
+            "apiVersion": "apps/v1",
+            "time": "2026-10-01T10:00:05Z",
+            "fieldsType": "FieldsV1",
+            "fieldsV1": {"f:status": {"f:replicas": {}}},
+            "subresource": "status"
+          },
+          {
+            "manager": "kubectl-scale",
+            "operation": "Update",
+            "apiVersion": "autoscaling/v1",
+            "time": "2026-10-12T08:14:03Z",
+            "fieldsType": "FieldsV1",
+            "fieldsV1": {"f:spec": {"f:replicas": {}}},
+            "subresource": "scale"
+          }
+        ]
+      }
+    },
+    {
+      "kind": "Deployment",
+      "metadata": {"name": "unmanaged", "namespace": "default"}
+    }
+  ]
+}`),
+		"broken.json": []byte(`{"items": "not a list"}`),
+	}
+
+	files, errs := managedFieldsHistories(map[string]map[string][]byte{"Deployment": deployments})
+
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs["broken"], "failed to unmarshal Deployment list")
+
+	require.Len(t, files, 1)
+	var histories []ManagedFieldsHistory
+	require.NoError(t, json.Unmarshal(files["default.json"], &histories))
+
+	at := func(s string) *metav1.Time {
+		parsed, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return &metav1.Time{Time: parsed.Local()}
+	}
+	assert.Equal(t, []ManagedFieldsHistory{
+		{
+			Kind:      "Deployment",
+			Namespace: "default",
+			Name:      "api",
+			Managers: []FieldManager{
+				{
+					Manager:     "kubectl-scale",
+					Operation:   "Update",
+					Subresource: "scale",
+					Time:        at("2026-10-12T08:14:03Z"),
+					Fields:      []string{"spec.replicas"},
+				},
+				{
+					Manager:     "kube-controller-manager",
+					Operation:   "Update",
+					Subresource: "status",
+					Time:        at("2026-10-01T10:00:05Z"),
+					Fields:      []string{"status.replicas"},
+				},
+				{
+					Manager:   "helm",
+					Operation: "Update",
+					Time:      at("2026-10-01T10:00:00Z"),
+					Fields: []string{
+						"metadata.labels.app",
+						"spec.template.spec.containers[name=api].image",
+						"spec.template.spec.containers[name=api].ports[containerPort=8080,protocol=TCP].containerPort",
+					},
+				},
+			},
+		},
+	}, histories)
+}
@@ -0,0 +1,297 @@
+package collect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+)
+
+// MetadataOnlyContents describes what a metadata only bundle includes and leaves out. It is saved
+// in the bundle as metadata-only.json.
+type MetadataOnlyContents struct {
+	Included []string `json:"included"`
+	Excluded []string `json:"excluded"`
+}
+
+// MetadataOnlyBundleContents is saved in every metadata only bundle
+var MetadataOnlyBundleContents = MetadataOnlyContents{
+	Included: []string{
+		"cluster version and API discovery (cluster-info, cluster-resources/groups.json, cluster-resources/resources.json)",
+		"apiVersion, kind and status of every collected object",
+		"object metadata: name, namespace, uid, resourceVersion, generation, creation and deletion timestamps, labels, owner references and finalizers",
+		"structural spec fields: replicas, job completions and parallelism, pod node, priority class, scheduler and restart policy, node taints, unschedulable and provider ID, service type, volume storage class, capacity and access modes, custom resource definition group, names and scope",
+		"storage class provisioner and policies, priority class values, role rules and binding role references",
+		"event type, reason, count, source, timestamps and involved object",
+		"collection errors and API warnings",
+	},
+	Excluded: []string{
+		"all collectors other than clusterInfo and clusterResources, and all host collectors",
+		"every other spec field, including container commands, args, env vars and volumes",
+		"annotations, including last-applied-configuration, and managed fields",
+		"configmap and secret data, image pull secrets, last-applied specs and field manager histories",
+		"event messages and container termination messages",
+		"role binding subjects, endpoint addresses",
+		"pod logs and every file that is not JSON",
+	},
+}
+
+// metadataOnlyMetadataFields are the object metadata fields kept in a metadata only bundle
+var metadataOnlyMetadataFields = []string{
+	"name",
+	"namespace",
+	"uid",
+	"resourceVersion",
+	"generation",
+	"creationTimestamp",
+	"deletionTimestamp",
+	"labels",
+	"ownerReferences",
+	"finalizers",
+}
+
+// metadataOnlySpecFields are the spec fields kept by kind, they describe how objects relate and
+// are scheduled without any of their configuration
+var metadataOnlySpecFields = map[string][]string{
+	"Pod":                      {"nodeName", "priorityClassName", "schedulerName", "restartPolicy"},
+	"Node":                     {"unschedulable", "taints", "providerID"},
+	"Deployment":               {"replicas"},
+	"StatefulSet":              {"replicas"},
+	"ReplicaSet":               {"replicas"},
+	"Job":                      {"completions", "parallelism"},
+	"Service":                  {"type"},
+	"PersistentVolumeClaim":    {"storageClassName", "volumeName", "accessModes"},
+	"PersistentVolume":         {"storageClassName", "capacity", "accessModes", "persistentVolumeReclaimPolicy"},
+	"CustomResourceDefinition": {"group", "names", "scope"},
+}
+
+// metadataOnlyTopLevelFields are the fields besides apiVersion, kind, metadata and status kept by
+// kind, for the kinds that have no spec
+var metadataOnlyTopLevelFields = map[string][]string{
+	"Event":              {"type", "reason", "count", "source", "firstTimestamp", "lastTimestamp", "eventTime", "reportingComponent", "involvedObject"},
+	"StorageClass":       {"provisioner", "reclaimPolicy", "volumeBindingMode", "allowVolumeExpansion"},
+	"PriorityClass":      {"value", "globalDefault", "preemptionPolicy"},
+	"Role":               {"rules"},
+	"ClusterRole":        {"rules"},
+	"RoleBinding":        {"roleRef"},
+	"ClusterRoleBinding": {"roleRef"},
+}
+
+// metadataOnlyResourceKinds are the kinds of the objects in each cluster resources directory, the
+// objects in lists don't always have their kind set
+var metadataOnlyResourceKinds = map[string]string{
+	constants.CLUSTER_RESOURCES_PODS:                        "Pod",
+	constants.CLUSTER_RESOURCES_NODES:                       "Node",
+	constants.CLUSTER_RESOURCES_DEPLOYMENTS:                 "Deployment",
+	constants.CLUSTER_RESOURCES_STATEFULSETS:                "StatefulSet",
+	constants.CLUSTER_RESOURCES_REPLICASETS:                 "ReplicaSet",
+	constants.CLUSTER_RESOURCES_JOBS:                        "Job",
+	constants.CLUSTER_RESOURCES_SERVICES:                    "Service",
+	constants.CLUSTER_RESOURCES_PVCS:                        "PersistentVolumeClaim",
+	constants.CLUSTER_RESOURCES_PVS:                         "PersistentVolume",
+	constants.CLUSTER_RESOURCES_CUSTOM_RESOURCE_DEFINITIONS: "CustomResourceDefinition",
+	constants.CLUSTER_RESOURCES_EVENTS:                      "Event",
+	constants.CLUSTER_RESOURCES_STORAGE_CLASS:               "StorageClass",
+	constants.CLUSTER_RESOURCES_PRIORITY_CLASS:              "PriorityClass",
+	constants.CLUSTER_RESOURCES_ROLES:                       "Role",
+	constants.CLUSTER_RESOURCES_CLUSTER_ROLES:               "ClusterRole",
+	constants.CLUSTER_RESOURCES_ROLE_BINDINGS:               "RoleBinding",
+	constants.CLUSTER_RESOURCES_CLUSTER_ROLE_BINDINGS:       "ClusterRoleBinding",
+}
+
+// metadataOnlyExcludedDirs are removed from a metadata only bundle, they hold nothing but values
+var metadataOnlyExcludedDirs = []string{
+	path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_LAST_APPLIED),
+	path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_MANAGED_FIELDS),
+	path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_IMAGE_PULL_SECRETS),
+	path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_PODS_LOGS),
+}
+
+// CollectsMetadataOnly returns true for the collectors that can run in a metadata only bundle. The
+// cluster resources they collect are reduced to metadata by MetadataOnlyResult.
+func CollectsMetadataOnly(collector Collector) bool {
+	switch collector.(type) {
+	case *CollectClusterInfo, *CollectClusterResources:
+		return true
+	default:
+		return false
+	}
+}
+
+// MetadataOnlyResult reduces the files in result to the metadata, statuses and structural fields
+// of the objects they hold, see MetadataOnlyBundleContents. Files that are not JSON are removed.
+func MetadataOnlyResult(bundlePath string, result CollectorResult) error {
+	fileNames := make([]string, 0, len(result))
+	for fileName := range result {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	for _, fileName := range fileNames {
+		if !isMetadataOnlyFile(fileName) {
+			if err := result.RemoveResult(bundlePath, fileName); err != nil {
+				return errors.Wrapf(err, "failed to remove %s", fileName)
+			}
+			continue
+		}
+
+		if err := metadataOnlyFile(bundlePath, result, fileName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isMetadataOnlyFile returns true when the file is kept, reduced to metadata, in a metadata only
+// bundle
+func isMetadataOnlyFile(fileName string) bool {
+	if path.Ext(fileName) != ".json" {
+		return false
+	}
+	for _, dir := range metadataOnlyExcludedDirs {
+		if strings.HasPrefix(fileName, dir+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+func metadataOnlyFile(bundlePath string, result CollectorResult, fileName string) error {
+	reader, err := result.GetReader(bundlePath, fileName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", fileName)
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", fileName)
+	}
+
+	var content interface{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		// a file that can't be reduced to its metadata is left out
+		return result.RemoveResult(bundlePath, fileName)
+	}
+
+	content = metadataOnlyValue(content, metadataOnlyResourceKind(fileName))
+	if path.Base(fileName) == constants.CLUSTER_RESOURCES_EVENTS_SUMMARY+".json" {
+		dropMetadataOnlyField(content, "lastMessage")
+	}
+
+	b, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", fileName)
+	}
+	if err := result.ReplaceResult(bundlePath, fileName, bytes.NewBuffer(b)); err != nil {
+		return errors.Wrapf(err, "failed to replace %s", fileName)
+	}
+
+	return nil
+}
+
+// metadataOnlyResourceKind returns the kind of the objects in a cluster resources file, or an
+// empty string when it is not known
+func metadataOnlyResourceKind(fileName string) string {
+	relativePath, ok := strings.CutPrefix(fileName, constants.CLUSTER_RESOURCES_DIR+"/")
+	if !ok {
+		return ""
+	}
+	resource, _, _ := strings.Cut(relativePath, "/")
+	return metadataOnlyResourceKinds[strings.TrimSuffix(resource, ".json")]
+}
+
+// metadataOnlyValue reduces the objects in a decoded JSON file, the file can hold a list, an array
+// of objects or a single object. Anything that is not a Kubernetes object is returned unchanged.
+func metadataOnlyValue(value interface{}, kind string) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = metadataOnlyValue(v[i], kind)
+		}
+		return v
+	case map[string]interface{}:
+		if items, ok := v["items"].([]interface{}); ok {
+			v["items"] = metadataOnlyValue(items, kind)
+			return v
+		}
+		if _, ok := v["metadata"].(map[string]interface{}); ok {
+			return metadataOnlyObject(v, kind)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func metadataOnlyObject(object map[string]interface{}, kind string) map[string]interface{} {
+	if objectKind, ok := object["kind"].(string); ok && objectKind != "" {
+		kind = objectKind
+	}
+
+	reduced := map[string]interface{}{}
+	copyFields := func(dst, src map[string]interface{}, fields []string) {
+		for _, field := range fields {
+			if value, ok := src[field]; ok {
+				dst[field] = value
+			}
+		}
+	}
+
+	copyFields(reduced, object, append([]string{"apiVersion", "kind", "status"}, metadataOnlyTopLevelFields[kind]...))
+
+	metadata := map[string]interface{}{}
+	copyFields(metadata, object["metadata"].(map[string]interface{}), metadataOnlyMetadataFields)
+	reduced["metadata"] = metadata
+
+	if spec, ok := object["spec"].(map[string]interface{}); ok && len(metadataOnlySpecFields[kind]) > 0 {
+		reducedSpec := map[string]interface{}{}
+		copyFields(reducedSpec, spec, metadataOnlySpecFields[kind])
+		reduced["spec"] = reducedSpec
+	}
+
+	if kind == "Pod" {
+		dropTerminationMessages(reduced["status"])
+	}
+
+	return reduced
+}
+
+// dropTerminationMessages removes the messages containers write when they terminate from a pod
+// status, they can hold anything the application wrote
+func dropTerminationMessages(status interface{}) {
+	statusMap, ok := status.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, field := range []string{"initContainerStatuses", "containerStatuses", "ephemeralContainerStatuses"} {
+		containerStatuses, _ := statusMap[field].([]interface{})
+		for _, containerStatus := range containerStatuses {
+			containerStatusMap, ok := containerStatus.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, state := range []string{"state", "lastState"} {
+				stateMap, _ := containerStatusMap[state].(map[string]interface{})
+				if terminated, ok := stateMap["terminated"].(map[string]interface{}); ok {
+					delete(terminated, "message")
+				}
+			}
+		}
+	}
+}
+
+// dropMetadataOnlyField removes field from each object in an array
+func dropMetadataOnlyField(content interface{}, field string) {
+	entries, _ := content.([]interface{})
+	for _, entry := range entries {
+		if entryMap, ok := entry.(map[string]interface{}); ok {
+			delete(entryMap, field)
+		}
+	}
+}
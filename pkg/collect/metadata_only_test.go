@@ -0,0 +1,122 @@
+package collect
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataOnlyResult(t *testing.T) {
+	result := CollectorResult{
+		"cluster-info/cluster_version.json": []byte(`{"info": {"major": "1", "minor": "31"}, "string": "v1.31.2"}`),
+		"cluster-resources/pods/default.json": []byte(`{
+  "kind": "PodList",
+  "apiVersion": "v1",
+  "metadata": {"resourceVersion": "1024"},
+  "items": [
+    {
+      "metadata": {
+        "name": "api-7d9f8b6c5-x2vbn",
+        "namespace": "default",
+        "labels": {"app": "api"},
+        "annotations": {"kubectl.kubernetes.io/last-applied-configuration": "{\"spec\":{}}"},
+        "managedFields": [{"manager": "kubectl"}]
+      },
+      "spec": {
+        "nodeName": "node-1",
+        "containers": [{"name": "api", "image": "api:1.2.0", "args": ["--password=hunter2"], "env": [{"name": "DB_PASSWORD", "value": "hunter2"}]}],
+        "volumes": [{"name": "config", "configMap": {"name": "api"}}]
+      },
+      "status": {
+        "phase": "Running",
+        "containerStatuses": [{"name": "api", "restartCount": 1, "lastState": {"terminated": {"exitCode": 1, "reason": "Error", "message": "connecting with password hunter2"}}}]
+      }
+    }
+  ]
+}`),
+		"cluster-resources/configmaps/default.json":                       []byte(`{"kind": "ConfigMapList", "items": [{"metadata": {"name": "api", "namespace": "default"}, "data": {"password": "hunter2"}, "binaryData": {"key": "aHVudGVyMg=="}}]}`),
+		"cluster-resources/events/default.json":                           []byte(`{"kind": "EventList", "items": [{"metadata": {"name": "api.17a8"}, "type": "Warning", "reason": "BackOff", "count": 3, "message": "password hunter2 rejected", "involvedObject": {"kind": "Pod", "name": "api-7d9f8b6c5-x2vbn"}}]}`),
+		"cluster-resources/storage-classes.json":                          []byte(`{"kind": "StorageClassList", "items": [{"metadata": {"name": "standard"}, "provisioner": "ebs.csi.aws.com", "parameters": {"kmsKeyId": "hunter2"}}]}`),
+		"cluster-resources/events-summary.json":                           []byte(`[{"namespace": "default", "reason": "BackOff", "count": 3, "lastMessage": "password hunter2 rejected"}]`),
+		"cluster-resources/last-applied/default.json":                     []byte(`{"spec": {"password": "hunter2"}}`),
+		"cluster-resources/image-pull-secrets/default.json":               []byte(`{"auths": {"registry": {"auth": "hunter2"}}}`),
+		"cluster-resources/pods/logs/default/api-7d9f8b6c5-x2vbn/api.log": []byte("password hunter2\n"),
+		"cluster-resources/pods-errors.json":                              []byte(`["pods is forbidden in namespace secret-ns"]`),
+	}
+
+	require.NoError(t, MetadataOnlyResult("", result))
+
+	fileNames := []string{}
+	for fileName := range result {
+		fileNames = append(fileNames, fileName)
+	}
+	assert.ElementsMatch(t, []string{
+		"cluster-info/cluster_version.json",
+		"cluster-resources/pods/default.json",
+		"cluster-resources/configmaps/default.json",
+		"cluster-resources/events/default.json",
+		"cluster-resources/storage-classes.json",
+		"cluster-resources/events-summary.json",
+		"cluster-resources/pods-errors.json",
+	}, fileNames)
+
+	valueFields := map[string]bool{
+		"annotations":   true,
+		"managedFields": true,
+		"containers":    true,
+		"volumes":       true,
+		"args":          true,
+		"env":           true,
+		"data":          true,
+		"binaryData":    true,
+		"parameters":    true,
+		"message":       true,
+		"lastMessage":   true,
+	}
+	for fileName, data := range result {
+		assert.NotContains(t, string(data), "hunter2", fileName)
+
+		var content interface{}
+		require.NoError(t, json.Unmarshal(data, &content), fileName)
+		for _, field := range collectJSONFields(content) {
+			assert.False(t, valueFields[field], "%s has value field %s", fileName, field)
+		}
+	}
+
+	var pods struct {
+		Items []struct {
+			Metadata map[string]interface{} `json:"metadata"`
+			Spec     map[string]interface{} `json:"spec"`
+			Status   map[string]interface{} `json:"status"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(result["cluster-resources/pods/default.json"], &pods))
+	require.Len(t, pods.Items, 1)
+	assert.Equal(t, "api-7d9f8b6c5-x2vbn", pods.Items[0].Metadata["name"])
+	assert.Equal(t, map[string]interface{}{"app": "api"}, pods.Items[0].Metadata["labels"])
+	assert.Equal(t, map[string]interface{}{"nodeName": "node-1"}, pods.Items[0].Spec)
+	assert.Equal(t, "Running", pods.Items[0].Status["phase"])
+
+	assert.Contains(t, string(result["cluster-resources/events/default.json"]), `"reason": "BackOff"`)
+	assert.Contains(t, string(result["cluster-resources/storage-classes.json"]), `"provisioner": "ebs.csi.aws.com"`)
+	assert.Contains(t, string(result["cluster-info/cluster_version.json"]), `"string": "v1.31.2"`)
+}
+
+// collectJSONFields returns the names of all object fields in decoded JSON
+func collectJSONFields(value interface{}) []string {
+	fields := []string{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for field, fieldValue := range v {
+			fields = append(fields, field)
+			fields = append(fields, collectJSONFields(fieldValue)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			fields = append(fields, collectJSONFields(item)...)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,104 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+const (
+	metricsGroup = "metrics.k8s.io"
+	// metricsServerNotInstalled is recorded in the errors file when the metrics API is not served,
+	// analyzers report it to explain why resource utilization can not be checked
+	metricsServerNotInstalled = "metrics-server is not installed, the metrics.k8s.io API is not served"
+)
+
+type CollectMetricsServer struct {
+	Collector    *troubleshootv1beta2.MetricsServer
+	BundlePath   string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+func (c *CollectMetricsServer) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectMetricsServer) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectMetricsServer) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	dynamicClient, err := dynamic.NewForConfig(c.ClientConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create dynamic client")
+	}
+
+	files, errs := metricsServerMetrics(c.Context, c.Client.Discovery(), dynamicClient)
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(constants.METRICS_SERVER_DIR, fileName), bytes.NewBuffer(data))
+	}
+	output.SaveResult(c.BundlePath, path.Join(constants.METRICS_SERVER_DIR, "errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// metricsServerMetrics lists the NodeMetrics and PodMetrics of all namespaces and returns the lists
+// as nodes.json and pods.json. When the metrics API is not served only an error saying that
+// metrics-server is not installed is returned.
+func metricsServerMetrics(ctx context.Context, dc discovery.DiscoveryInterface, client dynamic.Interface) (map[string][]byte, []string) {
+	groups, err := dc.ServerGroups()
+	if err != nil {
+		return nil, []string{errors.Wrap(err, "failed to list api groups").Error()}
+	}
+
+	version := ""
+	for _, group := range groups.Groups {
+		if group.Name == metricsGroup {
+			version = group.PreferredVersion.Version
+		}
+	}
+	if version == "" {
+		klog.V(2).Info("metrics api group was not found, skipping metrics-server collection")
+		return nil, []string{metricsServerNotInstalled}
+	}
+
+	files := map[string][]byte{}
+	errorList := []string{}
+	for _, resource := range []string{"nodes", "pods"} {
+		gvr := schema.GroupVersionResource{Group: metricsGroup, Version: version, Resource: resource}
+
+		// the group is listed while the metrics-server APIService is unavailable, the list then
+		// fails with a service unavailable error which is recorded as is
+		list, err := client.Resource(gvr).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errorList = append(errorList, errors.Wrapf(err, "failed to list %s", gvr.GroupResource()).Error())
+			continue
+		}
+
+		b, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			errorList = append(errorList, errors.Wrapf(err, "failed to marshal %s", gvr.GroupResource()).Error())
+			continue
+		}
+		files[resource+".json"] = b
+	}
+
+	return files, errorList
+}
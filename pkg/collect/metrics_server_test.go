@@ -0,0 +1,97 @@
+package collect
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	testdynamicclient "k8s.io/client-go/dynamic/fake"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_metricsServerMetrics(t *testing.T) {
+	nodesGVR := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+	podsGVR := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	metrics := func(kind, namespace, name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "metrics.k8s.io/v1beta1",
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"window": "10.062s",
+		}}
+	}
+
+	listKinds := map[schema.GroupVersionResource]string{
+		nodesGVR: "NodeMetricsList",
+		podsGVR:  "PodMetricsList",
+	}
+
+	metricsResources := []*metav1.APIResourceList{
+		{GroupVersion: "metrics.k8s.io/v1beta1", APIResources: []metav1.APIResource{
+			{Name: "nodes", Kind: "NodeMetrics"},
+			{Name: "pods", Kind: "PodMetrics", Namespaced: true},
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		wantFiles map[string]int
+		wantErrs  []string
+	}{
+		{
+			name:      "metrics-server not installed",
+			resources: []*metav1.APIResourceList{},
+			wantFiles: map[string]int{},
+			wantErrs:  []string{metricsServerNotInstalled},
+		},
+		{
+			name:      "node and pod metrics",
+			resources: metricsResources,
+			wantFiles: map[string]int{
+				"nodes.json": 2,
+				"pods.json":  2,
+			},
+			wantErrs: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testclient.NewSimpleClientset()
+			fakeDiscovery, ok := client.Discovery().(*fakediscovery.FakeDiscovery)
+			require.True(t, ok)
+			fakeDiscovery.Resources = tt.resources
+
+			// the metrics resources are named nodes and pods, their kinds can not be used to guess them
+			dynamicClient := testdynamicclient.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+			for _, obj := range []*unstructured.Unstructured{metrics("NodeMetrics", "", "node-1"), metrics("NodeMetrics", "", "node-2")} {
+				require.NoError(t, dynamicClient.Tracker().Create(nodesGVR, obj, ""))
+			}
+			for _, obj := range []*unstructured.Unstructured{metrics("PodMetrics", "default", "api-0"), metrics("PodMetrics", "web", "frontend-0")} {
+				require.NoError(t, dynamicClient.Tracker().Create(podsGVR, obj, obj.GetNamespace()))
+			}
+
+			files, errs := metricsServerMetrics(context.Background(), fakeDiscovery, dynamicClient)
+			assert.Equal(t, tt.wantErrs, errs)
+
+			gotFiles := map[string]int{}
+			for fileName, data := range files {
+				list := unstructured.UnstructuredList{}
+				require.NoError(t, json.Unmarshal(data, &list))
+				gotFiles[fileName] = len(list.Items)
+			}
+			assert.Equal(t, tt.wantFiles, gotFiles)
+		})
+	}
+}
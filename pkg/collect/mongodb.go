@@ -0,0 +1,170 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// mongodbTimeout bounds server selection and each command, the driver would otherwise keep
+// retrying an unreachable server for 30 seconds
+const mongodbTimeout = 10 * time.Second
+
+const (
+	MongodbRolePrimary    = "primary"
+	MongodbRoleSecondary  = "secondary"
+	MongodbRoleArbiter    = "arbiter"
+	MongodbRoleMongos     = "mongos"
+	MongodbRoleStandalone = "standalone"
+	MongodbRoleOther      = "other"
+)
+
+type CollectMongodb struct {
+	Collector    *troubleshootv1beta2.Database
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+// mongodbHello is the part of the hello (or legacy isMaster) command response used to derive the
+// replica set role of the server
+type mongodbHello struct {
+	IsWritablePrimary bool   `bson:"isWritablePrimary"`
+	IsMaster          bool   `bson:"ismaster"`
+	Secondary         bool   `bson:"secondary"`
+	ArbiterOnly       bool   `bson:"arbiterOnly"`
+	SetName           string `bson:"setName"`
+	Msg               string `bson:"msg"`
+}
+
+func (c *CollectMongodb) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectMongodb) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectMongodb) createClient() (*mongo.Client, error) {
+	opts := options.Client().
+		ApplyURI(c.Collector.URI).
+		SetServerSelectionTimeout(mongodbTimeout).
+		SetTimeout(mongodbTimeout)
+
+	if c.Collector.TLS != nil {
+		klog.V(2).Infof("Connecting to mongodb in mutual TLS")
+		tlsCfg, err := createTLSConfig(c.Context, c.Client, c.Collector.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsCfg)
+	} else {
+		klog.V(2).Infof("Connecting to mongodb using the TLS settings of the URI")
+	}
+
+	return mongo.Connect(opts)
+}
+
+func (c *CollectMongodb) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	databaseConnection := DatabaseConnection{}
+
+	client, err := c.createClient()
+	if err != nil {
+		databaseConnection.Error = err.Error()
+	} else {
+		defer client.Disconnect(context.Background())
+
+		admin := client.Database("admin")
+
+		hello := mongodbHello{}
+		if err := admin.RunCommand(c.Context, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+			// hello was added in MongoDB 4.4.2 (and 4.2.10), older servers only know isMaster
+			klog.V(2).Infof("Failed to run hello, falling back to isMaster: %v", err)
+			hello = mongodbHello{}
+			err = admin.RunCommand(c.Context, bson.D{{Key: "isMaster", Value: 1}}).Decode(&hello)
+			if err != nil {
+				databaseConnection.Error = err.Error()
+			}
+		}
+
+		databaseConnection.IsConnected = databaseConnection.Error == ""
+
+		if databaseConnection.IsConnected {
+			databaseConnection.ReplicaSetRole = mongodbReplicaSetRole(hello)
+			databaseConnection.ReplicaSet = hello.SetName
+
+			version, err := c.serverVersion(admin)
+			if err != nil {
+				databaseConnection.Error = err.Error()
+			}
+			databaseConnection.Version = version
+		}
+	}
+
+	b, err := json.Marshal(databaseConnection)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal database connection")
+	}
+
+	collectorName := c.Collector.CollectorName
+	if collectorName == "" {
+		collectorName = "mongodb"
+	}
+
+	output := NewResult()
+	output.SaveResult(c.BundlePath, fmt.Sprintf("mongodb/%s.json", collectorName), bytes.NewBuffer(b))
+
+	return output, nil
+}
+
+// serverVersion returns the version reported by serverStatus. serverStatus requires the
+// clusterMonitor role, so buildInfo, which any user can run, is used when it is denied.
+func (c *CollectMongodb) serverVersion(admin *mongo.Database) (string, error) {
+	status := struct {
+		Version string `bson:"version"`
+	}{}
+
+	err := admin.RunCommand(c.Context, bson.D{{Key: "serverStatus", Value: 1}}).Decode(&status)
+	if err == nil && status.Version != "" {
+		return status.Version, nil
+	}
+	klog.V(2).Infof("Failed to get mongodb version from serverStatus, falling back to buildInfo: %v", err)
+
+	if err := admin.RunCommand(c.Context, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&status); err != nil {
+		return "", errors.Wrap(err, "failed to get server version")
+	}
+	return status.Version, nil
+}
+
+// mongodbReplicaSetRole returns the role of the server in its deployment from a hello response
+func mongodbReplicaSetRole(hello mongodbHello) string {
+	switch {
+	case hello.Msg == "isdbgrid":
+		return MongodbRoleMongos
+	case hello.SetName == "":
+		return MongodbRoleStandalone
+	case hello.IsWritablePrimary || hello.IsMaster:
+		return MongodbRolePrimary
+	case hello.Secondary:
+		return MongodbRoleSecondary
+	case hello.ArbiterOnly:
+		return MongodbRoleArbiter
+	default:
+		// e.g. a member that is starting up or recovering
+		return MongodbRoleOther
+	}
+}
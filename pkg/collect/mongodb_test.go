@@ -0,0 +1,56 @@
+package collect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mongodbReplicaSetRole(t *testing.T) {
+	tests := []struct {
+		name  string
+		hello mongodbHello
+		want  string
+	}{
+		{
+			name:  "standalone",
+			hello: mongodbHello{IsWritablePrimary: true},
+			want:  MongodbRoleStandalone,
+		},
+		{
+			name:  "primary",
+			hello: mongodbHello{IsWritablePrimary: true, SetName: "rs0"},
+			want:  MongodbRolePrimary,
+		},
+		{
+			name:  "primary from isMaster",
+			hello: mongodbHello{IsMaster: true, SetName: "rs0"},
+			want:  MongodbRolePrimary,
+		},
+		{
+			name:  "secondary",
+			hello: mongodbHello{Secondary: true, SetName: "rs0"},
+			want:  MongodbRoleSecondary,
+		},
+		{
+			name:  "arbiter",
+			hello: mongodbHello{ArbiterOnly: true, SetName: "rs0"},
+			want:  MongodbRoleArbiter,
+		},
+		{
+			name:  "mongos",
+			hello: mongodbHello{IsWritablePrimary: true, Msg: "isdbgrid"},
+			want:  MongodbRoleMongos,
+		},
+		{
+			name:  "recovering member",
+			hello: mongodbHello{SetName: "rs0"},
+			want:  MongodbRoleOther,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mongodbReplicaSetRole(tt.hello))
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"github.com/replicatedhq/troubleshoot/pkg/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+const (
+	iperfImage = "networkstatic/iperf3:latest"
+	iperfPort  = 5201
+)
+
+type CollectNetworkThroughput struct {
+	Collector    *troubleshootv1beta2.NetworkThroughput
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+// NetworkThroughputResult is the throughput measured from a client node to a server node
+type NetworkThroughputResult struct {
+	ServerNode      string  `json:"serverNode"`
+	ClientNode      string  `json:"clientNode"`
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+	SentMbps        float64 `json:"sentMbps"`
+	ReceivedMbps    float64 `json:"receivedMbps"`
+	Retransmits     int     `json:"retransmits"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// iperfOutput is the part of the `iperf3 --json` output of the client that is used
+type iperfOutput struct {
+	End struct {
+		SumSent struct {
+			Seconds       float64 `json:"seconds"`
+			BitsPerSecond float64 `json:"bits_per_second"`
+			Retransmits   int     `json:"retransmits"`
+		} `json:"sum_sent"`
+		SumReceived struct {
+			BitsPerSecond float64 `json:"bits_per_second"`
+		} `json:"sum_received"`
+	} `json:"end"`
+	Error string `json:"error"`
+}
+
+func (c *CollectNetworkThroughput) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectNetworkThroughput) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+// Collect runs an iperf3 server pod on one node and a client pod on another, and saves the
+// throughput measured by the client. Both pods are deleted before returning, waiting for them to
+// be gone so that no terminating pods are left behind in the cluster resources collected later.
+func (c *CollectNetworkThroughput) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	duration, err := parseCollectDelay(c.Collector.Duration, "5s")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse duration")
+	}
+	timeout, err := parseCollectDelay(c.Collector.Timeout, "2m")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse timeout")
+	}
+
+	serverNode, clientNode, err := throughputNodes(c.Context, c.Client, c.Collector)
+	if err != nil {
+		output.SaveResult(c.BundlePath, path.Join(constants.NETWORK_THROUGHPUT_DIR, "errors.json"), marshalErrors([]string{err.Error()}))
+		return output, nil
+	}
+
+	result := NetworkThroughputResult{ServerNode: serverNode, ClientNode: clientNode}
+	logs, err := c.runIperf(serverNode, clientNode, duration, timeout)
+	if err == nil {
+		err = parseIperfOutput(logs, &result)
+	}
+	if err != nil {
+		klog.Errorf("Failed to measure network throughput from node %s to node %s: %v", clientNode, serverNode, err)
+		result.Error = err.Error()
+	}
+
+	b, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal network throughput")
+	}
+	fileName := path.Join(constants.NETWORK_THROUGHPUT_DIR, fmt.Sprintf("%s-%s.json", clientNode, serverNode))
+	output.SaveResult(c.BundlePath, fileName, bytes.NewBuffer(b))
+
+	return output, nil
+}
+
+// runIperf returns the logs of the iperf3 client once it completed
+func (c *CollectNetworkThroughput) runIperf(serverNode, clientNode string, duration, timeout time.Duration) ([]byte, error) {
+	namespace := "default"
+	if c.Collector.Namespace != "" {
+		namespace = c.Collector.Namespace
+	}
+	image := iperfImage
+	if c.Collector.Image != "" {
+		image = c.Collector.Image
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, timeout)
+	defer cancel()
+
+	serverPodSpec := throughputPod(namespace, image, "server", serverNode, []string{"iperf3", "--server", "--port", strconv.Itoa(iperfPort)})
+	serverPodSpec.Spec.Containers[0].ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(iperfPort)},
+		},
+		PeriodSeconds: 1,
+	}
+	serverPod, err := c.Client.CoreV1().Pods(namespace).Create(ctx, serverPodSpec, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create iperf server pod")
+	}
+	defer deletePod(c.Context, c.Client, serverPod)
+
+	serverPod, err = waitForPod(ctx, c.Client, serverPod, func(pod *corev1.Pod) bool {
+		return pod.Status.PodIP != "" && isPodReady(pod)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to wait for iperf server pod")
+	}
+
+	clientPodSpec := throughputPod(namespace, image, "client", clientNode, []string{
+		"iperf3", "--client", serverPod.Status.PodIP, "--port", strconv.Itoa(iperfPort),
+		"--time", strconv.Itoa(int(math.Ceil(duration.Seconds()))), "--json",
+	})
+	clientPod, err := c.Client.CoreV1().Pods(namespace).Create(ctx, clientPodSpec, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create iperf client pod")
+	}
+	defer deletePod(c.Context, c.Client, clientPod)
+
+	// a client that fails to connect still prints its error as JSON
+	clientPod, err = waitForPod(ctx, c.Client, clientPod, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to wait for iperf client pod")
+	}
+
+	logs, err := c.Client.CoreV1().Pods(namespace).GetLogs(clientPod.Name, &corev1.PodLogOptions{}).DoRaw(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get iperf client logs")
+	}
+	return logs, nil
+}
+
+// throughputNodes returns the server and client nodes of the collector, picking the first ready
+// nodes by name for those that are not set
+func throughputNodes(ctx context.Context, client kubernetes.Interface, collector *troubleshootv1beta2.NetworkThroughput) (string, string, error) {
+	serverNode, clientNode := collector.ServerNode, collector.ClientNode
+	if serverNode == "" || clientNode == "" {
+		nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+			LabelSelector: strings.Join(collector.Selector, ","),
+		})
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to list nodes")
+		}
+
+		readyNodes := []string{}
+		for _, node := range nodes.Items {
+			if k8sutil.NodeIsReady(node) {
+				readyNodes = append(readyNodes, node.Name)
+			}
+		}
+		sort.Strings(readyNodes)
+
+		for _, nodeName := range readyNodes {
+			if serverNode == "" && nodeName != clientNode {
+				serverNode = nodeName
+			} else if clientNode == "" && nodeName != serverNode {
+				clientNode = nodeName
+			}
+		}
+	}
+
+	if serverNode == "" || clientNode == "" {
+		return "", "", errors.New("two ready nodes are required to measure network throughput")
+	}
+	if serverNode == clientNode {
+		return "", "", errors.Errorf("server and client nodes must be different, both are %s", serverNode)
+	}
+	return serverNode, clientNode, nil
+}
+
+func throughputPod(namespace, image, role, nodeName string, command []string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("network-throughput-%s-", role),
+			Namespace:    namespace,
+			Labels: map[string]string{
+				"troubleshoot-role": "network-throughput",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{
+				"kubernetes.io/hostname": nodeName,
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:            "iperf",
+					Image:           image,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Command:         command,
+				},
+			},
+			Tolerations: []corev1.Toleration{
+				{
+					Key:      "node-role.kubernetes.io/master",
+					Operator: "Exists",
+					Effect:   "NoSchedule",
+				},
+				{
+					Key:      "node-role.kubernetes.io/control-plane",
+					Operator: "Exists",
+					Effect:   "NoSchedule",
+				},
+			},
+		},
+	}
+}
+
+// waitForPod polls a pod until done returns true. It fails early when the pod can't start.
+func waitForPod(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod, done func(*corev1.Pod) bool) (*corev1.Pod, error) {
+	var current *corev1.Pod
+	err := wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
+		got, err := client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get pod")
+		}
+		current = got
+
+		if done(got) {
+			return true, nil
+		}
+		if got.Status.Phase == corev1.PodSucceeded || got.Status.Phase == corev1.PodFailed {
+			return false, errors.Errorf("pod %s exited with phase %s", got.Name, got.Status.Phase)
+		}
+		for _, status := range got.Status.ContainerStatuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason == "ImagePullBackOff" {
+				return false, errors.Errorf("pod %s can't pull image %s", got.Name, status.Image)
+			}
+		}
+		return false, nil
+	})
+	return current, err
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// parseIperfOutput sets the throughput of the result from the JSON output of an iperf3 client
+func parseIperfOutput(logs []byte, result *NetworkThroughputResult) error {
+	var output iperfOutput
+	if err := json.Unmarshal(logs, &output); err != nil {
+		return errors.Wrap(err, "failed to parse iperf output")
+	}
+	if output.Error != "" {
+		return errors.Errorf("iperf: %s", output.Error)
+	}
+
+	result.DurationSeconds = output.End.SumSent.Seconds
+	result.SentMbps = bitsToMegabits(output.End.SumSent.BitsPerSecond)
+	result.ReceivedMbps = bitsToMegabits(output.End.SumReceived.BitsPerSecond)
+	result.Retransmits = output.End.SumSent.Retransmits
+	return nil
+}
+
+func bitsToMegabits(bits float64) float64 {
+	return math.Round(bits/1e4) / 100
+}
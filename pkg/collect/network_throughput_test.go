@@ -0,0 +1,137 @@
+package collect
+
+import (
+	"context"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_throughputNodes(t *testing.T) {
+	client := testclient.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node3", Labels: map[string]string{"zone": "b"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"zone": "a"}}},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"zone": "a"}},
+			Spec:       corev1.NodeSpec{Taints: []corev1.Taint{{Key: k8sutil.NotReadyTaint}}},
+		},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node4", Labels: map[string]string{"zone": "a"}}},
+	)
+
+	tests := []struct {
+		name       string
+		collector  troubleshootv1beta2.NetworkThroughput
+		wantServer string
+		wantClient string
+		wantErr    bool
+	}{
+		{
+			name:       "first ready nodes",
+			wantServer: "node1",
+			wantClient: "node3",
+		},
+		{
+			name:       "selector",
+			collector:  troubleshootv1beta2.NetworkThroughput{Selector: []string{"zone=a"}},
+			wantServer: "node1",
+			wantClient: "node4",
+		},
+		{
+			name:       "server node",
+			collector:  troubleshootv1beta2.NetworkThroughput{ServerNode: "node3"},
+			wantServer: "node3",
+			wantClient: "node1",
+		},
+		{
+			name:       "client node",
+			collector:  troubleshootv1beta2.NetworkThroughput{ClientNode: "node1"},
+			wantServer: "node3",
+			wantClient: "node1",
+		},
+		{
+			name:       "both nodes",
+			collector:  troubleshootv1beta2.NetworkThroughput{ServerNode: "node2", ClientNode: "node5"},
+			wantServer: "node2",
+			wantClient: "node5",
+		},
+		{
+			name:      "single ready node",
+			collector: troubleshootv1beta2.NetworkThroughput{Selector: []string{"zone=b"}},
+			wantErr:   true,
+		},
+		{
+			name:      "same nodes",
+			collector: troubleshootv1beta2.NetworkThroughput{ServerNode: "node1", ClientNode: "node1"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotServer, gotClient, err := throughputNodes(context.Background(), client, &tt.collector)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantServer, gotServer)
+			assert.Equal(t, tt.wantClient, gotClient)
+		})
+	}
+}
+
+func Test_parseIperfOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		logs    string
+		want    NetworkThroughputResult
+		wantErr bool
+	}{
+		{
+			name: "completed",
+			logs: `{
+	"start": {"connected": [{"socket": 5, "local_host": "10.0.1.5", "remote_host": "10.0.2.7", "remote_port": 5201}]},
+	"intervals": [],
+	"end": {
+		"sum_sent": {"start": 0, "end": 5.000123, "seconds": 5.000123, "bytes": 5905580032, "bits_per_second": 9448698123.4, "retransmits": 17, "sender": true},
+		"sum_received": {"start": 0, "end": 5.04, "seconds": 5.04, "bytes": 5903482880, "bits_per_second": 9370607746.03, "sender": true}
+	}
+}`,
+			want: NetworkThroughputResult{
+				ServerNode:      "node1",
+				ClientNode:      "node2",
+				DurationSeconds: 5.000123,
+				SentMbps:        9448.7,
+				ReceivedMbps:    9370.61,
+				Retransmits:     17,
+			},
+		},
+		{
+			name:    "connection refused",
+			logs:    `{"start": {}, "intervals": [], "end": {}, "error": "unable to connect to server: Connection refused"}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			logs:    "iperf3: error - unable to connect to server",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NetworkThroughputResult{ServerNode: "node1", ClientNode: "node2"}
+			err := parseIperfOutput([]byte(tt.logs), &result)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
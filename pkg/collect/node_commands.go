@@ -0,0 +1,389 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	nodeCommandsDir              = "host-collectors/commands"
+	nodeCommandsDefaultImage     = "replicated/troubleshoot:latest"
+	nodeCommandsDefaultMaxOutput = 1024 * 1024
+	nodeCommandsContainerName    = "node-commands"
+	nodeCommandsPodPollInterval  = time.Second
+	// nodeCommandsPodStartTimeout is how long a collection pod has to start running on its node,
+	// when the collector sets no timeout
+	nodeCommandsPodStartTimeout = 2 * time.Minute
+	// nodeCommandsExecTimeout is how long a command can run on a node, when the collector sets no
+	// timeout
+	nodeCommandsExecTimeout = time.Minute
+)
+
+// NodeCommandAllowlist is the set of commands the nodeCommands collector may run on a node, keyed
+// by the name used in the collector spec. Commands run in the host's namespaces. Programs
+// embedding troubleshoot can add or remove entries before collecting.
+var NodeCommandAllowlist = map[string][]string{
+	"ip-addr":          {"ip", "addr", "show"},
+	"ip-route":         {"ip", "route", "show", "table", "all"},
+	"ip-rule":          {"ip", "rule", "show"},
+	"ip-link":          {"ip", "-s", "link", "show"},
+	"iptables-save":    {"iptables-save"},
+	"ip6tables-save":   {"ip6tables-save"},
+	"nft-list-ruleset": {"nft", "list", "ruleset"},
+	"ss-listening":     {"ss", "-tulpn"},
+	"dmesg":            {"dmesg", "-T"},
+	"mount":            {"cat", "/proc/mounts"},
+	"lsmod":            {"lsmod"},
+	"df":               {"df", "-h"},
+}
+
+type CollectNodeCommands struct {
+	Collector    *troubleshootv1beta2.NodeCommands
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+// nodeCommandExecutor runs commands on nodes. Start must be called for a node before Exec, and
+// Stop releases anything Start created.
+type nodeCommandExecutor interface {
+	Start(ctx context.Context, nodeName string) error
+	Exec(ctx context.Context, nodeName string, command []string) (stdout []byte, stderr []byte, err error)
+	Stop(nodeName string)
+}
+
+func (c *CollectNodeCommands) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectNodeCommands) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectNodeCommands) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	ctx := c.Context
+	if c.Collector.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Collector.Timeout)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse timeout")
+		}
+		if timeout > 0 {
+			childCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			ctx = childCtx
+		}
+	}
+
+	maxOutputSize := int64(nodeCommandsDefaultMaxOutput)
+	if c.Collector.MaxOutputSize != "" {
+		quantity, err := resource.ParseQuantity(c.Collector.MaxOutputSize)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse max output size %q", c.Collector.MaxOutputSize)
+		}
+		maxOutputSize = quantity.Value()
+	}
+
+	namespace := c.Collector.Namespace
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+	if namespace == "" {
+		kubeconfig := k8sutil.GetKubeconfig()
+		namespace, _, _ = kubeconfig.Namespace()
+	}
+
+	nodes, err := c.Client.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(c.Collector.NodeSelector).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list nodes")
+	}
+	nodeNames := []string{}
+	for _, node := range nodes.Items {
+		if k8sutil.NodeIsReady(node) {
+			nodeNames = append(nodeNames, node.Name)
+		}
+	}
+
+	executor := &podNodeCommandExecutor{
+		client:          c.Client,
+		clientConfig:    c.ClientConfig,
+		namespace:       namespace,
+		image:           c.Collector.Image,
+		imagePullPolicy: c.Collector.ImagePullPolicy,
+		pods:            map[string]string{},
+	}
+
+	if c.Collector.ImagePullSecret != nil {
+		executor.imagePullSecretName = c.Collector.ImagePullSecret.Name
+
+		if c.Collector.ImagePullSecret.Data != nil {
+			secretName, err := createSecret(ctx, c.Client, namespace, c.Collector.ImagePullSecret)
+			if err != nil {
+				return nil, errors.Wrap(err, "create image pull secret")
+			}
+			defer func() {
+				err := c.Client.CoreV1().Secrets(namespace).Delete(context.Background(), c.Collector.ImagePullSecret.Name, metav1.DeleteOptions{})
+				if err != nil && !kuberneteserrors.IsNotFound(err) {
+					klog.Errorf("Failed to delete secret %s: %v", c.Collector.ImagePullSecret.Name, err)
+				}
+			}()
+
+			executor.imagePullSecretName = secretName
+		}
+	}
+
+	files, errs := nodeCommands(ctx, executor, nodeNames, c.Collector.Commands, maxOutputSize)
+
+	output := NewResult()
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(nodeCommandsDir, fileName), bytes.NewBuffer(data))
+	}
+	for nodeName, nodeErrs := range errs {
+		output.SaveResult(c.BundlePath, path.Join(nodeCommandsDir, nodeName, "errors.json"), marshalErrors(nodeErrs))
+	}
+
+	return output, nil
+}
+
+// nodeCommands runs the named allowlisted commands on each node and returns their output keyed by
+// "<node>/<command>.txt". Output beyond maxOutputSize bytes is dropped. Errors, including
+// commands that are not in the allowlist, are returned per node.
+func nodeCommands(ctx context.Context, executor nodeCommandExecutor, nodeNames []string, commandNames []string, maxOutputSize int64) (map[string][]byte, map[string][]string) {
+	files := map[string][]byte{}
+	errsByNode := map[string][]string{}
+	mtx := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	allowed := []string{}
+	rejected := []string{}
+	for _, name := range commandNames {
+		if _, ok := NodeCommandAllowlist[name]; ok {
+			allowed = append(allowed, name)
+		} else {
+			rejected = append(rejected, fmt.Sprintf("command %q is not in the node command allowlist", name))
+		}
+	}
+	sort.Strings(allowed)
+
+	for _, nodeName := range nodeNames {
+		wg.Add(1)
+		go func(nodeName string) {
+			defer wg.Done()
+
+			nodeFiles := map[string][]byte{}
+			errs := append([]string{}, rejected...)
+
+			if len(allowed) > 0 {
+				if err := executor.Start(ctx, nodeName); err != nil {
+					errs = append(errs, errors.Wrapf(err, "failed to start collection pod on node %s", nodeName).Error())
+				} else {
+					for _, name := range allowed {
+						stdout, stderr, err := executor.Exec(ctx, nodeName, NodeCommandAllowlist[name])
+						if err != nil {
+							errs = append(errs, errors.Wrapf(err, "failed to run %s", name).Error())
+						}
+						if len(stderr) > 0 {
+							nodeFiles[fmt.Sprintf("%s/%s-stderr.txt", nodeName, name)] = truncateNodeCommandOutput(stderr, maxOutputSize)
+						}
+						if int64(len(stdout)) > maxOutputSize {
+							errs = append(errs, fmt.Sprintf("output of %s was truncated from %d to %d bytes", name, len(stdout), maxOutputSize))
+						}
+						nodeFiles[fmt.Sprintf("%s/%s.txt", nodeName, name)] = truncateNodeCommandOutput(stdout, maxOutputSize)
+					}
+					executor.Stop(nodeName)
+				}
+			}
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			for k, v := range nodeFiles {
+				files[k] = v
+			}
+			if len(errs) > 0 {
+				errsByNode[nodeName] = errs
+			}
+		}(nodeName)
+	}
+	wg.Wait()
+
+	return files, errsByNode
+}
+
+func truncateNodeCommandOutput(output []byte, maxOutputSize int64) []byte {
+	if int64(len(output)) <= maxOutputSize {
+		return output
+	}
+	return output[:maxOutputSize]
+}
+
+// podNodeCommandExecutor runs commands through a privileged pod scheduled on each node. Commands
+// are exec'd in the pod through nsenter so they see the host's mounts, network and processes.
+type podNodeCommandExecutor struct {
+	client              kubernetes.Interface
+	clientConfig        *rest.Config
+	namespace           string
+	image               string
+	imagePullPolicy     string
+	imagePullSecretName string
+
+	mtx  sync.Mutex
+	pods map[string]string
+}
+
+func (e *podNodeCommandExecutor) Start(ctx context.Context, nodeName string) error {
+	image := e.image
+	if image == "" {
+		image = nodeCommandsDefaultImage
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "node-commands-",
+			Namespace:    e.namespace,
+			Labels: map[string]string{
+				"troubleshoot-role": "node-commands",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:      nodeName,
+			RestartPolicy: corev1.RestartPolicyNever,
+			HostNetwork:   true,
+			HostPID:       true,
+			HostIPC:       true,
+			Containers: []corev1.Container{
+				{
+					Name:            nodeCommandsContainerName,
+					Image:           image,
+					ImagePullPolicy: corev1.PullPolicy(e.imagePullPolicy),
+					Command:         []string{"sleep", "3600"},
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: ptr.To(true),
+					},
+				},
+			},
+			Tolerations: []corev1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+		},
+	}
+	if e.imagePullSecretName != "" {
+		pod.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: e.imagePullSecretName}}
+	}
+
+	created, err := e.client.CoreV1().Pods(e.namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "create pod")
+	}
+
+	e.mtx.Lock()
+	e.pods[nodeName] = created.Name
+	e.mtx.Unlock()
+
+	ctx, cancel := withDefaultDeadline(ctx, nodeCommandsPodStartTimeout)
+	defer cancel()
+	err = WaitForPodCondition(ctx, e.client, e.namespace, created.Name, nodeCommandsPodPollInterval, func(pod *corev1.Pod) (bool, error) {
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			return true, nil
+		case corev1.PodFailed, corev1.PodSucceeded:
+			return true, errors.Errorf("pod %s exited before commands could run", pod.Name)
+		}
+		return false, nil
+	})
+	if err != nil {
+		e.Stop(nodeName)
+		return errors.Wrap(err, "wait for pod to start")
+	}
+
+	return nil
+}
+
+func (e *podNodeCommandExecutor) Exec(ctx context.Context, nodeName string, command []string) ([]byte, []byte, error) {
+	e.mtx.Lock()
+	podName, ok := e.pods[nodeName]
+	e.mtx.Unlock()
+	if !ok {
+		return nil, nil, errors.Errorf("no collection pod running on node %s", nodeName)
+	}
+
+	req := e.client.CoreV1().RESTClient().Post().Resource("pods").Name(podName).Namespace(e.namespace).SubResource("exec")
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, nil, err
+	}
+
+	hostCommand := append([]string{"nsenter", "--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "--"}, command...)
+	req.VersionedParams(&corev1.PodExecOptions{
+		Command:   hostCommand,
+		Container: nodeCommandsContainerName,
+		Stdout:    true,
+		Stderr:    true,
+	}, runtime.NewParameterCodec(scheme))
+
+	exec, err := remotecommand.NewSPDYExecutor(e.clientConfig, "POST", req.URL())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := withDefaultDeadline(ctx, nodeCommandsExecTimeout)
+	defer cancel()
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+func (e *podNodeCommandExecutor) Stop(nodeName string) {
+	e.mtx.Lock()
+	podName, ok := e.pods[nodeName]
+	delete(e.pods, nodeName)
+	e.mtx.Unlock()
+	if !ok {
+		return
+	}
+
+	err := e.client.CoreV1().Pods(e.namespace).Delete(context.Background(), podName, metav1.DeleteOptions{
+		GracePeriodSeconds: ptr.To(int64(0)),
+	})
+	if err != nil && !kuberneteserrors.IsNotFound(err) {
+		klog.Errorf("Failed to delete node commands pod %s: %v", podName, err)
+	}
+}
+
+// withDefaultDeadline returns a context that is done after timeout when ctx has no deadline
+func withDefaultDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
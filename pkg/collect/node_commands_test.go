@@ -0,0 +1,170 @@
+package collect
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type fakeNodeCommandExecutor struct {
+	mtx      sync.Mutex
+	outputs  map[string]string
+	failNode string
+	started  []string
+	stopped  []string
+}
+
+func (e *fakeNodeCommandExecutor) Start(ctx context.Context, nodeName string) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if nodeName == e.failNode {
+		return errors.New("image pull failed")
+	}
+	e.started = append(e.started, nodeName)
+	return nil
+}
+
+func (e *fakeNodeCommandExecutor) Exec(ctx context.Context, nodeName string, command []string) ([]byte, []byte, error) {
+	output, ok := e.outputs[strings.Join(command, " ")]
+	if !ok {
+		return nil, []byte("command not found\n"), errors.New("command terminated with exit code 127")
+	}
+	return []byte(nodeName + ": " + output), nil, nil
+}
+
+func (e *fakeNodeCommandExecutor) Stop(nodeName string) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.stopped = append(e.stopped, nodeName)
+}
+
+func Test_nodeCommands(t *testing.T) {
+	tests := []struct {
+		name          string
+		nodes         []string
+		commands      []string
+		maxOutputSize int64
+		wantFiles     map[string]string
+		wantErrs      map[string][]string
+	}{
+		{
+			name:          "allowlisted commands are saved per node",
+			nodes:         []string{"node-1", "node-2"},
+			commands:      []string{"ip-route", "ip-addr"},
+			maxOutputSize: 1024,
+			wantFiles: map[string]string{
+				"node-1/ip-route.txt": "node-1: default via 10.0.0.1 dev eth0",
+				"node-1/ip-addr.txt":  "node-1: inet 10.0.0.5/24",
+				"node-2/ip-route.txt": "node-2: default via 10.0.0.1 dev eth0",
+				"node-2/ip-addr.txt":  "node-2: inet 10.0.0.5/24",
+			},
+			wantErrs: map[string][]string{},
+		},
+		{
+			name:          "commands outside the allowlist are not run",
+			nodes:         []string{"node-1"},
+			commands:      []string{"ip-route", "rm -rf /"},
+			maxOutputSize: 1024,
+			wantFiles: map[string]string{
+				"node-1/ip-route.txt": "node-1: default via 10.0.0.1 dev eth0",
+			},
+			wantErrs: map[string][]string{
+				"node-1": {`command "rm -rf /" is not in the node command allowlist`},
+			},
+		},
+		{
+			name:          "output is truncated to the size cap",
+			nodes:         []string{"node-1"},
+			commands:      []string{"ip-addr"},
+			maxOutputSize: 10,
+			wantFiles: map[string]string{
+				"node-1/ip-addr.txt": "node-1: in",
+			},
+			wantErrs: map[string][]string{
+				"node-1": {"output of ip-addr was truncated from 24 to 10 bytes"},
+			},
+		},
+		{
+			name:          "failed commands save stderr and an error",
+			nodes:         []string{"node-1"},
+			commands:      []string{"nft-list-ruleset"},
+			maxOutputSize: 1024,
+			wantFiles: map[string]string{
+				"node-1/nft-list-ruleset.txt":        "",
+				"node-1/nft-list-ruleset-stderr.txt": "command not found\n",
+			},
+			wantErrs: map[string][]string{
+				"node-1": {"failed to run nft-list-ruleset: command terminated with exit code 127"},
+			},
+		},
+		{
+			name:          "nodes where the pod fails to start are reported",
+			nodes:         []string{"node-1", "broken"},
+			commands:      []string{"ip-route"},
+			maxOutputSize: 1024,
+			wantFiles: map[string]string{
+				"node-1/ip-route.txt": "node-1: default via 10.0.0.1 dev eth0",
+			},
+			wantErrs: map[string][]string{
+				"broken": {"failed to start collection pod on node broken: image pull failed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &fakeNodeCommandExecutor{
+				outputs: map[string]string{
+					"ip route show table all": "default via 10.0.0.1 dev eth0",
+					"ip addr show":            "inet 10.0.0.5/24",
+				},
+				failNode: "broken",
+			}
+
+			files, errs := nodeCommands(context.Background(), executor, tt.nodes, tt.commands, tt.maxOutputSize)
+
+			gotFiles := map[string]string{}
+			for name, data := range files {
+				gotFiles[name] = string(data)
+			}
+			assert.Equal(t, tt.wantFiles, gotFiles)
+			assert.Equal(t, tt.wantErrs, errs)
+			assert.ElementsMatch(t, executor.started, executor.stopped)
+		})
+	}
+}
+
+func Test_podNodeCommandExecutorStartExitedPod(t *testing.T) {
+	client := testclient.NewSimpleClientset()
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+		pod.Name = "node-commands-abc"
+		pod.Status.Phase = corev1.PodFailed
+		return false, nil, nil
+	})
+
+	executor := &podNodeCommandExecutor{client: client, namespace: "default", pods: map[string]string{}}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- executor.Start(context.Background(), "node-1")
+	}()
+
+	select {
+	case err := <-done:
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exited before commands could run")
+	case <-time.After(10 * time.Second):
+		t.Fatal("Start kept waiting for a pod that exited")
+	}
+}
@@ -0,0 +1,188 @@
+package collect
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	ProgressEventCollectorStarted  = "collector_started"
+	ProgressEventCollectorFinished = "collector_finished"
+	ProgressEventCollectorFailed   = "collector_failed"
+	ProgressEventError             = "error"
+	ProgressEventMessage           = "message"
+
+	// progressWriteTimeout is how long a subscriber has to read an event before it is disconnected,
+	// so that a stuck subscriber never blocks collection
+	progressWriteTimeout = 5 * time.Second
+)
+
+// ProgressEvent is a progress message of a collection, as published on a progress socket. The
+// schema is documented in docs/progress-events.md.
+type ProgressEvent struct {
+	Time      string `json:"time"`
+	Type      string `json:"type"`
+	Collector string `json:"collector,omitempty"`
+	// Completed and Total count the collectors, they are only set on collector events
+	Completed int `json:"completed,omitempty"`
+	Total     int `json:"total,omitempty"`
+	// BytesWritten is the size of the results of a finished collector
+	BytesWritten int64  `json:"bytesWritten,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
+
+// NewProgressEvent converts a message sent on a progress channel to an event
+func NewProgressEvent(msg interface{}) ProgressEvent {
+	event := ProgressEvent{
+		Time: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	switch msg := msg.(type) {
+	case CollectProgress:
+		event.Collector = msg.CurrentName
+		event.Completed = msg.CompletedCount
+		event.Total = msg.TotalCount
+		event.BytesWritten = msg.BytesWritten
+		switch msg.CurrentStatus {
+		case "running":
+			event.Type = ProgressEventCollectorStarted
+		case "completed":
+			event.Type = ProgressEventCollectorFinished
+		case "failed":
+			event.Type = ProgressEventCollectorFailed
+		default:
+			event.Type = ProgressEventMessage
+			event.Message = msg.CurrentStatus
+		}
+	case error:
+		event.Type = ProgressEventError
+		event.Message = msg.Error()
+	case string:
+		event.Type = ProgressEventMessage
+		event.Message = msg
+	default:
+		event.Type = ProgressEventMessage
+		event.Message = fmt.Sprintf("%v", msg)
+	}
+
+	return event
+}
+
+// ProgressSocket publishes progress events as JSON lines to the clients connected to a Unix domain
+// socket. Clients that connect after collection started first receive the events already published.
+type ProgressSocket struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	history [][]byte
+	conns   map[net.Conn]struct{}
+	closed  bool
+}
+
+// ListenProgressSocket creates a Unix domain socket at path and accepts connections to it until
+// the socket is closed. A socket file left over at path is replaced.
+func ListenProgressSocket(path string) (*ProgressSocket, error) {
+	if info, err := os.Lstat(path); err == nil && info.Mode().Type() == os.ModeSocket {
+		if err := os.Remove(path); err != nil {
+			return nil, errors.Wrap(err, "failed to remove existing progress socket")
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen on progress socket")
+	}
+
+	s := &ProgressSocket{
+		listener: listener,
+		conns:    map[net.Conn]struct{}{},
+	}
+	go s.accept()
+
+	return s, nil
+}
+
+func (s *ProgressSocket) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			// the listener is closed
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		ok := true
+		for _, line := range s.history {
+			if ok = s.write(conn, line); !ok {
+				break
+			}
+		}
+		if ok {
+			s.conns[conn] = struct{}{}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Publish sends the progress message to all connected clients
+func (s *ProgressSocket) Publish(msg interface{}) {
+	line, err := json.Marshal(NewProgressEvent(msg))
+	if err != nil {
+		klog.Errorf("Failed to marshal progress event: %v", err)
+		return
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.history = append(s.history, line)
+	for conn := range s.conns {
+		if !s.write(conn, line) {
+			delete(s.conns, conn)
+		}
+	}
+}
+
+// write sends a line to a client, closing the connection when it fails
+func (s *ProgressSocket) write(conn net.Conn, line []byte) bool {
+	conn.SetWriteDeadline(time.Now().Add(progressWriteTimeout))
+	if _, err := conn.Write(line); err != nil {
+		klog.V(2).Infof("Disconnecting progress socket client: %v", err)
+		conn.Close()
+		return false
+	}
+	return true
+}
+
+// Close disconnects all clients and removes the socket
+func (s *ProgressSocket) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+	s.mu.Unlock()
+
+	// closing a unix listener also removes its socket file
+	if err := s.listener.Close(); err != nil {
+		return errors.Wrap(err, "failed to close progress socket")
+	}
+	return nil
+}
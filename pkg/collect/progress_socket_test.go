@@ -0,0 +1,94 @@
+package collect
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProgressEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  interface{}
+		want ProgressEvent
+	}{
+		{
+			name: "collector started",
+			msg:  CollectProgress{CurrentName: "cpu", CurrentStatus: "running", CompletedCount: 1, TotalCount: 3},
+			want: ProgressEvent{Type: ProgressEventCollectorStarted, Collector: "cpu", Completed: 1, Total: 3},
+		},
+		{
+			name: "collector finished",
+			msg:  CollectProgress{CurrentName: "cpu", CurrentStatus: "completed", CompletedCount: 2, TotalCount: 3, BytesWritten: 128},
+			want: ProgressEvent{Type: ProgressEventCollectorFinished, Collector: "cpu", Completed: 2, Total: 3, BytesWritten: 128},
+		},
+		{
+			name: "collector failed",
+			msg:  CollectProgress{CurrentName: "cpu", CurrentStatus: "failed", CompletedCount: 2, TotalCount: 3},
+			want: ProgressEvent{Type: ProgressEventCollectorFailed, Collector: "cpu", Completed: 2, Total: 3},
+		},
+		{
+			name: "error",
+			msg:  errors.New("failed to run collector"),
+			want: ProgressEvent{Type: ProgressEventError, Message: "failed to run collector"},
+		},
+		{
+			name: "message",
+			msg:  "[cpu] Excluding collector",
+			want: ProgressEvent{Type: ProgressEventMessage, Message: "[cpu] Excluding collector"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewProgressEvent(tt.msg)
+			assert.NotEmpty(t, got.Time)
+			got.Time = ""
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProgressSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "progress.sock")
+	socket, err := ListenProgressSocket(socketPath)
+	require.NoError(t, err)
+
+	// published before any client connects, it is replayed on connection
+	socket.Publish(CollectProgress{CurrentName: "cpu", CurrentStatus: "running", TotalCount: 1})
+
+	conn, err := net.Dial("unix", socketPath)
+	require.NoError(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	readEvent := func() ProgressEvent {
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+		var event ProgressEvent
+		require.NoError(t, json.Unmarshal(line, &event))
+		return event
+	}
+
+	event := readEvent()
+	assert.Equal(t, ProgressEventCollectorStarted, event.Type)
+	assert.Equal(t, "cpu", event.Collector)
+
+	socket.Publish(CollectProgress{CurrentName: "cpu", CurrentStatus: "completed", CompletedCount: 1, TotalCount: 1, BytesWritten: 64})
+	event = readEvent()
+	assert.Equal(t, ProgressEventCollectorFinished, event.Type)
+	assert.Equal(t, int64(64), event.BytesWritten)
+
+	require.NoError(t, socket.Close())
+	_, err = os.Stat(socketPath)
+	assert.True(t, os.IsNotExist(err))
+
+	// publishing after close is a no-op
+	socket.Publish("done")
+}
@@ -0,0 +1,106 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	prometheusQueryPath = "/api/v1/query"
+	// prometheusDefaultNamespace is where a named service is looked for when no namespace is set
+	prometheusDefaultNamespace = "monitoring"
+)
+
+// prometheusSelectors find the Prometheus service. The first is set by the prometheus helm chart,
+// the second by the prometheus operator on its prometheus-operated service and the last by older
+// charts and manifests.
+var prometheusSelectors = []string{
+	"app.kubernetes.io/name=prometheus",
+	"operated-prometheus=true",
+	"app=prometheus",
+}
+
+type CollectPrometheus struct {
+	Collector    *troubleshootv1beta2.Prometheus
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+func (c *CollectPrometheus) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectPrometheus) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectPrometheus) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	dir := path.Join(constants.PROMETHEUS_DIR, c.Collector.CollectorName)
+	files, errs := queryPrometheus(c.Context, c.Client, c.Collector)
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(dir, fileName), bytes.NewBuffer(data))
+	}
+	output.SaveResult(c.BundlePath, path.Join(dir, "errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// queryPrometheus runs each query as an instant query and returns the responses of the Prometheus
+// HTTP API, named after the queries. A query that fails is recorded in the errors and the others
+// are still run.
+func queryPrometheus(ctx context.Context, client kubernetes.Interface, collector *troubleshootv1beta2.Prometheus) (map[string][]byte, []string) {
+	if len(collector.Queries) == 0 {
+		return nil, []string{"no prometheus queries were specified"}
+	}
+
+	namespace := collector.Namespace
+	if collector.ServiceName != "" && namespace == "" {
+		namespace = prometheusDefaultNamespace
+	}
+	service, err := findLabeledService(ctx, client, namespace, collector.ServiceName, "prometheus", prometheusSelectors)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	port := collector.Port
+	if port == "" {
+		port = firstServicePort(service)
+	}
+
+	files := map[string][]byte{}
+	errorList := []string{}
+	for _, query := range collector.Queries {
+		if query.Name == "" || strings.ContainsAny(query.Name, `/\`) || query.Name == "errors" {
+			errorList = append(errorList, errors.Errorf("invalid prometheus query name %q", query.Name).Error())
+			continue
+		}
+		if _, ok := files[query.Name+".json"]; ok {
+			errorList = append(errorList, errors.Errorf("duplicate prometheus query name %q", query.Name).Error())
+			continue
+		}
+
+		params := map[string]string{"query": query.Query}
+		response, err := client.CoreV1().Services(service.Namespace).ProxyGet("http", service.Name, port, prometheusQueryPath, params).DoRaw(ctx)
+		if err != nil {
+			errorList = append(errorList, errors.Wrapf(err, "failed to run prometheus query %s", query.Name).Error())
+			continue
+		}
+		files[query.Name+".json"] = response
+	}
+
+	return files, errorList
+}
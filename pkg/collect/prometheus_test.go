@@ -0,0 +1,127 @@
+package collect
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// prometheusProxyResponse answers proxied requests with the query, or an error for a query of "bad"
+type prometheusProxyResponse struct {
+	query string
+}
+
+func (r prometheusProxyResponse) DoRaw(ctx context.Context) ([]byte, error) {
+	if r.query == "bad" {
+		return nil, errors.New("the server rejected our request")
+	}
+	return []byte(r.query), nil
+}
+
+func (r prometheusProxyResponse) Stream(ctx context.Context) (io.ReadCloser, error) {
+	return nil, errors.New("not implemented")
+}
+
+func Test_queryPrometheus(t *testing.T) {
+	operatedService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prometheus-operated",
+			Namespace: "monitoring",
+			Labels:    map[string]string{"operated-prometheus": "true"},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "web", Port: 9090}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		objects   []runtime.Object
+		collector troubleshootv1beta2.Prometheus
+		wantFiles map[string]string
+		wantProxy []string
+		wantErrs  []string
+	}{
+		{
+			name:    "queries through the service proxy",
+			objects: []runtime.Object{operatedService},
+			collector: troubleshootv1beta2.Prometheus{
+				Queries: []troubleshootv1beta2.PrometheusQuery{
+					{Name: "up", Query: `up{job="api"}`},
+					{Name: "error-rate", Query: "bad"},
+					{Name: "../disk", Query: "node_filesystem_avail_bytes"},
+					{Name: "up", Query: "up"},
+				},
+			},
+			wantFiles: map[string]string{
+				"up.json": `up{job="api"}`,
+			},
+			wantProxy: []string{"monitoring/prometheus-operated:web/api/v1/query", "monitoring/prometheus-operated:web/api/v1/query"},
+			wantErrs: []string{
+				"failed to run prometheus query error-rate: the server rejected our request",
+				`invalid prometheus query name "../disk"`,
+				`duplicate prometheus query name "up"`,
+			},
+		},
+		{
+			name:    "named service and port",
+			objects: []runtime.Object{operatedService},
+			collector: troubleshootv1beta2.Prometheus{
+				ServiceName: "prometheus-operated",
+				Port:        "9090",
+				Queries:     []troubleshootv1beta2.PrometheusQuery{{Name: "up", Query: "up"}},
+			},
+			wantFiles: map[string]string{"up.json": "up"},
+			wantProxy: []string{"monitoring/prometheus-operated:9090/api/v1/query"},
+			wantErrs:  []string{},
+		},
+		{
+			name: "not found",
+			collector: troubleshootv1beta2.Prometheus{
+				Queries: []troubleshootv1beta2.PrometheusQuery{{Name: "up", Query: "up"}},
+			},
+			wantFiles: map[string]string{},
+			wantErrs:  []string{"no prometheus service was found"},
+		},
+		{
+			name:      "no queries",
+			objects:   []runtime.Object{operatedService},
+			wantFiles: map[string]string{},
+			wantErrs:  []string{"no prometheus queries were specified"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testclient.NewSimpleClientset(tt.objects...)
+			proxied := []string{}
+			client.AddProxyReactor("services", func(action k8stesting.Action) (bool, rest.ResponseWrapper, error) {
+				proxy := action.(k8stesting.ProxyGetAction)
+				proxied = append(proxied, proxy.GetNamespace()+"/"+proxy.GetName()+":"+proxy.GetPort()+proxy.GetPath())
+				return true, prometheusProxyResponse{query: proxy.GetParams()["query"]}, nil
+			})
+
+			files, errs := queryPrometheus(context.Background(), client, &tt.collector)
+			assert.Equal(t, tt.wantErrs, errs)
+
+			gotFiles := map[string]string{}
+			for fileName, data := range files {
+				gotFiles[fileName] = string(data)
+			}
+			assert.Equal(t, tt.wantFiles, gotFiles)
+			if tt.wantProxy != nil {
+				assert.Equal(t, tt.wantProxy, proxied)
+			}
+		})
+	}
+}
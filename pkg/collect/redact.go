@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -91,6 +92,14 @@ func RedactResult(bundlePath string, input CollectorResult, additionalRedactors
 				reader = bytes.NewBuffer(data)
 			}
 
+			// A file gzipped by CompressResultFiles is redacted decompressed and compressed back
+			if IsCompressedFile(file) {
+				if err := redactCompressedFile(bundlePath, input, file, reader, additionalRedactors); err != nil {
+					errorCh <- err
+				}
+				return
+			}
+
 			// If the file is .tar, .tgz or .tar.gz, it must not be redacted. Instead it is
 			// decompressed and each file inside the tar redacted and compressed back into the archive.
 			if filepath.Ext(file) == ".tar" || filepath.Ext(file) == ".tgz" || strings.HasSuffix(file, ".tar.gz") {
@@ -153,6 +162,138 @@ func RedactResult(bundlePath string, input CollectorResult, additionalRedactors
 	return nil
 }
 
+// DryRunRedactResult returns the redactions RedactResult would perform on the files of the
+// result, ordered by file and line, without changing any of them. The files inside .tar, .tgz and
+// .tar.gz archives are reported as <archive>/<file>.
+func DryRunRedactResult(bundlePath string, input CollectorResult, additionalRedactors []*troubleshootv1beta2.Redact) ([]redact.RedactionMatch, error) {
+	matches := []redact.RedactionMatch{}
+
+	for file, data := range input {
+		fileMatches, err := dryRunRedactFile(bundlePath, input, file, data, additionalRedactors)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, fileMatches...)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].File != matches[j].File {
+			return matches[i].File < matches[j].File
+		}
+		return matches[i].Line < matches[j].Line
+	})
+
+	return matches, nil
+}
+
+func dryRunRedactFile(bundlePath string, input CollectorResult, file string, data []byte, additionalRedactors []*troubleshootv1beta2.Redact) ([]redact.RedactionMatch, error) {
+	var reader io.Reader
+	if data == nil {
+		info, err := os.Lstat(filepath.Join(bundlePath, file))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrap(err, "failed to stat file")
+		}
+		// the target of a symlink is in the result too and reported under its own name
+		if info.Mode().Type() == os.ModeSymlink {
+			return nil, nil
+		}
+
+		r, err := input.GetReader(bundlePath, file)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get reader")
+		}
+		defer r.Close()
+		reader = r
+	} else {
+		reader = bytes.NewBuffer(data)
+	}
+
+	if filepath.Ext(file) == ".tar" || filepath.Ext(file) == ".tgz" || strings.HasSuffix(file, ".tar.gz") {
+		return dryRunRedactArchive(reader, file, additionalRedactors)
+	}
+
+	// redactors select a file gzipped by CompressResultFiles by its name without the .gz suffix
+	name := file
+	if IsCompressedFile(file) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			klog.V(2).Infof("Not redacting %s, it is not gzipped: %v", file, err)
+			return nil, nil
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+		name = strings.TrimSuffix(file, CompressedFileSuffix)
+	}
+
+	matches, err := redact.DryRun(reader, name, additionalRedactors)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dry run redaction of %s", file)
+	}
+	for i := range matches {
+		matches[i].File = file
+	}
+	return matches, nil
+}
+
+func dryRunRedactArchive(reader io.Reader, file string, additionalRedactors []*troubleshootv1beta2.Redact) ([]redact.RedactionMatch, error) {
+	tmpDir, err := os.MkdirTemp("", "troubleshoot-subresult-")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temp dir")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	subResult, _, err := decompressFile(tmpDir, reader, file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decompress file")
+	}
+
+	matches, err := DryRunRedactResult(tmpDir, subResult, additionalRedactors)
+	if err != nil {
+		return nil, err
+	}
+	for i := range matches {
+		matches[i].File = filepath.Join(file, matches[i].File)
+	}
+
+	return matches, nil
+}
+
+// redactCompressedFile redacts a file gzipped by CompressResultFiles.
+// Redactors select it by its name without the .gz suffix.
+func redactCompressedFile(bundlePath string, input CollectorResult, file string, reader io.Reader, additionalRedactors []*troubleshootv1beta2.Redact) error {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		// a file named like a compressed file that is not gzipped can't hold anything to redact
+		klog.V(2).Infof("Not redacting %s, it is not gzipped: %v", file, err)
+		return nil
+	}
+	defer gzipReader.Close()
+
+	redacted, err := redact.Redact(gzipReader, strings.TrimSuffix(file, CompressedFileSuffix), additionalRedactors)
+	if err != nil {
+		return errors.Wrap(err, "failed to redact io stream")
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		gzipWriter := gzip.NewWriter(pw)
+		_, err := io.Copy(gzipWriter, redacted)
+		if err == nil {
+			err = gzipWriter.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	if err := input.ReplaceResult(bundlePath, file, pr); err != nil {
+		pr.CloseWithError(err)
+		return errors.Wrap(err, "failed to create redacted result")
+	}
+	return nil
+}
+
 func compressFiles(bundlePath string, result CollectorResult, tarHeaders map[string]*tar.Header, dstFilename string) error {
 	fw, err := os.Create(dstFilename)
 	if err != nil {
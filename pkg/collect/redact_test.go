@@ -0,0 +1,68 @@
+package collect
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DryRunRedactResult(t *testing.T) {
+	redact.ResetRedactionList()
+	defer redact.ResetRedactionList()
+
+	bundlePath := t.TempDir()
+	files := map[string]string{
+		"static/app.conf":  "listen 8080\npwd=hunter2;\n",
+		"logs/api/api.log": "GET /healthz 200\nauth tok_8f14e45fceea167a\n",
+		"logs/api/db.log":  "nothing to see here\n",
+	}
+
+	result := NewResult()
+	for name, content := range files {
+		require.NoError(t, result.SaveResult(bundlePath, name, bytes.NewBufferString(content)))
+	}
+	result["static/in-memory.txt"] = []byte("token tok_8f14e45fceea167a\n")
+
+	redactors := []*troubleshootv1beta2.Redact{
+		{
+			Name: "api-token",
+			Removals: troubleshootv1beta2.Removals{
+				Values: []string{"tok_8f14e45fceea167a"},
+			},
+		},
+	}
+
+	matches, err := DryRunRedactResult(bundlePath, result, redactors)
+	require.NoError(t, err)
+
+	type match struct {
+		file     string
+		redactor string
+		line     int
+		snippet  string
+	}
+	actual := []match{}
+	for _, m := range matches {
+		actual = append(actual, match{file: m.File, redactor: m.RedactorName, line: m.Line, snippet: m.Snippet})
+	}
+	assert.Equal(t, []match{
+		{file: "logs/api/api.log", redactor: "api-token.literal.0", line: 2, snippet: "auth ***HIDDEN***"},
+		{file: "static/app.conf", redactor: "Redact 'Pwd' values commonly found in database connection strings", line: 2, snippet: "pwd=***HIDDEN***;"},
+		{file: "static/in-memory.txt", redactor: "api-token.literal.0", line: 1, snippet: "token ***HIDDEN***"},
+	}, actual)
+
+	// nothing was changed or recorded as redacted
+	for name, content := range files {
+		b, err := os.ReadFile(filepath.Join(bundlePath, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(b))
+	}
+	assert.Equal(t, "token tok_8f14e45fceea167a\n", string(result["static/in-memory.txt"]))
+	assert.Equal(t, 0, redact.GetRedactionAudit().TotalRedactions)
+}
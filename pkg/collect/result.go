@@ -4,12 +4,15 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
@@ -28,6 +31,10 @@ func (r CollectorResult) SymLinkResult(bundlePath, relativeLinkPath, relativeFil
 	klog.V(4).Info("Creating symlink ", relativeLinkPath, " -> ", relativeFilePath)
 	data, ok := r[relativeFilePath]
 	if !ok {
+		if _, ok := r[relativeFilePath+CompressedFileSuffix]; ok {
+			// The file was gzipped by CompressResultFiles, link to the compressed file
+			return r.SymLinkResult(bundlePath, relativeLinkPath+CompressedFileSuffix, relativeFilePath+CompressedFileSuffix)
+		}
 		return errors.Errorf("cannot create symlink, result in %q not found", relativeFilePath)
 	}
 
@@ -208,6 +215,22 @@ func (r CollectorResult) ReplaceResult(bundlePath string, relativePath string, r
 	return nil
 }
 
+// RemoveResult removes relativePath from the result and, when bundlePath is set, from disk
+func (r CollectorResult) RemoveResult(bundlePath string, relativePath string) error {
+	delete(r, relativePath)
+
+	if bundlePath == "" {
+		return nil
+	}
+
+	err := os.Remove(filepath.Join(bundlePath, relativePath))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove file")
+	}
+
+	return nil
+}
+
 func (r CollectorResult) GetReader(bundlePath string, relativePath string) (io.ReadCloser, error) {
 	if r[relativePath] != nil {
 		// Memory only bundle
@@ -252,6 +275,15 @@ func (r CollectorResult) GetWriter(bundlePath string, relativePath string) (io.W
 }
 
 func (r CollectorResult) CloseWriter(bundlePath string, relativePath string, writer interface{}) error {
+	if w, ok := writer.(*compressingWriter); ok {
+		suffix, err := w.close()
+		if err != nil {
+			return err
+		}
+		r[relativePath+suffix] = nil // save the file name referencing the file on disk
+		return nil
+	}
+
 	if c, ok := writer.(io.Closer); ok {
 		return errors.Wrap(c.Close(), "failed to close writer")
 	}
@@ -278,19 +310,100 @@ func (r CollectorResult) ArchiveSupportBundle(bundlePath string, outputFilename
 
 // ArchiveBundle creates an archive of the files in the bundle directory
 func (r CollectorResult) ArchiveBundle(bundlePath string, outputFilename string) error {
-	fileWriter, err := os.Create(outputFilename)
+	store := &LocalBundleStore{Dir: filepath.Dir(outputFilename)}
+	_, err := r.ArchiveBundleToStore(context.Background(), bundlePath, store, filepath.Base(outputFilename))
+	return err
+}
+
+// ArchiveOptions control how the archive of a bundle is written
+type ArchiveOptions struct {
+	// Deterministic writes the files in lexicographic order, with the same modification time,
+	// owner and permissions, in a directory named DeterministicArchiveRoot instead of after the
+	// bundle directory, so that archives of the same files are identical byte for byte
+	Deterministic bool
+	// MaxSize is the budget, in bytes, of the compressed archive. Files are trimmed or left out of
+	// the archive to keep it within its budget, see ApplyBundleSizeBudget.
+	MaxSize int64
+	// ProgressChan receives a message when files are trimmed or left out to fit MaxSize
+	ProgressChan chan<- interface{}
+}
+
+// ArchiveBundleToStore streams an archive of the files in the bundle directory to a bundle store
+// and returns the size of the archive
+func (r CollectorResult) ArchiveBundleToStore(ctx context.Context, bundlePath string, store BundleStore, name string) (int64, error) {
+	return r.ArchiveBundleToStoreWithOptions(ctx, bundlePath, store, name, ArchiveOptions{})
+}
+
+// ArchiveBundleToStoreWithOptions is ArchiveBundleToStore with archive options
+func (r CollectorResult) ArchiveBundleToStoreWithOptions(ctx context.Context, bundlePath string, store BundleStore, name string, opts ArchiveOptions) (int64, error) {
+	pr, pw := io.Pipe()
+
+	archiveErr := make(chan error, 1)
+	go func() {
+		err := r.WriteArchiveWithOptions(pw, bundlePath, opts)
+		pw.CloseWithError(err)
+		archiveErr <- err
+	}()
+
+	n, err := store.Write(ctx, name, pr)
+	// Unblock the archive writer if the store stopped reading early
+	pr.CloseWithError(io.ErrClosedPipe)
+	writeErr := <-archiveErr
 	if err != nil {
-		return errors.Wrap(err, "failed to create output file")
+		// The store sees archive errors as read errors
+		return n, errors.Wrapf(err, "failed to save bundle %s", name)
+	}
+	if writeErr != nil {
+		return n, writeErr
 	}
-	defer fileWriter.Close()
+	return n, nil
+}
 
-	gzipWriter := gzip.NewWriter(fileWriter)
-	defer gzipWriter.Close()
+// DeterministicArchiveRoot is the directory deterministic archives store their files in
+const DeterministicArchiveRoot = "support-bundle"
 
+// WriteArchive writes a gzipped tar archive of the files in the bundle directory to w. Files are
+// stored in a directory named after the bundle directory.
+func (r CollectorResult) WriteArchive(w io.Writer, bundlePath string) error {
+	return r.WriteArchiveWithOptions(w, bundlePath, ArchiveOptions{})
+}
+
+// WriteArchiveWithOptions is WriteArchive with archive options
+func (r CollectorResult) WriteArchiveWithOptions(w io.Writer, bundlePath string, opts ArchiveOptions) error {
+	if opts.MaxSize > 0 {
+		if _, err := r.ApplyBundleSizeBudget(bundlePath, opts.MaxSize, opts.ProgressChan); err != nil {
+			return errors.Wrap(err, "failed to apply bundle size budget")
+		}
+	}
+
+	gzipWriter := gzip.NewWriter(w)
 	tarWriter := tar.NewWriter(gzipWriter)
-	defer tarWriter.Close()
 
+	if err := r.writeTar(tarWriter, bundlePath, opts); err != nil {
+		return err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to close tar writer")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to close gzip writer")
+	}
+	return nil
+}
+
+func (r CollectorResult) writeTar(tarWriter *tar.Writer, bundlePath string, opts ArchiveOptions) error {
+	relativeNames := make([]string, 0, len(r))
 	for relativeName := range r {
+		relativeNames = append(relativeNames, relativeName)
+	}
+	// files are stored in a subdirectory of the archive
+	archiveRoot := filepath.Base(bundlePath)
+	if opts.Deterministic {
+		sort.Strings(relativeNames)
+		archiveRoot = DeterministicArchiveRoot
+	}
+
+	for _, relativeName := range relativeNames {
 		filename := filepath.Join(bundlePath, relativeName)
 		info, err := os.Lstat(filename)
 		if err != nil {
@@ -308,12 +421,12 @@ func (r CollectorResult) ArchiveBundle(bundlePath string, outputFilename string)
 			return errors.Wrap(err, "failed to tar file info header")
 		}
 
-		parentDirName := filepath.Dir(bundlePath) // this is to have the files inside a subdirectory
-		nameInArchive, err := filepath.Rel(parentDirName, filename)
+		nameInBundle, err := filepath.Rel(bundlePath, filename)
 		if err != nil {
 			return errors.Wrap(err, "failed to create relative file name")
 		}
 		// Use the relative path of the file so as to retain directory hierachy
+		nameInArchive := filepath.Join(archiveRoot, nameInBundle)
 		hdr.Name = nameInArchive
 
 		if fileMode.Type() == os.ModeSymlink {
@@ -322,10 +435,11 @@ func (r CollectorResult) ArchiveBundle(bundlePath string, outputFilename string)
 				return errors.Wrap(err, "failed to get symlink target")
 			}
 
-			linkTargetInArchive, err := filepath.Rel(parentDirName, linkTarget)
+			linkTargetInBundle, err := filepath.Rel(bundlePath, linkTarget)
 			if err != nil {
 				return errors.Wrap(err, "failed to create relative file name")
 			}
+			linkTargetInArchive := filepath.Join(archiveRoot, linkTargetInBundle)
 
 			// Use the relative path of the link target so as to retain directory hierachy
 			// i.e link -> ../../../../target.log. When untarred, the link will point to the
@@ -338,12 +452,16 @@ func (r CollectorResult) ArchiveBundle(bundlePath string, outputFilename string)
 			hdr.Linkname = relLinkPath
 		}
 
+		if opts.Deterministic {
+			normalizeTarHeader(hdr)
+		}
+
 		err = tarWriter.WriteHeader(hdr)
 		if err != nil {
 			return errors.Wrap(err, "failed to write tar header")
 		}
 
-		func() error {
+		err = func() error {
 			if fileMode.Type() == os.ModeSymlink {
 				// Don't copy the symlink, just write the header which
 				// will create a symlink in the tarball
@@ -373,6 +491,21 @@ func (r CollectorResult) ArchiveBundle(bundlePath string, outputFilename string)
 	return nil
 }
 
+// normalizeTarHeader clears the fields of a header that depend on when and by whom the file was
+// written
+func normalizeTarHeader(hdr *tar.Header) {
+	hdr.ModTime = time.Unix(0, 0)
+	hdr.AccessTime = time.Time{}
+	hdr.ChangeTime = time.Time{}
+	hdr.Uid, hdr.Gid = 0, 0
+	hdr.Uname, hdr.Gname = "", ""
+	if hdr.Typeflag == tar.TypeSymlink {
+		hdr.Mode = 0777
+	} else {
+		hdr.Mode = 0644
+	}
+}
+
 // CollectorResultFromBundle creates a CollectorResult from a bundle directory
 // The bundle directory is not necessarily a support bundle, it can be any directory
 // of collected files as part of other operations or files that are already on disk.
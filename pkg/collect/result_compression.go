@@ -0,0 +1,233 @@
+package collect
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// CompressedFileSuffix is appended to the name of the files gzipped by CompressResultFiles
+const CompressedFileSuffix = ".gz"
+
+// compressibleFileExtensions are the extensions of the text files that are gzipped by
+// CompressResultFiles
+var compressibleFileExtensions = []string{".log", ".txt"}
+
+// CompressResultFiles gzips the .log and .txt files of a result larger than threshold bytes, and
+// saves them as <name>.gz in place of the original. Running it as each collector completes keeps
+// the disk usage of log heavy collections low until the bundle is archived, files written with
+// GetCompressingWriter are gzipped while they are written instead. Symlinks to a
+// compressed file are replaced by <link>.gz symlinks to <name>.gz. Analyzers and redactors read the
+// compressed files transparently. Files held in memory are left as they are.
+func CompressResultFiles(bundlePath string, result CollectorResult, threshold int64) error {
+	if bundlePath == "" || threshold <= 0 {
+		return nil
+	}
+
+	relativePaths := make([]string, 0, len(result))
+	for relativePath, data := range result {
+		if data == nil && IsCompressibleFile(relativePath) {
+			relativePaths = append(relativePaths, relativePath)
+		}
+	}
+	sort.Strings(relativePaths)
+
+	links := []string{}
+	for _, relativePath := range relativePaths {
+		info, err := os.Lstat(filepath.Join(bundlePath, relativePath))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to stat %s", relativePath)
+		}
+		if info.Mode().Type() == os.ModeSymlink {
+			links = append(links, relativePath)
+			continue
+		}
+		if !info.Mode().IsRegular() || info.Size() <= threshold {
+			continue
+		}
+
+		if err := compressBundleFile(bundlePath, relativePath); err != nil {
+			return errors.Wrapf(err, "failed to compress %s", relativePath)
+		}
+		delete(result, relativePath)
+		result[relativePath+CompressedFileSuffix] = nil
+		klog.V(4).Infof("Compressed %q in bundle output", relativePath)
+	}
+
+	for _, link := range links {
+		linkPath := filepath.Join(bundlePath, link)
+		target, err := os.Readlink(linkPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read symlink %s", link)
+		}
+		targetPath := target
+		if !filepath.IsAbs(targetPath) {
+			targetPath = filepath.Join(filepath.Dir(linkPath), target)
+		}
+		if _, err := os.Stat(targetPath + CompressedFileSuffix); err != nil {
+			continue
+		}
+		if _, err := os.Lstat(targetPath); !os.IsNotExist(err) {
+			continue
+		}
+
+		if err := os.Remove(linkPath); err != nil {
+			return errors.Wrapf(err, "failed to remove symlink %s", link)
+		}
+		if err := os.Symlink(target+CompressedFileSuffix, linkPath+CompressedFileSuffix); err != nil {
+			return errors.Wrapf(err, "failed to create symlink %s", link+CompressedFileSuffix)
+		}
+		delete(result, link)
+		result[link+CompressedFileSuffix] = nil
+	}
+
+	return nil
+}
+
+// GetCompressingWriter returns a writer for a file of the bundle that is gzipped as it is written,
+// and saved as <relativePath>.gz, once more than threshold bytes are written to it. Up to threshold
+// bytes are held in memory, a smaller file is saved as it is when the writer is closed with
+// CloseWriter. It is GetWriter when the bundle is held in memory or threshold is not positive.
+func (r CollectorResult) GetCompressingWriter(bundlePath string, relativePath string, threshold int64) (io.Writer, error) {
+	if bundlePath == "" || threshold <= 0 || !IsCompressibleFile(relativePath) {
+		return r.GetWriter(bundlePath, relativePath)
+	}
+
+	if err := os.MkdirAll(filepath.Join(bundlePath, filepath.Dir(relativePath)), 0777); err != nil {
+		return nil, errors.Wrap(err, "failed to create output directory")
+	}
+
+	return &compressingWriter{
+		filename:  filepath.Join(bundlePath, relativePath),
+		threshold: threshold,
+	}, nil
+}
+
+// compressingWriter buffers the start of a file and switches to writing it gzipped once it grows
+// larger than threshold bytes
+type compressingWriter struct {
+	filename  string
+	threshold int64
+	buf       bytes.Buffer
+	file      *os.File
+	gzip      *gzip.Writer
+}
+
+func (w *compressingWriter) Write(p []byte) (int, error) {
+	if w.gzip != nil {
+		return w.gzip.Write(p)
+	}
+	if int64(w.buf.Len()+len(p)) <= w.threshold {
+		return w.buf.Write(p)
+	}
+
+	f, err := os.Create(w.filename + CompressedFileSuffix)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create file")
+	}
+	w.file = f
+	w.gzip = gzip.NewWriter(f)
+	if _, err := w.gzip.Write(w.buf.Bytes()); err != nil {
+		return 0, errors.Wrap(err, "failed to compress file")
+	}
+	w.buf.Reset()
+	return w.gzip.Write(p)
+}
+
+// close saves the file and returns the path it was saved to relative to its original path, with
+// the .gz suffix when it was gzipped
+func (w *compressingWriter) close() (string, error) {
+	if w.gzip == nil {
+		if err := os.WriteFile(w.filename, w.buf.Bytes(), 0644); err != nil {
+			return "", errors.Wrap(err, "failed to write file")
+		}
+		return "", nil
+	}
+
+	if err := w.gzip.Close(); err != nil {
+		w.file.Close()
+		return "", errors.Wrap(err, "failed to close gzip writer")
+	}
+	if err := w.file.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to close file")
+	}
+	return CompressedFileSuffix, nil
+}
+
+// compressBundleFile gzips a file of the bundle to <relativePath>.gz and removes the original
+func compressBundleFile(bundlePath string, relativePath string) error {
+	filename := filepath.Join(bundlePath, relativePath)
+	src, err := os.Open(filename)
+	if err != nil {
+		return errors.Wrap(err, "failed to open file")
+	}
+	defer src.Close()
+
+	dst, err := os.Create(filename + CompressedFileSuffix)
+	if err != nil {
+		return errors.Wrap(err, "failed to create file")
+	}
+	defer dst.Close()
+
+	gzipWriter := gzip.NewWriter(dst)
+	if _, err := io.Copy(gzipWriter, src); err != nil {
+		return errors.Wrap(err, "failed to compress file")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to close gzip writer")
+	}
+
+	src.Close()
+	return os.Remove(filename)
+}
+
+// IsCompressibleFile returns true for the text files that are gzipped by CompressResultFiles
+func IsCompressibleFile(relativePath string) bool {
+	for _, ext := range compressibleFileExtensions {
+		if strings.HasSuffix(relativePath, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCompressedFile returns true for the files gzipped by CompressResultFiles, e.g. app.log.gz
+func IsCompressedFile(relativePath string) bool {
+	return strings.HasSuffix(relativePath, CompressedFileSuffix) &&
+		IsCompressibleFile(strings.TrimSuffix(relativePath, CompressedFileSuffix))
+}
+
+// ReadCompressedFile returns the decompressed contents of a file gzipped by CompressResultFiles
+func ReadCompressedFile(filename string) ([]byte, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return decompressFileContents(f)
+}
+
+func decompressFileContents(r io.Reader) ([]byte, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gzip reader")
+	}
+	defer gzipReader.Close()
+
+	data, err := io.ReadAll(gzipReader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decompress file")
+	}
+	return data, nil
+}
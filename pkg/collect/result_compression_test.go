@@ -0,0 +1,183 @@
+package collect
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressResultFiles(t *testing.T) {
+	bundlePath := t.TempDir()
+
+	large := strings.Repeat("GET /healthz 200\n", 20)
+	small := "GET /healthz 200\n"
+
+	result := NewResult()
+	require.NoError(t, result.SaveResult(bundlePath, "app/app-0.log", bytes.NewBufferString(large)))
+	require.NoError(t, result.SaveResult(bundlePath, "app/app-1.log", bytes.NewBufferString(small)))
+	require.NoError(t, result.SaveResult(bundlePath, "app/pods.json", bytes.NewBufferString(large)))
+
+	// logs written with a writer, like pod logs
+	w, err := result.GetWriter(bundlePath, "cluster-resources/pods/logs/default/app-0/app.log")
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		_, err := io.WriteString(w, small)
+		require.NoError(t, err)
+	}
+	require.NoError(t, result.CloseWriter(bundlePath, "cluster-resources/pods/logs/default/app-0/app.log", w))
+	require.NoError(t, result.SymLinkResult(bundlePath, "logs/app-0/app.log", "cluster-resources/pods/logs/default/app-0/app.log"))
+
+	require.NoError(t, CompressResultFiles(bundlePath, result, 100))
+	assert.Equal(t, CollectorResult{
+		"app/app-0.log.gz": nil,
+		"app/app-1.log":    nil,
+		"app/pods.json":    nil,
+		"cluster-resources/pods/logs/default/app-0/app.log.gz": nil,
+		"logs/app-0/app.log.gz":                                nil,
+	}, result)
+
+	for _, name := range []string{"app/app-0.log.gz", "cluster-resources/pods/logs/default/app-0/app.log.gz", "logs/app-0/app.log.gz"} {
+		data, err := ReadCompressedFile(filepath.Join(bundlePath, name))
+		require.NoError(t, err, name)
+		assert.Equal(t, large, string(data), name)
+	}
+	for name, want := range map[string]string{"app/app-1.log": small, "app/pods.json": large} {
+		data, err := os.ReadFile(filepath.Join(bundlePath, name))
+		require.NoError(t, err, name)
+		assert.Equal(t, want, string(data), name)
+	}
+	_, err = os.Stat(filepath.Join(bundlePath, "app/app-0.log"))
+	assert.True(t, os.IsNotExist(err))
+
+	// files held in memory and results without a threshold are left as they are
+	memoryResult := NewResult()
+	require.NoError(t, memoryResult.SaveResult("", "app/app-0.log", bytes.NewBufferString(large)))
+	require.NoError(t, CompressResultFiles("", memoryResult, 100))
+	assert.Equal(t, CollectorResult{"app/app-0.log": []byte(large)}, memoryResult)
+
+	other := t.TempDir()
+	otherResult := NewResult()
+	require.NoError(t, otherResult.SaveResult(other, "app/app-0.log", bytes.NewBufferString(large)))
+	require.NoError(t, CompressResultFiles(other, otherResult, 0))
+	assert.Equal(t, CollectorResult{"app/app-0.log": nil}, otherResult)
+}
+
+func TestGetCompressingWriter(t *testing.T) {
+	bundlePath := t.TempDir()
+	line := "GET /healthz 200\n"
+
+	result := NewResult()
+	w, err := result.GetCompressingWriter(bundlePath, "cluster-resources/pods/logs/default/app-0/app.log", 100)
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		_, err := io.WriteString(w, line)
+		require.NoError(t, err)
+	}
+	// the log is written gzipped once it is larger than the threshold, not when it is closed
+	_, err = os.Stat(filepath.Join(bundlePath, "cluster-resources/pods/logs/default/app-0/app.log.gz"))
+	require.NoError(t, err)
+	require.NoError(t, result.CloseWriter(bundlePath, "cluster-resources/pods/logs/default/app-0/app.log", w))
+	require.NoError(t, result.SymLinkResult(bundlePath, "logs/app-0/app.log", "cluster-resources/pods/logs/default/app-0/app.log"))
+
+	w, err = result.GetCompressingWriter(bundlePath, "cluster-resources/pods/logs/default/app-1/app.log", 100)
+	require.NoError(t, err)
+	_, err = io.WriteString(w, line)
+	require.NoError(t, err)
+	require.NoError(t, result.CloseWriter(bundlePath, "cluster-resources/pods/logs/default/app-1/app.log", w))
+
+	assert.Equal(t, CollectorResult{
+		"cluster-resources/pods/logs/default/app-0/app.log.gz": nil,
+		"cluster-resources/pods/logs/default/app-1/app.log":    nil,
+		"logs/app-0/app.log.gz":                                nil,
+	}, result)
+
+	data, err := ReadCompressedFile(filepath.Join(bundlePath, "logs/app-0/app.log.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat(line, 20), string(data))
+	data, err = os.ReadFile(filepath.Join(bundlePath, "cluster-resources/pods/logs/default/app-1/app.log"))
+	require.NoError(t, err)
+	assert.Equal(t, line, string(data))
+	_, err = os.Stat(filepath.Join(bundlePath, "cluster-resources/pods/logs/default/app-0/app.log"))
+	assert.True(t, os.IsNotExist(err))
+
+	// a bundle held in memory is not compressed
+	memoryResult := NewResult()
+	w, err = memoryResult.GetCompressingWriter("", "app.log", 1)
+	require.NoError(t, err)
+	_, err = io.WriteString(w, line)
+	require.NoError(t, err)
+	require.NoError(t, memoryResult.CloseWriter("", "app.log", w))
+	assert.Equal(t, CollectorResult{"app.log": []byte(line)}, memoryResult)
+}
+
+func TestRedactResult_CompressedFile(t *testing.T) {
+	redact.ResetRedactionList()
+	defer redact.ResetRedactionList()
+
+	bundlePath := t.TempDir()
+
+	result := NewResult()
+	require.NoError(t, result.SaveResult(bundlePath, "app/app.log", bytes.NewBufferString("starting\nauth with tok_8f14e45fceea167a\n")))
+	require.NoError(t, CompressResultFiles(bundlePath, result, 10))
+	require.Contains(t, result, "app/app.log.gz")
+
+	redactors := []*troubleshootv1beta2.Redact{
+		{
+			Name: "api-token",
+			// the compressed file is selected by its name without the .gz suffix
+			FileSelector: troubleshootv1beta2.FileSelector{File: "app/*.log"},
+			Removals: troubleshootv1beta2.Removals{
+				Values: []string{"tok_8f14e45fceea167a"},
+			},
+		},
+	}
+	require.NoError(t, RedactResult(bundlePath, result, redactors))
+
+	data, err := ReadCompressedFile(filepath.Join(bundlePath, "app/app.log.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, "starting\nauth with ***HIDDEN***\n", string(data))
+}
+
+func TestIsCompressedFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "app/app.log.gz", want: true},
+		{path: "host-collectors/run-host/ps.txt.gz", want: true},
+		{path: "app/app.log", want: false},
+		{path: "copy-from-host/data.tar.gz", want: false},
+		{path: "cluster-resources/pods.json.gz", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsCompressedFile(tt.path))
+		})
+	}
+}
+
+func TestReadCompressedFile(t *testing.T) {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	_, err := w.Write([]byte("hello\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	filename := filepath.Join(t.TempDir(), "app.log.gz")
+	require.NoError(t, os.WriteFile(filename, b.Bytes(), 0644))
+	data, err := ReadCompressedFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(data))
+
+	_, err = ReadCompressedFile(filepath.Join(t.TempDir(), "missing.log.gz"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,220 @@
+package collect
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/redact"
+	"k8s.io/klog/v2"
+)
+
+// StreamingOptions are the options of a StreamingResult
+type StreamingOptions struct {
+	// Redact redacts the files with the default redactors and Redactors before they are written
+	// to the archive
+	Redact    bool
+	Redactors []*troubleshootv1beta2.Redact
+}
+
+// StreamingResult writes the files of a bundle straight into a zip archive as they are saved,
+// instead of holding them in memory or on disk until the bundle is archived. Files are stored in a
+// directory named after the archive, like the files of a gzipped tar archive of a bundle
+// directory. Files can't be read back or replaced once they are written, so the bundle size budget
+// can't be applied to a streaming result. Close must be called once all the files are saved.
+type StreamingResult struct {
+	path   string
+	prefix string
+	opts   StreamingOptions
+
+	mu        sync.Mutex
+	file      *os.File
+	zipWriter *zip.Writer
+	// written holds the relative paths of the files written to the archive
+	written map[string]bool
+	closed  bool
+}
+
+// NewStreamingResult creates the zip archive at archivePath and returns a result writing to it
+func NewStreamingResult(archivePath string, opts StreamingOptions) (*StreamingResult, error) {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create archive")
+	}
+
+	return &StreamingResult{
+		path:      archivePath,
+		prefix:    strings.TrimSuffix(filepath.Base(archivePath), filepath.Ext(archivePath)),
+		opts:      opts,
+		file:      f,
+		zipWriter: zip.NewWriter(f),
+		written:   map[string]bool{},
+	}, nil
+}
+
+// SaveResult redacts reader, when redaction is enabled, and writes it into a new file of the
+// archive without holding the whole file in memory. Gzipped files and archives can only be
+// redacted from disk, with AddResult.
+func (r *StreamingResult) SaveResult(relativePath string, reader io.Reader) error {
+	if reader == nil {
+		return nil
+	}
+
+	if r.opts.Redact {
+		if IsCompressedFile(relativePath) || isArchiveFile(relativePath) {
+			return errors.Errorf("cannot redact %s as it is streamed, add it from disk", relativePath)
+		}
+		redacted, err := redact.Redact(reader, relativePath, r.opts.Redactors)
+		if err != nil {
+			return errors.Wrapf(err, "failed to redact %s", relativePath)
+		}
+		reader = redacted
+	}
+
+	return r.write(relativePath, reader, 0)
+}
+
+// AddResult writes the files of another result, held in memory or saved at bundlePath, into the
+// archive. When redaction is enabled they are redacted with RedactResult first, which redacts the
+// files on disk in place. Symlinks are stored as symlinks to their target in the archive.
+func (r *StreamingResult) AddResult(bundlePath string, other CollectorResult) error {
+	if r.opts.Redact {
+		if err := RedactResult(bundlePath, other, r.opts.Redactors); err != nil {
+			return errors.Wrap(err, "failed to redact result")
+		}
+	}
+
+	relativePaths := make([]string, 0, len(other))
+	for relativePath := range other {
+		relativePaths = append(relativePaths, relativePath)
+	}
+	sort.Strings(relativePaths)
+
+	for _, relativePath := range relativePaths {
+		if data := other[relativePath]; data != nil || bundlePath == "" {
+			if err := r.write(relativePath, bytes.NewReader(data), 0); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := r.addFile(bundlePath, relativePath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *StreamingResult) addFile(bundlePath string, relativePath string) error {
+	filename := filepath.Join(bundlePath, relativePath)
+	info, err := os.Lstat(filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat %s", relativePath)
+	}
+
+	if info.Mode().Type() == os.ModeSymlink {
+		target, err := os.Readlink(filename)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read symlink %s", relativePath)
+		}
+		if filepath.IsAbs(target) {
+			if target, err = filepath.Rel(filepath.Dir(filename), target); err != nil {
+				return errors.Wrapf(err, "failed to create relative path of symlink %s", relativePath)
+			}
+		}
+		return r.write(relativePath, strings.NewReader(filepath.ToSlash(target)), os.ModeSymlink)
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", relativePath)
+	}
+	defer f.Close()
+
+	return r.write(relativePath, f, 0)
+}
+
+// Contains returns true if relativePath was written to the archive
+func (r *StreamingResult) Contains(relativePath string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.written[relativePath]
+}
+
+// Close writes the central directory of the archive and closes it. It can be called more than
+// once, files can't be saved once it was called.
+func (r *StreamingResult) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	if err := r.zipWriter.Close(); err != nil {
+		r.file.Close()
+		return errors.Wrap(err, "failed to close zip writer")
+	}
+	if err := r.file.Close(); err != nil {
+		return errors.Wrap(err, "failed to close archive")
+	}
+
+	klog.V(4).Infof("Closed bundle archive %s with %d files", r.path, len(r.written))
+	return nil
+}
+
+// write copies reader into a new file of the archive. Files can not be replaced once written.
+func (r *StreamingResult) write(relativePath string, reader io.Reader, mode os.FileMode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return errors.Errorf("cannot add %s, the archive %s is closed", relativePath, r.path)
+	}
+	if r.written[relativePath] {
+		return errors.Errorf("%s is already in the archive", relativePath)
+	}
+
+	header := &zip.FileHeader{
+		Name:     path.Join(r.prefix, filepath.ToSlash(relativePath)),
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	}
+	header.SetMode(mode | 0644)
+	w, err := r.zipWriter.CreateHeader(header)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s in archive", relativePath)
+	}
+
+	n, err := io.Copy(w, reader)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write %s to archive", relativePath)
+	}
+	r.written[relativePath] = true
+
+	klog.V(4).Infof("Added %q (%d KB) to bundle archive", relativePath, n/1024)
+	return nil
+}
+
+// isArchiveFile returns true for the tar archives that RedactResult redacts file by file
+func isArchiveFile(relativePath string) bool {
+	return filepath.Ext(relativePath) == ".tar" || filepath.Ext(relativePath) == ".tgz" || strings.HasSuffix(relativePath, ".tar.gz")
+}
@@ -1,11 +1,20 @@
 package collect
 
 import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/replicatedhq/troubleshoot/internal/testutils"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/redact"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCollectorResult_AddResult(t *testing.T) {
@@ -51,3 +60,163 @@ func TestCollectorResultFromBundle(t *testing.T) {
 		})
 	}
 }
+
+func TestStreamingResult(t *testing.T) {
+	bundlePath := t.TempDir()
+	archivePath := filepath.Join(t.TempDir(), "support-bundle.zip")
+
+	r, err := NewStreamingResult(archivePath, StreamingOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, r.SaveResult("cluster-info/cluster_version.json", strings.NewReader(`{"major":"1"}`)))
+
+	onDisk := NewResult()
+	require.NoError(t, onDisk.SaveResult(bundlePath, "cluster-resources/pods/logs/default/app-0/app.log", strings.NewReader("started\n")))
+	require.NoError(t, onDisk.SymLinkResult(bundlePath, "app/app-0/app.log", "cluster-resources/pods/logs/default/app-0/app.log"))
+	require.NoError(t, r.AddResult(bundlePath, onDisk))
+	require.NoError(t, r.AddResult("", CollectorResult{"cluster-resources/pods/default.json": []byte(`{"items":[]}`)}))
+
+	err = r.SaveResult("cluster-info/cluster_version.json", strings.NewReader("again"))
+	assert.EqualError(t, err, "cluster-info/cluster_version.json is already in the archive")
+	assert.True(t, r.Contains("app/app-0/app.log"))
+	assert.False(t, r.Contains("version.yaml"))
+
+	require.NoError(t, r.Close())
+	require.NoError(t, r.Close())
+	err = r.SaveResult("version.yaml", strings.NewReader("version: 1"))
+	assert.EqualError(t, err, fmt.Sprintf("cannot add version.yaml, the archive %s is closed", archivePath))
+
+	files, links := readZipArchive(t, archivePath)
+	assert.Equal(t, map[string]string{
+		"support-bundle/cluster-info/cluster_version.json":                 `{"major":"1"}`,
+		"support-bundle/cluster-resources/pods/default.json":               `{"items":[]}`,
+		"support-bundle/cluster-resources/pods/logs/default/app-0/app.log": "started\n",
+	}, files)
+	assert.Equal(t, map[string]string{
+		"support-bundle/app/app-0/app.log": "../../cluster-resources/pods/logs/default/app-0/app.log",
+	}, links)
+}
+
+func TestStreamingResult_Redact(t *testing.T) {
+	redact.ResetRedactionList()
+	defer redact.ResetRedactionList()
+
+	bundlePath := t.TempDir()
+	archivePath := filepath.Join(t.TempDir(), "support-bundle.zip")
+	redactors := []*troubleshootv1beta2.Redact{
+		{
+			Name: "session-token",
+			Removals: troubleshootv1beta2.Removals{
+				Values: []string{"sess_c9f0f895fb98ab91"},
+			},
+		},
+	}
+
+	r, err := NewStreamingResult(archivePath, StreamingOptions{Redact: true, Redactors: redactors})
+	require.NoError(t, err)
+
+	require.NoError(t, r.SaveResult("app/config.txt", strings.NewReader("region: us-east-1\ntoken: sess_c9f0f895fb98ab91\n")))
+
+	onDisk := NewResult()
+	require.NoError(t, onDisk.SaveResult(bundlePath, "app/server.log", strings.NewReader("starting\nauth with sess_c9f0f895fb98ab91\n")))
+	require.NoError(t, r.AddResult(bundlePath, onDisk))
+
+	// archives are only redacted from disk
+	err = r.SaveResult("copy-from-host/data.tar.gz", strings.NewReader(""))
+	assert.EqualError(t, err, "cannot redact copy-from-host/data.tar.gz as it is streamed, add it from disk")
+	require.NoError(t, r.Close())
+
+	files, _ := readZipArchive(t, archivePath)
+	assert.Equal(t, map[string]string{
+		"support-bundle/app/config.txt": "region: us-east-1\ntoken: ***HIDDEN***\n",
+		"support-bundle/app/server.log": "starting\nauth with ***HIDDEN***\n",
+	}, files)
+	assert.Len(t, redact.GetRedactionList().ByRedactor["session-token.literal.0"], 2)
+}
+
+// readZipArchive returns the contents of the files and the targets of the symlinks of a zip archive
+func readZipArchive(t *testing.T, archivePath string) (map[string]string, map[string]string) {
+	zipReader, err := zip.OpenReader(archivePath)
+	require.NoError(t, err)
+	defer zipReader.Close()
+
+	files := map[string]string{}
+	links := map[string]string{}
+	for _, f := range zipReader.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		if f.Mode()&os.ModeSymlink != 0 {
+			links[f.Name] = string(b)
+		} else {
+			files[f.Name] = string(b)
+		}
+	}
+	return files, links
+}
+
+func TestStreamingResult_BoundedMemory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("writes 2GB to a streaming result")
+	}
+
+	const (
+		fileCount = 64
+		fileSize  = 32 * 1024 * 1024 // 2GB in total
+		maxHeap   = 64 * 1024 * 1024
+	)
+
+	archivePath := filepath.Join(t.TempDir(), "support-bundle.zip")
+	r, err := NewStreamingResult(archivePath, StreamingOptions{})
+	require.NoError(t, err)
+
+	line := []byte("2026-10-16T09:00:00Z INFO request served in 12ms path=/api/v1/status\n")
+	var maxHeapInUse uint64
+	for i := 0; i < fileCount; i++ {
+		reader := io.LimitReader(&repeatReader{data: line}, fileSize)
+		require.NoError(t, r.SaveResult(fmt.Sprintf("logs/pod-%d.log", i), reader))
+
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		if stats.HeapInuse > maxHeapInUse {
+			maxHeapInUse = stats.HeapInuse
+		}
+	}
+	require.NoError(t, r.Close())
+	assert.Less(t, maxHeapInUse, uint64(maxHeap))
+
+	zipReader, err := zip.OpenReader(archivePath)
+	require.NoError(t, err)
+	defer zipReader.Close()
+
+	require.Len(t, zipReader.File, fileCount)
+	for _, f := range zipReader.File {
+		assert.Equal(t, uint64(fileSize), f.UncompressedSize64)
+	}
+
+	// check the checksum of one of the files
+	rc, err := zipReader.File[fileCount-1].Open()
+	require.NoError(t, err)
+	defer rc.Close()
+	n, err := io.Copy(io.Discard, rc)
+	require.NoError(t, err)
+	assert.Equal(t, int64(fileSize), n)
+}
+
+// repeatReader endlessly repeats data without allocating
+type repeatReader struct {
+	data   []byte
+	offset int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		copied := copy(p[n:], r.data[r.offset:])
+		n += copied
+		r.offset = (r.offset + copied) % len(r.data)
+	}
+	return n, nil
+}
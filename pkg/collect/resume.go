@@ -0,0 +1,192 @@
+package collect
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+)
+
+// CollectionManifest lists the collectors that completed in a bundle directory. It is saved each
+// time a collector completes so that an interrupted collection can be resumed, skipping the
+// collectors whose output is already in the directory.
+type CollectionManifest struct {
+	Collectors []CompletedCollector `json:"collectors"`
+
+	mtx sync.Mutex
+}
+
+// CompletedCollector is a collector that completed, with the files it saved in the bundle
+type CompletedCollector struct {
+	// Key identifies the collector in the spec, see ResumeKeys
+	Key string `json:"key"`
+	// Namespaces are the namespaces the collector collected from, after collectors were merged
+	Namespaces []string       `json:"namespaces,omitempty"`
+	Files      []string       `json:"files"`
+	Size       *CollectorSize `json:"size,omitempty"`
+}
+
+// LoadCollectionManifest reads the manifest of a bundle directory. A directory without a manifest
+// has an empty one.
+func LoadCollectionManifest(bundlePath string) (*CollectionManifest, error) {
+	manifest := &CollectionManifest{}
+
+	b, err := os.ReadFile(filepath.Join(bundlePath, constants.COLLECTION_MANIFEST_FILENAME))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read collection manifest")
+	}
+
+	if err := json.Unmarshal(b, manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to parse collection manifest")
+	}
+	return manifest, nil
+}
+
+// Completed returns the result of a collector recorded in the manifest. The collector is only
+// considered complete when it collected from the same namespaces and all of its files are still in
+// the bundle directory, with at least one of them not empty.
+func (m *CollectionManifest) Completed(bundlePath string, key string, namespaces []string) (CollectorResult, *CollectorSize, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, completed := range m.Collectors {
+		if completed.Key != key {
+			continue
+		}
+		if !reflect.DeepEqual(completed.Namespaces, namespaces) || len(completed.Files) == 0 {
+			return nil, nil, false
+		}
+
+		result := NewResult()
+		hasData := false
+		for _, fileName := range completed.Files {
+			fileInfo, err := os.Lstat(filepath.Join(bundlePath, fileName))
+			if err != nil {
+				return nil, nil, false
+			}
+			if fileInfo.Size() > 0 {
+				hasData = true
+			}
+			result[fileName] = nil
+		}
+		if !hasData {
+			return nil, nil, false
+		}
+		return result, completed.Size, true
+	}
+
+	return nil, nil, false
+}
+
+// Record adds a completed collector to the manifest and saves the manifest in the bundle directory.
+// A previous record of the same collector is replaced.
+func (m *CollectionManifest) Record(bundlePath string, key string, namespaces []string, result CollectorResult, size *CollectorSize) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	files := make([]string, 0, len(result))
+	for fileName := range result {
+		files = append(files, fileName)
+	}
+	sort.Strings(files)
+
+	completed := CompletedCollector{
+		Key:        key,
+		Namespaces: namespaces,
+		Files:      files,
+		Size:       size,
+	}
+
+	replaced := false
+	for i := range m.Collectors {
+		if m.Collectors[i].Key == key {
+			m.Collectors[i] = completed
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		m.Collectors = append(m.Collectors, completed)
+	}
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal collection manifest")
+	}
+
+	// write to a temporary file first so that an interrupted write doesn't lose the manifest
+	manifestPath := filepath.Join(bundlePath, constants.COLLECTION_MANIFEST_FILENAME)
+	if err := os.WriteFile(manifestPath+".tmp", b, 0644); err != nil {
+		return errors.Wrap(err, "failed to write collection manifest")
+	}
+	if err := os.Rename(manifestPath+".tmp", manifestPath); err != nil {
+		return errors.Wrap(err, "failed to save collection manifest")
+	}
+	return nil
+}
+
+// ResumeKeys returns the keys identifying collectors in a collection manifest. Keys are made of the
+// collector type and title, collectors with the same type and title are numbered in the order they
+// are listed.
+func ResumeKeys[T interface{ Title() string }](collectors []T) []string {
+	keys := make([]string, 0, len(collectors))
+	seen := map[string]int{}
+	for _, collector := range collectors {
+		key := fmt.Sprintf("%s/%s", reflect.Indirect(reflect.ValueOf(collector)).Type().Name(), collector.Title())
+		seen[key]++
+		if seen[key] > 1 {
+			key = fmt.Sprintf("%s#%d", key, seen[key])
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// CollectorNamespaces returns the sorted namespaces of a collector's spec, or the namespace of the
+// collector when its spec has none. It returns nil for collectors that are not namespaced or that
+// collect from all namespaces.
+func CollectorNamespaces(collector Collector) []string {
+	v := reflect.ValueOf(collector)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	namespaces := map[string]bool{}
+	spec := v.Elem().FieldByName("Collector")
+	if spec.IsValid() && spec.Kind() == reflect.Ptr && !spec.IsNil() && spec.Elem().Kind() == reflect.Struct {
+		if field := spec.Elem().FieldByName("Namespaces"); field.IsValid() && field.Kind() == reflect.Slice {
+			for i := 0; i < field.Len(); i++ {
+				if namespace, ok := field.Index(i).Interface().(string); ok && namespace != "" {
+					namespaces[namespace] = true
+				}
+			}
+		}
+		if field := spec.Elem().FieldByName("Namespace"); field.IsValid() && field.Kind() == reflect.String && field.String() != "" {
+			namespaces[field.String()] = true
+		}
+	}
+	if len(namespaces) == 0 {
+		if field := v.Elem().FieldByName("Namespace"); field.IsValid() && field.Kind() == reflect.String && field.String() != "" {
+			namespaces[field.String()] = true
+		}
+	}
+	if len(namespaces) == 0 {
+		return nil
+	}
+
+	sorted := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		sorted = append(sorted, namespace)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,156 @@
+package collect
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionManifest(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespaces    []string
+		files         map[string]string
+		modify        func(t *testing.T, bundlePath string)
+		wantCompleted bool
+	}{
+		{
+			name:          "completed collector",
+			namespaces:    []string{"default"},
+			files:         map[string]string{"cluster-resources/pods/default.json": "[]", "cluster-resources/pods-errors.json": ""},
+			wantCompleted: true,
+		},
+		{
+			name:       "missing file",
+			namespaces: []string{"default"},
+			files:      map[string]string{"cluster-resources/pods/default.json": "[]", "cluster-resources/pods-errors.json": ""},
+			modify: func(t *testing.T, bundlePath string) {
+				require.NoError(t, os.Remove(filepath.Join(bundlePath, "cluster-resources/pods/default.json")))
+			},
+		},
+		{
+			name:  "only empty files",
+			files: map[string]string{"cluster-resources/pods-errors.json": ""},
+		},
+		{
+			name:  "no files",
+			files: map[string]string{},
+		},
+		{
+			name:       "namespaces changed",
+			namespaces: []string{"default", "kube-system"},
+			files:      map[string]string{"cluster-resources/pods/default.json": "[]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundlePath := t.TempDir()
+
+			result := NewResult()
+			for fileName, contents := range tt.files {
+				require.NoError(t, result.SaveResult(bundlePath, fileName, bytes.NewBufferString(contents)))
+			}
+			size := &CollectorSize{Collector: "cluster-resources", Files: len(tt.files)}
+
+			manifest, err := LoadCollectionManifest(bundlePath)
+			require.NoError(t, err)
+			assert.Empty(t, manifest.Collectors)
+			require.NoError(t, manifest.Record(bundlePath, "CollectClusterResources/cluster-resources", tt.namespaces, result, size))
+			assert.FileExists(t, filepath.Join(bundlePath, constants.COLLECTION_MANIFEST_FILENAME))
+
+			if tt.modify != nil {
+				tt.modify(t, bundlePath)
+			}
+
+			// the manifest of an interrupted collection is read back from the bundle directory
+			manifest, err = LoadCollectionManifest(bundlePath)
+			require.NoError(t, err)
+			gotResult, gotSize, ok := manifest.Completed(bundlePath, "CollectClusterResources/cluster-resources", []string{"default"})
+			assert.Equal(t, tt.wantCompleted, ok)
+			if tt.wantCompleted {
+				assert.Equal(t, result, gotResult)
+				assert.Equal(t, size, gotSize)
+			}
+
+			_, _, ok = manifest.Completed(bundlePath, "CollectClusterResources/other", []string{"default"})
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestCollectionManifest_RecordReplaces(t *testing.T) {
+	bundlePath := t.TempDir()
+	manifest := &CollectionManifest{}
+
+	result := NewResult()
+	require.NoError(t, result.SaveResult(bundlePath, "cluster-info/cluster_version.json", bytes.NewBufferString("{}")))
+	require.NoError(t, manifest.Record(bundlePath, "CollectClusterInfo/cluster-info", nil, result, nil))
+	require.NoError(t, manifest.Record(bundlePath, "CollectClusterInfo/cluster-info", nil, result, nil))
+
+	manifest, err := LoadCollectionManifest(bundlePath)
+	require.NoError(t, err)
+	assert.Equal(t, []CompletedCollector{
+		{Key: "CollectClusterInfo/cluster-info", Files: []string{"cluster-info/cluster_version.json"}},
+	}, manifest.Collectors)
+}
+
+func TestResumeKeys(t *testing.T) {
+	collectors := []Collector{
+		&CollectClusterInfo{Collector: &troubleshootv1beta2.ClusterInfo{}},
+		&CollectLogs{Collector: &troubleshootv1beta2.Logs{}},
+		&CollectLogs{Collector: &troubleshootv1beta2.Logs{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "api"}}},
+		&CollectLogs{Collector: &troubleshootv1beta2.Logs{}},
+	}
+
+	assert.Equal(t, []string{
+		"CollectClusterInfo/cluster-info",
+		"CollectLogs/logs",
+		"CollectLogs/logs/api",
+		"CollectLogs/logs#2",
+	}, ResumeKeys(collectors))
+}
+
+func TestCollectorNamespaces(t *testing.T) {
+	tests := []struct {
+		name      string
+		collector Collector
+		want      []string
+	}{
+		{
+			name: "merged cluster resources",
+			collector: &CollectClusterResources{
+				Collector: &troubleshootv1beta2.ClusterResources{Namespaces: []string{"kube-system", "default", "kube-system"}},
+			},
+			want: []string{"default", "kube-system"},
+		},
+		{
+			name:      "all namespaces",
+			collector: &CollectClusterResources{Collector: &troubleshootv1beta2.ClusterResources{}},
+		},
+		{
+			name:      "namespace flag",
+			collector: &CollectClusterResources{Collector: &troubleshootv1beta2.ClusterResources{}, Namespace: "app"},
+			want:      []string{"app"},
+		},
+		{
+			name:      "spec namespace",
+			collector: &CollectLogs{Collector: &troubleshootv1beta2.Logs{Namespace: "app"}, Namespace: "other"},
+			want:      []string{"app"},
+		},
+		{
+			name:      "not namespaced",
+			collector: &CollectClusterInfo{Collector: &troubleshootv1beta2.ClusterInfo{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CollectorNamespaces(tt.collector))
+		})
+	}
+}
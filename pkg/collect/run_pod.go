@@ -45,7 +45,7 @@ func (c *CollectRunPod) IsExcluded() (bool, error) {
 }
 
 func (c *CollectRunPod) Collect(progressChan chan<- interface{}) (result CollectorResult, err error) {
-	ctx := context.Background()
+	ctx := collectorContext(c.Context)
 	result = NewResult()
 
 	client, err := kubernetes.NewForConfig(c.ClientConfig)
@@ -64,7 +64,7 @@ func (c *CollectRunPod) Collect(progressChan chan<- interface{}) (result Collect
 	if c.Collector.ImagePullSecret != nil && c.Collector.ImagePullSecret.Data != nil {
 		defer func() {
 			if c.Collector.ImagePullSecret.Name != "" {
-				if err := client.CoreV1().Secrets(pod.Namespace).Delete(ctx, c.Collector.ImagePullSecret.Name, metav1.DeleteOptions{}); err != nil {
+				if err := client.CoreV1().Secrets(pod.Namespace).Delete(context.Background(), c.Collector.ImagePullSecret.Name, metav1.DeleteOptions{}); err != nil {
 					klog.Errorf("Failed to delete secret %s: %v", c.Collector.ImagePullSecret.Name, err)
 				}
 			}
@@ -187,7 +187,7 @@ func runWithoutTimeout(ctx context.Context, bundlePath string, clientConfig *res
 		MaxLines: 10000,
 		MaxBytes: 5000000,
 	}
-	podLogs, err := savePodLogs(ctx, bundlePath, client, pod, collectorName, "", &limits, true, true)
+	podLogs, err := savePodLogs(ctx, bundlePath, client, pod, collectorName, "", &limits, 0, true, true)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get pod logs")
 	}
@@ -419,7 +419,7 @@ func savePodDetails(ctx context.Context, client *kubernetes.Clientset, output Co
 	return output, nil
 }
 
-func deletePod(ctx context.Context, client *kubernetes.Clientset, pod *corev1.Pod) {
+func deletePod(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod) {
 	if err := client.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{}); err != nil {
 		klog.Errorf("Failed to delete pod %s: %v", pod.Name, err)
 		return
@@ -10,6 +10,7 @@ import (
 
 	"github.com/pkg/errors"
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
 	corev1 "k8s.io/api/core/v1"
 	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -133,7 +134,7 @@ func marshalSecretOutput(secretCollector *troubleshootv1beta2.Secret, secret Sec
 }
 
 func GetSecretFileName(secretCollector *troubleshootv1beta2.Secret, name string) string {
-	parts := []string{"secrets", secretCollector.Namespace, name}
+	parts := []string{constants.SECRETS_DIR, secretCollector.Namespace, name}
 	if secretCollector.Key != "" {
 		parts = append(parts, secretCollector.Key)
 	}
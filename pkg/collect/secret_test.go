@@ -3,9 +3,12 @@ package collect
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/redact"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -230,6 +233,47 @@ func TestSecret(t *testing.T) {
 	}
 }
 
+func TestSecret_RedactResult(t *testing.T) {
+	redact.ResetRedactionList()
+	defer redact.ResetRedactionList()
+
+	ctx := context.Background()
+	client := testclient.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "app",
+		},
+		Data: map[string][]byte{
+			"password": []byte("hunter2"),
+		},
+	})
+
+	bundlePath := t.TempDir()
+	secretCollector := &CollectSecret{&troubleshootv1beta2.Secret{
+		Namespace:    "app",
+		Name:         "db",
+		Key:          "password",
+		IncludeValue: true,
+	}, bundlePath, "", nil, client, ctx, nil}
+	result, err := secretCollector.Collect(nil)
+	require.NoError(t, err)
+	require.NoError(t, RedactResult(bundlePath, result, nil))
+
+	data, err := os.ReadFile(filepath.Join(bundlePath, "secrets/app/db/password.json"))
+	require.NoError(t, err)
+	var output SecretOutput
+	require.NoError(t, json.Unmarshal(data, &output))
+	assert.Equal(t, SecretOutput{
+		Namespace:    "app",
+		Name:         "db",
+		Key:          "password",
+		SecretExists: true,
+		KeyExists:    true,
+		Value:        redact.MASK_TEXT,
+	}, output)
+	assert.NotContains(t, string(data), "hunter2")
+}
+
 func mustJSONMarshalIndent(t *testing.T, v interface{}) []byte {
 	b, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
@@ -0,0 +1,301 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type CollectSecurity struct {
+	Collector    *troubleshootv1beta2.Security
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+// aggregatedClusterRole is a ClusterRole with an aggregation rule and the rules it resolves to
+// from the ClusterRoles matching its selectors
+type aggregatedClusterRole struct {
+	Name                 string                 `json:"name"`
+	ClusterRoleSelectors []metav1.LabelSelector `json:"clusterRoleSelectors"`
+	// AggregatedFrom are the names of the ClusterRoles matching the selectors
+	AggregatedFrom []string            `json:"aggregatedFrom"`
+	Rules          []rbacv1.PolicyRule `json:"rules"`
+}
+
+// apiServerAuditConfig is the audit configuration of one kube-apiserver
+type apiServerAuditConfig struct {
+	APIServer string `json:"apiServer"`
+	// Flags are the --audit-* flags the apiserver was started with
+	Flags      map[string]string `json:"flags"`
+	PolicyFile string            `json:"policyFile,omitempty"`
+	// Policy is the content of the policy file when it is mounted from a ConfigMap
+	Policy string `json:"policy,omitempty"`
+	// PolicySource describes where the policy file is mounted from
+	PolicySource string `json:"policySource,omitempty"`
+}
+
+func (c *CollectSecurity) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectSecurity) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectSecurity) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+	errs := []string{}
+
+	auditConfigs, auditErrs := apiServerAuditConfigs(c.Context, c.Client)
+	errs = append(errs, auditErrs...)
+	if auditConfigs != nil {
+		b, err := json.MarshalIndent(auditConfigs, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal audit policy")
+		}
+		output.SaveResult(c.BundlePath, path.Join(constants.SECURITY_DIR, constants.SECURITY_AUDIT_POLICY), bytes.NewBuffer(b))
+	}
+
+	clusterRoles, clusterRoleErrs := aggregatedClusterRoles(c.Context, c.Client)
+	errs = append(errs, clusterRoleErrs...)
+	if clusterRoles != nil {
+		b, err := json.MarshalIndent(clusterRoles, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal aggregated clusterroles")
+		}
+		output.SaveResult(c.BundlePath, path.Join(constants.SECURITY_DIR, constants.SECURITY_AGGREGATED_CLUSTER_ROLES), bytes.NewBuffer(b))
+	}
+
+	output.SaveResult(c.BundlePath, path.Join(constants.SECURITY_DIR, "errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// aggregatedClusterRoles resolves the rules of every ClusterRole with an aggregation rule from the
+// ClusterRoles its selectors match, the same way the clusterrole-aggregation controller does
+func aggregatedClusterRoles(ctx context.Context, client kubernetes.Interface) ([]aggregatedClusterRole, []string) {
+	clusterRoles, err := client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, []string{errors.Wrap(err, "failed to list clusterroles").Error()}
+	}
+
+	sort.Slice(clusterRoles.Items, func(i, j int) bool {
+		return clusterRoles.Items[i].Name < clusterRoles.Items[j].Name
+	})
+
+	result := []aggregatedClusterRole{}
+	errs := []string{}
+	for _, clusterRole := range clusterRoles.Items {
+		if clusterRole.AggregationRule == nil {
+			continue
+		}
+
+		aggregated := aggregatedClusterRole{
+			Name:                 clusterRole.Name,
+			ClusterRoleSelectors: clusterRole.AggregationRule.ClusterRoleSelectors,
+			AggregatedFrom:       []string{},
+			Rules:                []rbacv1.PolicyRule{},
+		}
+		for _, source := range clusterRoles.Items {
+			if source.Name == clusterRole.Name {
+				continue
+			}
+			matched, err := matchesAnyLabelSelector(clusterRole.AggregationRule.ClusterRoleSelectors, source.Labels)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "invalid aggregation rule of clusterrole %s", clusterRole.Name).Error())
+				break
+			}
+			if !matched {
+				continue
+			}
+			aggregated.AggregatedFrom = append(aggregated.AggregatedFrom, source.Name)
+			for _, rule := range source.Rules {
+				if !containsPolicyRule(aggregated.Rules, rule) {
+					aggregated.Rules = append(aggregated.Rules, rule)
+				}
+			}
+		}
+		result = append(result, aggregated)
+	}
+
+	return result, errs
+}
+
+func matchesAnyLabelSelector(selectors []metav1.LabelSelector, objectLabels map[string]string) (bool, error) {
+	for i := range selectors {
+		selector, err := metav1.LabelSelectorAsSelector(&selectors[i])
+		if err != nil {
+			return false, err
+		}
+		if selector.Matches(labels.Set(objectLabels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func containsPolicyRule(rules []rbacv1.PolicyRule, rule rbacv1.PolicyRule) bool {
+	for _, r := range rules {
+		if reflect.DeepEqual(r, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiServerAuditConfigs reads the audit flags of the kube-apiserver pods in kube-system. The
+// policy file itself is only readable when it is mounted from a ConfigMap, a policy on the host
+// is only referenced by path. Managed clusters do not expose their apiserver pods, no audit
+// configuration is returned for them.
+func apiServerAuditConfigs(ctx context.Context, client kubernetes.Interface) ([]apiServerAuditConfig, []string) {
+	pods, err := client.CoreV1().Pods(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, []string{errors.Wrap(err, "failed to list kube-system pods").Error()}
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+
+	configs := []apiServerAuditConfig{}
+	errs := []string{}
+	for _, pod := range pods.Items {
+		if pod.Labels["component"] != "kube-apiserver" && !strings.HasPrefix(pod.Name, "kube-apiserver-") {
+			continue
+		}
+
+		container := findAPIServerContainer(pod.Spec.Containers)
+		if container == nil {
+			continue
+		}
+
+		config := apiServerAuditConfig{
+			APIServer: pod.Name,
+			Flags:     auditFlags(append(append([]string{}, container.Command...), container.Args...)),
+		}
+		config.PolicyFile = config.Flags["--audit-policy-file"]
+		if config.PolicyFile != "" {
+			policy, source, err := readAuditPolicyFile(ctx, client, pod, *container, config.PolicyFile)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to read audit policy of %s", pod.Name).Error())
+			}
+			config.Policy = policy
+			config.PolicySource = source
+		}
+		configs = append(configs, config)
+	}
+
+	if len(configs) == 0 {
+		return nil, []string{"no kube-apiserver pods were found in kube-system, the audit policy is not accessible"}
+	}
+
+	return configs, errs
+}
+
+// findAPIServerContainer returns the kube-apiserver container of a pod, or its first container
+func findAPIServerContainer(containers []corev1.Container) *corev1.Container {
+	for i := range containers {
+		if containers[i].Name == "kube-apiserver" {
+			return &containers[i]
+		}
+	}
+	if len(containers) > 0 {
+		return &containers[0]
+	}
+	return nil
+}
+
+// auditFlags returns the --audit-* flags in both the --flag=value and --flag value forms
+func auditFlags(args []string) map[string]string {
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		if !strings.HasPrefix(name, "--audit-") {
+			continue
+		}
+		if !hasValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			value = args[i+1]
+			i++
+		}
+		flags[name] = value
+	}
+	return flags
+}
+
+// readAuditPolicyFile finds the volume the policy file is mounted from. The policy content is
+// returned for ConfigMap volumes, for other volumes only the source is described.
+func readAuditPolicyFile(ctx context.Context, client kubernetes.Interface, pod corev1.Pod, container corev1.Container, policyFile string) (string, string, error) {
+	var mount *corev1.VolumeMount
+	for i, m := range container.VolumeMounts {
+		mountPath := strings.TrimSuffix(m.MountPath, "/")
+		if policyFile != mountPath && !strings.HasPrefix(policyFile, mountPath+"/") {
+			continue
+		}
+		// the most specific mount wins
+		if mount == nil || len(m.MountPath) > len(mount.MountPath) {
+			mount = &container.VolumeMounts[i]
+		}
+	}
+	if mount == nil {
+		return "", "", nil
+	}
+
+	var volume *corev1.Volume
+	for i := range pod.Spec.Volumes {
+		if pod.Spec.Volumes[i].Name == mount.Name {
+			volume = &pod.Spec.Volumes[i]
+		}
+	}
+	if volume == nil {
+		return "", "", nil
+	}
+
+	switch {
+	case volume.HostPath != nil:
+		hostFile := path.Join(volume.HostPath.Path, strings.TrimPrefix(policyFile, strings.TrimSuffix(mount.MountPath, "/")))
+		return "", fmt.Sprintf("hostPath %s", hostFile), nil
+	case volume.ConfigMap != nil:
+		source := fmt.Sprintf("configmap %s/%s", pod.Namespace, volume.ConfigMap.Name)
+
+		key := strings.TrimPrefix(strings.TrimPrefix(policyFile, strings.TrimSuffix(mount.MountPath, "/")), "/")
+		if mount.SubPath != "" {
+			key = mount.SubPath
+		}
+		for _, item := range volume.ConfigMap.Items {
+			if item.Path == key {
+				key = item.Key
+			}
+		}
+
+		configMap, err := client.CoreV1().ConfigMaps(pod.Namespace).Get(ctx, volume.ConfigMap.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", source, errors.Wrapf(err, "failed to get configmap %s", volume.ConfigMap.Name)
+		}
+		policy, ok := configMap.Data[key]
+		if !ok {
+			return "", source, errors.Errorf("configmap %s has no key %s", volume.ConfigMap.Name, key)
+		}
+		return policy, source, nil
+	default:
+		return "", fmt.Sprintf("volume %s", volume.Name), nil
+	}
+}
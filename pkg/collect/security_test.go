@@ -0,0 +1,97 @@
+package collect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_aggregatedClusterRoles(t *testing.T) {
+	aggregateToView := map[string]string{"rbac.authorization.k8s.io/aggregate-to-view": "true"}
+	podRule := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}}
+	secretRule := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"*"}, Verbs: []string{"get"}}
+
+	client := testclient.NewSimpleClientset(
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "view"},
+			AggregationRule: &rbacv1.AggregationRule{
+				ClusterRoleSelectors: []metav1.LabelSelector{{MatchLabels: aggregateToView}},
+			},
+		},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "acme-pods", Labels: aggregateToView}, Rules: []rbacv1.PolicyRule{podRule}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "acme-everything", Labels: aggregateToView}, Rules: []rbacv1.PolicyRule{podRule, secretRule}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "edit"}, Rules: []rbacv1.PolicyRule{secretRule}},
+	)
+
+	roles, errs := aggregatedClusterRoles(context.Background(), client)
+	require.Empty(t, errs)
+	require.Len(t, roles, 1)
+	assert.Equal(t, "view", roles[0].Name)
+	assert.Equal(t, []string{"acme-everything", "acme-pods"}, roles[0].AggregatedFrom)
+	assert.Equal(t, []rbacv1.PolicyRule{podRule, secretRule}, roles[0].Rules)
+}
+
+func Test_apiServerAuditConfigs(t *testing.T) {
+	apiServer := func(name string, args []string, mounts []corev1.VolumeMount, volumes []corev1.Volume) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kube-system", Labels: map[string]string{"component": "kube-apiserver"}},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "kube-apiserver", Command: []string{"kube-apiserver"}, Args: args, VolumeMounts: mounts}},
+				Volumes:    volumes,
+			},
+		}
+	}
+
+	t.Run("policy mounted from a configmap and from the host", func(t *testing.T) {
+		client := testclient.NewSimpleClientset(
+			apiServer("kube-apiserver-cp1",
+				[]string{"--audit-policy-file=/etc/kubernetes/audit/policy.yaml", "--audit-log-path", "/var/log/audit.log", "--authorization-mode=Node,RBAC"},
+				[]corev1.VolumeMount{{Name: "audit", MountPath: "/etc/kubernetes/audit"}},
+				[]corev1.Volume{{Name: "audit", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "audit-policy"},
+				}}}},
+			),
+			apiServer("kube-apiserver-cp2",
+				[]string{"--audit-policy-file=/etc/kubernetes/audit-policy.yaml"},
+				[]corev1.VolumeMount{{Name: "k8s", MountPath: "/etc/kubernetes"}},
+				[]corev1.Volume{{Name: "k8s", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/srv/kubernetes"}}}},
+			),
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "coredns-abc", Namespace: "kube-system"}},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "audit-policy", Namespace: "kube-system"},
+				Data:       map[string]string{"policy.yaml": "apiVersion: audit.k8s.io/v1\nkind: Policy\n"},
+			},
+		)
+
+		configs, errs := apiServerAuditConfigs(context.Background(), client)
+		require.Empty(t, errs)
+		assert.Equal(t, []apiServerAuditConfig{
+			{
+				APIServer:    "kube-apiserver-cp1",
+				Flags:        map[string]string{"--audit-policy-file": "/etc/kubernetes/audit/policy.yaml", "--audit-log-path": "/var/log/audit.log"},
+				PolicyFile:   "/etc/kubernetes/audit/policy.yaml",
+				Policy:       "apiVersion: audit.k8s.io/v1\nkind: Policy\n",
+				PolicySource: "configmap kube-system/audit-policy",
+			},
+			{
+				APIServer:    "kube-apiserver-cp2",
+				Flags:        map[string]string{"--audit-policy-file": "/etc/kubernetes/audit-policy.yaml"},
+				PolicyFile:   "/etc/kubernetes/audit-policy.yaml",
+				PolicySource: "hostPath /srv/kubernetes/audit-policy.yaml",
+			},
+		}, configs)
+	})
+
+	t.Run("apiserver pods not visible", func(t *testing.T) {
+		configs, errs := apiServerAuditConfigs(context.Background(), testclient.NewSimpleClientset())
+		assert.Nil(t, configs)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0], "no kube-apiserver pods were found")
+	})
+}
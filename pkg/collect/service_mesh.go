@@ -0,0 +1,237 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+type CollectServiceMesh struct {
+	Collector    *troubleshootv1beta2.ServiceMesh
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+// serviceMesh describes how to find a mesh's control plane and configuration
+type serviceMesh struct {
+	name string
+	// detectGroup is the api group whose presence means the mesh is installed
+	detectGroup string
+	// controlPlaneSelector selects the control plane deployments and pods in any namespace
+	controlPlaneSelector string
+	resources            []schema.GroupResource
+	// injectionEnabled returns true when pods created in the namespace get a proxy sidecar
+	injectionEnabled func(namespace corev1.Namespace) bool
+	// proxyStatusPort and proxyStatusPath locate the proxy sync status on the service named
+	// after each control plane deployment, when the mesh exposes it
+	proxyStatusPort string
+	proxyStatusPath string
+}
+
+var serviceMeshes = []serviceMesh{
+	{
+		name:                 "istio",
+		detectGroup:          "networking.istio.io",
+		controlPlaneSelector: "app=istiod",
+		resources: []schema.GroupResource{
+			{Group: "networking.istio.io", Resource: "gateways"},
+			{Group: "networking.istio.io", Resource: "virtualservices"},
+			{Group: "networking.istio.io", Resource: "destinationrules"},
+			{Group: "networking.istio.io", Resource: "serviceentries"},
+			{Group: "networking.istio.io", Resource: "sidecars"},
+			{Group: "security.istio.io", Resource: "peerauthentications"},
+			{Group: "security.istio.io", Resource: "authorizationpolicies"},
+		},
+		injectionEnabled: func(namespace corev1.Namespace) bool {
+			injection := namespace.Labels["istio-injection"]
+			if injection == "enabled" {
+				return true
+			}
+			_, revision := namespace.Labels["istio.io/rev"]
+			return revision && injection != "disabled"
+		},
+		proxyStatusPort: "15014",
+		proxyStatusPath: "debug/syncz",
+	},
+	{
+		name:                 "linkerd",
+		detectGroup:          "linkerd.io",
+		controlPlaneSelector: "linkerd.io/control-plane-component",
+		resources: []schema.GroupResource{
+			{Group: "linkerd.io", Resource: "serviceprofiles"},
+			{Group: "policy.linkerd.io", Resource: "servers"},
+			{Group: "policy.linkerd.io", Resource: "authorizationpolicies"},
+			{Group: "policy.linkerd.io", Resource: "httproutes"},
+		},
+		injectionEnabled: func(namespace corev1.Namespace) bool {
+			return namespace.Annotations["linkerd.io/inject"] == "enabled"
+		},
+	},
+}
+
+func (c *CollectServiceMesh) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectServiceMesh) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectServiceMesh) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	dynamicClient, err := dynamic.NewForConfig(c.ClientConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create dynamic client")
+	}
+
+	namespaces := c.Collector.Namespaces
+	if len(namespaces) == 0 && c.Namespace != "" {
+		namespaces = []string{c.Namespace}
+	}
+
+	files, errs := collectServiceMeshes(c.Context, c.Client, dynamicClient, namespaces)
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(constants.MESH_DIR, fileName), bytes.NewBuffer(data))
+	}
+	output.SaveResult(c.BundlePath, path.Join(constants.MESH_DIR, "errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// collectServiceMeshes collects the state of each installed mesh, keyed by file name relative to
+// the mesh directory. For a mesh named <mesh> these are
+//
+//	<mesh>/control-plane/deployments.json and pods.json, the control plane workloads
+//	<mesh>/injected-namespaces.json, the names of the namespaces with sidecar injection enabled
+//	<mesh>/<resource>.<group>/<namespace>.json, the mesh configuration resources
+//	<mesh>/proxy-status/<deployment>.json, the proxy sync status reported by the control plane
+//
+// Nothing is returned when no mesh is installed.
+func collectServiceMeshes(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface, namespaces []string) (map[string][]byte, []string) {
+	groups, err := client.Discovery().ServerGroups()
+	if err != nil {
+		return nil, []string{errors.Wrap(err, "failed to list api groups").Error()}
+	}
+
+	preferredVersions := map[string]string{}
+	for _, group := range groups.Groups {
+		preferredVersions[group.Name] = group.PreferredVersion.Version
+	}
+
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	files := map[string][]byte{}
+	errorList := []string{}
+	detected := false
+
+	for _, mesh := range serviceMeshes {
+		if _, ok := preferredVersions[mesh.detectGroup]; !ok {
+			continue
+		}
+		detected = true
+
+		meshFiles, meshErrs := collectServiceMesh(ctx, client, dynamicClient, mesh, preferredVersions, namespaces)
+		for fileName, data := range meshFiles {
+			files[path.Join(mesh.name, fileName)] = data
+		}
+		errorList = append(errorList, meshErrs...)
+	}
+
+	if !detected {
+		klog.V(2).Info("neither istio nor linkerd api groups were found, skipping service mesh collection")
+	}
+
+	return files, errorList
+}
+
+func collectServiceMesh(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface, mesh serviceMesh, preferredVersions map[string]string, namespaces []string) (map[string][]byte, []string) {
+	files := map[string][]byte{}
+	errorList := []string{}
+
+	marshal := func(fileName string, v interface{}) {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			errorList = append(errorList, errors.Wrapf(err, "failed to marshal %s %s", mesh.name, fileName).Error())
+			return
+		}
+		files[fileName] = b
+	}
+
+	controlPlaneDeployments := []appsv1.Deployment{}
+	listOptions := metav1.ListOptions{LabelSelector: mesh.controlPlaneSelector}
+	deployments, err := client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		errorList = append(errorList, errors.Wrapf(err, "failed to list %s control plane deployments", mesh.name).Error())
+	} else {
+		controlPlaneDeployments = deployments.Items
+		marshal("control-plane/deployments.json", deployments)
+	}
+
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		errorList = append(errorList, errors.Wrapf(err, "failed to list %s control plane pods", mesh.name).Error())
+	} else {
+		marshal("control-plane/pods.json", pods)
+	}
+
+	namespaceList, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		errorList = append(errorList, errors.Wrapf(err, "failed to list namespaces for %s sidecar injection", mesh.name).Error())
+	} else {
+		injected := []string{}
+		for _, namespace := range namespaceList.Items {
+			if mesh.injectionEnabled(namespace) {
+				injected = append(injected, namespace.Name)
+			}
+		}
+		sort.Strings(injected)
+		marshal("injected-namespaces.json", injected)
+	}
+
+	for _, resource := range mesh.resources {
+		version, ok := preferredVersions[resource.Group]
+		if !ok {
+			continue
+		}
+		gvr := resource.WithVersion(version)
+		resourceFiles, resourceErrs := listCustomResourcesByNamespace(ctx, dynamicClient, gvr, namespaces)
+		for fileName, data := range resourceFiles {
+			files[fileName] = data
+		}
+		errorList = append(errorList, resourceErrs...)
+	}
+
+	if mesh.proxyStatusPort != "" {
+		for _, deployment := range controlPlaneDeployments {
+			status, err := client.CoreV1().Services(deployment.Namespace).ProxyGet("http", deployment.Name, mesh.proxyStatusPort, mesh.proxyStatusPath, nil).DoRaw(ctx)
+			if err != nil {
+				errorList = append(errorList, errors.Wrapf(err, "failed to get %s proxy status from %s/%s", mesh.name, deployment.Namespace, deployment.Name).Error())
+				continue
+			}
+			files[path.Join("proxy-status", deployment.Name+".json")] = status
+		}
+	}
+
+	return files, errorList
+}
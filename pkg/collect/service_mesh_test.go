@@ -0,0 +1,123 @@
+package collect
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	testdynamicclient "k8s.io/client-go/dynamic/fake"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type fakeProxyResponse struct {
+	body string
+}
+
+func (r fakeProxyResponse) DoRaw(context.Context) ([]byte, error) {
+	return []byte(r.body), nil
+}
+
+func (r fakeProxyResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(r.body)), nil
+}
+
+func Test_collectServiceMeshes(t *testing.T) {
+	virtualService := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.istio.io/v1",
+		"kind":       "VirtualService",
+		"metadata": map[string]interface{}{
+			"name":      "reviews",
+			"namespace": "bookinfo",
+		},
+	}}
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: "networking.istio.io", Version: "v1", Resource: "gateways"}:        "GatewayList",
+		{Group: "networking.istio.io", Version: "v1", Resource: "virtualservices"}: "VirtualServiceList",
+	}
+
+	tests := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		wantFiles []string
+	}{
+		{
+			name:      "no mesh installed",
+			resources: []*metav1.APIResourceList{},
+			wantFiles: []string{},
+		},
+		{
+			name: "istio",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "networking.istio.io/v1", APIResources: []metav1.APIResource{
+					{Name: "gateways", Kind: "Gateway", Namespaced: true},
+					{Name: "virtualservices", Kind: "VirtualService", Namespaced: true},
+				}},
+			},
+			wantFiles: []string{
+				"istio/control-plane/deployments.json",
+				"istio/control-plane/pods.json",
+				"istio/injected-namespaces.json",
+				"istio/virtualservices.networking.istio.io/bookinfo.json",
+				"istio/proxy-status/istiod.json",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testclient.NewSimpleClientset(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bookinfo", Labels: map[string]string{"istio-injection": "enabled"}}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"istio.io/rev": "canary"}}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "batch", Labels: map[string]string{"istio.io/rev": "canary", "istio-injection": "disabled"}}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "istio-system"}},
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "istiod", Namespace: "istio-system", Labels: map[string]string{"app": "istiod"}}},
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "bookinfo", Labels: map[string]string{"app": "reviews"}}},
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "istiod-7f9c-x2x4z", Namespace: "istio-system", Labels: map[string]string{"app": "istiod"}}},
+			)
+			fakeDiscovery, ok := client.Discovery().(*fakediscovery.FakeDiscovery)
+			require.True(t, ok)
+			fakeDiscovery.Resources = tt.resources
+
+			client.PrependProxyReactor("services", func(action k8stesting.Action) (bool, restclient.ResponseWrapper, error) {
+				return true, fakeProxyResponse{body: `[{"proxy": "reviews-v1-5d9f.bookinfo", "cluster_acked": "true"}]`}, nil
+			})
+
+			dynamicClient := testdynamicclient.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, virtualService)
+
+			files, errs := collectServiceMeshes(context.Background(), client, dynamicClient, nil)
+			assert.Empty(t, errs)
+
+			fileNames := []string{}
+			for fileName := range files {
+				fileNames = append(fileNames, fileName)
+			}
+			assert.ElementsMatch(t, tt.wantFiles, fileNames)
+
+			if _, ok := files["istio/injected-namespaces.json"]; !ok {
+				return
+			}
+
+			injected := []string{}
+			require.NoError(t, json.Unmarshal(files["istio/injected-namespaces.json"], &injected))
+			assert.Equal(t, []string{"bookinfo", "payments"}, injected)
+
+			var deployments appsv1.DeploymentList
+			require.NoError(t, json.Unmarshal(files["istio/control-plane/deployments.json"], &deployments))
+			require.Len(t, deployments.Items, 1)
+			assert.Equal(t, "istiod", deployments.Items[0].Name)
+		})
+	}
+}
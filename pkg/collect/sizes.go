@@ -0,0 +1,87 @@
+package collect
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// CollectorSize is the amount of data a collector added to the bundle
+type CollectorSize struct {
+	Collector string `json:"collector"`
+	Files     int    `json:"files"`
+	Bytes     int64  `json:"bytes"`
+	// Budget is the collector's size budget in bytes, it is zero when the collector has none
+	Budget int64 `json:"budget,omitempty"`
+	// Truncated lists the files left out of the bundle to keep the collector within its budget,
+	// and TruncatedBytes is their total size
+	Truncated      []string `json:"truncated,omitempty"`
+	TruncatedBytes int64    `json:"truncatedBytes,omitempty"`
+}
+
+// ApplySizeBudget measures the files in a collector's result and, when the collector has a size
+// budget, removes the files that don't fit in it. Files are considered in path order so the same
+// files are kept on every run.
+func ApplySizeBudget(bundlePath string, collector Collector, result CollectorResult) (CollectorSize, error) {
+	size := CollectorSize{
+		Collector: collector.Title(),
+	}
+
+	if meta := getCollectorMeta(collector); meta != nil && meta.SizeBudget != "" {
+		quantity, err := resource.ParseQuantity(meta.SizeBudget)
+		if err != nil {
+			return size, errors.Wrapf(err, "parse size budget %q", meta.SizeBudget)
+		}
+		size.Budget = quantity.Value()
+	}
+
+	fileNames := make([]string, 0, len(result))
+	for fileName := range result {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	for _, fileName := range fileNames {
+		fileSize, err := result.fileSize(bundlePath, fileName)
+		if err != nil {
+			return size, err
+		}
+
+		if size.Budget > 0 && size.Bytes+fileSize > size.Budget {
+			if err := result.RemoveResult(bundlePath, fileName); err != nil {
+				return size, errors.Wrapf(err, "failed to remove %s", fileName)
+			}
+			size.Truncated = append(size.Truncated, fileName)
+			size.TruncatedBytes += fileSize
+			continue
+		}
+
+		size.Files++
+		size.Bytes += fileSize
+	}
+
+	return size, nil
+}
+
+// fileSize returns the size of a file in the result. Symlinks and files that were never written to
+// disk have no size.
+func (r CollectorResult) fileSize(bundlePath string, relativePath string) (int64, error) {
+	if r[relativePath] != nil || bundlePath == "" {
+		return int64(len(r[relativePath])), nil
+	}
+
+	fileInfo, err := os.Lstat(filepath.Join(bundlePath, relativePath))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to stat %s", relativePath)
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return 0, nil
+	}
+	return fileInfo.Size(), nil
+}
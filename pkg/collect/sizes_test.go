@@ -0,0 +1,122 @@
+package collect
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySizeBudget(t *testing.T) {
+	files := map[string]string{
+		"cluster-info/a.json": "aaaa",
+		"cluster-info/b.json": "bbbbbbbb",
+		"cluster-info/c.json": "ccc",
+	}
+
+	tests := []struct {
+		name          string
+		sizeBudget    string
+		memoryOnly    bool
+		wantSize      CollectorSize
+		wantRemaining []string
+	}{
+		{
+			name: "no budget",
+			wantSize: CollectorSize{
+				Collector: "cluster-info/sizes",
+				Files:     3,
+				Bytes:     15,
+			},
+			wantRemaining: []string{"cluster-info/a.json", "cluster-info/b.json", "cluster-info/c.json"},
+		},
+		{
+			name:       "files that don't fit the budget are removed",
+			sizeBudget: "10",
+			wantSize: CollectorSize{
+				Collector:      "cluster-info/sizes",
+				Files:          2,
+				Bytes:          7,
+				Budget:         10,
+				Truncated:      []string{"cluster-info/b.json"},
+				TruncatedBytes: 8,
+			},
+			wantRemaining: []string{"cluster-info/a.json", "cluster-info/c.json"},
+		},
+		{
+			name:       "memory only bundle",
+			sizeBudget: "4",
+			memoryOnly: true,
+			wantSize: CollectorSize{
+				Collector:      "cluster-info/sizes",
+				Files:          1,
+				Bytes:          4,
+				Budget:         4,
+				Truncated:      []string{"cluster-info/b.json", "cluster-info/c.json"},
+				TruncatedBytes: 11,
+			},
+			wantRemaining: []string{"cluster-info/a.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundlePath := t.TempDir()
+			if tt.memoryOnly {
+				bundlePath = ""
+			}
+
+			result := NewResult()
+			for fileName, data := range files {
+				require.NoError(t, result.SaveResult(bundlePath, fileName, bytes.NewBufferString(data)))
+			}
+
+			collector := &CollectClusterInfo{
+				Collector: &troubleshootv1beta2.ClusterInfo{
+					CollectorMeta: troubleshootv1beta2.CollectorMeta{
+						CollectorName: "sizes",
+						SizeBudget:    tt.sizeBudget,
+					},
+				},
+			}
+
+			size, err := ApplySizeBudget(bundlePath, collector, result)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSize, size)
+
+			remaining := []string{}
+			for fileName := range result {
+				remaining = append(remaining, fileName)
+			}
+			assert.ElementsMatch(t, tt.wantRemaining, remaining)
+
+			if bundlePath == "" {
+				return
+			}
+			for _, fileName := range tt.wantSize.Truncated {
+				assert.NoFileExists(t, filepath.Join(bundlePath, fileName))
+			}
+			for _, fileName := range tt.wantRemaining {
+				_, err := os.Stat(filepath.Join(bundlePath, fileName))
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApplySizeBudgetInvalid(t *testing.T) {
+	collector := &CollectClusterInfo{
+		Collector: &troubleshootv1beta2.ClusterInfo{
+			CollectorMeta: troubleshootv1beta2.CollectorMeta{
+				SizeBudget: "lots",
+			},
+		},
+	}
+
+	_, err := ApplySizeBudget("", collector, NewResult())
+	assert.Error(t, err)
+}
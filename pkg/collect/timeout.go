@@ -0,0 +1,192 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"k8s.io/klog/v2"
+)
+
+// collectorCleanupTimeout is how long a collector that timed out has to return once its context is
+// cancelled, e.g. to delete the pods it created, before it is abandoned
+var collectorCleanupTimeout = 2 * time.Minute
+
+// CollectorTimeoutError is returned by CollectWithTimeout for a collector that did not complete in
+// time
+type CollectorTimeoutError struct {
+	Collector string
+	Timeout   time.Duration
+}
+
+func (e *CollectorTimeoutError) Error() string {
+	return fmt.Sprintf("collector %s timed out after %s", e.Collector, e.Timeout)
+}
+
+// GetCollectorTimeout returns how long a collector can run, from the collectorTimeout of its spec.
+// It returns 0 when the collector has no timeout, which is the default.
+func GetCollectorTimeout(collector Collector) (time.Duration, error) {
+	meta := getCollectorMeta(collector)
+	if meta == nil || meta.CollectorTimeout == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(meta.CollectorTimeout)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parse collector timeout %q", meta.CollectorTimeout)
+	}
+	if timeout < 0 {
+		return 0, errors.Errorf("collector timeout %q must not be negative", meta.CollectorTimeout)
+	}
+	return timeout, nil
+}
+
+// CollectWithTimeout runs a collector with a context derived from ctx, which is cancelled when the
+// collector does not complete within timeout. The collectors that create pods or exec into them
+// stop and clean up when their context is cancelled, CollectWithTimeout waits for the collector to
+// return before it returns. A collector that timed out returns a *CollectorTimeoutError and a
+// result with only the timeout in the collector's errors file, see CollectorErrorsPath, the files
+// it saved are removed. A collector that does not return within collectorCleanupTimeout of its
+// context being cancelled is left running in the background, its progress messages are dropped.
+func CollectWithTimeout(ctx context.Context, bundlePath string, collector Collector, timeout time.Duration, progressChan chan<- interface{}) (CollectorResult, error) {
+	if timeout <= 0 {
+		setCollectorContext(collector, ctx)
+		return collector.Collect(progressChan)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	setCollectorContext(collector, ctx)
+
+	type collected struct {
+		result CollectorResult
+		err    error
+	}
+	done := make(chan collected, 1)
+
+	forwarder := newProgressForwarder(progressChan)
+	go func() {
+		result, err := collector.Collect(forwarder.in)
+		forwarder.close()
+		done <- collected{result, err}
+	}()
+
+	select {
+	case c := <-done:
+		forwarder.wait()
+		return c.result, c.err
+	case <-ctx.Done():
+		forwarder.abandon()
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, ctx.Err()
+	}
+
+	cleanupTimer := time.NewTimer(collectorCleanupTimeout)
+	defer cleanupTimer.Stop()
+
+	select {
+	case c := <-done:
+		for relativePath := range c.result {
+			if err := c.result.RemoveResult(bundlePath, relativePath); err != nil {
+				klog.Errorf("Failed to remove %s saved by timed out collector %s: %v", relativePath, collector.Title(), err)
+			}
+		}
+	case <-cleanupTimer.C:
+		klog.Warningf("Collector %s did not stop within %s of timing out, it is left running", collector.Title(), collectorCleanupTimeout)
+	}
+
+	timeoutErr := &CollectorTimeoutError{
+		Collector: collector.Title(),
+		Timeout:   timeout,
+	}
+	output := NewResult()
+	if err := output.SaveResult(bundlePath, CollectorErrorsPath(collector), marshalErrors([]string{timeoutErr.Error()})); err != nil {
+		return nil, errors.Wrap(err, "failed to save collector timeout")
+	}
+	return output, timeoutErr
+}
+
+// setCollectorContext sets the Context a collector runs with, for the collectors that have one
+func setCollectorContext(collector Collector, ctx context.Context) {
+	v := reflect.ValueOf(collector)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	field := v.Elem().FieldByName("Context")
+	if !field.IsValid() || !field.CanSet() || field.Type() != reflect.TypeOf((*context.Context)(nil)).Elem() {
+		return
+	}
+	field.Set(reflect.ValueOf(ctx))
+}
+
+// collectorContext returns the context a collector runs with, or a background context for a
+// collector created without one
+func collectorContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+// CollectorErrorsPath is the path, in the bundle, of the file with the errors of a collector that
+// was abandoned
+func CollectorErrorsPath(collector Collector) string {
+	return path.Join(constants.COLLECTOR_ERRORS_DIR, rfc1035(strings.ToLower(collector.Title()))+"-errors.json")
+}
+
+// progressForwarder forwards the progress messages of a collector until it is abandoned, so that
+// an abandoned collector never sends on a progress channel that was closed after collection
+type progressForwarder struct {
+	in        chan interface{}
+	out       chan<- interface{}
+	forwarded chan struct{}
+
+	mu        sync.Mutex
+	abandoned bool
+}
+
+func newProgressForwarder(out chan<- interface{}) *progressForwarder {
+	f := &progressForwarder{
+		in:        make(chan interface{}),
+		out:       out,
+		forwarded: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(f.forwarded)
+		for msg := range f.in {
+			f.mu.Lock()
+			if !f.abandoned && f.out != nil {
+				f.out <- msg
+			}
+			f.mu.Unlock()
+		}
+	}()
+
+	return f
+}
+
+// close is called once the collector returned, it sends no more messages
+func (f *progressForwarder) close() {
+	close(f.in)
+}
+
+// wait returns once all messages of a collector that returned are forwarded
+func (f *progressForwarder) wait() {
+	<-f.forwarded
+}
+
+// abandon stops forwarding messages, the ones sent after it returns are dropped
+func (f *progressForwarder) abandon() {
+	f.mu.Lock()
+	f.abandoned = true
+	f.mu.Unlock()
+}
@@ -0,0 +1,191 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingCollector struct {
+	RBACErrors
+	release  chan struct{}
+	returned chan struct{}
+}
+
+func (c *blockingCollector) Title() string {
+	return "Blocking Collector"
+}
+
+func (c *blockingCollector) IsExcluded() (bool, error) {
+	return false, nil
+}
+
+func (c *blockingCollector) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	defer close(c.returned)
+
+	progressChan <- "started"
+	<-c.release
+	progressChan <- "finished"
+
+	output := NewResult()
+	output.SaveResult("", "blocking/result.json", bytes.NewBufferString("{}"))
+	return output, nil
+}
+
+// contextCollector runs until its context is done, then cleans up like the collectors that create
+// pods
+type contextCollector struct {
+	RBACErrors
+	BundlePath string
+	Context    context.Context
+	cleanedUp  bool
+}
+
+func (c *contextCollector) Title() string {
+	return "Context Collector"
+}
+
+func (c *contextCollector) IsExcluded() (bool, error) {
+	return false, nil
+}
+
+func (c *contextCollector) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+	output.SaveResult(c.BundlePath, "context/partial.log", bytes.NewBufferString("started\n"))
+
+	<-c.Context.Done()
+	time.Sleep(10 * time.Millisecond)
+	c.cleanedUp = true
+	return output, c.Context.Err()
+}
+
+func TestCollectWithTimeout(t *testing.T) {
+	t.Run("completes in time", func(t *testing.T) {
+		collector := &blockingCollector{release: make(chan struct{}), returned: make(chan struct{})}
+		close(collector.release)
+		progressChan := make(chan interface{}, 10)
+
+		result, err := CollectWithTimeout(context.Background(), "", collector, time.Minute, progressChan)
+		require.NoError(t, err)
+		assert.Equal(t, CollectorResult{"blocking/result.json": []byte("{}")}, result)
+
+		close(progressChan)
+		msgs := []interface{}{}
+		for msg := range progressChan {
+			msgs = append(msgs, msg)
+		}
+		assert.Equal(t, []interface{}{"started", "finished"}, msgs)
+	})
+
+	t.Run("cancels and waits for the collector", func(t *testing.T) {
+		bundlePath := t.TempDir()
+		collector := &contextCollector{BundlePath: bundlePath, Context: context.TODO()}
+
+		result, err := CollectWithTimeout(context.Background(), bundlePath, collector, 10*time.Millisecond, nil)
+		var timeoutErr *CollectorTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.True(t, collector.cleanedUp)
+
+		errorsPath := "collector-errors/context-collector-errors.json"
+		assert.Equal(t, CollectorResult{errorsPath: nil}, result)
+		_, err = os.Stat(filepath.Join(bundlePath, "context/partial.log"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("abandons a collector that does not stop", func(t *testing.T) {
+		cleanupTimeout := collectorCleanupTimeout
+		collectorCleanupTimeout = 10 * time.Millisecond
+		defer func() { collectorCleanupTimeout = cleanupTimeout }()
+
+		bundlePath := t.TempDir()
+		collector := &blockingCollector{release: make(chan struct{}), returned: make(chan struct{})}
+		progressChan := make(chan interface{}, 10)
+
+		result, err := CollectWithTimeout(context.Background(), bundlePath, collector, 10*time.Millisecond, progressChan)
+		var timeoutErr *CollectorTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, 10*time.Millisecond, timeoutErr.Timeout)
+
+		errorsPath := "collector-errors/blocking-collector-errors.json"
+		assert.Equal(t, CollectorResult{errorsPath: nil}, result)
+		data, err := os.ReadFile(filepath.Join(bundlePath, errorsPath))
+		require.NoError(t, err)
+		assert.JSONEq(t, `["collector Blocking Collector timed out after 10ms"]`, string(data))
+
+		// the abandoned collector must not send on the closed progress channel
+		close(progressChan)
+		close(collector.release)
+		<-collector.returned
+	})
+
+	t.Run("no timeout", func(t *testing.T) {
+		collector := &blockingCollector{release: make(chan struct{}), returned: make(chan struct{})}
+		close(collector.release)
+		progressChan := make(chan interface{}, 10)
+
+		_, err := CollectWithTimeout(context.Background(), "", collector, 0, progressChan)
+		require.NoError(t, err)
+		assert.Len(t, progressChan, 2)
+	})
+}
+
+func TestGetCollectorTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		collector Collector
+		want      time.Duration
+		wantErr   bool
+	}{
+		{
+			name:      "no timeout",
+			collector: &CollectData{Collector: &troubleshootv1beta2.Data{}},
+			want:      0,
+		},
+		{
+			name: "collector timeout",
+			collector: &CollectData{Collector: &troubleshootv1beta2.Data{
+				CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorTimeout: "30s"},
+			}},
+			want: 30 * time.Second,
+		},
+		{
+			name: "own timeout of the collector is not a collector timeout",
+			collector: &CollectExec{Collector: &troubleshootv1beta2.Exec{
+				Timeout: "20m",
+			}},
+			want: 0,
+		},
+		{
+			name: "invalid",
+			collector: &CollectData{Collector: &troubleshootv1beta2.Data{
+				CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorTimeout: "soon"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "negative",
+			collector: &CollectData{Collector: &troubleshootv1beta2.Data{
+				CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorTimeout: "-1m"},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetCollectorTimeout(tt.collector)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
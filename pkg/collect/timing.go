@@ -0,0 +1,210 @@
+package collect
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CollectionTiming is how long collection took, with a breakdown by collector
+type CollectionTiming struct {
+	Seconds    float64           `json:"seconds"`
+	Collectors []CollectorTiming `json:"collectors"`
+}
+
+// CollectorTiming is how long a collector took to run
+type CollectorTiming struct {
+	Collector string  `json:"collector"`
+	Seconds   float64 `json:"seconds"`
+	// Percent is the collector's share of the total collection time. Collectors that don't depend
+	// on each other run concurrently, so the percentages can add up to more than 100.
+	Percent float64 `json:"percent"`
+	// Resources and Namespaces break down the time spent in Kubernetes API requests by resource
+	// type and by namespace, for the collectors that record it
+	Resources  []TimingEntry `json:"resources,omitempty"`
+	Namespaces []TimingEntry `json:"namespaces,omitempty"`
+}
+
+// TimingEntry is the time spent in the Kubernetes API requests for a resource type or a namespace
+type TimingEntry struct {
+	Name     string  `json:"name"`
+	Seconds  float64 `json:"seconds"`
+	Requests int     `json:"requests"`
+}
+
+// APIRequestTimingCollector is implemented by collectors that record the time spent in each of
+// their Kubernetes API requests
+type APIRequestTimingCollector interface {
+	// APIRequestTimings returns the time spent by resource type and by namespace, longest first
+	APIRequestTimings() (resources []TimingEntry, namespaces []TimingEntry)
+}
+
+// CollectionTimer records how long each collector takes to run. It is safe for concurrent use.
+type CollectionTimer struct {
+	mtx     sync.Mutex
+	start   time.Time
+	timings []CollectorTiming
+}
+
+func NewCollectionTimer() *CollectionTimer {
+	return &CollectionTimer{
+		start: time.Now(),
+	}
+}
+
+// Record adds the time a collector took to run, with the breakdown of its API requests when the
+// collector records one
+func (t *CollectionTimer) Record(title string, collector interface{}, duration time.Duration) {
+	timing := CollectorTiming{
+		Collector: title,
+		Seconds:   duration.Seconds(),
+	}
+	if c, ok := collector.(APIRequestTimingCollector); ok {
+		timing.Resources, timing.Namespaces = c.APIRequestTimings()
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.timings = append(t.timings, timing)
+}
+
+// Report returns the time since the timer was created and the recorded collectors, longest first
+func (t *CollectionTimer) Report() CollectionTiming {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	report := CollectionTiming{
+		Seconds:    time.Since(t.start).Seconds(),
+		Collectors: make([]CollectorTiming, len(t.timings)),
+	}
+	copy(report.Collectors, t.timings)
+
+	for i := range report.Collectors {
+		if report.Seconds > 0 {
+			report.Collectors[i].Percent = roundPercent(report.Collectors[i].Seconds / report.Seconds * 100)
+		}
+	}
+	sort.SliceStable(report.Collectors, func(i, j int) bool {
+		return report.Collectors[i].Seconds > report.Collectors[j].Seconds
+	})
+
+	return report
+}
+
+func roundPercent(percent float64) float64 {
+	return float64(int64(percent*10+0.5)) / 10
+}
+
+// apiRequestTimer records the time spent in Kubernetes API requests by resource type and by
+// namespace
+type apiRequestTimer struct {
+	mtx        sync.Mutex
+	resources  map[string]*TimingEntry
+	namespaces map[string]*TimingEntry
+}
+
+func newAPIRequestTimer() *apiRequestTimer {
+	return &apiRequestTimer{
+		resources:  map[string]*TimingEntry{},
+		namespaces: map[string]*TimingEntry{},
+	}
+}
+
+// WrapTransport is a rest.Config wrapper timing every request sent through the transport
+func (t *apiRequestTimer) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := rt.RoundTrip(req)
+		t.record(req.URL.Path, time.Since(start))
+		return resp, err
+	})
+}
+
+func (t *apiRequestTimer) record(path string, duration time.Duration) {
+	resource, namespace := parseAPIRequestPath(path)
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	addTiming(t.resources, resource, duration)
+	if namespace != "" {
+		addTiming(t.namespaces, namespace, duration)
+	}
+}
+
+// Timings returns the time spent by resource type and by namespace, longest first
+func (t *apiRequestTimer) Timings() ([]TimingEntry, []TimingEntry) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return sortedTimings(t.resources), sortedTimings(t.namespaces)
+}
+
+func addTiming(timings map[string]*TimingEntry, name string, duration time.Duration) {
+	entry, ok := timings[name]
+	if !ok {
+		entry = &TimingEntry{Name: name}
+		timings[name] = entry
+	}
+	entry.Seconds += duration.Seconds()
+	entry.Requests++
+}
+
+func sortedTimings(timings map[string]*TimingEntry) []TimingEntry {
+	entries := make([]TimingEntry, 0, len(timings))
+	for _, entry := range timings {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Seconds != entries[j].Seconds {
+			return entries[i].Seconds > entries[j].Seconds
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// parseAPIRequestPath returns the resource type, named like kubectl does with the group after the
+// resource, and the namespace of a Kubernetes API request path, e.g. "deployments.apps" and
+// "default" for /apis/apps/v1/namespaces/default/deployments. Subresources are kept, like
+// "pods/log", and discovery requests are reported as "discovery".
+func parseAPIRequestPath(path string) (string, string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	group := ""
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		group = parts[1]
+		parts = parts[3:]
+	default:
+		return "discovery", ""
+	}
+	if len(parts) == 0 {
+		return "discovery", ""
+	}
+
+	namespace := ""
+	if parts[0] == "namespaces" && len(parts) >= 3 {
+		namespace = parts[1]
+		parts = parts[2:]
+	}
+
+	resource := parts[0]
+	if group != "" {
+		resource = resource + "." + group
+	}
+	if len(parts) >= 3 {
+		resource = resource + "/" + parts[2]
+	}
+	return resource, namespace
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
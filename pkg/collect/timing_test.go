@@ -0,0 +1,103 @@
+package collect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseAPIRequestPath(t *testing.T) {
+	tests := []struct {
+		path              string
+		expectedResource  string
+		expectedNamespace string
+	}{
+		{path: "/api/v1/namespaces/default/pods", expectedResource: "pods", expectedNamespace: "default"},
+		{path: "/api/v1/namespaces/default/pods/web-0/log", expectedResource: "pods/log", expectedNamespace: "default"},
+		{path: "/api/v1/nodes", expectedResource: "nodes"},
+		{path: "/api/v1/namespaces", expectedResource: "namespaces"},
+		{path: "/api/v1/namespaces/kube-system", expectedResource: "namespaces"},
+		{path: "/apis/apps/v1/namespaces/default/deployments", expectedResource: "deployments.apps", expectedNamespace: "default"},
+		{path: "/apis/storage.k8s.io/v1/storageclasses", expectedResource: "storageclasses.storage.k8s.io"},
+		{path: "/apis/apps/v1", expectedResource: "discovery"},
+		{path: "/apis", expectedResource: "discovery"},
+		{path: "/version", expectedResource: "discovery"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			resource, namespace := parseAPIRequestPath(test.path)
+			assert.Equal(t, test.expectedResource, resource)
+			assert.Equal(t, test.expectedNamespace, namespace)
+		})
+	}
+}
+
+func Test_apiRequestTimer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	timer := newAPIRequestTimer()
+	client := &http.Client{Transport: timer.WrapTransport(http.DefaultTransport)}
+
+	for _, path := range []string{
+		"/api/v1/namespaces/default/pods",
+		"/api/v1/namespaces/kube-system/pods",
+		"/apis/apps/v1/namespaces/default/deployments",
+		"/api/v1/nodes",
+	} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	resources, namespaces := timer.Timings()
+
+	requests := map[string]int{}
+	for _, entry := range resources {
+		requests[entry.Name] = entry.Requests
+	}
+	assert.Equal(t, map[string]int{"pods": 2, "deployments.apps": 1, "nodes": 1}, requests)
+
+	requests = map[string]int{}
+	for _, entry := range namespaces {
+		requests[entry.Name] = entry.Requests
+	}
+	assert.Equal(t, map[string]int{"default": 2, "kube-system": 1}, requests)
+}
+
+func TestCollectionTimer_Report(t *testing.T) {
+	timer := NewCollectionTimer()
+	timer.start = time.Now().Add(-10 * time.Second)
+
+	apiTimer := newAPIRequestTimer()
+	apiTimer.record("/api/v1/namespaces/default/pods", 2*time.Second)
+	apiTimer.record("/api/v1/namespaces/default/configmaps", time.Second)
+
+	timer.Record("cluster-info", &CollectClusterInfo{}, time.Second)
+	timer.Record("cluster-resources", &CollectClusterResources{apiTimer: apiTimer}, 4*time.Second)
+
+	report := timer.Report()
+	assert.GreaterOrEqual(t, report.Seconds, 10.0)
+	require.Len(t, report.Collectors, 2)
+
+	clusterResources := report.Collectors[0]
+	assert.Equal(t, "cluster-resources", clusterResources.Collector)
+	assert.Equal(t, 4.0, clusterResources.Seconds)
+	assert.InDelta(t, 40.0, clusterResources.Percent, 1)
+	assert.Equal(t, []TimingEntry{
+		{Name: "pods", Seconds: 2, Requests: 1},
+		{Name: "configmaps", Seconds: 1, Requests: 1},
+	}, clusterResources.Resources)
+	assert.Equal(t, []TimingEntry{{Name: "default", Seconds: 3, Requests: 2}}, clusterResources.Namespaces)
+
+	clusterInfo := report.Collectors[1]
+	assert.Equal(t, "cluster-info", clusterInfo.Collector)
+	assert.Empty(t, clusterInfo.Resources)
+}
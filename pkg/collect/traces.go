@@ -0,0 +1,665 @@
+package collect
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	tracesDefaultEndpoint = "0.0.0.0:4318"
+	tracesDefaultDuration = 30 * time.Second
+	// tracesMaxRequestSize caps the size of a single export request
+	tracesMaxRequestSize = 32 * 1024 * 1024
+
+	otlpHTTPTracesPath = "/v1/traces"
+	otlpGRPCExportPath = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+)
+
+var (
+	otlpSpanKinds   = []string{"", "internal", "server", "client", "producer", "consumer"}
+	otlpStatusCodes = []string{"", "ok", "error"}
+)
+
+type CollectTraces struct {
+	Collector  *troubleshootv1beta2.Traces
+	BundlePath string
+	Context    context.Context
+	RBACErrors
+}
+
+// TraceSpan is a span received by the traces collector, the spans are saved one per line
+type TraceSpan struct {
+	TraceID       string                 `json:"traceId"`
+	SpanID        string                 `json:"spanId"`
+	ParentSpanID  string                 `json:"parentSpanId,omitempty"`
+	Name          string                 `json:"name"`
+	Kind          string                 `json:"kind,omitempty"`
+	Service       string                 `json:"service,omitempty"`
+	Scope         string                 `json:"scope,omitempty"`
+	StartTime     time.Time              `json:"startTime"`
+	EndTime       time.Time              `json:"endTime"`
+	StatusCode    string                 `json:"statusCode,omitempty"`
+	StatusMessage string                 `json:"statusMessage,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+	Resource      map[string]interface{} `json:"resource,omitempty"`
+}
+
+func (c *CollectTraces) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectTraces) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectTraces) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	endpoint := c.Collector.Endpoint
+	if endpoint == "" {
+		endpoint = tracesDefaultEndpoint
+	}
+	duration := tracesDefaultDuration
+	if c.Collector.Duration != "" {
+		parsed, err := time.ParseDuration(c.Collector.Duration)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse duration %q", c.Collector.Duration)
+		}
+		duration = parsed
+	}
+
+	ctx := c.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	fileName := tracesFileName(endpoint)
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		errs := []string{errors.Wrapf(err, "failed to listen on %s", endpoint).Error()}
+		output.SaveResult(c.BundlePath, path.Join(constants.TRACES_DIR, fileName+"-errors.json"), marshalErrors(errs))
+		return output, nil
+	}
+
+	spans, errs := receiveTraces(ctx, listener, duration)
+	output.SaveResult(c.BundlePath, path.Join(constants.TRACES_DIR, fileName+".json"), bytes.NewBuffer(spans))
+	output.SaveResult(c.BundlePath, path.Join(constants.TRACES_DIR, fileName+"-errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// tracesFileName returns the name of the files saved for an endpoint, e.g. 0.0.0.0-4318
+func tracesFileName(endpoint string) string {
+	return strings.NewReplacer(":", "-", "/", "-", "[", "", "]", "").Replace(endpoint)
+}
+
+// receiveTraces serves OTLP/HTTP and OTLP/gRPC on listener until duration has passed or ctx is
+// done. It returns the received spans as newline delimited JSON, and the errors of the requests
+// that could not be decoded.
+func receiveTraces(ctx context.Context, listener net.Listener, duration time.Duration) ([]byte, []string) {
+	receiver := &tracesReceiver{}
+	server := &http.Server{
+		// gRPC clients connect with HTTP/2 without TLS
+		Handler:           h2c.NewHandler(receiver, &http2.Server{}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	case err := <-served:
+		receiver.addError(errors.Wrap(err, "failed to serve"))
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = server.Shutdown(shutdownCtx)
+
+	return receiver.close()
+}
+
+// tracesReceiver implements the OTLP trace service over HTTP and gRPC
+type tracesReceiver struct {
+	mu     sync.Mutex
+	closed bool
+	spans  bytes.Buffer
+	errs   []string
+}
+
+func (r *tracesReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc") {
+		r.serveGRPC(w, req)
+		return
+	}
+
+	if req.URL.Path != otlpHTTPTracesPath {
+		http.NotFound(w, req)
+		return
+	}
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := readTracesBody(req.Body, req.Header.Get("Content-Encoding"))
+	if err != nil {
+		r.addError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	isJSON := strings.HasPrefix(req.Header.Get("Content-Type"), "application/json")
+	var request otlpTracesRequest
+	if isJSON {
+		err = json.Unmarshal(body, &request)
+	} else {
+		request, err = unmarshalOTLPTracesRequest(body)
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "failed to decode export request from %s", req.RemoteAddr)
+		r.addError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.addSpans(request.spans())
+
+	// the response is an empty ExportTraceServiceResponse in the encoding of the request
+	if isJSON {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("{}"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *tracesReceiver) serveGRPC(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+
+	writeStatus := func(code int, message string) {
+		w.Header().Set("Grpc-Status", strconv.Itoa(code))
+		if message != "" {
+			w.Header().Set("Grpc-Message", message)
+		}
+	}
+
+	if req.URL.Path != otlpGRPCExportPath {
+		w.WriteHeader(http.StatusOK)
+		writeStatus(12, fmt.Sprintf("unknown method %s", req.URL.Path)) // UNIMPLEMENTED
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, tracesMaxRequestSize))
+	if err == nil {
+		body, err = grpcMessage(body, req.Header.Get("Grpc-Encoding"))
+	}
+	var request otlpTracesRequest
+	if err == nil {
+		request, err = unmarshalOTLPTracesRequest(body)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to decode export request from %s", req.RemoteAddr)
+		r.addError(err)
+		writeStatus(3, err.Error()) // INVALID_ARGUMENT
+		return
+	}
+	r.addSpans(request.spans())
+
+	// an uncompressed, empty ExportTraceServiceResponse
+	_, _ = w.Write([]byte{0, 0, 0, 0, 0})
+	writeStatus(0, "")
+}
+
+func (r *tracesReceiver) addSpans(spans []TraceSpan) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return
+	}
+	for _, span := range spans {
+		b, err := json.Marshal(span)
+		if err != nil {
+			r.errs = append(r.errs, errors.Wrapf(err, "failed to marshal span %s", span.SpanID).Error())
+			continue
+		}
+		r.spans.Write(b)
+		r.spans.WriteByte('\n')
+	}
+}
+
+func (r *tracesReceiver) addError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.closed {
+		r.errs = append(r.errs, err.Error())
+	}
+}
+
+// close stops recording and returns what was received, requests still in flight are dropped
+func (r *tracesReceiver) close() ([]byte, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	return r.spans.Bytes(), r.errs
+}
+
+func readTracesBody(body io.Reader, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", "identity":
+	case "gzip":
+		reader, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create gzip reader")
+		}
+		defer reader.Close()
+		body = reader
+	default:
+		return nil, errors.Errorf("unsupported encoding %q", encoding)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(body, tracesMaxRequestSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read request body")
+	}
+	return b, nil
+}
+
+// grpcMessage returns the message of a unary gRPC request body, which is prefixed with a
+// compressed flag and the length of the message
+func grpcMessage(body []byte, encoding string) ([]byte, error) {
+	if len(body) < 5 {
+		return nil, errors.New("gRPC message is too short")
+	}
+	length := binary.BigEndian.Uint32(body[1:5])
+	if uint64(len(body)-5) < uint64(length) {
+		return nil, errors.New("gRPC message is truncated")
+	}
+	message := body[5 : 5+length]
+	if body[0] == 0 {
+		return message, nil
+	}
+	return readTracesBody(bytes.NewReader(message), encoding)
+}
+
+// otlpTracesRequest is an ExportTraceServiceRequest in the OTLP JSON encoding, requests in the
+// protobuf encoding are decoded into the same types
+type otlpTracesRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano json.Number    `json:"startTimeUnixNano"`
+	EndTimeUnixNano   json.Number    `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string        `json:"stringValue,omitempty"`
+	BoolValue   *bool          `json:"boolValue,omitempty"`
+	IntValue    *json.Number   `json:"intValue,omitempty"`
+	DoubleValue *float64       `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArray     `json:"arrayValue,omitempty"`
+	KvlistValue *otlpKeyValues `json:"kvlistValue,omitempty"`
+	BytesValue  *string        `json:"bytesValue,omitempty"`
+}
+
+type otlpArray struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+type otlpKeyValues struct {
+	Values []otlpKeyValue `json:"values"`
+}
+
+func (r otlpTracesRequest) spans() []TraceSpan {
+	spans := []TraceSpan{}
+	for _, resourceSpans := range r.ResourceSpans {
+		resource := otlpAttributes(resourceSpans.Resource.Attributes)
+		service, _ := resource["service.name"].(string)
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			for _, span := range scopeSpans.Spans {
+				traceSpan := TraceSpan{
+					TraceID:       span.TraceID,
+					SpanID:        span.SpanID,
+					ParentSpanID:  span.ParentSpanID,
+					Name:          span.Name,
+					Service:       service,
+					Scope:         scopeSpans.Scope.Name,
+					StartTime:     otlpTime(span.StartTimeUnixNano),
+					EndTime:       otlpTime(span.EndTimeUnixNano),
+					StatusMessage: span.Status.Message,
+					Attributes:    otlpAttributes(span.Attributes),
+					Resource:      resource,
+				}
+				if span.Kind > 0 && span.Kind < len(otlpSpanKinds) {
+					traceSpan.Kind = otlpSpanKinds[span.Kind]
+				}
+				if span.Status.Code > 0 && span.Status.Code < len(otlpStatusCodes) {
+					traceSpan.StatusCode = otlpStatusCodes[span.Status.Code]
+				}
+				spans = append(spans, traceSpan)
+			}
+		}
+	}
+	return spans
+}
+
+func otlpTime(unixNano json.Number) time.Time {
+	n, err := strconv.ParseInt(unixNano.String(), 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
+
+func otlpAttributes(attributes []otlpKeyValue) map[string]interface{} {
+	if len(attributes) == 0 {
+		return nil
+	}
+	values := map[string]interface{}{}
+	for _, attribute := range attributes {
+		values[attribute.Key] = attribute.Value.value()
+	}
+	return values
+}
+
+func (v otlpAnyValue) value() interface{} {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != nil:
+		if n, err := v.IntValue.Int64(); err == nil {
+			return n
+		}
+		return v.IntValue.String()
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.ArrayValue != nil:
+		values := []interface{}{}
+		for _, value := range v.ArrayValue.Values {
+			values = append(values, value.value())
+		}
+		return values
+	case v.KvlistValue != nil:
+		return otlpAttributes(v.KvlistValue.Values)
+	case v.BytesValue != nil:
+		return *v.BytesValue
+	}
+	return nil
+}
+
+// protoField is a field of a protobuf message, Bytes holds the value of length delimited fields
+// and Scalar the value of varint and fixed size fields
+type protoField struct {
+	Number protowire.Number
+	Bytes  []byte
+	Scalar uint64
+}
+
+func forEachProtoField(b []byte, fn func(field protoField) error) error {
+	for len(b) > 0 {
+		number, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		field := protoField{Number: number}
+		switch typ {
+		case protowire.BytesType:
+			field.Bytes, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			field.Scalar, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			field.Scalar, n = protowire.ConsumeFixed64(b)
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(b)
+			field.Scalar = uint64(v)
+		default:
+			n = protowire.ConsumeFieldValue(number, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if err := fn(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalOTLPTracesRequest decodes an ExportTraceServiceRequest in the protobuf encoding, the
+// field numbers are those of the opentelemetry-proto trace and common messages
+func unmarshalOTLPTracesRequest(b []byte) (otlpTracesRequest, error) {
+	request := otlpTracesRequest{}
+	err := forEachProtoField(b, func(field protoField) error {
+		if field.Number != 1 {
+			return nil
+		}
+		resourceSpans, err := unmarshalOTLPResourceSpans(field.Bytes)
+		request.ResourceSpans = append(request.ResourceSpans, resourceSpans)
+		return err
+	})
+	return request, err
+}
+
+func unmarshalOTLPResourceSpans(b []byte) (otlpResourceSpans, error) {
+	resourceSpans := otlpResourceSpans{}
+	err := forEachProtoField(b, func(field protoField) error {
+		switch field.Number {
+		case 1:
+			return forEachProtoField(field.Bytes, func(field protoField) error {
+				if field.Number != 1 {
+					return nil
+				}
+				attribute, err := unmarshalOTLPKeyValue(field.Bytes)
+				resourceSpans.Resource.Attributes = append(resourceSpans.Resource.Attributes, attribute)
+				return err
+			})
+		case 2:
+			scopeSpans, err := unmarshalOTLPScopeSpans(field.Bytes)
+			resourceSpans.ScopeSpans = append(resourceSpans.ScopeSpans, scopeSpans)
+			return err
+		}
+		return nil
+	})
+	return resourceSpans, err
+}
+
+func unmarshalOTLPScopeSpans(b []byte) (otlpScopeSpans, error) {
+	scopeSpans := otlpScopeSpans{}
+	err := forEachProtoField(b, func(field protoField) error {
+		switch field.Number {
+		case 1:
+			return forEachProtoField(field.Bytes, func(field protoField) error {
+				switch field.Number {
+				case 1:
+					scopeSpans.Scope.Name = string(field.Bytes)
+				case 2:
+					scopeSpans.Scope.Version = string(field.Bytes)
+				}
+				return nil
+			})
+		case 2:
+			span, err := unmarshalOTLPSpan(field.Bytes)
+			scopeSpans.Spans = append(scopeSpans.Spans, span)
+			return err
+		}
+		return nil
+	})
+	return scopeSpans, err
+}
+
+func unmarshalOTLPSpan(b []byte) (otlpSpan, error) {
+	span := otlpSpan{}
+	err := forEachProtoField(b, func(field protoField) error {
+		switch field.Number {
+		case 1:
+			span.TraceID = hex.EncodeToString(field.Bytes)
+		case 2:
+			span.SpanID = hex.EncodeToString(field.Bytes)
+		case 4:
+			span.ParentSpanID = hex.EncodeToString(field.Bytes)
+		case 5:
+			span.Name = string(field.Bytes)
+		case 6:
+			span.Kind = int(field.Scalar)
+		case 7:
+			span.StartTimeUnixNano = json.Number(strconv.FormatUint(field.Scalar, 10))
+		case 8:
+			span.EndTimeUnixNano = json.Number(strconv.FormatUint(field.Scalar, 10))
+		case 9:
+			attribute, err := unmarshalOTLPKeyValue(field.Bytes)
+			span.Attributes = append(span.Attributes, attribute)
+			return err
+		case 15:
+			return forEachProtoField(field.Bytes, func(field protoField) error {
+				switch field.Number {
+				case 2:
+					span.Status.Message = string(field.Bytes)
+				case 3:
+					span.Status.Code = int(field.Scalar)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	return span, err
+}
+
+func unmarshalOTLPKeyValue(b []byte) (otlpKeyValue, error) {
+	keyValue := otlpKeyValue{}
+	err := forEachProtoField(b, func(field protoField) error {
+		switch field.Number {
+		case 1:
+			keyValue.Key = string(field.Bytes)
+		case 2:
+			value, err := unmarshalOTLPAnyValue(field.Bytes)
+			keyValue.Value = value
+			return err
+		}
+		return nil
+	})
+	return keyValue, err
+}
+
+func unmarshalOTLPAnyValue(b []byte) (otlpAnyValue, error) {
+	value := otlpAnyValue{}
+	err := forEachProtoField(b, func(field protoField) error {
+		switch field.Number {
+		case 1:
+			s := string(field.Bytes)
+			value.StringValue = &s
+		case 2:
+			v := field.Scalar != 0
+			value.BoolValue = &v
+		case 3:
+			n := json.Number(strconv.FormatInt(int64(field.Scalar), 10))
+			value.IntValue = &n
+		case 4:
+			v := math.Float64frombits(field.Scalar)
+			value.DoubleValue = &v
+		case 5:
+			value.ArrayValue = &otlpArray{Values: []otlpAnyValue{}}
+			return forEachProtoField(field.Bytes, func(field protoField) error {
+				if field.Number != 1 {
+					return nil
+				}
+				v, err := unmarshalOTLPAnyValue(field.Bytes)
+				value.ArrayValue.Values = append(value.ArrayValue.Values, v)
+				return err
+			})
+		case 6:
+			value.KvlistValue = &otlpKeyValues{Values: []otlpKeyValue{}}
+			return forEachProtoField(field.Bytes, func(field protoField) error {
+				if field.Number != 1 {
+					return nil
+				}
+				v, err := unmarshalOTLPKeyValue(field.Bytes)
+				value.KvlistValue.Values = append(value.KvlistValue.Values, v)
+				return err
+			})
+		case 7:
+			s := base64.StdEncoding.EncodeToString(field.Bytes)
+			value.BytesValue = &s
+		}
+		return nil
+	})
+	return value, err
+}
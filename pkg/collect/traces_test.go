@@ -0,0 +1,201 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func Test_receiveTraces(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := "http://" + listener.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	type received struct {
+		spans []byte
+		errs  []string
+	}
+	done := make(chan received)
+	go func() {
+		spans, errs := receiveTraces(ctx, listener, time.Minute)
+		done <- received{spans, errs}
+	}()
+
+	jsonRequest := `{
+  "resourceSpans": [{
+    "resource": {"attributes": [{"key": "service.name", "value": {"stringValue": "frontend"}}]},
+    "scopeSpans": [{
+      "scope": {"name": "net/http"},
+      "spans": [{
+        "traceId": "5b8efff798038103d269b633813fc60c",
+        "spanId": "eee19b7ec3c1b174",
+        "name": "GET /",
+        "kind": 2,
+        "startTimeUnixNano": "1760601600000000000",
+        "endTimeUnixNano": "1760601600250000000",
+        "attributes": [
+          {"key": "http.response.status_code", "value": {"intValue": "503"}},
+          {"key": "retry", "value": {"boolValue": true}}
+        ],
+        "status": {"code": 2, "message": "upstream unavailable"}
+      }]
+    }]
+  }]
+}`
+	resp, err := http.Post(endpoint+"/v1/traces", "application/json", strings.NewReader(jsonRequest))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	protoRequest := otlpTestRequest("checkout", "checkout-db", "SELECT")
+	resp, err = http.Post(endpoint+"/v1/traces", "application/x-protobuf", bytes.NewReader(protoRequest))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	grpcClient := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	grpcRequest := otlpTestRequest("payments", "payments-api", "Charge")
+	grpcBody := binary.BigEndian.AppendUint32([]byte{0}, uint32(len(grpcRequest)))
+	grpcBody = append(grpcBody, grpcRequest...)
+	req, err := http.NewRequest(http.MethodPost, endpoint+otlpGRPCExportPath, bytes.NewReader(grpcBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/grpc")
+	resp, err = grpcClient.Do(req)
+	require.NoError(t, err)
+	grpcResponse, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, []byte{0, 0, 0, 0, 0}, grpcResponse)
+	assert.Equal(t, "0", resp.Trailer.Get("Grpc-Status"))
+
+	resp, err = http.Post(endpoint+"/v1/traces", "application/x-protobuf", strings.NewReader("\x0a\xff"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	cancel()
+	result := <-done
+
+	require.Len(t, result.errs, 1)
+	assert.Contains(t, result.errs[0], "failed to decode export request")
+
+	spans := []TraceSpan{}
+	for _, line := range strings.Split(strings.TrimSpace(string(result.spans)), "\n") {
+		var span TraceSpan
+		require.NoError(t, json.Unmarshal([]byte(line), &span))
+		spans = append(spans, span)
+	}
+
+	start := time.Unix(0, 1760601600000000000).UTC()
+	assert.Equal(t, []TraceSpan{
+		{
+			TraceID:       "5b8efff798038103d269b633813fc60c",
+			SpanID:        "eee19b7ec3c1b174",
+			Name:          "GET /",
+			Kind:          "server",
+			Service:       "frontend",
+			Scope:         "net/http",
+			StartTime:     start,
+			EndTime:       start.Add(250 * time.Millisecond),
+			StatusCode:    "error",
+			StatusMessage: "upstream unavailable",
+			Attributes:    map[string]interface{}{"http.response.status_code": float64(503), "retry": true},
+			Resource:      map[string]interface{}{"service.name": "frontend"},
+		},
+		{
+			TraceID:      "0102030405060708090a0b0c0d0e0f10",
+			SpanID:       "1112131415161718",
+			ParentSpanID: "2122232425262728",
+			Name:         "SELECT",
+			Kind:         "client",
+			Service:      "checkout",
+			Scope:        "checkout-db",
+			StartTime:    start,
+			EndTime:      start.Add(time.Second),
+			StatusCode:   "ok",
+			Attributes:   map[string]interface{}{"db.system": "postgresql", "tags": []interface{}{"a", "b"}},
+			Resource:     map[string]interface{}{"service.name": "checkout"},
+		},
+		{
+			TraceID:      "0102030405060708090a0b0c0d0e0f10",
+			SpanID:       "1112131415161718",
+			ParentSpanID: "2122232425262728",
+			Name:         "Charge",
+			Kind:         "client",
+			Service:      "payments",
+			Scope:        "payments-api",
+			StartTime:    start,
+			EndTime:      start.Add(time.Second),
+			StatusCode:   "ok",
+			Attributes:   map[string]interface{}{"db.system": "postgresql", "tags": []interface{}{"a", "b"}},
+			Resource:     map[string]interface{}{"service.name": "payments"},
+		},
+	}, spans)
+}
+
+func Test_tracesFileName(t *testing.T) {
+	assert.Equal(t, "0.0.0.0-4318", tracesFileName("0.0.0.0:4318"))
+	assert.Equal(t, "--1-4317", tracesFileName("[::1]:4317"))
+}
+
+// otlpTestRequest returns an ExportTraceServiceRequest in the protobuf encoding with a single
+// client span
+func otlpTestRequest(service, scope, name string) []byte {
+	message := func(fields ...[]byte) []byte {
+		return bytes.Join(fields, nil)
+	}
+	bytesField := func(number protowire.Number, value []byte) []byte {
+		b := protowire.AppendTag(nil, number, protowire.BytesType)
+		return protowire.AppendBytes(b, value)
+	}
+	varintField := func(number protowire.Number, value uint64) []byte {
+		b := protowire.AppendTag(nil, number, protowire.VarintType)
+		return protowire.AppendVarint(b, value)
+	}
+	fixed64Field := func(number protowire.Number, value uint64) []byte {
+		b := protowire.AppendTag(nil, number, protowire.Fixed64Type)
+		return protowire.AppendFixed64(b, value)
+	}
+	stringValue := func(s string) []byte {
+		return bytesField(1, []byte(s))
+	}
+	keyValue := func(key string, value []byte) []byte {
+		return message(bytesField(1, []byte(key)), bytesField(2, value))
+	}
+
+	span := message(
+		bytesField(1, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}),
+		bytesField(2, []byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18}),
+		bytesField(4, []byte{0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28}),
+		bytesField(5, []byte(name)),
+		varintField(6, 3),
+		fixed64Field(7, 1760601600000000000),
+		fixed64Field(8, 1760601601000000000),
+		bytesField(9, keyValue("db.system", stringValue("postgresql"))),
+		bytesField(9, keyValue("tags", bytesField(5, message(bytesField(1, stringValue("a")), bytesField(1, stringValue("b")))))),
+		bytesField(15, varintField(3, 1)),
+	)
+	resource := bytesField(1, keyValue("service.name", stringValue(service)))
+	scopeSpans := message(bytesField(1, bytesField(1, []byte(scope))), bytesField(2, span))
+	resourceSpans := message(bytesField(1, resource), bytesField(2, scopeSpans))
+	return bytesField(1, resourceSpans)
+}
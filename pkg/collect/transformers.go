@@ -0,0 +1,199 @@
+package collect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OutputTransformer rewrites the files a collector produced before they are added to the bundle.
+// It can replace, add or remove files in the result. Collector specs select transformers by the
+// name they were registered with.
+type OutputTransformer interface {
+	Transform(bundlePath string, result CollectorResult) error
+}
+
+var (
+	outputTransformersMtx sync.RWMutex
+	outputTransformers    = map[string]OutputTransformer{
+		"eventsSummary": eventsSummaryTransformer{},
+	}
+)
+
+// RegisterOutputTransformer makes a transformer available to collector specs under name,
+// replacing any transformer registered with the same name
+func RegisterOutputTransformer(name string, transformer OutputTransformer) {
+	outputTransformersMtx.Lock()
+	defer outputTransformersMtx.Unlock()
+	outputTransformers[name] = transformer
+}
+
+// TransformCollectorResult runs the transformers listed in the collector's spec over its result
+func TransformCollectorResult(bundlePath string, collector Collector, result CollectorResult) error {
+	meta := getCollectorMeta(collector)
+	if meta == nil || len(meta.Transformers) == 0 || len(result) == 0 {
+		return nil
+	}
+
+	for _, name := range meta.Transformers {
+		outputTransformersMtx.RLock()
+		transformer, ok := outputTransformers[name]
+		outputTransformersMtx.RUnlock()
+		if !ok {
+			return errors.Errorf("unknown output transformer %q", name)
+		}
+
+		if err := transformer.Transform(bundlePath, result); err != nil {
+			return errors.Wrapf(err, "failed to run output transformer %q", name)
+		}
+	}
+
+	return nil
+}
+
+// EventSummary counts the events with the same type and reason for one kind of object in a namespace
+type EventSummary struct {
+	Namespace string `json:"namespace"`
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Kind      string `json:"kind"`
+	// Count adds up the count of every event, Objects is the number of distinct involved objects
+	Count          int32       `json:"count"`
+	Objects        int         `json:"objects"`
+	FirstTimestamp metav1.Time `json:"firstTimestamp,omitempty"`
+	LastTimestamp  metav1.Time `json:"lastTimestamp,omitempty"`
+	LastMessage    string      `json:"lastMessage,omitempty"`
+}
+
+// eventsSummaryTransformer collapses the per-namespace event lists of the cluster resources
+// collector into cluster-resources/events-summary.json and removes the lists
+type eventsSummaryTransformer struct{}
+
+func (eventsSummaryTransformer) Transform(bundlePath string, result CollectorResult) error {
+	eventsDir := path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_EVENTS)
+
+	eventFiles := []string{}
+	for fileName := range result {
+		if path.Dir(fileName) == eventsDir && strings.HasSuffix(fileName, ".json") {
+			eventFiles = append(eventFiles, fileName)
+		}
+	}
+	if len(eventFiles) == 0 {
+		return nil
+	}
+	sort.Strings(eventFiles)
+
+	events := []corev1.Event{}
+	for _, fileName := range eventFiles {
+		reader, err := result.GetReader(bundlePath, fileName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", fileName)
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", fileName)
+		}
+
+		var eventList corev1.EventList
+		if err := json.Unmarshal(data, &eventList); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal %s", fileName)
+		}
+		events = append(events, eventList.Items...)
+	}
+
+	b, err := json.MarshalIndent(summarizeEvents(events), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal events summary")
+	}
+	if err := result.SaveResult(bundlePath, path.Join(constants.CLUSTER_RESOURCES_DIR, constants.CLUSTER_RESOURCES_EVENTS_SUMMARY+".json"), bytes.NewBuffer(b)); err != nil {
+		return errors.Wrap(err, "failed to save events summary")
+	}
+
+	for _, fileName := range eventFiles {
+		if err := result.RemoveResult(bundlePath, fileName); err != nil {
+			return errors.Wrapf(err, "failed to remove %s", fileName)
+		}
+	}
+
+	return nil
+}
+
+// summarizeEvents groups events by namespace, type, reason and involved object kind, sorted by
+// count with the most frequent first
+func summarizeEvents(events []corev1.Event) []EventSummary {
+	type summaryKey struct {
+		namespace, eventType, reason, kind string
+	}
+
+	byKey := map[summaryKey]*EventSummary{}
+	objects := map[summaryKey]map[string]struct{}{}
+	for _, event := range events {
+		key := summaryKey{event.Namespace, event.Type, event.Reason, event.InvolvedObject.Kind}
+		summary, ok := byKey[key]
+		if !ok {
+			summary = &EventSummary{
+				Namespace: event.Namespace,
+				Type:      event.Type,
+				Reason:    event.Reason,
+				Kind:      event.InvolvedObject.Kind,
+			}
+			byKey[key] = summary
+			objects[key] = map[string]struct{}{}
+		}
+
+		count := event.Count
+		if count < 1 {
+			count = 1
+		}
+		summary.Count += count
+		objects[key][event.InvolvedObject.Name] = struct{}{}
+
+		first, last := eventTimes(event)
+		if !first.IsZero() && (summary.FirstTimestamp.IsZero() || first.Before(&summary.FirstTimestamp)) {
+			summary.FirstTimestamp = first
+		}
+		if !last.IsZero() && !last.Before(&summary.LastTimestamp) {
+			summary.LastTimestamp = last
+			summary.LastMessage = event.Message
+		}
+	}
+
+	summaries := []EventSummary{}
+	for key, summary := range byKey {
+		summary.Objects = len(objects[key])
+		summaries = append(summaries, *summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return strings.Join([]string{a.Namespace, a.Type, a.Reason, a.Kind}, "/") < strings.Join([]string{b.Namespace, b.Type, b.Reason, b.Kind}, "/")
+	})
+
+	return summaries
+}
+
+// eventTimes returns when an event was first and last seen, falling back to the event time of
+// events.k8s.io events that don't set the legacy timestamps
+func eventTimes(event corev1.Event) (metav1.Time, metav1.Time) {
+	first, last := event.FirstTimestamp, event.LastTimestamp
+	if first.IsZero() && !event.EventTime.IsZero() {
+		first = metav1.NewTime(event.EventTime.Time)
+	}
+	if last.IsZero() {
+		last = first
+	}
+	return first, last
+}
@@ -0,0 +1,146 @@
+package collect
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_eventsSummaryTransformer(t *testing.T) {
+	at := func(minute int) metav1.Time {
+		return metav1.NewTime(time.Date(2024, 5, 1, 12, minute, 0, 0, time.Local))
+	}
+	event := func(namespace, eventType, reason, kind, name string, count int32, first, last metav1.Time, message string) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: namespace, Name: name + "." + reason},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Namespace: namespace, Name: name},
+			Type:           eventType,
+			Reason:         reason,
+			Count:          count,
+			FirstTimestamp: first,
+			LastTimestamp:  last,
+			Message:        message,
+		}
+	}
+	eventList := func(events ...corev1.Event) []byte {
+		b, err := json.Marshal(corev1.EventList{Items: events})
+		require.NoError(t, err)
+		return b
+	}
+
+	files := map[string][]byte{
+		"cluster-resources/events/default.json": eventList(
+			event("default", "Warning", "BackOff", "Pod", "api-1", 5, at(0), at(10), "Back-off restarting failed container api"),
+			event("default", "Warning", "BackOff", "Pod", "api-2", 2, at(3), at(12), "Back-off restarting failed container api in pod api-2"),
+			event("default", "Warning", "BackOff", "Pod", "api-1", 1, at(11), at(11), "Back-off restarting failed container api"),
+			event("default", "Normal", "Scheduled", "Pod", "api-2", 0, metav1.Time{}, metav1.Time{}, "Successfully assigned default/api-2 to node-1"),
+		),
+		"cluster-resources/events/kube-system.json": eventList(
+			event("kube-system", "Warning", "FailedMount", "Pod", "coredns-0", 3, at(1), at(2), "MountVolume.SetUp failed for volume config-volume"),
+		),
+		"cluster-resources/events-errors.json": []byte(`["failed to list events in namespace payments"]`),
+		"cluster-resources/pods/default.json":  []byte(`{"items":[]}`),
+	}
+
+	want := []EventSummary{
+		{Namespace: "default", Type: "Warning", Reason: "BackOff", Kind: "Pod", Count: 8, Objects: 2, FirstTimestamp: at(0), LastTimestamp: at(12), LastMessage: "Back-off restarting failed container api in pod api-2"},
+		{Namespace: "kube-system", Type: "Warning", Reason: "FailedMount", Kind: "Pod", Count: 3, Objects: 1, FirstTimestamp: at(1), LastTimestamp: at(2), LastMessage: "MountVolume.SetUp failed for volume config-volume"},
+		{Namespace: "default", Type: "Normal", Reason: "Scheduled", Kind: "Pod", Count: 1, Objects: 1},
+	}
+
+	assertSummary := func(t *testing.T, data []byte) {
+		var got []EventSummary
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, want, got)
+	}
+
+	t.Run("in memory", func(t *testing.T) {
+		result := NewResult()
+		for fileName, data := range files {
+			require.NoError(t, result.SaveResult("", fileName, bytes.NewReader(data)))
+		}
+
+		require.NoError(t, eventsSummaryTransformer{}.Transform("", result))
+
+		assert.NotContains(t, result, "cluster-resources/events/default.json")
+		assert.NotContains(t, result, "cluster-resources/events/kube-system.json")
+		assert.Contains(t, result, "cluster-resources/events-errors.json")
+		assert.Contains(t, result, "cluster-resources/pods/default.json")
+		assertSummary(t, result["cluster-resources/events-summary.json"])
+	})
+
+	t.Run("on disk", func(t *testing.T) {
+		bundlePath := t.TempDir()
+		result := NewResult()
+		for fileName, data := range files {
+			require.NoError(t, result.SaveResult(bundlePath, fileName, bytes.NewReader(data)))
+		}
+
+		require.NoError(t, eventsSummaryTransformer{}.Transform(bundlePath, result))
+
+		assert.NotContains(t, result, "cluster-resources/events/default.json")
+		assert.NoFileExists(t, filepath.Join(bundlePath, "cluster-resources/events/default.json"))
+		assert.NoFileExists(t, filepath.Join(bundlePath, "cluster-resources/events/kube-system.json"))
+		assert.Contains(t, result, "cluster-resources/events-summary.json")
+
+		data, err := os.ReadFile(filepath.Join(bundlePath, "cluster-resources/events-summary.json"))
+		require.NoError(t, err)
+		assertSummary(t, data)
+	})
+}
+
+type upperCaseTransformer struct{}
+
+func (upperCaseTransformer) Transform(bundlePath string, result CollectorResult) error {
+	for fileName, data := range result {
+		if err := result.ReplaceResult(bundlePath, fileName, strings.NewReader(strings.ToUpper(string(data)))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestTransformCollectorResult(t *testing.T) {
+	RegisterOutputTransformer("test-upper-case", upperCaseTransformer{})
+
+	data := func(transformers ...string) *CollectData {
+		return &CollectData{
+			Collector: &troubleshootv1beta2.Data{
+				CollectorMeta: troubleshootv1beta2.CollectorMeta{Transformers: transformers},
+			},
+		}
+	}
+
+	t.Run("registered transformers rewrite the result", func(t *testing.T) {
+		result := CollectorResult{"data/config.txt": []byte("log_level=debug")}
+
+		err := TransformCollectorResult("", data("test-upper-case"), result)
+		require.NoError(t, err)
+		assert.Equal(t, CollectorResult{"data/config.txt": []byte("LOG_LEVEL=DEBUG")}, result)
+	})
+
+	t.Run("no transformers", func(t *testing.T) {
+		result := CollectorResult{"data/config.txt": []byte("log_level=debug")}
+
+		err := TransformCollectorResult("", data(), result)
+		require.NoError(t, err)
+		assert.Equal(t, CollectorResult{"data/config.txt": []byte("log_level=debug")}, result)
+	})
+
+	t.Run("unknown transformer", func(t *testing.T) {
+		result := CollectorResult{"data/config.txt": []byte("log_level=debug")}
+
+		err := TransformCollectorResult("", data("test-upper-case", "summarize-everything"), result)
+		assert.EqualError(t, err, `unknown output transformer "summarize-everything"`)
+	})
+}
@@ -0,0 +1,124 @@
+package collect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+const (
+	vpaGroup    = "autoscaling.k8s.io"
+	vpaResource = "verticalpodautoscalers"
+)
+
+type CollectVPA struct {
+	Collector    *troubleshootv1beta2.VPA
+	BundlePath   string
+	Namespace    string
+	ClientConfig *rest.Config
+	Client       kubernetes.Interface
+	Context      context.Context
+	RBACErrors
+}
+
+func (c *CollectVPA) Title() string {
+	return getCollectorName(c)
+}
+
+func (c *CollectVPA) IsExcluded() (bool, error) {
+	return isExcluded(c.Collector.Exclude)
+}
+
+func (c *CollectVPA) Collect(progressChan chan<- interface{}) (CollectorResult, error) {
+	output := NewResult()
+
+	dynamicClient, err := dynamic.NewForConfig(c.ClientConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create dynamic client")
+	}
+
+	namespaces := c.Collector.Namespaces
+	if len(namespaces) == 0 && c.Namespace != "" {
+		namespaces = []string{c.Namespace}
+	}
+
+	files, errs := verticalPodAutoscalers(c.Context, c.Client.Discovery(), dynamicClient, namespaces)
+	for fileName, data := range files {
+		output.SaveResult(c.BundlePath, path.Join(constants.VPA_DIR, fileName), bytes.NewBuffer(data))
+	}
+	output.SaveResult(c.BundlePath, path.Join(constants.VPA_DIR, "errors.json"), marshalErrors(errs))
+
+	return output, nil
+}
+
+// verticalPodAutoscalers lists the VerticalPodAutoscalers in the given namespaces, or in all of
+// them, and returns them as JSON arrays keyed by "<namespace>.json". Nothing is returned when the
+// VPA API is not served.
+func verticalPodAutoscalers(ctx context.Context, dc discovery.DiscoveryInterface, client dynamic.Interface, namespaces []string) (map[string][]byte, []string) {
+	groups, err := dc.ServerGroups()
+	if err != nil {
+		return nil, []string{errors.Wrap(err, "failed to list api groups").Error()}
+	}
+
+	version := ""
+	for _, group := range groups.Groups {
+		if group.Name == vpaGroup {
+			version = group.PreferredVersion.Version
+		}
+	}
+	if version == "" {
+		klog.V(2).Info("vertical pod autoscaler api group was not found, skipping vpa collection")
+		return nil, nil
+	}
+
+	served, err := isResourceServed(dc, schema.GroupVersion{Group: vpaGroup, Version: version}, vpaResource)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	if !served {
+		return nil, nil
+	}
+
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	gvr := schema.GroupVersionResource{Group: vpaGroup, Version: version, Resource: vpaResource}
+	objectsByNamespace := map[string][]map[string]interface{}{}
+	errorList := []string{}
+	for _, namespace := range namespaces {
+		list, err := client.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errorList = append(errorList, errors.Wrapf(err, "failed to list %s", gvr.GroupResource()).Error())
+			continue
+		}
+		for _, item := range list.Items {
+			objectsByNamespace[item.GetNamespace()] = append(objectsByNamespace[item.GetNamespace()], item.Object)
+		}
+	}
+
+	files := map[string][]byte{}
+	for namespace, objects := range objectsByNamespace {
+		b, err := json.MarshalIndent(objects, "", "  ")
+		if err != nil {
+			errorList = append(errorList, errors.Wrapf(err, "failed to marshal %s", gvr.GroupResource()).Error())
+			continue
+		}
+		files[fmt.Sprintf("%s.json", namespace)] = b
+	}
+
+	return files, errorList
+}
@@ -0,0 +1,90 @@
+package collect
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	testdynamicclient "k8s.io/client-go/dynamic/fake"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_verticalPodAutoscalers(t *testing.T) {
+	vpa := func(namespace, name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "autoscaling.k8s.io/v1",
+			"kind":       "VerticalPodAutoscaler",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+		}}
+	}
+
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: "autoscaling.k8s.io", Version: "v1", Resource: "verticalpodautoscalers"}: "VerticalPodAutoscalerList",
+	}
+
+	vpaResources := []*metav1.APIResourceList{
+		{GroupVersion: "autoscaling.k8s.io/v1", APIResources: []metav1.APIResource{{Name: "verticalpodautoscalers", Kind: "VerticalPodAutoscaler", Namespaced: true}}},
+	}
+
+	tests := []struct {
+		name       string
+		resources  []*metav1.APIResourceList
+		namespaces []string
+		wantFiles  map[string]int
+	}{
+		{
+			name:      "vpa not installed",
+			resources: []*metav1.APIResourceList{},
+			wantFiles: map[string]int{},
+		},
+		{
+			name:      "all namespaces",
+			resources: vpaResources,
+			wantFiles: map[string]int{
+				"default.json": 2,
+				"web.json":     1,
+			},
+		},
+		{
+			name:       "only selected namespaces",
+			resources:  vpaResources,
+			namespaces: []string{"web"},
+			wantFiles: map[string]int{
+				"web.json": 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testclient.NewSimpleClientset()
+			fakeDiscovery, ok := client.Discovery().(*fakediscovery.FakeDiscovery)
+			require.True(t, ok)
+			fakeDiscovery.Resources = tt.resources
+
+			dynamicClient := testdynamicclient.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+				vpa("default", "api"), vpa("default", "worker"), vpa("web", "frontend"))
+
+			files, errs := verticalPodAutoscalers(context.Background(), fakeDiscovery, dynamicClient, tt.namespaces)
+			assert.Empty(t, errs)
+
+			gotFiles := map[string]int{}
+			for fileName, data := range files {
+				objects := []map[string]interface{}{}
+				require.NoError(t, json.Unmarshal(data, &objects))
+				gotFiles[fileName] = len(objects)
+			}
+			assert.Equal(t, tt.wantFiles, gotFiles)
+		})
+	}
+}
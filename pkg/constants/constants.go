@@ -21,11 +21,33 @@ const (
 	TROUBLESHOOT_ROOT_SPAN_NAME = "ReplicatedTroubleshootRootSpan"
 	EXCLUDED                    = "excluded"
 	ANALYSIS_FILENAME           = "analysis.json"
+	// MERGED_BUNDLES_DIR holds the files of merged bundles that differ from the file at the same
+	// path in an earlier bundle, under merged/<index of the bundle>/
+	MERGED_BUNDLES_DIR = "merged"
+	// REDACTIONS_FILENAME is the name of the file with the counts of the redactions performed on a bundle
+	REDACTIONS_FILENAME = "redactions.json"
+	// COLLECTOR_SIZES_FILENAME is the name of the file with the number of bytes each collector added to a bundle
+	COLLECTOR_SIZES_FILENAME = "collector-sizes.json"
+	// BUNDLE_SIZE_BUDGET_FILENAME is the name of the file listing the files trimmed or left out to keep a bundle within its size budget
+	BUNDLE_SIZE_BUDGET_FILENAME = "bundle-size-budget.json"
+	// COLLECTION_TIMING_FILENAME is the name of the file with how long each collector took to run
+	COLLECTION_TIMING_FILENAME = "collection-timing.json"
+	// METADATA_ONLY_FILENAME is the name of the file that marks a bundle collected with only metadata, listing what it includes
+	METADATA_ONLY_FILENAME = "metadata-only.json"
+	// ROOT_CAUSES_FILENAME is the name of the file with the probable root causes correlated from the analysis
+	ROOT_CAUSES_FILENAME = "root-causes.json"
+	// ANALYSIS_GRAPH_FILENAME is the name of the file with the graph of the objects involved in the analysis
+	ANALYSIS_GRAPH_FILENAME = "analysis-graph.json"
+	// COLLECTION_MANIFEST_FILENAME is the name of the file listing the collectors that completed in a bundle directory, used to resume a collection
+	COLLECTION_MANIFEST_FILENAME = "collection-manifest.json"
+	// COLLECTOR_ERRORS_DIR holds the errors of collectors that were abandoned, e.g. because they timed out, under <collector>-errors.json
+	COLLECTOR_ERRORS_DIR = "collector-errors"
 
 	// Cluster Resources Collector Directories
 	CLUSTER_RESOURCES_DIR                         = "cluster-resources"
 	CLUSTER_RESOURCES_NAMESPACES                  = "namespaces"
 	CLUSTER_RESOURCES_AUTH_CANI                   = "auth-cani-list"
+	CLUSTER_RESOURCES_RBAC_GAPS                   = "rbac-gaps"
 	CLUSTER_RESOURCES_PODS                        = "pods"
 	CLUSTER_RESOURCES_PODS_LOGS                   = "pods/logs"
 	CLUSTER_RESOURCES_POD_DISRUPTION_BUDGETS      = "pod-disruption-budgets"
@@ -61,6 +83,12 @@ const (
 	CLUSTER_RESOURCES_LEASES                      = "leases"
 	CLUSTER_RESOURCES_VOLUME_ATTACHMENTS          = "volumeattachments"
 	CLUSTER_RESOURCES_CONFIGMAPS                  = "configmaps"
+	CLUSTER_RESOURCES_CSRS                        = "certificatesigningrequests"
+	CLUSTER_RESOURCES_API_SERVICES                = "apiservices"
+	CLUSTER_RESOURCES_API_WARNINGS                = "api-warnings"
+	CLUSTER_RESOURCES_EVENTS_SUMMARY              = "events-summary"
+	CLUSTER_RESOURCES_LAST_APPLIED                = "last-applied"
+	CLUSTER_RESOURCES_MANAGED_FIELDS              = "managed-fields"
 
 	// SelfSubjectRulesReview evaluation responses
 	SELFSUBJECTRULESREVIEW_ERROR_AUTHORIZATION_WEBHOOK_UNSUPPORTED = "webhook authorizer does not support user rule resolution"
@@ -106,6 +134,72 @@ const (
 	GP_DEFAULT_IMAGE     = "alpine:3"
 	GP_DEFAULT_NAMESPACE = "default"
 
+	// Secret collector directory, secrets are saved under secrets/<namespace>/<name>.json, or
+	// secrets/<namespace>/<name>/<key>.json when the collector selects a key
+	SECRETS_DIR = "secrets"
+
+	// GitOps collector directory, Flux and Argo CD custom resources are saved
+	// under gitops/<resource>.<group>/<namespace>.json
+	GITOPS_DIR = "gitops"
+
+	// VerticalPodAutoscaler collector directory, objects are saved under autoscaling/vpa/<namespace>.json
+	VPA_DIR = "autoscaling/vpa"
+
+	// Cluster autoscaler collector directory, the autoscaler status ConfigMap, or a note that it was
+	// not found, is saved under cluster-resources/autoscaler/status.json and the events it recorded
+	// under events.json
+	CLUSTER_AUTOSCALER_DIR = "cluster-resources/autoscaler"
+
+	// metrics-server collector directory, the node and pod metrics are saved under
+	// cluster-resources/metrics/nodes.json and pods.json
+	METRICS_SERVER_DIR = "cluster-resources/metrics"
+
+	// Focus collector directory, a single object and its related objects are saved
+	// under focus/<kind>/<name>/
+	FOCUS_DIR = "focus"
+
+	// Security collector directory, the audit policy configuration is saved under
+	// security/audit-policy.json and aggregated ClusterRoles under security/aggregated-clusterroles.json
+	SECURITY_DIR                      = "security"
+	SECURITY_AUDIT_POLICY             = "audit-policy.json"
+	SECURITY_AGGREGATED_CLUSTER_ROLES = "aggregated-clusterroles.json"
+
+	// Service mesh collector directory, the control plane, sidecar injection settings and
+	// configuration of each detected mesh are saved under mesh/<istio|linkerd>/
+	MESH_DIR = "mesh"
+
+	// Live logs are tailed by the logs collector when tailDuration is set, and are saved
+	// under live-logs/<collector name>/<namespace>/<pod>/<container>.log
+	LIVE_LOGS_DIR = "live-logs"
+
+	// kube-state-metrics collector directory, the scraped metrics are saved under
+	// metrics/kube-state/metrics.txt and where they were scraped from under scrape.json
+	KUBE_STATE_METRICS_DIR = "metrics/kube-state"
+
+	// prometheus collector directory, the response of each query is saved under
+	// metrics/prometheus/<collector name>/<query name>.json
+	PROMETHEUS_DIR = "metrics/prometheus"
+
+	// admission webhooks collector directory, the webhook configurations are saved under
+	// cluster-resources/webhooks/validating.json and mutating.json and the dial results under
+	// reachability.json
+	ADMISSION_WEBHOOKS_DIR = "cluster-resources/webhooks"
+
+	// traces collector directory, the spans received on each endpoint are saved as newline
+	// delimited JSON under traces/<endpoint>.json
+	TRACES_DIR = "traces"
+
+	// kubelet collector directory, the metrics and health check of each node's kubelet are saved
+	// under host-collectors/kubelet/<node>-metrics.txt and <node>-healthz.txt
+	KUBELET_DIR = "host-collectors/kubelet"
+
+	// helm releases collector directory, each release is saved under helm/<namespace>/<release>.json
+	HELM_RELEASES_DIR = "helm"
+
+	// network throughput collector directory, each measurement is saved under
+	// network/throughput/<clientNode>-<serverNode>.json
+	NETWORK_THROUGHPUT_DIR = "network/throughput"
+
 	// Analyzer Outcome types
 	OUTCOME_PASS = "pass"
 	OUTCOME_WARN = "warn"
@@ -0,0 +1,78 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CSVHeader is the first row of the csv export of analysis results
+var CSVHeader = []string{"name", "severity", "message", "category", "involvedObject"}
+
+// ToCSV returns analysis results converted with FromAnalyzerResult as csv, with a header and one
+// row per result. The name is the title of the check, the severity is pass, warn or fail, or info
+// for suppressed results, and the category is the icon key of the analyzer, e.g.
+// kubernetes_deployment_status. Values with commas, quotes or new lines are quoted.
+func ToCSV(results []*Result) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+
+	if err := w.Write(CSVHeader); err != nil {
+		return nil, errors.Wrap(err, "failed to write csv header")
+	}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		var name, message string
+		if result.Insight != nil {
+			name = result.Insight.Primary
+			message = result.Insight.Detail
+		}
+		row := []string{
+			name,
+			csvSeverity(result.Severity),
+			message,
+			result.Labels["iconKey"],
+			csvInvolvedObject(result.InvolvedObject),
+		}
+		if err := w.Write(row); err != nil {
+			return nil, errors.Wrapf(err, "failed to write csv row for %s", name)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, errors.Wrap(err, "failed to write csv")
+	}
+	return b.Bytes(), nil
+}
+
+// csvSeverity returns the analyzer outcome a converted severity was built from
+func csvSeverity(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "fail"
+	case SeverityWarn:
+		return "warn"
+	case SeverityDebug:
+		return "pass"
+	}
+	return string(severity)
+}
+
+// csvInvolvedObject returns an object as its kind, namespace and name, e.g. Pod default/api-0, or
+// its kind and name when it is not namespaced
+func csvInvolvedObject(object *corev1.ObjectReference) string {
+	if object == nil {
+		return ""
+	}
+	if object.Namespace == "" {
+		return fmt.Sprintf("%s %s", object.Kind, object.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", object.Kind, object.Namespace, object.Name)
+}
@@ -0,0 +1,79 @@
+package convert
+
+import (
+	"testing"
+
+	analyze "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestToCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []*analyze.AnalyzeResult
+		want    string
+	}{
+		{
+			name:    "no results",
+			results: []*analyze.AnalyzeResult{},
+			want:    "name,severity,message,category,involvedObject\n",
+		},
+		{
+			name: "severities and involved objects",
+			results: []*analyze.AnalyzeResult{
+				{
+					IsFail:         true,
+					Title:          "api Status",
+					Message:        "The api deployment has no ready replicas",
+					IconKey:        "kubernetes_deployment_status",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "api"},
+				},
+				{
+					IsWarn:         true,
+					Title:          "Node Resources",
+					Message:        "Node worker-1 has less than 8Gi of memory",
+					InvolvedObject: &corev1.ObjectReference{Kind: "Node", Name: "worker-1"},
+				},
+				{
+					IsPass:  true,
+					Title:   "Kubernetes Version",
+					Message: "Your cluster meets the recommended version",
+				},
+				{
+					IsWarn:      true,
+					Title:       "Storage Class",
+					Message:     "No default storage class",
+					Suppression: &analyze.Suppression{Title: "Storage Class"},
+				},
+				nil,
+			},
+			want: "name,severity,message,category,involvedObject\n" +
+				"api Status,fail,The api deployment has no ready replicas,kubernetes_deployment_status,Deployment default/api\n" +
+				"Node Resources,warn,Node worker-1 has less than 8Gi of memory,,Node worker-1\n" +
+				"Kubernetes Version,pass,Your cluster meets the recommended version,,\n" +
+				"Storage Class,info,No default storage class,,\n",
+		},
+		{
+			name: "commas, quotes and new lines are quoted",
+			results: []*analyze.AnalyzeResult{
+				{
+					IsFail:  true,
+					Title:   "Pods, failing",
+					Message: "Pod \"api-0\" is crashing:\nback-off restarting failed container",
+				},
+			},
+			want: "name,severity,message,category,involvedObject\n" +
+				"\"Pods, failing\",fail,\"Pod \"\"api-0\"\" is crashing:\nback-off restarting failed container\",,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToCSV(FromAnalyzerResult(tt.results))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
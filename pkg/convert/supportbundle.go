@@ -7,6 +7,7 @@ import (
 
 	multierror "github.com/hashicorp/go-multierror"
 	analyze "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -35,12 +36,13 @@ type Insight struct {
 type Result struct {
 	Meta `json:",inline" yaml:",inline" hcl:",inline"`
 
-	Insight        *Insight                `json:"insight" yaml:"insight" hcl:"insight"`
-	Severity       Severity                `json:"severity" yaml:"severity" hcl:"severity"`
-	AnalyzerSpec   string                  `json:"analyzerSpec" yaml:"analyzerSpec" hcl:"analyzerSpec"`
-	Variables      map[string]interface{}  `json:"variables,omitempty" yaml:"variables,omitempty" hcl:"variables,omitempty"`
-	Error          string                  `json:"error,omitempty" yaml:"error,omitempty" hcl:"error,omitempty"`
-	InvolvedObject *corev1.ObjectReference `json:"involvedObject,omitempty" yaml:"involvedObject,omitempty" hcl:"involvedObject,omitempty"`
+	Insight        *Insight                              `json:"insight" yaml:"insight" hcl:"insight"`
+	Severity       Severity                              `json:"severity" yaml:"severity" hcl:"severity"`
+	AnalyzerSpec   string                                `json:"analyzerSpec" yaml:"analyzerSpec" hcl:"analyzerSpec"`
+	Variables      map[string]interface{}                `json:"variables,omitempty" yaml:"variables,omitempty" hcl:"variables,omitempty"`
+	Error          string                                `json:"error,omitempty" yaml:"error,omitempty" hcl:"error,omitempty"`
+	InvolvedObject *corev1.ObjectReference               `json:"involvedObject,omitempty" yaml:"involvedObject,omitempty" hcl:"involvedObject,omitempty"`
+	Remediation    []troubleshootv1beta2.RemediationStep `json:"remediation,omitempty" yaml:"remediation,omitempty" hcl:"remediation,omitempty"`
 }
 
 func (m *Insight) Render(data interface{}) (*Insight, error) {
@@ -97,8 +99,14 @@ func FromAnalyzerResult(input []*analyze.AnalyzeResult) []*Result {
 			AnalyzerSpec:   "",
 			Variables:      map[string]interface{}{},
 			InvolvedObject: i.InvolvedObject,
+			Remediation:    i.Remediation,
 		}
-		if i.IsFail {
+		if i.Suppression != nil {
+			r.Severity = SeverityInfo
+			r.Insight.Severity = SeverityInfo
+			r.Labels["suppressed"] = "true"
+			r.Labels["suppression"] = i.Suppression.Summary()
+		} else if i.IsFail {
 			r.Severity = SeverityError
 			r.Insight.Severity = SeverityError
 			r.Error = i.Message
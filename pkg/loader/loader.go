@@ -11,6 +11,7 @@ import (
 	"github.com/replicatedhq/troubleshoot/pkg/client/troubleshootclientset/scheme"
 	"github.com/replicatedhq/troubleshoot/pkg/constants"
 	"github.com/replicatedhq/troubleshoot/pkg/docrewrite"
+	"github.com/replicatedhq/troubleshoot/pkg/oci"
 	"github.com/replicatedhq/troubleshoot/pkg/types"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -33,12 +34,23 @@ type parsedDoc struct {
 	StringData map[string]string `json:"stringData" yaml:"stringData"`
 }
 
+// pullSpecFromOCIRef is a var to allow stubbing in tests
+var pullSpecFromOCIRef = oci.PullSpecFromOCIRef
+
 type LoadOptions struct {
 	RawSpecs []string
 	RawSpec  string
 
+	// OCIRefs are references of spec artifacts to pull from OCI registries, e.g.
+	// oci://registry.example.com/app/troubleshoot:1.2.0. Registry credentials are read from the
+	// helm registry config, then the docker config.
+	OCIRefs []string
+	OCIRef  string
+
 	// If true, the loader will return an error if any of the specs are not valid
-	// else the invalid specs will be ignored
+	// else the invalid specs will be ignored. Specs are validated against the JSON
+	// schema of their kind: in strict mode, fields that don't match the schema and
+	// unknown fields are errors, else fields that don't match are logged.
 	Strict bool
 }
 
@@ -60,10 +72,27 @@ type LoadOptions struct {
 // If Secrets or ConfigMaps are found, they are parsed and the support bundle, redactor
 // or preflight spec extracted from them. All other yaml documents will be ignored.
 //
+// Specs referenced by `OCIRefs` are pulled from their registry and loaded like raw
+// specs. The media type of the pulled artifact must be one of the spec media types,
+// see oci.SpecMediaType.
+//
 // If the `Strict` flag is set to true, this function will return an error if any of
 // the documents are not valid, else the invalid documents will be ignored.
 func LoadSpecs(ctx context.Context, opt LoadOptions) (*TroubleshootKinds, error) {
 	opt.RawSpecs = append(opt.RawSpecs, opt.RawSpec)
+
+	ociRefs := opt.OCIRefs
+	if opt.OCIRef != "" {
+		ociRefs = append(ociRefs, opt.OCIRef)
+	}
+	for _, ref := range ociRefs {
+		content, err := pullSpecFromOCIRef(ctx, ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to pull spec from %s", ref)
+		}
+		opt.RawSpecs = append(opt.RawSpecs, string(content))
+	}
+
 	l := specLoader{
 		strict: opt.Strict,
 	}
@@ -226,6 +255,23 @@ func (l *specLoader) loadFromSplitDocs(splitdocs []string) (*TroubleshootKinds,
 			)
 		}
 
+		converted, err = expandAnalyzerMacros(converted)
+		if err != nil {
+			if !l.strict {
+				continue
+			}
+			return nil, types.NewExitCodeError(constants.EXIT_CODE_SPEC_ISSUES,
+				errors.Wrapf(err, "failed to expand analyzer macros in '\n%s'", doc),
+			)
+		}
+
+		if err := validateSpec(converted, l.strict); err != nil {
+			if l.strict {
+				return nil, types.NewExitCodeError(constants.EXIT_CODE_SPEC_ISSUES, err)
+			}
+			klog.V(1).Infof("Loading spec that does not match its schema: %v", err)
+		}
+
 		obj, _, err := decoder.Decode([]byte(converted), nil, nil)
 		if err != nil {
 			if !l.strict {
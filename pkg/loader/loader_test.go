@@ -2,10 +2,12 @@ package loader
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/replicatedhq/troubleshoot/internal/testutils"
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/oci"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -635,3 +637,63 @@ func TestLoadingEmptySpec(t *testing.T) {
 		},
 	}, kinds)
 }
+
+func TestLoadingSpecFromOCIRef(t *testing.T) {
+	pulled := []string{}
+	pullSpecFromOCIRef = func(ctx context.Context, ref string) ([]byte, error) {
+		pulled = append(pulled, ref)
+		switch ref {
+		case "oci://registry.example.com/app/troubleshoot:1.2.0":
+			return []byte(`apiVersion: troubleshoot.sh/v1beta2
+kind: SupportBundle
+metadata:
+  name: from-oci
+`), nil
+		default:
+			return nil, fmt.Errorf("not found")
+		}
+	}
+	t.Cleanup(func() {
+		pullSpecFromOCIRef = oci.PullSpecFromOCIRef
+	})
+
+	kinds, err := LoadSpecs(context.Background(), LoadOptions{
+		OCIRef: "oci://registry.example.com/app/troubleshoot:1.2.0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oci://registry.example.com/app/troubleshoot:1.2.0"}, pulled)
+	require.Len(t, kinds.SupportBundlesV1Beta2, 1)
+	assert.Equal(t, "from-oci", kinds.SupportBundlesV1Beta2[0].Name)
+
+	_, err = LoadSpecs(context.Background(), LoadOptions{
+		OCIRefs: []string{"oci://registry.example.com/app/missing:1.2.0"},
+	})
+	assert.ErrorContains(t, err, "failed to pull spec from oci://registry.example.com/app/missing:1.2.0: not found")
+}
+
+func TestLoadingSpecNotMatchingSchema(t *testing.T) {
+	spec := `apiVersion: troubleshoot.sh/v1beta2
+kind: SupportBundle
+metadata:
+  name: app
+spec:
+  collectors:
+    - logs:
+        name: app
+        selector: [app=web]
+        limits:
+          maxAge: 24h
+          maxLnes: 1000
+`
+	_, err := LoadSpecs(context.Background(), LoadOptions{RawSpec: spec, Strict: true})
+	var validationErr *SpecValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{
+		{Path: "spec.collectors[0].logs.limits.maxLnes", Reason: "unknown field"},
+	}, validationErr.Fields)
+
+	kinds, err := LoadSpecs(context.Background(), LoadOptions{RawSpec: spec})
+	require.NoError(t, err)
+	require.Len(t, kinds.SupportBundlesV1Beta2, 1)
+	assert.Equal(t, "24h", kinds.SupportBundlesV1Beta2[0].Spec.Collectors[0].Logs.Limits.MaxAge)
+}
@@ -0,0 +1,169 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// macroParamPattern matches the ${param} placeholders of a macro
+var macroParamPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// analyzerMacroLists are the spec fields where macros can be used
+var analyzerMacroLists = []string{"analyzers", "hostAnalyzers"}
+
+// expandAnalyzerMacros instantiates the analyzer macros of a spec. Macros are defined under
+// spec.analyzerMacros, each with a name and a list of analyzers using ${param} placeholders, and
+// are used in spec.analyzers or spec.hostAnalyzers with a `macro: {name, params}` entry, see
+// docs/analyzer-macros.md.
+//
+// Each macro entry is replaced by the analyzers of the macro with the placeholders resolved. A
+// value made of a single placeholder takes the parameter as is, so parameters can be lists or
+// numbers. The expanded spec has no macros left, the rest of the code only sees concrete
+// analyzers.
+func expandAnalyzerMacros(doc []byte) ([]byte, error) {
+	if !bytes.Contains(doc, []byte("macro")) && !bytes.Contains(doc, []byte("Macro")) {
+		return doc, nil
+	}
+
+	parsed := map[string]interface{}{}
+	if err := yaml.Unmarshal(doc, &parsed); err != nil {
+		return nil, errors.Wrap(err, "failed to parse spec")
+	}
+
+	spec, ok := parsed["spec"].(map[string]interface{})
+	if !ok {
+		return doc, nil
+	}
+
+	macros := map[string][]interface{}{}
+	if definitions, ok := spec["analyzerMacros"]; ok {
+		definitionList, ok := definitions.([]interface{})
+		if !ok {
+			return nil, errors.New("analyzerMacros must be a list")
+		}
+		for i, definition := range definitionList {
+			definitionMap, _ := definition.(map[string]interface{})
+			name, _ := definitionMap["name"].(string)
+			if name == "" {
+				return nil, errors.Errorf("analyzer macro %d has no name", i)
+			}
+			if _, ok := macros[name]; ok {
+				return nil, errors.Errorf("analyzer macro %q is defined more than once", name)
+			}
+			analyzers, ok := definitionMap["analyzers"].([]interface{})
+			if !ok || len(analyzers) == 0 {
+				return nil, errors.Errorf("analyzer macro %q has no analyzers", name)
+			}
+			for _, analyzer := range analyzers {
+				if _, ok := macroUse(analyzer); ok {
+					return nil, errors.Errorf("analyzer macro %q uses another macro, macros can't be nested", name)
+				}
+			}
+			macros[name] = analyzers
+		}
+		delete(spec, "analyzerMacros")
+	}
+
+	for _, listName := range analyzerMacroLists {
+		list, ok := spec[listName].([]interface{})
+		if !ok {
+			continue
+		}
+
+		expanded := []interface{}{}
+		for _, analyzer := range list {
+			use, ok := macroUse(analyzer)
+			if !ok {
+				expanded = append(expanded, analyzer)
+				continue
+			}
+
+			name, _ := use["name"].(string)
+			analyzers, ok := macros[name]
+			if !ok {
+				return nil, errors.Errorf("analyzer macro %q is not defined", name)
+			}
+			params, _ := use["params"].(map[string]interface{})
+			for _, macroAnalyzer := range analyzers {
+				instance, err := instantiateMacroValue(macroAnalyzer, params)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to expand analyzer macro %q", name)
+				}
+				expanded = append(expanded, instance)
+			}
+		}
+		spec[listName] = expanded
+	}
+
+	return yaml.Marshal(parsed)
+}
+
+// macroUse returns the macro entry of an analyzer list item, if it is one
+func macroUse(analyzer interface{}) (map[string]interface{}, bool) {
+	analyzerMap, ok := analyzer.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	use, ok := analyzerMap["macro"].(map[string]interface{})
+	return use, ok
+}
+
+// instantiateMacroValue returns a copy of a value from a macro definition with the placeholders
+// resolved
+func instantiateMacroValue(value interface{}, params map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		instance := make(map[string]interface{}, len(v))
+		for key, fieldValue := range v {
+			resolved, err := instantiateMacroValue(fieldValue, params)
+			if err != nil {
+				return nil, err
+			}
+			instance[key] = resolved
+		}
+		return instance, nil
+	case []interface{}:
+		instance := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			resolved, err := instantiateMacroValue(item, params)
+			if err != nil {
+				return nil, err
+			}
+			instance = append(instance, resolved)
+		}
+		return instance, nil
+	case string:
+		return instantiateMacroString(v, params)
+	default:
+		return v, nil
+	}
+}
+
+func instantiateMacroString(value string, params map[string]interface{}) (interface{}, error) {
+	if match := macroParamPattern.FindStringSubmatch(value); match != nil && match[0] == value {
+		param, ok := params[match[1]]
+		if !ok {
+			return nil, errors.Errorf("parameter %q is not set", match[1])
+		}
+		return param, nil
+	}
+
+	var missing error
+	resolved := macroParamPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		name := macroParamPattern.FindStringSubmatch(placeholder)[1]
+		param, ok := params[name]
+		if !ok {
+			missing = errors.Errorf("parameter %q is not set", name)
+			return placeholder
+		}
+		return fmt.Sprint(param)
+	})
+	if missing != nil {
+		return nil, missing
+	}
+	return resolved, nil
+}
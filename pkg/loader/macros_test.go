@@ -0,0 +1,172 @@
+package loader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	analyzer "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const macroSpec = `apiVersion: troubleshoot.sh/v1beta2
+kind: Analyzer
+metadata:
+  name: macros
+spec:
+  analyzerMacros:
+    - name: log-errors
+      analyzers:
+        - textAnalyze:
+            checkName: ${component} errors
+            fileName: logs/${component}.log
+            regex: ${pattern}
+            ignoreIfNoFiles: ${optional}
+            outcomes:
+              - fail:
+                  when: "true"
+                  message: "{{ .component }} logged an error"
+              - pass:
+                  when: "false"
+                  message: No errors in the ${component} logs
+  analyzers:
+    - clusterVersion:
+        outcomes:
+          - pass:
+              message: ok
+    - macro:
+        name: log-errors
+        params:
+          component: api
+          pattern: "ERROR"
+          optional: false
+    - macro:
+        name: log-errors
+        params:
+          component: worker
+          pattern: "panic:"
+          optional: true
+`
+
+func TestLoadingAnalyzerMacros(t *testing.T) {
+	l := specLoader{strict: true}
+	kinds, err := l.loadFromStrings(macroSpec)
+	require.NoError(t, err)
+	require.Len(t, kinds.AnalyzersV1Beta2, 1)
+
+	analyzers := kinds.AnalyzersV1Beta2[0].Spec.Analyzers
+	require.Len(t, analyzers, 3)
+	require.NotNil(t, analyzers[0].ClusterVersion)
+
+	api := analyzers[1].TextAnalyze
+	require.NotNil(t, api)
+	assert.Equal(t, "api errors", api.CheckName)
+	assert.Equal(t, "logs/api.log", api.FileName)
+	assert.Equal(t, "ERROR", api.RegexPattern)
+	assert.False(t, api.IgnoreIfNoFiles)
+	assert.Equal(t, "{{ .component }} logged an error", api.Outcomes[0].Fail.Message)
+	assert.Equal(t, "No errors in the api logs", api.Outcomes[1].Pass.Message)
+
+	worker := analyzers[2].TextAnalyze
+	require.NotNil(t, worker)
+	assert.Equal(t, "worker errors", worker.CheckName)
+	assert.Equal(t, "logs/worker.log", worker.FileName)
+	assert.Equal(t, "panic:", worker.RegexPattern)
+	assert.True(t, worker.IgnoreIfNoFiles)
+
+	files := map[string][]byte{
+		"logs/api.log":    []byte("level=info msg=started\nlevel=error msg=\"ERROR connecting to db\"\n"),
+		"logs/worker.log": []byte("level=info msg=started\n"),
+	}
+	getFile := func(n string) ([]byte, error) {
+		if b, ok := files[n]; ok {
+			return b, nil
+		}
+		return nil, errors.Errorf("%s was not collected", n)
+	}
+	findFiles := func(n string, _ []string) (map[string][]byte, error) {
+		if b, ok := files[n]; ok {
+			return map[string][]byte{n: b}, nil
+		}
+		return map[string][]byte{}, nil
+	}
+
+	results := []*analyzer.AnalyzeResult{}
+	for _, a := range analyzers[1:] {
+		r, err := analyzer.Analyze(context.Background(), a, getFile, findFiles)
+		require.NoError(t, err)
+		results = append(results, r...)
+	}
+
+	require.Len(t, results, 2)
+	assert.Equal(t, "api errors", results[0].Title)
+	assert.True(t, results[0].IsFail)
+	assert.Equal(t, "worker errors", results[1].Title)
+	assert.True(t, results[1].IsPass)
+	assert.Equal(t, "No errors in the worker logs", results[1].Message)
+}
+
+func TestLoadingAnalyzerMacros_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		err  string
+	}{
+		{
+			name: "undefined macro",
+			spec: `apiVersion: troubleshoot.sh/v1beta2
+kind: Preflight
+spec:
+  analyzers:
+    - macro:
+        name: missing
+`,
+			err: `analyzer macro "missing" is not defined`,
+		},
+		{
+			name: "missing parameter",
+			spec: `apiVersion: troubleshoot.sh/v1beta2
+kind: Preflight
+spec:
+  analyzerMacros:
+    - name: node-count
+      analyzers:
+        - nodeResources:
+            checkName: at least ${count} nodes
+  analyzers:
+    - macro:
+        name: node-count
+`,
+			err: `parameter "count" is not set`,
+		},
+		{
+			name: "nested macro",
+			spec: `apiVersion: troubleshoot.sh/v1beta2
+kind: Preflight
+spec:
+  analyzerMacros:
+    - name: outer
+      analyzers:
+        - macro:
+            name: inner
+  analyzers: []
+`,
+			err: `analyzer macro "outer" uses another macro`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := specLoader{strict: true}
+			_, err := l.loadFromStrings(test.spec)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+
+			l = specLoader{}
+			kinds, err := l.loadFromStrings(test.spec)
+			require.NoError(t, err)
+			assert.Len(t, kinds.PreflightsV1Beta2, 0)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/troubleshoot/schemas"
+	openapierrors "k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/yaml"
+)
+
+// schemaFiles are the JSON schemas of the troubleshoot.sh/v1beta2 kinds, in the schemas package
+var schemaFiles = map[string]string{
+	"Analyzer":        "analyzer-troubleshoot-v1beta2.json",
+	"Collector":       "collector-troubleshoot-v1beta2.json",
+	"HostCollector":   "hostcollector-troubleshoot-v1beta2.json",
+	"HostPreflight":   "hostpreflight-troubleshoot-v1beta2.json",
+	"Preflight":       "preflight-troubleshoot-v1beta2.json",
+	"Redactor":        "redactor-troubleshoot-v1beta2.json",
+	"RemoteCollector": "remotecollector-troubleshoot-v1beta2.json",
+	"SupportBundle":   "supportbundle-troubleshoot-v1beta2.json",
+}
+
+var (
+	kindSchemas   = map[string]*spec.Schema{}
+	kindSchemasMu sync.Mutex
+)
+
+// FieldError is a field of a spec that does not match the schema of its kind
+type FieldError struct {
+	// Path of the field, e.g. spec.collectors[0].logs.limits.maxLines
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Reason)
+}
+
+// SpecValidationError is returned for a spec that does not match the schema of its kind
+type SpecValidationError struct {
+	Kind   string
+	Name   string
+	Fields []FieldError
+}
+
+func (e *SpecValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		fields = append(fields, field.Error())
+	}
+	return fmt.Sprintf("invalid %s spec %q: %s", e.Kind, e.Name, strings.Join(fields, ", "))
+}
+
+// validateSpec validates a troubleshoot.sh/v1beta2 document against the JSON schema of its kind.
+// It returns a *SpecValidationError with every field that does not match the schema, and, when
+// strict is set, every field that is not in the schema.
+func validateSpec(doc []byte, strict bool) error {
+	var data map[string]interface{}
+	if err := yaml.Unmarshal(doc, &data); err != nil {
+		return errors.Wrap(err, "failed to parse spec")
+	}
+
+	kind, _ := data["kind"].(string)
+	schema, err := getKindSchema(kind)
+	if err != nil {
+		return err
+	}
+	if schema == nil {
+		return nil
+	}
+
+	fields := []FieldError{}
+	result := validate.NewSchemaValidator(schema, nil, "", strfmt.Default).Validate(data)
+	for _, err := range result.Errors {
+		fields = append(fields, toFieldError(err))
+	}
+	if strict {
+		fields = append(fields, unknownFields("", data, schema)...)
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Path != fields[j].Path {
+			return fields[i].Path < fields[j].Path
+		}
+		return fields[i].Reason < fields[j].Reason
+	})
+
+	name := ""
+	if metadata, ok := data["metadata"].(map[string]interface{}); ok {
+		name, _ = metadata["name"].(string)
+	}
+	return &SpecValidationError{Kind: kind, Name: name, Fields: fields}
+}
+
+// getKindSchema returns the JSON schema of a kind, or nil for kinds without one
+func getKindSchema(kind string) (*spec.Schema, error) {
+	fileName, ok := schemaFiles[kind]
+	if !ok {
+		return nil, nil
+	}
+
+	kindSchemasMu.Lock()
+	defer kindSchemasMu.Unlock()
+
+	if schema, ok := kindSchemas[kind]; ok {
+		return schema, nil
+	}
+
+	b, err := schemas.FS.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read schema of %s", kind)
+	}
+	schema := &spec.Schema{}
+	if err := json.Unmarshal(b, schema); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse schema of %s", kind)
+	}
+
+	kindSchemas[kind] = schema
+	return schema, nil
+}
+
+// toFieldError converts the errors of the schema validator, such as
+// "spec.collectors[0].logs.name in body must be of type string: "number""
+func toFieldError(err error) FieldError {
+	var validationErr *openapierrors.Validation
+	if errors.As(err, &validationErr) {
+		reason := strings.TrimPrefix(validationErr.Error(), validationErr.Name+" in body ")
+		path := validationErr.Name
+		if path == "" {
+			path = "."
+		}
+		return FieldError{Path: path, Reason: reason}
+	}
+	return FieldError{Path: ".", Reason: err.Error()}
+}
+
+// unknownFields returns the fields of data that are not in its schema. Objects without properties
+// in their schema, such as metadata, and objects that preserve unknown fields are not checked.
+func unknownFields(path string, data interface{}, schema *spec.Schema) []FieldError {
+	if schema == nil {
+		return nil
+	}
+
+	fields := []FieldError{}
+	switch value := data.(type) {
+	case map[string]interface{}:
+		if preservesUnknownFields(schema) {
+			return nil
+		}
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			for key, item := range value {
+				fields = append(fields, unknownFields(joinFieldPath(path, key), item, schema.AdditionalProperties.Schema)...)
+			}
+			return fields
+		}
+		if len(schema.Properties) == 0 {
+			return nil
+		}
+		for key, item := range value {
+			property, ok := schema.Properties[key]
+			if !ok {
+				fields = append(fields, FieldError{Path: joinFieldPath(path, key), Reason: "unknown field"})
+				continue
+			}
+			fields = append(fields, unknownFields(joinFieldPath(path, key), item, &property)...)
+		}
+	case []interface{}:
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return nil
+		}
+		for i, item := range value {
+			fields = append(fields, unknownFields(fmt.Sprintf("%s[%d]", path, i), item, schema.Items.Schema)...)
+		}
+	}
+	return fields
+}
+
+func preservesUnknownFields(schema *spec.Schema) bool {
+	preserve, _ := schema.Extensions.GetBool("x-kubernetes-preserve-unknown-fields")
+	return preserve
+}
+
+func joinFieldPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
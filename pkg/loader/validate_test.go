@@ -0,0 +1,147 @@
+package loader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		doc        string
+		strict     bool
+		wantFields []FieldError
+	}{
+		{
+			name: "valid",
+			doc: `apiVersion: troubleshoot.sh/v1beta2
+kind: SupportBundle
+metadata:
+  name: app
+spec:
+  collectors:
+    - logs:
+        name: app
+        selector: [app=web]
+        limits:
+          maxLines: 1000
+    - clusterResources:
+        exclude: true
+  analyzers:
+    - deploymentStatus:
+        name: web
+        namespace: default
+        outcomes:
+          - fail:
+              when: "< 1"
+              message: web is not ready
+`,
+			strict: true,
+		},
+		{
+			name: "wrong types",
+			doc: `apiVersion: troubleshoot.sh/v1beta2
+kind: SupportBundle
+metadata:
+  name: app
+spec:
+  collectors:
+    - logs:
+        name: app
+        selector: [app=web]
+        limits:
+          maxLines: lots
+    - clusterResources:
+        namespaces: [1]
+`,
+			wantFields: []FieldError{
+				{Path: "spec.collectors[0].logs.limits.maxLines", Reason: `must be of type integer: "string"`},
+				{Path: "spec.collectors[1].clusterResources.namespaces[0]", Reason: `must be of type string: "number"`},
+			},
+		},
+		{
+			name: "missing required field",
+			doc: `apiVersion: troubleshoot.sh/v1beta2
+kind: Preflight
+metadata:
+  name: app
+spec:
+  analyzers:
+    - deploymentStatus:
+        name: web
+        namespace: default
+`,
+			wantFields: []FieldError{
+				{Path: "spec.analyzers[0].deploymentStatus.outcomes", Reason: "is required"},
+			},
+		},
+		{
+			name: "unknown fields are ignored",
+			doc: `apiVersion: troubleshoot.sh/v1beta2
+kind: HostCollector
+metadata:
+  name: host
+spec:
+  collectors:
+    - cpu:
+        colectorName: cpu
+`,
+		},
+		{
+			name: "unknown fields in strict mode",
+			doc: `apiVersion: troubleshoot.sh/v1beta2
+kind: HostCollector
+metadata:
+  name: host
+  labels:
+    app: web
+spec:
+  collectors:
+    - cpu:
+        colectorName: cpu
+    - memroy: {}
+`,
+			strict: true,
+			wantFields: []FieldError{
+				{Path: "spec.collectors[0].cpu.colectorName", Reason: "unknown field"},
+				{Path: "spec.collectors[1].memroy", Reason: "unknown field"},
+			},
+		},
+		{
+			name: "kind without schema",
+			doc: `apiVersion: troubleshoot.sh/v1beta2
+kind: Unknown
+spec:
+  anything: 1
+`,
+			strict: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSpec([]byte(tt.doc), tt.strict)
+			if len(tt.wantFields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *SpecValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantFields, validationErr.Fields)
+		})
+	}
+}
+
+func TestSpecValidationErrorMessage(t *testing.T) {
+	err := &SpecValidationError{
+		Kind: "SupportBundle",
+		Name: "app",
+		Fields: []FieldError{
+			{Path: "spec.collectors[0].logs.limits.maxLines", Reason: `must be of type integer: "string"`},
+			{Path: "spec.collectors[1].clusterInfo.colectorName", Reason: "unknown field"},
+		},
+	}
+	assert.EqualError(t, err, `invalid SupportBundle spec "app": spec.collectors[0].logs.limits.maxLines: must be of type integer: "string", spec.collectors[1].clusterInfo.colectorName: unknown field`)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -22,8 +23,20 @@ import (
 
 const (
 	HelmCredentialsFileBasename = ".config/helm/registry/config.json"
+
+	// SpecMediaType is the media type of the layer of a spec artifact holding troubleshoot specs of
+	// any kind, e.g. pushed with
+	// oras push registry.example.com/app/troubleshoot:1.0.0 specs.yaml:application/vnd.troubleshoot.spec.v1+yaml
+	SpecMediaType = "application/vnd.troubleshoot.spec.v1+yaml"
 )
 
+// specMediaTypes are the media types a spec artifact pulled with PullSpecFromOCIRef can have
+var specMediaTypes = []string{
+	SpecMediaType,
+	"replicated.preflight.spec",
+	"replicated.supportbundle.spec",
+}
+
 var (
 	ErrNoRelease = errors.New("no release found")
 )
@@ -74,7 +87,31 @@ func PullSpecsFromOCI(ctx context.Context, uri string) ([]string, error) {
 	return rawSpecs, nil
 }
 
+// PullSpecFromOCIRef pulls the spec artifact ref points to, e.g.
+// oci://registry.example.com/app/troubleshoot:1.2.0, the tag defaults to latest. The layer of the
+// artifact must have one of the spec media types, see SpecMediaType. Credentials are read from the
+// helm registry config, then the docker config.
+func PullSpecFromOCIRef(ctx context.Context, ref string) ([]byte, error) {
+	parsedRef, err := parseRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return pullArtifact(ctx, parsedRef, specMediaTypes)
+}
+
 func pullFromOCI(ctx context.Context, uri string, mediaType string, imageName string) ([]byte, error) {
+	parsedRef, err := parseURI(uri, imageName)
+	if err != nil {
+		return nil, err
+	}
+
+	return pullArtifact(ctx, parsedRef, []string{mediaType})
+}
+
+// pullArtifact pulls an artifact with a single layer, of one of allowedMediaTypes, and returns the
+// content of the layer
+func pullArtifact(ctx context.Context, parsedRef string, allowedMediaTypes []string) ([]byte, error) {
 	// helm credentials
 	helmCredentialsFile := filepath.Join(util.HomeDir(), HelmCredentialsFileBasename)
 	dockerauthClient, err := dockerauth.NewClientWithDockerFallback(helmCredentialsFile)
@@ -93,18 +130,10 @@ func pullFromOCI(ctx context.Context, uri string, mediaType string, imageName st
 	}
 
 	memoryStore := content.NewMemory()
-	allowedMediaTypes := []string{
-		mediaType,
-	}
 
 	var descriptors, layers []ocispec.Descriptor
 	registryStore := content.Registry{Resolver: resolver}
 
-	parsedRef, err := parseURI(uri, imageName)
-	if err != nil {
-		return nil, err
-	}
-
 	klog.V(1).Infof("Pulling spec from %q OCI uri", parsedRef)
 
 	manifest, err := oras.Copy(ctx, registryStore, parsedRef, memoryStore, "",
@@ -133,14 +162,13 @@ func pullFromOCI(ctx context.Context, uri string, mediaType string, imageName st
 
 	for _, descriptor := range descriptors {
 		d := descriptor
-		switch d.MediaType {
-		case mediaType:
+		if slices.Contains(allowedMediaTypes, d.MediaType) {
 			matchingDescriptor = &d
 		}
 	}
 
 	if matchingDescriptor == nil {
-		return nil, fmt.Errorf("no descriptor found with media type: %s", mediaType)
+		return nil, fmt.Errorf("no descriptor found with media type: %s", strings.Join(allowedMediaTypes, ", "))
 	}
 
 	_, matchingSpec, ok := memoryStore.Get(*matchingDescriptor)
@@ -181,3 +209,22 @@ func parseURI(in, imageName string) (string, error) {
 
 	return parsedRef.String(), nil
 }
+
+// parseRef returns the reference of a spec artifact, with or without the oci:// scheme, tagged
+// latest when it has no tag or digest
+func parseRef(in string) (string, error) {
+	ref := strings.TrimPrefix(in, "oci://")
+	if strings.Contains(ref, "://") {
+		return "", fmt.Errorf("%q is not an OCI reference", in)
+	}
+
+	parsedRef, err := registry.ParseReference(ref)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse OCI reference")
+	}
+	if parsedRef.Reference == "" {
+		parsedRef.Reference = "latest"
+	}
+
+	return parsedRef.String(), nil
+}
@@ -64,3 +64,53 @@ func Test_parseURI(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     string
+		wantRef string
+		wantErr bool
+	}{
+		{
+			name:    "oci scheme",
+			ref:     "oci://registry.example.com/app/troubleshoot:1.2.0",
+			wantRef: "registry.example.com/app/troubleshoot:1.2.0",
+		},
+		{
+			name:    "no scheme",
+			ref:     "registry.example.com/app/troubleshoot:1.2.0",
+			wantRef: "registry.example.com/app/troubleshoot:1.2.0",
+		},
+		{
+			name:    "no tag",
+			ref:     "oci://localhost:5000/app/troubleshoot",
+			wantRef: "localhost:5000/app/troubleshoot:latest",
+		},
+		{
+			name:    "digest",
+			ref:     "oci://registry.example.com/app/troubleshoot@sha256:9834876dcfb05cb167a5c24953eba58c4ac89b1adf57f28f2f9d09af107ee8f0",
+			wantRef: "registry.example.com/app/troubleshoot@sha256:9834876dcfb05cb167a5c24953eba58c4ac89b1adf57f28f2f9d09af107ee8f0",
+		},
+		{
+			name:    "empty",
+			wantErr: true,
+		},
+		{
+			name:    "other scheme",
+			ref:     "https://registry.example.com/app/troubleshoot:1.2.0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRef(tt.ref)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRef, got)
+		})
+	}
+}
@@ -234,8 +234,15 @@ func CollectWithContext(ctx context.Context, opts CollectOpts, p *troubleshootv1
 		return collectResult, collect.ErrInsufficientPermissionsToRun
 	}
 
-	// move Copy Collectors if any to the end of the execution list
-	allCollectors = collect.EnsureCopyLast(allCollectors)
+	// preflight progress is reported one collector at a time, so the stages run sequentially
+	stages, dependencyErrs, err := collect.OrderCollectorsByDependencies(allCollectors)
+	if err != nil {
+		return collectResult, errors.Wrap(err, "failed to order collectors")
+	}
+	allCollectors = []collect.Collector{}
+	for _, stage := range stages {
+		allCollectors = append(allCollectors, stage...)
+	}
 
 	for i, collector := range allCollectors {
 		_, span := otel.Tracer(constants.LIB_TRACER_NAME).Start(ctx, collector.Title())
@@ -266,6 +273,23 @@ func CollectWithContext(ctx context.Context, opts CollectOpts, p *troubleshootv1
 			}
 		}
 
+		if err, ok := dependencyErrs[collector]; ok {
+			collectorList[collector.Title()] = CollectorStatus{
+				Status: "failed",
+			}
+			opts.ProgressChan <- errors.Errorf("failed to run collector: %s: %v", collector.Title(), err)
+			opts.ProgressChan <- CollectProgress{
+				CurrentName:    collector.Title(),
+				CurrentStatus:  "failed",
+				CompletedCount: i + 1,
+				TotalCount:     len(allCollectors),
+				Collectors:     collectorList,
+			}
+			span.SetStatus(codes.Error, err.Error())
+			span.End()
+			continue
+		}
+
 		collectorList[collector.Title()] = CollectorStatus{
 			Status: "running",
 		}
@@ -277,11 +301,23 @@ func CollectWithContext(ctx context.Context, opts CollectOpts, p *troubleshootv1
 			Collectors:     collectorList,
 		}
 
-		result, err := collector.Collect(opts.ProgressChan)
+		timeout, err := collect.GetCollectorTimeout(collector)
+		if err != nil {
+			opts.ProgressChan <- errors.Errorf("invalid collector timeout, running it without one: %s: %v", collector.Title(), err)
+		}
+
+		result, err := collect.CollectWithTimeout(ctx, opts.BundlePath, collector, timeout, opts.ProgressChan)
 		if err != nil {
 			collectorList[collector.Title()] = CollectorStatus{
 				Status: "failed",
 			}
+			// the errors file of a collector that timed out is kept for the analyzers
+			var timeoutErr *collect.CollectorTimeoutError
+			if errors.As(err, &timeoutErr) {
+				for k, v := range result {
+					allCollectedData[k] = v
+				}
+			}
 			opts.ProgressChan <- errors.Errorf("failed to run collector: %s: %v", collector.Title(), err)
 			opts.ProgressChan <- CollectProgress{
 				CurrentName:    collector.Title(),
@@ -295,6 +331,11 @@ func CollectWithContext(ctx context.Context, opts CollectOpts, p *troubleshootv1
 			continue
 		}
 
+		if err := collect.TransformCollectorResult(opts.BundlePath, collector, result); err != nil {
+			opts.ProgressChan <- errors.Errorf("failed to transform collector output: %s: %v", collector.Title(), err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
 		collectorList[collector.Title()] = CollectorStatus{
 			Status: "completed",
 		}
@@ -0,0 +1,153 @@
+package preflight
+
+import (
+	"testing"
+
+	analyzerunner "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkOutcomesToExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []*analyzerunner.AnalyzeResult
+		wantCode int
+	}{
+		{
+			name: "all pass",
+			results: []*analyzerunner.AnalyzeResult{
+				{IsPass: true},
+			},
+			wantCode: 0,
+		},
+		{
+			name: "advisory warning",
+			results: []*analyzerunner.AnalyzeResult{
+				{IsPass: true},
+				{IsWarn: true},
+			},
+			wantCode: constants.EXIT_CODE_WARN,
+		},
+		{
+			name: "blocking warning fails",
+			results: []*analyzerunner.AnalyzeResult{
+				{IsWarn: true},
+				{IsWarn: true, Blocking: true},
+				{IsPass: true},
+			},
+			wantCode: constants.EXIT_CODE_FAIL,
+		},
+		{
+			name: "suppressed failure and warning",
+			results: []*analyzerunner.AnalyzeResult{
+				{IsPass: true},
+				{IsFail: true, Suppression: &analyzerunner.Suppression{Title: "Node Count"}},
+				{IsWarn: true, Blocking: true, Suppression: &analyzerunner.Suppression{Title: "Audit Logging"}},
+			},
+			wantCode: 0,
+		},
+		{
+			name: "suppressed failure with warning",
+			results: []*analyzerunner.AnalyzeResult{
+				{IsFail: true, Suppression: &analyzerunner.Suppression{Title: "Node Count"}},
+				{IsWarn: true},
+			},
+			wantCode: constants.EXIT_CODE_WARN,
+		},
+		{
+			name: "failure",
+			results: []*analyzerunner.AnalyzeResult{
+				{IsFail: true},
+				{IsWarn: true},
+			},
+			wantCode: constants.EXIT_CODE_FAIL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCode, checkOutcomesToExitCode(tt.results))
+		})
+	}
+}
+
+func Test_showTextResultsHumanBlocking(t *testing.T) {
+	results := []*analyzerunner.AnalyzeResult{
+		{IsWarn: true, Title: "Audit Logging", Message: "audit logging is disabled", Blocking: true},
+		{IsWarn: true, Title: "Node Count", Message: "fewer than 3 nodes"},
+	}
+
+	got, err := showTextResultsHuman("regulated", results)
+	assert.NoError(t, err)
+	assert.Equal(t, `
+   --- WARN: Audit Logging
+      --- audit logging is disabled
+      --- Blocking: true
+   --- WARN: Node Count
+      --- fewer than 3 nodes
+--- FAIL   regulated
+FAILED
+`, got)
+}
+
+func Test_showTextResultsStructuredBlocking(t *testing.T) {
+	results := []*analyzerunner.AnalyzeResult{
+		{IsWarn: true, Title: "Audit Logging", Message: "audit logging is disabled", Blocking: true},
+		{IsWarn: true, Title: "Node Count", Message: "fewer than 3 nodes"},
+		{IsFail: true, Title: "Kubernetes Version", Message: "unsupported version"},
+	}
+
+	output := ShowTextResultsStructured("regulated", results)
+	assert.Empty(t, output.Pass)
+	assert.Equal(t, []TextResultOutput{
+		{Title: "Node Count", Message: "fewer than 3 nodes"},
+	}, output.Warn)
+	assert.Equal(t, []TextResultOutput{
+		{Title: "Audit Logging", Message: "audit logging is disabled", Blocking: true},
+		{Title: "Kubernetes Version", Message: "unsupported version"},
+	}, output.Fail)
+
+	got, err := showTextResultsJSON("regulated", results)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "warn": [{"title": "Node Count", "message": "fewer than 3 nodes"}],
+  "fail": [
+    {"title": "Audit Logging", "message": "audit logging is disabled", "blocking": true},
+    {"title": "Kubernetes Version", "message": "unsupported version"}
+  ]
+}`, got)
+}
+
+func Test_showTextResultsSuppressed(t *testing.T) {
+	results := []*analyzerunner.AnalyzeResult{
+		{IsPass: true, Title: "Kubernetes Version", Message: "supported version"},
+		{
+			IsFail:      true,
+			Title:       "Node Count",
+			Message:     "fewer than 3 nodes",
+			Suppression: &analyzerunner.Suppression{Title: "Node Count", Reason: "single node dev cluster", Expires: "2026-12-31"},
+		},
+	}
+
+	got, err := showTextResultsHuman("dev", results)
+	assert.NoError(t, err)
+	assert.Equal(t, `
+   --- PASS Kubernetes Version
+      --- supported version
+   --- INFO: Node Count
+      --- fewer than 3 nodes
+      --- Suppressed: single node dev cluster, until 2026-12-31
+--- PASS   dev
+PASS
+`, got)
+
+	output := ShowTextResultsStructured("dev", results)
+	assert.Empty(t, output.Fail)
+	assert.Equal(t, []TextResultOutput{{
+		Title:       "Node Count",
+		Message:     "fewer than 3 nodes",
+		Outcome:     "fail",
+		Suppression: &analyzerunner.Suppression{Title: "Node Count", Reason: "single node dev cluster", Expires: "2026-12-31"},
+	}}, output.Suppressed)
+}
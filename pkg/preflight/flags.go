@@ -16,6 +16,7 @@ const (
 	flagSince                     = "since"
 	flagOutput                    = "output"
 	flagDebug                     = "debug"
+	flagIgnoreList                = "ignore-list"
 )
 
 type PreflightFlags struct {
@@ -29,6 +30,7 @@ type PreflightFlags struct {
 	Since                     *string
 	Output                    *string
 	Debug                     *bool
+	IgnoreList                *string
 }
 
 var preflightFlags *PreflightFlags
@@ -45,6 +47,7 @@ func NewPreflightFlags() *PreflightFlags {
 		Since:                     utilpointer.To(""),
 		Output:                    utilpointer.To("o"),
 		Debug:                     utilpointer.To(false),
+		IgnoreList:                utilpointer.To(""),
 	}
 }
 
@@ -66,7 +69,7 @@ func (f *PreflightFlags) addFlags(flags *flag.FlagSet) {
 		flags.BoolVar(f.Interactive, flagInteractive, *f.Interactive, "interactive preflights")
 	}
 	if f.Format != nil {
-		flags.StringVar(f.Format, flagFormat, *f.Format, "output format, one of human, json, yaml. only used when interactive is set to false")
+		flags.StringVar(f.Format, flagFormat, *f.Format, "output format, one of human, json, yaml, junit. only used when interactive is set to false")
 	}
 
 	if f.CollectorImage != nil {
@@ -93,4 +96,7 @@ func (f *PreflightFlags) addFlags(flags *flag.FlagSet) {
 	if f.Debug != nil {
 		flags.BoolVar(f.Debug, flagDebug, *f.Debug, "enable debug logging")
 	}
+	if f.IgnoreList != nil {
+		flags.StringVar(f.IgnoreList, flagIgnoreList, *f.IgnoreList, "file listing known failures and warnings, by check title with an optional reason and expiry, to report as informational instead")
+	}
 }
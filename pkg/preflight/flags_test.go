@@ -53,6 +53,11 @@ func TestAddFlagsString(t *testing.T) {
 		flag:    "output",
 		want:    "",
 		wantErr: false,
+	}, {
+		name:    "expect ignore-list=empty, err=nil when ignore-list flag is set",
+		flag:    "ignore-list",
+		want:    "",
+		wantErr: false,
 	}}
 
 	for _, tt := range tests {
@@ -16,12 +16,20 @@ import (
 var (
 	selectedResult = 0
 	table          = widgets.NewTable()
-	isShowingSaved = false
+	isShowingPopup = false
+	// checkedResults are the indexes of the results selected to be re-run
+	checkedResults = map[int]bool{}
 )
 
-func showInteractiveResults(preflightName string, outputPath string, analyzeResults []*analyzerunner.AnalyzeResult) error {
+// rerunFunc re-runs the checks of the selected results and returns all the results, with the new
+// results of those checks
+type rerunFunc func(results []*analyzerunner.AnalyzeResult, selected []*analyzerunner.AnalyzeResult) ([]*analyzerunner.AnalyzeResult, error)
+
+// showInteractiveResults shows the results until the user quits and returns them, including the
+// new results of the checks that were re-run
+func showInteractiveResults(preflightName string, outputPath string, analyzeResults []*analyzerunner.AnalyzeResult, rerun rerunFunc) ([]*analyzerunner.AnalyzeResult, error) {
 	if err := ui.Init(); err != nil {
-		return errors.Wrap(err, "failed to create terminal ui")
+		return analyzeResults, errors.Wrap(err, "failed to create terminal ui")
 	}
 	defer ui.Close()
 
@@ -33,14 +41,14 @@ func showInteractiveResults(preflightName string, outputPath string, analyzeResu
 		case e := <-uiEvents:
 			switch e.ID {
 			case "<C-c>":
-				return nil
+				return analyzeResults, nil
 			case "q":
-				if isShowingSaved == true {
-					isShowingSaved = false
+				if isShowingPopup == true {
+					isShowingPopup = false
 					ui.Clear()
 					drawUI(preflightName, analyzeResults)
 				} else {
-					return nil
+					return analyzeResults, nil
 				}
 			case "s":
 				filename, err := outputToFile(preflightName, outputPath, analyzeResults)
@@ -50,11 +58,40 @@ func showInteractiveResults(preflightName string, outputPath string, analyzeResu
 					showSaved(filename)
 					go func() {
 						time.Sleep(time.Second * 5)
-						isShowingSaved = false
+						isShowingPopup = false
 						ui.Clear()
 						drawUI(preflightName, analyzeResults)
 					}()
 				}
+			case "<Space>":
+				if isRerunnable(analyzeResults[selectedResult]) {
+					checkedResults[selectedResult] = !checkedResults[selectedResult]
+					ui.Clear()
+					drawUI(preflightName, analyzeResults)
+				}
+			case "r":
+				selected := resultsToRerun(analyzeResults, checkedResults)
+				if len(selected) == 0 {
+					break
+				}
+				// analyzers don't declare the collectors they read, so whole specs are collected again
+				showPopup(fmt.Sprintf("Re-running %d preflight checks...\n\nAll the collectors of their specs run again, not only the ones these checks read.", len(selected)))
+				results, err := rerun(analyzeResults, selected)
+				if err != nil {
+					ui.Clear()
+					drawUI(preflightName, analyzeResults)
+					showPopup(fmt.Sprintf("Failed to re-run preflight checks\n\n%v", err))
+					break
+				}
+				analyzeResults = results
+				checkedResults = map[int]bool{}
+				if selectedResult >= len(analyzeResults) {
+					selectedResult = len(analyzeResults) - 1
+					table.SelectedRow = selectedResult
+				}
+				isShowingPopup = false
+				ui.Clear()
+				drawUI(preflightName, analyzeResults)
 			case "<Resize>":
 				ui.Clear()
 				drawUI(preflightName, analyzeResults)
@@ -115,7 +152,7 @@ func drawFooter() {
 	termWidth, termHeight := ui.TerminalDimensions()
 
 	instructions := widgets.NewParagraph()
-	instructions.Text = "[q] quit    [s] save    [↑][↓] scroll"
+	instructions.Text = "[q] quit    [s] save    [↑][↓] scroll    [space] select    [r] re-run failed or selected"
 	instructions.Border = false
 
 	left := 0
@@ -141,7 +178,15 @@ func drawPreflightTable(analyzeResults []*analyzerunner.AnalyzeResult) {
 		if analyzeResult.Strict {
 			title = title + fmt.Sprintf(" (Strict: %t)", analyzeResult.Strict)
 		}
-		if analyzeResult.IsPass {
+		if analyzeResult.Blocking {
+			title = title + " (Blocking)"
+		}
+		if checkedResults[i] {
+			title = fmt.Sprintf("[x] %s", title)
+		}
+		if analyzeResult.Suppression != nil {
+			title = fmt.Sprintf("ℹ  %s (Suppressed)", title)
+		} else if analyzeResult.IsPass {
 			title = fmt.Sprintf("✔  %s", title)
 		} else if analyzeResult.IsWarn {
 			title = fmt.Sprintf("⚠️  %s", title)
@@ -152,19 +197,25 @@ func drawPreflightTable(analyzeResults []*analyzerunner.AnalyzeResult) {
 			title,
 		})
 
-		if analyzeResult.IsPass {
+		if analyzeResult.Suppression != nil {
+			if i == selectedResult {
+				table.RowStyles[i] = ui.NewStyle(ui.ColorWhite, ui.ColorClear, ui.ModifierReverse)
+			} else {
+				table.RowStyles[i] = ui.NewStyle(ui.ColorWhite, ui.ColorClear)
+			}
+		} else if analyzeResult.IsPass {
 			if i == selectedResult {
 				table.RowStyles[i] = ui.NewStyle(ui.ColorGreen, ui.ColorClear, ui.ModifierReverse)
 			} else {
 				table.RowStyles[i] = ui.NewStyle(ui.ColorGreen, ui.ColorClear)
 			}
-		} else if analyzeResult.IsWarn {
+		} else if analyzeResult.IsWarn && !analyzeResult.Blocking {
 			if i == selectedResult {
 				table.RowStyles[i] = ui.NewStyle(ui.ColorYellow, ui.ColorClear, ui.ModifierReverse)
 			} else {
 				table.RowStyles[i] = ui.NewStyle(ui.ColorYellow, ui.ColorClear)
 			}
-		} else if analyzeResult.IsFail {
+		} else if analyzeResult.IsFail || analyzeResult.IsWarn {
 			if i == selectedResult {
 				table.RowStyles[i] = ui.NewStyle(ui.ColorRed, ui.ColorClear, ui.ModifierReverse)
 			} else {
@@ -186,11 +237,13 @@ func drawDetails(analysisResult *analyzerunner.AnalyzeResult) {
 	// For long title that lead to wrapping text, the terminal width is divided by 2 and deducted by MESSAGE_TEXT_PADDING to account for the padding
 	title.Text = wordwrap.WrapString(analysisResult.Title, uint(termWidth/2-constants.MESSAGE_TEXT_PADDING))
 	title.Border = false
-	if analysisResult.IsPass {
+	if analysisResult.Suppression != nil {
+		title.TextStyle = ui.NewStyle(ui.ColorWhite, ui.ColorClear, ui.ModifierBold)
+	} else if analysisResult.IsPass {
 		title.TextStyle = ui.NewStyle(ui.ColorGreen, ui.ColorClear, ui.ModifierBold)
-	} else if analysisResult.IsWarn {
+	} else if analysisResult.IsWarn && !analysisResult.Blocking {
 		title.TextStyle = ui.NewStyle(ui.ColorYellow, ui.ColorClear, ui.ModifierBold)
-	} else if analysisResult.IsFail {
+	} else if analysisResult.IsFail || analysisResult.IsWarn {
 		title.TextStyle = ui.NewStyle(ui.ColorRed, ui.ColorClear, ui.ModifierBold)
 	}
 	height := util.EstimateNumberOfLines(title.Text)
@@ -209,6 +262,10 @@ func drawDetails(analysisResult *analyzerunner.AnalyzeResult) {
 		urlText := wordwrap.WrapString(fmt.Sprintf("For more information: %s", analysisResult.URI), uint(termWidth/2-constants.MESSAGE_TEXT_PADDING))
 		message.Text = message.Text + "\n\n" + urlText
 	}
+	if analysisResult.Suppression != nil {
+		suppressedText := wordwrap.WrapString(fmt.Sprintf("Suppressed: %s", analysisResult.Suppression.Summary()), uint(termWidth/2-constants.MESSAGE_TEXT_PADDING))
+		message.Text = message.Text + "\n\n" + suppressedText
+	}
 	height = util.EstimateNumberOfLines(message.Text) + constants.MESSAGE_TEXT_LINES_MARGIN_TO_BOTTOM
 	message.Border = false
 	message.SetRect(termWidth/2, currentTop, termWidth, currentTop+height)
@@ -216,10 +273,14 @@ func drawDetails(analysisResult *analyzerunner.AnalyzeResult) {
 }
 
 func showSaved(filename string) {
+	showPopup(fmt.Sprintf("Preflight results saved to\n\n%s", filename))
+}
+
+func showPopup(text string) {
 	termWidth, termHeight := ui.TerminalDimensions()
 
 	savedMessage := widgets.NewParagraph()
-	savedMessage.Text = fmt.Sprintf("Preflight results saved to\n\n%s", filename)
+	savedMessage.Text = text
 	savedMessage.WrapText = true
 	savedMessage.Border = true
 
@@ -231,5 +292,5 @@ func showSaved(filename string) {
 	savedMessage.SetRect(left, top, right, bottom)
 	ui.Render(savedMessage)
 
-	isShowingSaved = true
+	isShowingPopup = true
 }
@@ -0,0 +1,127 @@
+package preflight
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	analyzerunner "github.com/replicatedhq/troubleshoot/pkg/analyze"
+)
+
+// The JUnit report follows the common schema understood by CI systems: a testsuite for the
+// preflight, with a testcase for each result named after its title
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// showTextResultsJUnit reports failures, and warnings from blocking checks, as failed tests.
+// Other warnings and suppressed results are reported as skipped tests.
+func showTextResultsJUnit(preflightName string, analyzeResults []*analyzerunner.AnalyzeResult) (string, error) {
+	suite := junitTestSuite{
+		Name:      preflightName,
+		TestCases: []junitTestCase{},
+	}
+
+	for _, analyzeResult := range analyzeResults {
+		if analyzeResult == nil {
+			continue
+		}
+
+		testCase := junitTestCase{
+			Name:      analyzeResult.Title,
+			ClassName: preflightName,
+			SystemOut: analyzeResult.Message,
+		}
+		details := junitResultDetails(analyzeResult)
+
+		if analyzeResult.Suppression != nil {
+			testCase.Skipped = &junitMessage{
+				Message: fmt.Sprintf("Suppressed: %s", analyzeResult.Suppression.Summary()),
+				Text:    details,
+			}
+			suite.Skipped++
+		} else if analyzeResult.IsFail || (analyzeResult.IsWarn && analyzeResult.Blocking) {
+			failureType := "fail"
+			if !analyzeResult.IsFail {
+				failureType = "warn"
+			}
+			testCase.Failure = &junitMessage{
+				Message: analyzeResult.Message,
+				Type:    failureType,
+				Text:    details,
+			}
+			suite.Failures++
+		} else if analyzeResult.IsWarn {
+			testCase.Skipped = &junitMessage{
+				Message: analyzeResult.Message,
+				Text:    details,
+			}
+			suite.Skipped++
+		} else if !analyzeResult.IsPass {
+			continue
+		}
+
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	report := junitTestSuites{
+		Name:     preflightName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal results as junit")
+	}
+
+	return fmt.Sprintf("%s%s\n", xml.Header, b), nil
+}
+
+func junitResultDetails(analyzeResult *analyzerunner.AnalyzeResult) string {
+	details := []string{analyzeResult.Message}
+	if analyzeResult.URI != "" {
+		details = append(details, fmt.Sprintf("URI: %s", analyzeResult.URI))
+	}
+	if analyzeResult.Strict {
+		details = append(details, fmt.Sprintf("Strict: %t", analyzeResult.Strict))
+	}
+	if analyzeResult.Blocking {
+		details = append(details, fmt.Sprintf("Blocking: %t", analyzeResult.Blocking))
+	}
+	return strings.Join(details, "\n")
+}
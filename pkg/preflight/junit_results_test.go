@@ -0,0 +1,122 @@
+package preflight
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	analyzerunner "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// junitSchema is the subset of the common JUnit XML schema (the one used by Jenkins and most CI
+// systems) a report can use: the attributes and child elements allowed on each element, and the
+// attributes each element requires
+var junitSchema = map[string]struct {
+	attributes []string
+	required   []string
+	children   []string
+}{
+	"testsuites": {
+		attributes: []string{"name", "time", "tests", "failures", "disabled", "errors", "skipped"},
+		children:   []string{"testsuite"},
+	},
+	"testsuite": {
+		attributes: []string{"name", "tests", "failures", "errors", "group", "time", "disabled", "skipped", "timestamp", "hostname", "id", "package", "file", "log", "url", "version"},
+		required:   []string{"name", "tests"},
+		children:   []string{"properties", "testcase", "system-out", "system-err"},
+	},
+	"testcase": {
+		attributes: []string{"name", "classname", "assertions", "time", "status", "file", "line"},
+		required:   []string{"name", "classname"},
+		children:   []string{"skipped", "error", "failure", "system-out", "system-err"},
+	},
+	"failure":    {attributes: []string{"message", "type"}},
+	"skipped":    {attributes: []string{"message"}},
+	"system-out": {},
+}
+
+func validateJUnitReport(t *testing.T, report string) {
+	decoder := xml.NewDecoder(strings.NewReader(report))
+	parents := []string{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			name := token.Name.Local
+			element, ok := junitSchema[name]
+			require.True(t, ok, "unexpected element %s", name)
+
+			if len(parents) == 0 {
+				require.Equal(t, "testsuites", name, "unexpected root element")
+			} else {
+				parent := parents[len(parents)-1]
+				require.Contains(t, junitSchema[parent].children, name, "%s is not allowed in %s", name, parent)
+			}
+
+			attributes := map[string]bool{}
+			for _, attr := range token.Attr {
+				require.Contains(t, element.attributes, attr.Name.Local, "attribute %s is not allowed on %s", attr.Name.Local, name)
+				attributes[attr.Name.Local] = true
+			}
+			for _, attr := range element.required {
+				require.True(t, attributes[attr], "%s is missing the %s attribute", name, attr)
+			}
+
+			parents = append(parents, name)
+		case xml.EndElement:
+			parents = parents[:len(parents)-1]
+		}
+	}
+}
+
+func Test_showTextResultsJUnit(t *testing.T) {
+	analyzeResults := []*analyzerunner.AnalyzeResult{
+		{IsPass: true, Title: "Kubernetes version", Message: "Your cluster meets the recommended version"},
+		{IsFail: true, Title: "Node memory", Message: "At least 8Gi & 2 nodes are required", URI: "https://example.com/memory", Strict: true},
+		{IsWarn: true, Title: "Ingress", Message: "No ingress controller found"},
+		{IsWarn: true, Blocking: true, Title: "Storage class", Message: "No default storage class"},
+		{IsFail: true, Title: "Registry", Message: "Registry unreachable", Suppression: &analyzerunner.Suppression{Title: "Registry", Reason: "air gapped"}},
+	}
+
+	report, err := showTextResultsJUnit("my-app", analyzeResults)
+	require.NoError(t, err)
+	validateJUnitReport(t, report)
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="my-app" tests="5" failures="2" errors="0" skipped="2">
+  <testsuite name="my-app" tests="5" failures="2" errors="0" skipped="2">
+    <testcase name="Kubernetes version" classname="my-app">
+      <system-out>Your cluster meets the recommended version</system-out>
+    </testcase>
+    <testcase name="Node memory" classname="my-app">
+      <failure message="At least 8Gi &amp; 2 nodes are required" type="fail">At least 8Gi &amp; 2 nodes are required&#xA;URI: https://example.com/memory&#xA;Strict: true</failure>
+      <system-out>At least 8Gi &amp; 2 nodes are required</system-out>
+    </testcase>
+    <testcase name="Ingress" classname="my-app">
+      <skipped message="No ingress controller found">No ingress controller found</skipped>
+      <system-out>No ingress controller found</system-out>
+    </testcase>
+    <testcase name="Storage class" classname="my-app">
+      <failure message="No default storage class" type="warn">No default storage class&#xA;Blocking: true</failure>
+      <system-out>No default storage class</system-out>
+    </testcase>
+    <testcase name="Registry" classname="my-app">
+      <skipped message="Suppressed: air gapped">Registry unreachable</skipped>
+      <system-out>Registry unreachable</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, report)
+
+	report, err = showTextResultsJUnit("empty", nil)
+	require.NoError(t, err)
+	validateJUnitReport(t, report)
+}
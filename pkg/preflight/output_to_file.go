@@ -35,7 +35,9 @@ func outputToFile(preflightName string, outputPath string, analyzeResults []*ana
 	for _, analyzeResult := range analyzeResults {
 		result := ""
 
-		if analyzeResult.IsPass {
+		if analyzeResult.Suppression != nil {
+			result = "Check INFO\n"
+		} else if analyzeResult.IsPass {
 			result = "Check PASS\n"
 		} else if analyzeResult.IsWarn {
 			result = "Check WARN\n"
@@ -54,6 +56,14 @@ func outputToFile(preflightName string, outputPath string, analyzeResults []*ana
 			result = result + fmt.Sprintf("Strict: %t\n", analyzeResult.Strict)
 		}
 
+		if analyzeResult.Blocking {
+			result = result + fmt.Sprintf("Blocking: %t\n", analyzeResult.Blocking)
+		}
+
+		if analyzeResult.Suppression != nil {
+			result = result + fmt.Sprintf("Suppressed: %s\n", analyzeResult.Suppression.Summary())
+		}
+
 		result = result + "\n------------\n"
 
 		results = results + result
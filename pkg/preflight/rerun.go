@@ -0,0 +1,194 @@
+package preflight
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	analyzer "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/loader"
+)
+
+// checkRerunner re-collects and re-analyzes a subset of the preflight checks from the interactive
+// results, reusing the bundle of the first run
+type checkRerunner struct {
+	specs            *loader.TroubleshootKinds
+	bundlePath       string
+	archivePath      string
+	collectorResults collect.CollectorResult
+	suppressions     []analyzer.Suppression
+}
+
+// rerun re-runs the checks of the selected results and returns results with the new results of
+// those checks in place of the old ones. Checks are matched to their analyzers by title. Only the
+// specs with a matching analyzer are collected again, and only the matching analyzers are run.
+// Analyzers don't declare the collectors they read, so every collector of those specs runs again,
+// not only the ones the matching analyzers read.
+func (r *checkRerunner) rerun(ctx context.Context, results []*analyzer.AnalyzeResult, selected []*analyzer.AnalyzeResult) ([]*analyzer.AnalyzeResult, error) {
+	titles := map[string]bool{}
+	for _, result := range selected {
+		titles[result.Title] = true
+	}
+
+	// the progress of the collectors is not shown while the results are on screen
+	progressCh := make(chan interface{})
+	defer close(progressCh)
+	go func() {
+		for range progressCh {
+		}
+	}()
+
+	analyzers := []*troubleshootv1beta2.Analyze{}
+	hostAnalyzers := []*troubleshootv1beta2.HostAnalyze{}
+
+	for _, spec := range r.specs.PreflightsV1Beta2 {
+		matching := analyzersWithTitles(spec.Spec.Analyzers, titles)
+		if len(matching) == 0 {
+			continue
+		}
+
+		res, err := collectInCluster(ctx, &spec, progressCh, r.bundlePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to collect in cluster")
+		}
+		collectorResult, ok := (*res).(ClusterCollectResult)
+		if !ok {
+			return nil, errors.Errorf("unexpected result type: %T", *res)
+		}
+		r.collectorResults.AddResult(collect.CollectorResult(collectorResult.AllCollectedData))
+		analyzers = append(analyzers, matching...)
+	}
+
+	for _, spec := range r.specs.HostPreflightsV1Beta2 {
+		matching := hostAnalyzersWithTitles(spec.Spec.Analyzers, titles)
+		if len(matching) == 0 {
+			continue
+		}
+
+		if len(spec.Spec.Collectors) > 0 {
+			res, err := collectHost(ctx, &spec, progressCh, r.bundlePath)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to collect from host")
+			}
+			collectorResult, ok := (*res).(HostCollectResult)
+			if !ok {
+				return nil, errors.Errorf("unexpected result type: %T", *res)
+			}
+			r.collectorResults.AddResult(collect.CollectorResult(collectorResult.AllCollectedData))
+		}
+		if len(spec.Spec.RemoteCollectors) > 0 {
+			res, err := collectRemote(ctx, &spec, progressCh)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to collect remotely")
+			}
+			collectorResult, ok := (*res).(RemoteCollectResult)
+			if !ok {
+				return nil, errors.Errorf("unexpected result type: %T", *res)
+			}
+			r.collectorResults.AddResult(collect.CollectorResult(collectorResult.AllCollectedData))
+		}
+		hostAnalyzers = append(hostAnalyzers, matching...)
+	}
+
+	if len(analyzers) == 0 && len(hostAnalyzers) == 0 {
+		return nil, errors.New("no analyzers found for the selected checks")
+	}
+
+	rerunResults, err := analyzer.AnalyzeLocal(ctx, r.bundlePath, analyzers, hostAnalyzers)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to analyze support bundle")
+	}
+	analyzer.SuppressResults(rerunResults, r.suppressions)
+
+	results = mergeRerunResults(results, rerunResults, titles)
+
+	if err := saveAnalysisResultsToBundle(r.collectorResults, results, r.bundlePath); err != nil {
+		return nil, errors.Wrap(err, "failed to save analysis results to bundle")
+	}
+	if err := r.collectorResults.ArchiveBundle(r.bundlePath, r.archivePath); err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s archive", r.archivePath)
+	}
+
+	return results, nil
+}
+
+// isRerunnable returns true for the results that can be selected to be re-run, the failed and
+// warning checks that are not suppressed
+func isRerunnable(result *analyzer.AnalyzeResult) bool {
+	return result.Suppression == nil && (result.IsFail || result.IsWarn)
+}
+
+// resultsToRerun returns the results at the checked indexes, or all the failed and warning
+// results when none are checked
+func resultsToRerun(results []*analyzer.AnalyzeResult, checked map[int]bool) []*analyzer.AnalyzeResult {
+	selected := []*analyzer.AnalyzeResult{}
+	for i, result := range results {
+		if checked[i] && isRerunnable(result) {
+			selected = append(selected, result)
+		}
+	}
+	if len(selected) > 0 {
+		return selected
+	}
+
+	for _, result := range results {
+		if isRerunnable(result) {
+			selected = append(selected, result)
+		}
+	}
+	return selected
+}
+
+func analyzersWithTitles(analyzers []*troubleshootv1beta2.Analyze, titles map[string]bool) []*troubleshootv1beta2.Analyze {
+	matching := []*troubleshootv1beta2.Analyze{}
+	for _, a := range analyzers {
+		analyzerInst := analyzer.GetAnalyzer(a)
+		if analyzerInst != nil && titles[analyzerInst.Title()] {
+			matching = append(matching, a)
+		}
+	}
+	return matching
+}
+
+func hostAnalyzersWithTitles(analyzers []*troubleshootv1beta2.HostAnalyze, titles map[string]bool) []*troubleshootv1beta2.HostAnalyze {
+	matching := []*troubleshootv1beta2.HostAnalyze{}
+	for _, a := range analyzers {
+		analyzerInst, ok := analyzer.GetHostAnalyzer(a)
+		if ok && titles[analyzerInst.Title()] {
+			matching = append(matching, a)
+		}
+	}
+	return matching
+}
+
+// mergeRerunResults replaces the results with one of the re-run titles by the new results with
+// that title, where the first of the old results was. Old results are kept when their check has no
+// new result, e.g. when its analyzer failed to run, and new results with another title are appended.
+func mergeRerunResults(results []*analyzer.AnalyzeResult, rerunResults []*analyzer.AnalyzeResult, titles map[string]bool) []*analyzer.AnalyzeResult {
+	rerunByTitle := map[string][]*analyzer.AnalyzeResult{}
+	for _, result := range rerunResults {
+		rerunByTitle[result.Title] = append(rerunByTitle[result.Title], result)
+	}
+
+	merged := []*analyzer.AnalyzeResult{}
+	replaced := map[string]bool{}
+	for _, result := range results {
+		newResults, ok := rerunByTitle[result.Title]
+		if !titles[result.Title] || !ok {
+			merged = append(merged, result)
+			continue
+		}
+		if !replaced[result.Title] {
+			merged = append(merged, newResults...)
+			replaced[result.Title] = true
+		}
+	}
+
+	for _, result := range rerunResults {
+		if !replaced[result.Title] {
+			merged = append(merged, result)
+		}
+	}
+	return merged
+}
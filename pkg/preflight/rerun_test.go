@@ -0,0 +1,94 @@
+package preflight
+
+import (
+	"testing"
+
+	analyzerunner "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resultsToRerun(t *testing.T) {
+	pass := &analyzerunner.AnalyzeResult{Title: "CPU", IsPass: true}
+	warn := &analyzerunner.AnalyzeResult{Title: "Memory", IsWarn: true}
+	fail := &analyzerunner.AnalyzeResult{Title: "Kubernetes Version", IsFail: true}
+	suppressed := &analyzerunner.AnalyzeResult{Title: "Disk", IsFail: true, Suppression: &analyzerunner.Suppression{Title: "Disk"}}
+	results := []*analyzerunner.AnalyzeResult{pass, warn, fail, suppressed}
+
+	tests := []struct {
+		name    string
+		checked map[int]bool
+		want    []*analyzerunner.AnalyzeResult
+	}{
+		{
+			name: "all failed and warning checks when none are checked",
+			want: []*analyzerunner.AnalyzeResult{warn, fail},
+		},
+		{
+			name:    "checked",
+			checked: map[int]bool{2: true},
+			want:    []*analyzerunner.AnalyzeResult{fail},
+		},
+		{
+			name:    "passed and suppressed checks are not re-run",
+			checked: map[int]bool{0: true, 1: true, 3: true},
+			want:    []*analyzerunner.AnalyzeResult{warn},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resultsToRerun(results, tt.checked))
+		})
+	}
+
+	assert.Empty(t, resultsToRerun([]*analyzerunner.AnalyzeResult{pass}, nil))
+}
+
+func Test_mergeRerunResults(t *testing.T) {
+	results := []*analyzerunner.AnalyzeResult{
+		{Title: "CPU", IsPass: true},
+		{Title: "Node Status", IsFail: true, Message: "node-1 is not ready"},
+		{Title: "Node Status", IsFail: true, Message: "node-2 is not ready"},
+		{Title: "Memory", IsWarn: true},
+		{Title: "Kubernetes Version", IsFail: true},
+	}
+	rerunResults := []*analyzerunner.AnalyzeResult{
+		{Title: "Node Status", IsPass: true, Message: "all nodes are ready"},
+		{Title: "Memory", IsPass: true},
+		{Title: "Registry", IsFail: true},
+	}
+	titles := map[string]bool{"Node Status": true, "Memory": true, "Kubernetes Version": true}
+
+	want := []*analyzerunner.AnalyzeResult{
+		{Title: "CPU", IsPass: true},
+		{Title: "Node Status", IsPass: true, Message: "all nodes are ready"},
+		{Title: "Memory", IsPass: true},
+		// kept, its analyzer did not return a result
+		{Title: "Kubernetes Version", IsFail: true},
+		{Title: "Registry", IsFail: true},
+	}
+	assert.Equal(t, want, mergeRerunResults(results, rerunResults, titles))
+}
+
+func Test_analyzersWithTitles(t *testing.T) {
+	clusterVersion := &troubleshootv1beta2.Analyze{ClusterVersion: &troubleshootv1beta2.ClusterVersion{}}
+	nodes := &troubleshootv1beta2.Analyze{NodeResources: &troubleshootv1beta2.NodeResources{
+		AnalyzeMeta: troubleshootv1beta2.AnalyzeMeta{CheckName: "Enough Nodes"},
+	}}
+	analyzers := []*troubleshootv1beta2.Analyze{clusterVersion, nodes, {}}
+
+	assert.Equal(t,
+		[]*troubleshootv1beta2.Analyze{nodes},
+		analyzersWithTitles(analyzers, map[string]bool{"Enough Nodes": true}),
+	)
+	assert.Equal(t,
+		[]*troubleshootv1beta2.Analyze{clusterVersion},
+		analyzersWithTitles(analyzers, map[string]bool{"Required Kubernetes Version": true}),
+	)
+
+	cpu := &troubleshootv1beta2.HostAnalyze{CPU: &troubleshootv1beta2.CPUAnalyze{}}
+	assert.Equal(t,
+		[]*troubleshootv1beta2.HostAnalyze{cpu},
+		hostAnalyzersWithTitles([]*troubleshootv1beta2.HostAnalyze{cpu, {}}, map[string]bool{"Number of CPUs": true}),
+	)
+}
@@ -67,6 +67,14 @@ func RunPreflights(interactive bool, output string, format string, args []string
 		}
 	}
 
+	var suppressions []analyzer.Suppression
+	if ignoreList := viper.GetString(flagIgnoreList); ignoreList != "" {
+		suppressions, err = analyzer.LoadSuppressions(ignoreList)
+		if err != nil {
+			return types.NewExitCodeError(constants.EXIT_CODE_SPEC_ISSUES, err)
+		}
+	}
+
 	warning := validatePreflight(specs)
 	if warning != nil {
 		fmt.Println(warning.Warning())
@@ -184,6 +192,8 @@ func RunPreflights(interactive bool, output string, format string, args []string
 	if err != nil {
 		return errors.Wrap(err, "failed to analyze support bundle")
 	}
+	analyzer.SuppressResults(analyzeResults, suppressions)
+
 	err = saveAnalysisResultsToBundle(collectorResults, analyzeResults, bundlePath)
 	if err != nil {
 		return errors.Wrap(err, "failed to save analysis results to bundle")
@@ -215,7 +225,18 @@ func RunPreflights(interactive bool, output string, format string, args []string
 	}
 
 	if interactive {
-		err = showInteractiveResults(preflightSpecName, output, analyzeResults)
+		rerunner := &checkRerunner{
+			specs:            specs,
+			bundlePath:       bundlePath,
+			archivePath:      archivePath,
+			collectorResults: collectorResults,
+			suppressions:     suppressions,
+		}
+		rerun := func(results []*analyzer.AnalyzeResult, selected []*analyzer.AnalyzeResult) ([]*analyzer.AnalyzeResult, error) {
+			// ctx is cancelled once the progress collection has stopped
+			return rerunner.rerun(context.WithoutCancel(ctx), results, selected)
+		}
+		analyzeResults, err = showInteractiveResults(preflightSpecName, output, analyzeResults, rerun)
 	} else {
 		err = showTextResults(format, preflightSpecName, output, analyzeResults)
 	}
@@ -266,16 +287,20 @@ func saveTSVersionToBundle(results collect.CollectorResult, bundlePath string) e
 
 // Determine if any preflight checks passed vs failed vs warned
 // If all checks passed: 0
-// If 1 or more checks failed: 3
+// If 1 or more checks failed, or warned from a blocking check: 3
 // If no checks failed, but 1 or more warn: 4
+// Suppressed results are not counted.
 func checkOutcomesToExitCode(analyzeResults []*analyzer.AnalyzeResult) int {
 	// Assume pass until they don't
 	exitCode := 0
 
 	for _, analyzeResult := range analyzeResults {
-		if analyzeResult.IsWarn {
+		if analyzeResult.Suppression != nil {
+			continue
+		}
+		if analyzeResult.IsWarn && !analyzeResult.Blocking {
 			exitCode = constants.EXIT_CODE_WARN
-		} else if analyzeResult.IsFail {
+		} else if analyzeResult.IsFail || analyzeResult.IsWarn {
 			exitCode = constants.EXIT_CODE_FAIL
 			// No need to check further, a fail is a fail
 			return exitCode
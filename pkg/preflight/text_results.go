@@ -21,6 +21,8 @@ func showTextResults(format string, preflightName string, outputPath string, ana
 		results, err = showTextResultsJSON(preflightName, analyzeResults)
 	} else if format == "yaml" {
 		results, err = showTextResultsYAML(preflightName, analyzeResults)
+	} else if format == "junit" {
+		results, err = showTextResultsJUnit(preflightName, analyzeResults)
 	} else {
 		return errors.Errorf("unknown output format: %q", format)
 	}
@@ -65,16 +67,21 @@ func showTextResultsHuman(preflightName string, analyzeResults []*analyzerunner.
 }
 
 type TextResultOutput struct {
-	Title   string `json:"title" yaml:"title"`
-	Message string `json:"message" yaml:"message"`
-	URI     string `json:"uri,omitempty" yaml:"uri,omitempty"`
-	Strict  bool   `json:"strict,omitempty" yaml:"strict,omitempty"`
+	Title    string `json:"title" yaml:"title"`
+	Message  string `json:"message" yaml:"message"`
+	URI      string `json:"uri,omitempty" yaml:"uri,omitempty"`
+	Strict   bool   `json:"strict,omitempty" yaml:"strict,omitempty"`
+	Blocking bool   `json:"blocking,omitempty" yaml:"blocking,omitempty"`
+	// Outcome and Suppression are set on suppressed results, Outcome is warn or fail
+	Outcome     string                     `json:"outcome,omitempty" yaml:"outcome,omitempty"`
+	Suppression *analyzerunner.Suppression `json:"suppression,omitempty" yaml:"suppression,omitempty"`
 }
 
 type TextOutput struct {
-	Pass []TextResultOutput `json:"pass,omitempty" yaml:"pass,omitempty"`
-	Warn []TextResultOutput `json:"warn,omitempty" yaml:"warn,omitempty"`
-	Fail []TextResultOutput `json:"fail,omitempty" yaml:"fail,omitempty"`
+	Pass       []TextResultOutput `json:"pass,omitempty" yaml:"pass,omitempty"`
+	Warn       []TextResultOutput `json:"warn,omitempty" yaml:"warn,omitempty"`
+	Fail       []TextResultOutput `json:"fail,omitempty" yaml:"fail,omitempty"`
+	Suppressed []TextResultOutput `json:"suppressed,omitempty" yaml:"suppressed,omitempty"`
 }
 
 // Used by both JSON and YAML outputs
@@ -95,12 +102,21 @@ func ShowTextResultsStructured(preflightName string, analyzeResults []*analyzeru
 		if analyzeResult.Strict {
 			resultOutput.Strict = analyzeResult.Strict
 		}
-
-		if analyzeResult.IsPass {
+		resultOutput.Blocking = analyzeResult.Blocking
+
+		if analyzeResult.Suppression != nil {
+			resultOutput.Outcome = "warn"
+			if analyzeResult.IsFail {
+				resultOutput.Outcome = "fail"
+			}
+			resultOutput.Suppression = analyzeResult.Suppression
+			output.Suppressed = append(output.Suppressed, resultOutput)
+		} else if analyzeResult.IsPass {
 			output.Pass = append(output.Pass, resultOutput)
-		} else if analyzeResult.IsWarn {
+		} else if analyzeResult.IsWarn && !analyzeResult.Blocking {
 			output.Warn = append(output.Warn, resultOutput)
-		} else if analyzeResult.IsFail {
+		} else if analyzeResult.IsFail || analyzeResult.IsWarn {
+			// warnings from blocking checks are reported as failures, marked blocking
 			output.Fail = append(output.Fail, resultOutput)
 		}
 	}
@@ -131,6 +147,13 @@ func showTextResultsYAML(preflightName string, analyzeResults []*analyzerunner.A
 }
 
 func outputResult(results string, analyzeResult *analyzerunner.AnalyzeResult) (string, bool) {
+	if analyzeResult.Suppression != nil {
+		results = fmt.Sprintf("%s   --- INFO: %s\n", results, analyzeResult.Title)
+		results = fmt.Sprintf("%s      --- %s\n", results, analyzeResult.Message)
+		results = fmt.Sprintf("%s      --- Suppressed: %s\n", results, analyzeResult.Suppression.Summary())
+		return results, false
+	}
+
 	if analyzeResult.IsPass {
 		results = fmt.Sprintf("%s   --- PASS %s\n", results, analyzeResult.Title)
 		results = fmt.Sprintf("%s      --- %s\n", results, analyzeResult.Message)
@@ -145,8 +168,11 @@ func outputResult(results string, analyzeResult *analyzerunner.AnalyzeResult) (s
 	if analyzeResult.Strict {
 		results = fmt.Sprintf("%s      --- Strict: %t\n", results, analyzeResult.Strict)
 	}
+	if analyzeResult.Blocking {
+		results = fmt.Sprintf("%s      --- Blocking: %t\n", results, analyzeResult.Blocking)
+	}
 
-	if analyzeResult.IsFail {
+	if analyzeResult.IsFail || analyzeResult.Blocking {
 		return results, true
 	}
 	return results, false
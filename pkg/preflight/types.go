@@ -1,10 +1,12 @@
 package preflight
 
 type UploadPreflightResult struct {
-	Strict bool `json:"strict,omitempty"`
-	IsFail bool `json:"isFail,omitempty"`
-	IsWarn bool `json:"isWarn,omitempty"`
-	IsPass bool `json:"isPass,omitempty"`
+	Strict     bool `json:"strict,omitempty"`
+	Blocking   bool `json:"blocking,omitempty"`
+	Suppressed bool `json:"suppressed,omitempty"`
+	IsFail     bool `json:"isFail,omitempty"`
+	IsWarn     bool `json:"isWarn,omitempty"`
+	IsPass     bool `json:"isPass,omitempty"`
 
 	Title   string `json:"title"`
 	Message string `json:"message"`
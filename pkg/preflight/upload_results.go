@@ -16,13 +16,15 @@ func uploadResults(uri string, analyzeResults []*analyzerunner.AnalyzeResult) er
 	}
 	for _, analyzeResult := range analyzeResults {
 		uploadPreflightResult := &UploadPreflightResult{
-			Strict:  analyzeResult.Strict,
-			IsFail:  analyzeResult.IsFail,
-			IsWarn:  analyzeResult.IsWarn,
-			IsPass:  analyzeResult.IsPass,
-			Title:   analyzeResult.Title,
-			Message: analyzeResult.Message,
-			URI:     analyzeResult.URI,
+			Strict:     analyzeResult.Strict,
+			Blocking:   analyzeResult.Blocking,
+			Suppressed: analyzeResult.Suppression != nil,
+			IsFail:     analyzeResult.IsFail,
+			IsWarn:     analyzeResult.IsWarn,
+			IsPass:     analyzeResult.IsPass,
+			Title:      analyzeResult.Title,
+			Message:    analyzeResult.Message,
+			URI:        analyzeResult.URI,
 		}
 
 		uploadPreflightResults.Results = append(uploadPreflightResults.Results, uploadPreflightResult)
@@ -74,7 +74,8 @@ func hasStrictAnalyzer(analyzerMap map[string]interface{}) (bool, error) {
 	return false, nil
 }
 
-// HasStrictAnalyzersFailed - checks if preflight analyzer's result is strict:true and isFail:true, then returns true else false
+// HasStrictAnalyzersFailed - checks if preflight analyzer's result is strict:true and isFail:true, then returns true else false.
+// Warnings from blocking checks count as failures, suppressed results don't count.
 func HasStrictAnalyzersFailed(preflightResult *UploadPreflightResults) bool {
 	hasStrictAnalyzersFailed := false
 	// if results are empty, treat as failure
@@ -82,7 +83,7 @@ func HasStrictAnalyzersFailed(preflightResult *UploadPreflightResults) bool {
 		hasStrictAnalyzersFailed = true
 	} else {
 		for _, result := range preflightResult.Results {
-			if result.IsFail && result.Strict {
+			if (result.IsFail || result.Blocking) && result.Strict && !result.Suppressed {
 				hasStrictAnalyzersFailed = true
 			}
 		}
@@ -349,6 +349,30 @@ func TestHasStrictAnalyzerFailed(t *testing.T) {
 				},
 			},
 			want: true,
+		}, {
+			name: "expect true when preflightResult.Results has result with strict true, IsWarn true, Blocking true",
+			preflightResult: &UploadPreflightResults{
+				Results: []*UploadPreflightResult{
+					{Strict: true, IsWarn: true, Blocking: true},
+				},
+			},
+			want: true,
+		}, {
+			name: "expect false when preflightResult.Results has result with strict true, IsFail true, Suppressed true",
+			preflightResult: &UploadPreflightResults{
+				Results: []*UploadPreflightResult{
+					{Strict: true, IsFail: true, Suppressed: true},
+				},
+			},
+			want: false,
+		}, {
+			name: "expect false when preflightResult.Results has result with strict true, IsWarn true, Blocking false",
+			preflightResult: &UploadPreflightResults{
+				Results: []*UploadPreflightResult{
+					{Strict: true, IsWarn: true},
+				},
+			},
+			want: false,
 		},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,82 @@
+package redact
+
+// RedactionAudit summarises the redactions performed on a bundle. It only contains counts so it
+// can be handed to reviewers as evidence that a bundle was scrubbed without exposing anything
+// about the values that were removed.
+type RedactionAudit struct {
+	TotalRedactions   int                            `json:"totalRedactions" yaml:"totalRedactions"`
+	EntropyRedactions int                            `json:"entropyRedactions" yaml:"entropyRedactions"`
+	ByRedactor        map[string]RedactionAuditCount `json:"byRedactor" yaml:"byRedactor"`
+	ByFile            map[string]RedactionAuditCount `json:"byFile" yaml:"byFile"`
+}
+
+type RedactionAuditCount struct {
+	Redactions        int `json:"redactions" yaml:"redactions"`
+	CharactersRemoved int `json:"charactersRemoved" yaml:"charactersRemoved"`
+}
+
+// GetRedactionAudit returns an audit report of the redactions performed so far
+func GetRedactionAudit() RedactionAudit {
+	return NewRedactionAudit(GetRedactionList())
+}
+
+// NewRedactionAudit builds an audit report from a list of redactions
+func NewRedactionAudit(list RedactionList) RedactionAudit {
+	audit := RedactionAudit{
+		ByRedactor: map[string]RedactionAuditCount{},
+		ByFile:     map[string]RedactionAuditCount{},
+	}
+
+	for redactor, redactions := range list.ByRedactor {
+		audit.ByRedactor[redactor] = countRedactions(redactions)
+	}
+
+	for file, redactions := range list.ByFile {
+		count := countRedactions(redactions)
+		audit.ByFile[file] = count
+		audit.TotalRedactions += count.Redactions
+		for _, r := range redactions {
+			if r.IsEntropyMatch {
+				audit.EntropyRedactions++
+			}
+		}
+	}
+
+	return audit
+}
+
+// Add adds the counts of other to the audit, e.g. to combine the redactions performed when a
+// bundle was generated with those of redacting it again
+func (a *RedactionAudit) Add(other RedactionAudit) {
+	if a.ByRedactor == nil {
+		a.ByRedactor = map[string]RedactionAuditCount{}
+	}
+	if a.ByFile == nil {
+		a.ByFile = map[string]RedactionAuditCount{}
+	}
+
+	a.TotalRedactions += other.TotalRedactions
+	a.EntropyRedactions += other.EntropyRedactions
+	for redactor, count := range other.ByRedactor {
+		a.ByRedactor[redactor] = addRedactionCounts(a.ByRedactor[redactor], count)
+	}
+	for file, count := range other.ByFile {
+		a.ByFile[file] = addRedactionCounts(a.ByFile[file], count)
+	}
+}
+
+func addRedactionCounts(a, b RedactionAuditCount) RedactionAuditCount {
+	return RedactionAuditCount{
+		Redactions:        a.Redactions + b.Redactions,
+		CharactersRemoved: a.CharactersRemoved + b.CharactersRemoved,
+	}
+}
+
+func countRedactions(redactions []Redaction) RedactionAuditCount {
+	count := RedactionAuditCount{}
+	for _, r := range redactions {
+		count.Redactions++
+		count.CharactersRemoved += r.CharactersRemoved
+	}
+	return count
+}
@@ -0,0 +1,77 @@
+package redact
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRedactionAudit(t *testing.T) {
+	req := require.New(t)
+	ResetRedactionList()
+	defer ResetRedactionList()
+
+	redactors := []*troubleshootv1beta2.Redact{
+		{
+			Name: "api-token",
+			Removals: troubleshootv1beta2.Removals{
+				Values: []string{"tok_8f14e45fceea167a"},
+			},
+		},
+		{
+			Name: "build-id",
+			FileSelector: troubleshootv1beta2.FileSelector{
+				File: "config/*.txt",
+			},
+			Removals: troubleshootv1beta2.Removals{
+				Regex: []troubleshootv1beta2.Regex{
+					{Redactor: `(build-id=)(?P<mask>[^\s]+)`},
+				},
+			},
+		},
+	}
+
+	files := map[string]string{
+		"logs/app.log":    "starting\nauth with tok_8f14e45fceea167a\nretrying with tok_8f14e45fceea167a\n",
+		"config/app.txt":  "name=app\nbuild-id=c0ffee42c0ffee42\n",
+		"config/ping.txt": "nothing to see here\n",
+	}
+
+	for path, content := range files {
+		reader, err := Redact(strings.NewReader(content), path, redactors)
+		req.NoError(err)
+		_, err = io.ReadAll(reader)
+		req.NoError(err)
+	}
+
+	list := GetRedactionList()
+	audit := GetRedactionAudit()
+
+	// every redaction that was performed is accounted for, both by file and by redactor
+	total := 0
+	for file, redactions := range list.ByFile {
+		req.Equal(len(redactions), audit.ByFile[file].Redactions, file)
+		total += len(redactions)
+	}
+	for redactor, redactions := range list.ByRedactor {
+		req.Equal(len(redactions), audit.ByRedactor[redactor].Redactions, redactor)
+	}
+	req.Equal(total, audit.TotalRedactions)
+	req.Len(audit.ByFile, len(list.ByFile))
+	req.Len(audit.ByRedactor, len(list.ByRedactor))
+
+	req.Equal(RedactionAuditCount{Redactions: 2, CharactersRemoved: 2 * (len("tok_8f14e45fceea167a") - len(MASK_TEXT))}, audit.ByRedactor["api-token.literal.0"])
+	req.Equal(2, audit.ByFile["logs/app.log"].Redactions)
+	req.Equal(1, audit.ByRedactor["build-id.regex.0"].Redactions)
+	req.NotContains(audit.ByFile, "config/ping.txt")
+
+	// the report never contains the redacted values
+	b, err := json.Marshal(audit)
+	req.NoError(err)
+	req.NotContains(string(b), "tok_8f14e45fceea167a")
+	req.NotContains(string(b), "c0ffee42c0ffee42")
+}
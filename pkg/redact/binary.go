@@ -0,0 +1,43 @@
+package redact
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+)
+
+// binarySniffLen is how many bytes at the start of a file are checked for null bytes, the same
+// heuristic git uses to tell binary files from text
+const binarySniffLen = 8000
+
+// skipRedaction returns true when path matches a skipRedaction glob of one of the redactors
+func skipRedaction(path string, redacts []*troubleshootv1beta2.Redact) (bool, error) {
+	for _, redact := range redacts {
+		if redact == nil {
+			continue
+		}
+		for i, fileGlobString := range redact.SkipRedaction {
+			fileGlob, err := glob.Compile(fileGlobString, '/')
+			if err != nil {
+				return false, errors.Wrapf(err, "invalid skipRedaction glob string %d %q", i, fileGlobString)
+			}
+			if fileGlob.Match(path) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// sniffBinary returns true when the first bytes of input contain a null byte, along with a reader
+// of the whole input as the bytes checked are buffered
+func sniffBinary(input io.Reader) (io.Reader, bool) {
+	reader := bufio.NewReaderSize(input, binarySniffLen)
+	// Peek returns the bytes available along with io.EOF for inputs shorter than binarySniffLen
+	head, _ := reader.Peek(binarySniffLen)
+	return reader, bytes.IndexByte(head, 0) != -1
+}
@@ -0,0 +1,93 @@
+package redact
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact_Binary(t *testing.T) {
+	// a null byte past the sniffed bytes is not detected
+	padding := bytes.Repeat([]byte("a"), binarySniffLen)
+
+	tests := []struct {
+		name       string
+		path       string
+		input      []byte
+		redactors  []*troubleshootv1beta2.Redact
+		want       []byte
+		redactions int
+	}{
+		{
+			name:  "binary file",
+			path:  "host-collectors/run-host/core.1234",
+			input: []byte("ELF\x02\x01\x01\x00\x00 password=hunter2;"),
+			want:  []byte("ELF\x02\x01\x01\x00\x00 password=hunter2;"),
+		},
+		{
+			name:       "text file",
+			path:       "host-collectors/run-host/app.log",
+			input:      []byte("password=hunter2;"),
+			want:       []byte("password=***HIDDEN***;"),
+			redactions: 1,
+		},
+		{
+			name:       "null byte after the sniffed bytes",
+			path:       "host-collectors/run-host/app.log",
+			input:      append(append([]byte{}, padding...), []byte("\x00 password=hunter2;")...),
+			want:       append(append([]byte{}, padding...), []byte("\x00 password=***HIDDEN***;")...),
+			redactions: 1,
+		},
+		{
+			name:  "skipped text file",
+			path:  "host-collectors/run-host/capture.txt",
+			input: []byte("password=hunter2;"),
+			redactors: []*troubleshootv1beta2.Redact{
+				{
+					SkipRedaction: []string{"host-collectors/*/capture.*"},
+					Removals:      troubleshootv1beta2.Removals{Values: []string{"hunter2"}},
+				},
+			},
+			want: []byte("password=hunter2;"),
+		},
+		{
+			name:  "glob of another file",
+			path:  "host-collectors/run-host/app.log",
+			input: []byte("password=hunter2;"),
+			redactors: []*troubleshootv1beta2.Redact{
+				{
+					SkipRedaction: []string{"host-collectors/*/capture.*"},
+				},
+			},
+			want:       []byte("password=***HIDDEN***;"),
+			redactions: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			ResetRedactionList()
+			defer ResetRedactionList()
+
+			reader, err := Redact(bytes.NewReader(tt.input), tt.path, tt.redactors)
+			req.NoError(err)
+
+			got, err := io.ReadAll(reader)
+			req.NoError(err)
+			// line redactors end their output with a new line, none of the inputs end with one
+			req.Equal(tt.want, bytes.TrimRight(got, "\n"))
+			req.Len(GetRedactionList().ByFile[tt.path], tt.redactions)
+		})
+	}
+}
+
+func TestRedact_InvalidSkipRedactionGlob(t *testing.T) {
+	_, err := Redact(bytes.NewReader([]byte("data")), "app.log", []*troubleshootv1beta2.Redact{
+		{SkipRedaction: []string{"["}},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package redact
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"k8s.io/klog/v2"
+)
+
+// RedactDir writes a redacted copy of a collected bundle directory to outDir, which must not be
+// inside inDir. Each file is redacted with the default and additional redactors, as it would have
+// been when the bundle was collected, under its path relative to inDir so that redactors limited
+// to some files apply. Gzipped files are redacted decompressed, while archives and other binary
+// files are copied as they are. The counts of the redactions are added to the redactions.json of
+// the bundle, and the redactions performed are returned.
+func RedactDir(inDir, outDir string, redactors []*troubleshootv1beta2.Redact) (*RedactionList, error) {
+	inDir, err := filepath.Abs(inDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get absolute path of input directory")
+	}
+	outDir, err = filepath.Abs(outDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get absolute path of output directory")
+	}
+	if rel, err := filepath.Rel(inDir, outDir); err == nil && !strings.HasPrefix(rel, "..") {
+		return nil, errors.Errorf("output directory %s is inside input directory %s", outDir, inDir)
+	}
+
+	before := redactionCounts()
+	redactedFiles := map[string]bool{}
+	audit := RedactionAudit{}
+
+	err = filepath.WalkDir(inDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(inDir, path)
+		if err != nil {
+			return errors.Wrap(err, "failed to get relative path")
+		}
+		outPath := filepath.Join(outDir, rel)
+		// redactors match the slash separated paths of the files in the bundle
+		rel = filepath.ToSlash(rel)
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(outPath, 0755)
+
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read symlink %s", rel)
+			}
+			return os.Symlink(target, outPath)
+
+		case !d.Type().IsRegular():
+			klog.V(2).Infof("Skipping %s, it is not a regular file", rel)
+			return nil
+
+		case rel == constants.REDACTIONS_FILENAME:
+			// the counts of earlier redactions are added to below
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return errors.Wrap(err, "failed to read existing redactions file")
+			}
+			if err := json.Unmarshal(b, &audit); err != nil {
+				return errors.Wrap(err, "failed to decode existing redactions file")
+			}
+			return nil
+		}
+
+		redactedPath, err := redactDirFile(path, outPath, rel, redactors)
+		if err != nil {
+			return errors.Wrapf(err, "failed to redact %s", rel)
+		}
+		redactedFiles[redactedPath] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	redactions := redactionsSince(before, redactedFiles)
+	audit.Add(NewRedactionAudit(redactions))
+
+	b, err := json.MarshalIndent(audit, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal redactions")
+	}
+	if err := os.WriteFile(filepath.Join(outDir, constants.REDACTIONS_FILENAME), b, 0644); err != nil {
+		return nil, errors.Wrap(err, "failed to write redactions file")
+	}
+
+	return &redactions, nil
+}
+
+// redactDirFile writes the redacted contents of the file at path to outPath, with the same
+// permissions, and returns the path the file was redacted as
+func redactDirFile(path, outPath, rel string, redactors []*troubleshootv1beta2.Redact) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to stat file")
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open file")
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(outPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create redacted file")
+	}
+	defer out.Close()
+
+	if strings.HasSuffix(rel, ".gz") && !strings.HasSuffix(rel, ".tar.gz") {
+		gzipReader, err := gzip.NewReader(in)
+		if err == nil {
+			defer gzipReader.Close()
+			rel = strings.TrimSuffix(rel, ".gz")
+			redacted, err := Redact(gzipReader, rel, redactors)
+			if err != nil {
+				return "", err
+			}
+			gzipWriter := gzip.NewWriter(out)
+			if _, err := io.Copy(gzipWriter, redacted); err != nil {
+				return "", errors.Wrap(err, "failed to write redacted file")
+			}
+			if err := gzipWriter.Close(); err != nil {
+				return "", errors.Wrap(err, "failed to write redacted file")
+			}
+			return rel, out.Close()
+		}
+		// a file named like a gzipped file that is not one is redacted as it is
+		klog.V(2).Infof("Redacting %s uncompressed, it is not gzipped: %v", rel, err)
+		if _, err := in.Seek(0, io.SeekStart); err != nil {
+			return "", errors.Wrap(err, "failed to rewind file")
+		}
+	}
+
+	redacted, err := Redact(in, rel, redactors)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, redacted); err != nil {
+		return "", errors.Wrap(err, "failed to write redacted file")
+	}
+	return rel, out.Close()
+}
+
+// redactionCounts returns the number of redactions recorded so far for each file
+func redactionCounts() map[string]int {
+	list := GetRedactionList()
+
+	redactionListMut.Lock()
+	defer redactionListMut.Unlock()
+
+	counts := map[string]int{}
+	for file, redactions := range list.ByFile {
+		counts[file] = len(redactions)
+	}
+	return counts
+}
+
+// redactionsSince returns the redactions of files recorded after the counts were taken
+func redactionsSince(counts map[string]int, files map[string]bool) RedactionList {
+	list := GetRedactionList()
+
+	redactionListMut.Lock()
+	defer redactionListMut.Unlock()
+
+	since := RedactionList{
+		ByRedactor: map[string][]Redaction{},
+		ByFile:     map[string][]Redaction{},
+	}
+	for file, redactions := range list.ByFile {
+		if !files[file] || len(redactions) <= counts[file] {
+			continue
+		}
+		for _, redaction := range redactions[counts[file]:] {
+			since.ByFile[file] = append(since.ByFile[file], redaction)
+			since.ByRedactor[redaction.RedactorName] = append(since.ByRedactor[redaction.RedactorName], redaction)
+		}
+	}
+	return since
+}
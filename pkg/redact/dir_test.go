@@ -0,0 +1,97 @@
+package redact
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactDir(t *testing.T) {
+	req := require.New(t)
+	ResetRedactionList()
+	defer ResetRedactionList()
+
+	inDir := t.TempDir()
+	outDir := filepath.Join(t.TempDir(), "redacted")
+
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	_, err := gzipWriter.Write([]byte("password=hunter2;\n"))
+	req.NoError(err)
+	req.NoError(gzipWriter.Close())
+
+	files := map[string][]byte{
+		"host-collectors/run-host/app.log":                     []byte("password=hunter2;\n"),
+		"cluster-resources/pods/logs/default/api/api.log.gz":   compressed.Bytes(),
+		"cluster-resources/pods/logs/default/api/api-0.log.gz": []byte("connect to db with s3cr3t\n"),
+		"host-collectors/run-host/core.1234":                   []byte("ELF\x02\x01\x01\x00\x00 password=hunter2;"),
+		"secrets/db.txt":                                       []byte("token s3cr3t\n"),
+		"redactions.json":                                      []byte(`{"totalRedactions": 2, "byRedactor": {"zero": {"redactions": 2, "charactersRemoved": 14}}, "byFile": {"secrets/db.txt": {"redactions": 2, "charactersRemoved": 14}}}`),
+	}
+	for name, content := range files {
+		req.NoError(os.MkdirAll(filepath.Join(inDir, filepath.Dir(name)), 0755))
+		req.NoError(os.WriteFile(filepath.Join(inDir, name), content, 0644))
+	}
+	req.NoError(os.Symlink("app.log", filepath.Join(inDir, "host-collectors/run-host/current.log")))
+
+	redactors := []*troubleshootv1beta2.Redact{
+		{
+			Name:     "db",
+			Removals: troubleshootv1beta2.Removals{Values: []string{"s3cr3t"}},
+		},
+	}
+
+	redactions, err := RedactDir(inDir, outDir, redactors)
+	req.NoError(err)
+
+	readOut := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(outDir, name))
+		req.NoError(err)
+		return string(b)
+	}
+	// line redactors end their output with a new line
+	readText := func(name string) string {
+		return strings.TrimRight(readOut(name), "\n")
+	}
+	req.Equal("password=***HIDDEN***;", readText("host-collectors/run-host/app.log"))
+	req.Equal("token ***HIDDEN***", readText("secrets/db.txt"))
+	req.Equal("connect to db with ***HIDDEN***", readText("cluster-resources/pods/logs/default/api/api-0.log.gz"))
+	req.Equal(string(files["host-collectors/run-host/core.1234"]), readOut("host-collectors/run-host/core.1234"))
+
+	gzipReader, err := gzip.NewReader(bytes.NewBufferString(readOut("cluster-resources/pods/logs/default/api/api.log.gz")))
+	req.NoError(err)
+	decompressed, err := io.ReadAll(gzipReader)
+	req.NoError(err)
+	req.Equal("password=***HIDDEN***;", strings.TrimRight(string(decompressed), "\n"))
+
+	target, err := os.Readlink(filepath.Join(outDir, "host-collectors/run-host/current.log"))
+	req.NoError(err)
+	req.Equal("app.log", target)
+
+	// the input directory is left as it is
+	b, err := os.ReadFile(filepath.Join(inDir, "secrets/db.txt"))
+	req.NoError(err)
+	req.Equal("token s3cr3t\n", string(b))
+
+	req.Len(redactions.ByFile, 4)
+	req.Len(redactions.ByFile["cluster-resources/pods/logs/default/api/api.log"], 1)
+	req.Len(redactions.ByFile["cluster-resources/pods/logs/default/api/api-0.log.gz"], 1)
+	req.Len(redactions.ByRedactor["db.literal.0"], 2)
+
+	audit := RedactionAudit{}
+	req.NoError(json.Unmarshal([]byte(readOut("redactions.json")), &audit))
+	req.Equal(6, audit.TotalRedactions)
+	// "s3cr3t" is shorter than the mask that replaces it
+	req.Equal(RedactionAuditCount{Redactions: 3, CharactersRemoved: 14 + len("s3cr3t") - len(MASK_TEXT)}, audit.ByFile["secrets/db.txt"])
+
+	_, err = RedactDir(inDir, filepath.Join(inDir, "redacted"), redactors)
+	req.ErrorContains(err, "is inside input directory")
+}
@@ -0,0 +1,142 @@
+package redact
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+)
+
+// snippetContext is the number of characters kept on each side of the first mask in a snippet
+const snippetContext = 40
+
+var (
+	// dryRuns holds the redactions found by the dry runs in progress, keyed by file path
+	dryRuns    = map[string]*[]Redaction{}
+	dryRunsMut sync.Mutex
+)
+
+// RedactionMatch is a redaction a dry run found, without the value that would be redacted
+type RedactionMatch struct {
+	File              string `json:"file" yaml:"file"`
+	RedactorName      string `json:"redactorName" yaml:"redactorName"`
+	IsDefaultRedactor bool   `json:"isDefaultRedactor" yaml:"isDefaultRedactor"`
+	// Line is 0 for redactors that re-encode the whole document, like the yaml and secrets
+	// redactors, and have no line to point to
+	Line              int `json:"line" yaml:"line"`
+	CharactersRemoved int `json:"charactersRemoved" yaml:"charactersRemoved"`
+	// Snippet is the redacted line around the first mask, so the matched value never appears in
+	// it. It is empty when Line is 0.
+	Snippet string `json:"snippet,omitempty" yaml:"snippet,omitempty"`
+}
+
+// DryRun runs the redactors that apply to path over input and returns the redactions they would
+// perform, ordered by line. input is only read, and the redactions are not added to the redaction
+// list or the audit.
+func DryRun(input io.Reader, path string, additionalRedactors []*troubleshootv1beta2.Redact) ([]RedactionMatch, error) {
+	redactions := []Redaction{}
+
+	dryRunsMut.Lock()
+	if _, ok := dryRuns[path]; ok {
+		dryRunsMut.Unlock()
+		return nil, errors.Errorf("a dry run of %s is already in progress", path)
+	}
+	dryRuns[path] = &redactions
+	dryRunsMut.Unlock()
+
+	defer func() {
+		dryRunsMut.Lock()
+		defer dryRunsMut.Unlock()
+		delete(dryRuns, path)
+	}()
+
+	redacted, err := Redact(input, path, additionalRedactors)
+	if err != nil {
+		return nil, err
+	}
+
+	// every redactor records its redactions before closing its output, so all of them have been
+	// recorded once the output of the last one is read
+	output, err := io.ReadAll(redacted)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read redacted output")
+	}
+
+	dryRunsMut.Lock()
+	defer dryRunsMut.Unlock()
+
+	lines := bytes.Split(output, NEW_LINE)
+	matches := make([]RedactionMatch, 0, len(redactions))
+	for _, redaction := range redactions {
+		match := RedactionMatch{
+			File:              path,
+			RedactorName:      redaction.RedactorName,
+			IsDefaultRedactor: redaction.IsDefaultRedactor,
+			Line:              redaction.Line,
+			CharactersRemoved: redaction.CharactersRemoved,
+		}
+		if redaction.Line > 0 && redaction.Line <= len(lines) {
+			match.Snippet = redactionSnippet(lines[redaction.Line-1])
+		}
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Line < matches[j].Line
+	})
+
+	return matches, nil
+}
+
+// recordDryRunRedaction adds the redaction to the dry run of its file, it returns false when the
+// file is not being dry run
+func recordDryRunRedaction(redaction Redaction) bool {
+	dryRunsMut.Lock()
+	defer dryRunsMut.Unlock()
+
+	redactions, ok := dryRuns[redaction.File]
+	if !ok {
+		return false
+	}
+	*redactions = append(*redactions, redaction)
+	return true
+}
+
+// redactionSnippet returns the part of a redacted line around its first mask or token
+func redactionSnippet(line []byte) string {
+	start, end := 0, len(line)
+	if i, n := firstMask(line); i >= 0 {
+		start = max(0, i-snippetContext)
+		end = min(len(line), i+n+snippetContext)
+	} else if end > 2*snippetContext {
+		end = 2 * snippetContext
+	}
+
+	snippet := string(bytes.TrimSpace(line[start:end]))
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(line) {
+		snippet = snippet + "..."
+	}
+	return snippet
+}
+
+// firstMask returns the index and length of the first mask or token in line, or -1 when there is
+// none
+func firstMask(line []byte) (int, int) {
+	mask := bytes.Index(line, maskTextBytes)
+	token := bytes.Index(line, tokenPrefixBytes)
+	if token >= 0 && (mask < 0 || token < mask) {
+		if end := bytes.Index(line[token+len(tokenPrefixBytes):], []byte(tokenSuffix)); end >= 0 {
+			return token, len(tokenPrefixBytes) + end + len(tokenSuffix)
+		}
+	}
+	if mask >= 0 {
+		return mask, len(maskTextBytes)
+	}
+	return -1, 0
+}
@@ -0,0 +1,172 @@
+package redact
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"unicode"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"k8s.io/klog/v2"
+)
+
+const (
+	defaultEntropyMinLength           = 20
+	defaultEntropyMinEntropy          = 4.2
+	defaultEntropyMinCharacterClasses = 3
+)
+
+// candidate tokens are runs of the characters used by base64, base64url and most api key formats
+var entropyTokenRegex = regexp.MustCompile(`[A-Za-z0-9+/_\-]+=*`)
+
+type EntropyRedactor struct {
+	minLength           int
+	minEntropy          float64
+	minCharacterClasses int
+	filePath            string
+	redactName          string
+	tokenize            bool
+}
+
+func NewEntropyRedactor(removal troubleshootv1beta2.EntropyRemoval, path, name string) (*EntropyRedactor, error) {
+	r := &EntropyRedactor{
+		minLength:           defaultEntropyMinLength,
+		minEntropy:          defaultEntropyMinEntropy,
+		minCharacterClasses: defaultEntropyMinCharacterClasses,
+		filePath:            path,
+		redactName:          name,
+	}
+
+	if removal.MinLength > 0 {
+		r.minLength = removal.MinLength
+	}
+	if removal.MinCharacterClasses > 0 {
+		r.minCharacterClasses = removal.MinCharacterClasses
+	}
+	if removal.MinEntropy != "" {
+		minEntropy, err := strconv.ParseFloat(removal.MinEntropy, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid minEntropy %q", removal.MinEntropy)
+		}
+		r.minEntropy = minEntropy
+	}
+
+	return r, nil
+}
+
+func (r *EntropyRedactor) Redact(input io.Reader, path string) io.Reader {
+	out, writer := io.Pipe()
+
+	go func() {
+		var err error
+		defer func() {
+			if err == nil || err == io.EOF {
+				writer.Close()
+			} else {
+				if err == bufio.ErrTooLong {
+					s := fmt.Sprintf("Error redacting %q. A line in the file exceeded %d MB max length", path, constants.SCANNER_MAX_SIZE/1024/1024)
+					klog.V(2).Info(s)
+				} else {
+					klog.V(2).Info(fmt.Sprintf("Error redacting %q: %v", path, err))
+				}
+				writer.CloseWithError(err)
+			}
+		}()
+
+		buf := make([]byte, constants.BUF_INIT_SIZE)
+		scanner := bufio.NewScanner(input)
+		scanner.Buffer(buf, constants.SCANNER_MAX_SIZE)
+
+		lineNum := 0
+		for scanner.Scan() {
+			lineNum++
+			line := scanner.Bytes()
+
+			clean := entropyTokenRegex.ReplaceAllFunc(line, func(token []byte) []byte {
+				if r.isLikelySecret(token) {
+					if r.tokenize {
+						return []byte(GetGlobalTokenizer().Token(string(token)))
+					}
+					return maskTextBytes
+				}
+				return token
+			})
+
+			// Append newline since scanner strips it
+			err = writeBytes(writer, clean, NEW_LINE)
+			if err != nil {
+				return
+			}
+
+			if !bytes.Equal(clean, line) {
+				addRedaction(Redaction{
+					RedactorName:      r.redactName,
+					CharactersRemoved: len(line) - len(clean),
+					Line:              lineNum,
+					File:              r.filePath,
+					IsEntropyMatch:    true,
+				})
+			}
+		}
+		if scanErr := scanner.Err(); scanErr != nil {
+			err = scanErr
+		}
+	}()
+	return out
+}
+
+// isLikelySecret applies the cheap length and character class checks before computing entropy
+func (r *EntropyRedactor) isLikelySecret(token []byte) bool {
+	if len(token) < r.minLength {
+		return false
+	}
+	if characterClasses(token) < r.minCharacterClasses {
+		return false
+	}
+	return shannonEntropy(token) >= r.minEntropy
+}
+
+// characterClasses counts which of lowercase letters, uppercase letters, digits and symbols
+// appear in a token
+func characterClasses(token []byte) int {
+	var lower, upper, digit, symbol int
+	for _, c := range token {
+		switch {
+		case unicode.IsLower(rune(c)):
+			lower = 1
+		case unicode.IsUpper(rune(c)):
+			upper = 1
+		case unicode.IsDigit(rune(c)):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// shannonEntropy returns the entropy of a token in bits per character
+func shannonEntropy(token []byte) float64 {
+	if len(token) == 0 {
+		return 0
+	}
+
+	counts := map[byte]int{}
+	for _, c := range token {
+		counts[c]++
+	}
+
+	entropy := 0.0
+	length := float64(len(token))
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,136 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// helmSensitiveValueKey matches the keys of Helm values holding credentials. Every string below
+// a matching key is masked, so that maps of secrets are redacted as well.
+var helmSensitiveValueKey = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|api_?key|access_?key|private_?key)`)
+
+// helmValuesRedactor masks the credentials in the values of Helm releases saved by the helm
+// collectors. Values are JSON objects under "values" keys and their keys are chart specific, so
+// the line redactors, which look for environment variable and connection string patterns, don't
+// match them.
+type helmValuesRedactor struct {
+	filePath   string
+	redactName string
+}
+
+func newHelmValuesRedactor(path, name string) *helmValuesRedactor {
+	return &helmValuesRedactor{
+		filePath:   path,
+		redactName: name,
+	}
+}
+
+func (r *helmValuesRedactor) Redact(input io.Reader, path string) io.Reader {
+	if !strings.HasSuffix(path, ".json") {
+		return input
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		var err error
+		defer func() {
+			writer.CloseWithError(err)
+		}()
+
+		var doc []byte
+		doc, err = io.ReadAll(input)
+		if err != nil {
+			return
+		}
+
+		redacted, removed := r.redactDocument(doc)
+		if len(removed) == 0 {
+			_, err = writer.Write(doc)
+			return
+		}
+
+		_, err = writer.Write(redacted)
+		for _, characters := range removed {
+			addRedaction(Redaction{
+				RedactorName:      r.redactName,
+				CharactersRemoved: characters,
+				Line:              0, // line 0 because the document is re-encoded
+				File:              r.filePath,
+				IsDefaultRedactor: true,
+			})
+		}
+	}()
+	return reader
+}
+
+func (r *helmValuesRedactor) redactDocument(doc []byte) ([]byte, []int) {
+	if !bytes.Contains(doc, []byte(`"values"`)) {
+		return doc, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil {
+		return doc, nil
+	}
+
+	removed := []int{}
+	redactHelmReleases(parsed, &removed)
+	if len(removed) == 0 {
+		return doc, nil
+	}
+
+	redacted, err := json.MarshalIndent(parsed, "", "  ")
+	if err != nil {
+		klog.Errorf("Failed to marshal redacted helm values in %q: %v", r.filePath, err)
+		return doc, nil
+	}
+	if bytes.HasSuffix(doc, []byte("\n")) {
+		redacted = append(redacted, '\n')
+	}
+	return redacted, removed
+}
+
+// redactHelmReleases finds the "values" objects of the releases and revisions in a document
+func redactHelmReleases(v interface{}, removed *[]int) {
+	switch typed := v.(type) {
+	case []interface{}:
+		for _, item := range typed {
+			redactHelmReleases(item, removed)
+		}
+	case map[string]interface{}:
+		for key, value := range typed {
+			if key == "values" {
+				redactHelmValues(value, false, removed)
+				continue
+			}
+			redactHelmReleases(value, removed)
+		}
+	}
+}
+
+// redactHelmValues masks the strings under sensitive keys
+func redactHelmValues(v interface{}, sensitive bool, removed *[]int) interface{} {
+	switch typed := v.(type) {
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = redactHelmValues(item, sensitive, removed)
+		}
+	case map[string]interface{}:
+		for key, value := range typed {
+			typed[key] = redactHelmValues(value, sensitive || helmSensitiveValueKey.MatchString(key), removed)
+		}
+	case string:
+		if sensitive && typed != "" && typed != MASK_TEXT {
+			*removed = append(*removed, len(typed)-len(MASK_TEXT))
+			return MASK_TEXT
+		}
+	}
+	return v
+}
@@ -0,0 +1,100 @@
+package redact
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact_HelmValues(t *testing.T) {
+	release := `{
+  "name": "api",
+  "namespace": "default",
+  "chart": "api",
+  "values": {
+    "replicaCount": 2,
+    "image": {"repository": "registry.example.com/api", "tag": "1.2.3"},
+    "postgres": {"host": "db", "password": "hunter2"},
+    "secrets": {"stripe": "sk_live_abc", "sentry": ["https://key@sentry.io/1"]},
+    "apiKey": "",
+    "tokenTTL": 3600
+  },
+  "history": [{"revision": 1, "description": "Install complete"}]
+}
+`
+
+	tests := []struct {
+		name           string
+		path           string
+		want           string
+		wantRedactions int
+	}{
+		{
+			name: "release file",
+			path: "helm/default/api.json",
+			want: `{
+  "name": "api",
+  "namespace": "default",
+  "chart": "api",
+  "values": {
+    "replicaCount": 2,
+    "image": {"repository": "registry.example.com/api", "tag": "1.2.3"},
+    "postgres": {"host": "db", "password": "***HIDDEN***"},
+    "secrets": {"stripe": "***HIDDEN***", "sentry": ["***HIDDEN***"]},
+    "apiKey": "",
+    "tokenTTL": 3600
+  },
+  "history": [{"revision": 1, "description": "Install complete"}]
+}`,
+			wantRedactions: 3,
+		},
+		{
+			name: "release history of a namespace",
+			path: "helm/default.json",
+			want: `{
+  "name": "api",
+  "namespace": "default",
+  "chart": "api",
+  "values": {
+    "replicaCount": 2,
+    "image": {"repository": "registry.example.com/api", "tag": "1.2.3"},
+    "postgres": {"host": "db", "password": "***HIDDEN***"},
+    "secrets": {"stripe": "***HIDDEN***", "sentry": ["***HIDDEN***"]},
+    "apiKey": "",
+    "tokenTTL": 3600
+  },
+  "history": [{"revision": 1, "description": "Install complete"}]
+}`,
+			wantRedactions: 3,
+		},
+		{
+			name: "other files are unchanged",
+			path: "cluster-resources/configmaps/default.json",
+			want: release,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			ResetRedactionList()
+			defer ResetRedactionList()
+
+			reader, err := Redact(strings.NewReader(release), tt.path, nil)
+			req.NoError(err)
+
+			got, err := io.ReadAll(reader)
+			req.NoError(err)
+			req.JSONEq(tt.want, string(got))
+
+			redactions := GetRedactionList().ByRedactor["Redact credentials in the values of Helm releases"]
+			req.Len(redactions, tt.wantRedactions)
+			for _, redaction := range redactions {
+				req.Equal(tt.path, redaction.File)
+				req.True(redaction.IsDefaultRedactor)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+)
+
+// JSONPathRedactor redacts the values matched by a JSONPath expression in JSON documents. The
+// matched values are replaced in place, so the formatting of the rest of the document is kept, and
+// documents without matches or that are not JSON are passed through unchanged.
+type JSONPathRedactor struct {
+	segments   []jsonPathSegment
+	regex      *regexp.Regexp
+	filePath   string
+	redactName string
+	isDefault  bool
+}
+
+// jsonPathSegment is a step of a JSONPath expression. It matches the object keys matching the
+// name glob, or equal to it when it is quoted, the array element at index, or any key or element
+// when wildcard is set. A recursive segment, written with .., also matches at any depth below the
+// previous segment.
+type jsonPathSegment struct {
+	recursive bool
+	wildcard  bool
+	literal   bool
+	name      string
+	index     int
+}
+
+// jsonPathElement is an object key or an array index on the path to a value in a document
+type jsonPathElement struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// jsonPathMatch is a matched value, start and end are its offsets in the document
+type jsonPathMatch struct {
+	start       int64
+	end         int64
+	replacement []byte
+}
+
+func NewJSONPathRedactor(removal troubleshootv1beta2.JSONPathRemoval, path, name string) (*JSONPathRedactor, error) {
+	segments, err := parseJSONPath(removal.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &JSONPathRedactor{
+		segments:   segments,
+		filePath:   path,
+		redactName: name,
+	}
+	if removal.Regex != "" {
+		r.regex, err = compileRegex(removal.Regex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid regex %q", removal.Regex)
+		}
+	}
+	return r, nil
+}
+
+func (r *JSONPathRedactor) Redact(input io.Reader, path string) io.Reader {
+	reader, writer := io.Pipe()
+	go func() {
+		var err error
+		defer func() {
+			writer.CloseWithError(err)
+		}()
+
+		var doc []byte
+		doc, err = io.ReadAll(input)
+		if err != nil {
+			return
+		}
+
+		matches := r.findMatches(doc)
+		if len(matches) == 0 {
+			_, err = writer.Write(doc)
+			return
+		}
+
+		redacted := make([]byte, 0, len(doc))
+		previousEnd := int64(0)
+		for _, match := range matches {
+			redacted = append(redacted, doc[previousEnd:match.start]...)
+			redacted = append(redacted, match.replacement...)
+			previousEnd = match.end
+
+			addRedaction(Redaction{
+				RedactorName:      r.redactName,
+				CharactersRemoved: int(match.end-match.start) - len(match.replacement),
+				Line:              bytes.Count(doc[:match.start], []byte("\n")) + 1,
+				File:              path,
+				IsDefaultRedactor: r.isDefault,
+			})
+		}
+		redacted = append(redacted, doc[previousEnd:]...)
+
+		_, err = writer.Write(redacted)
+	}()
+	return reader
+}
+
+// findMatches returns the values of the document to replace, in the order they appear. Nothing is
+// returned when the document is not JSON. A stream of JSON values, such as newline delimited
+// JSON, is matched value by value.
+func (r *JSONPathRedactor) findMatches(doc []byte) []jsonPathMatch {
+	trimmed := bytes.TrimLeft(doc, " \t\r\n")
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return nil
+	}
+
+	w := &jsonPathWalker{
+		redactor: r,
+		doc:      doc,
+		decoder:  json.NewDecoder(bytes.NewReader(doc)),
+	}
+	for w.decoder.More() {
+		if err := w.walkValue(nil, false); err != nil {
+			return nil
+		}
+	}
+	if _, err := w.decoder.Token(); err != io.EOF {
+		return nil
+	}
+	return w.matches
+}
+
+// jsonPathWalker walks the tokens of a document, keeping track of the path to each value
+type jsonPathWalker struct {
+	redactor *JSONPathRedactor
+	doc      []byte
+	decoder  *json.Decoder
+	// offset is the end of the previous token
+	offset  int64
+	matches []jsonPathMatch
+}
+
+// token returns the next token and its offsets in the document
+func (w *jsonPathWalker) token() (json.Token, int64, int64, error) {
+	tok, err := w.decoder.Token()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	// the separators between values are consumed with the tokens that follow them
+	start := w.offset
+	for start < int64(len(w.doc)) && strings.IndexByte(" \t\r\n,:", w.doc[start]) >= 0 {
+		start++
+	}
+	end := w.decoder.InputOffset()
+	w.offset = end
+	return tok, start, end, nil
+}
+
+// walkValue walks the value at elements. Values inside a container that is masked as a whole are
+// skipped.
+func (w *jsonPathWalker) walkValue(elements []jsonPathElement, skip bool) error {
+	tok, start, end, err := w.token()
+	if err != nil {
+		return err
+	}
+
+	matched := !skip && matchJSONPath(w.redactor.segments, elements)
+
+	delim, isDelim := tok.(json.Delim)
+	if !isDelim {
+		if matched {
+			w.matchScalar(tok, start, end)
+		}
+		return nil
+	}
+
+	// containers are only masked as a whole when there is no regex
+	maskContainer := matched && w.redactor.regex == nil
+	skipChildren := skip || maskContainer
+
+	switch delim {
+	case '{':
+		for w.decoder.More() {
+			keyToken, _, _, err := w.token()
+			if err != nil {
+				return err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return errors.New("object key is not a string")
+			}
+			if err := w.walkValue(append(elements, jsonPathElement{key: key}), skipChildren); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for index := 0; w.decoder.More(); index++ {
+			if err := w.walkValue(append(elements, jsonPathElement{index: index, isIndex: true}), skipChildren); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Errorf("unexpected %q", delim)
+	}
+
+	// the closing delimiter
+	_, _, end, err = w.token()
+	if err != nil {
+		return err
+	}
+	if maskContainer {
+		w.matches = append(w.matches, jsonPathMatch{start: start, end: end, replacement: jsonPathMaskedValue()})
+	}
+	return nil
+}
+
+// matchScalar masks a matched string, number or boolean. With a regex only the matching parts of
+// strings are masked.
+func (w *jsonPathWalker) matchScalar(tok json.Token, start, end int64) {
+	if tok == nil {
+		return
+	}
+
+	if w.redactor.regex == nil {
+		w.matches = append(w.matches, jsonPathMatch{start: start, end: end, replacement: jsonPathMaskedValue()})
+		return
+	}
+
+	value, ok := tok.(string)
+	if !ok || !w.redactor.regex.MatchString(value) {
+		return
+	}
+	replacement, err := marshalJSONString(w.redactor.regex.ReplaceAllLiteralString(value, MASK_TEXT))
+	if err != nil {
+		return
+	}
+	w.matches = append(w.matches, jsonPathMatch{start: start, end: end, replacement: replacement})
+}
+
+func jsonPathMaskedValue() []byte {
+	return []byte(strconv.Quote(MASK_TEXT))
+}
+
+// marshalJSONString encodes s without escaping HTML characters, as they were in the document
+func marshalJSONString(s string) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func matchJSONPath(segments []jsonPathSegment, elements []jsonPathElement) bool {
+	if len(segments) == 0 {
+		return len(elements) == 0
+	}
+	if len(elements) == 0 {
+		return false
+	}
+
+	segment := segments[0]
+	if segment.matches(elements[0]) && matchJSONPath(segments[1:], elements[1:]) {
+		return true
+	}
+	return segment.recursive && matchJSONPath(segments, elements[1:])
+}
+
+func (s jsonPathSegment) matches(element jsonPathElement) bool {
+	if s.wildcard {
+		return true
+	}
+	if element.isIndex {
+		return s.index == element.index
+	}
+	if s.index >= 0 {
+		return false
+	}
+	if s.literal {
+		return s.name == element.key
+	}
+	matched, _ := path.Match(s.name, element.key)
+	return matched
+}
+
+// parseJSONPath parses an expression made of $, .key, ..key, .*, [n], [*] and ['key'] steps
+func parseJSONPath(expression string) ([]jsonPathSegment, error) {
+	if !strings.HasPrefix(expression, "$") {
+		return nil, errors.Errorf("JSONPath %q does not start with $", expression)
+	}
+
+	segments := []jsonPathSegment{}
+	rest := expression[1:]
+	for rest != "" {
+		segment := jsonPathSegment{index: -1}
+
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			segment.recursive = true
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+		case strings.HasPrefix(rest, "["):
+		default:
+			return nil, errors.Errorf("unexpected %q in JSONPath %q", rest, expression)
+		}
+
+		if strings.HasPrefix(rest, "[") {
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, errors.Errorf("unterminated [ in JSONPath %q", expression)
+			}
+			selector := rest[1:end]
+			rest = rest[end+1:]
+
+			switch {
+			case selector == "*":
+				segment.wildcard = true
+			case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0]:
+				segment.name = selector[1 : len(selector)-1]
+				segment.literal = true
+			default:
+				index, err := strconv.Atoi(selector)
+				if err != nil || index < 0 {
+					return nil, errors.Errorf("invalid selector [%s] in JSONPath %q", selector, expression)
+				}
+				segment.index = index
+			}
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			segment.name = rest[:end]
+			rest = rest[end:]
+
+			if segment.name == "" {
+				return nil, errors.Errorf("empty key in JSONPath %q", expression)
+			}
+			if segment.name == "*" {
+				segment.wildcard = true
+			}
+		}
+
+		if !segment.literal && !segment.wildcard && segment.index < 0 {
+			if _, err := path.Match(segment.name, ""); err != nil {
+				return nil, errors.Wrapf(err, "invalid key %q in JSONPath %q", segment.name, expression)
+			}
+		}
+		segments = append(segments, segment)
+	}
+
+	if len(segments) == 0 {
+		return nil, errors.Errorf("JSONPath %q matches the whole document", expression)
+	}
+	return segments, nil
+}
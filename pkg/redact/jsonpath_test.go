@@ -0,0 +1,167 @@
+package redact
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPathRedactor(t *testing.T) {
+	secrets := `{
+  "kind": "SecretList",
+  "items": [
+    {"metadata": {"name": "db"}, "data": {"password": "aHVudGVyMg==", "username": "YWRtaW4="}},
+    {"metadata": {"name": "api"}, "data": {"password": "c2VjcmV0", "apiPassword": "dG9rZW4="}}
+  ]
+}
+`
+
+	tests := []struct {
+		name           string
+		removal        troubleshootv1beta2.JSONPathRemoval
+		input          string
+		want           string
+		wantRedactions int
+		wantLines      []int
+	}{
+		{
+			name:    "wildcard array index",
+			removal: troubleshootv1beta2.JSONPathRemoval{Path: "$.items[*].data.password"},
+			input:   secrets,
+			want: `{
+  "kind": "SecretList",
+  "items": [
+    {"metadata": {"name": "db"}, "data": {"password": "***HIDDEN***", "username": "YWRtaW4="}},
+    {"metadata": {"name": "api"}, "data": {"password": "***HIDDEN***", "apiPassword": "dG9rZW4="}}
+  ]
+}
+`,
+			wantRedactions: 2,
+			wantLines:      []int{4, 5},
+		},
+		{
+			name:    "glob key",
+			removal: troubleshootv1beta2.JSONPathRemoval{Path: "$.items[1].data.*assword"},
+			input:   secrets,
+			want: `{
+  "kind": "SecretList",
+  "items": [
+    {"metadata": {"name": "db"}, "data": {"password": "aHVudGVyMg==", "username": "YWRtaW4="}},
+    {"metadata": {"name": "api"}, "data": {"password": "***HIDDEN***", "apiPassword": "***HIDDEN***"}}
+  ]
+}
+`,
+			wantRedactions: 2,
+			wantLines:      []int{5, 5},
+		},
+		{
+			name:           "recursive descent masks objects and numbers",
+			removal:        troubleshootv1beta2.JSONPathRemoval{Path: "$..['credentials']"},
+			input:          `{"a": {"credentials": {"key": "k", "nested": [1, 2]}}, "b": [{"credentials": 1234}], "credentials2": true}`,
+			want:           `{"a": {"credentials": "***HIDDEN***"}, "b": [{"credentials": "***HIDDEN***"}], "credentials2": true}`,
+			wantRedactions: 2,
+			wantLines:      []int{1, 1},
+		},
+		{
+			name: "regex masks part of the value",
+			removal: troubleshootv1beta2.JSONPathRemoval{
+				Path:  "$.spec.containers[*].env[*].value",
+				Regex: `://[^@]+@`,
+			},
+			input: `{"spec": {"containers": [{"env": [
+  {"name": "DB", "value": "postgres://admin:hunter2@db:5432/app?x=<y>"},
+  {"name": "LEVEL", "value": "debug"}
+]}]}}`,
+			want: `{"spec": {"containers": [{"env": [
+  {"name": "DB", "value": "postgres***HIDDEN***db:5432/app?x=<y>"},
+  {"name": "LEVEL", "value": "debug"}
+]}]}}`,
+			wantRedactions: 1,
+			wantLines:      []int{2},
+		},
+		{
+			name:           "newline delimited JSON",
+			removal:        troubleshootv1beta2.JSONPathRemoval{Path: "$.attributes.token"},
+			input:          "{\"name\": \"GET /\", \"attributes\": {\"token\": \"abc\"}}\n{\"name\": \"POST /\"}\n{\"attributes\": {\"token\": null}}\n",
+			want:           "{\"name\": \"GET /\", \"attributes\": {\"token\": \"***HIDDEN***\"}}\n{\"name\": \"POST /\"}\n{\"attributes\": {\"token\": null}}\n",
+			wantRedactions: 1,
+			wantLines:      []int{1},
+		},
+		{
+			name:    "no match keeps the document",
+			removal: troubleshootv1beta2.JSONPathRemoval{Path: "$.items[*].stringData.password"},
+			input:   secrets,
+			want:    secrets,
+		},
+		{
+			name:    "not JSON",
+			removal: troubleshootv1beta2.JSONPathRemoval{Path: "$.password"},
+			input:   "password: hunter2\n",
+			want:    "password: hunter2\n",
+		},
+		{
+			name:    "invalid JSON",
+			removal: troubleshootv1beta2.JSONPathRemoval{Path: "$.password"},
+			input:   `{"password": "hunter2", `,
+			want:    `{"password": "hunter2", `,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			ResetRedactionList()
+			defer ResetRedactionList()
+
+			redactor, err := NewJSONPathRedactor(tt.removal, "secrets.json", "jsonpath")
+			req.NoError(err)
+
+			got, err := io.ReadAll(redactor.Redact(strings.NewReader(tt.input), "secrets.json"))
+			req.NoError(err)
+			req.Equal(tt.want, string(got))
+
+			// redactions are recorded asynchronously, in no particular order
+			redactions := GetRedactionList().ByRedactor["jsonpath"]
+			req.Len(redactions, tt.wantRedactions)
+			sort.Slice(redactions, func(i, j int) bool {
+				return redactions[i].Line < redactions[j].Line
+			})
+			for i, line := range tt.wantLines {
+				req.Equal(line, redactions[i].Line)
+			}
+		})
+	}
+}
+
+func TestRedact_JSONPath(t *testing.T) {
+	req := require.New(t)
+	ResetRedactionList()
+	defer ResetRedactionList()
+
+	redactors := []*troubleshootv1beta2.Redact{
+		{
+			Name:         "passwords",
+			FileSelector: troubleshootv1beta2.FileSelector{File: "cluster-resources/custom-resources/*"},
+			Removals: troubleshootv1beta2.Removals{
+				JSONPath: []troubleshootv1beta2.JSONPathRemoval{{Path: "$[*].spec.adminPassword"}},
+			},
+		},
+	}
+
+	input := `[{"kind": "Database", "spec": {"adminPassword": "hunter2", "replicas": 3}}]`
+	reader, err := Redact(strings.NewReader(input), "cluster-resources/custom-resources/databases.json", redactors)
+	req.NoError(err)
+	got, err := io.ReadAll(reader)
+	req.NoError(err)
+	req.JSONEq(`[{"kind": "Database", "spec": {"adminPassword": "***HIDDEN***", "replicas": 3}}]`, string(got))
+	req.Len(GetRedactionList().ByRedactor["passwords.jsonPath.0"], 1)
+
+	_, err = Redact(strings.NewReader(input), "databases.json", []*troubleshootv1beta2.Redact{
+		{Removals: troubleshootv1beta2.Removals{JSONPath: []troubleshootv1beta2.JSONPathRemoval{{Path: "items[*]"}}}},
+	})
+	req.EqualError(err, `build custom redactors: jsonPath redactor "items[*]": JSONPath "items[*]" does not start with $`)
+}
@@ -15,13 +15,15 @@ type literalRedactor struct {
 	filePath   string
 	redactName string
 	isDefault  bool
+	tokenize   bool
 }
 
-func literalString(match []byte, path, name string) Redactor {
+func literalString(match []byte, path, name string, tokenize bool) Redactor {
 	return literalRedactor{
 		match:      match,
 		filePath:   path,
 		redactName: name,
+		tokenize:   tokenize,
 	}
 }
 
@@ -48,12 +50,26 @@ func (r literalRedactor) Redact(input io.Reader, path string) io.Reader {
 		scanner := bufio.NewScanner(input)
 		scanner.Buffer(buf, constants.SCANNER_MAX_SIZE)
 
+		replacement := maskTextBytes
+		if r.tokenize {
+			// the token is issued once the value is found, so that the token mapping only has
+			// values that were in the bundle
+			replacement = nil
+		}
+
 		lineNum := 0
 		for scanner.Scan() {
 			lineNum++
 			line := scanner.Bytes()
 
-			clean := bytes.ReplaceAll(line, r.match, maskTextBytes)
+			if replacement == nil && bytes.Contains(line, r.match) {
+				replacement = []byte(GetGlobalTokenizer().Token(string(r.match)))
+			}
+
+			clean := line
+			if replacement != nil {
+				clean = bytes.ReplaceAll(line, r.match, replacement)
+			}
 
 			// Append newline since scanner strips it
 			err = writeBytes(writer, clean, NEW_LINE)
@@ -15,6 +15,8 @@ type MultiLineRedactor struct {
 	filePath   string
 	redactName string
 	isDefault  bool
+	// tokenize replaces the masked values with tokens instead of the mask text
+	tokenize bool
 }
 
 func NewMultiLineRedactor(re1 LineRedactor, re2 string, maskText, path, name string, isDefault bool) (*MultiLineRedactor, error) {
@@ -94,7 +96,12 @@ func (r *MultiLineRedactor) Redact(input io.Reader, path string) io.Reader {
 				continue
 			}
 			flushLastLine = false
-			clean := r.re2.ReplaceAll(line2, substStr)
+			var clean []byte
+			if r.tokenize {
+				clean = replaceAllTokenized(r.re2, line2, GetGlobalTokenizer())
+			} else {
+				clean = r.re2.ReplaceAll(line2, substStr)
+			}
 
 			// Append newlines since scanner strips them
 			err = writeBytes(writer, line1, NEW_LINE, clean, NEW_LINE)
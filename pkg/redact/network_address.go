@@ -0,0 +1,226 @@
+package redact
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"regexp"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"k8s.io/klog/v2"
+)
+
+var (
+	// candidates are checked with netip.ParseAddr, the regexes only find the runs of characters
+	// that could be an address. IPv6 candidates may end with an embedded IPv4 address, e.g.
+	// ::ffff:10.0.0.1.
+	ipv6CandidateRegex = regexp.MustCompile(`(?:::|[0-9A-Fa-f]{1,4}:)[0-9A-Fa-f:]*(?:\d{1,3}(?:\.\d{1,3}){3})?`)
+	ipv4CandidateRegex = regexp.MustCompile(`\d{1,3}(?:\.\d{1,3}){3}`)
+	macAddressRegex    = regexp.MustCompile(`[0-9A-Fa-f]{2}(?:[:-][0-9A-Fa-f]{2}){5}`)
+)
+
+func init() {
+	// prefer the embedded IPv4 address to a shorter run of hex digits
+	ipv6CandidateRegex.Longest()
+}
+
+// NetworkAddressRedactor replaces IPv4, IPv6 and MAC addresses with tokens of the global
+// tokenizer, so that the same address can be followed across the files of a bundle
+type NetworkAddressRedactor struct {
+	allowed    []netip.Prefix
+	filePath   string
+	redactName string
+}
+
+func NewNetworkAddressRedactor(removal troubleshootv1beta2.NetworkAddressRemoval, path, name string) (*NetworkAddressRedactor, error) {
+	r := &NetworkAddressRedactor{
+		filePath:   path,
+		redactName: name,
+	}
+
+	for _, cidr := range removal.AllowCIDRs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid allowCIDRs entry %q", cidr)
+		}
+		r.allowed = append(r.allowed, prefix.Masked())
+	}
+
+	return r, nil
+}
+
+func (r *NetworkAddressRedactor) Redact(input io.Reader, path string) io.Reader {
+	out, writer := io.Pipe()
+
+	go func() {
+		var err error
+		defer func() {
+			if err == nil || err == io.EOF {
+				writer.Close()
+			} else {
+				if err == bufio.ErrTooLong {
+					s := fmt.Sprintf("Error redacting %q. A line in the file exceeded %d MB max length", path, constants.SCANNER_MAX_SIZE/1024/1024)
+					klog.V(2).Info(s)
+				} else {
+					klog.V(2).Info(fmt.Sprintf("Error redacting %q: %v", path, err))
+				}
+				writer.CloseWithError(err)
+			}
+		}()
+
+		buf := make([]byte, constants.BUF_INIT_SIZE)
+		scanner := bufio.NewScanner(input)
+		scanner.Buffer(buf, constants.SCANNER_MAX_SIZE)
+
+		lineNum := 0
+		for scanner.Scan() {
+			lineNum++
+			line := scanner.Bytes()
+
+			clean := r.redactLine(line)
+
+			// Append newline since scanner strips it
+			err = writeBytes(writer, clean, NEW_LINE)
+			if err != nil {
+				return
+			}
+
+			if !bytes.Equal(clean, line) {
+				addRedaction(Redaction{
+					RedactorName:      r.redactName,
+					CharactersRemoved: len(line) - len(clean),
+					Line:              lineNum,
+					File:              r.filePath,
+				})
+			}
+		}
+		if scanErr := scanner.Err(); scanErr != nil {
+			err = scanErr
+		}
+	}()
+	return out
+}
+
+// redactLine tokenizes the IPv6 addresses first, as they may embed an IPv4 address, then the IPv4
+// and MAC addresses
+func (r *NetworkAddressRedactor) redactLine(line []byte) []byte {
+	tokenizer := GetGlobalTokenizer()
+
+	line = replaceStandalone(ipv6CandidateRegex, line, func(candidate []byte) ([]byte, bool) {
+		// a candidate may include the colon of a following ": message", which is kept
+		address := bytes.TrimRight(candidate, ":")
+		addr, err := netip.ParseAddr(string(candidate))
+		if err == nil {
+			address = candidate
+		} else if addr, err = netip.ParseAddr(string(address)); err != nil {
+			return nil, false
+		}
+		if !addr.Is6() {
+			return nil, false
+		}
+		if r.isKept(addr) {
+			return candidate, true
+		}
+		replacement := []byte(tokenizer.Token(string(address)))
+		return append(replacement, candidate[len(address):]...), true
+	})
+
+	line = replaceStandalone(ipv4CandidateRegex, line, func(candidate []byte) ([]byte, bool) {
+		addr, err := netip.ParseAddr(string(candidate))
+		if err != nil {
+			return nil, false
+		}
+		if r.isKept(addr) {
+			return candidate, true
+		}
+		return []byte(tokenizer.Token(string(candidate))), true
+	})
+
+	line = replaceStandalone(macAddressRegex, line, func(candidate []byte) ([]byte, bool) {
+		mac, err := net.ParseMAC(string(candidate))
+		if err != nil {
+			return nil, false
+		}
+		if isUnassignedMAC(mac) {
+			return candidate, true
+		}
+		// the same address is written with colons or dashes, in lower or upper case
+		return []byte(tokenizer.Token(mac.String())), true
+	})
+
+	return line
+}
+
+// isKept returns true for the addresses that identify nothing, the loopback and unspecified
+// addresses, and for the allowed ones
+func (r *NetworkAddressRedactor) isKept(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	if addr.IsLoopback() || addr.IsUnspecified() {
+		return true
+	}
+	for _, prefix := range r.allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnassignedMAC returns true for the all zeros and broadcast addresses
+func isUnassignedMAC(mac net.HardwareAddr) bool {
+	return bytes.Equal(mac, make([]byte, len(mac))) || bytes.Equal(mac, bytes.Repeat([]byte{0xff}, len(mac)))
+}
+
+// replaceStandalone replaces the matches of re that are not part of a longer word, number or
+// version string with the replacement returned by replace. replace returns false when the match is
+// not an address, re is then matched again from its next character, as in id:fe80::1 where the
+// first match, d:fe80::1, is part of a word.
+func replaceStandalone(re *regexp.Regexp, line []byte, replace func(match []byte) ([]byte, bool)) []byte {
+	var clean []byte
+	last := 0
+	for pos := 0; pos < len(line); {
+		match := re.FindIndex(line[pos:])
+		if match == nil {
+			break
+		}
+		start, end := pos+match[0], pos+match[1]
+
+		if isAddressBoundary(line, start-1, -1) && isAddressBoundary(line, end, 1) {
+			if replacement, ok := replace(line[start:end]); ok {
+				clean = append(clean, line[last:start]...)
+				clean = append(clean, replacement...)
+				last = end
+				pos = end
+				continue
+			}
+		}
+		pos = start + 1
+	}
+
+	if clean == nil {
+		return line
+	}
+	return append(clean, line[last:]...)
+}
+
+// isAddressBoundary returns true when the character at i can precede or follow an address. Letters,
+// digits and underscores can not, nor can a dot that continues a number such as in 1.2.3.4.5.
+func isAddressBoundary(line []byte, i int, direction int) bool {
+	if i < 0 || i >= len(line) {
+		return true
+	}
+	c := line[i]
+	switch {
+	case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		return false
+	case c == '.':
+		next := i + direction
+		return next < 0 || next >= len(line) || line[next] < '0' || line[next] > '9'
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package redact
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkAddressRedactor(t *testing.T) {
+	tests := []struct {
+		name    string
+		removal troubleshootv1beta2.NetworkAddressRemoval
+		input   string
+		// want has {address} where the token of address is expected
+		want            string
+		wantRedactions  int
+		wantErrContains string
+	}{
+		{
+			name:           "rfc1918 and public addresses are redacted",
+			input:          "dial tcp 10.0.3.4:5432: connection refused\nnode 192.168.1.20 joined, gateway 172.16.0.1\nupstream 203.0.113.7\n",
+			want:           "dial tcp {10.0.3.4}:5432: connection refused\nnode {192.168.1.20} joined, gateway {172.16.0.1}\nupstream {203.0.113.7}\n",
+			wantRedactions: 3,
+		},
+		{
+			name:           "loopback and unspecified addresses are kept",
+			input:          "listening on 127.0.0.1:8080 and 0.0.0.0:9090\nlistening on [::1]:8080 and [::]:9090\n",
+			want:           "listening on 127.0.0.1:8080 and 0.0.0.0:9090\nlistening on [::1]:8080 and [::]:9090\n",
+			wantRedactions: 0,
+		},
+		{
+			name:           "the same address gets the same token",
+			input:          `{"hostIP":"10.0.3.4","podIP":"10.244.1.7"}` + "\n" + `{"hostIP":"10.0.3.4"}` + "\n",
+			want:           `{"hostIP":"{10.0.3.4}","podIP":"{10.244.1.7}"}` + "\n" + `{"hostIP":"{10.0.3.4}"}` + "\n",
+			wantRedactions: 2,
+		},
+		{
+			name:           "ipv6 addresses",
+			input:          "peer [fd00:10:244::7]:443 via fe80::a8c1:abff:fe2e:1%eth0\nid:2001:db8::1: unreachable\nmapped ::ffff:10.0.3.4\n",
+			want:           "peer [{fd00:10:244::7}]:443 via {fe80::a8c1:abff:fe2e:1}%eth0\nid:{2001:db8::1}: unreachable\nmapped {::ffff:10.0.3.4}\n",
+			wantRedactions: 3,
+		},
+		{
+			name:           "mac addresses",
+			input:          "link/ether 02:42:AC:11:00:02 brd ff:ff:ff:ff:ff:ff\nhwaddr 02-42-ac-11-00-02 null 00:00:00:00:00:00\n",
+			want:           "link/ether {02:42:ac:11:00:02} brd ff:ff:ff:ff:ff:ff\nhwaddr {02:42:ac:11:00:02} null 00:00:00:00:00:00\n",
+			wantRedactions: 2,
+		},
+		{
+			name:           "versions, times and words are kept",
+			input:          "image app:v1.2.3.4 built 1.2.3.4.5 at 12:30:45\nstd::vector ActiveRecord::Base uid 3f1c9a2e-7b4d-4e8a\n",
+			want:           "image app:v1.2.3.4 built 1.2.3.4.5 at 12:30:45\nstd::vector ActiveRecord::Base uid 3f1c9a2e-7b4d-4e8a\n",
+			wantRedactions: 0,
+		},
+		{
+			name: "allowed cidrs are kept",
+			removal: troubleshootv1beta2.NetworkAddressRemoval{
+				AllowCIDRs: []string{"10.96.0.0/12", "10.244.0.0/16", "fd00:10:96::/112"},
+			},
+			input:          "service 10.96.0.1 pod 10.244.1.7 node 10.0.3.4\nservice fd00:10:96::a node fd00:10:1::4\n",
+			want:           "service 10.96.0.1 pod 10.244.1.7 node {10.0.3.4}\nservice fd00:10:96::a node {fd00:10:1::4}\n",
+			wantRedactions: 2,
+		},
+		{
+			name: "invalid allowed cidr",
+			removal: troubleshootv1beta2.NetworkAddressRemoval{
+				AllowCIDRs: []string{"10.96.0.0/40"},
+			},
+			wantErrContains: `invalid allowCIDRs entry "10.96.0.0/40"`,
+		},
+	}
+
+	placeholder := regexp.MustCompile(`\{([^{}"]+)\}`)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			ResetRedactionList()
+			defer ResetRedactionList()
+
+			redactors := []*troubleshootv1beta2.Redact{
+				{
+					Name: "network",
+					Removals: troubleshootv1beta2.Removals{
+						NetworkAddresses: &tt.removal,
+					},
+				},
+			}
+
+			reader, err := Redact(strings.NewReader(tt.input), "cluster-resources/pods/default.json", redactors)
+			if tt.wantErrContains != "" {
+				req.ErrorContains(err, tt.wantErrContains)
+				return
+			}
+			req.NoError(err)
+
+			got, err := io.ReadAll(reader)
+			req.NoError(err)
+
+			want := placeholder.ReplaceAllStringFunc(tt.want, func(s string) string {
+				return GetGlobalTokenizer().Token(strings.Trim(s, "{}"))
+			})
+			req.Equal(want, string(got))
+			req.Len(GetRedactionList().ByRedactor["network.networkAddresses.0"], tt.wantRedactions)
+		})
+	}
+}
+
+func TestNetworkAddressRedactorIsOptIn(t *testing.T) {
+	req := require.New(t)
+	ResetRedactionList()
+	defer ResetRedactionList()
+
+	input := "node 10.0.3.4 link/ether 02:42:ac:11:00:02\nready\n"
+	reader, err := Redact(strings.NewReader(input), "logs/app.log", nil)
+	req.NoError(err)
+
+	got, err := io.ReadAll(reader)
+	req.NoError(err)
+	req.Equal(input, string(got))
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"path/filepath"
 	"regexp"
 	"sync"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
 	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"k8s.io/klog/v2"
 )
 
 const (
@@ -69,6 +71,9 @@ type Redaction struct {
 	Line              int    `json:"line" yaml:"line"`
 	File              string `json:"file" yaml:"file"`
 	IsDefaultRedactor bool   `json:"isDefaultRedactor" yaml:"isDefaultRedactor"`
+	// IsEntropyMatch is set for redactions made by the entropy redactor, which are heuristic and
+	// may be false positives
+	IsEntropyMatch bool `json:"isEntropyMatch,omitempty" yaml:"isEntropyMatch,omitempty"`
 }
 
 type LineRedactor struct {
@@ -76,17 +81,29 @@ type LineRedactor struct {
 	scan  string
 }
 
+// Redact returns a reader of input with the default and additional redactors applied. Files that
+// match a skipRedaction glob, and binary files such as core dumps or packet captures that
+// redactors would corrupt, are returned unmodified.
 func Redact(input io.Reader, path string, additionalRedactors []*troubleshootv1beta2.Redact) (io.Reader, error) {
-	redactors, err := getRedactors(path)
+	skip, err := skipRedaction(path, additionalRedactors)
 	if err != nil {
 		return nil, err
 	}
+	if skip {
+		klog.V(2).Infof("Skipping redaction of %s, it matches a skipRedaction glob", path)
+		return input, nil
+	}
 
-	builtRedactors, err := buildAdditionalRedactors(path, additionalRedactors)
+	input, binary := sniffBinary(input)
+	if binary {
+		klog.Infof("Skipping redaction of %s, it looks like a binary file", path)
+		return input, nil
+	}
+
+	redactors, err := buildRedactors(path, additionalRedactors)
 	if err != nil {
-		return nil, errors.Wrap(err, "build custom redactors")
+		return nil, err
 	}
-	redactors = append(redactors, builtRedactors...)
 
 	nextReader := input
 	for _, r := range redactors {
@@ -96,6 +113,43 @@ func Redact(input io.Reader, path string, additionalRedactors []*troubleshootv1b
 	return nextReader, nil
 }
 
+// buildRedactors returns the default redactors followed by the additional redactors that apply to path
+func buildRedactors(path string, additionalRedactors []*troubleshootv1beta2.Redact) ([]Redactor, error) {
+	redactors, err := getRedactors(path)
+	if err != nil {
+		return nil, err
+	}
+
+	builtRedactors, err := buildAdditionalRedactors(path, additionalRedactors)
+	if err != nil {
+		return nil, errors.Wrap(err, "build custom redactors")
+	}
+
+	// a secrets removal replaces the default redaction of Secret data, so the values it keeps
+	// are not masked
+	if hasSecretRedactor(builtRedactors) {
+		defaultRedactors := make([]Redactor, 0, len(redactors))
+		for _, r := range redactors {
+			if secretRedactor, ok := r.(*SecretRedactor); ok && secretRedactor.secretsFile {
+				continue
+			}
+			defaultRedactors = append(defaultRedactors, r)
+		}
+		redactors = defaultRedactors
+	}
+
+	return append(redactors, builtRedactors...), nil
+}
+
+func hasSecretRedactor(redactors []Redactor) bool {
+	for _, r := range redactors {
+		if _, ok := r.(*SecretRedactor); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func GetRedactionList() RedactionList {
 	pendingRedactions.Wait()
 	redactionListMut.Lock()
@@ -118,6 +172,8 @@ func ResetRedactionList() {
 	defer regexCacheLock.Unlock()
 
 	regexCache = map[string]*regexp.Regexp{}
+
+	ResetGlobalTokenizer()
 }
 
 func buildAdditionalRedactors(path string, redacts []*troubleshootv1beta2.Redact) ([]Redactor, error) {
@@ -137,26 +193,32 @@ func buildAdditionalRedactors(path string, redacts []*troubleshootv1beta2.Redact
 			continue
 		}
 
+		tokenize := redact.Tokenize || tokenizationEnabled()
+
 		for j, literal := range redact.Removals.Values {
-			additionalRedactors = append(additionalRedactors, literalString([]byte(literal), path, redactorName(i, j, redact.Name, "literal")))
+			additionalRedactors = append(additionalRedactors, literalString([]byte(literal), path, redactorName(i, j, redact.Name, "literal"), tokenize))
 		}
 
 		for j, re := range redact.Removals.Regex {
 			var newRedactor Redactor
 			if re.Selector != "" {
-				newRedactor, err = NewMultiLineRedactor(LineRedactor{
+				r, err := NewMultiLineRedactor(LineRedactor{
 					regex: re.Selector,
 				}, re.Redactor, MASK_TEXT, path, redactorName(i, j, redact.Name, "multiLine"), false)
 				if err != nil {
 					return nil, errors.Wrapf(err, "multiline redactor %+v", re)
 				}
+				r.tokenize = tokenize
+				newRedactor = r
 			} else {
-				newRedactor, err = NewSingleLineRedactor(LineRedactor{
+				r, err := NewSingleLineRedactor(LineRedactor{
 					regex: re.Redactor,
 				}, MASK_TEXT, path, redactorName(i, j, redact.Name, "regex"), false)
 				if err != nil {
 					return nil, errors.Wrapf(err, "redactor %q", re)
 				}
+				r.tokenize = tokenize
+				newRedactor = r
 			}
 			additionalRedactors = append(additionalRedactors, newRedactor)
 		}
@@ -165,6 +227,36 @@ func buildAdditionalRedactors(path string, redacts []*troubleshootv1beta2.Redact
 			r := NewYamlRedactor(yaml, path, redactorName(i, j, redact.Name, "yaml"))
 			additionalRedactors = append(additionalRedactors, r)
 		}
+
+		for j, removal := range redact.Removals.JSONPath {
+			r, err := NewJSONPathRedactor(removal, path, redactorName(i, j, redact.Name, "jsonPath"))
+			if err != nil {
+				return nil, errors.Wrapf(err, "jsonPath redactor %q", removal.Path)
+			}
+			additionalRedactors = append(additionalRedactors, r)
+		}
+
+		if redact.Removals.Entropy != nil {
+			r, err := NewEntropyRedactor(*redact.Removals.Entropy, path, redactorName(i, 0, redact.Name, "entropy"))
+			if err != nil {
+				return nil, errors.Wrap(err, "entropy redactor")
+			}
+			r.tokenize = tokenize
+			additionalRedactors = append(additionalRedactors, r)
+		}
+
+		if redact.Removals.NetworkAddresses != nil {
+			r, err := NewNetworkAddressRedactor(*redact.Removals.NetworkAddresses, path, redactorName(i, 0, redact.Name, "networkAddresses"))
+			if err != nil {
+				return nil, errors.Wrap(err, "network address redactor")
+			}
+			additionalRedactors = append(additionalRedactors, r)
+		}
+
+		if redact.Removals.Secrets != nil {
+			r := NewSecretRedactor(*redact.Removals.Secrets, path, redactorName(i, 0, redact.Name, "secrets"))
+			additionalRedactors = append(additionalRedactors, r)
+		}
 	}
 	return additionalRedactors, nil
 }
@@ -348,12 +440,15 @@ func getRedactors(path string) ([]Redactor, error) {
 		},
 	}
 
+	tokenize := tokenizationEnabled()
+
 	redactors := make([]Redactor, 0)
 	for _, re := range singleLines {
 		r, err := NewSingleLineRedactor(re.regex, MASK_TEXT, path, re.name, true)
 		if err != nil {
 			return nil, err // maybe skip broken ones?
 		}
+		r.tokenize = tokenize
 		redactors = append(redactors, r)
 	}
 
@@ -433,6 +528,7 @@ func getRedactors(path string) ([]Redactor, error) {
 		if err != nil {
 			return nil, err // maybe skip broken ones?
 		}
+		r.tokenize = tokenize
 		redactors = append(redactors, r)
 	}
 
@@ -470,6 +566,22 @@ func getRedactors(path string) ([]Redactor, error) {
 		}
 	}
 
+	// values collected by the secret collector are masked whatever their keys, as they are not
+	// matched by the redactors above
+	for _, secretsGlob := range []string{constants.SECRETS_DIR + "/*.json", constants.SECRETS_DIR + "/*/*.json", constants.SECRETS_DIR + "/*/*/*.json"} {
+		if match, _ := filepath.Match(secretsGlob, path); match {
+			redactors = append(redactors, newSecretDataRedactor(path, "Redact values in the data of Kubernetes Secrets"))
+			break
+		}
+	}
+
+	for _, helmGlob := range []string{constants.HELM_RELEASES_DIR + "/*.json", constants.HELM_RELEASES_DIR + "/*/*.json"} {
+		if match, _ := filepath.Match(helmGlob, path); match {
+			redactors = append(redactors, newHelmValuesRedactor(path, "Redact credentials in the values of Helm releases"))
+			break
+		}
+	}
+
 	return redactors, nil
 }
 
@@ -510,6 +622,10 @@ func readLine(r *bufio.Reader) ([]byte, error) {
 }
 
 func addRedaction(redaction Redaction) {
+	if recordDryRunRedaction(redaction) {
+		return
+	}
+
 	pendingRedactions.Add(1)
 	go func(redaction Redaction) {
 		redactionListMut.Lock()
@@ -0,0 +1,264 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"k8s.io/klog/v2"
+)
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// secretPublicKeys are the data keys of each secret type whose values are not sensitive, as
+// path.Match patterns
+var secretPublicKeys = map[string][]string{
+	"kubernetes.io/tls":                   {"tls.crt", "ca.crt"},
+	"kubernetes.io/service-account-token": {"ca.crt", "namespace"},
+	"kubernetes.io/basic-auth":            {"username"},
+	"bootstrap.kubernetes.io/token":       {"token-id", "description", "expiration", "auth-extra-groups", "usage-bootstrap-*"},
+}
+
+// secretDockerConfigKeys are the data keys holding registry credentials, whose registries and
+// usernames are kept while the credentials are masked
+var secretDockerConfigKeys = map[string]string{
+	"kubernetes.io/dockerconfigjson": ".dockerconfigjson",
+	"kubernetes.io/dockercfg":        ".dockercfg",
+}
+
+// SecretRedactor redacts the data of Secret objects in JSON files according to their type. Files
+// that are not JSON or hold no Secrets are passed through unchanged.
+type SecretRedactor struct {
+	preserveKeys []string
+	filePath     string
+	redactName   string
+	// secretsFile is set for files written by the secret collector. Every value is masked,
+	// including those known to be public.
+	secretsFile bool
+	isDefault   bool
+}
+
+func NewSecretRedactor(removal troubleshootv1beta2.SecretRemoval, path, name string) *SecretRedactor {
+	return &SecretRedactor{
+		preserveKeys: removal.PreserveKeys,
+		filePath:     path,
+		redactName:   name,
+	}
+}
+
+// newSecretDataRedactor returns the default redactor of files written by the secret collector, it
+// masks the collected values and keeps the keys
+func newSecretDataRedactor(path, name string) *SecretRedactor {
+	return &SecretRedactor{
+		filePath:    path,
+		redactName:  name,
+		secretsFile: true,
+		isDefault:   true,
+	}
+}
+
+func (r *SecretRedactor) Redact(input io.Reader, path string) io.Reader {
+	if !strings.HasSuffix(path, ".json") {
+		return input
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		var err error
+		defer func() {
+			writer.CloseWithError(err)
+		}()
+
+		var doc []byte
+		doc, err = io.ReadAll(input)
+		if err != nil {
+			return
+		}
+
+		redacted, removed := r.redactDocument(doc)
+		if len(removed) == 0 {
+			_, err = writer.Write(doc)
+			return
+		}
+
+		_, err = writer.Write(redacted)
+		for _, characters := range removed {
+			addRedaction(Redaction{
+				RedactorName:      r.redactName,
+				CharactersRemoved: characters,
+				Line:              0, // line 0 because the document is re-encoded
+				File:              r.filePath,
+				IsDefaultRedactor: r.isDefault,
+			})
+		}
+	}()
+	return reader
+}
+
+// redactDocument returns the document with the secrets in it redacted and the number of
+// characters removed by each redaction. No redactions are returned when the document is not JSON
+// or holds no secrets.
+func (r *SecretRedactor) redactDocument(doc []byte) ([]byte, []int) {
+	if !r.secretsFile && !bytes.Contains(doc, []byte(`"Secret"`)) && !bytes.Contains(doc, []byte(`"secretExists"`)) {
+		return doc, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil {
+		return doc, nil
+	}
+
+	removed := []int{}
+	r.redactSecrets(parsed, &removed)
+	if len(removed) == 0 {
+		return doc, nil
+	}
+
+	redacted, err := json.MarshalIndent(parsed, "", "  ")
+	if err != nil {
+		klog.Errorf("Failed to marshal redacted secrets in %q: %v", r.filePath, err)
+		return doc, nil
+	}
+	if bytes.HasSuffix(doc, []byte("\n")) {
+		redacted = append(redacted, '\n')
+	}
+	return redacted, removed
+}
+
+// redactSecrets redacts a Secret, the output of the secret collector, the items of a list, or the
+// elements of an array of objects
+func (r *SecretRedactor) redactSecrets(v interface{}, removed *[]int) {
+	switch typed := v.(type) {
+	case []interface{}:
+		for _, item := range typed {
+			r.redactSecrets(item, removed)
+		}
+	case map[string]interface{}:
+		if typed["kind"] == "Secret" {
+			r.redactSecret(typed, removed)
+			return
+		}
+		if items, ok := typed["items"].([]interface{}); ok {
+			r.redactSecrets(items, removed)
+			return
+		}
+		if _, ok := typed["secretExists"]; ok {
+			r.redactSecretOutput(typed, removed)
+		}
+	}
+}
+
+// redactSecretOutput masks the value of a key collected by the secret collector with includeValue
+func (r *SecretRedactor) redactSecretOutput(output map[string]interface{}, removed *[]int) {
+	value, ok := output["value"].(string)
+	if !ok || value == "" || value == MASK_TEXT {
+		return
+	}
+	if key, _ := output["key"].(string); !r.secretsFile && slices.Contains(r.preserveKeys, key) {
+		return
+	}
+
+	output["value"] = MASK_TEXT
+	*removed = append(*removed, len(value)-len(MASK_TEXT))
+}
+
+func (r *SecretRedactor) redactSecret(secret map[string]interface{}, removed *[]int) {
+	secretType, _ := secret["type"].(string)
+
+	if metadata, ok := secret["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			if lastApplied, ok := annotations[lastAppliedConfigAnnotation].(string); ok {
+				annotations[lastAppliedConfigAnnotation] = MASK_TEXT
+				*removed = append(*removed, len(lastApplied)-len(MASK_TEXT))
+			}
+		}
+	}
+
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := secret[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		encoded := field == "data"
+
+		for key, value := range data {
+			s, ok := value.(string)
+			if !ok || s == MASK_TEXT || r.isPublicKey(secretType, key) {
+				continue
+			}
+
+			redacted := MASK_TEXT
+			if !r.secretsFile && secretDockerConfigKeys[secretType] == key {
+				redacted = redactDockerConfig(s, encoded)
+			}
+			data[key] = redacted
+			*removed = append(*removed, len(s)-len(redacted))
+		}
+	}
+}
+
+func (r *SecretRedactor) isPublicKey(secretType, key string) bool {
+	if r.secretsFile {
+		return false
+	}
+	if slices.Contains(r.preserveKeys, key) {
+		return true
+	}
+	for _, pattern := range secretPublicKeys[secretType] {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// redactDockerConfig masks the credentials in a .dockerconfigjson or .dockercfg value and keeps
+// the registries and usernames. The value is masked entirely when it can't be parsed.
+func redactDockerConfig(value string, encoded bool) string {
+	raw := []byte(value)
+	if encoded {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return MASK_TEXT
+		}
+		raw = decoded
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return MASK_TEXT
+	}
+
+	// .dockerconfigjson nests the registries under "auths", .dockercfg does not
+	registries := config
+	if auths, ok := config["auths"].(map[string]interface{}); ok {
+		registries = auths
+	}
+	for _, entry := range registries {
+		credentials, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for field := range credentials {
+			if field != "username" {
+				credentials[field] = MASK_TEXT
+			}
+		}
+	}
+
+	redacted, err := json.Marshal(config)
+	if err != nil {
+		return MASK_TEXT
+	}
+	if encoded {
+		return base64.StdEncoding.EncodeToString(redacted)
+	}
+	return string(redacted)
+}
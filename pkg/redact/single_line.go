@@ -18,6 +18,8 @@ type SingleLineRedactor struct {
 	filePath   string
 	redactName string
 	isDefault  bool
+	// tokenize replaces the masked values with tokens instead of the mask text
+	tokenize bool
 }
 
 var NEW_LINE = []byte{'\n'}
@@ -92,7 +94,12 @@ func (r *SingleLineRedactor) Redact(input io.Reader, path string) io.Reader {
 				continue
 			}
 
-			clean := r.re.ReplaceAll(line, substStr)
+			var clean []byte
+			if r.tokenize {
+				clean = replaceAllTokenized(r.re, line, GetGlobalTokenizer())
+			} else {
+				clean = r.re.ReplaceAll(line, substStr)
+			}
 			// Append newline since scanner strips it
 			err = writeBytes(writer, clean, NEW_LINE)
 			if err != nil {
@@ -0,0 +1,163 @@
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+const (
+	// TokenizationEnvVar enables tokenization for all redactors that support it, including the
+	// default redactors, when set to true
+	TokenizationEnvVar = "TROUBLESHOOT_TOKENIZATION"
+
+	tokenPrefix = "***TOKEN_"
+	tokenSuffix = "***"
+	// tokenLength is the number of hex characters of a token, it is increased for a value whose
+	// token is already used by another value
+	tokenLength = 12
+)
+
+var (
+	globalTokenizer    = newTokenizer()
+	globalTokenizerMut sync.Mutex
+	tokenPrefixBytes   = []byte(tokenPrefix)
+)
+
+// Tokenizer replaces redacted values with tokens such as ***TOKEN_3f9a0c1b7d2e***. The same value
+// is replaced with the same token everywhere in a bundle, so that values can be correlated without
+// being revealed. Tokens are keyed with a random key generated for each run, they can not be
+// computed from a guessed value and differ between runs.
+type Tokenizer struct {
+	key    []byte
+	mu     sync.Mutex
+	tokens map[string]string // value to token
+	values map[string]string // token to value
+}
+
+func newTokenizer() *Tokenizer {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err) // rand.Read never fails on supported platforms
+	}
+
+	return &Tokenizer{
+		key:    key,
+		tokens: map[string]string{},
+		values: map[string]string{},
+	}
+}
+
+// Token returns the token of value. An empty value is masked as there is nothing to correlate,
+// and values that were already masked or tokenized by another redactor are returned as they are.
+func (t *Tokenizer) Token(value string) string {
+	if value == "" {
+		return MASK_TEXT
+	}
+	if value == MASK_TEXT {
+		return value
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if token, ok := t.tokens[value]; ok {
+		return token
+	}
+	if _, ok := t.values[value]; ok {
+		return value
+	}
+
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte(value))
+	sum := hex.EncodeToString(mac.Sum(nil))
+
+	token := ""
+	for n := tokenLength; n <= len(sum); n++ {
+		token = tokenPrefix + sum[:n] + tokenSuffix
+		if _, used := t.values[token]; !used {
+			break
+		}
+	}
+
+	t.tokens[value] = token
+	t.values[token] = value
+	return token
+}
+
+// Mapping returns the values replaced by tokens, keyed by token
+func (t *Tokenizer) Mapping() map[string]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	mapping := make(map[string]string, len(t.values))
+	for token, value := range t.values {
+		mapping[token] = value
+	}
+	return mapping
+}
+
+// GetGlobalTokenizer returns the tokenizer used by redactors in this process
+func GetGlobalTokenizer() *Tokenizer {
+	globalTokenizerMut.Lock()
+	defer globalTokenizerMut.Unlock()
+	return globalTokenizer
+}
+
+// ResetGlobalTokenizer starts a new run, with a new key and no tokens
+func ResetGlobalTokenizer() {
+	globalTokenizerMut.Lock()
+	defer globalTokenizerMut.Unlock()
+	globalTokenizer = newTokenizer()
+}
+
+// GetTokenMapping returns the values replaced by tokens so far, keyed by token. It must not be
+// added to a bundle, as it reveals the redacted values.
+func GetTokenMapping() map[string]string {
+	return GetGlobalTokenizer().Mapping()
+}
+
+// tokenizationEnabled returns whether TROUBLESHOOT_TOKENIZATION enables tokenization for all
+// redactors
+func tokenizationEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(TokenizationEnvVar))
+	return enabled
+}
+
+// replaceAllTokenized replaces the matches of re in line like re.ReplaceAll with the pattern of
+// getReplacementPattern, but with each mask group replaced with the token of its value
+func replaceAllTokenized(re *regexp.Regexp, line []byte, tokenizer *Tokenizer) []byte {
+	names := re.SubexpNames()
+
+	matches := re.FindAllSubmatchIndex(line, -1)
+	if len(matches) == 0 {
+		return line
+	}
+
+	clean := make([]byte, 0, len(line))
+	last := 0
+	for _, match := range matches {
+		clean = append(clean, line[last:match[0]]...)
+		for i := 1; i < len(names); i++ {
+			var group []byte
+			if match[2*i] >= 0 {
+				group = line[match[2*i]:match[2*i+1]]
+			}
+
+			switch names[i] {
+			case "mask":
+				clean = append(clean, tokenizer.Token(string(group))...)
+			case "drop":
+			default:
+				clean = append(clean, group...)
+			}
+		}
+		last = match[1]
+	}
+	return append(clean, line[last:]...)
+}
@@ -0,0 +1,159 @@
+package redact
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tokenRegex = regexp.MustCompile(`\*\*\*TOKEN_[0-9a-f]{12}\*\*\*`)
+
+func TestTokenizer(t *testing.T) {
+	tokenizer := newTokenizer()
+
+	token := tokenizer.Token("hunter2")
+	assert.Regexp(t, tokenRegex, token)
+	assert.Equal(t, token, tokenizer.Token("hunter2"))
+	assert.NotEqual(t, token, tokenizer.Token("hunter3"))
+	assert.Equal(t, MASK_TEXT, tokenizer.Token(""))
+	assert.Equal(t, MASK_TEXT, tokenizer.Token(MASK_TEXT))
+	assert.Equal(t, token, tokenizer.Token(token))
+
+	assert.Equal(t, map[string]string{
+		token:                      "hunter2",
+		tokenizer.Token("hunter3"): "hunter3",
+	}, tokenizer.Mapping())
+
+	// tokens are keyed for each run, so they can't be matched across bundles or guessed
+	assert.NotEqual(t, token, newTokenizer().Token("hunter2"))
+}
+
+func Test_replaceAllTokenized(t *testing.T) {
+	tokenizer := newTokenizer()
+	token := func(value string) string {
+		return tokenizer.Token(value)
+	}
+
+	tests := []struct {
+		name  string
+		regex string
+		line  string
+		want  string
+	}{
+		{
+			name:  "mask group",
+			regex: `(?i)(password=)(?P<mask>\S+)`,
+			line:  "user=admin password=hunter2 ok",
+			want:  "user=admin password=" + token("hunter2") + " ok",
+		},
+		{
+			name:  "several masks and matches",
+			regex: `(\w+://)(?P<mask>[^:]+)(:)(?P<mask>[^@]+)(@\S+)`,
+			line:  "a postgres://admin:hunter2@db b mysql://root:hunter2@db",
+			want:  "a postgres://" + token("admin") + ":" + token("hunter2") + "@db b mysql://" + token("root") + ":" + token("hunter2") + "@db",
+		},
+		{
+			name:  "drop and named groups",
+			regex: `(?P<key>key=)(?P<drop>"?)(?P<mask>[^"\s]+)"?`,
+			line:  `key="abc" other`,
+			want:  "key=" + token("abc") + " other",
+		},
+		{
+			name:  "no match",
+			regex: `(password=)(?P<mask>\S+)`,
+			line:  "nothing to see",
+			want:  "nothing to see",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := regexp.MustCompile(tt.regex)
+			got := replaceAllTokenized(re, []byte(tt.line), tokenizer)
+			assert.Equal(t, tt.want, string(got))
+
+			// tokenized and masked replacements only differ by the mask
+			masked := re.ReplaceAll([]byte(tt.line), []byte(getReplacementPattern(re, MASK_TEXT)))
+			assert.Equal(t, string(masked), tokenRegex.ReplaceAllString(string(got), MASK_TEXT))
+		})
+	}
+}
+
+func TestRedact_Tokenize(t *testing.T) {
+	ResetRedactionList()
+	defer ResetRedactionList()
+
+	redactors := []*troubleshootv1beta2.Redact{
+		{
+			Name:     "tokenized",
+			Tokenize: true,
+			Removals: troubleshootv1beta2.Removals{
+				Values: []string{"s3cr3t-literal", "not-in-any-file"},
+				Regex: []troubleshootv1beta2.Regex{
+					{Redactor: `(api_key=)(?P<mask>\S+)`},
+					{Selector: `"name": "SIGNING_KEY"`, Redactor: `("value": ")(?P<mask>[^"]*)(")`},
+				},
+				Entropy: &troubleshootv1beta2.EntropyRemoval{},
+			},
+		},
+		{
+			Name:     "masked",
+			Removals: troubleshootv1beta2.Removals{Regex: []troubleshootv1beta2.Regex{{Redactor: `(masked=)(?P<mask>\S+)`}}},
+		},
+	}
+
+	redactFile := func(path, input string) string {
+		reader, err := Redact(strings.NewReader(input), path, redactors)
+		require.NoError(t, err)
+		got, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		return string(got)
+	}
+
+	app := redactFile("logs/app.log", "api_key=abc123 uses s3cr3t-literal\nmasked=value\ntoken wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY\n")
+	env := redactFile("config/env.json", "{\n  \"name\": \"SIGNING_KEY\",\n  \"value\": \"abc123\"\n}\ns3cr3t-literal\n")
+
+	mapping := GetTokenMapping()
+	tokens := map[string]string{}
+	for token, value := range mapping {
+		tokens[value] = token
+	}
+	require.ElementsMatch(t, []string{"abc123", "s3cr3t-literal", "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"}, mapKeys(tokens))
+
+	// the same value gets the same token in every file and from every redactor
+	assert.Equal(t, "api_key="+tokens["abc123"]+" uses "+tokens["s3cr3t-literal"]+"\nmasked=***HIDDEN***\ntoken "+tokens["wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"]+"\n", app)
+	assert.Equal(t, "{\n  \"name\": \"SIGNING_KEY\",\n  \"value\": \""+tokens["abc123"]+"\"\n}\n"+tokens["s3cr3t-literal"]+"\n", env)
+
+	ResetRedactionList()
+	assert.Empty(t, GetTokenMapping())
+}
+
+func TestRedact_TokenizeFromEnv(t *testing.T) {
+	ResetRedactionList()
+	defer ResetRedactionList()
+	t.Setenv(TokenizationEnvVar, "true")
+
+	reader, err := Redact(strings.NewReader("[\n  {\n    \"name\": \"DB_PASSWORD\",\n    \"value\": \"hunter2\"\n  }\n]\n"), "pods/app.json", nil)
+	require.NoError(t, err)
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	mapping := GetTokenMapping()
+	require.Len(t, mapping, 1)
+	for token, value := range mapping {
+		assert.Equal(t, "hunter2", value)
+		assert.Equal(t, "[\n  {\n    \"name\": \"DB_PASSWORD\",\n    \"value\": \""+token+"\"\n  }\n]\n", string(got))
+	}
+}
+
+func mapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
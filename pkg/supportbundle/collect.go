@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/replicatedhq/troubleshoot/pkg/collect"
 	"github.com/replicatedhq/troubleshoot/pkg/constants"
 	"github.com/replicatedhq/troubleshoot/pkg/convert"
+	"github.com/replicatedhq/troubleshoot/pkg/redact"
 	"github.com/replicatedhq/troubleshoot/pkg/version"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -43,6 +45,10 @@ const (
 	defaultTimeout     = 30
 )
 
+// collectorWorkers bounds how many collectors of a stage run at once, collectors create pods and
+// query the API server, so running every collector of a large spec at once overloads the cluster
+const collectorWorkers = 5
+
 func runHostCollectors(ctx context.Context, hostCollectors []*troubleshootv1beta2.HostCollect, additionalRedactors *troubleshootv1beta2.Redactor, bundlePath string, opts SupportBundleCreateOpts) (collect.CollectorResult, error) {
 
 	var err error
@@ -53,6 +59,10 @@ func runHostCollectors(ctx context.Context, hostCollectors []*troubleshootv1beta
 		if err != nil {
 			return collectResult, err
 		}
+		if err := collect.CompressResultFiles(bundlePath, collectResult, opts.GzipFileThreshold); err != nil {
+			return collectResult, errors.Wrap(err, "failed to compress host collector results")
+		}
+		streamResult(bundlePath, collectResult, opts)
 	} else {
 		collectResult = runLocalHostCollectors(ctx, hostCollectors, bundlePath, opts)
 	}
@@ -63,7 +73,8 @@ func runHostCollectors(ctx context.Context, hostCollectors []*troubleshootv1beta
 		globalRedactors = additionalRedactors.Spec.Redactors
 	}
 
-	if opts.Redact {
+	// a streamed result is redacted as each collector completes
+	if opts.Redact && opts.streamingResult == nil {
 		_, span := otel.Tracer(constants.LIB_TRACER_NAME).Start(ctx, "Host collectors")
 		span.SetAttributes(attribute.String("type", "Redactors"))
 		err := collect.RedactResult(bundlePath, collectResult, globalRedactors)
@@ -82,39 +93,14 @@ func runCollectors(ctx context.Context, collectors []*troubleshootv1beta2.Collec
 	var allCollectors []collect.Collector
 	var foundForbidden bool
 
-	collectSpecs := make([]*troubleshootv1beta2.Collect, 0)
-	collectSpecs = append(collectSpecs, collectors...)
-	collectSpecs = collect.EnsureCollectorInList(collectSpecs, troubleshootv1beta2.Collect{ClusterInfo: &troubleshootv1beta2.ClusterInfo{}})
-	collectSpecs = collect.EnsureCollectorInList(collectSpecs, troubleshootv1beta2.Collect{ClusterResources: &troubleshootv1beta2.ClusterResources{}})
-	collectSpecs = collect.DedupCollectors(collectSpecs)
-	collectSpecs = collect.EnsureClusterResourcesFirst(collectSpecs)
-
-	opts.KubernetesRestConfig.QPS = constants.DEFAULT_CLIENT_QPS
-	opts.KubernetesRestConfig.Burst = constants.DEFAULT_CLIENT_BURST
-	opts.KubernetesRestConfig.UserAgent = fmt.Sprintf("%s/%s", constants.DEFAULT_CLIENT_USER_AGENT, version.Version())
-
-	k8sClient, err := kubernetes.NewForConfig(opts.KubernetesRestConfig)
+	collectorsByType, _, err := newCollectorsByType(ctx, collectors, bundlePath, opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to instantiate Kubernetes client")
+		return nil, err
 	}
 
-	allCollectorsMap := make(map[reflect.Type][]collect.Collector)
 	allCollectedData := make(map[string][]byte)
 
-	for _, desiredCollector := range collectSpecs {
-		if collectorInterface, ok := collect.GetCollector(desiredCollector, bundlePath, opts.Namespace, opts.KubernetesRestConfig, k8sClient, opts.SinceTime); ok {
-			if collector, ok := collectorInterface.(collect.Collector); ok {
-				err := collector.CheckRBAC(ctx, collector, desiredCollector, opts.KubernetesRestConfig, opts.Namespace)
-				if err != nil {
-					return nil, errors.Wrap(err, "failed to check RBAC for collectors")
-				}
-				collectorType := reflect.TypeOf(collector)
-				allCollectorsMap[collectorType] = append(allCollectorsMap[collectorType], collector)
-			}
-		}
-	}
-
-	for _, collectors := range allCollectorsMap {
+	for _, collectors := range collectorsByType {
 		if mergeCollector, ok := collectors[0].(collect.MergeableCollector); ok {
 			mergedCollectors, err := mergeCollector.Merge(collectors)
 			if err != nil {
@@ -139,53 +125,59 @@ func runCollectors(ctx context.Context, collectors []*troubleshootv1beta2.Collec
 		return nil, collect.ErrInsufficientPermissionsToRun
 	}
 
-	// move Copy Collectors if any to the end of the execution list
-	allCollectors = collect.EnsureCopyLast(allCollectors)
-
-	for _, collector := range allCollectors {
-		_, span := otel.Tracer(constants.LIB_TRACER_NAME).Start(ctx, collector.Title())
-		span.SetAttributes(attribute.String("type", reflect.TypeOf(collector).String()))
+	// collectors in a stage don't depend on each other and run concurrently, stages run in order
+	stages, dependencyErrs, err := collect.OrderCollectorsByDependencies(allCollectors)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to order collectors")
+	}
 
-		isExcluded, _ := collector.IsExcluded()
-		if isExcluded {
-			msg := fmt.Sprintf("excluding %q collector", collector.Title())
-			opts.CollectorProgressCallback(opts.ProgressChan, msg)
-			span.SetAttributes(attribute.Bool(constants.EXCLUDED, true))
-			span.End()
-			continue
-		}
+	resumeKeys := make(map[collect.Collector]string, len(allCollectors))
+	for i, key := range collect.ResumeKeys(allCollectors) {
+		resumeKeys[allCollectors[i]] = key
+	}
 
-		// skip collectors with RBAC errors unless its the ClusterResources collector
-		if collector.HasRBACErrors() {
-			if _, ok := collector.(*collect.CollectClusterResources); !ok {
-				msg := fmt.Sprintf("skipping collector %q with insufficient RBAC permissions", collector.Title())
-				opts.CollectorProgressCallback(opts.ProgressChan, msg)
-				span.SetStatus(codes.Error, "skipping collector, insufficient RBAC permissions")
-				span.End()
+	var mtx sync.Mutex
+	collectorSizes := []collect.CollectorSize{}
+	for _, stage := range stages {
+		var g errgroup.Group
+		g.SetLimit(collectorWorkers)
+		for _, collector := range stage {
+			if err, ok := dependencyErrs[collector]; ok {
+				opts.ProgressChan <- errors.Errorf("failed to run collector: %s: %v", collector.Title(), err)
 				continue
 			}
-		}
-		opts.CollectorProgressCallback(opts.ProgressChan, collector.Title())
-		result, err := collector.Collect(opts.ProgressChan)
-		if err != nil {
-			span.SetStatus(codes.Error, err.Error())
-			opts.ProgressChan <- errors.Errorf("failed to run collector: %s: %v", collector.Title(), err)
-		}
 
-		for k, v := range result {
-			allCollectedData[k] = v
+			g.Go(func() error {
+				result, size := runCollector(ctx, collector, resumeKeys[collector], bundlePath, opts)
+				streamResult(bundlePath, result, opts)
+
+				mtx.Lock()
+				defer mtx.Unlock()
+				for k, v := range result {
+					allCollectedData[k] = v
+				}
+				if size != nil {
+					collectorSizes = append(collectorSizes, *size)
+				}
+				return nil
+			})
 		}
-		span.End()
+		_ = g.Wait()
 	}
 
 	collectResult := allCollectedData
 
+	if err := SaveCollectorSizesFile(bundlePath, collectResult, collectorSizes); err != nil {
+		opts.ProgressChan <- errors.Wrap(err, "failed to save collector sizes")
+	}
+
 	globalRedactors := []*troubleshootv1beta2.Redact{}
 	if additionalRedactors != nil {
 		globalRedactors = additionalRedactors.Spec.Redactors
 	}
 
-	if opts.Redact {
+	// a streamed result is redacted as each collector completes
+	if opts.Redact && opts.streamingResult == nil {
 		// TODO: Should we record how long each redactor takes?
 		_, span := otel.Tracer(constants.LIB_TRACER_NAME).Start(ctx, "In-cluster collectors")
 		span.SetAttributes(attribute.String("type", "Redactors"))
@@ -202,6 +194,163 @@ func runCollectors(ctx context.Context, collectors []*troubleshootv1beta2.Collec
 	return collectResult, nil
 }
 
+// newCollectorsByType creates the collectors of the spec, with the cluster info and cluster
+// resources collectors that always run, and checks their RBAC permissions. The collectors are
+// grouped by type, in the order each type first appears, so that the mergeable ones can be merged.
+// The spec each collector was created from is returned with them.
+func newCollectorsByType(ctx context.Context, collectors []*troubleshootv1beta2.Collect, bundlePath string, opts SupportBundleCreateOpts) ([][]collect.Collector, map[collect.Collector]*troubleshootv1beta2.Collect, error) {
+	collectSpecs := make([]*troubleshootv1beta2.Collect, 0)
+	collectSpecs = append(collectSpecs, collectors...)
+	collectSpecs = collect.EnsureCollectorInList(collectSpecs, troubleshootv1beta2.Collect{ClusterInfo: &troubleshootv1beta2.ClusterInfo{}})
+	collectSpecs = collect.EnsureCollectorInList(collectSpecs, troubleshootv1beta2.Collect{ClusterResources: &troubleshootv1beta2.ClusterResources{}})
+	collectSpecs = collect.DedupCollectors(collectSpecs)
+	collectSpecs = collect.EnsureClusterResourcesFirst(collectSpecs)
+
+	opts.KubernetesRestConfig.QPS = constants.DEFAULT_CLIENT_QPS
+	opts.KubernetesRestConfig.Burst = constants.DEFAULT_CLIENT_BURST
+	opts.KubernetesRestConfig.UserAgent = fmt.Sprintf("%s/%s", constants.DEFAULT_CLIENT_USER_AGENT, version.Version())
+
+	k8sClient, err := kubernetes.NewForConfig(opts.KubernetesRestConfig)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to instantiate Kubernetes client")
+	}
+
+	collectorsByType := [][]collect.Collector{}
+	typeIndex := map[reflect.Type]int{}
+	specs := map[collect.Collector]*troubleshootv1beta2.Collect{}
+
+	for _, desiredCollector := range collectSpecs {
+		if collectorInterface, ok := collect.GetCollector(desiredCollector, bundlePath, opts.Namespace, opts.KubernetesRestConfig, k8sClient, opts.SinceTime); ok {
+			if collector, ok := collectorInterface.(collect.Collector); ok {
+				if logsCollector, ok := collector.(*collect.CollectLogs); ok {
+					logsCollector.GzipFileThreshold = opts.GzipFileThreshold
+				}
+				err := collector.CheckRBAC(ctx, collector, desiredCollector, opts.KubernetesRestConfig, opts.Namespace)
+				if err != nil {
+					return nil, nil, errors.Wrap(err, "failed to check RBAC for collectors")
+				}
+				specs[collector] = desiredCollector
+
+				collectorType := reflect.TypeOf(collector)
+				i, ok := typeIndex[collectorType]
+				if !ok {
+					i = len(collectorsByType)
+					typeIndex[collectorType] = i
+					collectorsByType = append(collectorsByType, nil)
+				}
+				collectorsByType[i] = append(collectorsByType[i], collector)
+			}
+		}
+	}
+
+	return collectorsByType, specs, nil
+}
+
+// runCollector runs a collector and returns its result and the size of the result, the size is
+// nil when the collector did not run. When resuming a collection, collectors that completed in a
+// previous run return their result from the bundle directory, and resumeKey identifies the
+// collector in the collection manifest.
+func runCollector(ctx context.Context, collector collect.Collector, resumeKey string, bundlePath string, opts SupportBundleCreateOpts) (collect.CollectorResult, *collect.CollectorSize) {
+	_, span := otel.Tracer(constants.LIB_TRACER_NAME).Start(ctx, collector.Title())
+	span.SetAttributes(attribute.String("type", reflect.TypeOf(collector).String()))
+	defer span.End()
+
+	isExcluded, _ := collector.IsExcluded()
+	if isExcluded {
+		msg := fmt.Sprintf("excluding %q collector", collector.Title())
+		opts.CollectorProgressCallback(opts.ProgressChan, msg)
+		span.SetAttributes(attribute.Bool(constants.EXCLUDED, true))
+		return nil, nil
+	}
+
+	if opts.MetadataOnly && !collect.CollectsMetadataOnly(collector) {
+		msg := fmt.Sprintf("skipping %q collector, only metadata is collected", collector.Title())
+		opts.CollectorProgressCallback(opts.ProgressChan, msg)
+		span.SetAttributes(attribute.Bool(constants.EXCLUDED, true))
+		return nil, nil
+	}
+
+	// skip collectors with RBAC errors unless its the ClusterResources collector
+	if collector.HasRBACErrors() {
+		if _, ok := collector.(*collect.CollectClusterResources); !ok {
+			msg := fmt.Sprintf("skipping collector %q with insufficient RBAC permissions", collector.Title())
+			opts.CollectorProgressCallback(opts.ProgressChan, msg)
+			span.SetStatus(codes.Error, "skipping collector, insufficient RBAC permissions")
+			return nil, nil
+		}
+	}
+
+	namespaces := collect.CollectorNamespaces(collector)
+	if opts.collectionManifest != nil {
+		if result, size, ok := opts.collectionManifest.Completed(bundlePath, resumeKey, namespaces); ok {
+			msg := fmt.Sprintf("skipping %q collector, it completed in a previous run", collector.Title())
+			opts.CollectorProgressCallback(opts.ProgressChan, msg)
+			span.SetAttributes(attribute.Bool("resumed", true))
+			return result, size
+		}
+	}
+
+	timeout, err := collect.GetCollectorTimeout(collector)
+	if err != nil {
+		opts.ProgressChan <- errors.Errorf("invalid collector timeout, running it without one: %s: %v", collector.Title(), err)
+	}
+
+	opts.CollectorProgressCallback(opts.ProgressChan, collector.Title())
+	start := time.Now()
+	result, err := collect.CollectWithTimeout(ctx, bundlePath, collector, timeout, opts.ProgressChan)
+	if opts.collectionTimer != nil {
+		opts.collectionTimer.Record(collector.Title(), collector, time.Since(start))
+	}
+	failed := err != nil
+	var timeoutErr *collect.CollectorTimeoutError
+	timedOut := errors.As(err, &timeoutErr)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		opts.ProgressChan <- errors.Errorf("failed to run collector: %s: %v", collector.Title(), err)
+	}
+
+	// the result of a collector that timed out only has the timeout in its errors file
+	if !timedOut {
+		if err := collect.TransformCollectorResult(bundlePath, collector, result); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			opts.ProgressChan <- errors.Errorf("failed to transform collector output: %s: %v", collector.Title(), err)
+		}
+	}
+
+	if opts.MetadataOnly && !timedOut {
+		if err := collect.MetadataOnlyResult(bundlePath, result); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			opts.ProgressChan <- errors.Errorf("failed to reduce collector output to metadata: %s: %v", collector.Title(), err)
+		}
+	}
+
+	if result == nil {
+		result = collect.NewResult()
+	}
+	size, err := collect.ApplySizeBudget(bundlePath, collector, result)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		opts.ProgressChan <- errors.Errorf("failed to apply size budget: %s: %v", collector.Title(), err)
+	}
+	if len(size.Truncated) > 0 {
+		opts.ProgressChan <- errors.Errorf("collector %s exceeded its size budget of %d bytes, %d files were left out of the bundle", collector.Title(), size.Budget, len(size.Truncated))
+	}
+
+	if err := collect.CompressResultFiles(bundlePath, result, opts.GzipFileThreshold); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		opts.ProgressChan <- errors.Errorf("failed to compress collector output: %s: %v", collector.Title(), err)
+	}
+
+	// collectors that failed are run again when resuming, their output may be partial
+	if opts.collectionManifest != nil && !failed {
+		if err := opts.collectionManifest.Record(bundlePath, resumeKey, namespaces, result, &size); err != nil {
+			opts.ProgressChan <- errors.Errorf("failed to record collector in collection manifest: %s: %v", collector.Title(), err)
+		}
+	}
+
+	return result, &size
+}
+
 func findFileName(basename, extension string) (string, error) {
 	n := 1
 	name := basename
@@ -228,6 +377,97 @@ func getAnalysisFile(analyzeResults []*analyze.AnalyzeResult) (io.Reader, error)
 	return bytes.NewBuffer(analysis), nil
 }
 
+// SaveRootCausesFile writes the probable root causes correlated from the analysis to
+// root-causes.json at the root of the bundle, under a rootCauses key
+func SaveRootCausesFile(bundlePath string, result collect.CollectorResult, rootCauses []analyze.RootCause) error {
+	b, err := json.MarshalIndent(struct {
+		RootCauses []analyze.RootCause `json:"rootCauses"`
+	}{rootCauses}, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal root causes")
+	}
+
+	return result.SaveResult(bundlePath, constants.ROOT_CAUSES_FILENAME, bytes.NewBuffer(b))
+}
+
+// SaveAnalysisGraphFile writes the graph of the objects involved in the analysis to
+// analysis-graph.json at the root of the bundle
+func SaveAnalysisGraphFile(bundlePath string, result collect.CollectorResult, graph *analyze.AnalysisGraph) error {
+	b, err := json.MarshalIndent(graph, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal analysis graph")
+	}
+
+	return result.SaveResult(bundlePath, constants.ANALYSIS_GRAPH_FILENAME, bytes.NewBuffer(b))
+}
+
+// SaveRedactionsFile writes the counts of the redactions performed in this process, without any of
+// the redacted values, to redactions.json at the root of the bundle. When the bundle already has
+// the file, e.g. it is being redacted again, the earlier counts are added to.
+func SaveRedactionsFile(bundlePath string, result collect.CollectorResult) error {
+	audit := redact.RedactionAudit{}
+
+	if _, ok := result[constants.REDACTIONS_FILENAME]; ok {
+		reader, err := result.GetReader(bundlePath, constants.REDACTIONS_FILENAME)
+		if err != nil {
+			return errors.Wrap(err, "failed to open existing redactions file")
+		}
+		err = json.NewDecoder(reader).Decode(&audit)
+		reader.Close()
+		if err != nil {
+			return errors.Wrap(err, "failed to decode existing redactions file")
+		}
+	}
+	audit.Add(redact.GetRedactionAudit())
+
+	b, err := json.MarshalIndent(audit, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal redactions")
+	}
+
+	return result.SaveResult(bundlePath, constants.REDACTIONS_FILENAME, bytes.NewBuffer(b))
+}
+
+// SaveCollectorSizesFile writes the number of bytes each collector added to the bundle, largest
+// first, to collector-sizes.json at the root of the bundle
+func SaveCollectorSizesFile(bundlePath string, result collect.CollectorResult, sizes []collect.CollectorSize) error {
+	sort.SliceStable(sizes, func(i, j int) bool {
+		if sizes[i].Bytes != sizes[j].Bytes {
+			return sizes[i].Bytes > sizes[j].Bytes
+		}
+		return sizes[i].Collector < sizes[j].Collector
+	})
+
+	b, err := json.MarshalIndent(sizes, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal collector sizes")
+	}
+
+	return result.SaveResult(bundlePath, constants.COLLECTOR_SIZES_FILENAME, bytes.NewBuffer(b))
+}
+
+// SaveCollectionTimingFile writes how long collection took, with the breakdown by collector, to
+// collection-timing.json at the root of the bundle
+func SaveCollectionTimingFile(bundlePath string, result collect.CollectorResult, timing collect.CollectionTiming) error {
+	b, err := json.MarshalIndent(timing, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal collection timing")
+	}
+
+	return result.SaveResult(bundlePath, constants.COLLECTION_TIMING_FILENAME, bytes.NewBuffer(b))
+}
+
+// SaveMetadataOnlyFile writes what a metadata only bundle includes and leaves out to
+// metadata-only.json at the root of the bundle, analyzers use it to skip the checks that need data
+func SaveMetadataOnlyFile(bundlePath string, result collect.CollectorResult) error {
+	b, err := json.MarshalIndent(collect.MetadataOnlyBundleContents, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal metadata only contents")
+	}
+
+	return result.SaveResult(bundlePath, constants.METADATA_ONLY_FILENAME, bytes.NewBuffer(b))
+}
+
 func runLocalHostCollectors(ctx context.Context, hostCollectors []*troubleshootv1beta2.HostCollect, bundlePath string, opts SupportBundleCreateOpts) map[string][]byte {
 	collectSpecs := make([]*troubleshootv1beta2.HostCollect, 0)
 	collectSpecs = append(collectSpecs, hostCollectors...)
@@ -242,7 +482,8 @@ func runLocalHostCollectors(ctx context.Context, hostCollectors []*troubleshootv
 		}
 	}
 
-	for _, collector := range collectors {
+	resumeKeys := collect.ResumeKeys(collectors)
+	for i, collector := range collectors {
 		// TODO: Add context to host collectors
 		_, span := otel.Tracer(constants.LIB_TRACER_NAME).Start(ctx, collector.Title())
 		span.SetAttributes(attribute.String("type", reflect.TypeOf(collector).String()))
@@ -255,13 +496,39 @@ func runLocalHostCollectors(ctx context.Context, hostCollectors []*troubleshootv
 			continue
 		}
 
+		if opts.collectionManifest != nil {
+			if result, _, ok := opts.collectionManifest.Completed(bundlePath, resumeKeys[i], nil); ok {
+				opts.ProgressChan <- fmt.Sprintf("[%s] Skipping host collector, it completed in a previous run", collector.Title())
+				span.SetAttributes(attribute.Bool("resumed", true))
+				span.End()
+				streamResult(bundlePath, result, opts)
+				for k, v := range result {
+					allCollectedData[k] = v
+				}
+				continue
+			}
+		}
+
 		opts.ProgressChan <- fmt.Sprintf("[%s] Running host collector...", collector.Title())
+		start := time.Now()
 		result, err := collector.Collect(opts.ProgressChan)
+		if opts.collectionTimer != nil {
+			opts.collectionTimer.Record(collector.Title(), collector, time.Since(start))
+		}
+		if err := collect.CompressResultFiles(bundlePath, result, opts.GzipFileThreshold); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			opts.ProgressChan <- errors.Errorf("failed to compress host collector output: %s: %v", collector.Title(), err)
+		}
 		if err != nil {
 			span.SetStatus(codes.Error, err.Error())
 			opts.ProgressChan <- errors.Errorf("failed to run host collector: %s: %v", collector.Title(), err)
+		} else if opts.collectionManifest != nil {
+			if err := opts.collectionManifest.Record(bundlePath, resumeKeys[i], nil, result, nil); err != nil {
+				opts.ProgressChan <- errors.Errorf("failed to record host collector in collection manifest: %s: %v", collector.Title(), err)
+			}
 		}
 		span.End()
+		streamResult(bundlePath, result, opts)
 		for k, v := range result {
 			allCollectedData[k] = v
 		}
@@ -270,6 +537,17 @@ func runLocalHostCollectors(ctx context.Context, hostCollectors []*troubleshootv
 	return allCollectedData
 }
 
+// streamResult writes the files of a collector into the streamed bundle archive, when there is one,
+// redacting them first when redaction is enabled
+func streamResult(bundlePath string, result collect.CollectorResult, opts SupportBundleCreateOpts) {
+	if opts.streamingResult == nil || len(result) == 0 {
+		return
+	}
+	if err := opts.streamingResult.AddResult(bundlePath, result); err != nil {
+		opts.ProgressChan <- errors.Wrap(err, "failed to write collector output to the bundle archive")
+	}
+}
+
 // getExecOutputs executes `collect -` with collector data passed to stdin and returns stdout, stderr and error
 func getExecOutputs(
 	ctx context.Context, clientConfig *rest.Config, client *kubernetes.Clientset, pod corev1.Pod, collectorData []byte,
@@ -0,0 +1,158 @@
+package supportbundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/multitype"
+	"github.com/replicatedhq/troubleshoot/pkg/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SaveRedactionsFile(t *testing.T) {
+	redact.ResetRedactionList()
+	defer redact.ResetRedactionList()
+
+	redactors := []*troubleshootv1beta2.Redact{
+		{
+			Name: "api-token",
+			Removals: troubleshootv1beta2.Removals{
+				Values: []string{"tok_8f14e45fceea167a"},
+			},
+		},
+	}
+
+	bundlePath := t.TempDir()
+	result := collect.NewResult()
+	require.NoError(t, result.SaveResult(bundlePath, "host-collectors/run-host/app.txt", bytes.NewBufferString("token tok_8f14e45fceea167a\n")))
+	require.NoError(t, result.SaveResult(bundlePath, "logs/app.log", bytes.NewBufferString("auth tok_8f14e45fceea167a\nretry tok_8f14e45fceea167a\n")))
+	require.NoError(t, result.SaveResult(bundlePath, "logs/other.log", bytes.NewBufferString("nothing to see here\n")))
+
+	require.NoError(t, collect.RedactResult(bundlePath, result, redactors))
+	require.NoError(t, SaveRedactionsFile(bundlePath, result))
+
+	readAudit := func() redact.RedactionAudit {
+		b, err := os.ReadFile(filepath.Join(bundlePath, "redactions.json"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), "tok_8f14e45fceea167a")
+
+		var audit redact.RedactionAudit
+		require.NoError(t, json.Unmarshal(b, &audit))
+		return audit
+	}
+
+	// the file matches the redactions that were performed
+	audit := readAudit()
+	assert.Equal(t, redact.GetRedactionAudit(), audit)
+	assert.Equal(t, 3, audit.TotalRedactions)
+	assert.Equal(t, 1, audit.ByFile["host-collectors/run-host/app.txt"].Redactions)
+	assert.Equal(t, 2, audit.ByFile["logs/app.log"].Redactions)
+	assert.NotContains(t, audit.ByFile, "logs/other.log")
+	assert.Equal(t, 3, audit.ByRedactor["api-token.literal.0"].Redactions)
+
+	// redacting the bundle again adds to the counts already in the file
+	require.NoError(t, SaveRedactionsFile(bundlePath, result))
+	audit = readAudit()
+	assert.Equal(t, 6, audit.TotalRedactions)
+	assert.Equal(t, 4, audit.ByFile["logs/app.log"].Redactions)
+}
+
+func Test_SaveCollectorSizesFile(t *testing.T) {
+	bundlePath := t.TempDir()
+	result := collect.NewResult()
+
+	sizes := []collect.CollectorSize{
+		{Collector: "cluster-info", Files: 1, Bytes: 120},
+		{Collector: "logs/app", Files: 3, Bytes: 4096, Budget: 4096, Truncated: []string{"app/web.log"}, TruncatedBytes: 9000},
+		{Collector: "cluster-resources", Files: 40, Bytes: 2048},
+	}
+	require.NoError(t, SaveCollectorSizesFile(bundlePath, result, sizes))
+	assert.Contains(t, result, "collector-sizes.json")
+
+	b, err := os.ReadFile(filepath.Join(bundlePath, "collector-sizes.json"))
+	require.NoError(t, err)
+
+	var saved []collect.CollectorSize
+	require.NoError(t, json.Unmarshal(b, &saved))
+
+	collectors := []string{}
+	for _, size := range saved {
+		collectors = append(collectors, size.Collector)
+	}
+	assert.Equal(t, []string{"logs/app", "cluster-resources", "cluster-info"}, collectors)
+	assert.Equal(t, []string{"app/web.log"}, saved[0].Truncated)
+}
+
+func Test_SaveCollectionTimingFile(t *testing.T) {
+	bundlePath := t.TempDir()
+	result := collect.NewResult()
+
+	opts := SupportBundleCreateOpts{
+		CollectorProgressCallback: func(chan interface{}, string) {},
+		ProgressChan:              make(chan interface{}, 10),
+		collectionTimer:           collect.NewCollectionTimer(),
+	}
+
+	collectors := []*collect.CollectData{
+		{Collector: &troubleshootv1beta2.Data{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "config.yaml"}, Name: "static", Data: "key: value"}},
+		{Collector: &troubleshootv1beta2.Data{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "motd"}, Name: "static", Data: "hello"}},
+		{Collector: &troubleshootv1beta2.Data{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "skipped", Exclude: multitype.FromBool(true)}, Name: "static", Data: "skipped"}},
+	}
+	for _, collector := range collectors {
+		collector.BundlePath = bundlePath
+		output, _ := runCollector(context.Background(), collector, "", bundlePath, opts)
+		for k, v := range output {
+			result[k] = v
+		}
+	}
+
+	require.NoError(t, SaveCollectionTimingFile(bundlePath, result, opts.collectionTimer.Report()))
+	assert.Contains(t, result, "collection-timing.json")
+
+	b, err := os.ReadFile(filepath.Join(bundlePath, "collection-timing.json"))
+	require.NoError(t, err)
+
+	var timing collect.CollectionTiming
+	require.NoError(t, json.Unmarshal(b, &timing))
+
+	// every collector that ran has an entry, excluded collectors don't
+	timed := []string{}
+	for _, collector := range timing.Collectors {
+		timed = append(timed, collector.Collector)
+		assert.GreaterOrEqual(t, timing.Seconds, collector.Seconds)
+	}
+	assert.ElementsMatch(t, []string{"data/config.yaml", "data/motd"}, timed)
+}
+
+func Test_runCollectorMetadataOnly(t *testing.T) {
+	bundlePath := t.TempDir()
+
+	opts := SupportBundleCreateOpts{
+		CollectorProgressCallback: func(chan interface{}, string) {},
+		ProgressChan:              make(chan interface{}, 10),
+		MetadataOnly:              true,
+	}
+
+	collector := &collect.CollectData{
+		Collector:  &troubleshootv1beta2.Data{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "config.yaml"}, Name: "static", Data: "password: hunter2"},
+		BundlePath: bundlePath,
+	}
+	result, size := runCollector(context.Background(), collector, "", bundlePath, opts)
+	assert.Empty(t, result)
+	assert.Nil(t, size)
+
+	require.NoError(t, SaveMetadataOnlyFile(bundlePath, collect.NewResult()))
+	b, err := os.ReadFile(filepath.Join(bundlePath, "metadata-only.json"))
+	require.NoError(t, err)
+
+	var contents collect.MetadataOnlyContents
+	require.NoError(t, json.Unmarshal(b, &contents))
+	assert.Equal(t, collect.MetadataOnlyBundleContents, contents)
+}
@@ -0,0 +1,167 @@
+package supportbundle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	analyzer "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+)
+
+// bundleMerger copies the files of support bundles into a single bundle directory
+type bundleMerger struct {
+	bundleDir string
+	result    collect.CollectorResult
+	// checksums of the files copied to the bundle directory, by relative path
+	checksums map[string][32]byte
+	// analysis results of all bundles, in order and without duplicates
+	analysis     []json.RawMessage
+	seenAnalysis map[string]bool
+}
+
+// Merge combines the support bundle archives into a single archive at outPath, so that bundles
+// collected separately, e.g. on each node, can be analyzed together. Files are kept at the same
+// path when they are only in one bundle or are identical in all bundles. A file that differs from
+// the file at the same path in an earlier bundle is saved under merged/<index>/, where index is the
+// position of its bundle in bundlePaths. The analysis results of all bundles are combined into a
+// single analysis.json.
+func Merge(bundlePaths []string, outPath string) error {
+	if len(bundlePaths) == 0 {
+		return errors.New("no support bundles to merge")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "troubleshoot-merge")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temp dir")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	merger := &bundleMerger{
+		bundleDir:    filepath.Join(tmpDir, strings.TrimSuffix(filepath.Base(outPath), ".tar.gz")),
+		result:       collect.NewResult(),
+		checksums:    map[string][32]byte{},
+		seenAnalysis: map[string]bool{},
+	}
+	if err := os.MkdirAll(merger.bundleDir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create merged bundle dir")
+	}
+
+	for i, bundlePath := range bundlePaths {
+		extractDir := filepath.Join(tmpDir, "bundles", strconv.Itoa(i))
+		if err := extractBundle(bundlePath, extractDir); err != nil {
+			return errors.Wrapf(err, "failed to extract %s", bundlePath)
+		}
+		rootDir, err := analyzer.FindBundleRootDir(extractDir)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find root dir of %s", bundlePath)
+		}
+		if err := merger.add(i, rootDir); err != nil {
+			return errors.Wrapf(err, "failed to merge %s", bundlePath)
+		}
+	}
+
+	if err := merger.saveAnalysis(); err != nil {
+		return err
+	}
+
+	if err := merger.result.ArchiveBundle(merger.bundleDir, outPath); err != nil {
+		return errors.Wrap(err, "failed to archive merged bundle")
+	}
+	return nil
+}
+
+func extractBundle(bundlePath string, destDir string) error {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to open support bundle")
+	}
+	defer f.Close()
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create extract dir")
+	}
+	return analyzer.ExtractTroubleshootBundle(f, destDir)
+}
+
+// add copies the files of the bundle extracted in rootDir
+func (m *bundleMerger) add(index int, rootDir string) error {
+	return filepath.Walk(rootDir, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(rootDir, filename)
+		if err != nil {
+			return errors.Wrap(err, "failed to get relative path")
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		// symlinks are extracted with absolute targets, the merged bundle gets a copy of the target
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", relativePath)
+		}
+
+		if relativePath == constants.ANALYSIS_FILENAME {
+			return m.addAnalysis(data)
+		}
+
+		checksum := sha256.Sum256(data)
+		if existing, ok := m.checksums[relativePath]; ok {
+			if existing == checksum {
+				return nil
+			}
+			relativePath = fmt.Sprintf("%s/%d/%s", constants.MERGED_BUNDLES_DIR, index, relativePath)
+		}
+
+		m.checksums[relativePath] = checksum
+		return m.result.SaveResult(m.bundleDir, relativePath, bytes.NewReader(data))
+	})
+}
+
+// addAnalysis adds the results of an analysis.json that are not already in the merged analysis
+func (m *bundleMerger) addAnalysis(data []byte) error {
+	var results []json.RawMessage
+	if err := json.Unmarshal(data, &results); err != nil {
+		return errors.Wrap(err, "failed to parse analysis")
+	}
+
+	for _, result := range results {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, result); err != nil {
+			return errors.Wrap(err, "failed to compact analysis result")
+		}
+		if m.seenAnalysis[compacted.String()] {
+			continue
+		}
+		m.seenAnalysis[compacted.String()] = true
+		m.analysis = append(m.analysis, compacted.Bytes())
+	}
+	return nil
+}
+
+func (m *bundleMerger) saveAnalysis() error {
+	if m.analysis == nil {
+		return nil
+	}
+
+	analysis, err := json.MarshalIndent(m.analysis, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal analysis")
+	}
+	if err := m.result.SaveResult(m.bundleDir, constants.ANALYSIS_FILENAME, bytes.NewReader(analysis)); err != nil {
+		return errors.Wrap(err, "failed to write analysis")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package supportbundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	analyzer "github.com/replicatedhq/troubleshoot/pkg/analyze"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	dir := t.TempDir()
+
+	createBundle := func(name string, files map[string]string) string {
+		bundleDir := filepath.Join(dir, name)
+		result := collect.NewResult()
+		for fileName, contents := range files {
+			require.NoError(t, result.SaveResult(bundleDir, fileName, bytes.NewBufferString(contents)))
+		}
+		archivePath := filepath.Join(dir, name+".tar.gz")
+		require.NoError(t, result.ArchiveBundle(bundleDir, archivePath))
+		return archivePath
+	}
+
+	node1 := createBundle("node1", map[string]string{
+		"version.yaml":                       "apiVersion: troubleshoot.sh/v1beta2\n",
+		"host-collectors/system/memory.json": `{"total": 1024}`,
+		"host-collectors/run-host/node1.txt": "node1",
+		"analysis.json":                      `[{"name": "memory", "severity": "debug"}, {"name": "disk", "severity": "warn"}]`,
+	})
+	node2 := createBundle("node2", map[string]string{
+		"version.yaml":                       "apiVersion: troubleshoot.sh/v1beta2\n",
+		"host-collectors/system/memory.json": `{"total": 2048}`,
+		"host-collectors/run-host/node2.txt": "node2",
+		"analysis.json":                      `[{"name": "memory",  "severity": "debug"}, {"name": "cpu", "severity": "error"}]`,
+	})
+
+	outPath := filepath.Join(dir, "merged-bundle.tar.gz")
+	require.NoError(t, Merge([]string{node1, node2}, outPath))
+
+	f, err := os.Open(outPath)
+	require.NoError(t, err)
+	defer f.Close()
+	extractDir := t.TempDir()
+	require.NoError(t, analyzer.ExtractTroubleshootBundle(f, extractDir))
+
+	files := map[string]string{}
+	rootDir := filepath.Join(extractDir, "merged-bundle")
+	err = filepath.Walk(rootDir, func(filename string, info os.FileInfo, err error) error {
+		require.NoError(t, err)
+		if info.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(rootDir, filename)
+		require.NoError(t, err)
+		data, err := os.ReadFile(filename)
+		require.NoError(t, err)
+		files[filepath.ToSlash(relativePath)] = string(data)
+		return nil
+	})
+	require.NoError(t, err)
+
+	analysis := files["analysis.json"]
+	delete(files, "analysis.json")
+	assert.Equal(t, map[string]string{
+		"version.yaml":                                "apiVersion: troubleshoot.sh/v1beta2\n",
+		"host-collectors/system/memory.json":          `{"total": 1024}`,
+		"merged/1/host-collectors/system/memory.json": `{"total": 2048}`,
+		"host-collectors/run-host/node1.txt":          "node1",
+		"host-collectors/run-host/node2.txt":          "node2",
+	}, files)
+
+	var results []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(analysis), &results))
+	assert.Equal(t, []map[string]string{
+		{"name": "memory", "severity": "debug"},
+		{"name": "disk", "severity": "warn"},
+		{"name": "cpu", "severity": "error"},
+	}, results)
+}
+
+func TestMerge_NoBundles(t *testing.T) {
+	require.Error(t, Merge(nil, filepath.Join(t.TempDir(), "merged.tar.gz")))
+}
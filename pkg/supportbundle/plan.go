@@ -0,0 +1,133 @@
+package supportbundle
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+)
+
+// CollectorPlan describes what a collector of a support bundle spec would collect, a dry run
+// reports it instead of running the collector
+type CollectorPlan struct {
+	Name string `json:"name"`
+	// Host is true for host collectors, they run on the local host or, when the spec sets
+	// runHostCollectorsInPod, in a pod on each node
+	Host bool `json:"host,omitempty"`
+	// Excluded is true when the collector is excluded by the spec, or would be skipped because only
+	// metadata is collected
+	Excluded bool `json:"excluded,omitempty"`
+	// Namespaces the collector reads, once the collectors of the same type are merged.
+	// AllNamespaces is set instead when it reads every namespace, but those ExcludedNamespaces
+	// matches, and ClusterScoped when it reads cluster scoped resources.
+	Namespaces         []string `json:"namespaces,omitempty"`
+	AllNamespaces      bool     `json:"allNamespaces,omitempty"`
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+	ClusterScoped      bool     `json:"clusterScoped,omitempty"`
+	// Forbidden lists the permissions the current user lacks to run the collector
+	Forbidden []string `json:"forbidden,omitempty"`
+}
+
+// PlanCollection returns the collectors the spec would run, the host collectors followed by the
+// in-cluster collectors grouped by type, with the namespaces they would read and the permissions
+// they lack. Nothing is collected, the cluster is only queried to review the permissions of the
+// current user.
+func PlanCollection(ctx context.Context, spec *troubleshootv1beta2.SupportBundleSpec, opts SupportBundleCreateOpts) ([]CollectorPlan, error) {
+	plans := []CollectorPlan{}
+
+	for _, collectorSpec := range spec.HostCollectors {
+		collector, ok := collect.GetHostCollector(collectorSpec, "")
+		if !ok {
+			continue
+		}
+		excluded, _ := collector.IsExcluded()
+		plans = append(plans, CollectorPlan{
+			Name:     collector.Title(),
+			Host:     true,
+			Excluded: excluded || opts.MetadataOnly,
+		})
+	}
+
+	if spec.Collectors == nil {
+		return plans, nil
+	}
+
+	collectorsByType, specs, err := newCollectorsByType(ctx, spec.Collectors, "", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, collectors := range collectorsByType {
+		if mergeCollector, ok := collectors[0].(collect.MergeableCollector); ok {
+			merged, err := mergeCollector.Merge(collectors)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to merge collector %s", mergeCollector.Title())
+			}
+			collectors = merged
+		}
+
+		for _, collector := range collectors {
+			plan := planCollector(collector, specs[collector], opts.Namespace)
+			if opts.MetadataOnly && !collect.CollectsMetadataOnly(collector) {
+				plan.Excluded = true
+			}
+			plans = append(plans, plan)
+		}
+	}
+
+	return plans, nil
+}
+
+// planCollector returns the plan of an in-cluster collector. The namespaces are those the
+// permissions of the collector are reviewed in, except for cluster resources, which reads the
+// namespaces listed by the merged collector, the namespace of the command or every namespace but
+// those it excludes.
+func planCollector(collector collect.Collector, spec *troubleshootv1beta2.Collect, namespace string) CollectorPlan {
+	excluded, _ := collector.IsExcluded()
+	plan := CollectorPlan{
+		Name:     collector.Title(),
+		Excluded: excluded,
+	}
+
+	uniqueNamespaces := map[string]bool{}
+	if spec != nil {
+		for _, review := range spec.AccessReviewSpecs(namespace) {
+			if review.ResourceAttributes == nil {
+				continue
+			}
+			if review.ResourceAttributes.Namespace == "" {
+				plan.ClusterScoped = true
+				continue
+			}
+			uniqueNamespaces[review.ResourceAttributes.Namespace] = true
+		}
+	}
+
+	if clusterResources, ok := collector.(*collect.CollectClusterResources); ok {
+		uniqueNamespaces = map[string]bool{}
+		switch {
+		case len(clusterResources.Collector.Namespaces) > 0:
+			for _, ns := range clusterResources.Collector.Namespaces {
+				uniqueNamespaces[ns] = true
+			}
+		case clusterResources.Namespace != "":
+			uniqueNamespaces[clusterResources.Namespace] = true
+		default:
+			plan.AllNamespaces = true
+			plan.ExcludedNamespaces = clusterResources.Collector.ExcludeNamespaces
+		}
+	}
+
+	for ns := range uniqueNamespaces {
+		plan.Namespaces = append(plan.Namespaces, ns)
+	}
+	sort.Strings(plan.Namespaces)
+
+	for _, err := range collector.GetRBACErrors() {
+		plan.Forbidden = append(plan.Forbidden, err.Error())
+	}
+
+	return plan
+}
@@ -0,0 +1,70 @@
+package supportbundle
+
+import (
+	"testing"
+
+	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/collect"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_planCollector(t *testing.T) {
+	forbidden := collect.RBACError{DisplayName: "secret/db", Namespace: "db", Resource: "secrets", Verb: "get"}
+
+	tests := []struct {
+		name      string
+		collector collect.Collector
+		spec      *troubleshootv1beta2.Collect
+		namespace string
+		want      CollectorPlan
+	}{
+		{
+			name: "cluster resources of every namespace",
+			collector: &collect.CollectClusterResources{
+				Collector: &troubleshootv1beta2.ClusterResources{},
+			},
+			spec: &troubleshootv1beta2.Collect{ClusterResources: &troubleshootv1beta2.ClusterResources{}},
+			want: CollectorPlan{Name: "cluster-resources", AllNamespaces: true, ClusterScoped: true},
+		},
+		{
+			name: "cluster resources of every namespace but the excluded ones",
+			collector: &collect.CollectClusterResources{
+				Collector: &troubleshootv1beta2.ClusterResources{ExcludeNamespaces: []string{"kube-*"}},
+			},
+			spec: &troubleshootv1beta2.Collect{ClusterResources: &troubleshootv1beta2.ClusterResources{}},
+			want: CollectorPlan{Name: "cluster-resources", AllNamespaces: true, ExcludedNamespaces: []string{"kube-*"}, ClusterScoped: true},
+		},
+		{
+			name: "merged cluster resources namespaces",
+			collector: &collect.CollectClusterResources{
+				Collector: &troubleshootv1beta2.ClusterResources{Namespaces: []string{"web", "api"}},
+			},
+			spec: &troubleshootv1beta2.Collect{ClusterResources: &troubleshootv1beta2.ClusterResources{}},
+			want: CollectorPlan{Name: "cluster-resources", Namespaces: []string{"api", "web"}, ClusterScoped: true},
+		},
+		{
+			name: "logs in the namespace of the command",
+			collector: &collect.CollectLogs{
+				Collector: &troubleshootv1beta2.Logs{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "app"}, Namespace: "app"},
+			},
+			spec:      &troubleshootv1beta2.Collect{Logs: &troubleshootv1beta2.Logs{Namespace: "app"}},
+			namespace: "support",
+			want:      CollectorPlan{Name: "logs/app", Namespaces: []string{"support"}},
+		},
+		{
+			name: "forbidden secret",
+			collector: &collect.CollectSecret{
+				Collector:  &troubleshootv1beta2.Secret{CollectorMeta: troubleshootv1beta2.CollectorMeta{CollectorName: "db"}, Namespace: "db", Name: "db"},
+				RBACErrors: collect.RBACErrors{forbidden},
+			},
+			spec: &troubleshootv1beta2.Collect{Secret: &troubleshootv1beta2.Secret{Namespace: "db", Name: "db"}},
+			want: CollectorPlan{Name: "secret/db", Namespaces: []string{"db"}, Forbidden: []string{forbidden.Error()}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, planCollector(tt.collector, tt.spec, tt.namespace))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -40,12 +41,56 @@ type SupportBundleCreateOpts struct {
 	Redact                    bool
 	FromCLI                   bool
 	RunHostCollectorsInPod    bool
+	// BundleStore saves the archive, it defaults to a local directory store writing to the output
+	// path. With any other store the archive is saved under the base name of the output path.
+	BundleStore collect.BundleStore
+	// CollectionTiming adds collection-timing.json to the bundle, with how long each collector took
+	// to run and, for cluster resources, the time spent by resource type and namespace
+	CollectionTiming bool
+	// MetadataOnly collects a bundle without any data values: only the cluster info and cluster
+	// resources collectors run, their output is reduced to metadata, statuses and structural fields,
+	// and host collectors are skipped. See collect.MetadataOnlyBundleContents.
+	MetadataOnly bool
+	// Suppressions are the ignore-list entries applied to the analysis results
+	Suppressions []analyzer.Suppression
+	// ResumeDir is the directory the bundle directory is created in instead of a temporary directory.
+	// It is kept after collection. If it already holds a bundle directory with a collection manifest,
+	// that directory is collected in again and the collectors listed as completed are not run again,
+	// so an interrupted collection can be continued.
+	ResumeDir string
+	// Deterministic makes the bundle archive byte-stable for the same cluster state, so that
+	// bundles can be diffed: JSON files have sorted keys and lists of objects sorted by namespace
+	// and name, archive entries are sorted, have no timestamps and are stored in a directory named
+	// support-bundle whatever the archive is named, and the execution summary, which has timings,
+	// is left out
+	Deterministic bool
+	// MaxBundleSize is the budget, in bytes, of the compressed bundle archive. When the bundle is
+	// larger, the oldest lines of logs are removed and large files are left out, while events, pods
+	// and other critical files are kept. See collect.CollectorResult.ApplyBundleSizeBudget.
+	MaxBundleSize int64
+	// GzipFileThreshold is the size, in bytes, over which the .log and .txt files are gzipped, to
+	// lower the disk usage during collection. Pod logs are gzipped as they are written, other files
+	// as each collector completes. See collect.GetCompressingWriter and collect.CompressResultFiles.
+	GzipFileThreshold int64
+	// StreamArchive writes the bundle into a zip archive as each collector completes, instead of
+	// archiving the bundle directory once collection and analysis are done. Files are redacted
+	// before they are written. It can't be combined with MaxBundleSize, Deterministic or a
+	// BundleStore, which need the whole bundle. See collect.StreamingResult.
+	StreamArchive bool
+
+	collectionTimer    *collect.CollectionTimer
+	collectionManifest *collect.CollectionManifest
+	streamingResult    *collect.StreamingResult
 }
 
 type SupportBundleResponse struct {
 	AnalyzerResults []*analyzer.AnalyzeResult
-	ArchivePath     string
-	FileUploaded    bool
+	// RootCauses correlates the failures and warnings of AnalyzerResults that involve related objects
+	RootCauses []analyzer.RootCause
+	// AnalysisGraph links the objects involved in AnalyzerResults to their owners, services and events
+	AnalysisGraph *analyzer.AnalysisGraph
+	ArchivePath   string
+	FileUploaded  bool
 }
 
 // NodeList is a list of remote nodes to collect data from in a support bundle
@@ -71,6 +116,14 @@ func CollectSupportBundleFromSpec(
 		return nil, errors.New("did not receive collector progress chan")
 	}
 
+	if opts.StreamArchive && (opts.MaxBundleSize > 0 || opts.Deterministic || opts.BundleStore != nil) {
+		return nil, errors.New("a streamed bundle archive can't have a size budget, be deterministic or be saved to a bundle store")
+	}
+	archiveExtension := "tar.gz"
+	if opts.StreamArchive {
+		archiveExtension = "zip"
+	}
+
 	tmpDir, err := os.MkdirTemp("", "supportbundle")
 	if err != nil {
 		return nil, errors.Wrap(err, "create temp dir")
@@ -85,7 +138,7 @@ func CollectSupportBundleFromSpec(
 		if err != nil {
 			return nil, errors.Wrap(err, "override output file path")
 		}
-		basename = strings.TrimSuffix(overridePath, ".tar.gz")
+		basename = strings.TrimSuffix(overridePath, "."+archiveExtension)
 	} else {
 		// use default output path
 		basename = fmt.Sprintf("support-bundle-%s", time.Now().Format("2006-01-02T15_04_05"))
@@ -94,17 +147,45 @@ func CollectSupportBundleFromSpec(
 		}
 	}
 
-	filename, err := findFileName(basename, "tar.gz")
+	filename, err := findFileName(basename, archiveExtension)
 	if err != nil {
 		return nil, errors.Wrap(err, "find file name")
 	}
 	resultsResponse.ArchivePath = filename
 
-	bundlePath := filepath.Join(tmpDir, strings.TrimSuffix(filename, ".tar.gz"))
+	bundlePath := filepath.Join(tmpDir, strings.TrimSuffix(filename, "."+archiveExtension))
+	if opts.ResumeDir != "" {
+		bundlePath, err = resumeBundlePath(opts.ResumeDir, filepath.Base(bundlePath))
+		if err != nil {
+			return nil, errors.Wrap(err, "find bundle dir to resume")
+		}
+	}
 	if err := os.MkdirAll(bundlePath, 0777); err != nil {
 		return nil, errors.Wrap(err, "create bundle dir")
 	}
 
+	if opts.ResumeDir != "" {
+		opts.collectionManifest, err = collect.LoadCollectionManifest(bundlePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "load collection manifest")
+		}
+		if n := len(opts.collectionManifest.Collectors); n > 0 {
+			opts.CollectorProgressCallback(opts.ProgressChan, fmt.Sprintf("resuming collection in %s, %d collectors completed previously", bundlePath, n))
+		}
+	}
+
+	if opts.StreamArchive {
+		streamingOpts := collect.StreamingOptions{Redact: opts.Redact}
+		if additionalRedactors != nil {
+			streamingOpts.Redactors = additionalRedactors.Spec.Redactors
+		}
+		opts.streamingResult, err = collect.NewStreamingResult(filename, streamingOpts)
+		if err != nil {
+			return nil, errors.Wrap(err, "create bundle file")
+		}
+		defer opts.streamingResult.Close()
+	}
+
 	result := make(collect.CollectorResult)
 
 	ctx, root := otel.Tracer(constants.LIB_TRACER_NAME).Start(
@@ -123,7 +204,13 @@ func CollectSupportBundleFromSpec(
 	collectorsErrs := []string{}
 	var files, hostFiles collect.CollectorResult
 
-	if spec.HostCollectors != nil {
+	if opts.CollectionTiming {
+		opts.collectionTimer = collect.NewCollectionTimer()
+	}
+
+	if spec.HostCollectors != nil && opts.MetadataOnly {
+		opts.CollectorProgressCallback(opts.ProgressChan, "skipping host collectors, only metadata is collected")
+	} else if spec.HostCollectors != nil {
 		// Run host collectors
 		hostFiles, err = runHostCollectors(ctx, spec.HostCollectors, additionalRedactors, bundlePath, opts)
 		if err != nil {
@@ -155,6 +242,25 @@ func CollectSupportBundleFromSpec(
 		return nil, fmt.Errorf("failed to generate support bundle")
 	}
 
+	if opts.Redact {
+		// host and in-cluster collector results have both been redacted by now
+		if err := SaveRedactionsFile(bundlePath, result); err != nil {
+			return nil, errors.Wrap(err, "failed to write redactions")
+		}
+	}
+
+	if opts.MetadataOnly {
+		if err := SaveMetadataOnlyFile(bundlePath, result); err != nil {
+			return nil, errors.Wrap(err, "failed to write metadata only contents")
+		}
+	}
+
+	if opts.collectionTimer != nil {
+		if err := SaveCollectionTimingFile(bundlePath, result, opts.collectionTimer.Report()); err != nil {
+			return nil, errors.Wrap(err, "failed to write collection timing")
+		}
+	}
+
 	version, err := version.GetVersionFile()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get version file")
@@ -176,6 +282,7 @@ func CollectSupportBundleFromSpec(
 			return nil, errors.Wrap(err, "failed to run analysis")
 		}
 	}
+	analyzer.SuppressResults(analyzeResults, opts.Suppressions)
 	resultsResponse.AnalyzerResults = analyzeResults
 
 	analysis, err := getAnalysisFile(analyzeResults)
@@ -188,22 +295,89 @@ func CollectSupportBundleFromSpec(
 		return nil, errors.Wrap(err, "failed to write analysis")
 	}
 
+	if len(analyzeResults) > 0 {
+		rootCauses, err := analyzer.CorrelateLocal(bundlePath, analyzeResults)
+		if err != nil {
+			// Don't fail the support bundle, the analysis is complete without root causes
+			klog.Errorf("failed to correlate analysis results: %v", err)
+		} else if len(rootCauses) > 0 {
+			resultsResponse.RootCauses = rootCauses
+			if err := SaveRootCausesFile(bundlePath, result, rootCauses); err != nil {
+				return nil, errors.Wrap(err, "failed to write root causes")
+			}
+		}
+
+		graph, err := analyzer.BuildAnalysisGraphLocal(bundlePath, analyzeResults)
+		if err != nil {
+			// Don't fail the support bundle, the analysis is complete without the graph
+			klog.Errorf("failed to build analysis graph: %v", err)
+		} else if len(graph.Nodes) > 0 {
+			resultsResponse.AnalysisGraph = graph
+			if err := SaveAnalysisGraphFile(bundlePath, result, graph); err != nil {
+				return nil, errors.Wrap(err, "failed to write analysis graph")
+			}
+		}
+	}
+
 	// Complete tracing by ending the root span and collecting
 	// the summary of the traces. Store them in the support bundle.
 	root.End()
-	summary := traces.GetExporterInstance().GetSummary()
-	err = result.SaveResult(bundlePath, "execution-data/summary.txt", bytes.NewReader([]byte(summary)))
-	if err != nil {
-		// Don't fail the support bundle if we can't save the execution summary
-		klog.Errorf("failed to save execution summary file in the support bundle: %v", err)
+	if !opts.Deterministic {
+		summary := traces.GetExporterInstance().GetSummary()
+		err = result.SaveResult(bundlePath, "execution-data/summary.txt", bytes.NewReader([]byte(summary)))
+		if err != nil {
+			// Don't fail the support bundle if we can't save the execution summary
+			klog.Errorf("failed to save execution summary file in the support bundle: %v", err)
+		}
+	} else if err := result.SortJSONResults(bundlePath); err != nil {
+		return nil, errors.Wrap(err, "failed to sort json files")
 	}
 
 	// Archive Support Bundle
-	if err := result.ArchiveBundle(bundlePath, filename); err != nil {
-		return nil, errors.Wrap(err, "create bundle file")
+	store := opts.BundleStore
+	if store == nil {
+		store = &collect.LocalBundleStore{Dir: filepath.Dir(filename)}
+	}
+	archiveName := filepath.Base(filename)
+	if opts.streamingResult != nil {
+		// the collected files were written as their collectors completed, the files saved since,
+		// such as the analysis, complete the archive
+		remaining := collect.NewResult()
+		for relativePath, data := range result {
+			if !opts.streamingResult.Contains(relativePath) {
+				remaining[relativePath] = data
+			}
+		}
+		if err := opts.streamingResult.AddResult(bundlePath, remaining); err != nil {
+			return nil, errors.Wrap(err, "create bundle file")
+		}
+		if err := opts.streamingResult.Close(); err != nil {
+			return nil, errors.Wrap(err, "create bundle file")
+		}
+	} else {
+		archiveOpts := collect.ArchiveOptions{
+			Deterministic: opts.Deterministic,
+			MaxSize:       opts.MaxBundleSize,
+			ProgressChan:  opts.ProgressChan,
+		}
+		if _, err := result.ArchiveBundleToStoreWithOptions(ctx, bundlePath, store, archiveName, archiveOpts); err != nil {
+			return nil, errors.Wrap(err, "create bundle file")
+		}
+	}
+
+	archivePath := filename
+	if opts.BundleStore != nil {
+		resultsResponse.ArchivePath = archiveName
+		if len(spec.AfterCollection) > 0 {
+			// After collection actions upload a file from disk
+			archivePath, err = copyArchiveFromStore(ctx, store, archiveName, tmpDir)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read bundle from store")
+			}
+		}
 	}
 
-	fileUploaded, err := ProcessSupportBundleAfterCollection(spec, filename)
+	fileUploaded, err := ProcessSupportBundleAfterCollection(spec, archivePath)
 	if err != nil {
 		if opts.FromCLI {
 			c := color.New(color.FgHiRed)
@@ -243,6 +417,51 @@ func CollectSupportBundleFromURI(specURI string, redactorURIs []string, opts Sup
 	return CollectSupportBundleFromSpec(&supportBundle.Spec, additionalRedactors, opts)
 }
 
+// resumeBundlePath returns the bundle directory to collect in inside resumeDir: the most recent
+// directory that holds a collection manifest, or a new directory named bundleName
+func resumeBundlePath(resumeDir string, bundleName string) (string, error) {
+	entries, err := os.ReadDir(resumeDir)
+	if os.IsNotExist(err) {
+		return filepath.Join(resumeDir, bundleName), nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read resume dir")
+	}
+
+	// bundle directory names end with the collection time, so the last one is the most recent
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !entries[i].IsDir() {
+			continue
+		}
+		dir := filepath.Join(resumeDir, entries[i].Name())
+		if _, err := os.Stat(filepath.Join(dir, constants.COLLECTION_MANIFEST_FILENAME)); err == nil {
+			return dir, nil
+		}
+	}
+	return filepath.Join(resumeDir, bundleName), nil
+}
+
+// copyArchiveFromStore copies an archive saved in a bundle store to a file in dir
+func copyArchiveFromStore(ctx context.Context, store collect.BundleStore, name string, dir string) (string, error) {
+	r, err := store.Read(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create archive file")
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return "", errors.Wrap(err, "failed to copy archive")
+	}
+	return path, errors.Wrap(f.Close(), "failed to close archive file")
+}
+
 // ProcessSupportBundleAfterCollection performs the after collection actions, like Callbacks and sending the archive to a remote server.
 func ProcessSupportBundleAfterCollection(spec *troubleshootv1beta2.SupportBundleSpec, archivePath string) (bool, error) {
 	fileUploaded := false
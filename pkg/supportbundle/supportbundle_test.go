@@ -1,10 +1,15 @@
 package supportbundle
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	troubleshootv1beta2 "github.com/replicatedhq/troubleshoot/pkg/apis/troubleshoot/v1beta2"
+	"github.com/replicatedhq/troubleshoot/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -114,3 +119,50 @@ func Test_getNodeList(t *testing.T) {
 		})
 	}
 }
+
+func Test_resumeBundlePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		dirs     []string
+		manifest []string
+		expected string
+	}{
+		{
+			name:     "missing resume dir",
+			expected: "support-bundle-2024-01-02T10_00_00",
+		},
+		{
+			name:     "no bundle directory with a manifest",
+			dirs:     []string{"other"},
+			expected: "support-bundle-2024-01-02T10_00_00",
+		},
+		{
+			name:     "interrupted collection",
+			dirs:     []string{"other", "support-bundle-2024-01-01T10_00_00"},
+			manifest: []string{"support-bundle-2024-01-01T10_00_00"},
+			expected: "support-bundle-2024-01-01T10_00_00",
+		},
+		{
+			name:     "most recent interrupted collection",
+			dirs:     []string{"support-bundle-2024-01-01T09_00_00", "support-bundle-2024-01-01T10_00_00"},
+			manifest: []string{"support-bundle-2024-01-01T09_00_00", "support-bundle-2024-01-01T10_00_00"},
+			expected: "support-bundle-2024-01-01T10_00_00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resumeDir := filepath.Join(t.TempDir(), "resume")
+			for _, dir := range tt.dirs {
+				require.NoError(t, os.MkdirAll(filepath.Join(resumeDir, dir), 0755))
+			}
+			for _, dir := range tt.manifest {
+				require.NoError(t, os.WriteFile(filepath.Join(resumeDir, dir, constants.COLLECTION_MANIFEST_FILENAME), []byte("{}"), 0644))
+			}
+
+			got, err := resumeBundlePath(resumeDir, "support-bundle-2024-01-02T10_00_00")
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(resumeDir, tt.expected), got)
+		})
+	}
+}
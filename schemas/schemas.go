@@ -0,0 +1,8 @@
+// Package schemas embeds the JSON schemas of the troubleshoot kinds, generated from the CRDs in
+// config/crds with `make schemas`
+package schemas
+
+import "embed"
+
+//go:embed *.json
+var FS embed.FS